@@ -0,0 +1,353 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// activeXCommandButtonCLSID defined the class identifier of the Microsoft
+// Forms 2.0 command button control {D7053240-CE69-11CD-A777-00DD01143C57}.
+var activeXCommandButtonCLSID = []byte{0x40, 0x32, 0x05, 0xD7, 0x69, 0xCE, 0xCD, 0x11, 0xA7, 0x77, 0x00, 0xDD, 0x01, 0x14, 0x3C, 0x57}
+
+// activeXControlName defined the valid name of the ActiveX control, which
+// should be a valid VBA identifier.
+var activeXControlName = regexp.MustCompile(`^[A-Za-z][0-9A-Za-z_]{0,30}$`)
+
+// AddActiveXControl provides the method to add an ActiveX command button
+// control in a worksheet by given worksheet name and ActiveX control
+// settings. For example, add a command button at cell B2 of Sheet1, which
+// runs the click event procedure in the VBA project when clicking the button:
+//
+//	codeName := "Sheet1"
+//	if err := f.SetSheetProps("Sheet1", &excelize.SheetPropsOptions{
+//	    CodeName: &codeName,
+//	}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	file, err := os.ReadFile("vbaProject.bin")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.AddVBAProject(file); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.AddActiveXControl("Sheet1", excelize.ActiveXControl{
+//	    Cell:    "B2",
+//	    Name:    "CommandButton1",
+//	    Caption: "Run",
+//	}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.SaveAs("Book1.xlsm"); err != nil {
+//	    fmt.Println(err)
+//	}
+//
+// The optional parameter "Name" specifies the name of the control, which
+// should be unique in the worksheet, begins with a letter and only contains
+// letters, numbers and underscores, and the maximum length is 31 characters.
+// The default name is "CommandButton" followed by the smallest unused number.
+// The macro binding of the ActiveX control is specified by the event
+// procedure in the document module of the worksheet in the VBA project, for
+// example, the procedure "Private Sub CommandButton1_Click()" in the module
+// "Sheet1" will be run when clicking the control named "CommandButton1" on
+// the worksheet which code name is "Sheet1". If the workbook contains a VBA
+// project and the code name of the worksheet was set, the document module of
+// the worksheet should exist in the VBA project.
+//
+// The optional parameter "Caption" specifies the caption of the command
+// button, the default value of that is the name of the control.
+//
+// The optional parameters "Width" and "Height" specifies the size of the
+// control in pixels, the default size is 96 × 32 pixels.
+//
+// The optional parameter "Format" specifies the format of the control, the
+// "OffsetX", "OffsetY", "PrintObject" and "Positioning" settings are
+// supported.
+func (f *File) AddActiveXControl(sheet string, opts ActiveXControl) error {
+	col, row, err := CellNameToCoordinates(opts.Cell)
+	if err != nil {
+		return err
+	}
+	if opts.Format.Positioning != "" && inStrSlice(supportedPositioning, opts.Format.Positioning, true) == -1 {
+		return ErrParameterInvalid
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if opts.Name == "" {
+		for i := 1; opts.Name == "" || ws.hasControlName(opts.Name); i++ {
+			opts.Name = "CommandButton" + strconv.Itoa(i)
+		}
+	}
+	if !activeXControlName.MatchString(opts.Name) || ws.hasControlName(opts.Name) {
+		return ErrParameterInvalid
+	}
+	if ws.SheetPr != nil && ws.SheetPr.CodeName != "" {
+		if err = f.checkVBAMacro(ws.SheetPr.CodeName + "." + opts.Name + "_Click"); err != nil {
+			return err
+		}
+	}
+	if opts.Caption == "" {
+		opts.Caption = opts.Name
+	}
+	if opts.Width == 0 {
+		opts.Width = 96
+	}
+	if opts.Height == 0 {
+		opts.Height = 32
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	// Add the ActiveX control part xl/activeX/activeX%d.xml and the binary
+	// part xl/activeX/activeX%d.bin of the persisted data
+	activeXID := f.countActiveXControls() + 1
+	activeXRels := "xl/activeX/_rels/activeX" + strconv.Itoa(activeXID) + ".xml.rels"
+	binID := f.addRels(activeXRels, SourceRelationshipActiveXControlBinary, "activeX"+strconv.Itoa(activeXID)+".bin", "")
+	ocx, _ := xml.Marshal(xlsxOcx{
+		XMLNSAx:     NameSpaceActiveX,
+		XMLNSR:      SourceRelationship.Value,
+		ClassID:     "{D7053240-CE69-11CD-A777-00DD01143C57}",
+		Persistence: "persistStorage",
+		RID:         "rId" + strconv.Itoa(binID),
+	})
+	f.saveFileList("xl/activeX/activeX"+strconv.Itoa(activeXID)+".xml", ocx)
+	f.Pkg.Store("xl/activeX/activeX"+strconv.Itoa(activeXID)+".bin", newActiveXCommandButton(opts.Caption, int(opts.Width), int(opts.Height)))
+	rID := f.addRels(sheetRels, SourceRelationshipActiveXControl, "../activeX/activeX"+strconv.Itoa(activeXID)+".xml", "")
+	// Add the picture of the control in the legacy VML drawing
+	vmlID := f.countVMLDrawing() + 1
+	drawingVML := "xl/drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
+	sheetRelationshipsDrawingVML := "../drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
+	if ws.LegacyDrawing != nil {
+		// The worksheet already has a VML relationships, use the relationships drawing ../drawings/vmlDrawing%d.vml.
+		sheetRelationshipsDrawingVML = f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
+		vmlID, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
+		drawingVML = strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
+	} else {
+		// Add first VML drawing for given sheet.
+		f.addSheetLegacyDrawing(sheet, f.addRels(sheetRels, SourceRelationshipDrawingVML, sheetRelationshipsDrawingVML, ""))
+	}
+	vml, err := f.vmlDrawingReader(vmlID, drawingVML, &xlsxShapeType{
+		ID:        "_x0000_t201",
+		CoordSize: "21600,21600",
+		Spt:       201,
+		Path:      "m,l,21600r21600,l21600,xe",
+		Stroke:    &xlsxStroke{JoinStyle: "miter"},
+		VPath:     &vPath{GradientShapeOK: "t", ConnectType: "rect"},
+	})
+	if err != nil {
+		return err
+	}
+	spID := nextVMLShapeID(vml, vmlID)
+	drawingVMLRels := "xl/drawings/_rels/vmlDrawing" + strconv.Itoa(vmlID) + ".vml.rels"
+	mediaStr := ".." + strings.TrimPrefix(f.addMedia(newActiveXButtonImage(opts.Caption, int(opts.Width), int(opts.Height)), ".emf"), "xl")
+	imageID := f.addRels(drawingVMLRels, SourceRelationshipImage, mediaStr, "")
+	anchor, style := f.formCtrlAnchor(col, row, &vmlOptions{sheet: sheet, FormControl: FormControl{
+		Width: opts.Width, Height: opts.Height, Format: opts.Format,
+	}})
+	sp := encodeShape{
+		ImageData: &vImageData{RelID: "rId" + strconv.Itoa(imageID)},
+		ClientData: &xClientData{
+			ObjectType:    "Pict",
+			SizeWithCells: stringPtr(""),
+			Anchor:        anchor,
+			CF:            "Pict",
+			AutoPict:      stringPtr(""),
+		},
+	}
+	if opts.Format.PrintObject != nil && !*opts.Format.PrintObject {
+		sp.ClientData.PrintObject = "False"
+	}
+	if opts.Format.Positioning != "" {
+		idx := inStrSlice(supportedPositioning, opts.Format.Positioning, true)
+		sp.ClientData.MoveWithCells = []*string{stringPtr(""), nil, nil}[idx]
+		sp.ClientData.SizeWithCells = []*string{stringPtr(""), stringPtr(""), nil}[idx]
+	}
+	s, _ := xml.Marshal(sp)
+	vml.Shape = append(vml.Shape, xlsxShape{
+		ID:          opts.Name,
+		SpID:        "_x0000_s" + strconv.Itoa(spID),
+		Type:        "#_x0000_t201",
+		Style:       strings.TrimSuffix(style, ";mso-wrap-style:tight"),
+		Filled:      "f",
+		FillColor:   "window [65]",
+		Stroked:     "f",
+		StrokeColor: "windowText [64]",
+		InsetMode:   "auto",
+		Val:         string(s[13 : len(s)-14]),
+	})
+	f.VMLDrawing[drawingVML] = vml
+	ws.addControl(fmt.Sprintf(`<control shapeId="%d" r:id="rId%d" name="%s"/>`, spID, rID, opts.Name))
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	if err = f.setContentTypePartImageExtensions(); err != nil {
+		return err
+	}
+	if err = f.setContentTypePartVMLExtensions(); err != nil {
+		return err
+	}
+	if err = f.addContentTypePart(activeXID, "activeX"); err != nil {
+		return err
+	}
+	return f.addContentTypePart(activeXID, "activeXBinary")
+}
+
+// countActiveXControls provides a function to get ActiveX controls count
+// storage in the folder xl/activeX.
+func (f *File) countActiveXControls() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/activeX/activeX") && strings.HasSuffix(k.(string), ".xml") {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// hasControlName returns whether the worksheet contains the control with the
+// given name, the controls in the alternate content will be checked.
+func (ws *xlsxWorksheet) hasControlName(name string) bool {
+	attr := fmt.Sprintf(`name="%s"`, name)
+	if ws.Controls != nil && strings.Contains(ws.Controls.Content, attr) {
+		return true
+	}
+	for _, content := range ws.DecodeAlternateContent {
+		if strings.Contains(content.Content, "</controls>") && strings.Contains(content.Content, attr) {
+			return true
+		}
+	}
+	for _, content := range ws.AlternateContent {
+		if strings.Contains(content.Content, "</controls>") && strings.Contains(content.Content, attr) {
+			return true
+		}
+	}
+	return false
+}
+
+// addControl provides a function to add the control element in the controls
+// of the worksheet. If the controls of the worksheet was stored in the
+// alternate content, the control will be added in all controls elements in
+// the alternate content.
+func (ws *xlsxWorksheet) addControl(control string) {
+	if ws.Controls != nil {
+		ws.Controls.Content += control
+		return
+	}
+	var ok bool
+	for _, content := range ws.DecodeAlternateContent {
+		if strings.Contains(content.Content, "</controls>") {
+			content.Content, ok = strings.ReplaceAll(content.Content, "</controls>", control+"</controls>"), true
+		}
+	}
+	for _, content := range ws.AlternateContent {
+		if strings.Contains(content.Content, "</controls>") {
+			content.Content, ok = strings.ReplaceAll(content.Content, "</controls>", control+"</controls>"), true
+		}
+	}
+	if !ok {
+		ws.Controls = &xlsxInnerXML{Content: control}
+	}
+}
+
+// newActiveXCommandButton provides a function to create the compound file of
+// the persisted data of the command button control by given caption, width
+// and height in pixels. The compound file contains the \x01CompObj stream
+// which specifies the class of the control, and the contents stream which
+// stores the properties of the command button and the text properties.
+func newActiveXCommandButton(caption string, width, height int) []byte {
+	var contents bytes.Buffer
+	write := func(values ...interface{}) {
+		for _, value := range values {
+			_ = binary.Write(&contents, binary.LittleEndian, value)
+		}
+	}
+	// The caption will be stored in the compressed format if all characters
+	// could be represented in one byte
+	var text []byte
+	captionLength := uint32(0x80000000)
+	for _, r := range caption {
+		if r > 0xFF {
+			captionLength = 0
+		}
+	}
+	for _, r := range caption {
+		if captionLength != 0 {
+			text = append(text, byte(r))
+		}
+	}
+	if captionLength == 0 {
+		for _, u := range utf16.Encode([]rune(caption)) {
+			text = append(text, byte(u), byte(u>>8))
+		}
+	}
+	captionLength |= uint32(len(text))
+	padding := make([]byte, (4-len(text)%4)%4)
+	// MinorVersion, MajorVersion, cbCommandButton, PropMask (fCaption and
+	// fSize), CaptionLength, Caption and Size in HIMETRIC
+	write(uint8(0), uint8(2), uint16(4+4+len(text)+len(padding)+8), uint32(0x28), captionLength, text, padding)
+	write(int32(width*2540/96), int32(height*2540/96))
+	// TextProps: MinorVersion, MajorVersion, cbTextProps, PropMask
+	// (fFontName and fFontHeight), FontName length, FontHeight in twips and
+	// FontName
+	write(uint8(0), uint8(2), uint16(4+4+4+8), uint32(0x05), uint32(0x80000007), uint32(220), []byte("Calibri\x00"))
+	compoundFile := &cfb{
+		paths:   []string{"Root Entry/"},
+		sectors: []sector{{name: "Root Entry", typeID: 5, clsID: activeXCommandButtonCLSID}},
+	}
+	compoundFile.put("\x01CompObj", newCompObjStream(activeXCommandButtonCLSID, "Microsoft Forms 2.0 CommandButton", "Forms.CommandButton.1"))
+	compoundFile.put("contents", contents.Bytes())
+	return compoundFile.write()
+}
+
+// newActiveXButtonImage provides a function to create the picture of the
+// command button in the EMF (Enhanced Metafile Format) by given caption,
+// width and height in pixels, which draws a raised button with the caption
+// in the center of the button.
+func newActiveXButtonImage(caption string, width, height int) []byte {
+	var w emfWriter
+	text := utf16.Encode([]rune(caption))
+	right, bottom := int16(width-1), int16(height-1)
+	// EMR_SETBKMODE, EMR_CREATEBRUSHINDIRECT, EMR_SELECTOBJECT and draw the
+	// button face with EMR_RECTANGLE
+	w.record(18, uint32(1))
+	w.record(39, uint32(1), uint32(0), uint32(0x00F0F0F0), uint32(0))
+	w.record(37, uint32(1))
+	w.record(37, uint32(0x80000007))
+	w.record(43, []int32{0, 0, int32(right), int32(bottom)})
+	// EMR_CREATEPEN, EMR_SELECTOBJECT and draw the highlight and shadow of
+	// the button with EMR_POLYLINE16
+	w.record(38, uint32(2), uint32(0), []int32{1, 0}, uint32(0x00FFFFFF))
+	w.record(37, uint32(2))
+	w.poly(87, 1, bottom-1, 1, 1, right-1, 1)
+	w.record(38, uint32(3), uint32(0), []int32{1, 0}, uint32(0x00A0A0A0))
+	w.record(37, uint32(3))
+	w.poly(87, 1, bottom-1, right-1, bottom-1, right-1, 1)
+	// Create and select the font, EMR_SETTEXTCOLOR, EMR_SETTEXTALIGN and draw
+	// the caption
+	w.font(4)
+	w.record(24, uint32(0))
+	w.record(22, uint32(6))
+	w.text(text, int32(width/2), int32(height/2-7), 7)
+	return w.bytes(width, height, 5)
+}
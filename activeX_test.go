@@ -0,0 +1,172 @@
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richardlehane/mscfb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddActiveXControl(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{CodeName: stringPtr("Sheet1")}))
+	file, err := os.ReadFile(filepath.Join("test", "vbaProject.bin"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddVBAProject(file))
+	assert.NoError(t, f.AddActiveXControl("Sheet1", ActiveXControl{Cell: "B2"}))
+	assert.NoError(t, f.AddActiveXControl("Sheet1", ActiveXControl{
+		Cell: "E2", Name: "RunButton", Caption: "运行", Width: 120, Height: 40,
+		Format: GraphicOptions{OffsetX: 10, OffsetY: 5, PrintObject: boolPtr(false), Positioning: "absolute"},
+	}))
+	assert.NoError(t, f.AddActiveXControl("Sheet1", ActiveXControl{Cell: "B6"}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, `<control shapeId="1025" r:id="rId1" name="CommandButton1"/><control shapeId="1026" r:id="rId3" name="RunButton"/><control shapeId="1027" r:id="rId4" name="CommandButton2"/>`, ws.Controls.Content)
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Len(t, vml.Shape, 3)
+	assert.Equal(t, []string{"CommandButton1", "_x0000_s1025"}, []string{vml.Shape[0].ID, vml.Shape[0].SpID})
+	assert.Equal(t, "position:absolute;margin-left:199.5pt;margin-top:17.25pt;width:90pt;height:30pt;z-index:1", vml.Shape[1].Style)
+	assert.Contains(t, vml.Shape[1].Val, "<x:PrintObject>False</x:PrintObject>")
+	assert.Contains(t, vml.Shape[1].Val, "<x:Anchor>4, 10, 1, 5, 6, 2, 3, 9</x:Anchor>")
+	// Test add comment in the worksheet which contains ActiveX controls
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	assert.Equal(t, "_x0000_s1028", vml.Shape[3].ID)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddActiveXControl.xlsm")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestAddActiveXControl.xlsm"))
+	assert.NoError(t, err)
+	ocx, ok := f.Pkg.Load("xl/activeX/activeX2.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(ocx.([]byte)), `ax:classid="{D7053240-CE69-11CD-A777-00DD01143C57}" ax:persistence="persistStorage" r:id="rId1"`)
+	rels, err := f.relsReader("xl/activeX/_rels/activeX2.xml.rels")
+	assert.NoError(t, err)
+	assert.Equal(t, xlsxRelationship{ID: "rId1", Type: SourceRelationshipActiveXControlBinary, Target: "activeX2.bin"}, rels.Relationships[0])
+	data, ok := f.Pkg.Load("xl/activeX/activeX2.bin")
+	assert.True(t, ok)
+	doc, err := mscfb.New(bytes.NewReader(data.([]byte)))
+	assert.NoError(t, err)
+	streams := map[string][]byte{}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		buf, _ := io.ReadAll(entry)
+		streams[entry.Name] = buf
+	}
+	assert.True(t, bytes.Contains(streams["CompObj"], []byte("Forms.CommandButton.1\x00")))
+	contents := streams["contents"]
+	assert.Equal(t, []byte{0, 2}, contents[:2])
+	assert.Equal(t, uint32(0x28), binary.LittleEndian.Uint32(contents[4:]))
+	// The caption should be stored in the uncompressed format
+	assert.Equal(t, uint32(4), binary.LittleEndian.Uint32(contents[8:]))
+	assert.Equal(t, []byte{0xD0, 0x8F, 0x4C, 0x88}, contents[12:16])
+	assert.Equal(t, []int32{3175, 1058}, []int32{int32(binary.LittleEndian.Uint32(contents[16:])), int32(binary.LittleEndian.Uint32(contents[20:]))})
+	assert.Equal(t, int(binary.LittleEndian.Uint16(contents[2:]))+4, 24)
+	assert.True(t, bytes.HasSuffix(contents, []byte("Calibri\x00")))
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	var partNames []string
+	for _, override := range content.Overrides {
+		if strings.HasPrefix(override.ContentType, ContentTypeActiveXBinary) {
+			partNames = append(partNames, override.PartName)
+		}
+	}
+	assert.Equal(t, []string{"/xl/activeX/activeX1.xml", "/xl/activeX/activeX1.bin", "/xl/activeX/activeX2.xml", "/xl/activeX/activeX2.bin", "/xl/activeX/activeX3.xml", "/xl/activeX/activeX3.bin"}, partNames)
+	// Test delete form control on the cell which contains ActiveX control
+	assert.NoError(t, f.DeleteFormControl("Sheet1", "B2"))
+	assert.Len(t, f.VMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape, 4)
+	// Test add ActiveX control with duplicate name
+	assert.Equal(t, ErrParameterInvalid, f.AddActiveXControl("Sheet1", ActiveXControl{Cell: "B8", Name: "RunButton"}))
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add ActiveX control with invalid options
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddActiveXControl("Sheet1", ActiveXControl{Cell: "A"}))
+	assert.Equal(t, ErrParameterInvalid, f.AddActiveXControl("Sheet1", ActiveXControl{Cell: "A1", Format: GraphicOptions{Positioning: "x"}}))
+	for _, name := range []string{"1Button", "Button-1", strings.Repeat("B", 32)} {
+		assert.Equal(t, ErrParameterInvalid, f.AddActiveXControl("Sheet1", ActiveXControl{Cell: "A1", Name: name}))
+	}
+	// Test add ActiveX control on not exists worksheet
+	assert.EqualError(t, f.AddActiveXControl("SheetN", ActiveXControl{Cell: "A1"}), "sheet SheetN does not exist")
+	// Test add ActiveX control without document module of the worksheet
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{CodeName: stringPtr("Sheet2")}))
+	assert.NoError(t, f.AddVBAProject(file))
+	assert.Equal(t, newNoExistVBAModuleError("Sheet2"), f.AddActiveXControl("Sheet1", ActiveXControl{Cell: "A1"}))
+	// Test add ActiveX control with unsupported charset VML drawing
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/vmlDrawing" Target="../drawings/vmlDrawing1.vml"/></Relationships>`))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetPr.CodeName, ws.LegacyDrawing = "", &xlsxLegacyDrawing{RID: "rId1"}
+	assert.EqualError(t, f.AddActiveXControl("Sheet1", ActiveXControl{Cell: "A1"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestActiveXControlPreservation(t *testing.T) {
+	f := NewFile()
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	// Test add ActiveX control in the worksheet which controls was stored in
+	// the alternate content
+	ws.DecodeAlternateContent = []*xlsxInnerXML{
+		{Content: `<mc:Choice Requires="x14"><oleObjects><oleObject progId="Package" shapeId="1030" r:id="rId9"/></oleObjects></mc:Choice>`},
+		{Content: `<mc:Choice Requires="x14"><controls><control shapeId="1031" r:id="rId8" name="CommandButton1"/></controls></mc:Choice><mc:Fallback><controls><control shapeId="1031" r:id="rId8" name="CommandButton1"/></controls></mc:Fallback>`},
+	}
+	assert.NoError(t, f.AddActiveXControl("Sheet1", ActiveXControl{Cell: "A1"}))
+	assert.Nil(t, ws.Controls)
+	assert.Equal(t, 2, strings.Count(ws.DecodeAlternateContent[1].Content, `name="CommandButton2"/></controls>`))
+	assert.NotContains(t, ws.DecodeAlternateContent[0].Content, "CommandButton2")
+	// Test add ActiveX control after saving the workbook
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestActiveXControlPreservation.xlsx")))
+	assert.Len(t, ws.AlternateContent, 2)
+	assert.NoError(t, f.AddActiveXControl("Sheet1", ActiveXControl{Cell: "A5"}))
+	assert.Equal(t, 2, strings.Count(ws.AlternateContent[1].Content, `name="CommandButton3"/></controls>`))
+	assert.NoError(t, f.Close())
+
+	// Test all alternate content of the worksheet should be preserved
+	f, err = OpenFile(filepath.Join("test", "TestActiveXControlPreservation.xlsx"))
+	assert.NoError(t, err)
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.DecodeAlternateContent, 2)
+	assert.Contains(t, ws.DecodeAlternateContent[0].Content, `<oleObject progId="Package" shapeId="1030" r:id="rId9"/>`)
+	assert.Contains(t, ws.DecodeAlternateContent[1].Content, `name="CommandButton2"`)
+	// Test add VBA project in the workbook which contains ActiveX controls
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	content.Defaults = append(content.Defaults, xlsxDefault{Extension: "bin", ContentType: ContentTypeActiveXBinary})
+	file, err := os.ReadFile(filepath.Join("test", "vbaProject.bin"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddVBAProject(file))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestActiveXControlPreservation.xlsm")))
+	assert.Contains(t, content.Overrides, xlsxOverride{PartName: "/xl/vbaProject.bin", ContentType: ContentTypeVBA})
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestActiveXControlPreservation.xlsm")))
+	var count int
+	for _, override := range content.Overrides {
+		if override.PartName == "/xl/vbaProject.bin" {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+	assert.NoError(t, f.Close())
+}
+
+func TestNewActiveXButtonImage(t *testing.T) {
+	for _, caption := range []string{"a", "CommandButton1", strings.Repeat("运行", 10)} {
+		img := newActiveXButtonImage(caption, 96, 32)
+		assert.Equal(t, uint32(1), binary.LittleEndian.Uint32(img))
+		assert.Equal(t, uint32(0x464D4520), binary.LittleEndian.Uint32(img[40:]))
+		assert.Equal(t, len(img), int(binary.LittleEndian.Uint32(img[48:])))
+		assert.Equal(t, uint32(14), binary.LittleEndian.Uint32(img[len(img)-20:]))
+		// Each record size should be a multiple of 4
+		for offset := 0; offset < len(img); {
+			size := int(binary.LittleEndian.Uint32(img[offset+4:]))
+			assert.Zero(t, size%4)
+			offset += size
+		}
+	}
+}
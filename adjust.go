@@ -15,7 +15,11 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 )
 
 type adjustDirection bool
@@ -25,16 +29,31 @@ const (
 	rows    adjustDirection = true
 )
 
+var (
+	// adjustRefRegexp matches the start or end of the cell reference or range
+	// reference, such as $A$1, A, or 1.
+	adjustRefRegexp = regexp.MustCompile(`^(\$?)([A-Za-z]{0,3})(\$?)(\d*)$`)
+	// adjustFormulaRefRegexp matches the reference with worksheet name in the
+	// formula, such as Sheet1!$A$1:$B$2 or 'Sheet 1'!A1.
+	adjustFormulaRefRegexp = regexp.MustCompile(`('(?:[^']|'')+'|[^\s!,()'"=+\-*/&^<>;{}]+)!(\$?[A-Za-z]{0,3}\$?\d*(?::\$?[A-Za-z]{0,3}\$?\d*)?)`)
+	// adjustChartFormulaRegexp matches the formula element in the chart part.
+	adjustChartFormulaRegexp = regexp.MustCompile(`(<(?:[a-zA-Z0-9]+:)?f>)([^<]*)(</(?:[a-zA-Z0-9]+:)?f>)`)
+	// moveFormulaRefRegexp matches the reference with or without worksheet
+	// name in the formula, such as A1, $A$1:$B$2, A:B, 1:2 or 'Sheet 1'!A1.
+	moveFormulaRefRegexp = regexp.MustCompile(`((?:'(?:[^']|'')+'|[^\s!,()'"=+\-*/&^<>;{}:]+)!)?(\$?[A-Za-z]{1,3}\$?\d+(?::\$?[A-Za-z]{1,3}\$?\d+)?|\$?[A-Za-z]{1,3}:\$?[A-Za-z]{1,3}|\$?\d+:\$?\d+)`)
+)
+
 // adjustHelper provides a function to adjust rows and columns dimensions,
-// hyperlinks, merged cells and auto filter when inserting or deleting rows or
-// columns.
+// hyperlinks, merged cells, auto filter, tables, data validations,
+// conditional formats, defined names, chart series and pivot table data
+// sources when inserting or deleting rows or columns.
 //
 // sheet: Worksheet name that we're editing
 // column: Index number of the column we're inserting/deleting before
 // row: Index number of the row we're inserting/deleting before
 // offset: Number of rows/column to insert/delete negative values indicate deletion
 //
-// TODO: adjustPageBreaks, adjustComments, adjustDataValidations, adjustProtectedCells
+// TODO: adjustPageBreaks, adjustComments, adjustProtectedCells
 func (f *File) adjustHelper(sheet string, dir adjustDirection, num, offset int) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -60,6 +79,15 @@ func (f *File) adjustHelper(sheet string, dir adjustDirection, num, offset int)
 	if err = f.adjustCalcChain(dir, num, offset, sheetID); err != nil {
 		return err
 	}
+	f.adjustDataValidations(ws, dir, num, offset)
+	f.adjustConditionalFormats(ws, dir, num, offset)
+	if err = f.adjustDefinedNames(sheet, dir, num, offset); err != nil {
+		return err
+	}
+	f.adjustCharts(sheet, dir, num, offset)
+	if err = f.adjustPivotCaches(sheet, dir, num, offset); err != nil {
+		return err
+	}
 	ws.checkSheet()
 	_ = ws.checkRow()
 
@@ -92,7 +120,7 @@ func (f *File) adjustCols(ws *xlsxWorksheet, col, offset int) error {
 			}
 		}
 		if offset < 0 {
-			if ws.Cols.Col[i].Min == col && ws.Cols.Col[i].Max == col {
+			if ws.Cols.Col[i].Min >= col && ws.Cols.Col[i].Max <= col-offset-1 {
 				if len(ws.Cols.Col) > 1 {
 					ws.Cols.Col = append(ws.Cols.Col[:i], ws.Cols.Col[i+1:]...)
 				} else {
@@ -101,14 +129,7 @@ func (f *File) adjustCols(ws *xlsxWorksheet, col, offset int) error {
 				i--
 				continue
 			}
-			if ws.Cols.Col[i].Min > col {
-				ws.Cols.Col[i].Min += offset
-				ws.Cols.Col[i].Max += offset
-				continue
-			}
-			if ws.Cols.Col[i].Min <= col && ws.Cols.Col[i].Max >= col {
-				ws.Cols.Col[i].Max += offset
-			}
+			ws.Cols.Col[i].Min, ws.Cols.Col[i].Max = f.adjustMergeCellsHelper(ws.Cols.Col[i].Min, ws.Cols.Col[i].Max, col, offset)
 		}
 	}
 	return nil
@@ -207,7 +228,7 @@ func (f *File) adjustHyperlinks(ws *xlsxWorksheet, sheet string, dir adjustDirec
 			linkData := ws.Hyperlinks.Hyperlink[i]
 			colNum, rowNum, _ := CellNameToCoordinates(linkData.Ref)
 
-			if (dir == rows && num == rowNum) || (dir == columns && num == colNum) {
+			if (dir == rows && inDeletedRange(rowNum, num, offset)) || (dir == columns && inDeletedRange(colNum, num, offset)) {
 				f.deleteSheetRelationships(sheet, linkData.RID)
 				if len(ws.Hyperlinks.Hyperlink) > 1 {
 					ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink[:i],
@@ -260,7 +281,7 @@ func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection,
 			return
 		}
 		// Remove the table when deleting the header row of the table
-		if dir == rows && num == coordinates[0] {
+		if dir == rows && offset < 0 && inDeletedRange(coordinates[1], num, offset) {
 			ws.TableParts.TableParts = append(ws.TableParts.TableParts[:idx], ws.TableParts.TableParts[idx+1:]...)
 			ws.TableParts.Count = len(ws.TableParts.TableParts)
 			idx--
@@ -297,7 +318,8 @@ func (f *File) adjustAutoFilter(ws *xlsxWorksheet, dir adjustDirection, num, off
 	}
 	x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
 
-	if (dir == rows && y1 == num && offset < 0) || (dir == columns && x1 == num && x2 == num) {
+	if (dir == rows && offset < 0 && inDeletedRange(y1, num, offset)) ||
+		(dir == columns && offset < 0 && inDeletedRange(x1, num, offset) && inDeletedRange(x2, num, offset)) {
 		ws.AutoFilter = nil
 		for rowIdx := range ws.SheetData.Row {
 			rowData := &ws.SheetData.Row[rowIdx]
@@ -319,21 +341,20 @@ func (f *File) adjustAutoFilter(ws *xlsxWorksheet, dir adjustDirection, num, off
 // compare and calculate cell reference by the given adjust direction, operation
 // reference and offset.
 func (f *File) adjustAutoFilterHelper(dir adjustDirection, coordinates []int, num, offset int) []int {
-	if dir == rows {
-		if coordinates[1] >= num {
-			coordinates[1] += offset
+	adjust := func(p int) int {
+		if p < num {
+			return p
 		}
-		if coordinates[3] >= num {
-			coordinates[3] += offset
+		if p += offset; offset < 0 && p < num-1 {
+			return num - 1
 		}
-		return coordinates
-	}
-	if coordinates[0] >= num {
-		coordinates[0] += offset
+		return p
 	}
-	if coordinates[2] >= num {
-		coordinates[2] += offset
+	if dir == rows {
+		coordinates[1], coordinates[3] = adjust(coordinates[1]), adjust(coordinates[3])
+		return coordinates
 	}
+	coordinates[0], coordinates[2] = adjust(coordinates[0]), adjust(coordinates[2])
 	return coordinates
 }
 
@@ -356,7 +377,7 @@ func (f *File) adjustMergeCells(ws *xlsxWorksheet, dir adjustDirection, num, off
 		}
 		x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
 		if dir == rows {
-			if y1 == num && y2 == num && offset < 0 {
+			if offset < 0 && inDeletedRange(y1, num, offset) && inDeletedRange(y2, num, offset) {
 				f.deleteMergeCell(ws, i)
 				i--
 				continue
@@ -364,7 +385,7 @@ func (f *File) adjustMergeCells(ws *xlsxWorksheet, dir adjustDirection, num, off
 
 			y1, y2 = f.adjustMergeCellsHelper(y1, y2, num, offset)
 		} else {
-			if x1 == num && x2 == num && offset < 0 {
+			if offset < 0 && inDeletedRange(x1, num, offset) && inDeletedRange(x2, num, offset) {
 				f.deleteMergeCell(ws, i)
 				i--
 				continue
@@ -402,15 +423,29 @@ func (f *File) adjustMergeCellsHelper(p1, p2, num, offset int) (int, int) {
 		}
 		return p1, p2
 	}
-	if num < p1 || (num == p1 && num == p2) {
+	if inDeletedRange(p1, num, offset) && inDeletedRange(p2, num, offset) {
+		return p1 + offset, p2 + offset
+	}
+	if last := num - offset - 1; p1 > last {
 		p1 += offset
+	} else if p1 > num {
+		p1 = num
+	}
+	if last := num - offset - 1; p2 > last {
 		p2 += offset
-	} else if num <= p2 {
-		p2 += offset
+	} else if p2 >= num {
+		p2 = num - 1
 	}
 	return p1, p2
 }
 
+// inDeletedRange returns whether the row or column number in the deleted rows
+// or columns, which start from the given number and the number of the deleted
+// rows or columns is the opposite of the offset.
+func inDeletedRange(p, num, offset int) bool {
+	return offset < 0 && num <= p && p < num-offset
+}
+
 // deleteMergeCell provides a function to delete merged cell by given index.
 func (f *File) deleteMergeCell(ws *xlsxWorksheet, idx int) {
 	if idx < 0 {
@@ -425,10 +460,11 @@ func (f *File) deleteMergeCell(ws *xlsxWorksheet, idx int) {
 // adjustCalcChain provides a function to update the calculation chain when
 // inserting or deleting rows or columns.
 func (f *File) adjustCalcChain(dir adjustDirection, num, offset, sheetID int) error {
-	if f.CalcChain == nil {
-		return nil
+	calc, err := f.calcChainReader()
+	if err != nil {
+		return err
 	}
-	for index, c := range f.CalcChain.C {
+	for index, c := range calc.C {
 		if c.I != sheetID {
 			continue
 		}
@@ -438,14 +474,544 @@ func (f *File) adjustCalcChain(dir adjustDirection, num, offset, sheetID int) er
 		}
 		if dir == rows && num <= rowNum {
 			if newRow := rowNum + offset; newRow > 0 {
-				f.CalcChain.C[index].R, _ = CoordinatesToCellName(colNum, newRow)
+				calc.C[index].R, _ = CoordinatesToCellName(colNum, newRow)
 			}
 		}
 		if dir == columns && num <= colNum {
 			if newCol := colNum + offset; newCol > 0 {
-				f.CalcChain.C[index].R, _ = CoordinatesToCellName(newCol, rowNum)
+				calc.C[index].R, _ = CoordinatesToCellName(newCol, rowNum)
 			}
 		}
 	}
 	return nil
 }
+
+// adjustRef provides a function to adjust the cell reference or range
+// reference without worksheet name, such as A1, $A$1:$B$2, A:B or 1:2, by
+// given adjust direction, operation reference and offset. Returns the adjusted
+// reference and whether the referenced cells have been deleted.
+func (f *File) adjustRef(ref string, dir adjustDirection, num, offset int) (string, bool) {
+	parts := strings.Split(ref, ":")
+	if len(parts) > 2 {
+		return ref, false
+	}
+	type refPart struct {
+		colAbs, rowAbs string
+		col, row       int
+	}
+	var refParts []refPart
+	for _, part := range parts {
+		matches := adjustRefRegexp.FindStringSubmatch(part)
+		if matches == nil || (matches[2] == "" && matches[4] == "") {
+			return ref, false
+		}
+		rp := refPart{colAbs: matches[1], rowAbs: matches[3]}
+		if matches[2] != "" {
+			rp.col, _ = ColumnNameToNumber(matches[2])
+		}
+		if matches[4] != "" {
+			rp.row, _ = strconv.Atoi(matches[4])
+		}
+		refParts = append(refParts, rp)
+	}
+	from, to := &refParts[0].row, &refParts[len(refParts)-1].row
+	if dir == columns {
+		from, to = &refParts[0].col, &refParts[len(refParts)-1].col
+	}
+	if *from == 0 || *to == 0 {
+		return ref, false
+	}
+	if inDeletedRange(*from, num, offset) && inDeletedRange(*to, num, offset) {
+		return ref, true
+	}
+	if len(refParts) == 1 {
+		if (offset > 0 && num <= *from) || (offset < 0 && num-offset <= *from) {
+			*from += offset
+		}
+	} else {
+		*from, *to = f.adjustMergeCellsHelper(*from, *to, num, offset)
+	}
+	for i, rp := range refParts {
+		parts[i] = rp.colAbs
+		if rp.col > 0 {
+			name, _ := ColumnNumberToName(rp.col)
+			parts[i] += name
+		}
+		parts[i] += rp.rowAbs
+		if rp.row > 0 {
+			parts[i] += strconv.Itoa(rp.row)
+		}
+	}
+	return strings.Join(parts, ":"), false
+}
+
+// adjustSqref provides a function to adjust the space-separated list of cell
+// references or range references, returns an empty string if all referenced
+// cells have been deleted.
+func (f *File) adjustSqref(sqref string, dir adjustDirection, num, offset int) string {
+	var refs []string
+	for _, ref := range strings.Fields(sqref) {
+		if ref, deleted := f.adjustRef(ref, dir, num, offset); !deleted {
+			refs = append(refs, ref)
+		}
+	}
+	return strings.Join(refs, " ")
+}
+
+// adjustFormulaRefs provides a function to adjust the references with the
+// given worksheet name in the formula, such as Sheet1!$A$1:$B$2. The
+// reference to the deleted cells will be replaced with #REF!.
+func (f *File) adjustFormulaRefs(sheet, formula string, dir adjustDirection, num, offset int) string {
+	return adjustFormulaRefRegexp.ReplaceAllStringFunc(formula, func(match string) string {
+		idx := strings.LastIndex(match, "!")
+		name := match[:idx]
+		if strings.HasPrefix(name, "'") {
+			name = strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(name, "'"), "'"), "''", "'")
+		}
+		if !strings.EqualFold(name, sheet) {
+			return match
+		}
+		ref, deleted := f.adjustRef(match[idx+1:], dir, num, offset)
+		if deleted {
+			ref = formulaErrorREF
+		}
+		return match[:idx+1] + ref
+	})
+}
+
+// adjustDataValidations provides a function to update the data validations
+// when inserting or deleting rows or columns.
+func (f *File) adjustDataValidations(ws *xlsxWorksheet, dir adjustDirection, num, offset int) {
+	adjust := func(dataValidations []*DataValidation) []*DataValidation {
+		for i := 0; i < len(dataValidations); i++ {
+			dv := dataValidations[i]
+			if dv.Sqref = f.adjustSqref(dv.Sqref, dir, num, offset); dv.Sqref == "" {
+				dataValidations = append(dataValidations[:i], dataValidations[i+1:]...)
+				i--
+			}
+		}
+		return dataValidations
+	}
+	if extDataValidations, err := f.getExtDataValidations(ws); err == nil && len(extDataValidations) > 0 {
+		_ = f.setExtDataValidations(ws, adjust(extDataValidations))
+	}
+	if ws.DataValidations == nil {
+		return
+	}
+	ws.DataValidations.DataValidation = adjust(ws.DataValidations.DataValidation)
+	ws.DataValidations.Count = len(ws.DataValidations.DataValidation)
+	if ws.DataValidations.Count == 0 {
+		ws.DataValidations = nil
+	}
+}
+
+// adjustConditionalFormats provides a function to update the conditional
+// formats when inserting or deleting rows or columns.
+func (f *File) adjustConditionalFormats(ws *xlsxWorksheet, dir adjustDirection, num, offset int) {
+	for i := 0; i < len(ws.ConditionalFormatting); i++ {
+		cf := ws.ConditionalFormatting[i]
+		if cf.SQRef = f.adjustSqref(cf.SQRef, dir, num, offset); cf.SQRef == "" {
+			ws.ConditionalFormatting = append(ws.ConditionalFormatting[:i], ws.ConditionalFormatting[i+1:]...)
+			i--
+		}
+	}
+}
+
+// adjustDefinedNames provides a function to update the defined names which
+// refer to the worksheet when inserting or deleting rows or columns.
+func (f *File) adjustDefinedNames(sheet string, dir adjustDirection, num, offset int) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.DefinedNames == nil {
+		return err
+	}
+	for i := range wb.DefinedNames.DefinedName {
+		dn := &wb.DefinedNames.DefinedName[i]
+		dn.Data = f.adjustFormulaRefs(sheet, dn.Data, dir, num, offset)
+	}
+	return err
+}
+
+// adjustCharts provides a function to update the series references of the
+// charts which refer to the worksheet when inserting or deleting rows or
+// columns.
+func (f *File) adjustCharts(sheet string, dir adjustDirection, num, offset int) {
+	unescape := strings.NewReplacer("&apos;", "'", "&#39;", "'", "&quot;", "\"", "&#34;", "\"", "&lt;", "<", "&gt;", ">", "&amp;", "&")
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if !strings.HasPrefix(k.(string), "xl/charts/chart") {
+			return true
+		}
+		content := v.([]byte)
+		adjusted := adjustChartFormulaRegexp.ReplaceAllFunc(content, func(match []byte) []byte {
+			matches := adjustChartFormulaRegexp.FindSubmatch(match)
+			formula := unescape.Replace(string(matches[2]))
+			ref := f.adjustFormulaRefs(sheet, formula, dir, num, offset)
+			if ref == formula {
+				return match
+			}
+			var buf bytes.Buffer
+			buf.Write(matches[1])
+			_ = xml.EscapeText(&buf, []byte(ref))
+			buf.Write(matches[3])
+			return buf.Bytes()
+		})
+		if !bytes.Equal(content, adjusted) {
+			f.Pkg.Store(k, adjusted)
+		}
+		return true
+	})
+}
+
+// adjustPivotCaches provides a function to update the data source of the
+// pivot table caches which refer to the worksheet when inserting or deleting
+// rows or columns.
+func (f *File) adjustPivotCaches(sheet string, dir adjustDirection, num, offset int) error {
+	var err error
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if !strings.HasPrefix(k.(string), "xl/pivotCache/pivotCacheDefinition") {
+			return true
+		}
+		var pc *xlsxPivotCacheDefinition
+		if pc, err = f.pivotCacheReader(k.(string)); err != nil {
+			return false
+		}
+		if pc.CacheSource == nil || pc.CacheSource.WorksheetSource == nil {
+			return true
+		}
+		source := pc.CacheSource.WorksheetSource
+		if source.Ref == "" || !strings.EqualFold(source.Sheet, sheet) {
+			return true
+		}
+		ref, deleted := f.adjustRef(source.Ref, dir, num, offset)
+		if deleted || ref == source.Ref {
+			return true
+		}
+		source.Ref = ref
+		pivotCache, _ := xml.Marshal(pc)
+		f.saveFileList(k.(string), pivotCache)
+		return true
+	})
+	return err
+}
+
+// moveHelper provides a function to move a row or column to the destination
+// by given worksheet name, direction, source and destination number, the rows
+// or columns between the source and destination will be shifted by one. The
+// cell references, merged cells, hyperlinks, formulas and defined names which
+// refer to the worksheet will be updated.
+func (f *File) moveHelper(sheet string, dir adjustDirection, src, dst int) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || src == dst {
+		return err
+	}
+	if err = f.checkMoveMergeCells(ws, dir, src); err != nil {
+		return err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	for rowIdx := range ws.SheetData.Row {
+		rowData := &ws.SheetData.Row[rowIdx]
+		if dir == rows {
+			rowData.R = moveNum(rowData.R, src, dst)
+		}
+		for colIdx := range rowData.C {
+			col, row, err := CellNameToCoordinates(rowData.C[colIdx].R)
+			if err != nil {
+				return err
+			}
+			if dir == rows {
+				row = moveNum(row, src, dst)
+			} else {
+				col = moveNum(col, src, dst)
+			}
+			rowData.C[colIdx].R, _ = CoordinatesToCellName(col, row)
+		}
+		if dir == columns {
+			sort.SliceStable(rowData.C, func(i, j int) bool {
+				ci, _, _ := CellNameToCoordinates(rowData.C[i].R)
+				cj, _, _ := CellNameToCoordinates(rowData.C[j].R)
+				return ci < cj
+			})
+		}
+	}
+	if dir == rows {
+		sort.SliceStable(ws.SheetData.Row, func(i, j int) bool {
+			return ws.SheetData.Row[i].R < ws.SheetData.Row[j].R
+		})
+	} else {
+		f.moveCols(ws, src, dst)
+	}
+	ws.checkSheet()
+	_ = ws.checkRow()
+	f.moveMergeCells(ws, dir, src, dst)
+	if ws.Hyperlinks != nil {
+		for i := range ws.Hyperlinks.Hyperlink {
+			ws.Hyperlinks.Hyperlink[i].Ref = moveRef(ws.Hyperlinks.Hyperlink[i].Ref, dir, src, dst)
+		}
+	}
+	if err = f.moveFormulas(sheet, dir, src, dst); err != nil {
+		return err
+	}
+	calc, err := f.calcChainReader()
+	if err != nil {
+		return err
+	}
+	sheetID := f.getSheetID(sheet)
+	for i := range calc.C {
+		if calc.C[i].I == sheetID {
+			calc.C[i].R = moveRef(calc.C[i].R, dir, src, dst)
+		}
+	}
+	if wb.DefinedNames == nil {
+		return err
+	}
+	for i := range wb.DefinedNames.DefinedName {
+		dn := &wb.DefinedNames.DefinedName[i]
+		dn.Data = moveFormulaRefs(sheet, "", dn.Data, dir, src, dst)
+	}
+	return err
+}
+
+// moveNum returns the row or column number after moving the source row or
+// column to the destination.
+func moveNum(p, src, dst int) int {
+	switch {
+	case p == src:
+		return dst
+	case src < dst && src < p && p <= dst:
+		return p - 1
+	case dst < src && dst <= p && p < src:
+		return p + 1
+	}
+	return p
+}
+
+// moveRange returns the start and end row or column number of the range after
+// moving the source row or column to the destination. The range will be
+// shrunk when the source was the first or last one of the range.
+func moveRange(from, to, src, dst int) (int, int) {
+	if to < from {
+		from, to = to, from
+	}
+	if from < to && from == src {
+		from++
+	} else if from < to && to == src {
+		to--
+	}
+	return moveNum(from, src, dst), moveNum(to, src, dst)
+}
+
+// checkMoveMergeCells provides a function to check if the source row or
+// column is a part of the merged cells which across multiple rows or columns.
+func (f *File) checkMoveMergeCells(ws *xlsxWorksheet, dir adjustDirection, src int) error {
+	if ws.MergeCells == nil {
+		return nil
+	}
+	for _, mergeCell := range ws.MergeCells.Cells {
+		if mergeCell == nil {
+			continue
+		}
+		rect, err := rangeRefToCoordinates(mergeCell.Ref)
+		if err != nil {
+			return err
+		}
+		from, to := rect[0], rect[2]
+		if dir == rows {
+			from, to = rect[1], rect[3]
+		}
+		if from < to && from <= src && src <= to {
+			return ErrMoveMergedCells
+		}
+	}
+	return nil
+}
+
+// moveMergeCells provides a function to update merged cells after moving the
+// row or column.
+func (f *File) moveMergeCells(ws *xlsxWorksheet, dir adjustDirection, src, dst int) {
+	if ws.MergeCells == nil {
+		return
+	}
+	for _, mergeCell := range ws.MergeCells.Cells {
+		if mergeCell == nil {
+			continue
+		}
+		rect, _ := rangeRefToCoordinates(mergeCell.Ref)
+		if dir == rows {
+			rect[1], rect[3] = moveRange(rect[1], rect[3], src, dst)
+		} else {
+			rect[0], rect[2] = moveRange(rect[0], rect[2], src, dst)
+		}
+		mergeCell.rect = rect
+		mergeCell.Ref, _ = f.coordinatesToRangeRef(rect)
+	}
+}
+
+// moveCols provides a function to update the columns properties after moving
+// the column.
+func (f *File) moveCols(ws *xlsxWorksheet, src, dst int) {
+	if ws.Cols == nil {
+		return
+	}
+	min, max := src, dst
+	if max < min {
+		min, max = max, min
+	}
+	cols := flatCols(xlsxCol{Min: min, Max: max}, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+		c.Min, c.Max = fc.Min, fc.Max
+		return c
+	})
+	ws.Cols.Col = nil
+	for _, c := range cols {
+		col := c.Min
+		if c.Min, c.Max = 0, 0; c == (xlsxCol{}) {
+			continue
+		}
+		c.Min = moveNum(col, src, dst)
+		c.Max = c.Min
+		ws.Cols.Col = append(ws.Cols.Col, c)
+	}
+	if len(ws.Cols.Col) == 0 {
+		ws.Cols = nil
+		return
+	}
+	sort.Slice(ws.Cols.Col, func(i, j int) bool {
+		return ws.Cols.Col[i].Min < ws.Cols.Col[j].Min
+	})
+}
+
+// moveFormulas provides a function to update the formulas of the cells in
+// the workbook which refer to the worksheet after moving the row or column.
+func (f *File) moveFormulas(sheet string, dir adjustDirection, src, dst int) error {
+	for _, name := range f.GetSheetList() {
+		ws, err := f.workSheetReader(name)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(name).Error() {
+				continue
+			}
+			return err
+		}
+		for rowIdx := range ws.SheetData.Row {
+			for colIdx := range ws.SheetData.Row[rowIdx].C {
+				c := &ws.SheetData.Row[rowIdx].C[colIdx]
+				if c.F == nil {
+					continue
+				}
+				c.F.Content = moveFormulaRefs(sheet, name, c.F.Content, dir, src, dst)
+				if c.F.Ref != "" && strings.EqualFold(name, sheet) {
+					c.F.Ref = moveRef(c.F.Ref, dir, src, dst)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// moveRef provides a function to update the cell reference or range
+// reference without worksheet name after moving the row or column.
+func moveRef(ref string, dir adjustDirection, src, dst int) string {
+	parts := strings.Split(ref, ":")
+	if len(parts) > 2 {
+		return ref
+	}
+	var matches [][]string
+	for _, part := range parts {
+		m := adjustRefRegexp.FindStringSubmatch(part)
+		if m == nil || (m[2] == "" && m[4] == "") {
+			return ref
+		}
+		matches = append(matches, m)
+	}
+	idx := 4
+	if dir == columns {
+		idx = 2
+	}
+	nums := make([]int, len(matches))
+	for i, m := range matches {
+		if m[idx] == "" {
+			return ref
+		}
+		if dir == columns {
+			nums[i], _ = ColumnNameToNumber(m[idx])
+			continue
+		}
+		nums[i], _ = strconv.Atoi(m[idx])
+	}
+	if len(nums) == 1 {
+		nums[0] = moveNum(nums[0], src, dst)
+	} else {
+		nums[0], nums[1] = moveRange(nums[0], nums[1], src, dst)
+	}
+	for i, m := range matches {
+		if dir == columns {
+			m[idx], _ = ColumnNumberToName(nums[i])
+		} else {
+			m[idx] = strconv.Itoa(nums[i])
+		}
+		parts[i] = m[1] + m[2] + m[3] + m[4]
+	}
+	return strings.Join(parts, ":")
+}
+
+// moveFormulaRefs provides a function to update the references which refer to
+// the worksheet in the formula after moving the row or column. The references
+// without worksheet name will be updated only if the formula belongs to the
+// worksheet.
+func moveFormulaRefs(sheet, formulaSheet, formula string, dir adjustDirection, src, dst int) string {
+	var b strings.Builder
+	segments := strings.Split(formula, "\"")
+	for i, segment := range segments {
+		if i > 0 {
+			b.WriteString("\"")
+		}
+		if i%2 == 1 {
+			b.WriteString(segment)
+			continue
+		}
+		var last int
+		for _, loc := range moveFormulaRefRegexp.FindAllStringSubmatchIndex(segment, -1) {
+			if !isFormulaRefBoundary(segment, loc[0], loc[1]) {
+				continue
+			}
+			name := formulaSheet
+			if loc[2] != -1 {
+				name = strings.TrimSuffix(segment[loc[2]:loc[3]], "!")
+				if strings.HasPrefix(name, "'") {
+					name = strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(name, "'"), "'"), "''", "'")
+				}
+			}
+			if !strings.EqualFold(name, sheet) {
+				continue
+			}
+			b.WriteString(segment[last:loc[4]])
+			b.WriteString(moveRef(segment[loc[4]:loc[5]], dir, src, dst))
+			last = loc[1]
+		}
+		b.WriteString(segment[last:])
+	}
+	return b.String()
+}
+
+// isFormulaRefBoundary returns whether the matched reference in the formula
+// is a complete reference, instead of a part of a function name, defined name
+// or number.
+func isFormulaRefBoundary(formula string, start, end int) bool {
+	isNameChar := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '\\'
+	}
+	if start > 0 {
+		if r := rune(formula[start-1]); isNameChar(r) || r == '$' || r == '!' || r == ':' {
+			return false
+		}
+	}
+	if end < len(formula) {
+		if r := rune(formula[end]); isNameChar(r) || r == '(' || r == '!' || r == ':' || r == '$' {
+			return false
+		}
+	}
+	return true
+}
@@ -465,3 +465,177 @@ func TestAdjustFormula(t *testing.T) {
 	assert.Equal(t, f.adjustFormula(&xlsxF{Ref: "-"}, rows, 0, false), ErrParameterInvalid)
 	assert.Equal(t, f.adjustFormula(&xlsxF{Ref: "XFD1:XFD1"}, columns, 1, false), ErrColumnNumber)
 }
+
+func TestAdjustRef(t *testing.T) {
+	f := NewFile()
+	for _, c := range []struct {
+		ref         string
+		dir         adjustDirection
+		num, offset int
+		expected    string
+		deleted     bool
+	}{
+		{"A1", rows, 1, 1, "A2", false},
+		{"A1", rows, 2, 1, "A1", false},
+		{"$B$3", rows, 2, -1, "$B$2", false},
+		{"B3", rows, 3, -1, "B3", true},
+		{"B3", columns, 1, 2, "D3", false},
+		{"A1:B3", rows, 2, 2, "A1:B5", false},
+		{"$A$2:$B$3", rows, 1, -1, "$A$1:$B$2", false},
+		{"A2:B2", rows, 2, -1, "A2:B2", true},
+		{"A2:B2", columns, 1, -1, "A2:A2", false},
+		{"A:B", rows, 1, 1, "A:B", false},
+		{"A:B", columns, 1, 1, "B:C", false},
+		{"$2:$3", rows, 1, 1, "$3:$4", false},
+		{"B5", rows, 2, -3, "B2", false},
+		{"B4", rows, 2, -3, "B4", true},
+		{"A2:B6", rows, 3, -3, "A2:B3", false},
+		{"A4:B8", rows, 3, -3, "A3:B5", false},
+		{"C3:F3", columns, 2, -3, "B3:C3", false},
+		{"B1:D1", columns, 2, -3, "B1:D1", true},
+		{"A1:B2:C3", rows, 1, 1, "A1:B2:C3", false},
+		{"#REF!", rows, 1, 1, "#REF!", false},
+	} {
+		ref, deleted := f.adjustRef(c.ref, c.dir, c.num, c.offset)
+		assert.Equal(t, c.expected, ref, c.ref)
+		assert.Equal(t, c.deleted, deleted, c.ref)
+	}
+	assert.Equal(t, "A1 C1:D2", f.adjustSqref("A2 B1 C2:D3", rows, 1, -1))
+	assert.Equal(t, "", f.adjustSqref("A1 B1", rows, 1, -1))
+	assert.Equal(t, "SUM('Sheet 1'!$A$1,Sheet2!A1,'Sheet 1'!#REF!)", f.adjustFormulaRefs("Sheet 1", "SUM('Sheet 1'!$A$2,Sheet2!A1,'Sheet 1'!A1)", rows, 1, -1))
+}
+
+func TestAdjustDataValidations(t *testing.T) {
+	f := NewFile()
+	for _, sqref := range []string{"A1:B2", "A2", "C3 D4"} {
+		dv := NewDataValidation(true)
+		dv.Sqref = sqref
+		assert.NoError(t, dv.SetRange(1, 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+		assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	}
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 2)
+	assert.Equal(t, "A1:B1", dvs[0].Sqref)
+	assert.Equal(t, "C2 D3", dvs[1].Sqref)
+	assert.NoError(t, f.InsertCols("Sheet1", "A", 1))
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B1:C1", dvs[0].Sqref)
+	assert.Equal(t, "D2 E3", dvs[1].Sqref)
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).DataValidations)
+}
+
+func TestAdjustConditionalFormats(t *testing.T) {
+	f := NewFile()
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	for _, ref := range []string{"A1:A10", "B2", "C3:D4"} {
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", ref, []ConditionalFormatOptions{
+			{Type: "cell", Criteria: ">", Format: format, Value: "6"},
+		}))
+	}
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 2))
+	formats, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, formats, 2)
+	assert.Contains(t, formats, "A3:A11")
+	assert.Contains(t, formats, "C4:D5")
+}
+
+func TestAdjustDefinedNames(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "a", RefersTo: "Sheet1!$A$2:$B$5"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "b", RefersTo: "'Sheet 2'!$A$2"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "c", RefersTo: "Sheet1!$A$1,Sheet1!$C$3"}))
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 1))
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	refersTo := map[string]string{}
+	for _, dn := range f.GetDefinedName() {
+		refersTo[dn.Name] = dn.RefersTo
+	}
+	assert.Equal(t, map[string]string{
+		"a": "Sheet1!$A$3:$A$6",
+		"b": "'Sheet 2'!$A$2",
+		"c": "Sheet1!#REF!,Sheet1!$B$4",
+	}, refersTo)
+	// Test adjust defined names with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.adjustDefinedNames("Sheet1", rows, 1, 1), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestAdjustCharts(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddChart("Sheet1", "E4", &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+			{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+		},
+	}))
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 2))
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	for _, ref := range []string{"Sheet1!#REF!", "Sheet1!$A$1:$C$1", "Sheet1!$A$2:$C$2", "Sheet1!$A$5:$C$5"} {
+		assert.Contains(t, string(content.([]byte)), ref)
+	}
+	assert.NotContains(t, string(content.([]byte)), "Sheet1!$B$3:$D$3")
+	// Test adjust charts which not refer to the worksheet
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.InsertRows("Sheet2", 1, 1))
+	unchanged, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, content, unchanged)
+}
+
+func TestAdjustPivotCaches(t *testing.T) {
+	f := NewFile()
+	f.Pkg.Store("xl/pivotCache/pivotCacheDefinition1.xml", []byte(`<pivotCacheDefinition xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><cacheSource type="worksheet"><worksheetSource ref="A1:E31" sheet="Sheet1"/></cacheSource></pivotCacheDefinition>`))
+	f.Pkg.Store("xl/pivotCache/pivotCacheDefinition2.xml", []byte(`<pivotCacheDefinition xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><cacheSource type="worksheet"><worksheetSource ref="A1:E31" sheet="Sheet2"/></cacheSource></pivotCacheDefinition>`))
+	assert.NoError(t, f.InsertRows("Sheet1", 10, 5))
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	pc, err := f.pivotCacheReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:D36", pc.CacheSource.WorksheetSource.Ref)
+	pc, err = f.pivotCacheReader("xl/pivotCache/pivotCacheDefinition2.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:E31", pc.CacheSource.WorksheetSource.Ref)
+	// Test adjust pivot caches with unsupported charset
+	f.Pkg.Store("xl/pivotCache/pivotCacheDefinition1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.InsertRows("Sheet1", 1, 1), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestMoveFormulaRefs(t *testing.T) {
+	for _, c := range []struct {
+		formula, formulaSheet string
+		dir                   adjustDirection
+		src, dst              int
+		expected              string
+	}{
+		{"A1+B2", "Sheet1", rows, 1, 3, "A3+B1"},
+		{"A1+B2", "Sheet2", rows, 1, 3, "A1+B2"},
+		{"Sheet1!A1+Sheet2!A1", "Sheet2", rows, 1, 3, "Sheet1!A3+Sheet2!A1"},
+		{"'Sheet1'!$A$1:$A$5", "", rows, 5, 1, "'Sheet1'!$A$2:$A$5"},
+		{"SUM(3:4,C:D)", "Sheet1", rows, 4, 1, "SUM(4:4,C:D)"},
+		{"SUM(3:4,C:D)", "Sheet1", columns, 3, 1, "SUM(3:4,D:D)"},
+		{"LOG10(A2)&\"A2\"&Name_A2&A2B", "Sheet1", rows, 2, 1, "LOG10(A1)&\"A2\"&Name_A2&A2B"},
+		{"A1:B2:C3", "Sheet1", rows, 1, 2, "A1:B2:C3"},
+	} {
+		assert.Equal(t, c.expected, moveFormulaRefs("Sheet1", c.formulaSheet, c.formula, c.dir, c.src, c.dst), c.formula)
+	}
+	assert.Equal(t, "A1:B2:C3", moveRef("A1:B2:C3", rows, 1, 2))
+	assert.Equal(t, "#REF!", moveRef("#REF!", rows, 1, 2))
+	assert.Equal(t, "A:B", moveRef("A:B", rows, 1, 2))
+}
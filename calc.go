@@ -16,11 +16,13 @@ import (
 	"container/list"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/big"
 	"math/cmplx"
 	"math/rand"
 	"net/url"
+	"path"
 	"reflect"
 	"regexp"
 	"sort"
@@ -211,6 +213,19 @@ var (
 		criteriaL,
 		criteriaG,
 	}
+	// volatileFuncs defined the formula functions which result may be changed
+	// on every calculation, the cells depend on these functions will not be
+	// cached.
+	volatileFuncs = map[string]bool{
+		"CELL":        true,
+		"INDIRECT":    true,
+		"INFO":        true,
+		"NOW":         true,
+		"OFFSET":      true,
+		"RAND":        true,
+		"RANDBETWEEN": true,
+		"TODAY":       true,
+	}
 )
 
 // calcContext defines the formula execution context.
@@ -218,6 +233,8 @@ type calcContext struct {
 	mu                sync.Mutex
 	entry             string
 	maxCalcIterations uint
+	rawCellValue      bool
+	volatile          bool
 	iterations        map[string]uint
 	iterationsCache   map[string]formulaArg
 }
@@ -338,6 +355,36 @@ func (fa formulaArg) ToList() []formulaArg {
 	return nil
 }
 
+// CalcArg is the argument of a user-defined formula function registered by
+// the RegisterCalcFunc function. A cell range argument will be passed with
+// the ArgMatrix type, and the boolean value will be passed with the ArgNumber
+// type and the Boolean field set to true.
+type CalcArg struct {
+	Type    ArgType
+	Number  float64
+	String  string
+	Boolean bool
+	Error   string
+	Matrix  [][]CalcArg
+}
+
+// CalcResult is the result of a user-defined formula function registered by
+// the RegisterCalcFunc function. Set the Type field to ArgError and the Error
+// field to the formula error code (such as #N/A) for returning a formula
+// error. Set the Type field to ArgNumber, the Boolean field to true and the
+// Number field to 1 or 0 for returning the logical value TRUE or FALSE. The
+// NumFmt field is the number format code of the cell returned by the
+// CalcCellValueTyped function, and it will be ignored in the result of the
+// user-defined formula function.
+type CalcResult struct {
+	Type    ArgType
+	Number  float64
+	String  string
+	Boolean bool
+	Error   string
+	NumFmt  string
+}
+
 // formulaFuncs is the type of the formula functions.
 type formulaFuncs struct {
 	f           *File
@@ -348,7 +395,11 @@ type formulaFuncs struct {
 // CalcCellValue provides a function to get calculated cell value. This feature
 // is currently in working processing. Iterative calculation, implicit
 // intersection, explicit intersection, array formula, table formula and some
-// other formulas are not supported currently.
+// other formulas are not supported currently. Use the RegisterCalcFunc
+// function to provide the user-defined or not supported formula functions.
+// The calculated result will be cached in the workbook until the cell values,
+// formulas, worksheets or defined names have been changed by the setter
+// functions.
 //
 // Supported formula functions:
 //
@@ -657,6 +708,7 @@ type formulaFuncs struct {
 //	ODDFYIELD
 //	ODDLPRICE
 //	ODDLYIELD
+//	OFFSET
 //	OR
 //	PDURATION
 //	PEARSON
@@ -761,6 +813,7 @@ type formulaFuncs struct {
 //	TEXTAFTER
 //	TEXTBEFORE
 //	TEXTJOIN
+//	TEXTSPLIT
 //	TIME
 //	TIMEVALUE
 //	TINV
@@ -809,15 +862,12 @@ func (f *File) CalcCellValue(sheet, cell string, opts ...Options) (result string
 		styleIdx     int
 		token        formulaArg
 	)
-	if token, err = f.calcCellValue(&calcContext{
-		entry:             fmt.Sprintf("%s!%s", sheet, cell),
-		maxCalcIterations: getOptions(opts...).MaxCalcIterations,
-		iterations:        make(map[string]uint),
-		iterationsCache:   make(map[string]formulaArg),
-	}, sheet, cell); err != nil {
+	ctx := newCalcContext(sheet, cell, opts...)
+	if token, err = f.calcCellValue(ctx, sheet, cell); err != nil {
 		result = token.String
 		return
 	}
+	f.storeCalcCache(ctx, sheet, cell, token)
 	if !rawCellValue {
 		styleIdx, _ = f.GetCellStyle(sheet, cell)
 	}
@@ -834,9 +884,105 @@ func (f *File) CalcCellValue(sheet, cell string, opts ...Options) (result string
 	return
 }
 
+// CalcCellValueTyped provides a function to get calculated cell value with
+// the data type of the result, the number format code applied to the cell
+// will be returned in the NumFmt field of the result. The logical value
+// result will be returned with the ArgNumber type and the Boolean field set to
+// true, and the formula error will be returned with the ArgError type and the
+// formula error code in the Error field. For example, get the calculated
+// value of the cell Sheet1!A1 as a number:
+//
+//	result, err := f.CalcCellValueTyped("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if result.Type == excelize.ArgNumber && !result.Boolean {
+//	    fmt.Println(result.Number, result.NumFmt)
+//	}
+func (f *File) CalcCellValueTyped(sheet, cell string, opts ...Options) (result CalcResult, err error) {
+	var token formulaArg
+	ctx := newCalcContext(sheet, cell, opts...)
+	if token, err = f.calcCellValue(ctx, sheet, cell); err == nil {
+		f.storeCalcCache(ctx, sheet, cell, token)
+	}
+	if token.Type == ArgMatrix && len(token.Matrix) > 0 && len(token.Matrix[0]) > 0 {
+		token = token.Matrix[0][0]
+	}
+	switch token.Type {
+	case ArgNumber:
+		result = CalcResult{Type: ArgNumber, Number: token.Number, Boolean: token.Boolean}
+	case ArgString:
+		result = CalcResult{Type: ArgString, String: token.String}
+	case ArgError:
+		result = CalcResult{Type: ArgError, Error: token.String}
+	default:
+		result = CalcResult{Type: ArgEmpty}
+	}
+	if err != nil {
+		return
+	}
+	var styleIdx int
+	if styleIdx, err = f.GetCellStyle(sheet, cell); err != nil {
+		return
+	}
+	result.NumFmt, err = f.getCellNumFmtCode(styleIdx)
+	return
+}
+
+// clearCalcCache clear all the calculated cell values cached by the
+// CalcCellValue and CalcCellValueTyped functions and the decoded external link
+// parts, it should be called when the cell values, formulas, worksheets,
+// defined names or any other workbook settings used in calculation (such as
+// the date system) have been changed.
+func (f *File) clearCalcCache() {
+	f.calcCache.Range(func(key, value interface{}) bool {
+		f.calcCache.Delete(key)
+		return true
+	})
+	f.calcLinksMu.Lock()
+	f.calcLinks = nil
+	f.calcLinksMu.Unlock()
+}
+
+// storeCalcCache cache the calculated cell value by given formula execution
+// context, worksheet name and cell reference. The result depends on the
+// volatile functions will not be cached.
+func (f *File) storeCalcCache(ctx *calcContext, sheet, cell string, token formulaArg) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.volatile {
+		return
+	}
+	f.calcCache.Store(ctx.calcCacheKey(sheet, cell), token)
+}
+
+// calcCacheKey returns the key of the calculated cell value cache by given
+// worksheet name and cell reference, the calculation options are part of the
+// key since the result may be different with different options.
+func (ctx *calcContext) calcCacheKey(sheet, cell string) string {
+	return fmt.Sprintf("%s!%s!%t!%d", sheet, cell, ctx.rawCellValue, ctx.maxCalcIterations)
+}
+
+// newCalcContext create the formula execution context by given worksheet
+// name, cell reference and options.
+func newCalcContext(sheet, cell string, opts ...Options) *calcContext {
+	options := getOptions(opts...)
+	return &calcContext{
+		entry:             fmt.Sprintf("%s!%s", sheet, cell),
+		maxCalcIterations: options.MaxCalcIterations,
+		rawCellValue:      options.RawCellValue,
+		iterations:        make(map[string]uint),
+		iterationsCache:   make(map[string]formulaArg),
+	}
+}
+
 // calcCellValue calculate cell value by given context, worksheet name and cell
 // reference.
 func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result formulaArg, err error) {
+	if cached, ok := f.calcCache.Load(ctx.calcCacheKey(sheet, cell)); ok {
+		return cached.(formulaArg), err
+	}
 	var formula string
 	if formula, err = f.GetCellFormula(sheet, cell); err != nil {
 		return
@@ -1004,6 +1150,10 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 
 			// current token is arg
 			if token.TType == efp.TokenTypeArgument {
+				if !inArray && isEmptyArgument(tokens, i) {
+					argsStack.Peek().(*list.List).PushBack(newEmptyFormulaArg())
+					continue
+				}
 				for opftStack.Peek().(efp.Token) != opfStack.Peek().(efp.Token) {
 					// calculate trigger
 					topOpt := opftStack.Peek().(efp.Token)
@@ -1030,6 +1180,9 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 				inArray = false
 				continue
 			}
+			if isFunctionStopToken(token) && isEmptyArgument(tokens, i) {
+				argsStack.Peek().(*list.List).PushBack(newEmptyFormulaArg())
+			}
 			if errArg := f.evalInfixExpFunc(ctx, sheet, cell, token, nextToken, opfStack, opdStack, opftStack, opfdStack, argsStack); errArg.Type == ArgError {
 				return errArg, errors.New(errArg.Error)
 			}
@@ -1048,6 +1201,15 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 	return opdStack.Peek().(formulaArg), err
 }
 
+// isEmptyArgument determine if the argument before the token of given index
+// has been omitted, such as the second argument in the formula =F(1,,2).
+func isEmptyArgument(tokens []efp.Token, idx int) bool {
+	if idx < 1 || tokens[idx-1].TType != efp.TokenTypeArgument {
+		return idx > 0 && tokens[idx].TType == efp.TokenTypeArgument && isFunctionStartToken(tokens[idx-1])
+	}
+	return true
+}
+
 // evalInfixExpFunc evaluate formula function in the infix expression.
 func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nextToken efp.Token, opfStack, opdStack, opftStack, opfdStack, argsStack *Stack) formulaArg {
 	if !isFunctionStopToken(token) {
@@ -1055,9 +1217,19 @@ func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nex
 	}
 	prepareEvalInfixExp(opfStack, opftStack, opfdStack, argsStack)
 	// call formula function to evaluate
-	arg := callFuncByName(&formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx}, strings.NewReplacer(
-		"_xlfn.", "", ".", "dot").Replace(opfStack.Peek().(efp.Token).TValue),
-		[]reflect.Value{reflect.ValueOf(argsStack.Peek().(*list.List))})
+	var arg formulaArg
+	name := strings.NewReplacer("_xlfn.", "", "_xludf.", "").Replace(opfStack.Peek().(efp.Token).TValue)
+	if volatileFuncs[strings.ToUpper(name)] {
+		ctx.mu.Lock()
+		ctx.volatile = true
+		ctx.mu.Unlock()
+	}
+	if fn, ok := f.calcFuncs.Load(strings.ToUpper(name)); ok {
+		arg = callCalcFunc(fn.(func(args []CalcArg) (CalcResult, error)), argsStack.Peek().(*list.List))
+	} else {
+		arg = callFuncByName(&formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx}, strings.ReplaceAll(name, ".", "dot"),
+			[]reflect.Value{reflect.ValueOf(argsStack.Peek().(*list.List))})
+	}
 	if arg.Type == ArgError && opfStack.Len() == 1 {
 		return arg
 	}
@@ -1418,6 +1590,9 @@ func (f *File) parseToken(ctx *calcContext, sheet string, token efp.Token, opdSt
 		}
 		result, err := f.parseReference(ctx, sheet, token.TValue)
 		if err != nil {
+			if result.Type == ArgError && result.String == formulaErrorREF {
+				return errors.New(formulaErrorREF)
+			}
 			return errors.New(formulaErrorNAME)
 		}
 		token = formulaArgToToken(result)
@@ -1507,6 +1682,12 @@ func (cr *cellRange) prepareCellRange(col, row bool, cellRef cellRef) error {
 // characters and default sheet name.
 func (f *File) parseReference(ctx *calcContext, sheet, reference string) (formulaArg, error) {
 	reference = strings.ReplaceAll(reference, "$", "")
+	if strings.HasPrefix(reference, "[") {
+		return f.parseExternalReference(reference)
+	}
+	if sheets, ref, ok := split3DReference(reference); ok {
+		return f.parse3DReference(ctx, sheets, ref)
+	}
 	ranges, cellRanges, cellRefs := strings.Split(reference, ":"), list.New(), list.New()
 	if len(ranges) > 1 {
 		var cr cellRange
@@ -1546,6 +1727,285 @@ func (f *File) parseReference(ctx *calcContext, sheet, reference string) (formul
 	return f.rangeResolver(ctx, cellRefs, cellRanges)
 }
 
+// split3DReference split the 3D reference which spans a run of worksheets,
+// such as Sheet1:Sheet3!A1 or Sheet1:Sheet3!A1:B2, into the first and last
+// worksheet name and the cell reference.
+func split3DReference(reference string) ([]string, string, bool) {
+	idx := strings.LastIndex(reference, "!")
+	if idx == -1 || strings.Contains(reference[:idx], "!") {
+		return nil, "", false
+	}
+	sheets := strings.Split(reference[:idx], ":")
+	if len(sheets) != 2 {
+		return nil, "", false
+	}
+	return sheets, reference[idx+1:], true
+}
+
+// parse3DReference parse the 3D reference by given first and last worksheet
+// name and cell reference, the reference will be expanded to each worksheet
+// between the first and last worksheet in workbook order, and returns the
+// union of the values as a matrix.
+func (f *File) parse3DReference(ctx *calcContext, sheets []string, reference string) (formulaArg, error) {
+	sheetList, from, to := f.GetSheetList(), -1, -1
+	for idx, name := range sheetList {
+		if strings.EqualFold(name, sheets[0]) {
+			from = idx
+		}
+		if strings.EqualFold(name, sheets[1]) {
+			to = idx
+		}
+	}
+	if from == -1 || to == -1 {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF), errors.New(formulaErrorREF)
+	}
+	if from > to {
+		from, to = to, from
+	}
+	cellRefs, cellRanges, matrix := list.New(), list.New(), [][]formulaArg{}
+	for _, name := range sheetList[from : to+1] {
+		arg, err := f.parseReference(ctx, name, reference)
+		if err != nil {
+			return arg, err
+		}
+		cellRefs.PushBackList(arg.cellRefs)
+		cellRanges.PushBackList(arg.cellRanges)
+		if arg.Type == ArgMatrix {
+			matrix = append(matrix, arg.Matrix...)
+			continue
+		}
+		matrix = append(matrix, []formulaArg{arg})
+	}
+	arg := newMatrixFormulaArg(matrix)
+	arg.cellRefs, arg.cellRanges = cellRefs, cellRanges
+	return arg, nil
+}
+
+// RegisterExternalWorkbook provides a function to register a value provider
+// for the external workbook referenced in the formulas, such as
+// [Book2.xlsx]Sheet1!A1, by given file name of the external workbook, the
+// name is case-insensitive. The provider returns the value of the cell by
+// given worksheet name and cell reference in the external workbook, and takes
+// precedence over the cached values stored in the external link parts of the
+// workbook. If neither the provider nor the cached value is available, the
+// reference will be calculated as #REF!. For example, provide the values of
+// the external workbook Book2.xlsx:
+//
+//	err := f.RegisterExternalWorkbook("Book2.xlsx", func(sheet, cell string) (excelize.CalcArg, error) {
+//	    if sheet == "Sheet1" && cell == "A1" {
+//	        return excelize.CalcArg{Type: excelize.ArgNumber, Number: 100}, nil
+//	    }
+//	    return excelize.CalcArg{Type: excelize.ArgEmpty}, nil
+//	})
+//
+// Pass a nil function to unregister the value provider.
+func (f *File) RegisterExternalWorkbook(name string, fn func(sheet, cell string) (CalcArg, error)) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return ErrParameterRequired
+	}
+	f.clearCalcCache()
+	if fn == nil {
+		f.calcExternals.Delete(name)
+		return nil
+	}
+	f.calcExternals.Store(name, fn)
+	return nil
+}
+
+// calcExternalLink is the decoded external link part of the workbook, the
+// cached values of the cells are indexed by the lower-case worksheet name and
+// the cell reference.
+type calcExternalLink struct {
+	name  string
+	cells map[string]map[string]xlsxExternalCell
+}
+
+// getExternalLinks provides a function to decode all the external link parts
+// of the workbook once, and index them by the external workbook index (such
+// as 1 in [1]Sheet1!A1) and the lower-case file name (such as book2.xlsx in
+// [Book2.xlsx]Sheet1!A1).
+func (f *File) getExternalLinks() map[string]*calcExternalLink {
+	f.calcLinksMu.Lock()
+	defer f.calcLinksMu.Unlock()
+	if f.calcLinks != nil {
+		return f.calcLinks
+	}
+	f.calcLinks = make(map[string]*calcExternalLink)
+	wb, _ := f.workbookViewer()
+	if wb == nil || wb.ExternalReferences == nil {
+		return f.calcLinks
+	}
+	rels, _ := f.relsViewer(f.getWorkbookRelsPath())
+	if rels == nil {
+		return f.calcLinks
+	}
+	for i, ref := range wb.ExternalReferences.ExternalReference {
+		var target string
+		for _, rel := range rels.Relationships {
+			if rel.ID == ref.RID {
+				target = rel.Target
+			}
+		}
+		if target == "" {
+			continue
+		}
+		linkPath := strings.TrimPrefix(target, "/")
+		if !strings.HasPrefix(target, "/") {
+			linkPath = path.Join(path.Dir(f.getWorkbookPath()), target)
+		}
+		link := new(xlsxExternalLink)
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(linkPath)))).
+			Decode(link); err != nil && err != io.EOF {
+			continue
+		}
+		extLink := &calcExternalLink{cells: make(map[string]map[string]xlsxExternalCell)}
+		if link.ExternalBook != nil {
+			linkRels, _ := f.relsViewer(path.Join(path.Dir(linkPath), "_rels", path.Base(linkPath)+".rels"))
+			if linkRels != nil {
+				for _, rel := range linkRels.Relationships {
+					if rel.ID == link.ExternalBook.RID {
+						extLink.name = path.Base(strings.ReplaceAll(rel.Target, "\\", "/"))
+					}
+				}
+			}
+			extLink.indexCells(link.ExternalBook)
+		}
+		f.calcLinks[strconv.Itoa(i+1)] = extLink
+		if key := strings.ToLower(extLink.name); key != "" {
+			if _, ok := f.calcLinks[key]; !ok {
+				f.calcLinks[key] = extLink
+			}
+		}
+	}
+	return f.calcLinks
+}
+
+// indexCells index the cached values of the cells in the external workbook by
+// the lower-case worksheet name and the cell reference. The cells of the
+// worksheet with refresh error will not be indexed.
+func (link *calcExternalLink) indexCells(book *xlsxExternalBook) {
+	if book.SheetNames == nil || book.SheetDataSet == nil {
+		return
+	}
+	sheetData := make(map[int]map[string]xlsxExternalCell)
+	for _, data := range book.SheetDataSet.SheetData {
+		if _, ok := sheetData[data.SheetID]; ok || data.RefreshError {
+			continue
+		}
+		cells := make(map[string]xlsxExternalCell)
+		for _, row := range data.Row {
+			for _, c := range row.Cell {
+				if _, ok := cells[c.R]; !ok {
+					cells[c.R] = c
+				}
+			}
+		}
+		sheetData[data.SheetID] = cells
+	}
+	for idx, sheetName := range book.SheetNames.SheetName {
+		name := strings.ToLower(sheetName.Val)
+		if cells, ok := sheetData[idx]; ok {
+			link.cells[name] = cells
+			continue
+		}
+		delete(link.cells, name)
+	}
+}
+
+// getExternalLink provides a function to get the file name and the external
+// link part of the external workbook by given external workbook index (such
+// as 1 in [1]Sheet1!A1) or file name (such as Book2.xlsx in
+// [Book2.xlsx]Sheet1!A1).
+func (f *File) getExternalLink(book string) (string, *calcExternalLink) {
+	if idx, err := strconv.Atoi(book); err == nil {
+		book = strconv.Itoa(idx)
+	}
+	if link, ok := f.getExternalLinks()[strings.ToLower(book)]; ok {
+		return link.name, link
+	}
+	return book, nil
+}
+
+// externalCellResolver calc the cell value of the external workbook by given
+// external link part, worksheet name and cell reference.
+func (f *File) externalCellResolver(name string, link *calcExternalLink, sheet, cell string) (formulaArg, bool) {
+	if fn, ok := f.calcExternals.Load(strings.ToLower(name)); ok {
+		arg, err := fn.(func(sheet, cell string) (CalcArg, error))(sheet, cell)
+		if err != nil {
+			return newErrorFormulaArg(formulaErrorREF, err.Error()), false
+		}
+		return newFormulaArgFromCalcArg(arg), true
+	}
+	if link == nil {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF), false
+	}
+	cells, ok := link.cells[strings.ToLower(sheet)]
+	if !ok {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF), false
+	}
+	c, ok := cells[cell]
+	if !ok {
+		return newEmptyFormulaArg(), true
+	}
+	switch c.T {
+	case "b":
+		return newBoolFormulaArg(c.V == "1"), true
+	case "e":
+		return newErrorFormulaArg(c.V, c.V), true
+	case "s", "str":
+		return newStringFormulaArg(c.V), true
+	}
+	return newStringFormulaArg(c.V).ToNumber(), true
+}
+
+// parseExternalReference parse the external workbook reference, such as
+// [Book2.xlsx]Sheet1!A1 or [1]Sheet1!A1:B2, and extract the values from the
+// value provider or the cached values of the external workbook.
+func (f *File) parseExternalReference(reference string) (formulaArg, error) {
+	refErr := newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+	end, idx := strings.Index(reference, "]"), strings.LastIndex(reference, "!")
+	if end == -1 || idx < end {
+		return refErr, errors.New(formulaErrorREF)
+	}
+	name, link := f.getExternalLink(reference[1:end])
+	sheet, cells := reference[end+1:idx], strings.Split(reference[idx+1:], ":")
+	if len(cells) > 2 {
+		return refErr, errors.New(formulaErrorREF)
+	}
+	coordinates := make([]int, 0, 4)
+	for _, cell := range cells {
+		col, row, err := CellNameToCoordinates(cell)
+		if err != nil {
+			return refErr, errors.New(formulaErrorREF)
+		}
+		coordinates = append(coordinates, col, row)
+	}
+	if len(coordinates) == 2 {
+		coordinates = append(coordinates, coordinates...)
+	}
+	_ = sortCoordinates(coordinates)
+	var matrix [][]formulaArg
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		var matrixRow []formulaArg
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			arg, ok := f.externalCellResolver(name, link, sheet, cell)
+			if !ok {
+				return arg, errors.New(formulaErrorREF)
+			}
+			matrixRow = append(matrixRow, arg)
+		}
+		matrix = append(matrix, matrixRow)
+	}
+	arg := matrix[0][0]
+	if len(cells) > 1 {
+		arg = newMatrixFormulaArg(matrix)
+	}
+	arg.cellRefs, arg.cellRanges = list.New(), list.New()
+	return arg, nil
+}
+
 // prepareValueRange prepare value range.
 func prepareValueRange(cr cellRange, valueRange []int) {
 	if cr.From.Row < valueRange[0] || valueRange[0] == 0 {
@@ -1697,6 +2157,103 @@ func callFuncByName(receiver interface{}, name string, params []reflect.Value) (
 	return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("not support %s function", name))
 }
 
+// RegisterCalcFunc provides a function to register a user-defined formula
+// function by given function name for the formula calculation engine, the
+// name is case-insensitive. The registered function takes precedence over the
+// built-in function with the same name, so it can also be used to provide
+// the functions which not supported yet. For example, register a function
+// named DOUBLE that returns twice its first numeric argument:
+//
+//	err := f.RegisterCalcFunc("DOUBLE", func(args []excelize.CalcArg) (excelize.CalcResult, error) {
+//	    if len(args) != 1 || args[0].Type != excelize.ArgNumber {
+//	        return excelize.CalcResult{Type: excelize.ArgError, Error: "#VALUE!"}, nil
+//	    }
+//	    return excelize.CalcResult{Type: excelize.ArgNumber, Number: args[0].Number * 2}, nil
+//	})
+//
+// Pass a nil function to unregister the user-defined function.
+func (f *File) RegisterCalcFunc(name string, fn func(args []CalcArg) (CalcResult, error)) error {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if name == "" {
+		return ErrParameterRequired
+	}
+	f.clearCalcCache()
+	if fn == nil {
+		f.calcFuncs.Delete(name)
+		return nil
+	}
+	f.calcFuncs.Store(name, fn)
+	return nil
+}
+
+// newCalcArg convert the formula argument to the argument of the
+// user-defined formula function.
+func newCalcArg(arg formulaArg) CalcArg {
+	switch arg.Type {
+	case ArgNumber:
+		return CalcArg{Type: ArgNumber, Number: arg.Number, Boolean: arg.Boolean}
+	case ArgString:
+		return CalcArg{Type: ArgString, String: arg.String}
+	case ArgError:
+		return CalcArg{Type: ArgError, Error: arg.String}
+	case ArgList:
+		return newCalcArg(newMatrixFormulaArg([][]formulaArg{arg.List}))
+	case ArgMatrix:
+		calcArg := CalcArg{Type: ArgMatrix}
+		for _, row := range arg.Matrix {
+			var cells []CalcArg
+			for _, cell := range row {
+				cells = append(cells, newCalcArg(cell))
+			}
+			calcArg.Matrix = append(calcArg.Matrix, cells)
+		}
+		return calcArg
+	}
+	return CalcArg{Type: ArgEmpty}
+}
+
+// callCalcFunc calls the user-defined formula function by given arguments
+// list, and convert the function result to the formula argument.
+func callCalcFunc(fn func(args []CalcArg) (CalcResult, error), argsList *list.List) formulaArg {
+	var args []CalcArg
+	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
+		args = append(args, newCalcArg(arg.Value.(formulaArg)))
+	}
+	result, err := fn(args)
+	if err != nil {
+		return newErrorFormulaArg(formulaErrorVALUE, err.Error())
+	}
+	switch result.Type {
+	case ArgNumber:
+		if result.Boolean {
+			return newBoolFormulaArg(result.Number != 0)
+		}
+		return newNumberFormulaArg(result.Number)
+	case ArgString:
+		return newStringFormulaArg(result.String)
+	case ArgError:
+		return newErrorFormulaArg(result.Error, result.Error)
+	}
+	return newEmptyFormulaArg()
+}
+
+// newFormulaArgFromCalcArg convert the argument of the user-defined formula
+// function or external workbook value provider to the formula argument.
+func newFormulaArgFromCalcArg(arg CalcArg) formulaArg {
+	switch arg.Type {
+	case ArgNumber:
+		if arg.Boolean {
+			return newBoolFormulaArg(arg.Number != 0)
+		}
+		return newNumberFormulaArg(arg.Number)
+	case ArgString:
+		return newStringFormulaArg(arg.String)
+	case ArgError:
+		return newErrorFormulaArg(arg.Error, arg.Error)
+	}
+	return newEmptyFormulaArg()
+}
+
 // formulaCriteriaParser parse formula criteria.
 func formulaCriteriaParser(exp formulaArg) *formulaCriteria {
 	prepareValue := func(cond string) (expected float64, err error) {
@@ -1995,7 +2552,7 @@ func (fn *formulaFuncs) BIN2DEC(argsList *list.List) formulaArg {
 	if number.Type != ArgNumber {
 		return newErrorFormulaArg(formulaErrorVALUE, number.Error)
 	}
-	return fn.bin2dec(token.Value())
+	return fn.bin2dec(engineeringNumberText(token))
 }
 
 // BIN2HEX function converts a Binary (Base 2) number into a Hexadecimal
@@ -2014,7 +2571,7 @@ func (fn *formulaFuncs) BIN2HEX(argsList *list.List) formulaArg {
 	if number.Type != ArgNumber {
 		return newErrorFormulaArg(formulaErrorVALUE, number.Error)
 	}
-	decimal, newList := fn.bin2dec(token.Value()), list.New()
+	decimal, newList := fn.bin2dec(engineeringNumberText(token)), list.New()
 	if decimal.Type != ArgNumber {
 		return decimal
 	}
@@ -2041,7 +2598,7 @@ func (fn *formulaFuncs) BIN2OCT(argsList *list.List) formulaArg {
 	if number.Type != ArgNumber {
 		return newErrorFormulaArg(formulaErrorVALUE, number.Error)
 	}
-	decimal, newList := fn.bin2dec(token.Value()), list.New()
+	decimal, newList := fn.bin2dec(engineeringNumberText(token)), list.New()
 	if decimal.Type != ArgNumber {
 		return decimal
 	}
@@ -2054,13 +2611,12 @@ func (fn *formulaFuncs) BIN2OCT(argsList *list.List) formulaArg {
 
 // bin2dec is an implementation of the formula function BIN2DEC.
 func (fn *formulaFuncs) bin2dec(number string) formulaArg {
+	if len(number) > 10 {
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
 	decimal, length := 0.0, len(number)
 	for i := length; i > 0; i-- {
 		s := string(number[length-i])
-		if i == 10 && s == "1" {
-			decimal += math.Pow(-2.0, float64(i-1))
-			continue
-		}
 		if s == "1" {
 			decimal += math.Pow(2.0, float64(i-1))
 			continue
@@ -2069,7 +2625,27 @@ func (fn *formulaFuncs) bin2dec(number string) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	return newNumberFormulaArg(decimal)
+	return newNumberFormulaArg(twosComplement(decimal, 2, length))
+}
+
+// engineeringNumberText returns the text of the formula argument for the
+// base conversion engineering functions, the number will be formatted without
+// the exponent.
+func engineeringNumberText(arg formulaArg) string {
+	if arg.Type == ArgNumber && !arg.Boolean {
+		return strconv.FormatFloat(arg.Number, 'f', -1, 64)
+	}
+	return arg.Value()
+}
+
+// twosComplement returns the signed decimal number of the 10 digits number in
+// two's-complement notation by given unsigned decimal number, base and the
+// number of digits.
+func twosComplement(decimal, base float64, digits int) float64 {
+	if limit := math.Pow(base, 10); digits == 10 && decimal >= limit/2 {
+		return decimal - limit
+	}
+	return decimal
 }
 
 // BITAND function returns the bitwise 'AND' for two supplied integers. The
@@ -2391,47 +2967,47 @@ var unitConversions = map[byte]map[string]float64{
 	categoryWeightAndMass: {
 		"g":        1,
 		"sg":       6.85217658567918e-05,
-		"lbm":      2.20462262184878e-03,
+		"lbm":      1 / 453.59237,
 		"u":        6.02214179421676e+23,
-		"ozm":      3.52739619495804e-02,
-		"grain":    1.54323583529414e+01,
-		"cwt":      2.20462262184878e-05,
-		"shweight": 2.20462262184878e-05,
-		"uk_cwt":   1.96841305522212e-05,
-		"lcwt":     1.96841305522212e-05,
-		"hweight":  1.96841305522212e-05,
-		"stone":    1.57473044417770e-04,
-		"ton":      1.10231131092439e-06,
-		"uk_ton":   9.84206527611061e-07,
-		"LTON":     9.84206527611061e-07,
-		"brton":    9.84206527611061e-07,
+		"ozm":      1 / 28.349523125,
+		"grain":    1 / 0.06479891,
+		"cwt":      1 / 45359.237,
+		"shweight": 1 / 45359.237,
+		"uk_cwt":   1 / 50802.34544,
+		"lcwt":     1 / 50802.34544,
+		"hweight":  1 / 50802.34544,
+		"stone":    1 / 6350.29318,
+		"ton":      1 / 907184.74,
+		"uk_ton":   1 / 1016046.9088,
+		"LTON":     1 / 1016046.9088,
+		"brton":    1 / 1016046.9088,
 	},
 	// conversion uses meter (m) as an intermediate unit
 	categoryDistance: {
 		"m":         1,
-		"mi":        6.21371192237334e-04,
-		"Nmi":       5.39956803455724e-04,
-		"in":        3.93700787401575e+01,
-		"ft":        3.28083989501312e+00,
-		"yd":        1.09361329833771e+00,
+		"mi":        1 / 1609.344,
+		"Nmi":       1 / 1852.0,
+		"in":        1 / 0.0254,
+		"ft":        1 / 0.3048,
+		"yd":        1 / 0.9144,
 		"ang":       1.0e+10,
-		"ell":       8.74890638670166e-01,
-		"ly":        1.05700083402462e-16,
+		"ell":       1 / 1.143,
+		"ly":        1 / 9460730472580800.0,
 		"parsec":    3.24077928966473e-17,
 		"pc":        3.24077928966473e-17,
-		"Pica":      2.83464566929134e+03,
-		"Picapt":    2.83464566929134e+03,
-		"pica":      2.36220472440945e+02,
-		"survey_mi": 6.21369949494950e-04,
+		"Pica":      72 / 0.0254,
+		"Picapt":    72 / 0.0254,
+		"pica":      6 / 0.0254,
+		"survey_mi": 3937 / 6336000.0,
 	},
 	// conversion uses second (s) as an intermediate unit
 	categoryTime: {
-		"yr":  3.16880878140289e-08,
-		"day": 1.15740740740741e-05,
-		"d":   1.15740740740741e-05,
-		"hr":  2.77777777777778e-04,
-		"mn":  1.66666666666667e-02,
-		"min": 1.66666666666667e-02,
+		"yr":  1 / 31557600.0,
+		"day": 1 / 86400.0,
+		"d":   1 / 86400.0,
+		"hr":  1 / 3600.0,
+		"mn":  1 / 60.0,
+		"min": 1 / 60.0,
 		"sec": 1,
 		"s":   1,
 	},
@@ -2439,10 +3015,10 @@ var unitConversions = map[byte]map[string]float64{
 	categoryPressure: {
 		"Pa":   1,
 		"p":    1,
-		"atm":  9.86923266716013e-06,
-		"at":   9.86923266716013e-06,
-		"mmHg": 7.50063755419211e-03,
-		"psi":  1.45037737730209e-04,
+		"atm":  1 / 101325.0,
+		"at":   1 / 101325.0,
+		"mmHg": 760 / 101325.0,
+		"psi":  0.00064516 / 4.4482216152605,
 		"Torr": 7.50061682704170e-03,
 	},
 	// conversion uses Newton (N) as an intermediate unit
@@ -2450,32 +3026,32 @@ var unitConversions = map[byte]map[string]float64{
 		"N":    1,
 		"dyn":  1.0e+5,
 		"dy":   1.0e+5,
-		"lbf":  2.24808923655339e-01,
-		"pond": 1.01971621297793e+02,
+		"lbf":  1 / 4.4482216152605,
+		"pond": 1 / 0.00980665,
 	},
 	// conversion uses Joule (J) as an intermediate unit
 	categoryEnergy: {
 		"J":   1,
-		"e":   9.99999519343231e+06,
-		"c":   2.39006249473467e-01,
-		"cal": 2.38846190642017e-01,
+		"e":   1.0e+07,
+		"c":   1 / 4.184,
+		"cal": 1 / 4.1868,
 		"eV":  6.24145700000000e+18,
 		"ev":  6.24145700000000e+18,
-		"HPh": 3.72506430801000e-07,
-		"hh":  3.72506430801000e-07,
-		"Wh":  2.77777916238711e-04,
-		"wh":  2.77777916238711e-04,
+		"HPh": 1 / (745.69987158227022 * 3600),
+		"hh":  1 / (745.69987158227022 * 3600),
+		"Wh":  1 / 3600.0,
+		"wh":  1 / 3600.0,
 		"flb": 2.37304222192651e+01,
-		"BTU": 9.47815067349015e-04,
-		"btu": 9.47815067349015e-04,
+		"BTU": 1 / 1055.05585262,
+		"btu": 1 / 1055.05585262,
 	},
 	// conversion uses Horsepower (HP) as an intermediate unit
 	categoryPower: {
 		"HP": 1,
 		"h":  1,
-		"W":  7.45699871582270e+02,
-		"w":  7.45699871582270e+02,
-		"PS": 1.01386966542400e+00,
+		"W":  745.69987158227022,
+		"w":  745.69987158227022,
+		"PS": 745.69987158227022 / 735.49875,
 	},
 	// conversion uses Tesla (T) as an intermediate unit
 	categoryMagnetism: {
@@ -2487,71 +3063,71 @@ var unitConversions = map[byte]map[string]float64{
 		"l":        1,
 		"L":        1,
 		"lt":       1,
-		"tsp":      2.02884136211058e+02,
+		"tsp":      768 / 3.785411784,
 		"tspm":     2.0e+02,
-		"tbs":      6.76280454036860e+01,
-		"oz":       3.38140227018430e+01,
-		"cup":      4.22675283773038e+00,
-		"pt":       2.11337641886519e+00,
-		"us_pt":    2.11337641886519e+00,
-		"uk_pt":    1.75975398639270e+00,
-		"qt":       1.05668820943259e+00,
-		"uk_qt":    8.79876993196351e-01,
-		"gal":      2.64172052358148e-01,
-		"uk_gal":   2.19969248299088e-01,
+		"tbs":      256 / 3.785411784,
+		"oz":       128 / 3.785411784,
+		"cup":      16 / 3.785411784,
+		"pt":       8 / 3.785411784,
+		"us_pt":    8 / 3.785411784,
+		"uk_pt":    8 / 4.54609,
+		"qt":       4 / 3.785411784,
+		"uk_qt":    4 / 4.54609,
+		"gal":      1 / 3.785411784,
+		"uk_gal":   1 / 4.54609,
 		"ang3":     1.0e+27,
 		"ang^3":    1.0e+27,
-		"barrel":   6.28981077043211e-03,
-		"bushel":   2.83775932584017e-02,
-		"in3":      6.10237440947323e+01,
-		"in^3":     6.10237440947323e+01,
-		"ft3":      3.53146667214886e-02,
-		"ft^3":     3.53146667214886e-02,
+		"barrel":   1 / (42 * 3.785411784),
+		"bushel":   1 / 35.23907016688,
+		"in3":      1 / 0.016387064,
+		"in^3":     1 / 0.016387064,
+		"ft3":      1 / 28.316846592,
+		"ft^3":     1 / 28.316846592,
 		"ly3":      1.18093498844171e-51,
 		"ly^3":     1.18093498844171e-51,
 		"m3":       1.0e-03,
 		"m^3":      1.0e-03,
-		"mi3":      2.39912758578928e-13,
-		"mi^3":     2.39912758578928e-13,
-		"yd3":      1.30795061931439e-03,
-		"yd^3":     1.30795061931439e-03,
-		"Nmi3":     1.57426214685811e-13,
-		"Nmi^3":    1.57426214685811e-13,
+		"mi3":      1 / 4168181825.440579584,
+		"mi^3":     1 / 4168181825.440579584,
+		"yd3":      1 / 764.554857984,
+		"yd^3":     1 / 764.554857984,
+		"Nmi3":     1 / 6352182208.0,
+		"Nmi^3":    1 / 6352182208.0,
 		"Pica3":    2.27769904358706e+07,
 		"Pica^3":   2.27769904358706e+07,
 		"Picapt3":  2.27769904358706e+07,
 		"Picapt^3": 2.27769904358706e+07,
-		"GRT":      3.53146667214886e-04,
-		"regton":   3.53146667214886e-04,
-		"MTON":     8.82866668037215e-04,
+		"GRT":      1 / 2831.6846592,
+		"regton":   1 / 2831.6846592,
+		"MTON":     1 / 1132.67386368,
 	},
 	// conversion uses hectare (ha) as an intermediate unit
 	categoryArea: {
 		"ha":       1,
-		"uk_acre":  2.47105381467165e+00,
-		"us_acre":  2.47104393046628e+00,
+		"uk_acre":  1e4 / 4046.8564224,
+		"us_acre":  1e4 / 4046.872609874252,
 		"ang2":     1.0e+24,
 		"ang^2":    1.0e+24,
 		"ar":       1.0e+02,
-		"ft2":      1.07639104167097e+05,
-		"ft^2":     1.07639104167097e+05,
-		"in2":      1.55000310000620e+07,
-		"in^2":     1.55000310000620e+07,
+		"ft2":      1e4 / 0.09290304,
+		"ft^2":     1e4 / 0.09290304,
+		"in2":      1e4 / 0.00064516,
+		"in^2":     1e4 / 0.00064516,
 		"ly2":      1.11725076312873e-28,
 		"ly^2":     1.11725076312873e-28,
 		"m2":       1.0e+04,
 		"m^2":      1.0e+04,
 		"Morgen":   4.0e+00,
-		"mi2":      3.86102158542446e-03,
-		"mi^2":     3.86102158542446e-03,
-		"Nmi2":     2.91553349598123e-03,
-		"Nmi^2":    2.91553349598123e-03,
+		"mi2":      1e4 / 2589988.110336,
+		"mi^2":     1e4 / 2589988.110336,
+		"Nmi2":     1e4 / 3429904.0,
+		"Nmi^2":    1e4 / 3429904.0,
 		"Pica2":    8.03521607043214e+10,
 		"Pica^2":   8.03521607043214e+10,
 		"Picapt2":  8.03521607043214e+10,
 		"Picapt^2": 8.03521607043214e+10,
-		"yd2":      1.19599004630108e+04,
-		"yd^2":     1.19599004630108e+04,
+		"yd2":      1e4 / 0.83612736,
+		"yd^2":     1e4 / 0.83612736,
 	},
 	// conversion uses bit (bit) as an intermediate unit
 	categoryInformation: {
@@ -2564,9 +3140,9 @@ var unitConversions = map[byte]map[string]float64{
 		"m/sec": 1,
 		"m/h":   3.60e+03,
 		"m/hr":  3.60e+03,
-		"mph":   2.23693629205440e+00,
-		"admkn": 1.94260256941567e+00,
-		"kn":    1.94384449244060e+00,
+		"mph":   1 / 0.44704,
+		"admkn": 3600 / 1853.184,
+		"kn":    3600 / 1852.0,
 	},
 }
 
@@ -2624,7 +3200,7 @@ func getUnitDetails(uom string) (unit string, catgory byte, res float64, ok bool
 			return
 		}
 		unitCategory := conversionUnit.group
-		return uom, unitCategory, multiplier, true
+		return uom, unitCategory, prefixMultiplier(uom, multiplier), true
 	}
 	// 2 character standard and binary metric multiplier prefixes
 	if len(uom) > 0 {
@@ -2639,12 +3215,25 @@ func getUnitDetails(uom string) (unit string, catgory byte, res float64, ok bool
 			return
 		}
 		unitCategory := conversionUnit.group
-		return uom, unitCategory, multiplier, true
+		return uom, unitCategory, prefixMultiplier(uom, multiplier), true
 	}
 	ok = false
 	return
 }
 
+// prefixMultiplier returns the multiplier of the metric prefix for the given
+// unit of measure, the multiplier will be squared for area units (such as
+// "m2" and "m^2") and cubed for volume units (such as "m3" and "m^3").
+func prefixMultiplier(uom string, multiplier float64) float64 {
+	switch uom[len(uom)-1] {
+	case '2':
+		return math.Pow(multiplier, 2)
+	case '3':
+		return math.Pow(multiplier, 3)
+	}
+	return multiplier
+}
+
 // resolveTemperatureSynonyms returns unit of measure according to a given
 // temperature synonyms.
 func resolveTemperatureSynonyms(uom string) string {
@@ -2946,7 +3535,7 @@ func (fn *formulaFuncs) HEX2BIN(argsList *list.List) formulaArg {
 	if argsList.Len() > 2 {
 		return newErrorFormulaArg(formulaErrorVALUE, "HEX2BIN allows at most 2 arguments")
 	}
-	decimal, newList := fn.hex2dec(argsList.Front().Value.(formulaArg).Value()), list.New()
+	decimal, newList := fn.hex2dec(engineeringNumberText(argsList.Front().Value.(formulaArg))), list.New()
 	if decimal.Type != ArgNumber {
 		return decimal
 	}
@@ -2965,7 +3554,7 @@ func (fn *formulaFuncs) HEX2DEC(argsList *list.List) formulaArg {
 	if argsList.Len() != 1 {
 		return newErrorFormulaArg(formulaErrorVALUE, "HEX2DEC requires 1 numeric argument")
 	}
-	return fn.hex2dec(argsList.Front().Value.(formulaArg).Value())
+	return fn.hex2dec(engineeringNumberText(argsList.Front().Value.(formulaArg)))
 }
 
 // HEX2OCT function converts a Hexadecimal (Base 16) number into an Octal
@@ -2979,7 +3568,7 @@ func (fn *formulaFuncs) HEX2OCT(argsList *list.List) formulaArg {
 	if argsList.Len() > 2 {
 		return newErrorFormulaArg(formulaErrorVALUE, "HEX2OCT allows at most 2 arguments")
 	}
-	decimal, newList := fn.hex2dec(argsList.Front().Value.(formulaArg).Value()), list.New()
+	decimal, newList := fn.hex2dec(engineeringNumberText(argsList.Front().Value.(formulaArg))), list.New()
 	if decimal.Type != ArgNumber {
 		return decimal
 	}
@@ -2992,19 +3581,18 @@ func (fn *formulaFuncs) HEX2OCT(argsList *list.List) formulaArg {
 
 // hex2dec is an implementation of the formula function HEX2DEC.
 func (fn *formulaFuncs) hex2dec(number string) formulaArg {
+	if len(number) > 10 {
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
 	decimal, length := 0.0, len(number)
 	for i := length; i > 0; i-- {
 		num, err := strconv.ParseInt(string(number[length-i]), 16, 64)
 		if err != nil {
 			return newErrorFormulaArg(formulaErrorNUM, err.Error())
 		}
-		if i == 10 && string(number[length-i]) == "F" {
-			decimal += math.Pow(-16.0, float64(i-1))
-			continue
-		}
 		decimal += float64(num) * math.Pow(16.0, float64(i-1))
 	}
-	return newNumberFormulaArg(decimal)
+	return newNumberFormulaArg(twosComplement(decimal, 16, length))
 }
 
 // IMABS function returns the absolute value (the modulus) of a complex
@@ -3492,7 +4080,10 @@ func (fn *formulaFuncs) OCT2BIN(argsList *list.List) formulaArg {
 	if number.Type != ArgNumber {
 		return newErrorFormulaArg(formulaErrorVALUE, number.Error)
 	}
-	decimal, newList := fn.oct2dec(token.Value()), list.New()
+	decimal, newList := fn.oct2dec(engineeringNumberText(token)), list.New()
+	if decimal.Type != ArgNumber {
+		return decimal
+	}
 	newList.PushBack(decimal)
 	if argsList.Len() == 2 {
 		newList.PushBack(argsList.Back().Value.(formulaArg))
@@ -3513,7 +4104,7 @@ func (fn *formulaFuncs) OCT2DEC(argsList *list.List) formulaArg {
 	if number.Type != ArgNumber {
 		return newErrorFormulaArg(formulaErrorVALUE, number.Error)
 	}
-	return fn.oct2dec(token.Value())
+	return fn.oct2dec(engineeringNumberText(token))
 }
 
 // OCT2HEX function converts an Octal (Base 8) number into a Hexadecimal
@@ -3532,7 +4123,10 @@ func (fn *formulaFuncs) OCT2HEX(argsList *list.List) formulaArg {
 	if number.Type != ArgNumber {
 		return newErrorFormulaArg(formulaErrorVALUE, number.Error)
 	}
-	decimal, newList := fn.oct2dec(token.Value()), list.New()
+	decimal, newList := fn.oct2dec(engineeringNumberText(token)), list.New()
+	if decimal.Type != ArgNumber {
+		return decimal
+	}
 	newList.PushBack(decimal)
 	if argsList.Len() == 2 {
 		newList.PushBack(argsList.Back().Value.(formulaArg))
@@ -3542,16 +4136,18 @@ func (fn *formulaFuncs) OCT2HEX(argsList *list.List) formulaArg {
 
 // oct2dec is an implementation of the formula function OCT2DEC.
 func (fn *formulaFuncs) oct2dec(number string) formulaArg {
+	if len(number) > 10 {
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
 	decimal, length := 0.0, len(number)
 	for i := length; i > 0; i-- {
-		num, _ := strconv.Atoi(string(number[length-i]))
-		if i == 10 && string(number[length-i]) == "7" {
-			decimal += math.Pow(-8.0, float64(i-1))
-			continue
+		num, err := strconv.Atoi(string(number[length-i]))
+		if err != nil || num > 7 {
+			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 		decimal += float64(num) * math.Pow(8.0, float64(i-1))
 	}
-	return newNumberFormulaArg(decimal)
+	return newNumberFormulaArg(twosComplement(decimal, 8, length))
 }
 
 // Math and Trigonometric Functions
@@ -12021,7 +12617,7 @@ func (fn *formulaFuncs) DATE(argsList *list.List) formulaArg {
 
 // calcDateDif is an implementation of the formula function DATEDIF,
 // calculation difference between two dates.
-func calcDateDif(unit string, diff float64, seq []int, startArg, endArg formulaArg) float64 {
+func (fn *formulaFuncs) calcDateDif(unit string, diff float64, seq []int, startArg, endArg formulaArg) float64 {
 	ey, sy, em, sm, ed, sd := seq[0], seq[1], seq[2], seq[3], seq[4], seq[5]
 	switch unit {
 	case "d":
@@ -12031,7 +12627,7 @@ func calcDateDif(unit string, diff float64, seq []int, startArg, endArg formulaA
 		if ed < sd {
 			smMD--
 		}
-		diff = endArg.Number - daysBetween(excelMinTime1900.Unix(), makeDate(ey, time.Month(smMD), sd)) - 1
+		diff = endArg.Number - fn.dateSerial(daysBetween(excelMinTime1900.Unix(), makeDate(ey, time.Month(smMD), sd))+1)
 	case "ym":
 		diff = float64(em - sm)
 		if ed < sd {
@@ -12071,7 +12667,7 @@ func (fn *formulaFuncs) DATEDIF(argsList *list.List) formulaArg {
 		return newNumberFormulaArg(0)
 	}
 	unit := strings.ToLower(argsList.Back().Value.(formulaArg).Value())
-	startDate, endDate := timeFromExcelTime(startArg.Number, false), timeFromExcelTime(endArg.Number, false)
+	startDate, endDate := fn.serialToTime(startArg.Number), fn.serialToTime(endArg.Number)
 	sy, smm, sd := startDate.Date()
 	ey, emm, ed := endDate.Date()
 	sm, em, diff := int(smm), int(emm), 0.0
@@ -12093,7 +12689,7 @@ func (fn *formulaFuncs) DATEDIF(argsList *list.List) formulaArg {
 		}
 		diff = float64(yDiff*12 + mDiff)
 	case "d", "md", "ym", "yd":
-		diff = calcDateDif(unit, diff, []int{ey, sy, em, sm, ed, sd}, startArg, endArg)
+		diff = fn.calcDateDif(unit, diff, []int{ey, sy, em, sm, ed, sd}, startArg, endArg)
 	default:
 		return newErrorFormulaArg(formulaErrorVALUE, "DATEDIF has invalid unit")
 	}
@@ -12350,7 +12946,7 @@ func (fn *formulaFuncs) DATEVALUE(argsList *list.List) formulaArg {
 	if err.Type == ArgError {
 		return err
 	}
-	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), makeDate(y, time.Month(m), d)) + 1)
+	return newNumberFormulaArg(fn.dateSerial(daysBetween(excelMinTime1900.Unix(), makeDate(y, time.Month(m), d)) + 1))
 }
 
 // DAY function returns the day of a date, represented by a serial number. The
@@ -12380,10 +12976,10 @@ func (fn *formulaFuncs) DAY(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "DAY only accepts positive argument")
 	}
-	if num.Number <= 60 {
+	if num.Number <= 60 && !fn.date1904() {
 		return newNumberFormulaArg(math.Mod(num.Number, 31.0))
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Day()))
+	return newNumberFormulaArg(float64(fn.serialToTime(num.Number).Day()))
 }
 
 // DAYS function returns the number of days between two supplied dates. The
@@ -12413,15 +13009,15 @@ func (fn *formulaFuncs) DAYS360(argsList *list.List) formulaArg {
 	if argsList.Len() > 3 {
 		return newErrorFormulaArg(formulaErrorVALUE, "DAYS360 requires at most 3 arguments")
 	}
-	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg))
+	startDate := fn.toExcelDateArg(argsList.Front().Value.(formulaArg))
 	if startDate.Type != ArgNumber {
 		return startDate
 	}
-	endDate := toExcelDateArg(argsList.Front().Next().Value.(formulaArg))
+	endDate := fn.toExcelDateArg(argsList.Front().Next().Value.(formulaArg))
 	if endDate.Type != ArgNumber {
 		return endDate
 	}
-	start, end := timeFromExcelTime(startDate.Number, false), timeFromExcelTime(endDate.Number, false)
+	start, end := fn.serialToTime(startDate.Number), fn.serialToTime(endDate.Number)
 	sy, sm, sd, ey, em, ed := start.Year(), int(start.Month()), start.Day(), end.Year(), int(end.Month()), end.Day()
 	method := newBoolFormulaArg(false)
 	if argsList.Len() > 2 {
@@ -12479,7 +13075,7 @@ func (fn *formulaFuncs) ISOWEEKNUM(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		_, weekNum = timeFromExcelTime(num.Number, false).ISOWeek()
+		_, weekNum = fn.serialToTime(num.Number).ISOWeek()
 	}
 	return newNumberFormulaArg(float64(weekNum))
 }
@@ -12511,7 +13107,7 @@ func (fn *formulaFuncs) EDATE(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		dateTime = timeFromExcelTime(num.Number, false)
+		dateTime = fn.serialToTime(num.Number)
 	}
 	month := argsList.Back().Value.(formulaArg).ToNumber()
 	if month.Type != ArgNumber {
@@ -12533,7 +13129,7 @@ func (fn *formulaFuncs) EDATE(argsList *list.List) formulaArg {
 			d = days
 		}
 	}
-	result, _ := timeToExcelTime(time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC), false)
+	result := fn.timeToSerial(time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC))
 	return newNumberFormulaArg(result)
 }
 
@@ -12565,7 +13161,7 @@ func (fn *formulaFuncs) EOMONTH(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		dateTime = timeFromExcelTime(num.Number, false)
+		dateTime = fn.serialToTime(num.Number)
 	}
 	months := argsList.Back().Value.(formulaArg).ToNumber()
 	if months.Type != ArgNumber {
@@ -12581,7 +13177,7 @@ func (fn *formulaFuncs) EOMONTH(argsList *list.List) formulaArg {
 	if m = m % 12; m < 0 {
 		m += 12
 	}
-	result, _ := timeToExcelTime(time.Date(y, time.Month(m+1), getDaysInMonth(y, m+1), 0, 0, 0, 0, time.UTC), false)
+	result := fn.timeToSerial(time.Date(y, time.Month(m+1), getDaysInMonth(y, m+1), 0, 0, 0, 0, time.UTC))
 	return newNumberFormulaArg(result)
 }
 
@@ -12615,7 +13211,7 @@ func (fn *formulaFuncs) HOUR(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "HOUR only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Hour()))
+	return newNumberFormulaArg(float64(fn.serialToTime(num.Number).Hour()))
 }
 
 // MINUTE function returns an integer representing the minute component of a
@@ -12645,7 +13241,7 @@ func (fn *formulaFuncs) MINUTE(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "MINUTE only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Minute()))
+	return newNumberFormulaArg(float64(fn.serialToTime(num.Number).Minute()))
 }
 
 // MONTH function returns the month of a date represented by a serial number.
@@ -12675,7 +13271,7 @@ func (fn *formulaFuncs) MONTH(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "MONTH only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Month()))
+	return newNumberFormulaArg(float64(fn.serialToTime(num.Number).Month()))
 }
 
 // genWeekendMask generate weekend mask of a series of seven 0's and 1's which
@@ -12695,8 +13291,8 @@ func genWeekendMask(weekend int) []byte {
 }
 
 // isWorkday check if the date is workday.
-func isWorkday(weekendMask []byte, date float64) bool {
-	dateTime := timeFromExcelTime(date, false)
+func (fn *formulaFuncs) isWorkday(weekendMask []byte, date float64) bool {
+	dateTime := fn.serialToTime(date)
 	weekday := dateTime.Weekday()
 	if weekday == time.Sunday {
 		weekday = 7
@@ -12734,7 +13330,7 @@ func prepareWorkday(weekend formulaArg) ([]byte, int) {
 
 // toExcelDateArg function converts a text representation of a time, into an
 // Excel date time number formula argument.
-func toExcelDateArg(arg formulaArg) formulaArg {
+func (fn *formulaFuncs) toExcelDateArg(arg formulaArg) formulaArg {
 	num := arg.ToNumber()
 	if num.Type != ArgNumber {
 		dateString := strings.ToLower(arg.Value())
@@ -12747,7 +13343,7 @@ func toExcelDateArg(arg formulaArg) formulaArg {
 		if err.Type == ArgError {
 			return err
 		}
-		num.Number, _ = timeToExcelTime(time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC), false)
+		num.Number = fn.timeToSerial(time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC))
 		return newNumberFormulaArg(num.Number)
 	}
 	if arg.Number < 0 {
@@ -12758,10 +13354,10 @@ func toExcelDateArg(arg formulaArg) formulaArg {
 
 // prepareHolidays function converts array type formula arguments to into an
 // Excel date time number formula arguments list.
-func prepareHolidays(args formulaArg) []int {
+func (fn *formulaFuncs) prepareHolidays(args formulaArg) []int {
 	var holidays []int
 	for _, arg := range args.ToList() {
-		num := toExcelDateArg(arg)
+		num := fn.toExcelDateArg(arg)
 		if num.Type != ArgNumber {
 			continue
 		}
@@ -12771,7 +13367,7 @@ func prepareHolidays(args formulaArg) []int {
 }
 
 // workdayIntl is an implementation of the formula function WORKDAY.INTL.
-func workdayIntl(endDate, sign int, holidays []int, weekendMask []byte, startDate float64) int {
+func (fn *formulaFuncs) workdayIntl(endDate, sign int, holidays []int, weekendMask []byte, startDate float64) int {
 	for i := 0; i < len(holidays); i++ {
 		holiday := holidays[i]
 		if sign > 0 {
@@ -12785,18 +13381,18 @@ func workdayIntl(endDate, sign int, holidays []int, weekendMask []byte, startDat
 		}
 		if sign > 0 {
 			if holiday > int(math.Ceil(startDate)) {
-				if isWorkday(weekendMask, float64(holiday)) {
+				if fn.isWorkday(weekendMask, float64(holiday)) {
 					endDate += sign
-					for !isWorkday(weekendMask, float64(endDate)) {
+					for !fn.isWorkday(weekendMask, float64(endDate)) {
 						endDate += sign
 					}
 				}
 			}
 		} else {
 			if holiday < int(math.Ceil(startDate)) {
-				if isWorkday(weekendMask, float64(holiday)) {
+				if fn.isWorkday(weekendMask, float64(holiday)) {
 					endDate += sign
-					for !isWorkday(weekendMask, float64(endDate)) {
+					for !fn.isWorkday(weekendMask, float64(endDate)) {
 						endDate += sign
 					}
 				}
@@ -12842,11 +13438,11 @@ func (fn *formulaFuncs) NETWORKDAYSdotINTL(argsList *list.List) formulaArg {
 	if argsList.Len() > 4 {
 		return newErrorFormulaArg(formulaErrorVALUE, "NETWORKDAYS.INTL requires at most 4 arguments")
 	}
-	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg))
+	startDate := fn.toExcelDateArg(argsList.Front().Value.(formulaArg))
 	if startDate.Type != ArgNumber {
 		return startDate
 	}
-	endDate := toExcelDateArg(argsList.Front().Next().Value.(formulaArg))
+	endDate := fn.toExcelDateArg(argsList.Front().Next().Value.(formulaArg))
 	if endDate.Type != ArgNumber {
 		return endDate
 	}
@@ -12856,7 +13452,7 @@ func (fn *formulaFuncs) NETWORKDAYSdotINTL(argsList *list.List) formulaArg {
 	}
 	var holidays []int
 	if argsList.Len() == 4 {
-		holidays = prepareHolidays(argsList.Back().Value.(formulaArg))
+		holidays = fn.prepareHolidays(argsList.Back().Value.(formulaArg))
 		sort.Ints(holidays)
 	}
 	weekendMask, workdaysPerWeek := prepareWorkday(weekend)
@@ -12874,14 +13470,14 @@ func (fn *formulaFuncs) NETWORKDAYSdotINTL(argsList *list.List) formulaArg {
 	count := int(math.Floor(offset/7) * float64(workdaysPerWeek))
 	daysMod := int(offset) % 7
 	for daysMod >= 0 {
-		if isWorkday(weekendMask, endDate.Number-float64(daysMod)) {
+		if fn.isWorkday(weekendMask, endDate.Number-float64(daysMod)) {
 			count++
 		}
 		daysMod--
 	}
 	for i := 0; i < len(holidays); i++ {
 		holiday := float64(holidays[i])
-		if isWorkday(weekendMask, holiday) && holiday >= startDate.Number && holiday <= endDate.Number {
+		if fn.isWorkday(weekendMask, holiday) && holiday >= startDate.Number && holiday <= endDate.Number {
 			count--
 		}
 	}
@@ -12923,7 +13519,7 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 	if argsList.Len() > 4 {
 		return newErrorFormulaArg(formulaErrorVALUE, "WORKDAY.INTL requires at most 4 arguments")
 	}
-	startDate := toExcelDateArg(argsList.Front().Value.(formulaArg))
+	startDate := fn.toExcelDateArg(argsList.Front().Value.(formulaArg))
 	if startDate.Type != ArgNumber {
 		return startDate
 	}
@@ -12937,7 +13533,7 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 	}
 	var holidays []int
 	if argsList.Len() == 4 {
-		holidays = prepareHolidays(argsList.Back().Value.(formulaArg))
+		holidays = fn.prepareHolidays(argsList.Back().Value.(formulaArg))
 		sort.Ints(holidays)
 	}
 	if days.Number == 0 {
@@ -12955,13 +13551,13 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 	daysMod := int(days.Number) % workdaysPerWeek
 	endDate := int(math.Ceil(startDate.Number)) + offset*7
 	if daysMod == 0 {
-		for !isWorkday(weekendMask, float64(endDate)) {
+		for !fn.isWorkday(weekendMask, float64(endDate)) {
 			endDate -= sign
 		}
 	} else {
 		for daysMod != 0 {
 			endDate += sign
-			if isWorkday(weekendMask, float64(endDate)) {
+			if fn.isWorkday(weekendMask, float64(endDate)) {
 				if daysMod < 0 {
 					daysMod++
 					continue
@@ -12970,7 +13566,7 @@ func (fn *formulaFuncs) WORKDAYdotINTL(argsList *list.List) formulaArg {
 			}
 		}
 	}
-	return newNumberFormulaArg(float64(workdayIntl(endDate, sign, holidays, weekendMask, startDate.Number)))
+	return newNumberFormulaArg(float64(fn.workdayIntl(endDate, sign, holidays, weekendMask, startDate.Number)))
 }
 
 // YEAR function returns an integer representing the year of a supplied date.
@@ -12999,7 +13595,7 @@ func (fn *formulaFuncs) YEAR(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "YEAR only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Year()))
+	return newNumberFormulaArg(float64(fn.serialToTime(num.Number).Year()))
 }
 
 // yearFracBasisCond is an implementation of the yearFracBasis1.
@@ -13009,8 +13605,8 @@ func yearFracBasisCond(sy, sm, sd, ey, em, ed int) bool {
 
 // yearFracBasis0 function returns the fraction of a year that between two
 // supplied dates in US (NASD) 30/360 type of day.
-func yearFracBasis0(startDate, endDate float64) (dayDiff, daysInYear float64) {
-	startTime, endTime := timeFromExcelTime(startDate, false), timeFromExcelTime(endDate, false)
+func (fn *formulaFuncs) yearFracBasis0(startDate, endDate float64) (dayDiff, daysInYear float64) {
+	startTime, endTime := fn.serialToTime(startDate), fn.serialToTime(endDate)
 	sy, smM, sd := startTime.Date()
 	ey, emM, ed := endTime.Date()
 	sm, em := int(smM), int(emM)
@@ -13032,8 +13628,8 @@ func yearFracBasis0(startDate, endDate float64) (dayDiff, daysInYear float64) {
 
 // yearFracBasis1 function returns the fraction of a year that between two
 // supplied dates in actual type of day.
-func yearFracBasis1(startDate, endDate float64) (dayDiff, daysInYear float64) {
-	startTime, endTime := timeFromExcelTime(startDate, false), timeFromExcelTime(endDate, false)
+func (fn *formulaFuncs) yearFracBasis1(startDate, endDate float64) (dayDiff, daysInYear float64) {
+	startTime, endTime := fn.serialToTime(startDate), fn.serialToTime(endDate)
 	sy, smM, sd := startTime.Date()
 	ey, emM, ed := endTime.Date()
 	sm, em := int(smM), int(emM)
@@ -13061,8 +13657,8 @@ func yearFracBasis1(startDate, endDate float64) (dayDiff, daysInYear float64) {
 
 // yearFracBasis4 function returns the fraction of a year that between two
 // supplied dates in European 30/360 type of day.
-func yearFracBasis4(startDate, endDate float64) (dayDiff, daysInYear float64) {
-	startTime, endTime := timeFromExcelTime(startDate, false), timeFromExcelTime(endDate, false)
+func (fn *formulaFuncs) yearFracBasis4(startDate, endDate float64) (dayDiff, daysInYear float64) {
+	startTime, endTime := fn.serialToTime(startDate), fn.serialToTime(endDate)
 	sy, smM, sd := startTime.Date()
 	ey, emM, ed := endTime.Date()
 	sm, em := int(smM), int(emM)
@@ -13078,17 +13674,17 @@ func yearFracBasis4(startDate, endDate float64) (dayDiff, daysInYear float64) {
 }
 
 // yearFrac is an implementation of the formula function YEARFRAC.
-func yearFrac(startDate, endDate float64, basis int) formulaArg {
-	startTime, endTime := timeFromExcelTime(startDate, false), timeFromExcelTime(endDate, false)
+func (fn *formulaFuncs) yearFrac(startDate, endDate float64, basis int) formulaArg {
+	startTime, endTime := fn.serialToTime(startDate), fn.serialToTime(endDate)
 	if startTime == endTime {
 		return newNumberFormulaArg(0)
 	}
 	var dayDiff, daysInYear float64
 	switch basis {
 	case 0:
-		dayDiff, daysInYear = yearFracBasis0(startDate, endDate)
+		dayDiff, daysInYear = fn.yearFracBasis0(startDate, endDate)
 	case 1:
-		dayDiff, daysInYear = yearFracBasis1(startDate, endDate)
+		dayDiff, daysInYear = fn.yearFracBasis1(startDate, endDate)
 	case 2:
 		dayDiff = endDate - startDate
 		daysInYear = 360
@@ -13096,7 +13692,7 @@ func yearFrac(startDate, endDate float64, basis int) formulaArg {
 		dayDiff = endDate - startDate
 		daysInYear = 365
 	case 4:
-		dayDiff, daysInYear = yearFracBasis4(startDate, endDate)
+		dayDiff, daysInYear = fn.yearFracBasis4(startDate, endDate)
 	default:
 		return newErrorFormulaArg(formulaErrorNUM, "invalid basis")
 	}
@@ -13139,7 +13735,7 @@ func (fn *formulaFuncs) YEARFRAC(argsList *list.List) formulaArg {
 			return basis
 		}
 	}
-	return yearFrac(start.Number, end.Number, int(basis.Number))
+	return fn.yearFrac(start.Number, end.Number, int(basis.Number))
 }
 
 // NOW function returns the current date and time. The function receives no
@@ -13152,7 +13748,7 @@ func (fn *formulaFuncs) NOW(argsList *list.List) formulaArg {
 	}
 	now := time.Now()
 	_, offset := now.Zone()
-	return newNumberFormulaArg(25569.0 + float64(now.Unix()+int64(offset))/86400)
+	return newNumberFormulaArg(fn.dateSerial(25569.0 + float64(now.Unix()+int64(offset))/86400))
 }
 
 // SECOND function returns an integer representing the second component of a
@@ -13182,7 +13778,7 @@ func (fn *formulaFuncs) SECOND(argsList *list.List) formulaArg {
 	if num.Number < 0 {
 		return newErrorFormulaArg(formulaErrorNUM, "SECOND only accepts positive argument")
 	}
-	return newNumberFormulaArg(float64(timeFromExcelTime(num.Number, false).Second()))
+	return newNumberFormulaArg(float64(fn.serialToTime(num.Number).Second()))
 }
 
 // TIME function accepts three integer arguments representing hours, minutes
@@ -13248,7 +13844,7 @@ func (fn *formulaFuncs) TODAY(argsList *list.List) formulaArg {
 	}
 	now := time.Now()
 	_, offset := now.Zone()
-	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), now.Unix()+int64(offset)) + 1)
+	return newNumberFormulaArg(fn.dateSerial(daysBetween(excelMinTime1900.Unix(), now.Unix()+int64(offset)) + 1))
 }
 
 // makeDate return date as a Unix time, the number of seconds elapsed since
@@ -13261,6 +13857,38 @@ func makeDate(y int, m time.Month, d int) int64 {
 	return date.Unix()
 }
 
+// date1904 returns true if the workbook of the formula uses the 1904 date
+// system.
+func (fn *formulaFuncs) date1904() bool {
+	if fn.f == nil {
+		return false
+	}
+	date1904, _ := fn.f.getDate1904()
+	return date1904
+}
+
+// dateSerial converts the serial date number in the 1900 date system to the
+// date system of the workbook of the formula.
+func (fn *formulaFuncs) dateSerial(serial float64) float64 {
+	if fn.date1904() {
+		return serial - date1904Offset
+	}
+	return serial
+}
+
+// serialToTime converts the serial date number in the date system of the
+// workbook of the formula to the time.
+func (fn *formulaFuncs) serialToTime(serial float64) time.Time {
+	return timeFromExcelTime(serial, fn.date1904())
+}
+
+// timeToSerial converts the time to the serial date number in the date system
+// of the workbook of the formula.
+func (fn *formulaFuncs) timeToSerial(t time.Time) float64 {
+	serial, _ := timeToExcelTime(t, fn.date1904())
+	return serial
+}
+
 // daysBetween return time interval of the given start timestamp and end
 // timestamp.
 func daysBetween(startDate, endDate int64) float64 {
@@ -13297,7 +13925,7 @@ func (fn *formulaFuncs) WEEKDAY(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		weekday = int(timeFromExcelTime(num.Number, false).Weekday())
+		weekday = int(fn.serialToTime(num.Number).Weekday())
 	}
 	if argsList.Len() == 2 {
 		returnTypeArg := argsList.Back().Value.(formulaArg).ToNumber()
@@ -13389,7 +14017,7 @@ func (fn *formulaFuncs) WEEKNUM(argsList *list.List) formulaArg {
 		if num.Number < 0 {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
-		snTime = timeFromExcelTime(num.Number, false)
+		snTime = fn.serialToTime(num.Number)
 	}
 	if argsList.Len() == 2 {
 		returnTypeArg := argsList.Back().Value.(formulaArg).ToNumber()
@@ -14064,7 +14692,7 @@ func (fn *formulaFuncs) TEXT(argsList *list.List) formulaArg {
 	if num := value.ToNumber(); num.Type != ArgNumber {
 		cellType = CellTypeSharedString
 	}
-	return newStringFormulaArg(format(value.Value(), fmtText.Value(), false, cellType, nil))
+	return newStringFormulaArg(format(value.Value(), fmtText.Value(), fn.date1904(), cellType, nil))
 }
 
 // prepareTextAfterBefore checking and prepare arguments for the formula
@@ -14236,16 +14864,32 @@ func (fn *formulaFuncs) TEXTJOIN(argsList *list.List) formulaArg {
 	if argsList.Len() > 252 {
 		return newErrorFormulaArg(formulaErrorVALUE, "TEXTJOIN accepts at most 252 arguments")
 	}
-	delimiter := argsList.Front().Value.(formulaArg)
+	var delimiters []string
+	for _, delimiter := range argsList.Front().Value.(formulaArg).ToList() {
+		if delimiter.Type == ArgError {
+			return delimiter
+		}
+		delimiters = append(delimiters, delimiter.Value())
+	}
 	ignoreEmpty := argsList.Front().Next().Value.(formulaArg)
-	if ignoreEmpty.Type != ArgNumber || !ignoreEmpty.Boolean {
+	if ignoreEmpty.Type == ArgString {
+		ignoreEmpty = ignoreEmpty.ToBool()
+	}
+	if ignoreEmpty.Type != ArgNumber {
 		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
 	}
 	args, ok := textJoin(argsList.Front().Next().Next(), []string{}, ignoreEmpty.Number != 0)
 	if ok.Type != ArgNumber {
 		return ok
 	}
-	result := strings.Join(args, delimiter.Value())
+	var buf strings.Builder
+	for i, arg := range args {
+		if i > 0 && len(delimiters) > 0 {
+			buf.WriteString(delimiters[(i-1)%len(delimiters)])
+		}
+		buf.WriteString(arg)
+	}
+	result := buf.String()
 	if len(result) > TotalCellChars {
 		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("TEXTJOIN function exceeds %d characters", TotalCellChars))
 	}
@@ -14281,6 +14925,115 @@ func textJoin(arg *list.Element, arr []string, ignoreEmpty bool) ([]string, form
 	return arr, newBoolFormulaArg(true)
 }
 
+// TEXTSPLIT function splits text strings by using column and row delimiters,
+// and returns the result as an array which spilled across the rows and
+// columns. The syntax of the function is:
+//
+//	TEXTSPLIT(text,col_delimiter,[row_delimiter],[ignore_empty],[match_mode],[pad_with])
+func (fn *formulaFuncs) TEXTSPLIT(argsList *list.List) formulaArg {
+	if argsList.Len() < 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, "TEXTSPLIT requires at least 2 arguments")
+	}
+	if argsList.Len() > 6 {
+		return newErrorFormulaArg(formulaErrorVALUE, "TEXTSPLIT allows at most 6 arguments")
+	}
+	text := argsList.Front().Value.(formulaArg)
+	if text.Type == ArgError {
+		return text
+	}
+	args := []formulaArg{newEmptyFormulaArg(), newEmptyFormulaArg(), newBoolFormulaArg(false), newNumberFormulaArg(0), newErrorFormulaArg(formulaErrorNA, formulaErrorNA)}
+	for i, arg := 0, argsList.Front().Next(); arg != nil; i, arg = i+1, arg.Next() {
+		if arg.Value.(formulaArg).Type == ArgError {
+			return arg.Value.(formulaArg)
+		}
+		if arg.Value.(formulaArg).Type != ArgEmpty {
+			args[i] = arg.Value.(formulaArg)
+		}
+	}
+	colDelimiters, rowDelimiters := textSplitDelimiters(args[0]), textSplitDelimiters(args[1])
+	if len(colDelimiters) == 0 && len(rowDelimiters) == 0 {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	ignoreEmpty := args[2]
+	if ignoreEmpty.Type == ArgString {
+		ignoreEmpty = ignoreEmpty.ToBool()
+	}
+	matchMode := args[3].ToNumber()
+	if ignoreEmpty.Type != ArgNumber || matchMode.Type != ArgNumber || (matchMode.Number != 0 && matchMode.Number != 1) {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	var (
+		matrix [][]formulaArg
+		cols   int
+	)
+	for _, row := range textSplit(text.Value(), rowDelimiters, ignoreEmpty.Number != 0, matchMode.Number == 1) {
+		var cells []formulaArg
+		for _, cell := range textSplit(row, colDelimiters, ignoreEmpty.Number != 0, matchMode.Number == 1) {
+			cells = append(cells, newStringFormulaArg(cell))
+		}
+		if len(cells) > cols {
+			cols = len(cells)
+		}
+		matrix = append(matrix, cells)
+	}
+	if cols == 0 {
+		return newErrorFormulaArg(formulaErrorCALC, formulaErrorCALC)
+	}
+	for i := range matrix {
+		for len(matrix[i]) < cols {
+			matrix[i] = append(matrix[i], args[4])
+		}
+	}
+	return newMatrixFormulaArg(matrix)
+}
+
+// textSplitDelimiters returns the non-empty delimiters list by given the
+// delimiter argument of the formula function TEXTSPLIT.
+func textSplitDelimiters(arg formulaArg) []string {
+	var delimiters []string
+	for _, delimiter := range arg.ToList() {
+		if val := delimiter.Value(); val != "" && delimiter.Type != ArgEmpty {
+			delimiters = append(delimiters, val)
+		}
+	}
+	return delimiters
+}
+
+// textSplit splits the text by given delimiters, the longest delimiter will
+// be matched first if more than one delimiter found at the same position.
+// This is a helper function for the formula function TEXTSPLIT.
+func textSplit(text string, delimiters []string, ignoreEmpty, caseInsensitive bool) []string {
+	var parts []string
+	appendPart := func(part string) {
+		if part != "" || !ignoreEmpty {
+			parts = append(parts, part)
+		}
+	}
+	if len(delimiters) == 0 {
+		appendPart(text)
+		return parts
+	}
+	start := 0
+	for i := 0; i < len(text); {
+		var matched int
+		for _, delimiter := range delimiters {
+			if n := len(delimiter); n > matched && i+n <= len(text) &&
+				(text[i:i+n] == delimiter || (caseInsensitive && strings.EqualFold(text[i:i+n], delimiter))) {
+				matched = n
+			}
+		}
+		if matched == 0 {
+			i++
+			continue
+		}
+		appendPart(text[start:i])
+		i += matched
+		start = i
+	}
+	appendPart(text[start:])
+	return parts
+}
+
 // TRIM removes extra spaces (i.e. all spaces except for single spaces between
 // words or characters) from a supplied text string. The syntax of the
 // function is:
@@ -14359,7 +15112,7 @@ func (fn *formulaFuncs) VALUE(argsList *list.List) formulaArg {
 	y, m, d, _, err := strToDate(text)
 	errDate = err.Type == ArgError
 	if !errDate {
-		dateValue = daysBetween(excelMinTime1900.Unix(), makeDate(y, time.Month(m), d)) + 1
+		dateValue = fn.dateSerial(daysBetween(excelMinTime1900.Unix(), makeDate(y, time.Month(m), d)) + 1)
 	}
 	if errTime && errDate {
 		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
@@ -14914,6 +15667,72 @@ func (fn *formulaFuncs) MATCH(argsList *list.List) formulaArg {
 	return calcMatch(matchType, formulaCriteriaParser(argsList.Front().Value.(formulaArg)), lookupArray)
 }
 
+// OFFSET function returns a reference to a range that is a specified number
+// of rows and columns from a cell or range of cells, the height and width of
+// the returned reference default to the size of the given reference. The
+// syntax of the function is:
+//
+//	OFFSET(reference,rows,cols,[height],[width])
+func (fn *formulaFuncs) OFFSET(argsList *list.List) formulaArg {
+	if argsList.Len() < 3 || argsList.Len() > 5 {
+		return newErrorFormulaArg(formulaErrorVALUE, "OFFSET requires 3 to 5 arguments")
+	}
+	ref := argsList.Front().Value.(formulaArg)
+	var from, to cellRef
+	if ref.cellRanges != nil && ref.cellRanges.Len() > 0 {
+		cr := ref.cellRanges.Front().Value.(cellRange)
+		from, to = cr.From, cr.To
+	} else if ref.cellRefs != nil && ref.cellRefs.Len() > 0 {
+		from = ref.cellRefs.Front().Value.(cellRef)
+		to = from
+	} else {
+		return newErrorFormulaArg(formulaErrorVALUE, "invalid reference")
+	}
+	if from.Row > to.Row {
+		from.Row, to.Row = to.Row, from.Row
+	}
+	if from.Col > to.Col {
+		from.Col, to.Col = to.Col, from.Col
+	}
+	params := []float64{0, 0, float64(to.Row - from.Row + 1), float64(to.Col - from.Col + 1)}
+	idx := 0
+	for arg := argsList.Front().Next(); arg != nil; arg = arg.Next() {
+		if arg.Value.(formulaArg).Type == ArgError {
+			return arg.Value.(formulaArg)
+		}
+		if idx < 2 || arg.Value.(formulaArg).Type != ArgEmpty {
+			num := arg.Value.(formulaArg).ToNumber()
+			if num.Type != ArgNumber {
+				return num
+			}
+			params[idx] = math.Trunc(num.Number)
+		}
+		idx++
+	}
+	if params[2] < 1 || params[3] < 1 {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+	}
+	fromRow, fromCol := from.Row+int(params[0]), from.Col+int(params[1])
+	toRow, toCol := fromRow+int(params[2])-1, fromCol+int(params[3])-1
+	if fromRow < 1 || fromCol < 1 || toRow > TotalRows || toCol > MaxColumns {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+	}
+	reference, _ := CoordinatesToCellName(fromCol, fromRow)
+	if toRow != fromRow || toCol != fromCol {
+		toCell, _ := CoordinatesToCellName(toCol, toRow)
+		reference += ":" + toCell
+	}
+	sheet := fn.sheet
+	if from.Sheet != "" {
+		sheet = from.Sheet
+	}
+	arg, err := fn.f.parseReference(fn.ctx, sheet, reference)
+	if err != nil {
+		return newErrorFormulaArg(formulaErrorREF, err.Error())
+	}
+	return arg
+}
+
 // TRANSPOSE function 'transposes' an array of cells (i.e. the function copies
 // a horizontal range of cells into a vertical range and vice versa). The
 // syntax of the function is:
@@ -15368,14 +16187,13 @@ func (fn *formulaFuncs) INDIRECT(argsList *list.List) formulaArg {
 			toRef = to
 		}
 	}
-	if len(refs) == 1 {
-		value, err := fn.f.GetCellValue(fn.sheet, fromRef)
-		if err != nil {
-			return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
-		}
-		return newStringFormulaArg(value)
+	if len(refs) == 2 {
+		fromRef += ":" + toRef
+	}
+	arg, err := fn.f.parseReference(fn.ctx, fn.sheet, fromRef)
+	if err != nil {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
 	}
-	arg, _ := fn.f.parseReference(fn.ctx, fn.sheet, fromRef+":"+toRef)
 	return arg
 }
 
@@ -15524,7 +16342,7 @@ func (fn *formulaFuncs) ACCRINT(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
 		}
 	}
-	frac1 := yearFrac(issue.Number, settlement.Number, int(basis.Number))
+	frac1 := fn.yearFrac(issue.Number, settlement.Number, int(basis.Number))
 	if frac1.Type != ArgNumber {
 		return frac1
 	}
@@ -15561,7 +16379,7 @@ func (fn *formulaFuncs) ACCRINTM(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(issue.Number, settlement.Number, int(basis.Number))
+	frac := fn.yearFrac(issue.Number, settlement.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -15643,7 +16461,7 @@ func (fn *formulaFuncs) AMORDEGRC(argsList *list.List) formulaArg {
 		amorCoeff = 2
 	}
 	rate.Number *= amorCoeff
-	frac := yearFrac(datePurchased.Number, firstPeriod.Number, int(basis.Number))
+	frac := fn.yearFrac(datePurchased.Number, firstPeriod.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -15681,7 +16499,7 @@ func (fn *formulaFuncs) AMORLINC(argsList *list.List) formulaArg {
 		return args
 	}
 	cost, datePurchased, firstPeriod, salvage, period, rate, basis := args.List[0], args.List[1], args.List[2], args.List[3], args.List[4], args.List[5], args.List[6]
-	frac := yearFrac(datePurchased.Number, firstPeriod.Number, int(basis.Number))
+	frac := fn.yearFrac(datePurchased.Number, firstPeriod.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -15806,8 +16624,8 @@ func (fn *formulaFuncs) COUPDAYBS(argsList *list.List) formulaArg {
 	if args.Type != ArgList {
 		return args
 	}
-	settlement := timeFromExcelTime(args.List[0].Number, false)
-	pcd := timeFromExcelTime(fn.COUPPCD(argsList).Number, false)
+	settlement := fn.serialToTime(args.List[0].Number)
+	pcd := fn.serialToTime(fn.COUPPCD(argsList).Number)
 	return newNumberFormulaArg(coupdays(pcd, settlement, int(args.List[3].Number)))
 }
 
@@ -15823,7 +16641,7 @@ func (fn *formulaFuncs) COUPDAYS(argsList *list.List) formulaArg {
 	freq := args.List[2].Number
 	basis := int(args.List[3].Number)
 	if basis == 1 {
-		pcd := timeFromExcelTime(fn.COUPPCD(argsList).Number, false)
+		pcd := fn.serialToTime(fn.COUPPCD(argsList).Number)
 		next := pcd.AddDate(0, 12/int(freq), 0)
 		return newNumberFormulaArg(coupdays(pcd, next, basis))
 	}
@@ -15839,16 +16657,16 @@ func (fn *formulaFuncs) COUPDAYSNC(argsList *list.List) formulaArg {
 	if args.Type != ArgList {
 		return args
 	}
-	settlement := timeFromExcelTime(args.List[0].Number, false)
+	settlement := fn.serialToTime(args.List[0].Number)
 	basis := int(args.List[3].Number)
-	ncd := timeFromExcelTime(fn.COUPNCD(argsList).Number, false)
+	ncd := fn.serialToTime(fn.COUPNCD(argsList).Number)
 	return newNumberFormulaArg(coupdays(settlement, ncd, basis))
 }
 
 // coupons is an implementation of the formula functions COUPNCD and COUPPCD.
 func (fn *formulaFuncs) coupons(name string, arg formulaArg) formulaArg {
-	settlement := timeFromExcelTime(arg.List[0].Number, false)
-	maturity := timeFromExcelTime(arg.List[1].Number, false)
+	settlement := fn.serialToTime(arg.List[0].Number)
+	maturity := fn.serialToTime(arg.List[1].Number)
 	maturityDays := (maturity.Year()-settlement.Year())*12 + (int(maturity.Month()) - int(settlement.Month()))
 	coupon := 12 / int(arg.List[2].Number)
 	mod := maturityDays % coupon
@@ -15876,7 +16694,7 @@ func (fn *formulaFuncs) coupons(name string, arg formulaArg) formulaArg {
 	} else if day > 27 && day > days {
 		day = days
 	}
-	return newNumberFormulaArg(daysBetween(excelMinTime1900.Unix(), makeDate(year, time.Month(month), day)) + 1)
+	return newNumberFormulaArg(fn.dateSerial(daysBetween(excelMinTime1900.Unix(), makeDate(year, time.Month(month), day)) + 1))
 }
 
 // COUPNCD function calculates the number of coupons payable, between a
@@ -15902,7 +16720,7 @@ func (fn *formulaFuncs) COUPNUM(argsList *list.List) formulaArg {
 	if args.Type != ArgList {
 		return args
 	}
-	frac := yearFrac(args.List[0].Number, args.List[1].Number, 0)
+	frac := fn.yearFrac(args.List[0].Number, args.List[1].Number, 0)
 	return newNumberFormulaArg(math.Ceil(frac.Number * args.List[2].Number))
 }
 
@@ -16170,7 +16988,7 @@ func (fn *formulaFuncs) discIntrate(name string, argsList *list.List) formulaArg
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := fn.yearFrac(settlement.Number, maturity.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -16281,7 +17099,7 @@ func (fn *formulaFuncs) prepareDurationArgs(name string, argsList *list.List) fo
 
 // duration is an implementation of the formula function DURATION.
 func (fn *formulaFuncs) duration(settlement, maturity, coupon, yld, frequency, basis formulaArg) formulaArg {
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := fn.yearFrac(settlement.Number, maturity.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -16899,8 +17717,8 @@ func datesAggregate(startDate, endDate time.Time, numMonths float64, f func(pcd,
 }
 
 // coupNumber is a part of implementation of the formula function ODDFPRICE.
-func coupNumber(maturity, settlement, numMonths float64) float64 {
-	maturityTime, settlementTime := timeFromExcelTime(maturity, false), timeFromExcelTime(settlement, false)
+func (fn *formulaFuncs) coupNumber(maturity, settlement, numMonths float64) float64 {
+	maturityTime, settlementTime := fn.serialToTime(maturity), fn.serialToTime(settlement)
 	my, mm, md := maturityTime.Year(), maturityTime.Month(), maturityTime.Day()
 	sy, sm, sd := settlementTime.Year(), settlementTime.Month(), settlementTime.Day()
 	couponsTemp, endOfMonthTemp := 0.0, getDaysInMonth(my, int(mm)) == md
@@ -17004,10 +17822,10 @@ func (fn *formulaFuncs) ODDFPRICE(argsList *list.List) formulaArg {
 	if basisArg.Number < 0 || basisArg.Number > 4 {
 		return newErrorFormulaArg(formulaErrorNUM, "invalid basis")
 	}
-	issueTime := timeFromExcelTime(issue.Number, false)
-	settlementTime := timeFromExcelTime(settlement.Number, false)
-	maturityTime := timeFromExcelTime(maturity.Number, false)
-	firstCouponTime := timeFromExcelTime(firstCoupon.Number, false)
+	issueTime := fn.serialToTime(issue.Number)
+	settlementTime := fn.serialToTime(settlement.Number)
+	maturityTime := fn.serialToTime(maturity.Number)
+	firstCouponTime := fn.serialToTime(firstCoupon.Number)
 	basis := int(basisArg.Number)
 	monthDays := getDaysInMonth(maturityTime.Year(), int(maturityTime.Month()))
 	returnLastMonth := monthDays == maturityTime.Day()
@@ -17053,9 +17871,9 @@ func (fn *formulaFuncs) ODDFPRICE(argsList *list.List) formulaArg {
 	nc := fn.COUPNUM(fnArgs)
 	lastCoupon := firstCoupon.Number
 	aggrFunc := func(acc []float64, index float64) []float64 {
-		lastCouponTime := timeFromExcelTime(lastCoupon, false)
-		earlyCoupon := daysBetween(excelMinTime1900.Unix(), makeDate(lastCouponTime.Year(), time.Month(float64(lastCouponTime.Month())+numMonthsNeg), lastCouponTime.Day())) + 1
-		earlyCouponTime := timeFromExcelTime(earlyCoupon, false)
+		lastCouponTime := fn.serialToTime(lastCoupon)
+		earlyCoupon := fn.dateSerial(daysBetween(excelMinTime1900.Unix(), makeDate(lastCouponTime.Year(), time.Month(float64(lastCouponTime.Month())+numMonthsNeg), lastCouponTime.Day())) + 1)
+		earlyCouponTime := fn.serialToTime(earlyCoupon)
 		nl := e.Number
 		if basis == 1 {
 			nl = coupdays(earlyCouponTime, lastCouponTime, basis)
@@ -17072,8 +17890,8 @@ func (fn *formulaFuncs) ODDFPRICE(argsList *list.List) formulaArg {
 		if settlement.Number < lastCoupon {
 			endDate = settlement.Number
 		}
-		startDateTime := timeFromExcelTime(startDate, false)
-		endDateTime := timeFromExcelTime(endDate, false)
+		startDateTime := fn.serialToTime(startDate)
+		endDateTime := fn.serialToTime(endDate)
 		a := coupdays(startDateTime, endDateTime, basis)
 		lastCoupon = earlyCoupon
 		dcnl := acc[0]
@@ -17088,14 +17906,14 @@ func (fn *formulaFuncs) ODDFPRICE(argsList *list.List) formulaArg {
 	fnArgs.PushBack(firstCoupon)
 	fnArgs.PushBack(frequency)
 	if basis == 2 || basis == 3 {
-		d := timeFromExcelTime(fn.COUPNCD(fnArgs).Number, false)
+		d := fn.serialToTime(fn.COUPNCD(fnArgs).Number)
 		dsc = coupdays(settlementTime, d, basis)
 	} else {
-		d := timeFromExcelTime(fn.COUPPCD(fnArgs).Number, false)
+		d := fn.serialToTime(fn.COUPPCD(fnArgs).Number)
 		a := coupdays(d, settlementTime, basis)
 		dsc = e.Number - a
 	}
-	nq := coupNumber(firstCoupon.Number, settlement.Number, numMonths)
+	nq := fn.coupNumber(firstCoupon.Number, settlement.Number, numMonths)
 	fnArgs.Init()
 	fnArgs.PushBack(firstCoupon)
 	fnArgs.PushBack(maturity)
@@ -17148,8 +17966,8 @@ func (fn *formulaFuncs) ODDFYIELD(argsList *list.List) formulaArg {
 	if basisArg.Number < 0 || basisArg.Number > 4 {
 		return newErrorFormulaArg(formulaErrorNUM, "invalid basis")
 	}
-	settlementTime := timeFromExcelTime(settlement.Number, false)
-	maturityTime := timeFromExcelTime(maturity.Number, false)
+	settlementTime := fn.serialToTime(settlement.Number)
+	maturityTime := fn.serialToTime(maturity.Number)
 	years := coupdays(settlementTime, maturityTime, int(basisArg.Number))
 	px := pr.Number - 100
 	num := rate.Number*years*100 - px
@@ -17235,8 +18053,8 @@ func (fn *formulaFuncs) oddl(name string, argsList *list.List) formulaArg {
 	if basisArg.Number < 0 || basisArg.Number > 4 {
 		return newErrorFormulaArg(formulaErrorNUM, "invalid basis")
 	}
-	settlementTime := timeFromExcelTime(settlement.Number, false)
-	maturityTime := timeFromExcelTime(maturity.Number, false)
+	settlementTime := fn.serialToTime(settlement.Number)
+	maturityTime := fn.serialToTime(maturity.Number)
 	basis := int(basisArg.Number)
 	numMonths := 12 / frequency.Number
 	fnArgs := list.New().Init()
@@ -17247,9 +18065,9 @@ func (fn *formulaFuncs) oddl(name string, argsList *list.List) formulaArg {
 	nc := fn.COUPNUM(fnArgs)
 	earlyCoupon := lastInterest.Number
 	aggrFunc := func(acc []float64, index float64) []float64 {
-		earlyCouponTime := timeFromExcelTime(earlyCoupon, false)
+		earlyCouponTime := fn.serialToTime(earlyCoupon)
 		lateCouponTime := changeMonth(earlyCouponTime, numMonths, false)
-		lateCoupon, _ := timeToExcelTime(lateCouponTime, false)
+		lateCoupon := fn.timeToSerial(lateCouponTime)
 		nl := coupdays(earlyCouponTime, lateCouponTime, basis)
 		dci := coupdays(earlyCouponTime, maturityTime, basis)
 		if index < nc.Number {
@@ -17269,8 +18087,8 @@ func (fn *formulaFuncs) oddl(name string, argsList *list.List) formulaArg {
 		if maturity.Number < lateCoupon {
 			endDate = maturity.Number
 		}
-		startDateTime := timeFromExcelTime(startDate, false)
-		endDateTime := timeFromExcelTime(endDate, false)
+		startDateTime := fn.serialToTime(startDate)
+		endDateTime := fn.serialToTime(endDate)
 		dsc := coupdays(startDateTime, endDateTime, basis)
 		earlyCoupon = lateCoupon
 		dcnl := acc[0]
@@ -17535,7 +18353,7 @@ func (fn *formulaFuncs) PRICEDISC(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := fn.yearFrac(settlement.Number, maturity.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -17581,12 +18399,12 @@ func (fn *formulaFuncs) PRICEMAT(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	dsm := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	dsm := fn.yearFrac(settlement.Number, maturity.Number, int(basis.Number))
 	if dsm.Type != ArgNumber {
 		return dsm
 	}
-	dis := yearFrac(issue.Number, settlement.Number, int(basis.Number))
-	dim := yearFrac(issue.Number, maturity.Number, int(basis.Number))
+	dis := fn.yearFrac(issue.Number, settlement.Number, int(basis.Number))
+	dim := fn.yearFrac(issue.Number, maturity.Number, int(basis.Number))
 	return newNumberFormulaArg(((1+dim.Number*rate.Number)/(1+dsm.Number*yld.Number) - dis.Number*rate.Number) * 100)
 }
 
@@ -17738,7 +18556,7 @@ func (fn *formulaFuncs) RECEIVED(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := fn.yearFrac(settlement.Number, maturity.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -18264,7 +19082,7 @@ func (fn *formulaFuncs) YIELDDISC(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	frac := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	frac := fn.yearFrac(settlement.Number, maturity.Number, int(basis.Number))
 	if frac.Type != ArgNumber {
 		return frac
 	}
@@ -18316,12 +19134,12 @@ func (fn *formulaFuncs) YIELDMAT(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 		}
 	}
-	dim := yearFrac(issue.Number, maturity.Number, int(basis.Number))
+	dim := fn.yearFrac(issue.Number, maturity.Number, int(basis.Number))
 	if dim.Type != ArgNumber {
 		return dim
 	}
-	dis := yearFrac(issue.Number, settlement.Number, int(basis.Number))
-	dsm := yearFrac(settlement.Number, maturity.Number, int(basis.Number))
+	dis := fn.yearFrac(issue.Number, settlement.Number, int(basis.Number))
+	dsm := fn.yearFrac(settlement.Number, maturity.Number, int(basis.Number))
 	f1 := dim.Number * rate.Number
 	result := 1 + math.Nextafter(f1, f1)
 	result /= pr.Number/100 + dis.Number*rate.Number
@@ -2,6 +2,8 @@ package excelize
 
 import (
 	"container/list"
+	"errors"
+	"fmt"
 	"math"
 	"path/filepath"
 	"strings"
@@ -106,6 +108,7 @@ func TestCalcCellValue(t *testing.T) {
 		"=BIN2DEC(\"1111111110\")": "-2",
 		"=BIN2DEC(\"110\")":        "6",
 		"=BIN2DEC({\"110\"})":      "6",
+		"=BIN2DEC(1111111111)":     "-1",
 		// BIN2HEX
 		"=BIN2HEX(\"10\")":         "2",
 		"=BIN2HEX(\"0000000001\")": "1",
@@ -176,7 +179,12 @@ func TestCalcCellValue(t *testing.T) {
 		"=CONVERT(1.23450000000000E+05,\"ang\",\"um\")":  "12.345",
 		"=CONVERT(1.23450000000000E+02,\"kang\",\"um\")": "12.345",
 		"=CONVERT(1000,\"dal\",\"hl\")":                  "100",
-		"=CONVERT(1,\"yd\",\"ft\")":                      "2.99999999999999",
+		"=CONVERT(1,\"yd\",\"ft\")":                      "3",
+		"=CONVERT(1,\"mm^3\",\"l\")":                     "1E-06",
+		"=CONVERT(1,\"km^2\",\"m^2\")":                   "1000000",
+		"=CONVERT(1,\"kWh\",\"J\")":                      "3600000",
+		"=CONVERT(1,\"lbm\",\"kg\")":                     "0.45359237",
+		"=CONVERT(1,\"day\",\"sec\")":                    "86400",
 		"=CONVERT(20,\"C\",\"F\")":                       "68",
 		"=CONVERT(68,\"F\",\"C\")":                       "20",
 		"=CONVERT(293.15,\"K\",\"F\")":                   "68",
@@ -254,6 +262,8 @@ func TestCalcCellValue(t *testing.T) {
 		"=HEX2DEC(\"FFFFFFFFF0\")": "-16",
 		"=HEX2DEC(\"111\")":        "273",
 		"=HEX2DEC(\"\")":           "0",
+		"=HEX2DEC(\"8000000000\")": "-549755813888",
+		"=HEX2DEC(\"ffffffffff\")": "-1",
 		// HEX2OCT
 		"=HEX2OCT(\"A\")":          "12",
 		"=HEX2OCT(\"000000000F\")": "17",
@@ -376,6 +386,7 @@ func TestCalcCellValue(t *testing.T) {
 		"=OCT2DEC(\"0000000010\")": "8",
 		"=OCT2DEC(\"7777777770\")": "-8",
 		"=OCT2DEC(\"355\")":        "237",
+		"=OCT2DEC(\"4000000000\")": "-536870912",
 		// OCT2HEX
 		"=OCT2HEX(\"10\")":         "8",
 		"=OCT2HEX(\"0000000007\")": "7",
@@ -1873,11 +1884,24 @@ func TestCalcCellValue(t *testing.T) {
 		"=TEXTBEFORE(\"ABX-123-Red-XYZ\",\"-\",4,0,1)":                        "ABX-123-Red-XYZ",
 		"=TEXTBEFORE(\"ABX-112-Red-Y\",\"A\")":                                "",
 		// TEXTJOIN
-		"=TEXTJOIN(\"-\",TRUE,1,2,3,4)":  "1-2-3-4",
-		"=TEXTJOIN(A4,TRUE,A1:B2)":       "1040205",
-		"=TEXTJOIN(\",\",FALSE,A1:C2)":   "1,4,,2,5,",
-		"=TEXTJOIN(\",\",TRUE,A1:C2)":    "1,4,2,5",
-		"=TEXTJOIN(\",\",TRUE,MUNIT(2))": "1,0,0,1",
+		"=TEXTJOIN(\"-\",TRUE,1,2,3,4)":       "1-2-3-4",
+		"=TEXTJOIN(A4,TRUE,A1:B2)":            "1040205",
+		"=TEXTJOIN(\",\",FALSE,A1:C2)":        "1,4,,2,5,",
+		"=TEXTJOIN(\",\",TRUE,A1:C2)":         "1,4,2,5",
+		"=TEXTJOIN(\",\",TRUE,MUNIT(2))":      "1,0,0,1",
+		"=TEXTJOIN(\",\",1,\"a\",\"\",\"b\")": "a,b",
+		"=TEXTJOIN(\",\",\"TRUE\",A1:C1)":     "1,4",
+		// TEXTSPLIT
+		"=TEXTSPLIT(\"a,b;c\",\",\",\";\")":                                     "a",
+		"=INDEX(TEXTSPLIT(\"a,b;c,d\",\",\",\";\"),2,2)":                        "d",
+		"=INDEX(TEXTSPLIT(\"a,b;c\",\",\",\";\",,,\"-\"),2,2)":                  "-",
+		"=TEXTJOIN(\"|\",FALSE,TEXTSPLIT(\"a,,b\",\",\"))":                      "a||b",
+		"=TEXTJOIN(\"|\",FALSE,TEXTSPLIT(\"a,,b\",\",\",,TRUE))":                "a|b",
+		"=TEXTJOIN(\"|\",FALSE,TEXTSPLIT(\"a;;b\",,\";\",\"TRUE\"))":            "a|b",
+		"=TEXTJOIN(\"|\",FALSE,TEXTSPLIT(\"aXbxc\",\"x\"))":                     "aXb|c",
+		"=TEXTJOIN(\"|\",FALSE,TEXTSPLIT(\"aXbxc\",\"x\",,,1))":                 "a|b|c",
+		"=TEXTJOIN(\"|\",FALSE,TEXTSPLIT(\"a, b,c\",\", \"))":                   "a|b,c",
+		"=TEXTJOIN(\"|\",FALSE,TEXTSPLIT(\"a,b;c\",\",\",\";\",FALSE,0,\"z\"))": "a|b|c|z",
 		// TRIM
 		"=TRIM(\" trim text \")": "trim text",
 		"=TRIM(0)":               "0",
@@ -2011,6 +2035,23 @@ func TestCalcCellValue(t *testing.T) {
 		"=LOOKUP(F4+1,F3:F4,F3:F4)":    "53321",
 		"=LOOKUP(1,MUNIT(1))":          "1",
 		"=LOOKUP(1,MUNIT(1),MUNIT(1))": "1",
+		// OFFSET
+		"=OFFSET(A1,1,0)":                      "2",
+		"=OFFSET(A1,0,1)":                      "4",
+		"=OFFSET(Sheet1!A1,3,4)":               "South 1",
+		"=SUM(OFFSET(A1,0,0,2,2))":             "12",
+		"=SUM(OFFSET(A1:B2,1,0))":              "10",
+		"=SUM(OFFSET(B2:A1,1,0))":              "10",
+		"=SUM(OFFSET(A1,1,0,3))":               "5",
+		"=SUM(OFFSET(Sheet1!F1,1,0,8))":        "304113",
+		"=COUNT(OFFSET(F1,0,0,9))":             "8",
+		"=ROW(OFFSET(A1,4,1))":                 "5",
+		"=COLUMN(OFFSET(A1,4,1))":              "2",
+		"=ROWS(OFFSET(A1,0,0,3,2))":            "3",
+		"=COLUMNS(OFFSET(A1,0,0,3,2))":         "2",
+		"=INDIRECT(\"Sheet1!E2\")":             "North 1",
+		"=ROW(INDIRECT(\"B3\"))":               "3",
+		"=SUM(OFFSET(INDIRECT(\"A1\"),1,0,2))": "5",
 		// ROW
 		"=ROW()":                "1",
 		"=ROW(Sheet1!A1)":       "1",
@@ -2266,8 +2307,9 @@ func TestCalcCellValue(t *testing.T) {
 		"=BESSELY(-1,0)":   {"#NUM!", "#NUM!"},
 		"=BESSELY(1,-1)":   {"#NUM!", "#NUM!"},
 		// BIN2DEC
-		"=BIN2DEC()":     {"#VALUE!", "BIN2DEC requires 1 numeric argument"},
-		"=BIN2DEC(\"\")": {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=BIN2DEC()":                {"#VALUE!", "BIN2DEC requires 1 numeric argument"},
+		"=BIN2DEC(\"\")":            {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=BIN2DEC(\"10000000000\")": {"#NUM!", "#NUM!"},
 		// BIN2HEX
 		"=BIN2HEX()":               {"#VALUE!", "BIN2HEX requires at least 1 argument"},
 		"=BIN2HEX(1,1,1)":          {"#VALUE!", "BIN2HEX allows at most 2 arguments"},
@@ -2402,8 +2444,9 @@ func TestCalcCellValue(t *testing.T) {
 		"=HEX2BIN(1,-1)":    {"#NUM!", "#NUM!"},
 		"=HEX2BIN(2,1)":     {"#NUM!", "#NUM!"},
 		// HEX2DEC
-		"=HEX2DEC()":      {"#VALUE!", "HEX2DEC requires 1 numeric argument"},
-		"=HEX2DEC(\"X\")": {"#NUM!", "strconv.ParseInt: parsing \"X\": invalid syntax"},
+		"=HEX2DEC()":                {"#VALUE!", "HEX2DEC requires 1 numeric argument"},
+		"=HEX2DEC(\"X\")":           {"#NUM!", "strconv.ParseInt: parsing \"X\": invalid syntax"},
+		"=HEX2DEC(\"10000000000\")": {"#NUM!", "#NUM!"},
 		// HEX2OCT
 		"=HEX2OCT()":        {"#VALUE!", "HEX2OCT requires at least 1 argument"},
 		"=HEX2OCT(1,1,1)":   {"#VALUE!", "HEX2OCT allows at most 2 arguments"},
@@ -2505,8 +2548,10 @@ func TestCalcCellValue(t *testing.T) {
 		"=OCT2BIN(-536870912 ,10)": {"#NUM!", "#NUM!"},
 		"=OCT2BIN(1,-1)":           {"#NUM!", "#NUM!"},
 		// OCT2DEC
-		"=OCT2DEC()":     {"#VALUE!", "OCT2DEC requires 1 numeric argument"},
-		"=OCT2DEC(\"\")": {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=OCT2DEC()":                {"#VALUE!", "OCT2DEC requires 1 numeric argument"},
+		"=OCT2DEC(\"\")":            {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=OCT2DEC(\"10000000000\")": {"#NUM!", "#NUM!"},
+		"=OCT2DEC(\"18\")":          {"#NUM!", "#NUM!"},
 		// OCT2HEX
 		"=OCT2HEX()":               {"#VALUE!", "OCT2HEX requires at least 1 argument"},
 		"=OCT2HEX(1,1,1)":          {"#VALUE!", "OCT2HEX allows at most 2 arguments"},
@@ -3932,6 +3977,16 @@ func TestCalcCellValue(t *testing.T) {
 		"=TEXTJOIN(\"\",TRUE,NA())": {"#N/A", "#N/A"},
 		"=TEXTJOIN(\"\",TRUE," + strings.Repeat("0,", 250) + ",0)": {"#VALUE!", "TEXTJOIN accepts at most 252 arguments"},
 		"=TEXTJOIN(\",\",FALSE,REPT(\"*\",32768))":                 {"#VALUE!", "TEXTJOIN function exceeds 32767 characters"},
+		"=TEXTJOIN(NA(),TRUE,1)":                                   {"#N/A", "#N/A"},
+		// TEXTSPLIT
+		"=TEXTSPLIT()":                         {"#VALUE!", "TEXTSPLIT requires at least 2 arguments"},
+		"=TEXTSPLIT(\"\",\",\",\";\",1,0,1,1)": {"#VALUE!", "TEXTSPLIT allows at most 6 arguments"},
+		"=TEXTSPLIT(NA(),\",\")":               {"#N/A", "#N/A"},
+		"=TEXTSPLIT(\"a\",NA())":               {"#N/A", "#N/A"},
+		"=TEXTSPLIT(\"a\",\"\")":               {"#VALUE!", "#VALUE!"},
+		"=TEXTSPLIT(\"a\",\",\",,\"x\")":       {"#VALUE!", "#VALUE!"},
+		"=TEXTSPLIT(\"a\",\",\",,,2)":          {"#VALUE!", "#VALUE!"},
+		"=TEXTSPLIT(\",\",\",\",,TRUE)":        {"#CALC!", "#CALC!"},
 		// TRIM
 		"=TRIM()":    {"#VALUE!", "TRIM requires 1 argument"},
 		"=TRIM(1,2)": {"#VALUE!", "TRIM requires 1 argument"},
@@ -4057,6 +4112,17 @@ func TestCalcCellValue(t *testing.T) {
 		"=LOOKUP(D2,D1,D2,FALSE)":       {"#VALUE!", "LOOKUP requires at most 3 arguments"},
 		"=LOOKUP(1,MUNIT(0))":           {"#VALUE!", "LOOKUP requires not empty range as second argument"},
 		"=LOOKUP(D1,MUNIT(1),MUNIT(1))": {"#N/A", "LOOKUP no result found"},
+		// OFFSET
+		"=OFFSET()":             {"#VALUE!", "OFFSET requires 3 to 5 arguments"},
+		"=OFFSET(A1,0,0,1,1,1)": {"#VALUE!", "OFFSET requires 3 to 5 arguments"},
+		"=OFFSET(1,1,1)":        {"#VALUE!", "invalid reference"},
+		"=OFFSET(A1,\"\",1)":    {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=OFFSET(A1,NA(),1)":    {"#N/A", "#N/A"},
+		"=OFFSET(A1,-1,0)":      {"#REF!", "#REF!"},
+		"=OFFSET(A1,0,0,0)":     {"#REF!", "#REF!"},
+		"=OFFSET(A1,0,0,1,0)":   {"#REF!", "#REF!"},
+		"=OFFSET(A1,1048576,0)": {"#REF!", "#REF!"},
+		"=OFFSET(A1,0,16384)":   {"#REF!", "#REF!"},
 		// ROW
 		"=ROW(1,2)":          {"#VALUE!", "ROW requires at most 1 argument"},
 		"=ROW(\"\")":         {"#VALUE!", "invalid reference"},
@@ -5065,6 +5131,41 @@ func TestCalcDatabase(t *testing.T) {
 	}
 }
 
+func TestCalcDatabaseCriteria(t *testing.T) {
+	cellData := [][]interface{}{
+		{"Tree", "Height", "Age", "Yield", "Profit", nil, "Tree", "Height"},
+		{"Apple", 18, 20, 14, 105, nil, "?ear", ">=10"},
+		{"Pear", 12, 12, 10, 96},
+		{"Cherry", 13, 14, 9, 105},
+		{"Apple", 14, nil, 10, 75},
+		{"Pear", 9, 8, 8, 77},
+		{"Applesauce", 12, 11, 6, 45},
+		{},
+		{"Tree", "Height"},
+		{"Apple", nil},
+		{"?ear", ">=10"},
+		{"A*", 12},
+	}
+	f := prepareCalcData(cellData)
+	for formula, expected := range map[string]string{
+		"=DSUM(A1:E7,\"Profit\",A9:A10)":              "225",
+		"=DSUM(A1:E7,5,A9:B11)":                       "321",
+		"=DSUM(A1:E7,5,A9:B12)":                       "321",
+		"=DSUM(A1:E7,\"Profit\",G1:H2)":               "96",
+		"=DSUM(OFFSET(A1,0,0,7,5),\"Profit\",A9:A10)": "225",
+		"=DCOUNT(A1:E7,\"Age\",A9:B10)":               "2",
+		"=DCOUNT(A1:E7,\"Height\",B9:B10)":            "6",
+		"=DAVERAGE(A1:E7,\"Yield\",A9:A10)":           "10",
+		"=DGET(A1:E7,\"Tree\",G1:H2)":                 "Pear",
+		"=DGET(INDIRECT(\"A1:E7\"),\"Profit\",G1:H2)": "96",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "J1", formula))
+		result, err := f.CalcCellValue("Sheet1", "J1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+}
+
 func TestCalcFORMULATEXT(t *testing.T) {
 	f, formulaText := NewFile(), "=SUM(B1:C1)"
 	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", formulaText))
@@ -6230,3 +6331,422 @@ func TestParseToken(t *testing.T) {
 		efp.Token{TSubType: efp.TokenSubTypeRange, TValue: "1A"}, nil, nil,
 	).Error())
 }
+
+func TestRegisterCalcFunc(t *testing.T) {
+	cellData := [][]interface{}{
+		{1, 2, 3},
+		{4, "a", true},
+	}
+	f := prepareCalcData(cellData)
+	assert.EqualError(t, f.RegisterCalcFunc(" ", nil), ErrParameterRequired.Error())
+	assert.NoError(t, f.RegisterCalcFunc("mysum", func(args []CalcArg) (CalcResult, error) {
+		var sum float64
+		for _, arg := range args {
+			cells := [][]CalcArg{{arg}}
+			if arg.Type == ArgMatrix {
+				cells = arg.Matrix
+			}
+			for _, row := range cells {
+				for _, cell := range row {
+					if cell.Type == ArgNumber && !cell.Boolean {
+						sum += cell.Number
+					}
+				}
+			}
+		}
+		return CalcResult{Type: ArgNumber, Number: sum}, nil
+	}))
+	assert.NoError(t, f.RegisterCalcFunc("ISTEXTARG", func(args []CalcArg) (CalcResult, error) {
+		if len(args) != 1 {
+			return CalcResult{Type: ArgError, Error: formulaErrorNA}, nil
+		}
+		if args[0].Type == ArgError {
+			return CalcResult{}, errors.New("unexpected error argument")
+		}
+		if args[0].Type == ArgString {
+			return CalcResult{Type: ArgNumber, Number: 1, Boolean: true}, nil
+		}
+		return CalcResult{Type: ArgNumber, Boolean: true}, nil
+	}))
+	assert.NoError(t, f.RegisterCalcFunc("UPPERTEXT", func(args []CalcArg) (CalcResult, error) {
+		return CalcResult{Type: ArgString, String: strings.ToUpper(args[0].String)}, nil
+	}))
+	for formula, expected := range map[string]string{
+		"MYSUM(A1:C2,10)":              "20",
+		"_xludf.MYSUM(A1,B1)":          "3",
+		"MYSUM(A1:C2)*2":               "20",
+		"SUM(MYSUM(A1:B1),1)":          "4",
+		"ISTEXTARG(B2)":                "TRUE",
+		"ISTEXTARG(A1)":                "FALSE",
+		"ISTEXTARG(A1,B1)":             formulaErrorNA,
+		"UPPERTEXT(\"excelize\")":      "EXCELIZE",
+		"CONCATENATE(UPPERTEXT(B2),1)": "A1",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", formula))
+		result, _ := f.CalcCellValue("Sheet1", "D1")
+		assert.Equal(t, expected, result, formula)
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "ISTEXTARG(1/0)"))
+	result, err := f.CalcCellValue("Sheet1", "D1")
+	assert.EqualError(t, err, "unexpected error argument")
+	assert.Equal(t, formulaErrorVALUE, result)
+	// Test override and unregister the user-defined function
+	assert.NoError(t, f.RegisterCalcFunc("ABS", func(args []CalcArg) (CalcResult, error) {
+		return CalcResult{Type: ArgNumber, Number: -1}, nil
+	}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "ABS(1)"))
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "-1", result)
+	assert.NoError(t, f.RegisterCalcFunc("abs", nil))
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", result)
+}
+
+func TestCalcCellValueTyped(t *testing.T) {
+	cellData := [][]interface{}{
+		{1, 2, "text"},
+		{0.5, true, nil},
+	}
+	f := prepareCalcData(cellData)
+	for formula, expected := range map[string]CalcResult{
+		"A1+B1":        {Type: ArgNumber, Number: 3, NumFmt: "general"},
+		"AND(B2,TRUE)": {Type: ArgNumber, Number: 1, Boolean: true, NumFmt: "general"},
+		"C1&\"s\"":     {Type: ArgString, String: "texts", NumFmt: "general"},
+		"UPPER(C1)":    {Type: ArgString, String: "TEXT", NumFmt: "general"},
+		"NA()":         {Type: ArgError, Error: formulaErrorNA},
+		"SQRT(-1)":     {Type: ArgError, Error: formulaErrorNUM},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", formula))
+		result, _ := f.CalcCellValueTyped("Sheet1", "D1")
+		assert.Equal(t, expected, result, formula)
+	}
+	// Test get calculated cell value with number format
+	style, err := f.NewStyle(&Style{NumFmt: 10})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D1", "D1", style))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "A2*B1"))
+	result, err := f.CalcCellValueTyped("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, CalcResult{Type: ArgNumber, Number: 1, NumFmt: "0.00%"}, result)
+	customFmt := "0.000"
+	style, err = f.NewStyle(&Style{CustomNumFmt: &customFmt})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D1", "D1", style))
+	result, err = f.CalcCellValueTyped("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, CalcResult{Type: ArgNumber, Number: 1, NumFmt: customFmt}, result)
+	// Test get calculated cell value on not exists worksheet
+	_, err = f.CalcCellValueTyped("SheetN", "D1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get calculated cell value with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.CalcCellValueTyped("Sheet1", "D1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestCalcCache(t *testing.T) {
+	cacheKey := func(cell string, opts ...Options) string {
+		return newCalcContext("Sheet1", cell, opts...).calcCacheKey("Sheet1", cell)
+	}
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "A1*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "B1+1"))
+	result, err := f.CalcCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", result)
+	_, ok := f.calcCache.Load(cacheKey("C1"))
+	assert.True(t, ok)
+	// Test get cached result without re-evaluating the formula
+	f.calcCache.Store(cacheKey("C1"), newNumberFormulaArg(10))
+	result, err = f.CalcCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "10", result)
+	// Test calculate formula with cached precedent cell
+	f.calcCache.Store(cacheKey("B1"), newNumberFormulaArg(5))
+	f.calcCache.Delete(cacheKey("C1"))
+	result, err = f.CalcCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "6", result)
+	// Test clear cache after changes
+	for _, fn := range []func() error{
+		func() error { return f.SetCellValue("Sheet1", "A1", 2) },
+		func() error { return f.SetCellInt("Sheet1", "A1", 2) },
+		func() error { return f.SetCellFormula("Sheet1", "B1", "A1*2") },
+		func() error { return f.InsertRows("Sheet1", 2, 1) },
+		func() error { return f.RemoveRow("Sheet1", 2) },
+		func() error { return f.DuplicateRow("Sheet1", 1) },
+		func() error { return f.SetDefinedName(&DefinedName{Name: "x", RefersTo: "Sheet1!$A$1"}) },
+		func() error { return f.RegisterCalcFunc("X", nil) },
+		func() error { return f.SetWorkbookDateSystem(DateSystem1904) },
+		func() error { return f.SetWorkbookProps(&WorkbookPropsOptions{Date1904: boolPtr(false)}) },
+		func() error { _, err := f.NewSheet("Sheet2"); return err },
+		func() error { return f.RemoveMetadata(nil) },
+		func() error { return f.MergeCell("Sheet1", "D1", "E1") },
+		func() error { return f.UnmergeCell("Sheet1", "D1", "E1") },
+		func() error { return f.SetColWidth("Sheet1", "A", "A", 10) },
+	} {
+		f.calcCache.Store(cacheKey("C1"), newNumberFormulaArg(10))
+		assert.NoError(t, fn())
+		_, ok = f.calcCache.Load(cacheKey("C1"))
+		assert.False(t, ok)
+	}
+	// Test keep cache after reading the workbook
+	for _, fn := range []func() error{
+		func() error { _, err := f.GetCellValue("Sheet1", "A1"); return err },
+		func() error { _, err := f.GetCellStyle("Sheet1", "A1"); return err },
+		func() error { _, err := f.GetRows("Sheet1"); return err },
+		func() error { _, err := f.GetColWidth("Sheet1", "A"); return err },
+		func() error { _, err := f.GetSheetProps("Sheet1"); return err },
+	} {
+		f.calcCache.Store(cacheKey("C1"), newNumberFormulaArg(10))
+		assert.NoError(t, fn())
+		_, ok = f.calcCache.Load(cacheKey("C1"))
+		assert.True(t, ok)
+	}
+	f.clearCalcCache()
+	typed, err := f.CalcCellValueTyped("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, CalcResult{Type: ArgNumber, Number: 5, NumFmt: "general"}, typed)
+	// Test the calculation options are part of the cache key
+	f.calcCache.Store(cacheKey("C1"), newNumberFormulaArg(10))
+	result, err = f.CalcCellValue("Sheet1", "C1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "5", result)
+	result, err = f.CalcCellValue("Sheet1", "C1", Options{MaxCalcIterations: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, "5", result)
+	_, ok = f.calcCache.Load(cacheKey("C1", Options{RawCellValue: true}))
+	assert.True(t, ok)
+	// Test the cells depend on volatile functions will not be cached
+	for _, formula := range []string{
+		"RAND()", "RANDBETWEEN(1,100)", "NOW()", "TODAY()", "OFFSET(A1,0,0)",
+		"INDIRECT(\"A1\")", "_xlfn.RAND()+1",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", formula))
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "D1"))
+		for _, cell := range []string{"D1", "E1"} {
+			_, err = f.CalcCellValue("Sheet1", cell)
+			assert.NoError(t, err, formula)
+			_, err = f.CalcCellValueTyped("Sheet1", cell)
+			assert.NoError(t, err, formula)
+			_, ok = f.calcCache.Load(cacheKey(cell))
+			assert.False(t, ok, formula)
+		}
+	}
+	// Test the result of the date function changes after changing the date system
+	f = NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "YEAR(1000)"))
+	result, err = f.CalcCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1902", result)
+	assert.NoError(t, f.SetWorkbookDateSystem(DateSystem1904))
+	result, err = f.CalcCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1906", result)
+	// Test the result of the formula depends on merged cells changes after
+	// merging and unmerging cells
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 7))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "B1"))
+	for _, step := range []struct {
+		fn       func() error
+		expected string
+	}{
+		{func() error { return nil }, ""},
+		{func() error { return f.MergeCell("Sheet1", "A1", "B1") }, "7"},
+		{func() error { return f.UnmergeCell("Sheet1", "A1", "B1") }, ""},
+	} {
+		assert.NoError(t, step.fn())
+		result, err = f.CalcCellValue("Sheet1", "C1")
+		assert.NoError(t, err)
+		assert.Equal(t, step.expected, result)
+	}
+}
+
+func TestCalc3DReference(t *testing.T) {
+	f := NewFile()
+	for idx, sheet := range []string{"Sheet2", "Sheet 3", "Sheet4"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow(sheet, "A1", &[]int{idx + 1, 10}))
+	}
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]int{100, 100}))
+	for formula, expected := range map[string]string{
+		"SUM(Sheet2:Sheet4!A1)":        "6",
+		"SUM('Sheet2:Sheet 3'!A1)":     "3",
+		"SUM('Sheet 3:Sheet2'!A1:B1)":  "23",
+		"SUM(Sheet1:Sheet4!$A$1:$B$1)": "236",
+		"COUNT(Sheet2:Sheet4!A1:B2)":   "6",
+		"MAX(Sheet2:Sheet4!A1)":        "3",
+		"SUM(Sheet2:SheetN!A1)":        "#REF!",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "C1", formula))
+		result, _ := f.CalcCellValue("Sheet1", "C1")
+		assert.Equal(t, expected, result, formula)
+	}
+}
+
+func TestCalcExternalReference(t *testing.T) {
+	f := NewFile()
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.ExternalReferences = &xlsxExternalReferences{ExternalReference: []xlsxExternalReference{{RID: "rId100"}}}
+	rels, err := f.relsReader(defaultXMLPathWorkbookRels)
+	assert.NoError(t, err)
+	rels.Relationships = append(rels.Relationships, xlsxRelationship{ID: "rId100", Target: "externalLinks/externalLink1.xml"})
+	f.Pkg.Store("xl/externalLinks/externalLink1.xml", []byte(`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><externalBook r:id="rId1"><sheetNames><sheetName val="Sheet1"/><sheetName val="Sheet2"/></sheetNames><sheetDataSet><sheetData sheetId="0"><row r="1"><cell r="A1"><v>1</v></cell><cell r="B1"><v>2</v></cell></row><row r="2"><cell r="A2" t="str"><v>text</v></cell><cell r="B2" t="b"><v>1</v></cell></row><row r="3"><cell r="A3" t="e"><v>#DIV/0!</v></cell></row></sheetData><sheetData sheetId="1" refreshError="1"/></sheetDataSet></externalBook></externalLink>`))
+	f.Pkg.Store("xl/externalLinks/_rels/externalLink1.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/externalLinkPath" Target="file:///C:\Data\Book2.xlsx" TargetMode="External"/></Relationships>`))
+	for formula, expected := range map[string]string{
+		"[1]Sheet1!A1":               "1",
+		"[1]Sheet1!A1+1":             "2",
+		"SUM([1]Sheet1!A1:B1)":       "3",
+		"[Book2.xlsx]Sheet1!$B$1":    "2",
+		"[book2.xlsx]Sheet1!A2":      "text",
+		"[1]Sheet1!B2":               "TRUE",
+		"[1]Sheet1!A3":               "#DIV/0!",
+		"[1]Sheet1!C3":               "",
+		"[1]Sheet2!A1":               "",
+		"SUM([1]Sheet2!A1)":          "#REF!",
+		"SUM([1]SheetN!A1)":          "#REF!",
+		"SUM([2]Sheet1!A1)":          "#REF!",
+		"SUM([Book3.xlsx]Sheet1!A1)": "#REF!",
+		"SUM([1]Sheet1!A1:B1:C1)":    "#REF!",
+		"SUM([1]Sheet1!XYZ)":         "#REF!",
+		"SUM([1]!Name)":              "#REF!",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", formula))
+		result, _ := f.CalcCellValue("Sheet1", "A1")
+		assert.Equal(t, expected, result, formula)
+	}
+	// Test calculate external reference with value provider
+	assert.NoError(t, f.RegisterExternalWorkbook("BOOK2.xlsx", func(sheet, cell string) (CalcArg, error) {
+		if cell == "A1" {
+			return CalcArg{Type: ArgNumber, Number: 100}, nil
+		}
+		return CalcArg{}, errors.New("unavailable")
+	}))
+	assert.NoError(t, f.RegisterExternalWorkbook("Book3.xlsx", func(sheet, cell string) (CalcArg, error) {
+		return CalcArg{Type: ArgString, String: sheet + "!" + cell}, nil
+	}))
+	for formula, expected := range map[string]string{
+		"SUM([1]Sheet1!A1)":          "100",
+		"SUM([Book2.xlsx]Sheet2!A1)": "100",
+		"SUM([1]Sheet1!B1)":          "#REF!",
+		"[Book3.xlsx]Sheet1!A1":      "Sheet1!A1",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", formula))
+		result, _ := f.CalcCellValue("Sheet1", "A1")
+		assert.Equal(t, expected, result, formula)
+	}
+	// Test unregister value provider
+	assert.NoError(t, f.RegisterExternalWorkbook("Book2.xlsx", nil))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "[1]Sheet1!A1"))
+	result, err := f.CalcCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", result)
+	assert.Equal(t, ErrParameterRequired, f.RegisterExternalWorkbook(" ", nil))
+	// Test the external link parts are decoded once and indexed by the
+	// external workbook index and file name
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "SUM([01]Sheet1!B1)"))
+	result, err = f.CalcCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", result)
+	assert.Len(t, f.calcLinks, 2)
+	assert.Equal(t, f.calcLinks["1"], f.calcLinks["book2.xlsx"])
+	f.Pkg.Store("xl/externalLinks/externalLink1.xml", []byte(`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"/>`))
+	f.calcCache.Delete(newCalcContext("Sheet1", "B1").calcCacheKey("Sheet1", "B1"))
+	result, err = f.CalcCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", result)
+	// Test clear the decoded external link parts on registering value provider
+	assert.NoError(t, f.RegisterExternalWorkbook("Book3.xlsx", nil))
+	assert.Nil(t, f.calcLinks)
+	result, err = f.CalcCellValue("Sheet1", "B1")
+	assert.Equal(t, "#REF!", result)
+	assert.EqualError(t, err, "#REF!")
+}
+
+func BenchmarkCalcExternalReference(b *testing.B) {
+	f := NewFile()
+	wb, _ := f.workbookReader()
+	wb.ExternalReferences = &xlsxExternalReferences{}
+	rels, _ := f.relsReader(defaultXMLPathWorkbookRels)
+	var cells strings.Builder
+	for row := 1; row <= 1000; row++ {
+		cells.WriteString(fmt.Sprintf(`<row r="%d"><cell r="A%d"><v>%d</v></cell></row>`, row, row, row))
+	}
+	for i := 1; i <= 10; i++ {
+		rID, linkPath := fmt.Sprintf("rId%d", 100+i), fmt.Sprintf("xl/externalLinks/externalLink%d.xml", i)
+		wb.ExternalReferences.ExternalReference = append(wb.ExternalReferences.ExternalReference, xlsxExternalReference{RID: rID})
+		rels.Relationships = append(rels.Relationships, xlsxRelationship{ID: rID, Target: strings.TrimPrefix(linkPath, "xl/")})
+		f.Pkg.Store(linkPath, []byte(`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><externalBook r:id="rId1"><sheetNames><sheetName val="Sheet1"/></sheetNames><sheetDataSet><sheetData sheetId="0">`+cells.String()+`</sheetData></sheetDataSet></externalBook></externalLink>`))
+	}
+	if err := f.SetCellFormula("Sheet1", "A1", "SUM([10]Sheet1!A1:A1000)"); err != nil {
+		b.Error(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.calcCache.Delete(newCalcContext("Sheet1", "A1").calcCacheKey("Sheet1", "A1"))
+		if _, err := f.CalcCellValue("Sheet1", "A1"); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func TestCalcDate1904(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetWorkbookDateSystem(DateSystem1904))
+	for formula, expected := range map[string]string{
+		"DATEVALUE(\"2023-06-01\")":  "43616",
+		"DAY(43616)":                 "1",
+		"DAY(30)":                    "31",
+		"MONTH(43616)":               "6",
+		"YEAR(43616)":                "2023",
+		"TEXT(43616,\"yyyy-mm-dd\")": "2023-06-01",
+		"WEEKDAY(43616)":             "5",
+		"TODAY()-DATEVALUE(TEXT(TODAY(),\"yyyy-mm-dd\"))": "0",
+		"INT(NOW())-TODAY()":                              "0",
+		"VALUE(\"2020-01-15\")":                           "42383",
+		// Test date functions read and produce serial date numbers in the 1904 date system
+		"EDATE(DATEVALUE(\"2020-01-15\"),1)":                                                         "42414",
+		"EDATE(\"2020-01-15\",1)":                                                                    "42414",
+		"EOMONTH(DATEVALUE(\"2020-01-15\"),1)":                                                       "42428",
+		"EOMONTH(\"2020-01-15\",1)":                                                                  "42428",
+		"MONTH(EOMONTH(DATEVALUE(\"2020-01-15\"),0))":                                                "1",
+		"DAYS(DATEVALUE(\"2020-03-01\"),\"2020-01-01\")":                                             "60",
+		"DAYS360(DATEVALUE(\"2020-01-01\"),DATEVALUE(\"2020-03-01\"))":                               "60",
+		"DAYS360(\"2020-01-31\",\"2020-03-31\",TRUE)":                                                "60",
+		"DATEDIF(DATEVALUE(\"2020-02-28\"),DATEVALUE(\"2020-03-01\"),\"md\")":                        "2",
+		"DATEDIF(DATEVALUE(\"2020-01-31\"),DATEVALUE(\"2021-03-01\"),\"m\")":                         "13",
+		"DATEDIF(DATEVALUE(\"2020-01-31\"),DATEVALUE(\"2021-03-01\"),\"yd\")":                        "30",
+		"WORKDAY(DATEVALUE(\"2020-01-03\"),1)":                                                       "42374",
+		"WORKDAY(DATEVALUE(\"2020-01-03\"),1,DATEVALUE(\"2020-01-06\"))":                             "42375",
+		"WORKDAY.INTL(DATEVALUE(\"2020-01-03\"),1,11)":                                               "42372",
+		"NETWORKDAYS(DATEVALUE(\"2020-01-01\"),DATEVALUE(\"2020-01-31\"))":                           "23",
+		"NETWORKDAYS(DATEVALUE(\"2020-01-01\"),DATEVALUE(\"2020-01-31\"),DATEVALUE(\"2020-01-06\"))": "22",
+		"NETWORKDAYS.INTL(DATEVALUE(\"2020-01-01\"),DATEVALUE(\"2020-01-31\"),11)":                   "27",
+		"YEARFRAC(DATEVALUE(\"2020-01-31\"),DATEVALUE(\"2020-03-01\"))":                              "0.0861111111111111",
+		"YEARFRAC(DATEVALUE(\"2020-01-31\"),DATEVALUE(\"2020-03-01\"),4)":                            "0.0861111111111111",
+		"ISOWEEKNUM(DATEVALUE(\"2020-01-06\"))":                                                      "2",
+		"WEEKNUM(DATEVALUE(\"2020-01-04\"))":                                                         "1",
+		"HOUR(DATEVALUE(\"2020-01-05\")+0.75)":                                                       "18",
+		"MINUTE(DATEVALUE(\"2020-01-05\")+0.5+1/1440)":                                               "1",
+		"SECOND(DATEVALUE(\"2020-01-05\")+0.5+1/86400)":                                              "1",
+		// Test financial functions read and produce serial date numbers in the 1904 date system
+		"COUPPCD(DATEVALUE(\"2020-01-31\"),DATEVALUE(\"2020-11-30\"),2)":             "42337",
+		"COUPNCD(DATEVALUE(\"2020-01-31\"),DATEVALUE(\"2020-11-30\"),2)":             "42520",
+		"COUPDAYBS(DATEVALUE(\"2020-01-31\"),DATEVALUE(\"2020-11-30\"),2)":           "60",
+		"COUPDAYSNC(DATEVALUE(\"2020-01-31\"),DATEVALUE(\"2020-11-30\"),2)":          "120",
+		"PRICE(DATEVALUE(\"2020-01-31\"),DATEVALUE(\"2029-11-30\"),0.05,0.06,100,2)": "92.6444369856072",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", formula))
+		result, err := f.CalcCellValue("Sheet1", "A1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	assert.NoError(t, f.Close())
+}
@@ -18,8 +18,26 @@ import (
 )
 
 // calcChainReader provides a function to get the pointer to the structure
-// after deserialization of xl/calcChain.xml.
+// after deserialization of xl/calcChain.xml, and track the calculation chain
+// was modified.
 func (f *File) calcChainReader() (*xlsxCalcChain, error) {
+	return f.getCalcChain(false)
+}
+
+// calcChainViewer provides a function to get the pointer to the structure
+// after deserialization of xl/calcChain.xml for read only. If the calculation
+// chain was loaded by this function and never be got by the calcChainReader,
+// the original part will be copied verbatim on save instead of serialize the
+// structure.
+func (f *File) calcChainViewer() (*xlsxCalcChain, error) {
+	return f.getCalcChain(true)
+}
+
+// getCalcChain provides a function to get the pointer to the structure after
+// deserialization of xl/calcChain.xml, and track if the calculation chain was
+// modified.
+func (f *File) getCalcChain(readOnly bool) (*xlsxCalcChain, error) {
+	defer f.trackPart(defaultXMLPathCalcChain, f.CalcChain == nil, readOnly)
 	if f.CalcChain == nil {
 		f.CalcChain = new(xlsxCalcChain)
 		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathCalcChain)))).
@@ -33,6 +51,9 @@ func (f *File) calcChainReader() (*xlsxCalcChain, error) {
 // calcChainWriter provides a function to save xl/calcChain.xml after
 // serialize structure.
 func (f *File) calcChainWriter() {
+	if _, ok := f.unmodified.Load(defaultXMLPathCalcChain); ok {
+		return
+	}
 	if f.CalcChain != nil && f.CalcChain.C != nil {
 		output, _ := xml.Marshal(f.CalcChain)
 		f.saveFileList(defaultXMLPathCalcChain, output)
@@ -14,13 +14,17 @@ package excelize
 import (
 	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"math/big"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
+
+	"github.com/mohae/deepcopy"
 )
 
 // CellType is the type of cell value type.
@@ -65,18 +69,110 @@ var cellTypes = map[string]CellType{
 // converted to the 'string' data type. This function is concurrency safe. If
 // the cell format can be applied to the value of a cell, the applied value
 // will be returned, otherwise the original value will be returned. All cells'
-// values will be the same in a merged range.
+// values will be the same in a merged range. The empty string will be
+// returned for the cell with the picture placed in the cell, please use the
+// GetPictures function to get the picture.
 func (f *File) GetCellValue(sheet, cell string, opts ...Options) (string, error) {
 	return f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
-		sst, err := f.sharedStringsReader()
+		sst, err := f.sharedStringsViewer()
 		if err != nil {
 			return "", true, err
 		}
-		val, err := c.getValueFrom(f, sst, getOptions(opts...).RawCellValue)
+		val, err := c.getValueFrom(f, sst, getOptions(opts...))
 		return val, true, err
 	})
 }
 
+// GetCellValueTyped provides a function to get the value of the cell with the
+// Go data type and the cell's data type by given worksheet name and cell
+// reference in one call. The value will be converted according to the cell's
+// data type and number format:
+//
+//	Cell                                   Value type  Cell type
+//	-------------------------------------  ----------  --------------------
+//	Empty cell                             nil         CellTypeUnset
+//	Boolean                                bool        CellTypeBool
+//	Number                                 float64     CellTypeNumber
+//	Number with date and time format       time.Time   CellTypeDate
+//	Date in the ISO 8601 format            time.Time   CellTypeDate
+//	Error                                  error       CellTypeError
+//	Shared string                          string      CellTypeSharedString
+//	Inline string                          string      CellTypeInlineString
+//	Formula string result                  string      CellTypeFormula
+//
+// The string value will be returned without the number format applied. For
+// example, get the value of the cell A1 on Sheet1:
+//
+//	value, cellType, err := f.GetCellValueTyped("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if date, ok := value.(time.Time); ok {
+//	    fmt.Println(date.Format(time.RFC3339), cellType)
+//	}
+func (f *File) GetCellValueTyped(sheet, cell string) (interface{}, CellType, error) {
+	var (
+		value    interface{}
+		cellType CellType
+	)
+	_, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		var err error
+		value, cellType, err = c.getTypedValue(f)
+		return "", true, err
+	})
+	if err != nil {
+		return nil, CellTypeUnset, err
+	}
+	return value, cellType, err
+}
+
+// getTypedValue provides a function to get the value of the cell with the Go
+// data type and the cell's data type.
+func (c *xlsxC) getTypedValue(f *File) (interface{}, CellType, error) {
+	switch c.T {
+	case "b":
+		return c.V == "1" || strings.EqualFold(c.V, "true"), CellTypeBool, nil
+	case "d":
+		if timestamp, err := parseCellDate(c.V); err == nil {
+			return timestamp, CellTypeDate, nil
+		}
+		return c.V, CellTypeDate, nil
+	case "e":
+		return errors.New(c.V), CellTypeError, nil
+	case "s", "str", "inlineStr":
+		sst, err := f.sharedStringsViewer()
+		if err != nil {
+			return nil, CellTypeUnset, err
+		}
+		val, err := c.getValueFrom(f, sst, &Options{RawCellValue: true})
+		return val, cellTypes[c.T], err
+	}
+	if c.V == "" {
+		return nil, CellTypeUnset, nil
+	}
+	number, err := strconv.ParseFloat(c.V, 64)
+	if err != nil {
+		return c.V, CellTypeNumber, nil
+	}
+	if c.S != 0 {
+		fmtCode, err := f.getCellNumFmtCode(c.S)
+		if err != nil {
+			return nil, CellTypeUnset, err
+		}
+		if isDateTimeNumFmt(fmtCode) {
+			date1904, err := f.getDate1904()
+			if err != nil {
+				return nil, CellTypeUnset, err
+			}
+			if timestamp, err := ExcelDateToTime(number, date1904); err == nil {
+				return timestamp, CellTypeDate, nil
+			}
+		}
+	}
+	return number, CellTypeNumber, nil
+}
+
 // GetCellType provides a function to get the cell's data type by given
 // worksheet name and cell reference in spreadsheet file.
 func (f *File) GetCellType(sheet, cell string) (CellType, error) {
@@ -117,6 +213,13 @@ func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 //	time.Time
 //	bool
 //	nil
+//	*big.Int
+//	*big.Float
+//	Decimal
+//
+// The big integer, big float and decimal numbers will be stored with all
+// digits, use the FullPrecision option to read them without rounding to 15
+// significant digits.
 //
 // Note that default date format is m/d/yy h:mm of time.Time type value. You
 // can set numbers format by the SetCellStyle function. If you need to set the
@@ -125,35 +228,142 @@ func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 // the cell value as number 0 or 60, then create and bind the date-time number
 // format style for the cell.
 func (f *File) SetCellValue(sheet, cell string, value interface{}) error {
-	var err error
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	c, col, row, err := ws.prepareCell(cell)
+	if err != nil {
+		ws.mu.Unlock()
+		return err
+	}
+	c.S = ws.prepareCellStyle(col, row, c.S)
+	numFmt, err := f.setCellValueOf(c, value, f.setSharedString)
+	if err == nil {
+		err = f.removeFormula(c, ws, sheet)
+	}
+	ws.mu.Unlock()
+	if err != nil || numFmt == 0 {
+		return err
+	}
+	return f.setDefaultTimeStyle(sheet, cell, numFmt)
+}
+
+// setCellValueOf provides a function to set the cell type and value by given
+// value of the data types supported by SetCellValue. The string value will be
+// stored in the shared string table by the given function, which returns the
+// index of the string. It returns the built-in number format ID which should
+// be applied to the cell without style, or zero if no number format needed.
+func (f *File) setCellValueOf(c *xlsxC, value interface{}, sharedString func(string) (int, error)) (int, error) {
+	var (
+		err   error
+		str   string
+		isStr bool
+	)
+	c.IS = nil
 	switch v := value.(type) {
 	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-		err = f.setCellIntFunc(sheet, cell, v)
+		err = setCellIntFunc(c, v)
 	case float32:
-		err = f.SetCellFloat(sheet, cell, float64(v), -1, 32)
+		c.T, c.V = setCellFloat(float64(v), -1, 32)
 	case float64:
-		err = f.SetCellFloat(sheet, cell, v, -1, 64)
+		c.T, c.V = setCellFloat(v, -1, 64)
 	case string:
-		err = f.SetCellStr(sheet, cell, v)
+		str, isStr = v, true
 	case []byte:
-		err = f.SetCellStr(sheet, cell, string(v))
+		str, isStr = string(v), true
 	case time.Duration:
 		_, d := setCellDuration(v)
-		err = f.SetCellDefault(sheet, cell, d)
-		if err != nil {
-			return err
-		}
-		err = f.setDefaultTimeStyle(sheet, cell, 21)
+		c.setCellDefault(d)
+		return 21, err
 	case time.Time:
-		err = f.setCellTimeFunc(sheet, cell, v)
+		var date1904, isNum bool
+		if date1904, err = f.getDate1904(); err != nil {
+			return 0, err
+		}
+		if isNum, err = c.setCellTime(v, date1904); err != nil || !isNum {
+			return 0, err
+		}
+		return 22, err
 	case bool:
-		err = f.SetCellBool(sheet, cell, v)
+		c.T, c.V = setCellBool(v)
 	case nil:
-		err = f.SetCellDefault(sheet, cell, "")
+		c.setCellDefault("")
+	case *big.Int, *big.Float, Decimal:
+		c.setCellBigNumber(v)
 	default:
-		err = f.SetCellStr(sheet, cell, fmt.Sprint(value))
+		str, isStr = fmt.Sprint(value), true
 	}
-	return err
+	if !isStr {
+		return 0, err
+	}
+	if utf8.RuneCountInString(str) > TotalCellChars {
+		str = string([]rune(str)[:TotalCellChars])
+	}
+	si, err := sharedString(str)
+	if err != nil {
+		return 0, err
+	}
+	c.T, c.V = "s", strconv.Itoa(si)
+	return 0, err
+}
+
+// Decimal is the interface implemented by the arbitrary-precision decimal
+// number types, for example, the Decimal type in the
+// github.com/shopspring/decimal package. The value of the decimal number is
+// the coefficient multiplied by 10 to the power of the exponent.
+type Decimal interface {
+	Coefficient() *big.Int
+	Exponent() int32
+}
+
+// setCellBigNumber prepares cell type and the full-precision string type cell
+// value by given big integer, big float or decimal number. The infinite big
+// float will be stored as an inline string.
+func (c *xlsxC) setCellBigNumber(value interface{}) {
+	var val string
+	switch v := value.(type) {
+	case *big.Int:
+		if v != nil {
+			val = v.String()
+		}
+	case *big.Float:
+		if v != nil && v.IsInf() {
+			c.setInlineStr(v.String())
+			return
+		}
+		if v != nil {
+			val = v.Text('f', -1)
+		}
+	case Decimal:
+		val = decimalString(v.Coefficient(), v.Exponent())
+	}
+	c.T, c.V, c.IS = "", val, nil
+}
+
+// decimalString returns the string of the decimal number by given coefficient
+// and exponent, without scientific notation.
+func decimalString(coefficient *big.Int, exponent int32) string {
+	if coefficient == nil || coefficient.Sign() == 0 {
+		return "0"
+	}
+	var sign string
+	if coefficient.Sign() < 0 {
+		sign = "-"
+	}
+	digits := new(big.Int).Abs(coefficient).String()
+	if exponent >= 0 {
+		return sign + digits + strings.Repeat("0", int(exponent))
+	}
+	n := -int(exponent)
+	if len(digits) <= n {
+		digits = strings.Repeat("0", n-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-n] + "." + digits[len(digits)-n:]
 }
 
 // String extracts characters from a string item.
@@ -201,65 +411,6 @@ func (f *File) removeFormula(c *xlsxC, ws *xlsxWorksheet, sheet string) error {
 	return nil
 }
 
-// setCellIntFunc is a wrapper of SetCellInt.
-func (f *File) setCellIntFunc(sheet, cell string, value interface{}) error {
-	var err error
-	switch v := value.(type) {
-	case int:
-		err = f.SetCellInt(sheet, cell, v)
-	case int8:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case int16:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case int32:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case int64:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint:
-		err = f.SetCellUint(sheet, cell, uint64(v))
-	case uint8:
-		err = f.SetCellUint(sheet, cell, uint64(v))
-	case uint16:
-		err = f.SetCellUint(sheet, cell, uint64(v))
-	case uint32:
-		err = f.SetCellUint(sheet, cell, uint64(v))
-	case uint64:
-		err = f.SetCellUint(sheet, cell, v)
-	}
-	return err
-}
-
-// setCellTimeFunc provides a method to process time type of value for
-// SetCellValue.
-func (f *File) setCellTimeFunc(sheet, cell string, value time.Time) error {
-	ws, err := f.workSheetReader(sheet)
-	if err != nil {
-		return err
-	}
-	c, col, row, err := ws.prepareCell(cell)
-	if err != nil {
-		return err
-	}
-	ws.mu.Lock()
-	c.S = ws.prepareCellStyle(col, row, c.S)
-	ws.mu.Unlock()
-	var date1904, isNum bool
-	wb, err := f.workbookReader()
-	if err != nil {
-		return err
-	}
-	if wb != nil && wb.WorkbookPr != nil {
-		date1904 = wb.WorkbookPr.Date1904
-	}
-	if isNum, err = c.setCellTime(value, date1904); err != nil {
-		return err
-	}
-	if isNum {
-		_ = f.setDefaultTimeStyle(sheet, cell, 22)
-	}
-	return err
-}
-
 // setCellTime prepares cell type and Excel time by given Go time.Time type
 // timestamp.
 func (c *xlsxC) setCellTime(value time.Time, date1904 bool) (isNum bool, err error) {
@@ -285,6 +436,58 @@ func setCellDuration(value time.Duration) (t string, v string) {
 	return
 }
 
+// SetCellDate provides a function to set the date and time value of a cell by
+// given worksheet name, cell reference, time.Time type value and number
+// format code. The value will be stored as the Excel serial date number in
+// the date system of the workbook, and the number format will be applied to
+// the cell with keeping the other formatting of the cell. The default date
+// and time format "m/d/yy h:mm" will be used if the number format code is
+// empty. The value before the epoch of the date system will be stored as a
+// string in the RFC3339 format without number format applied. For example,
+// set the date value of the cell A1 on Sheet1 with the "yyyy-mm-dd" format:
+//
+//	err := f.SetCellDate("Sheet1", "A1", time.Now(), "yyyy-mm-dd")
+func (f *File) SetCellDate(sheet, cell string, value time.Time, format string) error {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	c, col, row, err := ws.prepareCell(cell)
+	if err != nil {
+		return err
+	}
+	c.S = ws.prepareCellStyle(col, row, c.S)
+	var isNum bool
+	date1904, err := f.getDate1904()
+	if err != nil {
+		return err
+	}
+	if isNum, err = c.setCellTime(value, date1904); err != nil {
+		return err
+	}
+	if isNum {
+		style := &Style{}
+		if c.S != 0 {
+			if style, err = f.GetStyle(c.S); err != nil {
+				return err
+			}
+		}
+		style.NumFmt, style.CustomNumFmt = 22, nil
+		if format != "" {
+			style.NumFmt, style.CustomNumFmt = 0, &format
+		}
+		if c.S, err = f.NewStyle(style); err != nil {
+			return err
+		}
+	}
+	return f.removeFormula(c, ws, sheet)
+}
+
 // SetCellInt provides a function to set int type value of a cell by given
 // worksheet name, cell reference and cell value.
 func (f *File) SetCellInt(sheet, cell string, value int) error {
@@ -468,7 +671,7 @@ func (f *File) sharedStringsLoader() (err error) {
 		}
 		f.tempFiles.Delete(defaultTempFileSST)
 		f.sharedStringItem, err = nil, os.Remove(f.sharedStringTemp.Name())
-		f.sharedStringTemp = nil
+		f.sharedStringTemp, f.sharedStringLRU = nil, nil
 	}
 	return
 }
@@ -482,13 +685,11 @@ func (f *File) setSharedString(val string) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	f.mu.Lock()
-	defer f.mu.Unlock()
+	sst.mu.Lock()
+	defer sst.mu.Unlock()
 	if i, ok := f.sharedStringsMap[val]; ok {
 		return i, nil
 	}
-	sst.mu.Lock()
-	defer sst.mu.Unlock()
 	sst.Count++
 	sst.UniqueCount++
 	t := xlsxT{Val: val}
@@ -498,6 +699,42 @@ func (f *File) setSharedString(val string) (int, error) {
 	return sst.UniqueCount - 1, nil
 }
 
+// setSharedStrings provides a function to add the given strings into the
+// shared string table in bulk, and returns the index of each string in the
+// table.
+func (f *File) setSharedStrings(values []string) ([]int, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	if err := f.sharedStringsLoader(); err != nil {
+		return nil, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return nil, err
+	}
+	sst.mu.Lock()
+	defer sst.mu.Unlock()
+	indexes := make([]int, len(values))
+	for i, val := range values {
+		if utf8.RuneCountInString(val) > TotalCellChars {
+			val = string([]rune(val)[:TotalCellChars])
+		}
+		if idx, ok := f.sharedStringsMap[val]; ok {
+			indexes[i] = idx
+			continue
+		}
+		sst.Count++
+		sst.UniqueCount++
+		t := xlsxT{Val: val}
+		val, t.Space = trimCellValue(val, false)
+		sst.SI = append(sst.SI, xlsxSI{T: &t})
+		f.sharedStringsMap[val] = sst.UniqueCount - 1
+		indexes[i] = sst.UniqueCount - 1
+	}
+	return indexes, nil
+}
+
 // trimCellValue provides a function to set string type to cell.
 func trimCellValue(value string, escape bool) (v string, ns xml.Attr) {
 	if utf8.RuneCountInString(value) > TotalCellChars {
@@ -578,27 +815,40 @@ func (c *xlsxC) setCellDefault(value string) {
 // getCellDate parse cell value which contains a date in the ISO 8601 format.
 func (c *xlsxC) getCellDate(f *File, raw bool) (string, error) {
 	if !raw {
-		layout := "20060102T150405.999"
-		if strings.HasSuffix(c.V, "Z") {
-			layout = "20060102T150405Z"
-			if strings.Contains(c.V, "-") {
-				layout = "2006-01-02T15:04:05Z"
-			}
-		} else if strings.Contains(c.V, "-") {
-			layout = "2006-01-02 15:04:05Z"
-		}
-		if timestamp, err := time.Parse(layout, strings.ReplaceAll(c.V, ",", ".")); err == nil {
+		if timestamp, err := parseCellDate(c.V); err == nil {
 			excelTime, _ := timeToExcelTime(timestamp, false)
-			c.V = strconv.FormatFloat(excelTime, 'G', 15, 64)
+			return f.formattedValue(&xlsxC{S: c.S, V: strconv.FormatFloat(excelTime, 'G', 15, 64)}, raw, CellTypeDate)
 		}
 	}
 	return f.formattedValue(c, raw, CellTypeDate)
 }
 
+// parseCellDate parse the date cell value in the ISO 8601 format.
+func parseCellDate(value string) (time.Time, error) {
+	layout := "20060102T150405.999"
+	if strings.HasSuffix(value, "Z") {
+		layout = "20060102T150405Z"
+		if strings.Contains(value, "-") {
+			layout = "2006-01-02T15:04:05Z"
+		}
+	} else if strings.Contains(value, "-") {
+		layout = "2006-01-02 15:04:05Z"
+	}
+	return time.Parse(layout, strings.ReplaceAll(value, ",", "."))
+}
+
 // getValueFrom return a value from a column/row cell, this function is
 // intended to be used with for range on rows an argument with the spreadsheet
 // opened file.
-func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, raw bool) (string, error) {
+func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, opts *Options) (string, error) {
+	raw := opts.RawCellValue
+	if c.Vm != nil && c.T == "e" {
+		// The value of the cell with the picture placed in the cell is empty
+		pics, err := f.getPicturesInCell()
+		if _, ok := pics[*c.Vm]; ok || err != nil {
+			return "", err
+		}
+	}
 	switch c.T {
 	case "b":
 		return c.getCellBool(f, raw)
@@ -625,12 +875,12 @@ func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, raw bool) (string, error) {
 		}
 		return f.formattedValue(c, raw, CellTypeInlineString)
 	default:
-		if isNum, precision, decimal := isNumeric(c.V); isNum && !raw {
+		if isNum, precision, decimal := isNumeric(c.V); isNum && !raw && !opts.FullPrecision {
+			val := strconv.FormatFloat(decimal, 'f', -1, 64)
 			if precision > 15 {
-				c.V = strconv.FormatFloat(decimal, 'G', 15, 64)
-			} else {
-				c.V = strconv.FormatFloat(decimal, 'f', -1, 64)
+				val = strconv.FormatFloat(decimal, 'G', 15, 64)
 			}
+			return f.formattedValue(&xlsxC{S: c.S, V: val}, raw, CellTypeNumber)
 		}
 		return f.formattedValue(c, raw, CellTypeNumber)
 	}
@@ -773,6 +1023,7 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 		return f.deleteCalcChain(f.getSheetID(sheet), cell)
 	}
 
+	formula = localizedFormulaToInvariant(formula, f.options.CultureInfo)
 	if c.F != nil {
 		c.F.Content = formula
 	} else {
@@ -799,6 +1050,64 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 	return err
 }
 
+// cultureFormulaSeparators defined the argument separator and decimal
+// separator of the formulas for the cultures which not use the comma as the
+// argument separator.
+var cultureFormulaSeparators = map[CultureName][2]rune{
+	CultureNameDeDE: {';', ','},
+	CultureNameFrFR: {';', ','},
+}
+
+// localizedFormulaToInvariant convert the formula with the argument separator
+// and decimal separator of the given culture to the invariant formula, which
+// uses the comma as the argument separator and the period as the decimal
+// separator. The separators in the array constants will be converted too, the
+// string literals, quoted worksheet names and bracketed names (such as the
+// external workbook and table column names) in the formula will not be
+// changed, and only the separators between the items of structured references
+// will be converted.
+func localizedFormulaToInvariant(formula string, culture CultureName) string {
+	separators, ok := cultureFormulaSeparators[culture]
+	if !ok {
+		return formula
+	}
+	var (
+		buf         strings.Builder
+		quote, last rune
+		depth       int
+	)
+	for _, r := range formula {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case depth > 0:
+			switch {
+			case r == '[':
+				depth++
+			case r == ']':
+				depth--
+			case depth == 1 && last == ']' && r == separators[0]:
+				r = ','
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '[':
+			depth = 1
+		case r == separators[0]:
+			r = ','
+		case r == separators[1]:
+			r = '.'
+		}
+		buf.WriteRune(r)
+		if r != ' ' {
+			last = r
+		}
+	}
+	return buf.String()
+}
+
 // setSharedFormula set shared formula for the cells.
 func (ws *xlsxWorksheet) setSharedFormula(ref string) error {
 	coordinates, err := rangeRefToCoordinates(ref)
@@ -809,8 +1118,8 @@ func (ws *xlsxWorksheet) setSharedFormula(ref string) error {
 	cnt := ws.countSharedFormula()
 	for c := coordinates[0]; c <= coordinates[2]; c++ {
 		for r := coordinates[1]; r <= coordinates[3]; r++ {
-			ws.prepareSheetXML(c, r)
-			cell := &ws.SheetData.Row[r-1].C[c-1]
+			rowIdx, colIdx := ws.prepareSheetXML(c, r)
+			cell := &ws.SheetData.Row[rowIdx].C[colIdx]
 			if cell.F == nil {
 				cell.F = &xlsxF{}
 			}
@@ -846,7 +1155,7 @@ func (f *File) GetCellHyperLink(sheet, cell string) (bool, string, error) {
 	if _, _, err := SplitCellName(cell); err != nil {
 		return false, "", err
 	}
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		return false, "", err
 	}
@@ -867,6 +1176,128 @@ func (f *File) GetCellHyperLink(sheet, cell string) (bool, string, error) {
 	return false, "", err
 }
 
+// Hyperlink directly maps the settings of the hyperlink in the worksheet. The
+// Cell is the cell reference or range reference of the hyperlink, the Link is
+// the URL for the "External" link type, or the location in the workbook for
+// the "Location" link type.
+type Hyperlink struct {
+	Cell     string
+	Link     string
+	LinkType string
+	Display  string
+	Tooltip  string
+}
+
+// GetSheetHyperlinks provides a function to get all hyperlinks in a worksheet
+// by given worksheet name. For example, get all hyperlinks on Sheet1:
+//
+//	links, err := f.GetSheetHyperlinks("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, link := range links {
+//	    fmt.Println(link.Cell, link.LinkType, link.Link, link.Display, link.Tooltip)
+//	}
+func (f *File) GetSheetHyperlinks(sheet string) ([]Hyperlink, error) {
+	ws, err := f.workSheetViewer(sheet)
+	if err != nil {
+		return nil, err
+	}
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+	var links []Hyperlink
+	if ws.Hyperlinks == nil {
+		return links, err
+	}
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		hyperlink := Hyperlink{
+			Cell:     link.Ref,
+			Link:     link.Location,
+			LinkType: "Location",
+			Display:  link.Display,
+			Tooltip:  link.Tooltip,
+		}
+		if link.RID != "" {
+			hyperlink.Link, hyperlink.LinkType = f.getSheetRelationshipsTargetByID(sheet, link.RID), "External"
+		}
+		links = append(links, hyperlink)
+	}
+	return links, err
+}
+
+// DeleteCellHyperLink provides a function to delete the hyperlink of a cell
+// by given worksheet name and cell reference, the relationship of the external
+// link will be deleted too. The hyperlink which covers the cell will be
+// deleted, and the range reference can be used to delete the hyperlink which
+// was set across the range. For example, delete the hyperlink of the cell A3
+// on Sheet1:
+//
+//	err := f.DeleteCellHyperLink("Sheet1", "A3")
+func (f *File) DeleteCellHyperLink(sheet, cell string) error {
+	ref, err := f.parseHyperlinkRef(cell)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if !strings.Contains(cell, ":") {
+		if ref, err = ws.mergeCellsParser(ref); err != nil {
+			return err
+		}
+	}
+	f.deleteHyperlinks(ws, sheet, func(link xlsxHyperlink) bool {
+		if link.Ref == ref || strings.Contains(ref, ":") {
+			return link.Ref == ref
+		}
+		ok, _ := f.checkCellInRangeRef(ref, link.Ref)
+		return ok
+	})
+	return err
+}
+
+// parseHyperlinkRef provides a function to parse the cell reference or range
+// reference of the hyperlink, and returns the normalized reference.
+func (f *File) parseHyperlinkRef(ref string) (string, error) {
+	if !strings.Contains(ref, ":") {
+		_, _, err := SplitCellName(ref)
+		return ref, err
+	}
+	coordinates, err := rangeRefToCoordinates(ref)
+	if err != nil {
+		return ref, err
+	}
+	_ = sortCoordinates(coordinates)
+	return f.coordinatesToRangeRef(coordinates)
+}
+
+// deleteHyperlinks provides a function to delete the hyperlinks and the
+// relationships of the external links in the worksheet by given function to
+// check if the hyperlink should be deleted.
+func (f *File) deleteHyperlinks(ws *xlsxWorksheet, sheet string, fn func(link xlsxHyperlink) bool) {
+	if ws.Hyperlinks == nil {
+		return
+	}
+	for i := 0; i < len(ws.Hyperlinks.Hyperlink); i++ {
+		link := ws.Hyperlinks.Hyperlink[i]
+		if !fn(link) {
+			continue
+		}
+		if link.RID != "" {
+			f.deleteSheetRelationships(sheet, link.RID)
+		}
+		ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink[:i], ws.Hyperlinks.Hyperlink[i+1:]...)
+		i--
+	}
+	if len(ws.Hyperlinks.Hyperlink) == 0 {
+		ws.Hyperlinks = nil
+	}
+}
+
 // HyperlinkOpts can be passed to SetCellHyperlink to set optional hyperlink
 // attributes (e.g. display value)
 type HyperlinkOpts struct {
@@ -903,9 +1334,15 @@ type HyperlinkOpts struct {
 // This is another example for "Location":
 //
 //	err := f.SetCellHyperLink("Sheet1", "A3", "Sheet1!A40", "Location")
+//
+// The range reference can be used to set one hyperlink across the range, for
+// example, set the hyperlink for the range A3:C5 on Sheet1:
+//
+//	err := f.SetCellHyperLink("Sheet1", "A3:C5", "Sheet1!A40", "Location")
 func (f *File) SetCellHyperLink(sheet, cell, link, linkType string, opts ...HyperlinkOpts) error {
-	// Check for correct cell name
-	if _, _, err := SplitCellName(cell); err != nil {
+	// Check for correct cell name or range reference
+	cell, err := f.parseHyperlinkRef(cell)
+	if err != nil {
 		return err
 	}
 
@@ -913,8 +1350,10 @@ func (f *File) SetCellHyperLink(sheet, cell, link, linkType string, opts ...Hype
 	if err != nil {
 		return err
 	}
-	if cell, err = ws.mergeCellsParser(cell); err != nil {
-		return err
+	if !strings.Contains(cell, ":") {
+		if cell, err = ws.mergeCellsParser(cell); err != nil {
+			return err
+		}
 	}
 
 	var linkData xlsxHyperlink
@@ -969,22 +1408,100 @@ func (f *File) SetCellHyperLink(sheet, cell, link, linkType string, opts ...Hype
 	return err
 }
 
-// getCellRichText returns rich text of cell by given string item.
-func getCellRichText(si *xlsxSI) (runs []RichTextRun) {
-	for _, v := range si.R {
-		run := RichTextRun{
-			Text: v.T.Val,
-		}
-		if v.RPr != nil {
-			run.Font = newFont(v.RPr)
-		}
-		runs = append(runs, run)
+// ClearOptions directly maps the settings of the parts of cells to be cleared
+// by the ClearCells function.
+type ClearOptions struct {
+	Contents   bool
+	Formats    bool
+	Comments   bool
+	Hyperlinks bool
+}
+
+// ClearCells provides a function to clear the parts of cells in a range by
+// given worksheet name, range reference and clear options. The Contents
+// option removes the values and formulas of the cells, the Formats option
+// removes the styles of the cells, the Comments option deletes the comments
+// in the cells, and the Hyperlinks option deletes the hyperlinks in the
+// cells. For example, clear the values, formulas and styles of the cells in
+// the range A1:C10 on Sheet1, and keep the comments and hyperlinks:
+//
+//	err := f.ClearCells("Sheet1", "A1:C10", excelize.ClearOptions{
+//	    Contents: true,
+//	    Formats:  true,
+//	})
+func (f *File) ClearCells(sheet, rangeRef string, opts ClearOptions) error {
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef += ":" + rangeRef
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for r := range ws.SheetData.Row {
+		rowData := &ws.SheetData.Row[r]
+		if (!opts.Contents && !opts.Formats) || rowData.R < coordinates[1] || rowData.R > coordinates[3] {
+			continue
+		}
+		for i := range rowData.C {
+			c := &rowData.C[i]
+			col, _, err := CellNameToCoordinates(c.R)
+			if err != nil || col < coordinates[0] || col > coordinates[2] {
+				continue
+			}
+			if opts.Contents {
+				if err = f.removeFormula(c, ws, sheet); err != nil {
+					return err
+				}
+				c.T, c.V, c.IS, c.XMLSpace = "", "", nil, xml.Attr{}
+			}
+			if opts.Formats {
+				c.S = 0
+			}
+		}
+	}
+	if opts.Hyperlinks {
+		f.deleteHyperlinks(ws, sheet, func(link xlsxHyperlink) bool {
+			col, row, err := CellNameToCoordinates(strings.Split(link.Ref, ":")[0])
+			return err == nil && cellInRange([]int{col, row}, coordinates)
+		})
+	}
+	if opts.Comments {
+		return f.deleteComments(sheet, func(ref string) bool {
+			col, row, err := CellNameToCoordinates(ref)
+			return err == nil && cellInRange([]int{col, row}, coordinates)
+		})
+	}
+	return err
+}
+
+// getCellRichText returns rich text of cell by given string item.
+func getCellRichText(si *xlsxSI) (runs []RichTextRun) {
+	for _, v := range si.R {
+		run := RichTextRun{
+			Text: v.T.Val,
+		}
+		if v.RPr != nil {
+			run.Font = newFont(v.RPr)
+		}
+		runs = append(runs, run)
 	}
 	return
 }
 
 // GetCellRichText provides a function to get rich text of cell by given
-// worksheet.
+// worksheet. Both the shared string cells and the inline string cells are
+// supported, and the color theme, color index and vertical alignment of each
+// run will be returned in the font.
 func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err error) {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -994,11 +1511,17 @@ func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err erro
 	if err != nil {
 		return
 	}
+	if c.T == "inlineStr" {
+		if c.IS != nil {
+			runs = getCellRichText(c.IS)
+		}
+		return
+	}
 	siIdx, err := strconv.Atoi(c.V)
 	if err != nil || c.T != "s" {
 		return
 	}
-	sst, err := f.sharedStringsReader()
+	sst, err := f.sharedStringsViewer()
 	if err != nil {
 		return
 	}
@@ -1028,7 +1551,16 @@ func newRpr(fnt *Font) *xlsxRPr {
 	if fnt.Family != "" {
 		rpr.RFont = &attrValString{Val: &fnt.Family}
 	}
-	if inStrSlice([]string{"baseline", "superscript", "subscript"}, fnt.VertAlign, true) != -1 {
+	if fnt.Condense {
+		rpr.Condense = &trueVal
+	}
+	if fnt.Extend {
+		rpr.Extend = &trueVal
+	}
+	if fnt.Charset != nil {
+		rpr.Charset = &attrValInt{Val: fnt.Charset}
+	}
+	if inStrSlice(supportedVertAlignTypes, fnt.VertAlign, true) != -1 {
 		rpr.VertAlign = &attrValString{Val: &fnt.VertAlign}
 	}
 	if fnt.Size > 0 {
@@ -1056,6 +1588,14 @@ func newFont(rPr *xlsxRPr) *Font {
 		font.Size = *rPr.Sz.Val
 	}
 	font.Strike = rPr.Strike != nil
+	font.Condense = rPr.Condense != nil
+	font.Extend = rPr.Extend != nil
+	if rPr.Charset != nil && rPr.Charset.Val != nil {
+		font.Charset = intPtr(*rPr.Charset.Val)
+	}
+	if rPr.VertAlign != nil && rPr.VertAlign.Val != nil {
+		font.VertAlign = *rPr.VertAlign.Val
+	}
 	if rPr.Color != nil {
 		font.Color = strings.TrimPrefix(rPr.Color.RGB, "FF")
 		if rPr.Color.Theme != nil {
@@ -1246,6 +1786,166 @@ func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
 	return err
 }
 
+// getCellPhonetic provides a function to get the phonetic settings by given
+// string item.
+func getCellPhonetic(si *xlsxSI) (phonetic Phonetic) {
+	if si.PhoneticPr != nil {
+		phonetic.Type, phonetic.Alignment = si.PhoneticPr.Type, si.PhoneticPr.Alignment
+	}
+	for _, rPh := range si.RPh {
+		phonetic.Runs = append(phonetic.Runs, PhoneticRun{
+			Start: int(rPh.Sb), End: int(rPh.Eb), Text: rPh.T,
+		})
+	}
+	return
+}
+
+// GetCellPhonetic provides a function to get the phonetic text (such as
+// furigana for the Japanese) settings of the string cell by given worksheet
+// name and cell reference.
+func (f *File) GetCellPhonetic(sheet, cell string) (Phonetic, error) {
+	var phonetic Phonetic
+	_, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		switch c.T {
+		case "inlineStr":
+			if c.IS != nil {
+				phonetic = getCellPhonetic(c.IS)
+			}
+		case "s":
+			siIdx, err := strconv.Atoi(c.V)
+			if err != nil {
+				return "", true, err
+			}
+			sst, err := f.sharedStringsViewer()
+			if err != nil {
+				return "", true, err
+			}
+			if siIdx >= 0 && siIdx < len(sst.SI) {
+				phonetic = getCellPhonetic(&sst.SI[siIdx])
+			}
+		}
+		phonetic.Show = c.Ph != nil && *c.Ph
+		return "", true, nil
+	})
+	return phonetic, err
+}
+
+// setCellPhonetic provides a function to set the phonetic settings for the
+// string item.
+func setCellPhonetic(si *xlsxSI, phonetic Phonetic) error {
+	chars := len([]rune(si.String()))
+	si.RPh, si.PhoneticPr = nil, nil
+	for _, run := range phonetic.Runs {
+		if run.Start < 0 || run.End < run.Start || run.End > chars {
+			return ErrParameterInvalid
+		}
+		si.RPh = append(si.RPh, &xlsxPhoneticRun{
+			Sb: uint32(run.Start), Eb: uint32(run.End), T: run.Text,
+		})
+	}
+	if len(si.RPh) > 0 || phonetic.Type != "" || phonetic.Alignment != "" {
+		si.PhoneticPr = &xlsxPhoneticPr{
+			FontID: intPtr(0), Type: phonetic.Type, Alignment: phonetic.Alignment,
+		}
+	}
+	return nil
+}
+
+// SetCellPhonetic provides a function to set the phonetic text (such as
+// furigana for the Japanese) of the string cell by given worksheet name, cell
+// reference and phonetic settings. The phonetic settings will be removed if
+// there are no phonetic runs and type or alignment settings. The optional
+// value of the 'Type' is "halfwidthKatakana", "fullwidthKatakana", "Hiragana"
+// and "noConversion", and the optional value of the 'Alignment' is
+// "noControl", "left", "center" and "distributed". For example, set the
+// phonetic text for the cell A1 with the value "東京" on Sheet1 and show it:
+//
+//	err := f.SetCellPhonetic("Sheet1", "A1", excelize.Phonetic{
+//	    Show: true,
+//	    Type: "Hiragana",
+//	    Runs: []excelize.PhoneticRun{
+//	        {Start: 0, End: 1, Text: "とう"},
+//	        {Start: 1, End: 2, Text: "きょう"},
+//	    },
+//	})
+func (f *File) SetCellPhonetic(sheet, cell string, phonetic Phonetic) error {
+	if phonetic.Type != "" && inStrSlice(supportedPhoneticTypes, phonetic.Type, true) == -1 {
+		return ErrParameterInvalid
+	}
+	if phonetic.Alignment != "" && inStrSlice(supportedPhoneticAlignments, phonetic.Alignment, true) == -1 {
+		return ErrParameterInvalid
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	c, _, _, err := ws.prepareCell(cell)
+	if err != nil {
+		return err
+	}
+	switch c.T {
+	case "inlineStr":
+		if c.IS == nil {
+			c.IS = &xlsxSI{}
+		}
+		err = setCellPhonetic(c.IS, phonetic)
+	case "s":
+		err = f.setSharedStringPhonetic(c, phonetic)
+	default:
+		return ErrPhoneticCellValue
+	}
+	if err != nil {
+		return err
+	}
+	c.Ph = nil
+	if phonetic.Show {
+		c.Ph = boolPtr(true)
+	}
+	return err
+}
+
+// setSharedStringPhonetic provides a function to set the phonetic settings
+// for the shared string cell. A new string item will be used to avoid
+// changing the other cells which referenced the same string item.
+func (f *File) setSharedStringPhonetic(c *xlsxC, phonetic Phonetic) error {
+	siIdx, err := strconv.Atoi(c.V)
+	if err != nil {
+		return err
+	}
+	if err = f.sharedStringsLoader(); err != nil {
+		return err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	sst.mu.Lock()
+	defer sst.mu.Unlock()
+	if siIdx < 0 || siIdx >= len(sst.SI) {
+		return ErrPhoneticCellValue
+	}
+	si := xlsxSI{T: sst.SI[siIdx].T, R: sst.SI[siIdx].R}
+	if err = setCellPhonetic(&si, phonetic); err != nil {
+		return err
+	}
+	for idx, strItem := range sst.SI {
+		if reflect.DeepEqual(strItem, si) {
+			c.V = strconv.Itoa(idx)
+			return err
+		}
+	}
+	sst.SI = append(sst.SI, si)
+	sst.Count++
+	sst.UniqueCount++
+	c.V = strconv.Itoa(len(sst.SI) - 1)
+	return err
+}
+
 // SetSheetRow writes an array to row by given worksheet name, starting
 // cell reference and a pointer to array type 'slice'. This function is
 // concurrency safe. For example, writes an array to row 6 start with the cell
@@ -1257,14 +1957,219 @@ func (f *File) SetSheetRow(sheet, cell string, slice interface{}) error {
 }
 
 // SetSheetCol writes an array to column by given worksheet name, starting
-// cell reference and a pointer to array type 'slice'. For example, writes an
-// array to column B start with the cell B6 on Sheet1:
+// cell reference and a pointer to array type 'slice'. Each element will be
+// written downwards with the same data types handling as SetCellValue, and
+// nothing will be written if the array exceeds the maximum rows limit. This
+// function is concurrency safe. For example, writes an array to column B
+// start with the cell B6 on Sheet1:
 //
 //	err := f.SetSheetCol("Sheet1", "B6", &[]interface{}{"1", nil, 2})
 func (f *File) SetSheetCol(sheet, cell string, slice interface{}) error {
 	return f.setSheetCells(sheet, cell, slice, columns)
 }
 
+// SetRows writes a two-dimensional array to the rows by given worksheet name
+// and starting cell reference. Each element of the 'values' will be written
+// to a row, from the starting cell towards the right, with the same data
+// types handling as SetCellValue. The worksheet will be prepared once, and
+// all string values will be added into the shared string table in bulk, so
+// it's faster than calling SetSheetRow for each row. This function is
+// concurrency safe. For example, writes two rows start with the cell B6 on
+// Sheet1:
+//
+//	err := f.SetRows("Sheet1", "B6", [][]interface{}{
+//	    {"Name", "Score", "Date"},
+//	    {"Bob", 95.5, time.Now()},
+//	})
+func (f *File) SetRows(sheet, cell string, values [][]interface{}) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	var (
+		maxCols int
+		strs    []string
+		scratch xlsxC
+	)
+	collect := func(str string) (int, error) {
+		strs = append(strs, str)
+		return len(strs) - 1, nil
+	}
+	for _, rowValues := range values {
+		if len(rowValues) > maxCols {
+			maxCols = len(rowValues)
+		}
+		for _, value := range rowValues {
+			if _, err = f.setCellValueOf(&scratch, value, collect); err != nil {
+				return err
+			}
+		}
+	}
+	if len(values) == 0 || maxCols == 0 {
+		return err
+	}
+	if col+maxCols-1 > MaxColumns {
+		return ErrColumnNumber
+	}
+	if row+len(values)-1 > TotalRows {
+		return ErrMaxRows
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	si, err := f.setSharedStrings(strs)
+	if err != nil {
+		return err
+	}
+	sharedString := func(string) (int, error) {
+		idx := si[0]
+		si = si[1:]
+		return idx, nil
+	}
+	if rows := len(ws.SheetData.Row) + len(values); cap(ws.SheetData.Row) < rows {
+		sheetData := make([]xlsxRow, len(ws.SheetData.Row), rows)
+		copy(sheetData, ws.SheetData.Row)
+		ws.SheetData.Row = sheetData
+	}
+	merged := ws.MergeCells != nil && len(ws.MergeCells.Cells) > 0
+	timeStyles := map[int]int{}
+	for r, rowValues := range values {
+		for i, value := range rowValues {
+			cellCol, cellRow := col+i, row+r
+			rowIdx, colIdx := ws.prepareSheetXML(cellCol, cellRow)
+			c := &ws.SheetData.Row[rowIdx].C[colIdx]
+			if merged {
+				cell, _ := CoordinatesToCellName(cellCol, cellRow)
+				if c, cellCol, cellRow, err = ws.prepareCell(cell); err != nil {
+					return err
+				}
+			}
+			c.S = ws.prepareCellStyle(cellCol, cellRow, c.S)
+			var numFmt int
+			if numFmt, err = f.setCellValueOf(c, value, sharedString); err != nil {
+				return err
+			}
+			if numFmt != 0 && c.S == 0 {
+				if _, ok := timeStyles[numFmt]; !ok {
+					if timeStyles[numFmt], err = f.NewStyle(&Style{NumFmt: numFmt}); err != nil {
+						return err
+					}
+				}
+				c.S = timeStyles[numFmt]
+			}
+			if err = f.removeFormula(c, ws, sheet); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// SetSheetData writes a slice of structs to the worksheet by given worksheet
+// name, starting cell reference and a slice or a pointer to slice of structs
+// or struct pointers. The header row will be written at the starting cell,
+// and each element of the slice will be written to a row after the header.
+// The header text and the number format of each column can be specified by
+// the 'xlsx' key in the struct field's tag value, the header text is the
+// first part of the tag value and the number format code is the rest part
+// after the first comma. The field name will be used as the header text if
+// the header not specified, and the field with the tag value "-" or
+// unexported field will be skipped. The cells of the row for a nil struct
+// pointer will be cleared. For example, writes the orders start with the cell
+// A1 on Sheet1:
+//
+//	type Order struct {
+//	    ID     int       `xlsx:"Order ID"`
+//	    Amount float64   `xlsx:"Amount,#,##0.00"`
+//	    Date   time.Time `xlsx:"Date,yyyy-mm-dd"`
+//	    Note   string    `xlsx:"-"`
+//	}
+//	err := f.SetSheetData("Sheet1", "A1", []Order{
+//	    {ID: 1, Amount: 1200.5, Date: time.Now()},
+//	    {ID: 2, Amount: 86.25, Date: time.Now()},
+//	})
+func (f *File) SetSheetData(sheet, cell string, slice interface{}) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(slice)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return ErrParameterInvalid
+	}
+	typ := v.Type().Elem()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return ErrParameterInvalid
+	}
+	var (
+		fields  []int
+		header  []interface{}
+		formats []string
+	)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("xlsx")
+		if field.PkgPath != "" || tag == "-" {
+			continue
+		}
+		opts := strings.SplitN(tag, ",", 2)
+		if opts[0] == "" {
+			opts[0] = field.Name
+		}
+		if len(opts) == 1 {
+			opts = append(opts, "")
+		}
+		fields, header, formats = append(fields, i), append(header, opts[0]), append(formats, opts[1])
+	}
+	values := make([][]interface{}, 0, v.Len()+1)
+	values = append(values, header)
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				values = append(values, make([]interface{}, len(fields)))
+				continue
+			}
+			elem = elem.Elem()
+		}
+		rowValues := make([]interface{}, len(fields))
+		for j, idx := range fields {
+			rowValues[j] = elem.Field(idx).Interface()
+		}
+		values = append(values, rowValues)
+	}
+	if err = f.SetRows(sheet, cell, values); err != nil || v.Len() == 0 {
+		return err
+	}
+	for i, format := range formats {
+		if format == "" {
+			continue
+		}
+		styleID, err := f.NewStyle(&Style{CustomNumFmt: &format})
+		if err != nil {
+			return err
+		}
+		topLeftCell, _ := CoordinatesToCellName(col+i, row+1)
+		bottomRightCell, _ := CoordinatesToCellName(col+i, row+v.Len())
+		if err = f.SetCellStyle(sheet, topLeftCell, bottomRightCell, styleID); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
 // setSheetCells provides a function to set worksheet cells value.
 func (f *File) setSheetCells(sheet, cell string, slice interface{}, dir adjustDirection) error {
 	col, row, err := CellNameToCoordinates(cell)
@@ -1277,6 +2182,12 @@ func (f *File) setSheetCells(sheet, cell string, slice interface{}, dir adjustDi
 		return ErrParameterInvalid
 	}
 	v = v.Elem()
+	if dir == rows && col+v.Len()-1 > MaxColumns {
+		return ErrColumnNumber
+	}
+	if dir == columns && row+v.Len()-1 > TotalRows {
+		return ErrMaxRows
+	}
 	for i := 0; i < v.Len(); i++ {
 		var cell string
 		var err error
@@ -1297,6 +2208,182 @@ func (f *File) setSheetCells(sheet, cell string, slice interface{}, dir adjustDi
 	return err
 }
 
+// CopyRange provides a function to copy the values, styles, formulas and
+// merged cells of a cell range by given source worksheet name, source range
+// reference, destination worksheet name, top-left cell reference of the
+// destination range and copy options. The relative references of the formulas
+// will be adjusted according to the offset of the destination cells, and the
+// existing cells in the destination range will be overwritten. The source and
+// destination worksheet must be in the same workbook. For example, copy the
+// range A1:C3 on Sheet1 to Sheet2 start with the cell E5, and switch the rows
+// and columns of the range:
+//
+//	err := f.CopyRange("Sheet1", "A1:C3", "Sheet2", "E5", &excelize.CopyOptions{
+//	    Transpose: true,
+//	})
+func (f *File) CopyRange(srcSheet, srcRef, dstSheet, dstCell string, opts *CopyOptions) error {
+	if opts == nil {
+		opts = &CopyOptions{}
+	}
+	if !strings.Contains(srcRef, ":") {
+		srcRef += ":" + srcRef
+	}
+	rect, err := rangeRefToCoordinates(srcRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(rect)
+	col, row, err := CellNameToCoordinates(dstCell)
+	if err != nil {
+		return err
+	}
+	cols, rows := rect[2]-rect[0], rect[3]-rect[1]
+	if opts.Transpose {
+		cols, rows = rows, cols
+	}
+	if col+cols > MaxColumns {
+		return ErrColumnNumber
+	}
+	if row+rows > TotalRows {
+		return ErrMaxRows
+	}
+	srcWs, err := f.workSheetReader(srcSheet)
+	if err != nil {
+		return err
+	}
+	dstWs, err := f.workSheetReader(dstSheet)
+	if err != nil {
+		return err
+	}
+	// destination provides a function to get the destination coordinates of
+	// the source cell.
+	destination := func(c, r int) (int, int) {
+		if opts.Transpose {
+			return col + r - rect[1], row + c - rect[0]
+		}
+		return col + c - rect[0], row + r - rect[1]
+	}
+	cells := f.copyRangeCells(srcWs, rect, destination)
+	for _, c := range cells {
+		dstCol, dstRow, _ := CellNameToCoordinates(c.R)
+		rowIdx, colIdx := dstWs.prepareSheetXML(dstCol, dstRow)
+		cell := &dstWs.SheetData.Row[rowIdx].C[colIdx]
+		if err = f.removeFormula(cell, dstWs, dstSheet); err != nil {
+			return err
+		}
+		*cell = c
+	}
+	return f.copyRangeMergeCells(srcWs, dstWs, dstSheet, rect, destination)
+}
+
+// copyRangeCells returns the copy of cells in the source range with the
+// destination cell references, the relative references of the formulas will
+// be adjusted, and the shared formulas will be converted to normal formulas.
+func (f *File) copyRangeCells(ws *xlsxWorksheet, rect []int, destination func(c, r int) (int, int)) []xlsxC {
+	srcCells := make(map[string]xlsxC)
+	for _, r := range ws.SheetData.Row {
+		if r.R < rect[1] || r.R > rect[3] {
+			continue
+		}
+		for _, c := range r.C {
+			srcCells[c.R] = c
+		}
+	}
+	cells := make([]xlsxC, 0, (rect[2]-rect[0]+1)*(rect[3]-rect[1]+1))
+	for r := rect[1]; r <= rect[3]; r++ {
+		for c := rect[0]; c <= rect[2]; c++ {
+			cellName, _ := CoordinatesToCellName(c, r)
+			dstCol, dstRow := destination(c, r)
+			cell := deepcopy.Copy(srcCells[cellName]).(xlsxC)
+			cell.R, _ = CoordinatesToCellName(dstCol, dstRow)
+			if cell.F != nil {
+				formula := cell.F.Content
+				if cell.F.T == STCellFormulaTypeShared && cell.F.Si != nil {
+					formula = getSharedFormula(ws, *cell.F.Si, cellName)
+					cell.F = &xlsxF{}
+				}
+				orig := []byte(formula)
+				res, start := parseSharedFormula(dstCol-c, dstRow-r, orig)
+				if start < len(orig) {
+					res += string(orig[start:])
+				}
+				cell.F.Content = res
+				if cell.F.Ref != "" {
+					cell.F.Ref = copyRangeRef(cell.F.Ref, destination)
+				}
+			}
+			cells = append(cells, cell)
+		}
+	}
+	return cells
+}
+
+// copyRangeMergeCells merge cells in the destination range if there are merged
+// cells in the copied range, and the merged cells which overlap with the
+// destination range will be removed.
+func (f *File) copyRangeMergeCells(srcWs, dstWs *xlsxWorksheet, dstSheet string, rect []int, destination func(c, r int) (int, int)) error {
+	var refs []string
+	if srcWs.MergeCells != nil {
+		for _, mergeCell := range srcWs.MergeCells.Cells {
+			if mergeCell == nil {
+				continue
+			}
+			coordinates, err := rangeRefToCoordinates(mergeCell.Ref)
+			if err != nil {
+				return err
+			}
+			_ = sortCoordinates(coordinates)
+			if cellInRange(coordinates[:2], rect) && cellInRange(coordinates[2:], rect) {
+				refs = append(refs, copyRangeRef(mergeCell.Ref, destination))
+			}
+		}
+	}
+	if dstWs.MergeCells != nil {
+		x1, y1 := destination(rect[0], rect[1])
+		x2, y2 := destination(rect[2], rect[3])
+		for i := 0; i < len(dstWs.MergeCells.Cells); i++ {
+			mergeCell := dstWs.MergeCells.Cells[i]
+			if mergeCell == nil {
+				continue
+			}
+			coordinates, err := rangeRefToCoordinates(mergeCell.Ref)
+			if err != nil {
+				return err
+			}
+			_ = sortCoordinates(coordinates)
+			if isOverlap(coordinates, []int{x1, y1, x2, y2}) {
+				dstWs.MergeCells.Cells = append(dstWs.MergeCells.Cells[:i], dstWs.MergeCells.Cells[i+1:]...)
+				i--
+			}
+		}
+		if dstWs.MergeCells.Count = len(dstWs.MergeCells.Cells); dstWs.MergeCells.Count == 0 {
+			dstWs.MergeCells = nil
+		}
+	}
+	for _, ref := range refs {
+		cells := strings.Split(ref, ":")
+		if err := f.MergeCell(dstSheet, cells[0], cells[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyRangeRef returns the destination range reference of the source range
+// reference.
+func copyRangeRef(ref string, destination func(c, r int) (int, int)) string {
+	coordinates, err := rangeRefToCoordinates(ref)
+	if err != nil {
+		return ref
+	}
+	coordinates[0], coordinates[1] = destination(coordinates[0], coordinates[1])
+	coordinates[2], coordinates[3] = destination(coordinates[2], coordinates[3])
+	_ = sortCoordinates(coordinates)
+	from, _ := CoordinatesToCellName(coordinates[0], coordinates[1])
+	to, _ := CoordinatesToCellName(coordinates[2], coordinates[3])
+	return from + ":" + to
+}
+
 // getCellInfo does common preparation for all set cell value functions.
 func (ws *xlsxWorksheet) prepareCell(cell string) (*xlsxC, int, int, error) {
 	var err error
@@ -1309,8 +2396,8 @@ func (ws *xlsxWorksheet) prepareCell(cell string) (*xlsxC, int, int, error) {
 		return nil, 0, 0, err
 	}
 
-	ws.prepareSheetXML(col, row)
-	return &ws.SheetData.Row[row-1].C[col-1], col, row, err
+	rowIdx, colIdx := ws.prepareSheetXML(col, row)
+	return &ws.SheetData.Row[rowIdx].C[colIdx], col, row, err
 }
 
 // getCellStringFunc does common value extraction workflow for all get cell
@@ -1318,14 +2405,14 @@ func (ws *xlsxWorksheet) prepareCell(cell string) (*xlsxC, int, int, error) {
 // logic.
 func (f *File) getCellStringFunc(sheet, cell string, fn func(x *xlsxWorksheet, c *xlsxC) (string, bool, error)) (string, error) {
 	f.mu.Lock()
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		f.mu.Unlock()
 		return "", err
 	}
 	f.mu.Unlock()
-	ws.mu.Lock()
-	defer ws.mu.Unlock()
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
 	cell, err = ws.mergeCellsParser(cell)
 	if err != nil {
 		return "", err
@@ -1334,21 +2421,12 @@ func (f *File) getCellStringFunc(sheet, cell string, fn func(x *xlsxWorksheet, c
 	if err != nil {
 		return "", err
 	}
-	lastRowNum := 0
-	if l := len(ws.SheetData.Row); l > 0 {
-		lastRowNum = ws.SheetData.Row[l-1].R
-	}
-
-	// keep in mind: row starts from 1
-	if row > lastRowNum {
+	rowIdx := ws.searchRow(row)
+	if rowIdx == -1 {
 		return "", nil
 	}
-
-	for rowIdx := range ws.SheetData.Row {
+	for ; rowIdx < len(ws.SheetData.Row) && ws.SheetData.Row[rowIdx].R == row; rowIdx++ {
 		rowData := &ws.SheetData.Row[rowIdx]
-		if rowData.R != row {
-			continue
-		}
 		for colIdx := range rowData.C {
 			colData := &rowData.C[colIdx]
 			if cell != colData.R {
@@ -1373,7 +2451,7 @@ func (f *File) formattedValue(c *xlsxC, raw bool, cellType CellType) (string, er
 	if raw || c.S == 0 {
 		return c.V, nil
 	}
-	styleSheet, err := f.stylesReader()
+	styleSheet, err := f.stylesViewer()
 	if err != nil {
 		return c.V, err
 	}
@@ -1387,14 +2465,10 @@ func (f *File) formattedValue(c *xlsxC, raw bool, cellType CellType) (string, er
 	if styleSheet.CellXfs.Xf[c.S].NumFmtID != nil {
 		numFmtID = *styleSheet.CellXfs.Xf[c.S].NumFmtID
 	}
-	date1904 := false
-	wb, err := f.workbookReader()
+	date1904, err := f.getDate1904()
 	if err != nil {
 		return c.V, err
 	}
-	if wb != nil && wb.WorkbookPr != nil {
-		date1904 = wb.WorkbookPr.Date1904
-	}
 	if fmtCode, ok := styleSheet.getCustomNumFmtCode(numFmtID); ok {
 		return format(c.V, fmtCode, date1904, cellType, f.options), err
 	}
@@ -1404,6 +2478,29 @@ func (f *File) formattedValue(c *xlsxC, raw bool, cellType CellType) (string, er
 	return c.V, err
 }
 
+// getCellNumFmtCode provides a function to returns the number format code by
+// given cell style index.
+func (f *File) getCellNumFmtCode(styleIdx int) (string, error) {
+	styleSheet, err := f.stylesViewer()
+	if err != nil {
+		return "", err
+	}
+	if styleSheet.CellXfs == nil || styleIdx >= len(styleSheet.CellXfs.Xf) || styleIdx < 0 {
+		return builtInNumFmt[0], err
+	}
+	var numFmtID int
+	if styleSheet.CellXfs.Xf[styleIdx].NumFmtID != nil {
+		numFmtID = *styleSheet.CellXfs.Xf[styleIdx].NumFmtID
+	}
+	if fmtCode, ok := styleSheet.getCustomNumFmtCode(numFmtID); ok {
+		return fmtCode, err
+	}
+	if fmtCode, ok := f.getBuiltInNumFmtCode(numFmtID); ok {
+		return fmtCode, err
+	}
+	return builtInNumFmt[0], err
+}
+
 // getCustomNumFmtCode provides a function to returns custom number format code.
 func (ss *xlsxStyleSheet) getCustomNumFmtCode(numFmtID int) (string, bool) {
 	if ss.NumFmts == nil {
@@ -1421,14 +2518,16 @@ func (ss *xlsxStyleSheet) getCustomNumFmtCode(numFmtID int) (string, bool) {
 }
 
 // prepareCellStyle provides a function to prepare style index of cell in
-// worksheet by given column index and style index.
+// worksheet by given column index and style index. The style of the row takes
+// precedence over the style of the column, and the style of the row only
+// takes effect when the custom format of the row was set.
 func (ws *xlsxWorksheet) prepareCellStyle(col, row, style int) int {
 	if style != 0 {
 		return style
 	}
-	if row <= len(ws.SheetData.Row) {
-		if styleID := ws.SheetData.Row[row-1].S; styleID != 0 {
-			return styleID
+	if rowIdx := ws.searchRow(row); rowIdx != -1 {
+		if r := ws.SheetData.Row[rowIdx]; r.CustomFormat && r.S != 0 {
+			return r.S
 		}
 	}
 	if ws.Cols != nil {
@@ -1450,6 +2549,8 @@ func (ws *xlsxWorksheet) mergeCellsParser(cell string) (string, error) {
 		return cell, err
 	}
 	if ws.MergeCells != nil {
+		ws.mergeMu.Lock()
+		defer ws.mergeMu.Unlock()
 		for i := 0; i < len(ws.MergeCells.Cells); i++ {
 			if ws.MergeCells.Cells[i] == nil {
 				ws.MergeCells.Cells = append(ws.MergeCells.Cells[:i], ws.MergeCells.Cells[i+1:]...)
@@ -1,9 +1,11 @@
 package excelize
 
 import (
+	"errors"
 	"fmt"
 	_ "image/jpeg"
 	"math"
+	"math/big"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -101,6 +103,48 @@ func TestConcurrency(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestConcurrencySheets(t *testing.T) {
+	f := NewFile()
+	sheets := []string{"Sheet1", "Sheet2", "Sheet3", "Sheet4"}
+	for _, sheet := range sheets[1:] {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.MergeCell("Sheet1", "D1", "E2"))
+	wg := new(sync.WaitGroup)
+	for _, sheet := range sheets {
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func(sheet string, col int) {
+				defer wg.Done()
+				for row := 1; row <= 50; row++ {
+					cell, err := CoordinatesToCellName(col+1, row)
+					assert.NoError(t, err)
+					// Concurrency set cell value on different worksheets
+					assert.NoError(t, f.SetCellValue(sheet, cell, fmt.Sprintf("%s-%d", sheet, row)))
+					// Concurrency get cell value on the same worksheet
+					_, err = f.GetCellValue(sheet, cell)
+					assert.NoError(t, err)
+					_, err = f.GetCellValue("Sheet1", "E2")
+					assert.NoError(t, err)
+					_, err = f.GetColWidth(sheet, "A")
+					assert.NoError(t, err)
+					_, err = f.GetRowHeight(sheet, row)
+					assert.NoError(t, err)
+				}
+			}(sheet, i)
+		}
+	}
+	wg.Wait()
+	for _, sheet := range sheets {
+		rows, err := f.GetRows(sheet)
+		assert.NoError(t, err)
+		assert.Len(t, rows, 50)
+		assert.Equal(t, fmt.Sprintf("%s-%d", sheet, 50), rows[49][3])
+	}
+	assert.NoError(t, f.Close())
+}
+
 func TestCheckCellInRangeRef(t *testing.T) {
 	f := NewFile()
 	expectedTrueCellInRangeRefList := [][2]string{
@@ -287,6 +331,79 @@ func TestSetCellBool(t *testing.T) {
 	assert.EqualError(t, f.SetCellBool("Sheet:1", "A1", true), ErrSheetNameInvalid.Error())
 }
 
+type testDecimal struct {
+	coefficient *big.Int
+	exponent    int32
+}
+
+func (d testDecimal) Coefficient() *big.Int { return d.coefficient }
+
+func (d testDecimal) Exponent() int32 { return d.exponent }
+
+func TestSetCellBigNumber(t *testing.T) {
+	f := NewFile()
+	bigInt, ok := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	assert.True(t, ok)
+	bigFloat, ok := new(big.Float).SetPrec(200).SetString("12345678901234567890.123456789")
+	assert.True(t, ok)
+	for cell, value := range map[string]interface{}{
+		"A1": bigInt,
+		"A2": bigFloat,
+		"A3": testDecimal{big.NewInt(12345678901234567), -10},
+		"A4": testDecimal{big.NewInt(-15), -4},
+		"A5": testDecimal{big.NewInt(25), 3},
+		"A6": testDecimal{big.NewInt(0), -2},
+		"A7": new(big.Float).SetInf(false),
+		"A8": (*big.Int)(nil),
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	for cell, expected := range map[string][2]string{
+		"A1": {"-123456789012345678901234567890", "-1.23456789012346E+29"},
+		"A2": {"12345678901234567890.123456789", "1.23456789012346E+19"},
+		"A3": {"1234567.8901234567", "1234567.89012346"},
+		"A4": {"-0.0015", "-0.0015"},
+		"A5": {"25000", "25000"},
+		"A6": {"0", "0"},
+		"A7": {"+Inf", "+Inf"},
+		"A8": {"", ""},
+	} {
+		val, err := f.GetCellValue("Sheet1", cell, Options{FullPrecision: true})
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected[0], val, cell)
+		val, err = f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected[1], val, cell)
+	}
+	cellType, err := f.GetCellType("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeUnset, cellType)
+	// Test read big numbers by rows and columns iterator with full precision
+	rows, err := f.GetRows("Sheet1", Options{FullPrecision: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "-123456789012345678901234567890", rows[0][0])
+	cols, err := f.GetCols("Sheet1", Options{FullPrecision: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "12345678901234567890.123456789", cols[0][1])
+	// Test set big numbers by rows and stream writer
+	assert.NoError(t, f.SetRows("Sheet1", "B1", [][]interface{}{{bigInt, testDecimal{big.NewInt(1), -20}}}))
+	val, err := f.GetCellValue("Sheet1", "C1", Options{FullPrecision: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "0.00000000000000000001", val)
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{bigInt, bigFloat}))
+	assert.NoError(t, sw.Flush())
+	val, err = f.GetCellValue("Sheet1", "B1", Options{FullPrecision: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "12345678901234567890.123456789", val)
+	// Test set big number with invalid sheet name
+	assert.EqualError(t, f.SetCellValue("Sheet:1", "A1", bigInt), ErrSheetNameInvalid.Error())
+	// Test set big number with invalid cell reference
+	assert.EqualError(t, f.SetCellValue("Sheet1", "A", bigInt), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.NoError(t, f.Close())
+}
+
 func TestSetCellTime(t *testing.T) {
 	date, err := time.Parse(time.RFC3339Nano, "2009-11-10T23:00:00Z")
 	assert.NoError(t, err)
@@ -306,6 +423,62 @@ func TestSetCellTime(t *testing.T) {
 	}
 }
 
+func TestSetCellDate(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2023, time.June, 1, 12, 30, 0, 0, time.UTC)
+	assert.NoError(t, f.SetCellDate("Sheet1", "A1", date, "yyyy-mm-dd"))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-06-01", val)
+	val, err = f.GetCellValue("Sheet1", "A1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "45078.520833333336", val)
+	// Test set cell date with default number format
+	assert.NoError(t, f.SetCellDate("Sheet1", "A2", date, ""))
+	val, err = f.GetCellValue("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "6/1/23 12:30", val)
+	// Test set cell date keeps the other formatting of the cell
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}, NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", style))
+	assert.NoError(t, f.SetCellDate("Sheet1", "A3", date, "hh:mm"))
+	val, err = f.GetCellValue("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, "12:30", val)
+	styleID, err := f.GetCellStyle("Sheet1", "A3")
+	assert.NoError(t, err)
+	cellStyle, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.True(t, cellStyle.Font.Bold)
+	assert.Equal(t, "hh:mm", *cellStyle.CustomNumFmt)
+	// Test set cell date in the 1904 date system
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.WorkbookPr.Date1904 = true
+	assert.NoError(t, f.SetCellDate("Sheet1", "A4", date, "yyyy-mm-dd"))
+	val, err = f.GetCellValue("Sheet1", "A4", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "43616.520833333336", val)
+	// Test set cell date before the epoch of the date system
+	assert.NoError(t, f.SetCellDate("Sheet1", "A5", time.Date(1903, time.December, 31, 0, 0, 0, 0, time.UTC), "yyyy-mm-dd"))
+	val, err = f.GetCellValue("Sheet1", "A5")
+	assert.NoError(t, err)
+	assert.Equal(t, "1903-12-31T00:00:00Z", val)
+	// Test set cell date with invalid cell reference
+	assert.EqualError(t, f.SetCellDate("Sheet1", "A", date, ""), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set cell date with invalid sheet name
+	assert.EqualError(t, f.SetCellDate("Sheet:1", "A1", date, ""), ErrSheetNameInvalid.Error())
+	// Test set cell date with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellDate("Sheet1", "A3", date, ""), "XML syntax error on line 1: invalid UTF-8")
+	// Test set cell date with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellDate("Sheet1", "A1", date, ""), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetCellValue(t *testing.T) {
 	// Test get cell value without r attribute of the row
 	f := NewFile()
@@ -478,17 +651,96 @@ func TestGetCellType(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestGetCellValueTyped(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2023, 6, 1, 12, 30, 0, 0, time.UTC)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{true, 42.5, date, "text", nil}))
+	assert.NoError(t, f.SetCellRichText("Sheet1", "F1", []RichTextRun{{Text: "rich"}}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C = append(ws.(*xlsxWorksheet).SheetData.Row[0].C,
+		xlsxC{R: "G1", T: "e", V: "#DIV/0!"},
+		xlsxC{R: "H1", T: "d", V: "2023-06-01T12:30:00Z"},
+		xlsxC{R: "I1", T: "d", V: "date"},
+		xlsxC{R: "J1", T: "str", V: "result", F: &xlsxF{Content: "\"result\""}},
+		xlsxC{R: "K1", V: "NaN1"},
+		xlsxC{R: "L1", T: "inlineStr", IS: &xlsxSI{T: &xlsxT{Val: "inline"}}},
+	)
+	for _, expected := range []struct {
+		cell     string
+		value    interface{}
+		cellType CellType
+	}{
+		{"A1", true, CellTypeBool},
+		{"B1", 42.5, CellTypeNumber},
+		{"C1", date, CellTypeDate},
+		{"D1", "text", CellTypeSharedString},
+		{"E1", nil, CellTypeUnset},
+		{"F1", "rich", CellTypeSharedString},
+		{"G1", errors.New("#DIV/0!"), CellTypeError},
+		{"H1", date, CellTypeDate},
+		{"I1", "date", CellTypeDate},
+		{"J1", "result", CellTypeFormula},
+		{"K1", "NaN1", CellTypeNumber},
+		{"L1", "inline", CellTypeInlineString},
+		{"Z1", nil, CellTypeUnset},
+	} {
+		value, cellType, err := f.GetCellValueTyped("Sheet1", expected.cell)
+		assert.NoError(t, err, expected.cell)
+		assert.Equal(t, expected.value, value, expected.cell)
+		assert.Equal(t, expected.cellType, cellType, expected.cell)
+	}
+	// Test get typed cell value with number formatted as a time
+	style, err := f.NewStyle(&Style{CustomNumFmt: stringPtr("[h]:mm")})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 1.5))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", style))
+	value, cellType, err := f.GetCellValueTyped("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(1899, 12, 31, 12, 0, 0, 0, time.UTC), value)
+	assert.Equal(t, CellTypeDate, cellType)
+	// Test get typed cell value with number format but not date
+	style, err = f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", style))
+	value, cellType, err = f.GetCellValueTyped("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, value)
+	assert.Equal(t, CellTypeNumber, cellType)
+	// Test get typed cell value with invalid cell reference
+	_, _, err = f.GetCellValueTyped("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get typed cell value with invalid sheet name
+	_, _, err = f.GetCellValueTyped("Sheet:1", "A1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get typed cell value with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, _, err = f.GetCellValueTyped("Sheet1", "C1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get typed cell value with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, _, err = f.GetCellValueTyped("Sheet1", "D1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get typed cell value with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, _, err = f.GetCellValueTyped("Sheet1", "C1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetValueFrom(t *testing.T) {
 	f := NewFile()
 	c := xlsxC{T: "s"}
 	sst, err := f.sharedStringsReader()
 	assert.NoError(t, err)
-	value, err := c.getValueFrom(f, sst, false)
+	value, err := c.getValueFrom(f, sst, &Options{})
 	assert.NoError(t, err)
 	assert.Equal(t, "", value)
 
 	c = xlsxC{T: "s", V: " 1 "}
-	value, err = c.getValueFrom(f, &xlsxSST{Count: 1, SI: []xlsxSI{{}, {T: &xlsxT{Val: "s"}}}}, false)
+	value, err = c.getValueFrom(f, &xlsxSST{Count: 1, SI: []xlsxSI{{}, {T: &xlsxT{Val: "s"}}}}, &Options{})
 	assert.NoError(t, err)
 	assert.Equal(t, "s", value)
 }
@@ -565,6 +817,34 @@ func BenchmarkSetCellValue(b *testing.B) {
 	}
 }
 
+func BenchmarkSetRows(b *testing.B) {
+	values := make([][]interface{}, 100)
+	for i := range values {
+		values[i] = []interface{}{"Name" + strconv.Itoa(i), i, float64(i) / 3, true, time.Duration(i) * time.Minute}
+	}
+	b.Run("SetRows", func(b *testing.B) {
+		f := NewFile()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := f.SetRows("Sheet1", "A1", values); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+	b.Run("SetSheetRow", func(b *testing.B) {
+		f := NewFile()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for r, rowValues := range values {
+				row := rowValues
+				if err := f.SetSheetRow("Sheet1", "A"+strconv.Itoa(r+1), &row); err != nil {
+					b.Error(err)
+				}
+			}
+		}
+	})
+}
+
 func TestOverflowNumericCell(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "OverflowNumericCell.xlsx"))
 	if !assert.NoError(t, err) {
@@ -634,6 +914,32 @@ func TestSetCellFormula(t *testing.T) {
 	formulaType = STCellFormulaTypeDataTable
 	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "=SUM(Table1[[A]:[B]])", FormulaOpts{Type: &formulaType}))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellFormula6.xlsx")))
+
+	// Test set cell formula with localized argument and decimal separators
+	f = NewFile(Options{CultureInfo: CultureNameDeDE})
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 2))
+	for formula, expected := range map[string]string{
+		"=SUM(1,5;A1)":                       "=SUM(1.5,A1)",
+		"=IF(A1>1,5;\"a;b,c\";'x;y'!A1)":     "=IF(A1>1.5,\"a;b,c\",'x;y'!A1)",
+		"=SUM(Table1[[#This Row];[A]];1)":    "=SUM(Table1[[#This Row],[A]],1)",
+		"=SUM(Table1[[#Data]; [A;B]];1)":     "=SUM(Table1[[#Data], [A;B]],1)",
+		"=SUM(Table1[A;B];[Book2.xlsx]S!A1)": "=SUM(Table1[A;B],[Book2.xlsx]S!A1)",
+		"=SUM({1;2};0,5)":                    "=SUM({1,2},0.5)",
+		"=SUM({1,5;\"a;b\"})":                "=SUM({1.5,\"a;b\"})",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B1", formula))
+		result, err := f.GetCellFormula("Sheet1", "B1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=SUM(1,5;A1)"))
+	result, err := f.CalcCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "3.5", result)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=SUM({1,5;2,5})"))
+	result, err = f.CalcCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "4", result)
 }
 
 func TestGetCellRichText(t *testing.T) {
@@ -718,6 +1024,41 @@ func TestGetCellRichText(t *testing.T) {
 	// Test get cell rich text with invalid sheet name
 	_, err = f.GetCellRichText("Sheet:1", "A1")
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+
+	// Test get cell rich text on inline string cells
+	f = NewFile()
+	indexed := 10
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row = []xlsxRow{{R: 1, C: []xlsxC{
+		{R: "A1", T: "inlineStr", IS: &xlsxSI{R: []xlsxR{
+			{T: &xlsxT{Val: "H"}},
+			{RPr: &xlsxRPr{VertAlign: &attrValString{Val: stringPtr("subscript")}, Color: &xlsxColor{Theme: &theme}}, T: &xlsxT{Val: "2"}},
+			{T: &xlsxT{Val: "O"}},
+			{RPr: &xlsxRPr{VertAlign: &attrValString{Val: stringPtr("superscript")}, Color: &xlsxColor{Indexed: indexed}}, T: &xlsxT{Val: "1"}},
+		}}},
+		{R: "B1", T: "inlineStr"},
+	}}}
+	runs, err = f.GetCellRichText("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []RichTextRun{
+		{Text: "H"},
+		{Text: "2", Font: &Font{Underline: "none", ColorTheme: &theme, VertAlign: "subscript"}},
+		{Text: "O"},
+		{Text: "1", Font: &Font{Underline: "none", ColorIndexed: indexed, VertAlign: "superscript"}},
+	}, runs)
+	runs, err = f.GetCellRichText("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Empty(t, runs)
+	// Test get cell rich text with extended font attributes
+	runsSource = []RichTextRun{
+		{Text: "CO"},
+		{Text: "2", Font: &Font{Underline: "none", VertAlign: "subscript", Charset: intPtr(128), Condense: true, Extend: true}},
+	}
+	assert.NoError(t, f.SetCellRichText("Sheet1", "C1", runsSource))
+	runs, err = f.GetCellRichText("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, runsSource, runs)
 }
 
 func TestSetCellRichText(t *testing.T) {
@@ -989,6 +1330,7 @@ func TestSharedStringsError(t *testing.T) {
 			assert.NoError(t, err)
 			// Test get cell value from string item with invalid offset
 			f.sharedStringItem[1] = []uint{maxUint16 - 1, maxUint16}
+			f.sharedStringLRU = newLRUCache(defaultSharedStringsCache)
 			assert.Equal(t, "1", f.getFromStringItem(1))
 			break
 		}
@@ -1029,3 +1371,267 @@ func TestSharedStringsError(t *testing.T) {
 func TestSIString(t *testing.T) {
 	assert.Empty(t, xlsxSI{}.String())
 }
+
+func TestClearCells(t *testing.T) {
+	prepare := func() *File {
+		f := NewFile()
+		style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, "text", 3}))
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "SUM(A1,C1)"))
+		assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "C2", style))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "B1", "https://github.com/xuri/excelize", "External"))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "C3", "Sheet1!A1", "Location"))
+		assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "comment"}))
+		assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "C3", Author: "Excelize", Text: "comment"}))
+		return f
+	}
+	// Test clear contents only
+	f := prepare()
+	assert.NoError(t, f.ClearCells("Sheet1", "B2:A1", ClearOptions{Contents: true}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"", "", "3"}}, rows)
+	formula, err := f.GetCellFormula("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	styleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.NotZero(t, styleID)
+	link, _, err := f.GetCellHyperLink("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	// Test clear formats, comments and hyperlinks
+	assert.NoError(t, f.ClearCells("Sheet1", "A1:C2", ClearOptions{Formats: true, Comments: true, Hyperlinks: true}))
+	for _, cell := range []string{"A1", "B2", "C1"} {
+		styleID, err = f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Zero(t, styleID)
+	}
+	val, err := f.GetCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", val)
+	link, _, err = f.GetCellHyperLink("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.False(t, link)
+	link, _, err = f.GetCellHyperLink("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "C3", comments[0].Cell)
+	// Test clear all parts of a single cell
+	assert.NoError(t, f.ClearCells("Sheet1", "C3", ClearOptions{Contents: true, Formats: true, Comments: true, Hyperlinks: true}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).Hyperlinks)
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 0)
+	// Test clear cells with invalid range reference
+	assert.EqualError(t, f.ClearCells("Sheet1", "A:B1", ClearOptions{Contents: true}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test clear cells with invalid sheet name
+	assert.EqualError(t, f.ClearCells("Sheet:1", "A1", ClearOptions{Contents: true}), ErrSheetNameInvalid.Error())
+	// Test clear cells with unsupported charset calculation chain
+	f = prepare()
+	f.CalcChain = nil
+	f.Pkg.Store(defaultXMLPathCalcChain, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ClearCells("Sheet1", "A2", ClearOptions{Contents: true}), "XML syntax error on line 1: invalid UTF-8")
+	// Test clear cells with unsupported charset comments
+	f = prepare()
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ClearCells("Sheet1", "A1", ClearOptions{Comments: true}), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestCellPhonetic(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "東京"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "東京"))
+	expected := Phonetic{
+		Show:      true,
+		Type:      "Hiragana",
+		Alignment: "center",
+		Runs: []PhoneticRun{
+			{Start: 0, End: 1, Text: "とう"},
+			{Start: 1, End: 2, Text: "きょう"},
+		},
+	}
+	assert.NoError(t, f.SetCellPhonetic("Sheet1", "A1", expected))
+	phonetic, err := f.GetCellPhonetic("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, phonetic)
+	// Test the other cells which referenced the same string item not be changed
+	phonetic, err = f.GetCellPhonetic("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, Phonetic{}, phonetic)
+	// Test set the same phonetic settings reuse the string item
+	assert.NoError(t, f.SetCellPhonetic("Sheet1", "A2", expected))
+	for _, cell := range []string{"A1", "A2"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "東京", val)
+	}
+	sst, err := f.sharedStringsReader()
+	assert.NoError(t, err)
+	assert.Len(t, sst.SI, 2)
+	// Test phonetic settings round trip
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	phonetic, err = f.GetCellPhonetic("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, phonetic)
+	// Test edit the other cell keep the phonetic settings
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "大阪"))
+	phonetic, err = f.GetCellPhonetic("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, phonetic)
+	// Test remove the phonetic settings
+	assert.NoError(t, f.SetCellPhonetic("Sheet1", "A1", Phonetic{}))
+	phonetic, err = f.GetCellPhonetic("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, Phonetic{}, phonetic)
+	// Test set phonetic settings on inline string cell
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C = append(ws.(*xlsxWorksheet).SheetData.Row[0].C,
+		xlsxC{R: "C1", T: "inlineStr", IS: &xlsxSI{T: &xlsxT{Val: "京都"}}}, xlsxC{R: "D1", T: "inlineStr"})
+	assert.NoError(t, f.SetCellPhonetic("Sheet1", "C1", expected))
+	phonetic, err = f.GetCellPhonetic("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, phonetic)
+	assert.Equal(t, ErrParameterInvalid, f.SetCellPhonetic("Sheet1", "D1", expected))
+	// Test get phonetic settings on the cell without value
+	phonetic, err = f.GetCellPhonetic("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, Phonetic{}, phonetic)
+	// Test set phonetic settings with invalid settings
+	assert.Equal(t, ErrParameterInvalid, f.SetCellPhonetic("Sheet1", "A1", Phonetic{Type: "Katakana"}))
+	assert.Equal(t, ErrParameterInvalid, f.SetCellPhonetic("Sheet1", "A1", Phonetic{Alignment: "right"}))
+	assert.Equal(t, ErrParameterInvalid, f.SetCellPhonetic("Sheet1", "A1", Phonetic{Runs: []PhoneticRun{{Start: 1, End: 3}}}))
+	// Test set phonetic settings on the cell without string value
+	assert.NoError(t, f.SetCellValue("Sheet1", "F1", 1))
+	assert.Equal(t, ErrPhoneticCellValue, f.SetCellPhonetic("Sheet1", "F1", expected))
+	// Test set phonetic settings with invalid string item index
+	for _, idx := range []string{"-1", "x"} {
+		ws.(*xlsxWorksheet).SheetData.Row[0].C[0].V = idx
+		_, err = f.GetCellPhonetic("Sheet1", "A1")
+		if idx == "x" {
+			assert.EqualError(t, err, "strconv.Atoi: parsing \"x\": invalid syntax")
+			assert.EqualError(t, f.SetCellPhonetic("Sheet1", "A1", expected), "strconv.Atoi: parsing \"x\": invalid syntax")
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, ErrPhoneticCellValue, f.SetCellPhonetic("Sheet1", "A1", expected))
+	}
+	// Test phonetic settings with invalid cell reference
+	_, err = f.GetCellPhonetic("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetCellPhonetic("Sheet1", "A", expected), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test phonetic settings with not exist worksheet
+	_, err = f.GetCellPhonetic("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetCellPhonetic("SheetN", "A1", expected), "sheet SheetN does not exist")
+	// Test phonetic settings with unsupported charset shared strings table
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].V = "0"
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.GetCellPhonetic("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetCellPhonetic("Sheet1", "A1", expected), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestCopyRange(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", 1, 2}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Total", nil, nil}))
+	formulaType, ref := STCellFormulaTypeShared, "B2:C2"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "B1*$C$1+Sheet2!A1", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A2", style))
+	assert.NoError(t, f.MergeCell("Sheet1", "A3", "C3"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", "Merged"))
+	assert.NoError(t, f.SetCellValue("Sheet2", "F6", "Overwritten"))
+
+	// Test copy range across worksheets
+	assert.NoError(t, f.CopyRange("Sheet1", "C3:A1", "Sheet2", "E5", nil))
+	for cell, expected := range map[string]string{"E5": "Name", "F5": "1", "E6": "Total", "F6": "", "E7": "Merged"} {
+		value, err := f.GetCellValue("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	for cell, expected := range map[string]string{"F6": "F5*$C$1+Sheet2!E5", "G6": "G5*$C$1+Sheet2!F5"} {
+		formula, err := f.GetCellFormula("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	styleID, err := f.GetCellStyle("Sheet2", "E6")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	mergeCells, err := f.GetMergeCells("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "E7", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "G7", mergeCells[0].GetEndAxis())
+
+	// Test copy range with transpose in the same worksheet
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:C3", "Sheet1", "B2", &CopyOptions{Transpose: true}))
+	for cell, expected := range map[string]string{"B2": "Name", "C2": "Total", "B3": "1", "B4": "2", "D2": "Merged"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, "C2*$C$1+Sheet2!B2", formula)
+	mergeCells, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "D2", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "D4", mergeCells[0].GetEndAxis())
+
+	// Test copy a single cell with array formula
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "{1,2}", FormulaOpts{Type: &[]string{STCellFormulaTypeArray}[0], Ref: &[]string{"A1:B1"}[0]}))
+	assert.NoError(t, f.CopyRange("Sheet2", "A1", "Sheet2", "A10", nil))
+	ws, err := f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, "A10:B10", ws.SheetData.Row[ws.searchRow(10)].C[0].F.Ref)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopyRange.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	// Test copy range with invalid references
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.CopyRange("Sheet1", "A:B1", "Sheet2", "A1", nil))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.CopyRange("Sheet1", "A1", "Sheet2", "A", nil))
+	// Test copy range exceeds the maximum limits
+	assert.Equal(t, ErrColumnNumber, f.CopyRange("Sheet1", "A1:B1", "Sheet2", "XFD1", nil))
+	assert.Equal(t, ErrMaxRows, f.CopyRange("Sheet1", "A1:B1", "Sheet2", fmt.Sprintf("A%d", TotalRows), &CopyOptions{Transpose: true}))
+	// Test copy range on not exists worksheet
+	assert.EqualError(t, f.CopyRange("SheetN", "A1", "Sheet2", "A1", nil), "sheet SheetN does not exist")
+	assert.EqualError(t, f.CopyRange("Sheet1", "A1", "SheetN", "A1", nil), "sheet SheetN does not exist")
+	// Test copy range with invalid merged cell reference
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		ws, err := f.workSheetReader(sheet)
+		assert.NoError(t, err)
+		ws.MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{nil, {Ref: "A"}}}
+		assert.Equal(t, ErrParameterInvalid, f.CopyRange("Sheet1", "A1", "Sheet2", "A1", nil))
+		ws.MergeCells = nil
+	}
+	// Test copy range with unsupported charset calculation chain
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "1+1"))
+	f.CalcChain = nil
+	f.Pkg.Store(defaultXMLPathCalcChain, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopyRange("Sheet1", "A1", "Sheet2", "A1", nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
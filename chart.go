@@ -12,8 +12,12 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"path"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -78,6 +82,9 @@ const (
 	WireframeContour
 	Bubble
 	Bubble3D
+	StockHighLowClose
+	StockOpenHighLowClose
+	Funnel
 )
 
 // This section defines the default value of chart properties.
@@ -258,6 +265,25 @@ var (
 		Contour:                     0,
 		Bubble:                      0,
 		Bubble3D:                    0,
+		StockHighLowClose:           0,
+		StockOpenHighLowClose:       0,
+	}
+	chartDataLabelPosition = map[string]string{
+		"best_fit":    "bestFit",
+		"bottom":      "b",
+		"center":      "ctr",
+		"inside_base": "inBase",
+		"inside_end":  "inEnd",
+		"left":        "l",
+		"outside_end": "outEnd",
+		"right":       "r",
+		"top":         "t",
+	}
+	chartErrorBarsType = map[string]string{
+		"fixed":              "fixedVal",
+		"percentage":         "percentage",
+		"standard_deviation": "stdDev",
+		"standard_error":     "stdErr",
 	}
 	chartLegendPosition = map[string]string{
 		"bottom":    "b",
@@ -266,6 +292,14 @@ var (
 		"top":       "t",
 		"top_right": "tr",
 	}
+	chartTrendlineType = map[string]string{
+		"exponential":    "exp",
+		"linear":         "linear",
+		"logarithmic":    "log",
+		"moving_average": "movingAvg",
+		"polynomial":     "poly",
+		"power":          "power",
+	}
 	chartValAxNumFmtFormatCode = map[ChartType]string{
 		Area:                        "General",
 		AreaStacked:                 "General",
@@ -322,6 +356,8 @@ var (
 		WireframeContour:            "General",
 		Bubble:                      "General",
 		Bubble3D:                    "General",
+		StockHighLowClose:           "General",
+		StockOpenHighLowClose:       "General",
 	}
 	chartValAxCrossBetween = map[ChartType]string{
 		Area:                        "midCat",
@@ -379,6 +415,8 @@ var (
 		WireframeContour:            "midCat",
 		Bubble:                      "midCat",
 		Bubble3D:                    "midCat",
+		StockHighLowClose:           "between",
+		StockOpenHighLowClose:       "between",
 	}
 	plotAreaChartGrouping = map[ChartType]string{
 		Area:                        "standard",
@@ -480,12 +518,63 @@ var (
 	}
 )
 
+// parseChartSeriesOptions provides a function to check the format settings of
+// the chart series and set the default value of the trendline and error bars.
+func parseChartSeriesOptions(ser *ChartSeries) error {
+	if ser.Values == "" {
+		return ErrChartSeries
+	}
+	if _, ok := chartDataLabelPosition[ser.DataLabel.Position]; !ok && ser.DataLabel.Position != "" {
+		return ErrChartDataLabelPosition
+	}
+	if ser.Marker.Symbol != "" && inStrSlice(supportedChartMarkerSymbol, ser.Marker.Symbol, true) == -1 ||
+		ser.Marker.Size != 0 && (ser.Marker.Size < 2 || ser.Marker.Size > 72) {
+		return ErrChartMarker
+	}
+	if ser.Trendline.Type != "" {
+		if _, ok := chartTrendlineType[ser.Trendline.Type]; !ok {
+			return ErrChartTrendline
+		}
+		if ser.Trendline.Type == "polynomial" && ser.Trendline.Order == 0 {
+			ser.Trendline.Order = 2
+		}
+		if ser.Trendline.Type == "moving_average" && ser.Trendline.Period == 0 {
+			ser.Trendline.Period = 2
+		}
+		if ser.Trendline.Type == "polynomial" && (ser.Trendline.Order < 2 || ser.Trendline.Order > 6) ||
+			ser.Trendline.Type == "moving_average" && ser.Trendline.Period < 2 {
+			return ErrChartTrendline
+		}
+	}
+	if ser.ErrorBars.Type != "" {
+		if ser.ErrorBars.Direction == "" {
+			ser.ErrorBars.Direction = "both"
+		}
+		if _, ok := chartErrorBarsType[ser.ErrorBars.Type]; !ok ||
+			inStrSlice(supportedChartErrorBarsDirection, ser.ErrorBars.Direction, true) == -1 {
+			return ErrChartErrorBars
+		}
+	}
+	return nil
+}
+
 // parseChartOptions provides a function to parse the format settings of the
 // chart with default value.
 func parseChartOptions(opts *Chart) (*Chart, error) {
 	if opts == nil {
 		return nil, ErrParameterInvalid
 	}
+	if len(opts.Series) == 0 {
+		return nil, ErrChartSeries
+	}
+	for i := range opts.Series {
+		if err := parseChartSeriesOptions(&opts.Series[i]); err != nil {
+			return nil, err
+		}
+	}
+	if n, ok := map[ChartType]int{StockHighLowClose: 3, StockOpenHighLowClose: 4}[opts.Type]; ok && len(opts.Series) != n {
+		return nil, ErrChartStockSeries
+	}
 	if opts.Dimension.Width == 0 {
 		opts.Dimension.Width = defaultChartDimensionWidth
 	}
@@ -507,6 +596,9 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 	if opts.Legend.Position == "" {
 		opts.Legend.Position = defaultChartLegendPosition
 	}
+	if _, ok := chartLegendPosition[opts.Legend.Position]; !ok && opts.Legend.Position != "none" {
+		return nil, ErrChartLegendPosition
+	}
 	for i := range opts.Title {
 		if opts.Title[i].Font == nil {
 			opts.Title[i].Font = &Font{}
@@ -662,6 +754,9 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	 52 | WireframeContour            | wireframe contour chart
 //	 53 | Bubble                      | bubble chart
 //	 54 | Bubble3D                    | 3D bubble chart
+//	 55 | StockHighLowClose           | high-low-close stock chart
+//	 56 | StockOpenHighLowClose       | open-high-low-close stock chart
+//	 57 | Funnel                      | funnel chart
 //
 // In Excel a chart series is a collection of information that defines which
 // data is plotted such as values, axis labels and formatting.
@@ -675,6 +770,9 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	Fill
 //	Line
 //	Marker
+//	DataLabel
+//	Trendline
+//	ErrorBars
 //
 // Name: Set the name for the series. The name is displayed in the chart legend
 // and in the formula bar. The 'Name' property is optional and if it isn't
@@ -691,15 +789,34 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // mandatory option for every chart object. This option links the chart with
 // the worksheet data that it displays.
 //
-// Fill: This set the format for the data series fill.
-//
-// Line: This sets the line format of the line chart. The 'Line' property is
+// Fill: This set the format for the data series fill. The 'Color' of the fill
+// could be a RGB color such as 'FF0000', or a theme color name. The available
+// theme colors are:
+//
+//	accent1 - accent6
+//	bg1
+//	bg2
+//	dk1
+//	dk2
+//	folHlink
+//	hlink
+//	lt1
+//	lt2
+//	tx1
+//	tx2
+//
+// Line: This sets the line format of the data series. The 'Line' property is
 // optional and if it isn't supplied it will default style. The options that
 // can be set are width and color. The range of width is 0.25pt - 999pt. If the
 // value of width is outside the range, the default width of the line is 2pt.
+// The 'Color' could be a RGB color or a theme color name, which sets the line
+// color of the line chart, the scatter chart and the border color of the
+// series in other charts. If the 'Color' isn't supplied, the line color of
+// the line chart will follow the fill color.
 //
 // Marker: This sets the marker of the line chart and scatter chart. The range
-// of optional field 'Size' is 2-72 (default value is 5). The enumeration value
+// of optional field 'Size' is 2-72 (default value is 5). The optional field
+// 'Fill' sets the fill and border color of the marker. The enumeration value
 // of optional field 'Symbol' are (default value is 'auto'):
 //
 //	circle
@@ -715,6 +832,55 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	x
 //	auto
 //
+// DataLabel: This sets the data labels of the series, which overrides the
+// data labels options in the 'PlotArea' for the series. The options that can
+// be set are 'ShowCatName', 'ShowPercent', 'ShowSerName', 'ShowVal', 'NumFmt'
+// and 'Position'. Note that not every position is available for all chart
+// types. The available positions are:
+//
+//	best_fit
+//	bottom
+//	center
+//	inside_base
+//	inside_end
+//	left
+//	outside_end
+//	right
+//	top
+//
+// Trendline: This sets the trendline of the series, it works for the 2D area,
+// bar, column, line, scatter and bubble chart without stacked. The 'Order'
+// specifies the order of the polynomial trendline, the range is 2-6 (default
+// value is 2). The 'Period' specifies the period of the moving average
+// trendline, which shall be greater than 1 (default value is 2). The
+// 'DisplayEquation' and 'DisplayRSquared' specifies to display the equation
+// and R-squared value of the trendline on the chart. The available types are:
+//
+//	exponential
+//	linear
+//	logarithmic
+//	moving_average
+//	polynomial
+//	power
+//
+// ErrorBars: This sets the vertical error bars of the series, it works for the
+// 2D area, bar, column, line, scatter and bubble chart. The 'Value' specifies
+// the fixed value, percentage or number of the standard deviation of the error
+// bars. The 'Direction' specifies the error bars direction with 'both', 'minus'
+// or 'plus' (default value is 'both'). The 'NoEndCap' specifies the error bars
+// without end cap. The available types are:
+//
+//	fixed
+//	percentage
+//	standard_deviation
+//	standard_error
+//
+// The stock chart plots the series in a fixed order. The 'StockHighLowClose'
+// chart requires exactly 3 series for the high, low and close prices, and the
+// 'StockOpenHighLowClose' chart requires exactly 4 series for the open, high,
+// low and close prices. The funnel chart only plots the first series, and it
+// can't be combined with other charts or created in a chartsheet.
+//
 // Set properties of the chart legend. The options that can be set are:
 //
 //	Position
@@ -771,6 +937,7 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	ShowPercent
 //	ShowSerName
 //	ShowVal
+//	Fill
 //
 // SecondPlotValues: Specifies the values in second plot for the 'pieOfPie' and
 // 'barOfPie' chart.
@@ -793,6 +960,9 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // ShowVal: Specifies that the value shall be shown in a data label.
 // The 'ShowVal' property is optional. The default value is false.
 //
+// Fill: Specifies the fill color of the plot area, same as the 'Fill' of the
+// series. The 'Fill' property is optional.
+//
 // Set the primary horizontal and vertical axis options by 'XAxis' and 'YAxis'.
 // The properties of 'XAxis' that can be set are:
 //
@@ -806,6 +976,7 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	Font
 //	NumFmt
 //	Title
+//	TitleRef
 //
 // The properties of 'YAxis' that can be set are:
 //
@@ -813,6 +984,7 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	MajorGridLines
 //	MinorGridLines
 //	MajorUnit
+//	MinorUnit
 //	Secondary
 //	ReverseOrder
 //	Maximum
@@ -821,6 +993,7 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	LogBase
 //	NumFmt
 //	Title
+//	TitleRef
 //
 // None: Disable axes.
 //
@@ -832,9 +1005,16 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // positive floating-point number. The 'MajorUnit' property is optional. The
 // default value is auto.
 //
+// MinorUnit: Specifies the distance between minor ticks. Shall contain a
+// positive floating-point number. The 'MinorUnit' property is optional. The
+// default value is auto.
+//
 // Secondary: Specifies the current series vertical axis as the secondary axis,
 // this only works for the second and later chart in the combo chart. The
-// default value is false.
+// vertical axis settings of the combo chart, such as 'Maximum', 'Minimum',
+// 'LogBase', 'MajorUnit', 'MinorUnit', 'NumFmt' and 'Title', are applied to the secondary
+// axis, and the primary axis keeps the settings of the first chart, so each
+// axis can be scaled independently. The default value is false.
 //
 // TickLabelSkip: Specifies how many tick labels to skip between label that is
 // drawn. The 'TickLabelSkip' property is optional. The default value is auto.
@@ -870,6 +1050,11 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // Title: Specifies that the primary horizontal or vertical axis title and
 // resize chart. The 'Title' property is optional.
 //
+// TitleRef: Specifies the reference of the cell which contains the axis title,
+// for example "Sheet1!$A$1", the axis title will be updated with the cell
+// value. The 'TitleRef' property is optional, and it takes precedence over the
+// 'Title' property.
+//
 // Set chart size by 'Dimension' property. The 'Dimension' property is optional.
 // The default width is 480, and height is 260.
 //
@@ -989,6 +1174,9 @@ func (f *File) AddChart(sheet, cell string, chart *Chart, combo ...*Chart) error
 	if err != nil {
 		return err
 	}
+	if opts.Type == Funnel {
+		return f.addChartEx(ws, sheet, cell, opts)
+	}
 	// Add first picture for given sheet, create xl/drawings/ and xl/drawings/_rels/ folder.
 	drawingID := f.countDrawings() + 1
 	chartID := f.countCharts() + 1
@@ -1026,6 +1214,9 @@ func (f *File) AddChartSheet(sheet string, chart *Chart, combo ...*Chart) error
 	if err != nil {
 		return err
 	}
+	if opts.Type == Funnel {
+		return newUnsupportedChartType(opts.Type)
+	}
 	cs := xlsxChartsheet{
 		SheetViews: &xlsxChartsheetViews{
 			SheetView: []*xlsxChartsheetView{{ZoomScaleAttr: 100, ZoomToFitAttr: true}},
@@ -1084,16 +1275,20 @@ func (f *File) getChartOptions(opts *Chart, combo []*Chart) (*Chart, []*Chart, e
 		if _, ok := chartValAxNumFmtFormatCode[comboChart.Type]; !ok {
 			return options, comboCharts, newUnsupportedChartType(comboChart.Type)
 		}
+		if options.Type == Funnel {
+			return options, comboCharts, newUnsupportedChartType(options.Type)
+		}
 		comboCharts = append(comboCharts, comboChart)
 	}
-	if _, ok := chartValAxNumFmtFormatCode[options.Type]; !ok {
+	if _, ok := chartValAxNumFmtFormatCode[options.Type]; !ok && options.Type != Funnel {
 		return options, comboCharts, newUnsupportedChartType(options.Type)
 	}
 	return options, comboCharts, err
 }
 
 // DeleteChart provides a function to delete chart in spreadsheet by given
-// worksheet name and cell reference.
+// worksheet name and cell reference. The chart part and its style and colors
+// parts will be removed if the chart isn't referenced by any other drawing.
 func (f *File) DeleteChart(sheet, cell string) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
@@ -1108,22 +1303,579 @@ func (f *File) DeleteChart(sheet, cell string) error {
 	if ws.Drawing == nil {
 		return err
 	}
-	drawingXML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl")
-	_, err = f.deleteDrawing(col, row, drawingXML, "Chart")
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.ReplaceAll(target, "..", "xl")
+	drawingRels := strings.ReplaceAll(
+		strings.ReplaceAll(target, "../drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	rIDs, err := f.getChartRIDs(col, row, drawingXML)
+	if err != nil {
+		return err
+	}
+	if _, err = f.deleteDrawing(col, row, drawingXML, "Chart"); err != nil {
+		return err
+	}
+	for _, rID := range rIDs {
+		drawRel := f.getDrawingRelationships(drawingRels, rID)
+		f.deleteDrawingRels(drawingRels, rID)
+		if drawRel != nil && drawRel.Type == SourceRelationshipChart {
+			if err = f.deleteChartPart(strings.ReplaceAll(drawRel.Target, "..", "xl")); err != nil {
+				return err
+			}
+		}
+	}
 	return err
 }
 
-// countCharts provides a function to get chart files count storage in the
-// folder xl/charts.
-func (f *File) countCharts() int {
-	count := 0
+// deleteChartPart provides a function to delete the chart part, the chart
+// relationships part and the style and colors parts of the chart by given
+// chart part path, if the chart isn't referenced by any drawing part.
+func (f *File) deleteChartPart(chartXML string) error {
+	var drawingRels []string
 	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/charts/chart") {
-			count++
+		if strings.HasPrefix(k.(string), "xl/drawings/_rels/") {
+			drawingRels = append(drawingRels, k.(string))
 		}
 		return true
 	})
-	return count
+	f.Relationships.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/drawings/_rels/") && inStrSlice(drawingRels, k.(string), true) == -1 {
+			drawingRels = append(drawingRels, k.(string))
+		}
+		return true
+	})
+	for _, rels := range drawingRels {
+		rel, err := f.relsReader(rels)
+		if err != nil {
+			return err
+		}
+		if rel == nil {
+			continue
+		}
+		for _, r := range rel.Relationships {
+			if r.Type == SourceRelationshipChart && strings.ReplaceAll(r.Target, "..", "xl") == chartXML {
+				return err
+			}
+		}
+	}
+	chartRels := strings.Replace(chartXML, "xl/charts/", "xl/charts/_rels/", 1) + ".rels"
+	rels, err := f.relsReader(chartRels)
+	if err != nil {
+		return err
+	}
+	contentTypes := map[string]string{
+		SourceRelationshipChartColorStyle: ContentTypeChartColorStyle,
+		SourceRelationshipChartStyle:      ContentTypeChartStyle,
+	}
+	if rels != nil {
+		for _, rel := range rels.Relationships {
+			if contentType, ok := contentTypes[rel.Type]; ok {
+				partName := path.Join(path.Dir(chartXML), rel.Target)
+				f.Pkg.Delete(partName)
+				if err = f.removeContentTypesPart(contentType, "/"+partName); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	f.Relationships.Delete(chartRels)
+	f.Pkg.Delete(chartRels)
+	f.Pkg.Delete(chartXML)
+	return f.removeContentTypesPart(ContentTypeDrawingML, "/"+chartXML)
+}
+
+// GetCharts provides a function to get the charts anchored at the given cell
+// by given worksheet name and cell reference. This function returns the chart
+// type, series formulas, titles, legend, data labels and axes options of each
+// chart. The first chart read from a chart part is the primary chart, and the
+// rest are the combo charts in the same plot area. For example, get the chart
+// anchored at cell E1 in the worksheet named Sheet1:
+//
+//	charts, err := f.GetCharts("Sheet1", "E1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, chart := range charts {
+//	    fmt.Println(chart.Type, len(chart.Series))
+//	}
+func (f *File) GetCharts(sheet, cell string) ([]Chart, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	col--
+	row--
+	f.mu.Lock()
+	ws, err := f.workSheetViewer(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	if ws.Drawing == nil {
+		return nil, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.ReplaceAll(target, "..", "xl")
+	drawingRelationships := strings.ReplaceAll(
+		strings.ReplaceAll(target, "../drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	rIDs, err := f.getChartRIDs(col, row, drawingXML)
+	if err != nil {
+		return nil, err
+	}
+	var charts []Chart
+	for _, rID := range rIDs {
+		drawRel := f.getDrawingRelationships(drawingRelationships, rID)
+		if drawRel == nil || drawRel.Type != SourceRelationshipChart {
+			continue
+		}
+		chart, err := f.getChart(strings.ReplaceAll(drawRel.Target, "..", "xl"))
+		if err != nil {
+			return charts, err
+		}
+		charts = append(charts, chart...)
+	}
+	return charts, err
+}
+
+// getChartRIDs provides a function to get the relationship IDs of the charts
+// anchored at the given cell by given zero-based column and row number and
+// drawing part path.
+func (f *File) getChartRIDs(col, row int, drawingXML string) ([]string, error) {
+	var rIDs []string
+	wsDr, _, err := f.drawingViewer(drawingXML)
+	if err != nil {
+		return rIDs, err
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	for _, anchor := range wsDr.TwoCellAnchor {
+		deCellAnchor := new(decodeCellAnchor)
+		if err = f.xmlNewDecoder(strings.NewReader("<decodeCellAnchor>" + anchor.GraphicFrame + "</decodeCellAnchor>")).
+			Decode(deCellAnchor); err != nil && err != io.EOF {
+			return rIDs, err
+		}
+		err = nil
+		from := deCellAnchor.From
+		if anchor.From != nil {
+			from = &decodeFrom{Col: anchor.From.Col, Row: anchor.From.Row}
+		}
+		if from == nil || from.Col != col || from.Row != row || deCellAnchor.GraphicFrame == nil {
+			continue
+		}
+		if graphicData := deCellAnchor.GraphicFrame.GraphicData; graphicData.URI == NameSpaceDrawingMLChart.Value && graphicData.Chart != nil {
+			rIDs = append(rIDs, graphicData.Chart.RID)
+		}
+	}
+	return rIDs, err
+}
+
+// getChart provides a function to read the primary chart and combo charts
+// from the chart part by given chart part path.
+func (f *File) getChart(chartXML string) ([]Chart, error) {
+	var (
+		charts     []Chart
+		chartSpace = new(xlsxChartSpace)
+		deChart    = new(decodeChartSpace)
+		content    = namespaceStrictToTransitional(f.readXML(chartXML))
+	)
+	if err := f.xmlNewDecoder(bytes.NewReader(content)).Decode(chartSpace); err != nil && err != io.EOF {
+		return charts, err
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(content)).Decode(deChart); err != nil && err != io.EOF {
+		return charts, err
+	}
+	plotArea := chartSpace.Chart.PlotArea
+	if plotArea == nil {
+		return charts, nil
+	}
+	axes, titles := map[int]*cAxs{}, map[int]*decodeChartTitle{}
+	for _, ax := range append(append([]*cAxs{}, plotArea.CatAx...), plotArea.ValAx...) {
+		if ax != nil && ax.AxID != nil && ax.AxID.Val != nil {
+			axes[*ax.AxID.Val] = ax
+		}
+	}
+	for _, ax := range append(append([]decodeChartAxis{}, deChart.PlotArea.CatAx...), deChart.PlotArea.ValAx...) {
+		if ax.AxID.Val != nil {
+			titles[*ax.AxID.Val] = ax.Title
+		}
+	}
+	groups := []struct {
+		name   string
+		charts *cCharts
+	}{
+		{"area", plotArea.AreaChart}, {"area3D", plotArea.Area3DChart},
+		{"bar", plotArea.BarChart}, {"bar3D", plotArea.Bar3DChart},
+		{"bubble", plotArea.BubbleChart}, {"doughnut", plotArea.DoughnutChart},
+		{"line", plotArea.LineChart}, {"line3D", plotArea.Line3DChart},
+		{"pie", plotArea.PieChart}, {"pie3D", plotArea.Pie3DChart},
+		{"ofPie", plotArea.OfPieChart}, {"radar", plotArea.RadarChart},
+		{"scatter", plotArea.ScatterChart}, {"stock", plotArea.StockChart},
+		{"surface3D", plotArea.Surface3DChart}, {"surface", plotArea.SurfaceChart},
+	}
+	valAxIDs := map[int]int{}
+	for _, group := range groups {
+		if group.charts == nil {
+			continue
+		}
+		chartType, ok := f.getChartType(group.name, group.charts)
+		if !ok {
+			continue
+		}
+		chart := Chart{Type: chartType, Series: f.getChartSeries(group.charts)}
+		for _, deGroup := range deChart.PlotArea.Charts {
+			if deGroup.XMLName.Local == group.name+"Chart" {
+				f.getChartSeriesFormat(group.charts, deGroup.Ser, &chart)
+			}
+		}
+		if group.charts.Ser != nil && len(*group.charts.Ser) > 0 && (*group.charts.Ser)[0].IDx != nil {
+			chart.order = intValue((*group.charts.Ser)[0].IDx)
+		}
+		if group.charts.VaryColors != nil && group.charts.VaryColors.Val != nil {
+			chart.VaryColors = boolPtr(*group.charts.VaryColors.Val)
+		}
+		chart.HoleSize = intValue(group.charts.HoleSize)
+		chart.PlotArea.SecondPlotValues = intValue(group.charts.SplitPos)
+		f.getChartDLbls(group.charts.DLbls, &chart)
+		if len(group.charts.AxID) > 1 && group.charts.AxID[0].Val != nil && group.charts.AxID[1].Val != nil {
+			xAxID, yAxID := *group.charts.AxID[0].Val, *group.charts.AxID[1].Val
+			chart.XAxis = f.getChartAxis(axes[xAxID], titles[xAxID], "General")
+			chart.YAxis = f.getChartAxis(axes[yAxID], titles[yAxID], chartValAxNumFmtFormatCode[chartType])
+			valAxIDs[chart.order] = yAxID
+		}
+		charts = append(charts, chart)
+	}
+	if len(charts) == 0 {
+		return charts, nil
+	}
+	sort.SliceStable(charts, func(i, j int) bool { return charts[i].order < charts[j].order })
+	for i := range charts {
+		charts[i].YAxis.Secondary = i > 0 && valAxIDs[charts[i].order] != valAxIDs[charts[0].order]
+		charts[i].order = 0
+	}
+	charts[0].Title = f.getChartTitle(deChart.Title)
+	charts[0].Legend.Position = "none"
+	if legend := chartSpace.Chart.Legend; legend != nil {
+		if charts[0].Legend.Position = getMapKey(chartLegendPosition, stringValue(legend.LegendPos)); charts[0].Legend.Position == "" {
+			charts[0].Legend.Position = "right"
+		}
+	}
+	charts[0].ShowBlanksAs = stringValue(chartSpace.Chart.DispBlanksAs)
+	if spPr := deChart.PlotArea.SpPr; spPr != nil {
+		if color := getChartColor(spPr.SolidFill); color != "" {
+			charts[0].PlotArea.Fill.Color = []string{color}
+		}
+	}
+	return charts, nil
+}
+
+// getChartType provides a function to get the chart type by given chart
+// element name without the "Chart" suffix and the chart group properties.
+func (f *File) getChartType(name string, c *cCharts) (ChartType, bool) {
+	grouping, barDir := stringValue(c.Grouping), stringValue(c.BarDir)
+	switch name {
+	case "area", "area3D":
+		chartTypes := map[string][]ChartType{
+			"area":   {Area, AreaStacked, AreaPercentStacked},
+			"area3D": {Area3D, Area3DStacked, Area3DPercentStacked},
+		}
+		for _, chartType := range chartTypes[name] {
+			if grouping == "" || plotAreaChartGrouping[chartType] == grouping {
+				return chartType, true
+			}
+		}
+	case "bar", "bar3D":
+		shape := stringValue(c.Shape)
+		if shape == "box" {
+			shape = ""
+		}
+		for chartType := Bar; chartType <= Col3DCylinderPercentStacked; chartType++ {
+			if (chartView3DRotX[chartType] != 0) == (name == "bar3D") &&
+				plotAreaChartBarDir[chartType] == barDir &&
+				plotAreaChartGrouping[chartType] == grouping &&
+				stringValue(f.drawChartShape(&Chart{Type: chartType})) == shape {
+				return chartType, true
+			}
+		}
+	case "bubble":
+		if c.Ser != nil && len(*c.Ser) > 0 && boolValue((*c.Ser)[0].Bubble3D) {
+			return Bubble3D, true
+		}
+		return Bubble, true
+	case "ofPie":
+		if stringValue(c.OfPieType) == "bar" {
+			return BarOfPie, true
+		}
+		return PieOfPie, true
+	case "stock":
+		if c.UpDownBars != nil {
+			return StockOpenHighLowClose, true
+		}
+		return StockHighLowClose, true
+	case "surface3D":
+		if boolValue(c.Wireframe) {
+			return WireframeSurface3D, true
+		}
+		return Surface3D, true
+	case "surface":
+		if boolValue(c.Wireframe) {
+			return WireframeContour, true
+		}
+		return Contour, true
+	default:
+		chartType, ok := map[string]ChartType{
+			"doughnut": Doughnut, "line": Line, "line3D": Line3D, "pie": Pie,
+			"pie3D": Pie3D, "radar": Radar, "scatter": Scatter,
+		}[name]
+		return chartType, ok
+	}
+	return Area, false
+}
+
+// getChartSeries provides a function to get the series formulas of the chart
+// by given chart group properties.
+func (f *File) getChartSeries(c *cCharts) []ChartSeries {
+	var series []ChartSeries
+	if c.Ser == nil {
+		return series
+	}
+	for _, ser := range *c.Ser {
+		var s ChartSeries
+		if ser.Tx != nil && ser.Tx.StrRef != nil {
+			s.Name = ser.Tx.StrRef.F
+		}
+		for _, cat := range []*cCat{ser.Cat, ser.XVal} {
+			if cat != nil && cat.StrRef != nil {
+				s.Categories = cat.StrRef.F
+			}
+		}
+		for _, val := range []*cVal{ser.Val, ser.YVal} {
+			if val != nil && val.NumRef != nil {
+				s.Values = val.NumRef.F
+			}
+		}
+		if ser.BubbleSize != nil && ser.BubbleSize.NumRef != nil {
+			s.Sizes = ser.BubbleSize.NumRef.F
+		}
+		s.Line.Smooth = boolValue(ser.Smooth)
+		if ser.DLbls != nil {
+			if label := f.getChartDataLabel(ser.DLbls); c.DLbls == nil || label != f.getChartDataLabel(c.DLbls) {
+				s.DataLabel = label
+			}
+		}
+		if trendline := ser.Trendline; trendline != nil {
+			s.Trendline = ChartTrendline{
+				Type:            getMapKey(chartTrendlineType, stringValue(trendline.TrendlineType)),
+				Order:           intValue(trendline.Order),
+				Period:          intValue(trendline.Period),
+				DisplayEquation: boolValue(trendline.DispEq),
+				DisplayRSquared: boolValue(trendline.DispRSqr),
+			}
+		}
+		if errBars := ser.ErrBars; errBars != nil {
+			s.ErrorBars = ChartErrorBars{
+				Type:      getMapKey(chartErrorBarsType, stringValue(errBars.ErrValType)),
+				Direction: stringValue(errBars.ErrBarType),
+				NoEndCap:  boolValue(errBars.NoEndCap),
+			}
+			if errBars.Val != nil && errBars.Val.Val != nil {
+				s.ErrorBars.Value = *errBars.Val.Val
+			}
+		}
+		series = append(series, s)
+	}
+	return series
+}
+
+// getChartSeriesFormat provides a function to get the fill, line and marker
+// format of the chart series by given chart group properties and the shape
+// properties of the series. The default colors of the series will be ignored.
+func (f *File) getChartSeriesFormat(c *cCharts, deSer []decodeChartSer, chart *Chart) {
+	lineTypes := map[ChartType]bool{Line: true, Scatter: true, StockHighLowClose: true, StockOpenHighLowClose: true}
+	for i := range chart.Series {
+		if c.Ser == nil || i >= len(*c.Ser) || i >= len(deSer) {
+			return
+		}
+		s, ser := &chart.Series[i], (*c.Ser)[i]
+		if spPr := deSer[i].SpPr; spPr != nil {
+			var lineColor string
+			if spPr.Ln != nil {
+				lineColor = getChartColor(spPr.Ln.SolidFill)
+			}
+			if lineTypes[chart.Type] {
+				if lineColor != "accent"+strconv.Itoa(intValue(ser.IDx)%6+1) {
+					s.Line.Color = lineColor
+				}
+			} else {
+				if color := getChartColor(spPr.SolidFill); color != "" {
+					s.Fill.Color = []string{color}
+				}
+				s.Line.Color = lineColor
+			}
+		}
+		if ser.Marker == nil {
+			continue
+		}
+		marker := f.drawChartSeriesMarker(i, &Chart{Type: chart.Type, Series: make([]ChartSeries, i+1)})
+		if symbol := stringValue(ser.Marker.Symbol); marker != nil && symbol != stringValue(marker.Symbol) {
+			s.Marker.Symbol = symbol
+		}
+		if size := intValue(ser.Marker.Size); size != 5 {
+			s.Marker.Size = size
+		}
+		if deSer[i].Marker != nil {
+			color := getChartColor(deSer[i].Marker.SolidFill)
+			if color != "" && (i >= 6 || color != "accent"+strconv.Itoa(i+1)) {
+				s.Marker.Fill.Color = []string{color}
+			}
+		}
+	}
+}
+
+// getChartColor provides a function to get the RGB color or theme color name
+// by given solid fill element.
+func getChartColor(fill *decodeChartSolidFill) string {
+	if fill == nil {
+		return ""
+	}
+	if fill.SrgbClr != nil {
+		return stringValue(fill.SrgbClr)
+	}
+	return stringValue(fill.SchemeClr)
+}
+
+// getChartDLbls provides a function to get the data labels options of the
+// chart by given data labels element.
+func (f *File) getChartDLbls(dLbls *cDLbls, chart *Chart) {
+	if dLbls == nil {
+		return
+	}
+	label := f.getChartDataLabel(dLbls)
+	chart.Legend.ShowLegendKey = boolValue(dLbls.ShowLegendKey)
+	chart.PlotArea.ShowBubbleSize = boolValue(dLbls.ShowBubbleSize)
+	chart.PlotArea.ShowCatName = label.ShowCatName
+	chart.PlotArea.ShowLeaderLines = boolValue(dLbls.ShowLeaderLines)
+	chart.PlotArea.ShowPercent = label.ShowPercent
+	chart.PlotArea.ShowSerName = label.ShowSerName
+	chart.PlotArea.ShowVal = label.ShowVal
+	chart.PlotArea.NumFmt = label.NumFmt
+}
+
+// getChartDataLabel provides a function to get the data labels options by
+// given data labels element.
+func (f *File) getChartDataLabel(dLbls *cDLbls) ChartDataLabel {
+	label := ChartDataLabel{
+		Position:    getMapKey(chartDataLabelPosition, stringValue(dLbls.DLblPos)),
+		ShowCatName: boolValue(dLbls.ShowCatName),
+		ShowPercent: boolValue(dLbls.ShowPercent),
+		ShowSerName: boolValue(dLbls.ShowSerName),
+		ShowVal:     boolValue(dLbls.ShowVal),
+	}
+	if dLbls.NumFmt != nil {
+		label.NumFmt = ChartNumFmt{CustomNumFmt: dLbls.NumFmt.FormatCode, SourceLinked: dLbls.NumFmt.SourceLinked}
+	}
+	return label
+}
+
+// getChartAxis provides a function to get the axis options by given axis
+// element, axis title and the default number format code of the axis.
+func (f *File) getChartAxis(ax *cAxs, title *decodeChartTitle, formatCode string) ChartAxis {
+	var axis ChartAxis
+	if ax == nil {
+		return axis
+	}
+	axis.None = boolValue(ax.Delete)
+	axis.MajorGridLines = ax.MajorGridlines != nil
+	axis.MinorGridLines = ax.MinorGridlines != nil
+	if ax.MajorUnit != nil && ax.MajorUnit.Val != nil {
+		axis.MajorUnit = *ax.MajorUnit.Val
+	}
+	if ax.MinorUnit != nil && ax.MinorUnit.Val != nil {
+		axis.MinorUnit = *ax.MinorUnit.Val
+	}
+	axis.TickLabelSkip = intValue(ax.TickLblSkip)
+	if ax.Scaling != nil {
+		axis.ReverseOrder = stringValue(ax.Scaling.Orientation) == orientation[true]
+		if ax.Scaling.Max != nil && ax.Scaling.Max.Val != nil {
+			axis.Maximum = float64Ptr(*ax.Scaling.Max.Val)
+		}
+		if ax.Scaling.Min != nil && ax.Scaling.Min.Val != nil {
+			axis.Minimum = float64Ptr(*ax.Scaling.Min.Val)
+		}
+		if ax.Scaling.LogBase != nil && ax.Scaling.LogBase.Val != nil {
+			axis.LogBase = *ax.Scaling.LogBase.Val
+		}
+	}
+	if ax.NumFmt != nil && (ax.NumFmt.SourceLinked || ax.NumFmt.FormatCode != formatCode) {
+		axis.NumFmt = ChartNumFmt{CustomNumFmt: ax.NumFmt.FormatCode, SourceLinked: ax.NumFmt.SourceLinked}
+	}
+	if axis.Title = f.getChartTitle(title); title != nil {
+		axis.TitleRef = title.F
+	}
+	return axis
+}
+
+// getChartTitle provides a function to get the rich text runs of the title by
+// given decoded title element.
+func (f *File) getChartTitle(title *decodeChartTitle) []RichTextRun {
+	var runs []RichTextRun
+	if title == nil {
+		return runs
+	}
+	for _, p := range title.P {
+		for _, r := range p.R {
+			run := RichTextRun{Text: r.T}
+			if r.RPr.B || r.RPr.I || r.RPr.Sz > 0 || r.RPr.SrgbClr != nil {
+				run.Font = &Font{Bold: r.RPr.B, Italic: r.RPr.I, Size: r.RPr.Sz / 100}
+				if r.RPr.SrgbClr != nil {
+					run.Font.Color = stringValue(r.RPr.SrgbClr)
+				}
+			}
+			runs = append(runs, run)
+		}
+	}
+	return runs
+}
+
+// getMapKey returns the key of the map by given value, or an empty string if
+// the value doesn't exist in the map.
+func getMapKey(m map[string]string, val string) string {
+	for k, v := range m {
+		if v == val {
+			return k
+		}
+	}
+	return ""
+}
+
+// boolValue returns the value of the boolean attribute, or false if the
+// attribute doesn't exist.
+func boolValue(v *attrValBool) bool {
+	return v != nil && v.Val != nil && *v.Val
+}
+
+// intValue returns the value of the integer attribute, or 0 if the attribute
+// doesn't exist.
+func intValue(v *attrValInt) int {
+	if v == nil || v.Val == nil {
+		return 0
+	}
+	return *v.Val
+}
+
+// stringValue returns the value of the string attribute, or an empty string if
+// the attribute doesn't exist.
+func stringValue(v *attrValString) string {
+	if v == nil || v.Val == nil {
+		return ""
+	}
+	return *v.Val
+}
+
+// countCharts provides a function to get the largest index of the chart parts
+// storage in the folder xl/charts.
+func (f *File) countCharts() int {
+	return f.countPartIndex("xl/charts/chart")
 }
 
 // ptToEMUs provides a function to convert pt to EMUs, 1 pt = 12700 EMUs. The
@@ -297,11 +297,11 @@ func TestAddChart(t *testing.T) {
 	// Test with illegal cell reference
 	assert.EqualError(t, f.AddChart("Sheet2", "A", &Chart{Type: Col, Series: series, Format: format, Legend: legend, Title: []RichTextRun{{Text: "2D Column Chart"}}, PlotArea: plotArea, ShowBlanksAs: "zero"}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 	// Test with unsupported chart type
-	assert.EqualError(t, f.AddChart("Sheet2", "BD32", &Chart{Type: 0x37, Series: series, Format: format, Legend: legend, Title: []RichTextRun{{Text: "Bubble 3D Chart"}}, PlotArea: plotArea, ShowBlanksAs: "zero"}), newUnsupportedChartType(0x37).Error())
+	assert.EqualError(t, f.AddChart("Sheet2", "BD32", &Chart{Type: 0x3A, Series: series, Format: format, Legend: legend, Title: []RichTextRun{{Text: "Bubble 3D Chart"}}, PlotArea: plotArea, ShowBlanksAs: "zero"}), newUnsupportedChartType(0x3A).Error())
 	// Test add combo chart with invalid format set
 	assert.EqualError(t, f.AddChart("Sheet2", "BD32", &Chart{Type: Col, Series: series, Format: format, Legend: legend, Title: []RichTextRun{{Text: "2D Column Chart"}}, PlotArea: plotArea, ShowBlanksAs: "zero"}, nil), ErrParameterInvalid.Error())
 	// Test add combo chart with unsupported chart type
-	assert.EqualError(t, f.AddChart("Sheet2", "BD64", &Chart{Type: BarOfPie, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$A$30:$D$37", Values: "Sheet1!$B$30:$B$37"}}, Format: format, Legend: legend, Title: []RichTextRun{{Text: "Bar of Pie Chart"}}, PlotArea: plotArea, ShowBlanksAs: "zero", XAxis: ChartAxis{MajorGridLines: true}, YAxis: ChartAxis{MajorGridLines: true}}, &Chart{Type: 0x37, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$A$30:$D$37", Values: "Sheet1!$B$30:$B$37"}}, Format: format, Legend: legend, Title: []RichTextRun{{Text: "Bar of Pie Chart"}}, PlotArea: plotArea, ShowBlanksAs: "zero", XAxis: ChartAxis{MajorGridLines: true}, YAxis: ChartAxis{MajorGridLines: true}}), newUnsupportedChartType(0x37).Error())
+	assert.EqualError(t, f.AddChart("Sheet2", "BD64", &Chart{Type: BarOfPie, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$A$30:$D$37", Values: "Sheet1!$B$30:$B$37"}}, Format: format, Legend: legend, Title: []RichTextRun{{Text: "Bar of Pie Chart"}}, PlotArea: plotArea, ShowBlanksAs: "zero", XAxis: ChartAxis{MajorGridLines: true}, YAxis: ChartAxis{MajorGridLines: true}}, &Chart{Type: 0x3A, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$A$30:$D$37", Values: "Sheet1!$B$30:$B$37"}}, Format: format, Legend: legend, Title: []RichTextRun{{Text: "Bar of Pie Chart"}}, PlotArea: plotArea, ShowBlanksAs: "zero", XAxis: ChartAxis{MajorGridLines: true}, YAxis: ChartAxis{MajorGridLines: true}}), newUnsupportedChartType(0x3A).Error())
 	assert.NoError(t, f.Close())
 
 	// Test add chart with unsupported charset content types.
@@ -344,7 +344,7 @@ func TestAddChartSheet(t *testing.T) {
 	// Test add chartsheet with invalid sheet name
 	assert.EqualError(t, f.AddChartSheet("Sheet:1", nil, &Chart{Type: Col3DClustered, Series: series, Title: []RichTextRun{{Text: "Fruit 3D Clustered Column Chart"}}}), ErrSheetNameInvalid.Error())
 	// Test with unsupported chart type
-	assert.EqualError(t, f.AddChartSheet("Chart2", &Chart{Type: 0x37, Series: series, Title: []RichTextRun{{Text: "Fruit 3D Clustered Column Chart"}}}), newUnsupportedChartType(0x37).Error())
+	assert.EqualError(t, f.AddChartSheet("Chart2", &Chart{Type: 0x3A, Series: series, Title: []RichTextRun{{Text: "Fruit 3D Clustered Column Chart"}}}), newUnsupportedChartType(0x3A).Error())
 
 	assert.NoError(t, f.UpdateLinkedValue())
 
@@ -402,6 +402,221 @@ func TestDeleteChart(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestDeleteChartParts(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.DeleteChart("Sheet1", "A1"))
+	for _, part := range []string{
+		"xl/charts/chart1.xml", "xl/charts/_rels/chart1.xml.rels",
+		"xl/charts/style1.xml", "xl/charts/colors1.xml",
+	} {
+		_, ok := f.Pkg.Load(part)
+		assert.False(t, ok, part)
+	}
+	_, ok := f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, override := range contentTypes.Overrides {
+		assert.NotContains(t, []string{"/xl/charts/chart1.xml", "/xl/charts/style1.xml", "/xl/charts/colors1.xml"}, override.PartName)
+	}
+	assert.Nil(t, f.getDrawingRelationships("xl/drawings/_rels/drawing1.xml.rels", "rId1"))
+	// Test delete chart which referenced by other drawing part
+	f.Relationships.Store("xl/drawings/_rels/drawing2.xml.rels", &xlsxRelationships{
+		Relationships: []xlsxRelationship{{ID: "rId1", Type: SourceRelationshipChart, Target: "../charts/chart2.xml"}},
+	})
+	assert.NoError(t, f.DeleteChart("Sheet1", "G1"))
+	_, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, f.Close())
+
+	// Test chart parts index after delete chart
+	f = NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Col, Series: series}))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", &Chart{Type: Line, Series: series}))
+	assert.NoError(t, f.DeleteChart("Sheet1", "E1"))
+	_, ok = f.Pkg.Load("xl/charts/chart1.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f.AddChart("Sheet1", "E40", &Chart{Type: Pie, Series: series}))
+	_, ok = f.Pkg.Load("xl/charts/chart3.xml")
+	assert.True(t, ok)
+	charts, err := f.GetCharts("Sheet1", "E20")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	assert.Equal(t, Line, charts[0].Type)
+	charts, err = f.GetCharts("Sheet1", "E40")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	assert.Equal(t, Pie, charts[0].Type)
+
+	// Test delete chart with unsupported charset drawing relationships
+	assert.NoError(t, f.AddChart("Sheet1", "E60", &Chart{Type: Col, Series: series}))
+	f.Relationships.Delete("xl/drawings/_rels/drawing2.xml.rels")
+	f.Pkg.Store("xl/drawings/_rels/drawing2.xml.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteChart("Sheet1", "E60"), "XML syntax error on line 1: invalid UTF-8")
+	f.Pkg.Delete("xl/drawings/_rels/drawing2.xml.rels")
+	// Test delete chart with unsupported charset chart relationships
+	f.Pkg.Store("xl/charts/_rels/chart5.xml.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.deleteChartPart("xl/charts/chart5.xml"), "XML syntax error on line 1: invalid UTF-8")
+	// Test delete chart with unsupported charset content types
+	f.Relationships.Store("xl/charts/_rels/chart5.xml.rels", &xlsxRelationships{
+		Relationships: []xlsxRelationship{{ID: "rId1", Type: SourceRelationshipChartStyle, Target: "style5.xml"}},
+	})
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.deleteChartPart("xl/charts/chart5.xml"), "XML syntax error on line 1: invalid UTF-8")
+	f.Relationships.Delete("xl/charts/_rels/chart5.xml.rels")
+	f.Pkg.Delete("xl/charts/_rels/chart5.xml.rels")
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.deleteChartPart("xl/charts/chart5.xml"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test delete chart with unsupported charset drawing part
+	f = NewFile()
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Col, Series: series}))
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteChart("Sheet1", "E1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetCharts(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	charts, err := f.GetCharts("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	assert.Equal(t, Doughnut, charts[0].Type)
+	assert.Equal(t, "Sheet2!$A$1", charts[0].Series[0].Name)
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type:   Col,
+		Series: series,
+		Title:  []RichTextRun{{Text: "Fruit ", Font: &Font{Bold: true}}, {Text: "2D Column Chart"}},
+		Legend: ChartLegend{Position: "left"},
+		XAxis: ChartAxis{
+			ReverseOrder: true, MajorGridLines: true, TickLabelSkip: 2,
+			Title: []RichTextRun{{Text: "Category"}},
+		},
+		YAxis: ChartAxis{
+			Maximum: float64Ptr(100), Minimum: float64Ptr(10), LogBase: 10, MajorUnit: 5,
+			MinorGridLines: true, NumFmt: ChartNumFmt{CustomNumFmt: "0.00%"},
+			Title: []RichTextRun{{Text: "Value", Font: &Font{Color: "FF0000", Size: 12}}},
+		},
+		PlotArea:     ChartPlotArea{ShowVal: true, ShowCatName: true},
+		ShowBlanksAs: "zero",
+	}, &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4"}},
+		YAxis:  ChartAxis{Secondary: true},
+	}))
+	check := func(charts []Chart) {
+		assert.Len(t, charts, 2)
+		assert.Equal(t, Col, charts[0].Type)
+		assert.Equal(t, series, charts[0].Series)
+		assert.Equal(t, []RichTextRun{
+			{Text: "Fruit ", Font: &Font{Bold: true, Color: "595959", Size: 14}},
+			{Text: "2D Column Chart", Font: &Font{Color: "595959", Size: 14}},
+		}, charts[0].Title)
+		assert.Equal(t, "left", charts[0].Legend.Position)
+		assert.Equal(t, "zero", charts[0].ShowBlanksAs)
+		assert.True(t, charts[0].PlotArea.ShowVal)
+		assert.True(t, charts[0].PlotArea.ShowCatName)
+		assert.False(t, charts[0].PlotArea.ShowPercent)
+		assert.Equal(t, ChartAxis{
+			ReverseOrder: true, MajorGridLines: true, TickLabelSkip: 2,
+			Title: []RichTextRun{{Text: "Category"}},
+		}, charts[0].XAxis)
+		assert.Equal(t, ChartAxis{
+			Maximum: float64Ptr(100), Minimum: float64Ptr(10), LogBase: 10, MajorUnit: 5,
+			MinorGridLines: true, NumFmt: ChartNumFmt{CustomNumFmt: "0.00%"},
+			Title: []RichTextRun{{Text: "Value", Font: &Font{Color: "FF0000", Size: 12}}},
+		}, charts[0].YAxis)
+		assert.Equal(t, Line, charts[1].Type)
+		assert.Equal(t, "Sheet1!$B$4:$D$4", charts[1].Series[0].Values)
+		assert.True(t, charts[1].YAxis.Secondary)
+	}
+	charts, err = f.GetCharts("Sheet1", "E1")
+	assert.NoError(t, err)
+	check(charts)
+	// Test get charts from the saved workbook
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	charts, err = f.GetCharts("Sheet1", "E1")
+	assert.NoError(t, err)
+	check(charts)
+	// Test get charts on the cell without chart
+	charts, err = f.GetCharts("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, charts)
+	assert.NoError(t, f.Close())
+
+	// Test get charts with all supported chart types
+	f = NewFile()
+	for chartType := Area; chartType <= Bubble3D; chartType++ {
+		cell, err := CoordinatesToCellName(1, int(chartType)*20+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.AddChart("Sheet1", cell, &Chart{Type: chartType, Series: []ChartSeries{
+			{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2", Sizes: "Sheet1!$B$3:$D$3"},
+		}}))
+		charts, err := f.GetCharts("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Len(t, charts, 1)
+		assert.Equal(t, chartType, charts[0].Type)
+	}
+	// Test get charts with invalid sheet name
+	_, err = f.GetCharts("Sheet:1", "A1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get charts on not exists worksheet
+	_, err = f.GetCharts("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get charts with invalid cell reference
+	_, err = f.GetCharts("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get charts on no chart worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	charts, err = f.GetCharts("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Nil(t, charts)
+	// Test get charts with unsupported charset chart part
+	f.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCharts("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get charts with unsupported charset drawing part
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCharts("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetChartType(t *testing.T) {
+	f := NewFile()
+	_, ok := f.getChartType("area", &cCharts{Grouping: &attrValString{Val: stringPtr("unknown")}})
+	assert.False(t, ok)
+	_, ok = f.getChartType("unknown", &cCharts{})
+	assert.False(t, ok)
+	charts, err := f.getChart("xl/charts/chart1.xml")
+	assert.NoError(t, err)
+	assert.Empty(t, charts)
+	f.Pkg.Store("xl/charts/chart1.xml", []byte(`<c:chartSpace xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart"><c:chart><c:plotArea><c:areaChart><c:grouping val="unknown"/></c:areaChart></c:plotArea></c:chart></c:chartSpace>`))
+	charts, err = f.getChart("xl/charts/chart1.xml")
+	assert.NoError(t, err)
+	assert.Empty(t, charts)
+	assert.NoError(t, f.Close())
+}
+
 func TestChartWithLogarithmicBase(t *testing.T) {
 	// Create test XLSX file with data
 	f := NewFile()
@@ -503,3 +718,481 @@ func TestChartWithLogarithmicBase(t *testing.T) {
 		}
 	}
 }
+
+func TestChartAxisOptions(t *testing.T) {
+	f := NewFile()
+	for idx, val := range []string{"Year", "Amount", "Rate"} {
+		cell, err := CoordinatesToCellName(idx+1, 1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, val))
+	}
+	series := []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$6", Values: "Sheet1!$B$2:$B$6"}}
+	xAxis := ChartAxis{ReverseOrder: true, NumFmt: ChartNumFmt{CustomNumFmt: "0"}, TitleRef: "Sheet1!$A$1"}
+	yAxis := ChartAxis{
+		Maximum: float64Ptr(1000), Minimum: float64Ptr(1), LogBase: 10, MajorUnit: 100, MinorUnit: 10,
+		NumFmt: ChartNumFmt{CustomNumFmt: "#,##0"}, TitleRef: "Sheet1!$B$1",
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Line, Series: series, XAxis: xAxis, YAxis: yAxis},
+		&Chart{
+			Type:   Col,
+			Series: []ChartSeries{{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$6", Values: "Sheet1!$C$2:$C$6"}},
+			YAxis:  ChartAxis{Secondary: true, MajorUnit: 0.1, MinorUnit: 0.05, TitleRef: "Sheet1!$C$1"},
+		},
+	))
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	plotArea := chartSpace.Chart.PlotArea
+	assert.Equal(t, "Sheet1!$A$1", plotArea.CatAx[0].Title.Tx.StrRef.F)
+	assert.Equal(t, "Sheet1!$B$1", plotArea.ValAx[0].Title.Tx.StrRef.F)
+	assert.Equal(t, "Sheet1!$C$1", plotArea.ValAx[1].Title.Tx.StrRef.F)
+	assert.Equal(t, 10.0, *plotArea.ValAx[0].MinorUnit.Val)
+	assert.Equal(t, 0.05, *plotArea.ValAx[1].MinorUnit.Val)
+	assert.Equal(t, "#,##0", plotArea.ValAx[0].NumFmt.FormatCode)
+	// Test get chart axis options
+	charts, err := f.GetCharts("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 2)
+	assert.Equal(t, xAxis, charts[0].XAxis)
+	assert.Equal(t, yAxis, charts[0].YAxis)
+	assert.Equal(t, 0.1, charts[1].YAxis.MajorUnit)
+	assert.Equal(t, 0.05, charts[1].YAxis.MinorUnit)
+	assert.Equal(t, "Sheet1!$C$1", charts[1].YAxis.TitleRef)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartAxisOptions.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestChartSeriesLabelsTrendlinesErrorBars(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{
+		{
+			Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$F$1", Values: "Sheet1!$B$2:$F$2",
+			DataLabel: ChartDataLabel{Position: "outside_end", ShowVal: true, NumFmt: ChartNumFmt{CustomNumFmt: "0.0"}},
+			Trendline: ChartTrendline{Type: "linear", DisplayEquation: true, DisplayRSquared: true},
+			ErrorBars: ChartErrorBars{Type: "percentage", Value: 5},
+		},
+		{
+			Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$F$1", Values: "Sheet1!$B$3:$F$3",
+			Trendline: ChartTrendline{Type: "moving_average"},
+			ErrorBars: ChartErrorBars{Type: "standard_error", Direction: "plus", NoEndCap: true},
+		},
+		{
+			Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$F$1", Values: "Sheet1!$B$4:$F$4",
+			Trendline: ChartTrendline{Type: "polynomial", Order: 3, DisplayRSquared: true},
+		},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "H1", &Chart{Type: Col, Series: series}))
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ser := *chartSpace.Chart.PlotArea.BarChart.Ser
+	assert.Equal(t, "outEnd", *ser[0].DLbls.DLblPos.Val)
+	assert.Equal(t, "0.0", ser[0].DLbls.NumFmt.FormatCode)
+	assert.Equal(t, "linear", *ser[0].Trendline.TrendlineType.Val)
+	assert.True(t, *ser[0].Trendline.DispRSqr.Val)
+	assert.NotNil(t, ser[0].Trendline.TrendlineLbl)
+	assert.Equal(t, "percentage", *ser[0].ErrBars.ErrValType.Val)
+	assert.Equal(t, "both", *ser[0].ErrBars.ErrBarType.Val)
+	assert.Nil(t, ser[0].ErrBars.ErrDir)
+	assert.Equal(t, 2, *ser[1].Trendline.Period.Val)
+	assert.Nil(t, ser[1].Trendline.DispRSqr)
+	assert.Nil(t, ser[1].ErrBars.Val)
+	assert.Equal(t, 3, *ser[2].Trendline.Order.Val)
+	assert.Nil(t, ser[2].DLbls.DLblPos)
+	// Test get chart series data labels, trendlines and error bars
+	charts, err := f.GetCharts("Sheet1", "H1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	series[1].Trendline.Period, series[1].ErrorBars.Value = 2, 0
+	assert.Equal(t, series, charts[0].Series)
+	// Test add scatter chart with error bars and data labels
+	assert.NoError(t, f.AddChart("Sheet1", "H20", &Chart{Type: Scatter, Series: []ChartSeries{
+		{
+			Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$F$1", Values: "Sheet1!$B$2:$F$2",
+			DataLabel: ChartDataLabel{Position: "right", ShowSerName: true},
+			ErrorBars: ChartErrorBars{Type: "standard_deviation", Value: 1, Direction: "minus"},
+		},
+	}}))
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	chartSpace = xlsxChartSpace{}
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ser = *chartSpace.Chart.PlotArea.ScatterChart.Ser
+	assert.Equal(t, "r", *ser[0].DLbls.DLblPos.Val)
+	assert.Equal(t, "y", *ser[0].ErrBars.ErrDir.Val)
+	// Test add chart with trendline and error bars on unsupported chart type
+	assert.NoError(t, f.AddChart("Sheet1", "H40", &Chart{Type: Pie, Series: []ChartSeries{
+		{
+			Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$F$1", Values: "Sheet1!$B$2:$F$2",
+			Trendline: ChartTrendline{Type: "linear"}, ErrorBars: ChartErrorBars{Type: "fixed", Value: 1},
+		},
+	}}))
+	assert.NoError(t, f.AddChart("Sheet1", "H60", &Chart{Type: Contour, Series: []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$F$1", Values: "Sheet1!$B$2:$F$2", DataLabel: ChartDataLabel{ShowVal: true}},
+	}}))
+	for _, part := range []string{"xl/charts/chart3.xml", "xl/charts/chart4.xml"} {
+		content, ok = f.Pkg.Load(part)
+		assert.True(t, ok)
+		assert.NotContains(t, string(content.([]byte)), "trendline")
+		assert.NotContains(t, string(content.([]byte)), "errBars")
+	}
+	assert.NotContains(t, string(content.([]byte)), "dLbls")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartSeriesLabelsTrendlinesErrorBars.xlsx")))
+	// Test add chart with invalid series options
+	for _, c := range []struct {
+		ser ChartSeries
+		err error
+	}{
+		{ser: ChartSeries{DataLabel: ChartDataLabel{Position: "unknown"}}, err: ErrChartDataLabelPosition},
+		{ser: ChartSeries{Trendline: ChartTrendline{Type: "unknown"}}, err: ErrChartTrendline},
+		{ser: ChartSeries{Trendline: ChartTrendline{Type: "polynomial", Order: 7}}, err: ErrChartTrendline},
+		{ser: ChartSeries{Trendline: ChartTrendline{Type: "moving_average", Period: 1}}, err: ErrChartTrendline},
+		{ser: ChartSeries{ErrorBars: ChartErrorBars{Type: "unknown"}}, err: ErrChartErrorBars},
+		{ser: ChartSeries{ErrorBars: ChartErrorBars{Type: "fixed", Direction: "unknown"}}, err: ErrChartErrorBars},
+	} {
+		c.ser.Values = "Sheet1!$B$2:$F$2"
+		assert.Equal(t, c.err, f.AddChart("Sheet1", "H80", &Chart{Type: Col, Series: []ChartSeries{c.ser}}))
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartSeriesReferences(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3},
+		{"Normal", 5, 2, 4}, {"Large", 6, 7, 8},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+	}
+	for idx, chartType := range []ChartType{Col, Bar, Line, Pie, Doughnut, Area, Scatter, Radar} {
+		assert.NoError(t, f.AddChart("Sheet1", fmt.Sprintf("F%d", idx*16+1), &Chart{Type: chartType, Series: series}))
+		chart, ok := f.Pkg.Load(fmt.Sprintf("xl/charts/chart%d.xml", idx+1))
+		assert.True(t, ok)
+		var chartSpace xlsxChartSpace
+		assert.NoError(t, xml.Unmarshal(chart.([]byte), &chartSpace))
+		plotArea := chartSpace.Chart.PlotArea
+		charts := map[ChartType]*cCharts{
+			Col: plotArea.BarChart, Bar: plotArea.BarChart, Line: plotArea.LineChart,
+			Pie: plotArea.PieChart, Doughnut: plotArea.DoughnutChart, Area: plotArea.AreaChart,
+			Scatter: plotArea.ScatterChart, Radar: plotArea.RadarChart,
+		}[chartType]
+		if !assert.NotNil(t, charts) {
+			continue
+		}
+		if chartType == Col || chartType == Bar {
+			assert.Equal(t, map[ChartType]string{Col: "col", Bar: "bar"}[chartType], *charts.BarDir.Val)
+		}
+		assert.Len(t, *charts.Ser, len(series))
+		for i, ser := range *charts.Ser {
+			assert.Equal(t, series[i].Name, ser.Tx.StrRef.F)
+			if chartType == Scatter {
+				assert.Equal(t, series[i].Categories, ser.XVal.StrRef.F)
+				assert.Equal(t, series[i].Values, ser.YVal.NumRef.F)
+				continue
+			}
+			assert.Equal(t, series[i].Categories, ser.Cat.StrRef.F)
+			assert.Equal(t, series[i].Values, ser.Val.NumRef.F)
+		}
+	}
+	// Test add chart without series
+	assert.Equal(t, ErrChartSeries, f.AddChart("Sheet1", "P1", &Chart{Type: Col}))
+	// Test add chart with series without values
+	assert.Equal(t, ErrChartSeries, f.AddChart("Sheet1", "P1", &Chart{
+		Type: Col, Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1"}},
+	}))
+	// Test add combo chart with series without values
+	assert.Equal(t, ErrChartSeries, f.AddChart("Sheet1", "P1", &Chart{Type: Col, Series: series}, &Chart{Type: Line}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartSecondaryAxis(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Q1", "Q2", "Q3"}, {"Sales", 20, 30, 30},
+		{"Growth", 0.5, 0.2, 0.4}, {"Profit", 6, 7, 8},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	primaryMax, secondaryMax := 50.0, 1.0
+	assert.NoError(t, f.AddChart("Sheet1", "F1", &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		},
+		YAxis: ChartAxis{Maximum: &primaryMax},
+	}, &Chart{
+		Type: Line,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+		},
+		YAxis: ChartAxis{Secondary: true, Maximum: &secondaryMax, MajorUnit: 0.25, NumFmt: ChartNumFmt{CustomNumFmt: "0%"}},
+	}, &Chart{
+		Type: Area,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4"},
+		},
+		YAxis: ChartAxis{Secondary: true},
+	}))
+	chart, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(chart.([]byte), &chartSpace))
+	plotArea := chartSpace.Chart.PlotArea
+	assert.NotNil(t, plotArea.BarChart)
+	assert.NotNil(t, plotArea.LineChart)
+	assert.NotNil(t, plotArea.AreaChart)
+	// Test the primary axes keep the settings of the first chart
+	assert.Len(t, plotArea.CatAx, 2)
+	assert.Len(t, plotArea.ValAx, 2)
+	assert.Equal(t, 100000001, *plotArea.ValAx[0].AxID.Val)
+	assert.Equal(t, primaryMax, *plotArea.ValAx[0].Scaling.Max.Val)
+	assert.Nil(t, plotArea.ValAx[0].MajorUnit)
+	// Test the secondary axis is scaled independently
+	assert.Equal(t, 100000004, *plotArea.ValAx[1].AxID.Val)
+	assert.Equal(t, "r", *plotArea.ValAx[1].AxPos.Val)
+	assert.Equal(t, secondaryMax, *plotArea.ValAx[1].Scaling.Max.Val)
+	assert.Equal(t, 0.25, *plotArea.ValAx[1].MajorUnit.Val)
+	assert.Equal(t, "0%", plotArea.ValAx[1].NumFmt.FormatCode)
+	assert.NoError(t, f.Close())
+}
+
+func TestAddStockChart(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Date", "Open", "High", "Low", "Close"},
+		{"2023-06-01", 44, 55, 11, 25},
+		{"2023-06-02", 25, 57, 12, 38},
+		{"2023-06-03", 38, 57, 13, 50},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"},
+		{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$C$2:$C$4"},
+		{Name: "Sheet1!$D$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$D$2:$D$4"},
+		{Name: "Sheet1!$E$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$E$2:$E$4"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "G1", &Chart{Type: StockHighLowClose, Series: series[1:], Title: []RichTextRun{{Text: "High-Low-Close Stock Chart"}}}))
+	assert.NoError(t, f.AddChart("Sheet1", "G16", &Chart{Type: StockOpenHighLowClose, Series: series, Title: []RichTextRun{{Text: "Open-High-Low-Close Stock Chart"}}}))
+	for i, expected := range []struct {
+		chartType  ChartType
+		upDownBars bool
+		symbols    []string
+	}{
+		{chartType: StockHighLowClose, symbols: []string{"none", "none", "dot"}},
+		{chartType: StockOpenHighLowClose, upDownBars: true, symbols: []string{"none", "none", "none", "none"}},
+	} {
+		chartSpace := xlsxChartSpace{}
+		content, ok := f.Pkg.Load(fmt.Sprintf("xl/charts/chart%d.xml", i+1))
+		assert.True(t, ok)
+		assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+		stockChart := chartSpace.Chart.PlotArea.StockChart
+		assert.NotNil(t, stockChart)
+		assert.NotNil(t, stockChart.HiLowLines)
+		assert.Equal(t, expected.upDownBars, stockChart.UpDownBars != nil)
+		assert.Len(t, *stockChart.Ser, len(expected.symbols))
+		for j, ser := range *stockChart.Ser {
+			assert.Equal(t, expected.symbols[j], *ser.Marker.Symbol.Val)
+		}
+		assert.Equal(t, len(expected.symbols), bytes.Count(content.([]byte), []byte(`<a:ln w="25400"><a:noFill>`)))
+		assert.Len(t, chartSpace.Chart.PlotArea.CatAx, 1)
+		assert.Len(t, chartSpace.Chart.PlotArea.ValAx, 1)
+		charts, err := f.GetCharts("Sheet1", []string{"G1", "G16"}[i])
+		assert.NoError(t, err)
+		assert.Len(t, charts, 1)
+		assert.Equal(t, expected.chartType, charts[0].Type)
+		assert.Len(t, charts[0].Series, len(expected.symbols))
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddStockChart.xlsx")))
+	// Test add stock chart with invalid number of series
+	assert.Equal(t, ErrChartStockSeries, f.AddChart("Sheet1", "P1", &Chart{Type: StockHighLowClose, Series: series}))
+	assert.Equal(t, ErrChartStockSeries, f.AddChart("Sheet1", "P1", &Chart{Type: StockOpenHighLowClose, Series: series[1:]}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddFunnelChart(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Stage", "Amount"}, {"Prospects", 500}, {"Qualified", 425},
+		{"Proposals", 200}, {"Finalized", 150},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$5", Values: "Sheet1!$B$2:$B$5"}}
+	assert.NoError(t, f.AddChart("Sheet1", "D1", &Chart{Type: Col, Series: series}))
+	assert.NoError(t, f.AddChart("Sheet1", "D16", &Chart{Type: Funnel, Series: series, Title: []RichTextRun{{Text: "Sales "}, {Text: "Funnel"}}}))
+	// Test the chartex part
+	content, ok := f.Pkg.Load("xl/charts/chartEx1.xml")
+	assert.True(t, ok)
+	chartSpace := xlsxChartExSpace{}
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	assert.Equal(t, "Sales Funnel", chartSpace.Chart.Title.Tx.TxData.V)
+	assert.Equal(t, "Sheet1!$A$2:$A$5", chartSpace.ChartData.Data[0].StrDim.F)
+	assert.Equal(t, "Sheet1!$B$2:$B$5", chartSpace.ChartData.Data[0].NumDim.F)
+	assert.Equal(t, "funnel", chartSpace.Chart.PlotArea.PlotAreaRegion.Series[0].LayoutID)
+	assert.Equal(t, "Sheet1!$B$1", chartSpace.Chart.PlotArea.PlotAreaRegion.Series[0].Tx.TxData.F)
+	// Test the relationships and content types of the chartex part
+	rels, ok := f.Relationships.Load("xl/drawings/_rels/drawing1.xml.rels")
+	assert.True(t, ok)
+	relationships := rels.(*xlsxRelationships).Relationships
+	assert.Len(t, relationships, 2)
+	assert.Equal(t, SourceRelationshipChartEx, relationships[1].Type)
+	assert.Equal(t, "../charts/chartEx1.xml", relationships[1].Target)
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, contentTypes.Overrides, xlsxOverride{PartName: "/xl/charts/chartEx1.xml", ContentType: ContentTypeChartEx})
+	// Test the graphic frame in the alternate content of the drawing part
+	wsDr, _, err := f.drawingParser("xl/drawings/drawing1.xml")
+	assert.NoError(t, err)
+	assert.Len(t, wsDr.TwoCellAnchor, 2)
+	assert.Len(t, wsDr.TwoCellAnchor[1].AlternateContent, 1)
+	assert.Contains(t, wsDr.TwoCellAnchor[1].AlternateContent[0].Content, `Requires="cx2"`)
+	assert.Contains(t, wsDr.TwoCellAnchor[1].AlternateContent[0].Content, `<cx:chart xmlns:cx="http://schemas.microsoft.com/office/drawing/2014/chartex" r:id="rId2"`)
+	assert.NoError(t, f.AddChart("Sheet1", "D32", &Chart{Type: Funnel, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$5"}}}))
+	_, ok = f.Pkg.Load("xl/charts/chartEx2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddFunnelChart.xlsx")))
+	// Test add funnel chart with illegal cell reference
+	assert.EqualError(t, f.AddChart("Sheet1", "A", &Chart{Type: Funnel, Series: series}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test add funnel chart combined with other charts
+	assert.EqualError(t, f.AddChart("Sheet1", "D48", &Chart{Type: Funnel, Series: series}, &Chart{Type: Col, Series: series}), newUnsupportedChartType(Funnel).Error())
+	assert.EqualError(t, f.AddChart("Sheet1", "D48", &Chart{Type: Col, Series: series}, &Chart{Type: Funnel, Series: series}), newUnsupportedChartType(Funnel).Error())
+	// Test add funnel chart in chartsheet
+	assert.EqualError(t, f.AddChartSheet("Chart1", &Chart{Type: Funnel, Series: series}), newUnsupportedChartType(Funnel).Error())
+	// Test add funnel chart with unsupported charset content types
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddChart("Sheet1", "D48", &Chart{Type: Funnel, Series: series}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddBubbleAndSurfaceChart(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2", Sizes: "Sheet1!$B$3:$D$3"}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Bubble, Series: series}))
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{Type: Surface3D, Series: series}))
+	chartSpace := xlsxChartSpace{}
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ser := (*chartSpace.Chart.PlotArea.BubbleChart.Ser)[0]
+	assert.Equal(t, "Sheet1!$B$3:$D$3", ser.BubbleSize.NumRef.F)
+	assert.Equal(t, "Sheet1!$B$1:$D$1", ser.XVal.StrRef.F)
+	assert.Equal(t, "Sheet1!$B$2:$D$2", ser.YVal.NumRef.F)
+	chartSpace = xlsxChartSpace{}
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	assert.NotNil(t, chartSpace.Chart.PlotArea.Surface3DChart)
+	assert.Len(t, chartSpace.Chart.PlotArea.SerAx, 1)
+	charts, err := f.GetCharts("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!$B$3:$D$3", charts[0].Series[0].Sizes)
+	assert.NoError(t, f.Close())
+}
+
+func TestChartStyleOptions(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3},
+		{"Normal", 5, 2, 4}, {"Large", 6, 7, 8},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	col := &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2", Fill: Fill{Color: []string{"#FF0000"}}, Line: ChartLine{Color: "tx1", Width: 1.5}},
+			{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3", Fill: Fill{Color: []string{"accent6"}}},
+		},
+		Legend:   ChartLegend{Position: "top"},
+		PlotArea: ChartPlotArea{Fill: Fill{Color: []string{"bg2"}}},
+		XAxis:    ChartAxis{MajorGridLines: true, MinorGridLines: true},
+		YAxis:    ChartAxis{MajorGridLines: true, MinorGridLines: true},
+	}
+	line := &Chart{
+		Type: Line,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4", Line: ChartLine{Color: "accent4"}, Marker: ChartMarker{Symbol: "diamond", Size: 8, Fill: Fill{Color: []string{"00FF00"}}}},
+		},
+		Legend: ChartLegend{Position: "none"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", col))
+	assert.NoError(t, f.AddChart("Sheet1", "E16", line))
+	chartSpace := xlsxChartSpace{}
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	assert.Equal(t, "t", *chartSpace.Chart.Legend.LegendPos.Val)
+	assert.NotNil(t, chartSpace.Chart.PlotArea.CatAx[0].MajorGridlines)
+	assert.NotNil(t, chartSpace.Chart.PlotArea.CatAx[0].MinorGridlines)
+	assert.NotNil(t, chartSpace.Chart.PlotArea.ValAx[0].MajorGridlines)
+	assert.NotNil(t, chartSpace.Chart.PlotArea.ValAx[0].MinorGridlines)
+	for _, expected := range []string{
+		`<spPr><a:solidFill><a:srgbClr val="FF0000"></a:srgbClr></a:solidFill><a:ln w="19050"><a:solidFill><a:schemeClr val="tx1"></a:schemeClr></a:solidFill></a:ln></spPr>`,
+		`<spPr><a:solidFill><a:schemeClr val="accent6"></a:schemeClr></a:solidFill></spPr>`,
+		`<spPr><a:solidFill><a:schemeClr val="bg2"></a:schemeClr></a:solidFill></spPr></plotArea>`,
+	} {
+		assert.Contains(t, string(content.([]byte)), expected)
+	}
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	for _, expected := range []string{
+		`<a:ln cap="rnd" w="25400"><a:solidFill><a:schemeClr val="accent4"></a:schemeClr></a:solidFill></a:ln>`,
+		`<marker><symbol val="diamond"></symbol><size val="8"></size><spPr><a:solidFill><a:srgbClr val="00FF00"></a:srgbClr></a:solidFill><a:ln w="9252"><a:solidFill><a:srgbClr val="00FF00"></a:srgbClr></a:solidFill></a:ln></spPr></marker>`,
+	} {
+		assert.Contains(t, string(content.([]byte)), expected)
+	}
+	assert.NotContains(t, string(content.([]byte)), "<legend>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartStyleOptions.xlsx")))
+	// Test get charts with style options
+	charts, err := f.GetCharts("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	assert.Equal(t, "top", charts[0].Legend.Position)
+	assert.Equal(t, []string{"bg2"}, charts[0].PlotArea.Fill.Color)
+	assert.Equal(t, []string{"FF0000"}, charts[0].Series[0].Fill.Color)
+	assert.Equal(t, "tx1", charts[0].Series[0].Line.Color)
+	assert.Equal(t, []string{"accent6"}, charts[0].Series[1].Fill.Color)
+	assert.Empty(t, charts[0].Series[1].Line.Color)
+	assert.True(t, charts[0].XAxis.MinorGridLines)
+	assert.True(t, charts[0].YAxis.MinorGridLines)
+	charts, err = f.GetCharts("Sheet1", "E16")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	assert.Equal(t, "none", charts[0].Legend.Position)
+	assert.Empty(t, charts[0].PlotArea.Fill.Color)
+	assert.Equal(t, "accent4", charts[0].Series[0].Line.Color)
+	assert.Equal(t, ChartMarker{Symbol: "diamond", Size: 8, Fill: Fill{Color: []string{"00FF00"}}}, charts[0].Series[0].Marker)
+	// Test get charts with default series colors and markers
+	series := []ChartSeries{{Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4"}}
+	assert.NoError(t, f.AddChart("Sheet1", "E32", &Chart{Type: Scatter, Series: series}))
+	assert.NoError(t, f.AddChart("Sheet1", "E48", &Chart{Type: Line, Series: series}))
+	for _, cell := range []string{"E32", "E48"} {
+		charts, err = f.GetCharts("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Len(t, charts, 1)
+		assert.Empty(t, charts[0].Series[0].Fill.Color)
+		assert.Equal(t, ChartMarker{}, charts[0].Series[0].Marker)
+	}
+	assert.Equal(t, "", charts[0].Series[0].Line.Color)
+	// Test add chart with unsupported legend position
+	assert.Equal(t, ErrChartLegendPosition, f.AddChart("Sheet1", "M1", &Chart{Type: Col, Series: col.Series, Legend: ChartLegend{Position: "unknown"}}))
+	// Test add chart with unsupported marker symbol and size
+	for _, marker := range []ChartMarker{{Symbol: "unknown"}, {Size: 1}, {Size: 73}} {
+		assert.Equal(t, ErrChartMarker, f.AddChart("Sheet1", "M1", &Chart{Type: Line, Series: []ChartSeries{{Values: "Sheet1!$B$2:$D$2", Marker: marker}}}))
+	}
+	assert.NoError(t, f.Close())
+}
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/mohae/deepcopy"
+	"golang.org/x/text/width"
 )
 
 // Define the default cell size and EMU unit of measurement.
@@ -30,15 +31,33 @@ const (
 	EMU                    int     = 9525
 )
 
+// defaultCharWidthPixels defined the width of characters in pixels with the
+// default font Calibri in size 11, which be used for estimate the width of the
+// text when auto fit the column width.
+var defaultCharWidthPixels = map[rune]float64{
+	' ': 3, '!': 5, '"': 6, '#': 7, '$': 7, '%': 11, '&': 10, '\'': 3, '(': 5,
+	')': 5, '*': 7, '+': 7, ',': 4, '-': 5, '.': 4, '/': 6, '0': 7, '1': 7,
+	'2': 7, '3': 7, '4': 7, '5': 7, '6': 7, '7': 7, '8': 7, '9': 7, ':': 4,
+	';': 4, '<': 7, '=': 7, '>': 7, '?': 7, '@': 13, 'A': 9, 'B': 8, 'C': 8,
+	'D': 9, 'E': 7, 'F': 7, 'G': 9, 'H': 9, 'I': 4, 'J': 5, 'K': 8, 'L': 6,
+	'M': 12, 'N': 10, 'O': 10, 'P': 8, 'Q': 10, 'R': 8, 'S': 7, 'T': 7, 'U': 10,
+	'V': 9, 'W': 13, 'X': 8, 'Y': 7, 'Z': 7, '[': 5, '\\': 6, ']': 5, '^': 7,
+	'_': 7, '`': 4, 'a': 7, 'b': 8, 'c': 6, 'd': 8, 'e': 8, 'f': 5, 'g': 7,
+	'h': 8, 'i': 4, 'j': 4, 'k': 7, 'l': 4, 'm': 12, 'n': 8, 'o': 8, 'p': 8,
+	'q': 8, 'r': 5, 's': 6, 't': 5, 'u': 8, 'v': 7, 'w': 11, 'x': 7, 'y': 7,
+	'z': 6, '{': 5, '|': 7, '}': 5, '~': 7,
+}
+
 // Cols defines an iterator to a sheet
 type Cols struct {
 	err                                    error
 	curCol, totalCols, totalRows, stashCol int
-	rawCellValue                           bool
+	options                                *Options
 	sheet                                  string
 	f                                      *File
 	sheetXML                               []byte
 	sst                                    *xlsxSST
+	stashRows                              []string
 }
 
 // GetCols gets the value of all cells by columns on the worksheet based on the
@@ -86,14 +105,19 @@ func (cols *Cols) Error() error {
 	return cols.err
 }
 
-// Rows return the current column's row values.
+// Rows return the current column's row values. The values of the current
+// column are cached, so calling this function again before the next call of
+// Next will not parse the worksheet again, and each call returns a new slice
+// which can be changed by the caller.
 func (cols *Cols) Rows(opts ...Options) ([]string, error) {
 	var rowIterator rowXMLIterator
-	if cols.stashCol >= cols.curCol {
-		return rowIterator.cells, rowIterator.err
+	options := getOptions(opts...)
+	if cols.stashCol == cols.curCol && cols.options != nil &&
+		cols.options.RawCellValue == options.RawCellValue && cols.options.FullPrecision == options.FullPrecision {
+		return append([]string(nil), cols.stashRows...), nil
 	}
-	cols.rawCellValue = getOptions(opts...).RawCellValue
-	if cols.sst, rowIterator.err = cols.f.sharedStringsReader(); rowIterator.err != nil {
+	cols.options = options
+	if cols.sst, rowIterator.err = cols.f.sharedStringsViewer(); rowIterator.err != nil {
 		return rowIterator.cells, rowIterator.err
 	}
 	decoder := cols.f.xmlNewDecoder(bytes.NewReader(cols.sheetXML))
@@ -118,10 +142,12 @@ func (cols *Cols) Rows(opts ...Options) ([]string, error) {
 			}
 		case xml.EndElement:
 			if xmlElement.Name.Local == "sheetData" {
+				cols.stashCol, cols.stashRows = cols.curCol, append([]string(nil), rowIterator.cells...)
 				return rowIterator.cells, rowIterator.err
 			}
 		}
 	}
+	cols.stashCol, cols.stashRows = cols.curCol, append([]string(nil), rowIterator.cells...)
 	return rowIterator.cells, rowIterator.err
 }
 
@@ -182,7 +208,7 @@ func (cols *Cols) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 		if rowIterator.cellCol == cols.curCol {
 			colCell := xlsxC{}
 			_ = decoder.DecodeElement(&colCell, xmlElement)
-			val, _ := colCell.getValueFrom(cols.f, cols.sst, cols.rawCellValue)
+			val, _ := colCell.getValueFrom(cols.f, cols.sst, cols.options)
 			rowIterator.cells = append(rowIterator.cells, val)
 		}
 	}
@@ -258,14 +284,14 @@ func (f *File) GetColVisible(sheet, col string) (bool, error) {
 		return true, err
 	}
 	f.mu.Lock()
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		f.mu.Unlock()
 		return false, err
 	}
 	f.mu.Unlock()
-	ws.mu.Lock()
-	defer ws.mu.Unlock()
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
 	if ws.Cols == nil {
 		return true, err
 	}
@@ -337,7 +363,7 @@ func (f *File) GetColOutlineLevel(sheet, col string) (uint8, error) {
 	if err != nil {
 		return level, err
 	}
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		return 0, err
 	}
@@ -414,6 +440,105 @@ func (f *File) SetColOutlineLevel(sheet, col string, level uint8) error {
 	return err
 }
 
+// GroupCols provides a function to group the columns by given worksheet name
+// and columns range, which will increase the outline level of the columns by
+// 1. The maximum outline level is 7. For example, group the columns B to E in
+// Sheet1:
+//
+//	err := f.GroupCols("Sheet1", "B:E")
+func (f *File) GroupCols(sheet, columns string) error {
+	return f.adjustColsOutlineLevel(sheet, columns, 1)
+}
+
+// UngroupCols provides a function to ungroup the columns by given worksheet
+// name and columns range, which will decrease the outline level of the
+// columns by 1. For example, ungroup the columns B to E in Sheet1:
+//
+//	err := f.UngroupCols("Sheet1", "B:E")
+func (f *File) UngroupCols(sheet, columns string) error {
+	return f.adjustColsOutlineLevel(sheet, columns, -1)
+}
+
+// adjustColsOutlineLevel provides a function to adjust the outline level of
+// the columns by given worksheet name, columns range and offset.
+func (f *File) adjustColsOutlineLevel(sheet, columns string, offset int) error {
+	min, max, err := f.parseColRange(columns)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.Cols == nil {
+		ws.Cols = &xlsxCols{}
+	}
+	cols := flatCols(xlsxCol{Min: min, Max: max}, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+		c.Min, c.Max = fc.Min, fc.Max
+		return c
+	})
+	for i := range cols {
+		if min <= cols[i].Min && cols[i].Min <= max && int(cols[i].OutlineLevel)+offset > 7 {
+			return ErrOutlineLevel
+		}
+	}
+	var maxLevel uint8
+	for i := range cols {
+		if level := int(cols[i].OutlineLevel) + offset; min <= cols[i].Min && cols[i].Min <= max && level >= 0 {
+			cols[i].OutlineLevel = uint8(level)
+		}
+		if cols[i].OutlineLevel > maxLevel {
+			maxLevel = cols[i].OutlineLevel
+		}
+	}
+	ws.Cols.Col = cols
+	if ws.SheetFormatPr == nil {
+		ws.SheetFormatPr = &xlsxSheetFormatPr{DefaultRowHeight: defaultRowHeight}
+	}
+	ws.SheetFormatPr.OutlineLevelCol = maxLevel
+	return err
+}
+
+// SetColsCollapsed provides a function to collapse or expand the grouped
+// columns by given worksheet name, columns range and collapsed state. The
+// columns in the range will be hidden when collapsed, and the collapsed state
+// will be set on the summary column, which is the column on the right of the
+// range by default, or the column on the left of the range if the
+// OutlineSummaryRight of the worksheet properties was set to false. For
+// example, collapse the columns B to E in Sheet1:
+//
+//	err := f.SetColsCollapsed("Sheet1", "B:E", true)
+func (f *File) SetColsCollapsed(sheet, columns string, collapsed bool) error {
+	min, max, err := f.parseColRange(columns)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	summaryCol := max + 1
+	if ws.SheetPr != nil && ws.SheetPr.OutlinePr != nil &&
+		ws.SheetPr.OutlinePr.SummaryRight != nil && !*ws.SheetPr.OutlinePr.SummaryRight {
+		summaryCol = min - 1
+	}
+	if ws.Cols == nil {
+		ws.Cols = &xlsxCols{}
+	}
+	ws.Cols.Col = flatCols(xlsxCol{Min: min, Max: max, Hidden: collapsed}, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+		c.Min, c.Max, c.Hidden = fc.Min, fc.Max, fc.Hidden
+		return c
+	})
+	if summaryCol < 1 || summaryCol > MaxColumns {
+		return err
+	}
+	ws.Cols.Col = flatCols(xlsxCol{Min: summaryCol, Max: summaryCol, Collapsed: collapsed}, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+		c.Min, c.Max, c.Collapsed = fc.Min, fc.Max, fc.Collapsed
+		return c
+	})
+	return err
+}
+
 // SetColStyle provides a function to set style of columns by given worksheet
 // name, columns range and style ID. This function is concurrency safe. Note
 // that this will overwrite the existing styles for the columns, it won't
@@ -432,7 +557,7 @@ func (f *File) SetColStyle(sheet, columns string, styleID int) error {
 		return err
 	}
 	f.mu.Lock()
-	s, err := f.stylesReader()
+	s, err := f.stylesViewer()
 	if err != nil {
 		f.mu.Unlock()
 		return err
@@ -468,14 +593,13 @@ func (f *File) SetColStyle(sheet, columns string, styleID int) error {
 		fc.Width = c.Width
 		return fc
 	})
-	ws.mu.Unlock()
-	if rows := len(ws.SheetData.Row); rows > 0 {
+	for rowIdx := range ws.SheetData.Row {
+		rowData := &ws.SheetData.Row[rowIdx]
 		for col := min; col <= max; col++ {
-			from, _ := CoordinatesToCellName(col, 1)
-			to, _ := CoordinatesToCellName(col, rows)
-			err = f.SetCellStyle(sheet, from, to, styleID)
+			rowData.C[rowData.prepareCell(col)].S = styleID
 		}
 	}
+	ws.mu.Unlock()
 	return err
 }
 
@@ -524,6 +648,208 @@ func (f *File) SetColWidth(sheet, startCol, endCol string, width float64) error
 	return err
 }
 
+// AutoFitColWidth provides a function to set the width of the columns to fit
+// the content by given worksheet name, columns range and optional settings.
+// The width of each cell was estimated by the formatted value of the cell and
+// the metrics of the font applied on the cell, and the cells in the merged
+// range across multiple columns will be ignored. The width of the columns
+// without any value will be kept. This function is concurrency safe. For
+// example, auto fit the width of columns A to H on Sheet1 and limit the width
+// of the columns between 8 and 50 characters:
+//
+//	err := f.AutoFitColWidth("Sheet1", "A", "H", &excelize.AutoFitColWidthOptions{
+//	    MinWidth: 8,
+//	    MaxWidth: 50,
+//	})
+func (f *File) AutoFitColWidth(sheet, startCol, endCol string, opts *AutoFitColWidthOptions) error {
+	min, max, err := f.parseColRange(startCol + ":" + endCol)
+	if err != nil {
+		return err
+	}
+	options, err := parseAutoFitColWidthOptions(opts)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	sst, err := f.sharedStringsViewer()
+	if err != nil {
+		return err
+	}
+	s, err := f.stylesViewer()
+	if err != nil {
+		return err
+	}
+	ws.mu.RLock()
+	widths, err := f.getColContentWidths(ws, s, sst, min, max)
+	ws.mu.RUnlock()
+	if err != nil || len(widths) == 0 {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.Cols == nil {
+		ws.Cols = &xlsxCols{}
+	}
+	for col := min; col <= max; col++ {
+		pixels, ok := widths[col]
+		if !ok {
+			continue
+		}
+		ws.Cols.Col = flatCols(xlsxCol{
+			Min:         col,
+			Max:         col,
+			Width:       float64Ptr(math.Max(options.MinWidth, math.Min(options.MaxWidth, convertPixelsToColWidth(pixels)))),
+			BestFit:     true,
+			CustomWidth: true,
+		}, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+			fc.Collapsed = c.Collapsed
+			fc.Hidden = c.Hidden
+			fc.OutlineLevel = c.OutlineLevel
+			fc.Phonetic = c.Phonetic
+			fc.Style = c.Style
+			return fc
+		})
+	}
+	return err
+}
+
+// parseAutoFitColWidthOptions provides a function to validate and set the
+// default value of the auto fit column width settings.
+func parseAutoFitColWidthOptions(opts *AutoFitColWidthOptions) (*AutoFitColWidthOptions, error) {
+	options := AutoFitColWidthOptions{MaxWidth: MaxColumnWidth}
+	if opts != nil {
+		options.MinWidth = opts.MinWidth
+		if opts.MaxWidth != 0 {
+			options.MaxWidth = opts.MaxWidth
+		}
+	}
+	if options.MinWidth > MaxColumnWidth || options.MaxWidth > MaxColumnWidth {
+		return &options, ErrColumnWidth
+	}
+	if options.MinWidth < 0 || options.MaxWidth < 0 || options.MinWidth > options.MaxWidth {
+		return &options, ErrParameterInvalid
+	}
+	return &options, nil
+}
+
+// getColContentWidths provides a function to get the maximum width of the
+// cell values in pixels for each column in given columns range.
+func (f *File) getColContentWidths(ws *xlsxWorksheet, s *xlsxStyleSheet, sst *xlsxSST, min, max int) (map[int]float64, error) {
+	var mergedCols [][]int
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			if mergeCell == nil {
+				continue
+			}
+			rect, err := rangeRefToCoordinates(mergeCell.Ref)
+			if err != nil {
+				return nil, err
+			}
+			if rect[0] != rect[2] {
+				mergedCols = append(mergedCols, rect)
+			}
+		}
+	}
+	inMergedCell := func(col, row int) bool {
+		for _, rect := range mergedCols {
+			if rect[0] <= col && col <= rect[2] && rect[1] <= row && row <= rect[3] {
+				return true
+			}
+		}
+		return false
+	}
+	widths, opts := map[int]float64{}, getOptions()
+	for rowIdx := range ws.SheetData.Row {
+		for colIdx := range ws.SheetData.Row[rowIdx].C {
+			c := &ws.SheetData.Row[rowIdx].C[colIdx]
+			col, row, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return widths, err
+			}
+			if col < min || col > max || inMergedCell(col, row) {
+				continue
+			}
+			val, err := c.getValueFrom(f, sst, opts)
+			if err != nil {
+				return widths, err
+			}
+			if val == "" {
+				continue
+			}
+			pixels := getTextWidthPixels(val, s.getCellFont(ws.prepareCellStyle(col, row, c.S)))
+			if pixels > widths[col] {
+				widths[col] = pixels
+			}
+		}
+	}
+	return widths, nil
+}
+
+// getCellFont provides a function to get the font of the cell by given style
+// index, the default font of the workbook will be returned if the cell style
+// doesn't specify the font.
+func (ss *xlsxStyleSheet) getCellFont(styleID int) *xlsxFont {
+	if ss.Fonts == nil || len(ss.Fonts.Font) == 0 {
+		return nil
+	}
+	if ss.CellXfs != nil && 0 <= styleID && styleID < len(ss.CellXfs.Xf) {
+		if fontID := ss.CellXfs.Xf[styleID].FontID; fontID != nil && 0 <= *fontID && *fontID < len(ss.Fonts.Font) {
+			return ss.Fonts.Font[*fontID]
+		}
+	}
+	return ss.Fonts.Font[0]
+}
+
+// getTextWidthPixels provides a function to estimate the width of the text in
+// pixels by given font. The width of the multiple lines text depends on the
+// longest line.
+func getTextWidthPixels(text string, font *xlsxFont) float64 {
+	scale := 1.0
+	if font != nil {
+		if font.Sz != nil && font.Sz.Val != nil && *font.Sz.Val > 0 {
+			scale = *font.Sz.Val / 11
+		}
+		if font.B != nil && (font.B.Val == nil || *font.B.Val) {
+			scale *= 1.1
+		}
+	}
+	var pixels float64
+	for _, line := range strings.Split(text, "\n") {
+		var linePixels float64
+		for _, r := range line {
+			if charWidth, ok := defaultCharWidthPixels[r]; ok {
+				linePixels += charWidth
+				continue
+			}
+			if kind := width.LookupRune(r).Kind(); kind == width.EastAsianWide || kind == width.EastAsianFullwidth {
+				linePixels += 15
+				continue
+			}
+			linePixels += 8
+		}
+		pixels = math.Max(pixels, linePixels*scale)
+	}
+	return pixels
+}
+
+// convertPixelsToColWidth provides a function to convert the width of the
+// content in pixels to the column width in characters, which include the
+// padding of the cell.
+func convertPixelsToColWidth(pixels float64) float64 {
+	var padding float64 = 7
+	var maxDigitWidth float64 = 7
+	if pixels += padding; pixels <= 12 {
+		return math.Round(pixels/12*100) / 100
+	}
+	return math.Round((pixels-5)/maxDigitWidth*100) / 100
+}
+
 // flatCols provides a method for the column's operation functions to flatten
 // and check the worksheet columns.
 func flatCols(col xlsxCol, cols []xlsxCol, replacer func(fc, c xlsxCol) xlsxCol) []xlsxCol {
@@ -639,10 +965,51 @@ func (f *File) positionObjectPixels(sheet string, col, row, x1, y1, width, heigh
 	return colIdx, rowIdx, colEnd, rowEnd, width, height
 }
 
+// positionObjectEMUs calculate the vertices that define the position of a
+// graphical object within the worksheet in EMUs. Unlike positionObjectPixels,
+// the offsets and size of the object are measured in EMUs, and the adjusted
+// start offsets are also returned, so that the exact position of the object
+// will not be lost by rounding to pixels.
+func (f *File) positionObjectEMUs(sheet string, col, row, x1, y1, width, height int) (int, int, int, int, int, int, int, int) {
+	colIdx, rowIdx := col-1, row-1
+	// Adjust start column for offsets that are greater than the col width.
+	for x1 >= f.getColWidth(sheet, colIdx+1)*EMU {
+		colIdx++
+		x1 -= f.getColWidth(sheet, colIdx) * EMU
+	}
+
+	// Adjust start row for offsets that are greater than the row height.
+	for y1 >= f.getRowHeight(sheet, rowIdx+1)*EMU {
+		rowIdx++
+		y1 -= f.getRowHeight(sheet, rowIdx) * EMU
+	}
+
+	// Initialized end cell to the same as the start cell.
+	colEnd, rowEnd := colIdx, rowIdx
+
+	width += x1
+	height += y1
+
+	// Subtract the underlying cell widths to find end cell of the object.
+	for width >= f.getColWidth(sheet, colEnd+1)*EMU {
+		colEnd++
+		width -= f.getColWidth(sheet, colEnd) * EMU
+	}
+
+	// Subtract the underlying cell heights to find end cell of the object.
+	for height >= f.getRowHeight(sheet, rowEnd+1)*EMU {
+		rowEnd++
+		height -= f.getRowHeight(sheet, rowEnd) * EMU
+	}
+
+	// The end vertices are whatever is left from the width and height.
+	return colIdx, rowIdx, colEnd, rowEnd, x1, y1, width, height
+}
+
 // getColWidth provides a function to get column width in pixels by given
 // sheet name and column number.
 func (f *File) getColWidth(sheet string, col int) int {
-	ws, _ := f.workSheetReader(sheet)
+	ws, _ := f.workSheetViewer(sheet)
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 	if ws.Cols != nil {
@@ -672,14 +1039,14 @@ func (f *File) GetColStyle(sheet, col string) (int, error) {
 		return styleID, err
 	}
 	f.mu.Lock()
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		f.mu.Unlock()
 		return styleID, err
 	}
 	f.mu.Unlock()
-	ws.mu.Lock()
-	defer ws.mu.Unlock()
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
 	if ws.Cols != nil {
 		for _, v := range ws.Cols.Col {
 			if v.Min <= colNum && colNum <= v.Max {
@@ -698,14 +1065,14 @@ func (f *File) GetColWidth(sheet, col string) (float64, error) {
 		return defaultColWidth, err
 	}
 	f.mu.Lock()
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		f.mu.Unlock()
 		return defaultColWidth, err
 	}
 	f.mu.Unlock()
-	ws.mu.Lock()
-	defer ws.mu.Unlock()
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
 	if ws.Cols != nil {
 		var width float64
 		for _, v := range ws.Cols.Col {
@@ -755,26 +1122,64 @@ func (f *File) InsertCols(sheet, col string, n int) error {
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
 func (f *File) RemoveCol(sheet, col string) error {
+	return f.RemoveCols(sheet, col, 1)
+}
+
+// RemoveCols provides a function to remove columns by given worksheet name,
+// the first column name to be removed and number of columns, the references
+// will be adjusted only once. For example, remove columns C to E in Sheet1:
+//
+//	err := f.RemoveCols("Sheet1", "C", 3)
+//
+// Use this method with caution, which will affect changes in references such
+// as formulas, charts, and so on. If there is any referenced value of the
+// worksheet, it will cause a file error when you open it. The excelize only
+// partially updates these references currently.
+func (f *File) RemoveCols(sheet, col string, n int) error {
 	num, err := ColumnNameToNumber(col)
 	if err != nil {
 		return err
 	}
-
+	if n < 1 || n > MaxColumns {
+		return ErrColumnNumber
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
 	for rowIdx := range ws.SheetData.Row {
 		rowData := &ws.SheetData.Row[rowIdx]
+		keep := 0
 		for colIdx := range rowData.C {
-			colName, _, _ := SplitCellName(rowData.C[colIdx].R)
-			if colName == col {
-				rowData.C = append(rowData.C[:colIdx], rowData.C[colIdx+1:]...)[:len(rowData.C)-1]
-				break
+			if cellCol, _, _ := CellNameToCoordinates(rowData.C[colIdx].R); cellCol < num || cellCol >= num+n {
+				rowData.C[keep] = rowData.C[colIdx]
+				keep++
 			}
 		}
+		rowData.C = rowData.C[:keep]
+	}
+	return f.adjustHelper(sheet, columns, num, -n)
+}
+
+// MoveCol provides a function to move a column to the destination position by
+// given worksheet name, source and destination column name. The columns
+// between the source and destination will be shifted by one, and the cell
+// references, merged cells, hyperlinks, formulas and defined names which refer
+// to the worksheet will be updated. For example, move column B to column E in
+// Sheet1, and the original columns C to E will be shifted left as columns B to
+// D:
+//
+//	err := f.MoveCol("Sheet1", "B", "E")
+func (f *File) MoveCol(sheet, col, dest string) error {
+	src, err := ColumnNameToNumber(col)
+	if err != nil {
+		return err
+	}
+	dst, err := ColumnNameToNumber(dest)
+	if err != nil {
+		return err
 	}
-	return f.adjustHelper(sheet, columns, num, -1)
+	return f.moveHelper(sheet, columns, src, dst)
 }
 
 // convertColWidthToPixels provides function to convert the width of a cell
@@ -2,6 +2,7 @@ package excelize
 
 import (
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 
@@ -104,6 +105,28 @@ func TestColumnsIterator(t *testing.T) {
 	assert.Equal(t, expectedNumCol, colCount)
 }
 
+func TestColsSparse(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{"A1": "a", "B2": 2, "A4": "c", "C3": 3.5} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	cols, err := f.Cols("Sheet1")
+	assert.NoError(t, err)
+	var results [][]string
+	for cols.Next() {
+		col, err := cols.Rows()
+		assert.NoError(t, err)
+		// Test get the current column's values again
+		cached, err := cols.Rows()
+		assert.NoError(t, err)
+		assert.Equal(t, col, cached)
+		results = append(results, col)
+	}
+	assert.NoError(t, cols.Error())
+	assert.Equal(t, [][]string{{"a", "", "", "c"}, {"", "2", ""}, {"", "", "3.5"}}, results)
+	assert.NoError(t, f.Close())
+}
+
 func TestColsError(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -181,6 +204,19 @@ func TestColsRows(t *testing.T) {
 	cols.sheetXML = nil
 	_, err = cols.Rows()
 	assert.NoError(t, err)
+
+	// Test change the returned row values will not affect the cached values
+	f = NewFile()
+	assert.NoError(t, f.SetSheetCol("Sheet1", "A1", &[]string{"a", "b"}))
+	cols, err = f.Cols("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, cols.Next())
+	for i := 0; i < 2; i++ {
+		rows, err := cols.Rows()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, rows)
+		rows[0] = "c"
+	}
 }
 
 func TestColumnVisibility(t *testing.T) {
@@ -317,6 +353,68 @@ func TestOutlineLevel(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestGroupCols(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetColWidth("Sheet1", "C", "C", 20))
+	assert.NoError(t, f.GroupCols("Sheet1", "B:E"))
+	assert.NoError(t, f.GroupCols("Sheet1", "D:C"))
+	for col, expected := range map[string]uint8{"A": 0, "B": 1, "C": 2, "D": 2, "E": 1, "F": 0} {
+		level, err := f.GetColOutlineLevel("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, level, col)
+	}
+	width, err := f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, uint8(2), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelCol)
+	// Test collapse and expand the grouped columns
+	assert.NoError(t, f.SetColsCollapsed("Sheet1", "B:E", true))
+	for col, expected := range map[string]bool{"A": true, "B": false, "E": false, "F": true} {
+		visible, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, visible, col)
+	}
+	for _, col := range ws.(*xlsxWorksheet).Cols.Col {
+		assert.Equal(t, col.Min == 6, col.Collapsed, col.Min)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGroupCols.xlsx")))
+	assert.NoError(t, f.SetColsCollapsed("Sheet1", "B:E", false))
+	visible, err := f.GetColVisible("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	// Test collapse the grouped columns with summary columns on the left
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{OutlineSummaryRight: boolPtr(false)}))
+	assert.NoError(t, f.SetColsCollapsed("Sheet1", "C:D", true))
+	for _, col := range ws.(*xlsxWorksheet).Cols.Col {
+		assert.Equal(t, col.Min == 2, col.Collapsed, col.Min)
+	}
+	assert.NoError(t, f.SetColsCollapsed("Sheet1", "A:B", true))
+	// Test ungroup the columns
+	assert.NoError(t, f.UngroupCols("Sheet1", "A:E"))
+	assert.NoError(t, f.UngroupCols("Sheet1", "A:E"))
+	for _, col := range []string{"A", "B", "C", "D", "E"} {
+		level, err := f.GetColOutlineLevel("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Zero(t, level, col)
+	}
+	assert.Zero(t, ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelCol)
+	// Test group columns exceeds the maximum outline level
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "C", 7))
+	assert.Equal(t, ErrOutlineLevel, f.GroupCols("Sheet1", "A:C"))
+	level, err := f.GetColOutlineLevel("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Zero(t, level)
+	// Test group and collapse columns with illegal column name
+	assert.EqualError(t, f.GroupCols("Sheet1", "*"), newInvalidColumnNameError("*").Error())
+	assert.EqualError(t, f.SetColsCollapsed("Sheet1", "*", true), newInvalidColumnNameError("*").Error())
+	// Test group and collapse columns on not exists worksheet
+	assert.EqualError(t, f.GroupCols("SheetN", "A"), "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetColsCollapsed("SheetN", "A", true), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestSetColStyle(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "Hello"))
@@ -345,7 +443,7 @@ func TestSetColStyle(t *testing.T) {
 	assert.NoError(t, f.SetColStyle("Sheet1", "D:C", styleID))
 	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
 	assert.True(t, ok)
-	ws.(*xlsxWorksheet).SheetData.Row[1].C[2].S = 0
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[1].S = 0
 	cellStyleID, err := f.GetCellStyle("Sheet1", "C2")
 	assert.NoError(t, err)
 	assert.Equal(t, styleID, cellStyleID)
@@ -396,6 +494,84 @@ func TestColWidth(t *testing.T) {
 	convertRowHeightToPixels(0)
 }
 
+func TestAutoFitColWidth(t *testing.T) {
+	f := NewFile()
+	numFmt, err := f.NewStyle(&Style{NumFmt: 4})
+	assert.NoError(t, err)
+	bold, err := f.NewStyle(&Style{Font: &Font{Bold: true, Size: 22}})
+	assert.NoError(t, err)
+	for cell, value := range map[string]interface{}{
+		"A1": "Hello", "A2": "Hi", "B1": 1234.5678, "C1": "中文", "D1": strings.Repeat("merged", 10),
+		"D2": "ab", "F1": "a\nlonger", "G1": strings.Repeat("W", 300),
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", numFmt))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D2", "D2", bold))
+	assert.NoError(t, f.MergeCell("Sheet1", "D1", "E1"))
+	assert.NoError(t, f.SetColWidth("Sheet1", "E", "E", 20))
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "A", 1))
+	assert.NoError(t, f.AutoFitColWidth("Sheet1", "A", "G", nil))
+	for col, expected := range map[string]float64{
+		"A": 5, "B": 7.43, "C": 4.57, "D": 5, "E": 20, "F": 6, "G": MaxColumnWidth, "H": defaultColWidth,
+	} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	level, err := f.GetColOutlineLevel("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(1), level)
+	// Test auto fit column width with minimum and maximum width
+	assert.NoError(t, f.AutoFitColWidth("Sheet1", "G", "A", &AutoFitColWidthOptions{MinWidth: 6, MaxWidth: 50}))
+	for col, expected := range map[string]float64{"A": 6, "B": 7.43, "G": 50} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAutoFitColWidth.xlsx")))
+	// Test auto fit column width with invalid options
+	assert.Equal(t, ErrColumnWidth, f.AutoFitColWidth("Sheet1", "A", "B", &AutoFitColWidthOptions{MaxWidth: MaxColumnWidth + 1}))
+	assert.Equal(t, ErrParameterInvalid, f.AutoFitColWidth("Sheet1", "A", "B", &AutoFitColWidthOptions{MinWidth: 10, MaxWidth: 5}))
+	assert.Equal(t, ErrParameterInvalid, f.AutoFitColWidth("Sheet1", "A", "B", &AutoFitColWidthOptions{MinWidth: -1}))
+	// Test auto fit column width with illegal column name
+	assert.EqualError(t, f.AutoFitColWidth("Sheet1", "*", "B", nil), newInvalidColumnNameError("*").Error())
+	// Test auto fit column width on not exists worksheet
+	assert.EqualError(t, f.AutoFitColWidth("SheetN", "A", "B", nil), "sheet SheetN does not exist")
+	// Test auto fit column width with invalid merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells.Cells[0].Ref = "A"
+	assert.Equal(t, ErrParameterInvalid, f.AutoFitColWidth("Sheet1", "A", "B", nil))
+	assert.NoError(t, f.Close())
+
+	// Test auto fit column width without any value
+	f = NewFile()
+	assert.NoError(t, f.AutoFitColWidth("Sheet1", "A", "B", nil))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).Cols)
+	// Test auto fit column width with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AutoFitColWidth("Sheet1", "A", "B", nil), "XML syntax error on line 1: invalid UTF-8")
+	// Test auto fit column width with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AutoFitColWidth("Sheet1", "A", "B", nil), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetCellFont(t *testing.T) {
+	ss := &xlsxStyleSheet{}
+	assert.Nil(t, ss.getCellFont(0))
+	ss.Fonts = &xlsxFonts{Font: []*xlsxFont{{}, {B: &attrValBool{}}}}
+	ss.CellXfs = &xlsxCellXfs{Xf: []xlsxXf{{FontID: intPtr(1)}, {FontID: intPtr(2)}}}
+	assert.Equal(t, ss.Fonts.Font[1], ss.getCellFont(0))
+	assert.Equal(t, ss.Fonts.Font[0], ss.getCellFont(1))
+	assert.Equal(t, ss.Fonts.Font[0], ss.getCellFont(2))
+}
+
 func TestGetColStyle(t *testing.T) {
 	f := NewFile()
 	styleID, err := f.GetColStyle("Sheet1", "A")
@@ -461,6 +637,91 @@ func TestRemoveCol(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveCol.xlsx")))
 }
 
+func TestRemoveCols(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, fillCells(f, "Sheet1", 10, 5))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C3", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "C1"))
+	assert.NoError(t, f.MergeCell("Sheet1", "E2", "F2"))
+	assert.NoError(t, f.SetColWidth("Sheet1", "C", "F", 20))
+	assert.NoError(t, f.AutoFilter("Sheet1", "C4:D5", nil))
+	assert.NoError(t, f.RemoveCols("Sheet1", "b", 3))
+
+	for cell, expected := range map[string]string{"A1": "A1", "B1": "E1", "G5": "J5", "H5": ""} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "B2", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "C2", mergeCells[0].GetEndAxis())
+	for col, expected := range map[string]float64{"A": defaultColWidth, "B": 20, "C": 20, "D": defaultColWidth} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.Hyperlinks)
+	assert.Nil(t, ws.AutoFilter)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveCols.xlsx")))
+
+	// Test remove columns with illegal column name and number of columns
+	assert.EqualError(t, f.RemoveCols("Sheet1", "*", 1), newInvalidColumnNameError("*").Error())
+	assert.Equal(t, ErrColumnNumber, f.RemoveCols("Sheet1", "A", 0))
+	assert.Equal(t, ErrColumnNumber, f.RemoveCols("Sheet1", "A", MaxColumns+1))
+	// Test remove columns on not exists worksheet
+	assert.EqualError(t, f.RemoveCols("SheetN", "A", 2), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestMoveCol(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, fillCells(f, "Sheet1", 6, 3))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A5", "SUM(B1:D1)*$B$2"))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 20))
+	assert.NoError(t, f.SetColWidth("Sheet1", "F", "F", 30))
+	assert.NoError(t, f.MergeCell("Sheet1", "C4", "C5"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$B:$C"}))
+	assert.NoError(t, f.MoveCol("Sheet1", "B", "E"))
+
+	for cell, expected := range map[string]string{"A1": "A1", "B1": "C1", "D2": "E2", "E2": "B2", "F3": "F3"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "A5")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(B1:C1)*$E$2", formula)
+	for col, expected := range map[string]float64{"B": defaultColWidth, "E": 20, "F": 30} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width, col)
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B4", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "$B:$B", strings.TrimPrefix(f.GetDefinedName()[0].RefersTo, "Sheet1!"))
+	// Test move column backward
+	assert.NoError(t, f.MoveCol("Sheet1", "E", "B"))
+	value, err := f.GetCellValue("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2", value)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMoveCol.xlsx")))
+	// Test move column without columns properties
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.Cols = &xlsxCols{Col: []xlsxCol{{Min: 1, Max: 2}}}
+	assert.NoError(t, f.MoveCol("Sheet1", "A", "B"))
+	assert.Nil(t, ws.Cols)
+	// Test move column with illegal column name
+	assert.EqualError(t, f.MoveCol("Sheet1", "*", "A"), newInvalidColumnNameError("*").Error())
+	assert.EqualError(t, f.MoveCol("Sheet1", "A", "*"), newInvalidColumnNameError("*").Error())
+	assert.NoError(t, f.Close())
+}
+
 func TestConvertColWidthToPixels(t *testing.T) {
 	assert.Equal(t, -11.0, convertColWidthToPixels(-1))
 }
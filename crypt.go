@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha1"
@@ -38,6 +39,10 @@ import (
 
 var (
 	blockKey                    = []byte{0x14, 0x6e, 0x0b, 0xe7, 0xab, 0xac, 0xd0, 0xd6} // Block keys used for encryption
+	blockKeyHmacKey             = []byte{0x5f, 0xb2, 0xad, 0x01, 0x0c, 0xb9, 0xe1, 0xf6}
+	blockKeyHmacValue           = []byte{0xa0, 0x67, 0x7f, 0x02, 0xb2, 0x2c, 0x84, 0x33}
+	blockKeyVerifierHashInput   = []byte{0xfe, 0xa7, 0xd2, 0x76, 0x3b, 0x4b, 0x9e, 0x79}
+	blockKeyVerifierHashValue   = []byte{0xd7, 0xaa, 0x0f, 0x6d, 0x30, 0x61, 0x34, 0x4e}
 	oleIdentifier               = []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}
 	headerCLSID                 = make([]byte, 16)
 	difSect                     = -4
@@ -46,6 +51,7 @@ var (
 	iterCount                   = 50000
 	packageEncryptionChunkSize  = 4096
 	packageOffset               = 8 // First 8 bytes are the size of the stream
+	passwordSpinCount           = 1e5
 	sheetProtectionSpinCount    = 1e5
 	workbookProtectionSpinCount = 1e5
 )
@@ -129,14 +135,6 @@ type StandardEncryptionVerifier struct {
 	EncryptedVerifierHash []byte
 }
 
-// encryptionInfo structure is used for standard encryption with SHA1
-// cryptographic algorithm.
-type encryption struct {
-	BlockSize, SaltSize                                                                  int
-	EncryptedKeyValue, EncryptedVerifierHashInput, EncryptedVerifierHashValue, SaltValue []byte
-	KeyBits                                                                              uint32
-}
-
 // Decrypt API decrypts the CFB file format with ECMA-376 agile encryption and
 // standard encryption. Support cryptographic algorithm: MD4, MD5, RIPEMD-160,
 // SHA1, SHA256, SHA384 and SHA512 currently.
@@ -156,31 +154,64 @@ func Decrypt(raw []byte, opts *Options) (packageBuf []byte, err error) {
 	return standardDecrypt(encryptionInfoBuf, encryptedPackageBuf, opts)
 }
 
-// Encrypt API encrypt data with the password.
+// Encrypt API encrypts data with the password by ECMA-376 agile encryption,
+// using the SHA512 hash algorithm and the AES-256 cipher algorithm.
 func Encrypt(raw []byte, opts *Options) ([]byte, error) {
-	encryptor := encryption{
-		EncryptedVerifierHashInput: make([]byte, 16),
-		EncryptedVerifierHashValue: make([]byte, 32),
-		SaltValue:                  make([]byte, 16),
-		BlockSize:                  16,
-		KeyBits:                    128,
-		SaltSize:                   16,
+	if len(opts.Password) == 0 || len(opts.Password) > MaxFieldLength {
+		return nil, ErrPasswordLengthInvalid
 	}
-	// Key Encryption
-	encryptionInfoBuffer, err := encryptor.standardKeyEncryption(opts.Password)
+	keyData := KeyData{
+		SaltSize:        16,
+		BlockSize:       16,
+		KeyBits:         256,
+		HashSize:        64,
+		CipherAlgorithm: "AES",
+		CipherChaining:  "ChainingModeCBC",
+		HashAlgorithm:   "SHA512",
+	}
+	encryption := Encryption{
+		KeyData: keyData,
+		KeyEncryptors: KeyEncryptors{KeyEncryptor: []KeyEncryptor{{
+			URI:          "http://schemas.microsoft.com/office/2006/keyEncryptor/password",
+			EncryptedKey: EncryptedKey{SpinCount: int(passwordSpinCount), KeyData: keyData},
+		}}},
+	}
+	packageKey, err := randomBytes(keyData.KeyBits / 8)
+	if err != nil {
+		return nil, err
+	}
+	keyDataSalt, err := randomBytes(keyData.SaltSize)
 	if err != nil {
 		return nil, err
 	}
+	encryption.KeyData.SaltValue = base64.StdEncoding.EncodeToString(keyDataSalt)
 	// Package Encryption
-	encryptedPackage := make([]byte, 8)
-	binary.LittleEndian.PutUint64(encryptedPackage, uint64(len(raw)))
-	encryptedPackage = append(encryptedPackage, encryptor.encrypt(raw)...)
+	encryptedPackage, err := encryptPackage(packageKey, raw, encryption)
+	if err != nil {
+		return nil, err
+	}
+	// Data Integrity
+	if err = encryption.setDataIntegrity(packageKey, encryptedPackage); err != nil {
+		return nil, err
+	}
+	// Key Encryption
+	if err = encryption.setPasswordKeyEncryptor(opts.Password, packageKey); err != nil {
+		return nil, err
+	}
+	encryptionInfo, err := xml.Marshal(encryption)
+	if err != nil {
+		return nil, err
+	}
+	encryptionInfoBuffer := bytes.NewBuffer([]byte{0x04, 0x00, 0x04, 0x00, 0x40, 0x00, 0x00, 0x00})
+	encryptionInfoBuffer.WriteString(xml.Header)
+	encryptionInfoBuffer.Write(bytes.Replace(encryptionInfo, []byte("<encryption>"),
+		[]byte(`<encryption xmlns="http://schemas.microsoft.com/office/2006/encryption">`), 1))
 	// Create a new CFB
 	compoundFile := &cfb{
 		paths:   []string{"Root Entry/"},
 		sectors: []sector{{name: "Root Entry", typeID: 5}},
 	}
-	compoundFile.put("EncryptionInfo", encryptionInfoBuffer)
+	compoundFile.put("EncryptionInfo", encryptionInfoBuffer.Bytes())
 	compoundFile.put("EncryptedPackage", encryptedPackage)
 	return compoundFile.write(), nil
 }
@@ -270,7 +301,7 @@ func standardDecrypt(encryptionInfoBuf, encryptedPackageBuf []byte, opts *Option
 	for bs, be := 0, size; bs < len(x); bs, be = bs+size, be+size {
 		blob.Decrypt(decrypted[bs:be], x[bs:be])
 	}
-	return decrypted, err
+	return trimPackage(decrypted, encryptedPackageBuf), err
 }
 
 // standardEncryptionVerifier extract ECMA-376 standard encryption verifier.
@@ -329,68 +360,6 @@ func standardXORBytes(a, b []byte) []byte {
 	return buf
 }
 
-// encrypt provides a function to encrypt given value with AES cryptographic
-// algorithm.
-func (e *encryption) encrypt(input []byte) []byte {
-	inputBytes := len(input)
-	if pad := inputBytes % e.BlockSize; pad != 0 {
-		inputBytes += e.BlockSize - pad
-	}
-	var output, chunk []byte
-	encryptedChunk := make([]byte, e.BlockSize)
-	for i := 0; i < inputBytes; i += e.BlockSize {
-		if i+e.BlockSize <= len(input) {
-			chunk = input[i : i+e.BlockSize]
-		} else {
-			chunk = input[i:]
-		}
-		chunk = append(chunk, make([]byte, e.BlockSize-len(chunk))...)
-		c, _ := aes.NewCipher(e.EncryptedKeyValue)
-		c.Encrypt(encryptedChunk, chunk)
-		output = append(output, encryptedChunk...)
-	}
-	return output
-}
-
-// standardKeyEncryption encrypt convert the password to an encryption key.
-func (e *encryption) standardKeyEncryption(password string) ([]byte, error) {
-	if len(password) == 0 || len(password) > MaxFieldLength {
-		return nil, ErrPasswordLengthInvalid
-	}
-	var storage cfb
-	storage.writeUint16(0x0003)
-	storage.writeUint16(0x0002)
-	storage.writeUint32(0x24)
-	storage.writeUint32(0xA4)
-	storage.writeUint32(0x24)
-	storage.writeUint32(0x00)
-	storage.writeUint32(0x660E)
-	storage.writeUint32(0x8004)
-	storage.writeUint32(0x80)
-	storage.writeUint32(0x18)
-	storage.writeUint64(0x00)
-	providerName := "Microsoft Enhanced RSA and AES Cryptographic Provider (Prototype)"
-	storage.writeStrings(providerName)
-	storage.writeUint16(0x00)
-	storage.writeUint32(0x10)
-	keyDataSaltValue, _ := randomBytes(16)
-	verifierHashInput, _ := randomBytes(16)
-	e.SaltValue = keyDataSaltValue
-	e.EncryptedKeyValue, _ = standardConvertPasswdToKey(
-		StandardEncryptionHeader{KeySize: e.KeyBits},
-		StandardEncryptionVerifier{Salt: e.SaltValue},
-		&Options{Password: password})
-	verifierHashInputKey := hashing("sha1", verifierHashInput)
-	e.EncryptedVerifierHashInput = e.encrypt(verifierHashInput)
-	e.EncryptedVerifierHashValue = e.encrypt(verifierHashInputKey)
-	storage.writeBytes(e.SaltValue)
-	storage.writeBytes(e.EncryptedVerifierHashInput)
-	storage.writeUint32(0x14)
-	storage.writeBytes(e.EncryptedVerifierHashValue)
-	storage.position = 0
-	return storage.stream, nil
-}
-
 // ECMA-376 Agile Encryption
 
 // agileDecrypt decrypt the CFB file format with ECMA-376 agile encryption.
@@ -418,7 +387,107 @@ func agileDecrypt(encryptionInfoBuf, encryptedPackageBuf []byte, opts *Options)
 	}
 	packageKey, _ := decrypt(key, saltValue, encryptedKeyValue)
 	// Use the package key to decrypt the package.
-	return decryptPackage(packageKey, encryptedPackageBuf, encryptionInfo)
+	if packageBuf, err = decryptPackage(packageKey, encryptedPackageBuf, encryptionInfo); err != nil {
+		return
+	}
+	return trimPackage(packageBuf, encryptedPackageBuf), err
+}
+
+// trimPackage provides a function to remove the padding bytes of the
+// decrypted package by given stream size in the encrypted package.
+func trimPackage(packageBuf, encryptedPackageBuf []byte) []byte {
+	if len(encryptedPackageBuf) < packageOffset {
+		return packageBuf
+	}
+	if size := binary.LittleEndian.Uint64(encryptedPackageBuf[:packageOffset]); size < uint64(len(packageBuf)) {
+		return packageBuf[:size]
+	}
+	return packageBuf
+}
+
+// encryptPackage encrypt package by given packageKey and encryption info, the
+// encrypted package starts with the size of the stream.
+func encryptPackage(packageKey, input []byte, encryption Encryption) ([]byte, error) {
+	output := make([]byte, packageOffset)
+	binary.LittleEndian.PutUint64(output, uint64(len(input)))
+	for i, start := 0, 0; start < len(input); i, start = i+1, start+packageEncryptionChunkSize {
+		end := start + packageEncryptionChunkSize
+		if end > len(input) {
+			end = len(input)
+		}
+		iv, err := createIV(i, encryption)
+		if err != nil {
+			return nil, err
+		}
+		outputChunk, err := encrypt(packageKey, iv, input[start:end])
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, outputChunk...)
+	}
+	return output, nil
+}
+
+// setDataIntegrity provides a function to generate the encrypted HMAC key and
+// the encrypted HMAC value of the encrypted package.
+func (encryption *Encryption) setDataIntegrity(packageKey, encryptedPackage []byte) error {
+	hmacKey, err := randomBytes(encryption.KeyData.HashSize)
+	if err != nil {
+		return err
+	}
+	iv, err := createIV(blockKeyHmacKey, *encryption)
+	if err != nil {
+		return err
+	}
+	encryptedHmacKey, err := encrypt(packageKey, iv, hmacKey)
+	if err != nil {
+		return err
+	}
+	h := hmac.New(sha512.New, hmacKey)
+	_, _ = h.Write(encryptedPackage)
+	if iv, err = createIV(blockKeyHmacValue, *encryption); err != nil {
+		return err
+	}
+	encryptedHmacValue, err := encrypt(packageKey, iv, h.Sum(nil))
+	encryption.DataIntegrity = DataIntegrity{
+		EncryptedHmacKey:   base64.StdEncoding.EncodeToString(encryptedHmacKey),
+		EncryptedHmacValue: base64.StdEncoding.EncodeToString(encryptedHmacValue),
+	}
+	return err
+}
+
+// setPasswordKeyEncryptor provides a function to generate the password salt,
+// password verifier and encrypted package key of the password key encryptor.
+func (encryption *Encryption) setPasswordKeyEncryptor(passwd string, packageKey []byte) error {
+	encryptedKey := &encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey
+	saltValue, err := randomBytes(encryptedKey.SaltSize)
+	if err != nil {
+		return err
+	}
+	verifierHashInput, err := randomBytes(encryptedKey.SaltSize)
+	if err != nil {
+		return err
+	}
+	encryptedKey.SaltValue = base64.StdEncoding.EncodeToString(saltValue)
+	for _, v := range []struct {
+		blockKey, input []byte
+		value           *string
+	}{
+		{blockKeyVerifierHashInput, verifierHashInput, &encryptedKey.EncryptedVerifierHashInput},
+		{blockKeyVerifierHashValue, hashing(encryptedKey.HashAlgorithm, verifierHashInput), &encryptedKey.EncryptedVerifierHashValue},
+		{blockKey, packageKey, &encryptedKey.EncryptedKeyValue},
+	} {
+		key, err := convertPasswdToKey(passwd, v.blockKey, *encryption)
+		if err != nil {
+			return err
+		}
+		output, err := encrypt(key, saltValue, v.input)
+		if err != nil {
+			return err
+		}
+		*v.value = base64.StdEncoding.EncodeToString(output)
+	}
+	return err
 }
 
 // convertPasswdToKey convert the password into an encryption key.
@@ -502,6 +571,22 @@ func decrypt(key, iv, input []byte) (packageKey []byte, err error) {
 	return input, nil
 }
 
+// encrypt provides a function to encrypt input by given AES cipher algorithm
+// with CBC chaining mode, key and initialization vector, the input will be
+// padded to an integer multiple of the block size.
+func encrypt(key, iv, input []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if remainder := len(input) % block.BlockSize(); remainder != 0 {
+		input = append(input[:len(input):len(input)], make([]byte, block.BlockSize()-remainder)...)
+	}
+	output := make([]byte, len(input))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(output, input)
+	return output, nil
+}
+
 // decryptPackage decrypt package by given packageKey and encryption
 // info.
 func decryptPackage(packageKey, input []byte, encryption Encryption) (outputChunks []byte, err error) {
@@ -668,14 +753,6 @@ func (c *cfb) writeUint32(value int) {
 	c.writeBytes(buf)
 }
 
-// writeUint64 write an uint64 data type bytes in the stream by a given value
-// with an offset.
-func (c *cfb) writeUint64(value int) {
-	buf := make([]byte, 8)
-	binary.LittleEndian.PutUint64(buf, uint64(value))
-	c.writeBytes(buf)
-}
-
 // writeBytes write strings in the stream by a given value with an offset.
 func (c *cfb) writeStrings(value string) {
 	encoder := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder()
@@ -944,7 +1021,7 @@ func (c *cfb) writeSectorChains(location []int) sector {
 		if sectorSize = len(sector.content); sectorSize == 0 || sectorSize >= 0x1000 {
 			continue
 		}
-		sector.start = offset
+		c.sectors[j].start = offset
 		offset = writeSectorChain((sectorSize+0x3F)>>6, offset)
 	}
 	for c.position&0x1FF != 0 {
@@ -51,13 +51,34 @@ func TestEncrypt(t *testing.T) {
 	cell, err = f.GetCellValue("Sheet1", "A1")
 	assert.NoError(t, err)
 	assert.Equal(t, "SECRET", cell)
+	// Test the spreadsheet was encrypted with agile encryption
+	encrypted, err := os.ReadFile(filepath.Join("test", "Encryption.xlsx"))
+	assert.NoError(t, err)
+	doc, err := mscfb.New(bytes.NewReader(encrypted))
+	assert.NoError(t, err)
+	encryptionInfoBuf, encryptedPackageBuf := extractPart(doc)
+	mechanism, err := encryptionMechanism(encryptionInfoBuf)
+	assert.NoError(t, err)
+	assert.Equal(t, "agile", mechanism)
+	encryptionInfo, err := parseEncryptionInfo(encryptionInfoBuf[8:])
+	assert.NoError(t, err)
+	assert.Equal(t, "SHA512", encryptionInfo.KeyEncryptors.KeyEncryptor[0].EncryptedKey.HashAlgorithm)
+	assert.Equal(t, 256, encryptionInfo.KeyEncryptors.KeyEncryptor[0].EncryptedKey.KeyBits)
+	assert.NotEmpty(t, encryptionInfo.DataIntegrity.EncryptedHmacValue)
+	assert.Contains(t, string(encryptionInfoBuf), `<encryption xmlns="http://schemas.microsoft.com/office/2006/encryption">`)
+	packageBuf, err := agileDecrypt(encryptionInfoBuf, encryptedPackageBuf, &Options{Password: "passwd"})
+	assert.NoError(t, err)
+	assert.Equal(t, binary.LittleEndian.Uint64(encryptedPackageBuf[:8]), uint64(len(packageBuf)))
+	// Test open encrypted spreadsheet with incorrect password
+	_, err = OpenFile(filepath.Join("test", "Encryption.xlsx"), Options{Password: "password"})
+	assert.EqualError(t, err, ErrWorkbookPassword.Error())
 	// Test remove password by save workbook with options
 	assert.NoError(t, f.Save(Options{Password: ""}))
 	assert.NoError(t, f.Close())
 
-	doc, err := mscfb.New(bytes.NewReader(raw))
+	doc, err = mscfb.New(bytes.NewReader(raw))
 	assert.NoError(t, err)
-	encryptionInfoBuf, encryptedPackageBuf := extractPart(doc)
+	encryptionInfoBuf, encryptedPackageBuf = extractPart(doc)
 	binary.LittleEndian.PutUint64(encryptionInfoBuf[20:32], uint64(0))
 	_, err = standardDecrypt(encryptionInfoBuf, encryptedPackageBuf, &Options{Password: "password"})
 	assert.NoError(t, err)
@@ -73,6 +94,17 @@ func TestEncrypt(t *testing.T) {
 	assert.EqualError(t, err, "illegal base64 data at input byte 0")
 	_, err = createIV([]byte{0}, Encryption{KeyData: KeyData{SaltValue: "=="}})
 	assert.EqualError(t, err, "illegal base64 data at input byte 0")
+	_, err = encrypt(nil, nil, nil)
+	assert.EqualError(t, err, "crypto/aes: invalid key size 0")
+	_, err = encryptPackage(nil, []byte{0}, Encryption{KeyData: KeyData{SaltValue: "=="}})
+	assert.EqualError(t, err, "illegal base64 data at input byte 0")
+	_, err = encryptPackage(nil, []byte{0}, Encryption{KeyData: KeyData{BlockSize: 16}})
+	assert.EqualError(t, err, "crypto/aes: invalid key size 0")
+	encryption := Encryption{KeyData: KeyData{SaltValue: "=="}}
+	assert.EqualError(t, encryption.setDataIntegrity(nil, nil), "illegal base64 data at input byte 0")
+	encryption = Encryption{KeyData: KeyData{BlockSize: 16}}
+	assert.EqualError(t, encryption.setDataIntegrity(nil, nil), "crypto/aes: invalid key size 0")
+	assert.Equal(t, []byte{1}, trimPackage([]byte{1}, nil))
 }
 
 func TestEncryptionMechanism(t *testing.T) {
@@ -12,8 +12,12 @@
 package excelize
 
 import (
+	"bytes"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"math"
+	"sort"
 	"strings"
 	"unicode/utf16"
 )
@@ -178,6 +182,15 @@ func (dv *DataValidation) SetRange(f1, f2 interface{}, t DataValidationType, o D
 //	dv.Sqref = "A7:B8"
 //	dv.SetSqrefDropList("$E$1:$E$3")
 //	err := f.AddDataValidation("Sheet1", dv)
+//
+// The source reference range could be on another worksheet, for example, set
+// data validation on Sheet1!A9:B10 with validation criteria source
+// Sheet2!A1:A10 settings:
+//
+//	dv := excelize.NewDataValidation(true)
+//	dv.Sqref = "A9:B10"
+//	dv.SetSqrefDropList("Sheet2!$A$1:$A$10")
+//	err := f.AddDataValidation("Sheet1", dv)
 func (dv *DataValidation) SetSqrefDropList(sqref string) {
 	dv.Formula1 = fmt.Sprintf("<formula1>%s</formula1>", sqref)
 	dv.Type = convDataValidationType(typeList)
@@ -254,11 +267,25 @@ func convDataValidationOperator(o DataValidationOperator) string {
 //	dv.Sqref = "A5:B6"
 //	dv.SetDropList([]string{"1", "2", "3"})
 //	err = f.AddDataValidation("Sheet1", dv)
+//
+// The list data validation which source references cells on another worksheet
+// will be stored in the worksheet extension list automatically.
 func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
+	if _, _, ok := dv.crossSheetFormulas(); ok {
+		dataValidations, err := f.getExtDataValidations(ws)
+		if err != nil {
+			return err
+		}
+		if err = f.setExtDataValidations(ws, append(dataValidations, dv)); err != nil {
+			return err
+		}
+		f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX14)
+		return err
+	}
 	if nil == ws.DataValidations {
 		ws.DataValidations = new(xlsxDataValidations)
 	}
@@ -269,14 +296,19 @@ func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 
 // GetDataValidations returns data validations list by given worksheet name.
 func (f *File) GetDataValidations(sheet string) ([]*DataValidation, error) {
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		return nil, err
 	}
-	if ws.DataValidations == nil || len(ws.DataValidations.DataValidation) == 0 {
+	extDataValidations, err := f.getExtDataValidations(ws)
+	if err != nil {
 		return nil, err
 	}
-	return ws.DataValidations.DataValidation, err
+	var dataValidations []*DataValidation
+	if ws.DataValidations != nil {
+		dataValidations = append(dataValidations, ws.DataValidations.DataValidation...)
+	}
+	return append(dataValidations, extDataValidations...), err
 }
 
 // DeleteDataValidation delete data validation by given worksheet name and
@@ -287,23 +319,45 @@ func (f *File) DeleteDataValidation(sheet string, sqref ...string) error {
 	if err != nil {
 		return err
 	}
-	if ws.DataValidations == nil {
+	extDataValidations, err := f.getExtDataValidations(ws)
+	if err != nil {
+		return err
+	}
+	if ws.DataValidations == nil && len(extDataValidations) == 0 {
 		return nil
 	}
 	if sqref == nil {
 		ws.DataValidations = nil
-		return nil
+		return f.setExtDataValidations(ws, nil)
 	}
 	delCells, err := f.flatSqref(sqref[0])
 	if err != nil {
 		return err
 	}
-	dv := ws.DataValidations
-	for i := 0; i < len(dv.DataValidation); i++ {
+	if dv := ws.DataValidations; dv != nil {
+		if dv.DataValidation, err = f.deleteDataValidationCells(dv.DataValidation, delCells); err != nil {
+			return err
+		}
+		dv.Count = len(dv.DataValidation)
+		if dv.Count == 0 {
+			ws.DataValidations = nil
+		}
+	}
+	if extDataValidations, err = f.deleteDataValidationCells(extDataValidations, delCells); err != nil {
+		return err
+	}
+	return f.setExtDataValidations(ws, extDataValidations)
+}
+
+// deleteDataValidationCells removes the given cells from the reference
+// sequence of the data validations, and returns the data validations which
+// still applied to some cells.
+func (f *File) deleteDataValidationCells(dataValidations []*DataValidation, delCells map[int][][]int) ([]*DataValidation, error) {
+	for i := 0; i < len(dataValidations); i++ {
 		var applySqref []string
-		colCells, err := f.flatSqref(dv.DataValidation[i].Sqref)
+		colCells, err := f.flatSqref(dataValidations[i].Sqref)
 		if err != nil {
-			return err
+			return dataValidations, err
 		}
 		for col, cells := range delCells {
 			for _, cell := range cells {
@@ -313,20 +367,157 @@ func (f *File) DeleteDataValidation(sheet string, sqref ...string) error {
 				}
 			}
 		}
-		for _, col := range colCells {
-			applySqref = append(applySqref, f.squashSqref(col)...)
+		cols := make([]int, 0, len(colCells))
+		for col := range colCells {
+			cols = append(cols, col)
+		}
+		sort.Ints(cols)
+		for _, col := range cols {
+			applySqref = append(applySqref, f.squashSqref(colCells[col])...)
 		}
-		dv.DataValidation[i].Sqref = strings.Join(applySqref, " ")
+		dataValidations[i].Sqref = strings.Join(applySqref, " ")
 		if len(applySqref) == 0 {
-			dv.DataValidation = append(dv.DataValidation[:i], dv.DataValidation[i+1:]...)
+			dataValidations = append(dataValidations[:i], dataValidations[i+1:]...)
 			i--
 		}
 	}
-	dv.Count = len(dv.DataValidation)
-	if dv.Count == 0 {
-		ws.DataValidations = nil
+	return dataValidations, nil
+}
+
+// crossSheetFormulas returns the unescaped formulas of the list data
+// validation, and whether the source of the list references cells on other
+// worksheets. Excel requires these data validations be stored in the worksheet
+// extension list.
+func (dv *DataValidation) crossSheetFormulas() (string, string, bool) {
+	if dv.Type != convDataValidationType(typeList) {
+		return "", "", false
 	}
-	return nil
+	var formulas struct {
+		Formula1 string `xml:"formula1"`
+		Formula2 string `xml:"formula2"`
+	}
+	if err := xml.Unmarshal([]byte("<dataValidation>"+dv.Formula1+dv.Formula2+"</dataValidation>"), &formulas); err != nil {
+		return "", "", false
+	}
+	for i, part := range strings.Split(formulas.Formula1, "\"") {
+		if i%2 == 0 && strings.Contains(part, "!") {
+			return formulas.Formula1, formulas.Formula2, true
+		}
+	}
+	return "", "", false
+}
+
+// getExtDataValidations provides a function to get the data validations in the
+// worksheet extension list.
+func (f *File) getExtDataValidations(ws *xlsxWorksheet) ([]*DataValidation, error) {
+	var (
+		dataValidations []*DataValidation
+		decodeExtLst    = new(decodeExtLst)
+	)
+	if ws.ExtLst == nil {
+		return dataValidations, nil
+	}
+	if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return dataValidations, err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURIDataValidations {
+			continue
+		}
+		decodeDataValidations := new(decodeX14DataValidations)
+		if err := f.xmlNewDecoder(strings.NewReader(ext.Content)).
+			Decode(decodeDataValidations); err != nil && err != io.EOF {
+			return dataValidations, err
+		}
+		for _, v := range decodeDataValidations.DataValidation {
+			dv := &DataValidation{
+				AllowBlank: v.AllowBlank, Error: v.Error, ErrorStyle: v.ErrorStyle,
+				ErrorTitle: v.ErrorTitle, Operator: v.Operator, Prompt: v.Prompt,
+				PromptTitle: v.PromptTitle, ShowDropDown: v.ShowDropDown,
+				ShowErrorMessage: v.ShowErrorMessage, ShowInputMessage: v.ShowInputMessage,
+				Sqref: v.Sqref, Type: v.Type,
+			}
+			var buf bytes.Buffer
+			if v.Formula1 != nil {
+				_ = xml.EscapeText(&buf, []byte(v.Formula1.F))
+				dv.Formula1 = fmt.Sprintf("<formula1>%s</formula1>", buf.String())
+			}
+			if v.Formula2 != nil {
+				buf.Reset()
+				_ = xml.EscapeText(&buf, []byte(v.Formula2.F))
+				dv.Formula2 = fmt.Sprintf("<formula2>%s</formula2>", buf.String())
+			}
+			dataValidations = append(dataValidations, dv)
+		}
+	}
+	return dataValidations, nil
+}
+
+// setExtDataValidations provides a function to replace the data validations in
+// the worksheet extension list by given data validations.
+func (f *File) setExtDataValidations(ws *xlsxWorksheet, dataValidations []*DataValidation) error {
+	var (
+		decodeExtLst = new(decodeExtLst)
+		exts         []*xlsxExt
+		found        bool
+	)
+	if ws.ExtLst != nil {
+		if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI == ExtURIDataValidations {
+			found = true
+			continue
+		}
+		exts = append(exts, ext)
+	}
+	if !found && len(dataValidations) == 0 {
+		return nil
+	}
+	if len(dataValidations) > 0 {
+		x14DataValidations := &xlsxX14DataValidations{
+			XMLNSXM: NameSpaceSpreadSheetExcel2006Main.Value,
+			Count:   len(dataValidations),
+		}
+		for _, dv := range dataValidations {
+			formula1, formula2, _ := dv.crossSheetFormulas()
+			x14DataValidation := &xlsxX14DataValidation{
+				AllowBlank: dv.AllowBlank, Error: dv.Error, ErrorStyle: dv.ErrorStyle,
+				ErrorTitle: dv.ErrorTitle, Operator: dv.Operator, Prompt: dv.Prompt,
+				PromptTitle: dv.PromptTitle, ShowDropDown: dv.ShowDropDown,
+				ShowErrorMessage: dv.ShowErrorMessage, ShowInputMessage: dv.ShowInputMessage,
+				Sqref: dv.Sqref, Type: dv.Type,
+			}
+			if formula1 != "" {
+				x14DataValidation.Formula1 = &xlsxX14Formula{F: formula1}
+			}
+			if formula2 != "" {
+				x14DataValidation.Formula2 = &xlsxX14Formula{F: formula2}
+			}
+			x14DataValidations.DataValidation = append(x14DataValidations.DataValidation, x14DataValidation)
+		}
+		dataValidationsBytes, _ := xml.Marshal(x14DataValidations)
+		exts = append(exts, &xlsxExt{
+			xmlns: []xml.Attr{{Name: xml.Name{Local: "xmlns:" + NameSpaceSpreadSheetX14.Name.Local}, Value: NameSpaceSpreadSheetX14.Value}},
+			URI:   ExtURIDataValidations, Content: string(dataValidationsBytes),
+		})
+	}
+	if len(exts) == 0 {
+		ws.ExtLst = nil
+		return nil
+	}
+	sort.Slice(exts, func(i, j int) bool {
+		return inStrSlice(worksheetExtURIPriority, exts[i].URI, false) <
+			inStrSlice(worksheetExtURIPriority, exts[j].URI, false)
+	})
+	decodeExtLst.Ext = exts
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
 }
 
 // squashSqref generates cell reference sequence by given cells coordinates list.
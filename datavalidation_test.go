@@ -169,6 +169,87 @@ func TestDataValidationError(t *testing.T) {
 	assert.EqualError(t, f.AddDataValidation("Sheet:1", nil), ErrSheetNameInvalid.Error())
 }
 
+func TestCrossSheetDataValidation(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetCol("Sheet2", "A1", &[]interface{}{"A", "B", "C"}))
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:A3"
+	dv.SetSqrefDropList("Sheet2!$A$1:$A$3")
+	dv.SetError(DataValidationErrorStyleStop, "error title", "error body")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	// Test the list source with exclamation mark in string literal should not
+	// be stored in the extension list
+	dv = NewDataValidation(true)
+	dv.Sqref = "B1"
+	assert.NoError(t, dv.SetDropList([]string{"Yes!", "No!"}))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	dv = NewDataValidation(false)
+	dv.Sqref = "C1:C2 D1"
+	dv.SetSqrefDropList("'Sheet 3'!$A$1:$A$3")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.DataValidations.DataValidation, 1)
+	assert.Equal(t, `<ext xmlns:x14="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main" uri="{CCE6A557-97BC-4B89-ADB6-D9C93CAAB3DF}"><x14:dataValidations xmlns:xm="http://schemas.microsoft.com/office/excel/2006/main" count="2"><x14:dataValidation allowBlank="true" error="error body" errorStyle="stop" errorTitle="error title" showErrorMessage="true" type="list"><x14:formula1><xm:f>Sheet2!$A$1:$A$3</xm:f></x14:formula1><xm:sqref>A1:A3</xm:sqref></x14:dataValidation><x14:dataValidation allowBlank="false" type="list"><x14:formula1><xm:f>&#39;Sheet 3&#39;!$A$1:$A$3</xm:f></x14:formula1><xm:sqref>C1:C2 D1</xm:sqref></x14:dataValidation></x14:dataValidations></ext>`, ws.ExtLst.Ext)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCrossSheetDataValidation.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestCrossSheetDataValidation.xlsx"))
+	assert.NoError(t, err)
+	dataValidations, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dataValidations, 3)
+	assert.Equal(t, "A1:A3", dataValidations[1].Sqref)
+	assert.Equal(t, "list", dataValidations[1].Type)
+	assert.Equal(t, "<formula1>Sheet2!$A$1:$A$3</formula1>", dataValidations[1].Formula1)
+	assert.Equal(t, "error body", *dataValidations[1].Error)
+	assert.Equal(t, "<formula1>&#39;Sheet 3&#39;!$A$1:$A$3</formula1>", dataValidations[2].Formula1)
+	// Test insert rows and delete data validations in the extension list
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 1))
+	assert.NoError(t, f.DeleteDataValidation("Sheet1", "A2:A3"))
+	dataValidations, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dataValidations, 3)
+	assert.Equal(t, []string{"B2", "A4", "C2:C3 D2"}, []string{dataValidations[0].Sqref, dataValidations[1].Sqref, dataValidations[2].Sqref})
+	assert.NoError(t, f.DeleteDataValidation("Sheet1", "A4:D4"))
+	dataValidations, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dataValidations, 2)
+	assert.NoError(t, f.DeleteDataValidation("Sheet1", "C2:D3"))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.ExtLst)
+	assert.NotNil(t, ws.DataValidations)
+	// Test delete all data validations should keep other extensions
+	ws.ExtLst = &xlsxExtLst{Ext: `<ext uri="` + ExtURISparklineGroups + `"><x14:sparklineGroups/></ext>`}
+	dv = NewDataValidation(true)
+	dv.Sqref = "A1"
+	dv.SetSqrefDropList("Sheet2!$A$1:$A$3")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	assert.True(t, strings.HasPrefix(ws.ExtLst.Ext, `<ext xmlns:x14="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main" uri="`+ExtURIDataValidations+`">`))
+	assert.NoError(t, f.DeleteDataValidation("Sheet1"))
+	assert.Nil(t, ws.DataValidations)
+	assert.Equal(t, `<ext uri="`+ExtURISparklineGroups+`"><x14:sparklineGroups/></ext>`, ws.ExtLst.Ext)
+	// Test delete data validation with invalid reference in the extension list
+	ws.ExtLst = &xlsxExtLst{Ext: `<ext uri="` + ExtURIDataValidations + `"><x14:dataValidations count="1"><x14:dataValidation type="list"><xm:sqref>A</xm:sqref></x14:dataValidation></x14:dataValidations></ext>`}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.DeleteDataValidation("Sheet1", "A1"))
+	// Test add, get and delete data validations with invalid extension list
+	for _, extLst := range []string{
+		`<ext uri="` + ExtURIDataValidations + `"><x14:dataValidations count="x"></x14:dataValidations></ext>`,
+		`<ext><x14:dataValidations></ext>`,
+	} {
+		ws.ExtLst = &xlsxExtLst{Ext: extLst}
+		_, err = f.GetDataValidations("Sheet1")
+		assert.Error(t, err)
+		assert.Error(t, f.AddDataValidation("Sheet1", dv))
+		assert.Error(t, f.DeleteDataValidation("Sheet1"))
+	}
+	assert.Error(t, f.setExtDataValidations(ws, nil))
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteDataValidation(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.DeleteDataValidation("Sheet1", "A1:B2"))
@@ -196,6 +277,20 @@ func TestDeleteDataValidation(t *testing.T) {
 	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
 	assert.NoError(t, f.DeleteDataValidation("Sheet1", "D3"))
 
+	// Test delete data validation keeps the column order of the reference sequence
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		dv.Sqref = "E1:J2"
+		assert.NoError(t, f.AddDataValidation("Sheet2", dv))
+		assert.NoError(t, f.DeleteDataValidation("Sheet2", "E1"))
+		dvs, err := f.GetDataValidations("Sheet2")
+		assert.NoError(t, err)
+		assert.Len(t, dvs, 1)
+		assert.Equal(t, "E2 F1:F2 G1:G2 H1:H2 I1:I2 J1:J2", dvs[0].Sqref)
+		assert.NoError(t, f.DeleteDataValidation("Sheet2"))
+	}
+
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteDataValidation.xlsx")))
 
 	dv.Sqref = "A"
@@ -21,6 +21,7 @@ const (
 	dayNanoseconds = 24 * time.Hour
 	maxDuration    = 290 * 364 * dayNanoseconds
 	roundEpsilon   = 1e-9
+	date1904Offset = 1462
 )
 
 var (
@@ -171,6 +172,16 @@ func ExcelDateToTime(excelDate float64, use1904Format bool) (time.Time, error) {
 	return timeFromExcelTime(excelDate, use1904Format), nil
 }
 
+// TimeToExcelDate converts a time.Time to a float-based Excel date
+// representation in the 1900 or 1904 date system. The time zone offset of
+// the value will be added into the result, so the Excel date represents the
+// local time of the value. It returns 0 if the value is before the epoch of
+// the date system.
+func TimeToExcelDate(t time.Time, use1904Format bool) (float64, error) {
+	_, offset := t.In(t.Location()).Zone()
+	return timeToExcelTime(t.Add(time.Duration(offset)*time.Second), use1904Format)
+}
+
 // isLeapYear determine if leap year for a given year.
 func isLeapYear(y int) bool {
 	if y == y/400*400 {
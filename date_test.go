@@ -114,3 +114,29 @@ func TestExcelDateToTime(t *testing.T) {
 	_, err := ExcelDateToTime(-1, false)
 	assert.EqualError(t, err, newInvalidExcelDateError(-1).Error())
 }
+
+func TestTimeToExcelDate(t *testing.T) {
+	for i, test := range trueExpectedDateList {
+		t.Run(fmt.Sprintf("TestData%d", i+1), func(t *testing.T) {
+			excelDate, err := TimeToExcelDate(test.GoValue, false)
+			assert.NoError(t, err)
+			assert.Equal(t, test.ExcelValue, excelDate)
+		})
+	}
+	// Check round trip in the 1904 date system
+	date := time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+	excelDate, err := TimeToExcelDate(date, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 43616.5, excelDate)
+	timeValue, err := ExcelDateToTime(excelDate, true)
+	assert.NoError(t, err)
+	assert.Equal(t, date, timeValue)
+	// Check the local time of the value was used
+	excelDate, err = TimeToExcelDate(time.Date(2023, time.June, 1, 12, 0, 0, 0, time.FixedZone("UTC+6", 6*60*60)), false)
+	assert.NoError(t, err)
+	assert.Equal(t, 45078.5, excelDate)
+	// Check the time before the epoch
+	excelDate, err = TimeToExcelDate(time.Date(1903, time.December, 31, 0, 0, 0, 0, time.UTC), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, excelDate)
+}
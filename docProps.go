@@ -16,6 +16,9 @@ import (
 	"encoding/xml"
 	"io"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // SetAppProps provides a function to set document application properties. The
@@ -39,6 +42,8 @@ import (
 //	                   |
 //	 Company           | The name of a company associated with the document.
 //	                   |
+//	 Manager           | The name of the supervisor associated with the document.
+//	                   |
 //	 LinksUpToDate     | Indicates whether hyperlinks in a document are up-to-date. Set this
 //	                   | element to 'true' to indicate that hyperlinks are updated. Set this
 //	                   | element to 'false' to indicate that hyperlinks are outdated.
@@ -60,6 +65,7 @@ import (
 //	    ScaleCrop:         true,
 //	    DocSecurity:       3,
 //	    Company:           "Company Name",
+//	    Manager:           "Manager Name",
 //	    LinksUpToDate:     true,
 //	    HyperlinksChanged: true,
 //	    AppVersion:        "16.0000",
@@ -78,7 +84,7 @@ func (f *File) SetAppProps(appProperties *AppProperties) error {
 		Decode(app); err != nil && err != io.EOF {
 		return err
 	}
-	fields = []string{"Application", "ScaleCrop", "DocSecurity", "Company", "LinksUpToDate", "HyperlinksChanged", "AppVersion"}
+	fields = []string{"Application", "ScaleCrop", "DocSecurity", "Company", "Manager", "LinksUpToDate", "HyperlinksChanged", "AppVersion"}
 	immutable, mutable = reflect.ValueOf(*appProperties), reflect.ValueOf(app).Elem()
 	for _, field = range fields {
 		immutableField := immutable.FieldByName(field)
@@ -109,6 +115,7 @@ func (f *File) GetAppProps() (ret *AppProperties, err error) {
 		ScaleCrop:         app.ScaleCrop,
 		DocSecurity:       app.DocSecurity,
 		Company:           app.Company,
+		Manager:           app.Manager,
 		LinksUpToDate:     app.LinksUpToDate,
 		HyperlinksChanged: app.HyperlinksChanged,
 		AppVersion:        app.AppVersion,
@@ -180,22 +187,35 @@ func (f *File) GetAppProps() (ret *AppProperties, err error) {
 //	    Version:        "1.0.0",
 //	})
 func (f *File) SetDocProps(docProperties *DocProperties) error {
-	var (
-		core               *decodeCoreProperties
-		err                error
-		field, val         string
-		fields             []string
-		immutable, mutable reflect.Value
-		newProps           *xlsxCoreProperties
-		output             []byte
-	)
+	return f.updateDocProps(func(newProps *xlsxCoreProperties) {
+		fields := []string{
+			"Category", "ContentStatus", "Creator", "Description", "Identifier", "Keywords",
+			"LastModifiedBy", "Revision", "Subject", "Title", "Language", "Version",
+		}
+		immutable, mutable := reflect.ValueOf(*docProperties), reflect.ValueOf(newProps).Elem()
+		for _, field := range fields {
+			if val := immutable.FieldByName(field).String(); val != "" {
+				mutable.FieldByName(field).SetString(val)
+			}
+		}
+		if docProperties.Created != "" {
+			newProps.Created = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Created}
+		}
+		if docProperties.Modified != "" {
+			newProps.Modified = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Modified}
+		}
+	})
+}
 
-	core = new(decodeCoreProperties)
-	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsCore)))).
+// updateDocProps provides a function to update the document core properties
+// by given update function.
+func (f *File) updateDocProps(fn func(newProps *xlsxCoreProperties)) error {
+	core := new(decodeCoreProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsCore)))).
 		Decode(core); err != nil && err != io.EOF {
 		return err
 	}
-	newProps = &xlsxCoreProperties{
+	newProps := &xlsxCoreProperties{
 		Dc:             NameSpaceDublinCore,
 		Dcterms:        NameSpaceDublinCoreTerms,
 		Dcmitype:       NameSpaceDublinCoreMetadataInitiative,
@@ -219,25 +239,35 @@ func (f *File) SetDocProps(docProperties *DocProperties) error {
 	if core.Modified != nil {
 		newProps.Modified = &xlsxDcTerms{Type: core.Modified.Type, Text: core.Modified.Text}
 	}
-	fields = []string{
-		"Category", "ContentStatus", "Creator", "Description", "Identifier", "Keywords",
-		"LastModifiedBy", "Revision", "Subject", "Title", "Language", "Version",
-	}
-	immutable, mutable = reflect.ValueOf(*docProperties), reflect.ValueOf(newProps).Elem()
-	for _, field = range fields {
-		if val = immutable.FieldByName(field).String(); val != "" {
-			mutable.FieldByName(field).SetString(val)
+	fn(newProps)
+	output, err := xml.Marshal(newProps)
+	f.saveFileList(defaultXMLPathDocPropsCore, output)
+	return err
+}
+
+// removeDocPropsPersonalInfo provides a function to remove the author and the
+// organization of the document in the document core properties and
+// application properties.
+func (f *File) removeDocPropsPersonalInfo() error {
+	if len(f.readXML(defaultXMLPathDocPropsCore)) > 0 {
+		if err := f.updateDocProps(func(newProps *xlsxCoreProperties) {
+			newProps.Creator, newProps.LastModifiedBy = "", ""
+		}); err != nil {
+			return err
 		}
 	}
-	if docProperties.Created != "" {
-		newProps.Created = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Created}
+	if len(f.readXML(defaultXMLPathDocPropsApp)) == 0 {
+		return nil
 	}
-	if docProperties.Modified != "" {
-		newProps.Modified = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Modified}
+	app := new(xlsxProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsApp)))).
+		Decode(app); err != nil && err != io.EOF {
+		return err
 	}
-	output, err = xml.Marshal(newProps)
-	f.saveFileList(defaultXMLPathDocPropsCore, output)
-
+	app.Company, app.Manager = "", ""
+	app.Vt = NameSpaceDocumentPropertiesVariantTypes.Value
+	output, err := xml.Marshal(app)
+	f.saveFileList(defaultXMLPathDocPropsApp, output)
 	return err
 }
 
@@ -271,3 +301,136 @@ func (f *File) GetDocProps() (ret *DocProperties, err error) {
 	}
 	return
 }
+
+// SetCustomProps provides a function to set custom file properties by given
+// property name and value. If the property name already exists, it will be
+// updated, otherwise a new property will be added. The value can be of type
+// int32, float64, bool, string, time.Time or nil. The property will be
+// deleted if the value is nil. The function returns an error if the property
+// value is not of the correct type. For example, set a custom property named
+// "Approved" with a boolean value:
+//
+//	err := f.SetCustomProps(excelize.CustomProperty{
+//	    Name:  "Approved",
+//	    Value: true,
+//	})
+func (f *File) SetCustomProps(prop CustomProperty) error {
+	if prop.Name == "" {
+		return ErrParameterInvalid
+	}
+	value, err := customPropertyValue(prop.Value)
+	if err != nil {
+		return err
+	}
+	customPropsXML := f.getCustomPropsPath()
+	props := new(decodeCustomProperties)
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(customPropsXML)))).
+		Decode(props); err != nil && err != io.EOF {
+		return err
+	}
+	newProps, pid, found := &xlsxCustomProperties{Vt: NameSpaceDocumentPropertiesVariantTypes.Value}, 1, false
+	for _, p := range props.Property {
+		if p.PID > pid {
+			pid = p.PID
+		}
+		property := xlsxCustomProperty{FmtID: p.FmtID, PID: p.PID, Name: p.Name, LinkTarget: p.LinkTarget, Value: p.Value}
+		if p.Name == prop.Name {
+			found = true
+			if prop.Value == nil {
+				continue
+			}
+			property.Value = value
+		}
+		newProps.Property = append(newProps.Property, property)
+	}
+	if !found && prop.Value != nil {
+		newProps.Property = append(newProps.Property, xlsxCustomProperty{
+			FmtID: "{D5CDD505-2E9C-101B-9397-08002B2CF9AE}", PID: pid + 1, Name: prop.Name, Value: value,
+		})
+	}
+	if customPropsXML == "" {
+		customPropsXML = defaultXMLPathDocPropsCustom
+		f.addRels("_rels/.rels", SourceRelationshipCustomProperties, customPropsXML, "")
+		if err = f.setContentTypes("/"+customPropsXML, ContentTypeCustomProperties); err != nil {
+			return err
+		}
+	}
+	output, err := xml.Marshal(newProps)
+	f.saveFileList(customPropsXML, output)
+	return err
+}
+
+// GetCustomProps provides a function to get custom file properties. The value
+// of the property with an unsupported data type will be nil.
+func (f *File) GetCustomProps() ([]CustomProperty, error) {
+	var customProps []CustomProperty
+	props := new(decodeCustomProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(f.getCustomPropsPath())))).
+		Decode(props); err != nil && err != io.EOF {
+		return customProps, err
+	}
+	for _, p := range props.Property {
+		prop := CustomProperty{Name: p.Name}
+		switch {
+		case p.I4 != nil:
+			prop.Value = *p.I4
+		case p.Int != nil:
+			prop.Value = *p.Int
+		case p.R8 != nil:
+			prop.Value = *p.R8
+		case p.Lpwstr != nil:
+			prop.Value = *p.Lpwstr
+		case p.Lpstr != nil:
+			prop.Value = *p.Lpstr
+		case p.Bool != nil:
+			prop.Value = *p.Bool
+		case p.FileTime != nil:
+			prop.Value = *p.FileTime
+		}
+		customProps = append(customProps, prop)
+	}
+	return customProps, nil
+}
+
+// getCustomPropsPath provides a function to get the path of the custom file
+// properties part in the spreadsheet.
+func (f *File) getCustomPropsPath() (path string) {
+	if rels, _ := f.relsViewer("_rels/.rels"); rels != nil {
+		rels.mu.Lock()
+		defer rels.mu.Unlock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipCustomProperties {
+				path = strings.TrimPrefix(rel.Target, "/")
+				return
+			}
+		}
+	}
+	return
+}
+
+// customPropertyValue provides a function to convert the value of the custom
+// property to the variant type element.
+func customPropertyValue(value interface{}) (string, error) {
+	var (
+		buf bytes.Buffer
+		err error
+	)
+	switch v := value.(type) {
+	case int32:
+		buf.WriteString("<vt:i4>" + strconv.FormatInt(int64(v), 10) + "</vt:i4>")
+	case float64:
+		buf.WriteString("<vt:r8>" + strconv.FormatFloat(v, 'f', -1, 64) + "</vt:r8>")
+	case string:
+		buf.WriteString("<vt:lpwstr>")
+		err = xml.EscapeText(&buf, []byte(v))
+		buf.WriteString("</vt:lpwstr>")
+	case bool:
+		buf.WriteString("<vt:bool>" + strconv.FormatBool(v) + "</vt:bool>")
+	case time.Time:
+		buf.WriteString("<vt:filetime>" + v.UTC().Format(time.RFC3339) + "</vt:filetime>")
+	case nil:
+	default:
+		err = ErrParameterInvalid
+	}
+	return buf.String(), err
+}
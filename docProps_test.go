@@ -14,6 +14,7 @@ package excelize
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -30,10 +31,14 @@ func TestSetAppProps(t *testing.T) {
 		ScaleCrop:         true,
 		DocSecurity:       3,
 		Company:           "Company Name",
+		Manager:           "Manager Name",
 		LinksUpToDate:     true,
 		HyperlinksChanged: true,
 		AppVersion:        "16.0000",
 	}))
+	props, err := f.GetAppProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "Manager Name", props.Manager)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetAppProps.xlsx")))
 	f.Pkg.Store(defaultXMLPathDocPropsApp, nil)
 	assert.NoError(t, f.SetAppProps(&AppProperties{}))
@@ -116,3 +121,60 @@ func TestGetDocProps(t *testing.T) {
 	_, err = f.GetDocProps()
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestCustomProps(t *testing.T) {
+	f := NewFile()
+	props, err := f.GetCustomProps()
+	assert.NoError(t, err)
+	assert.Empty(t, props)
+	dateTime := time.Date(2023, 6, 1, 8, 30, 0, 0, time.UTC)
+	expected := []CustomProperty{
+		{Name: "Text", Value: "<Excelize>"},
+		{Name: "Number", Value: int32(100)},
+		{Name: "Float", Value: 3.14},
+		{Name: "Approved", Value: true},
+		{Name: "Date", Value: dateTime},
+	}
+	for _, prop := range expected {
+		assert.NoError(t, f.SetCustomProps(prop))
+	}
+	// Test update and delete custom property
+	assert.NoError(t, f.SetCustomProps(CustomProperty{Name: "Approved", Value: false}))
+	assert.NoError(t, f.SetCustomProps(CustomProperty{Name: "Float", Value: nil}))
+	expected = append(expected[:2], CustomProperty{Name: "Approved", Value: false}, expected[4])
+	props, err = f.GetCustomProps()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, props)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCustomProps.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestCustomProps.xlsx"))
+	assert.NoError(t, err)
+	props, err = f.GetCustomProps()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, props)
+	// Test keep the value of the unsupported data type
+	f.Pkg.Store(defaultXMLPathDocPropsCustom, []byte(`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/custom-properties" xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"><property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="2" name="Currency"><vt:cy>1.5</vt:cy></property><property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="3" name="Count"><vt:int>1</vt:int></property></Properties>`))
+	assert.NoError(t, f.SetCustomProps(CustomProperty{Name: "Text", Value: "Excelize"}))
+	props, err = f.GetCustomProps()
+	assert.NoError(t, err)
+	assert.Equal(t, []CustomProperty{{Name: "Currency"}, {Name: "Count", Value: int32(1)}, {Name: "Text", Value: "Excelize"}}, props)
+	assert.Contains(t, string(f.readXML(defaultXMLPathDocPropsCustom)), `<property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="2" name="Currency"><vt:cy>1.5</vt:cy></property>`)
+	assert.Contains(t, string(f.readXML(defaultXMLPathDocPropsCustom)), `pid="4" name="Text"`)
+	// Test set custom property with invalid name and value
+	assert.Equal(t, ErrParameterInvalid, f.SetCustomProps(CustomProperty{}))
+	assert.Equal(t, ErrParameterInvalid, f.SetCustomProps(CustomProperty{Name: "Text", Value: 1}))
+	// Test set and get custom properties with unsupported charset
+	f.Pkg.Store(defaultXMLPathDocPropsCustom, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCustomProps(CustomProperty{Name: "Text", Value: "Excelize"}), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetCustomProps()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test set custom properties with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCustomProps(CustomProperty{Name: "Text", Value: "Excelize"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
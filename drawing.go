@@ -179,6 +179,8 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 		WireframeContour:            f.drawSurfaceChart,
 		Bubble:                      f.drawBubbleChart,
 		Bubble3D:                    f.drawBubbleChart,
+		StockHighLowClose:           f.drawStockChart,
+		StockOpenHighLowClose:       f.drawStockChart,
 	}
 	if opts.Legend.Position == "none" {
 		xlsxChartSpace.Chart.Legend = nil
@@ -197,14 +199,169 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 	order := len(opts.Series)
 	for idx := range comboCharts {
 		comboCharts[idx].order = order
-		addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[comboCharts[idx].Type](comboCharts[idx]))
+		plotArea, combo := xlsxChartSpace.Chart.PlotArea, plotAreaFunc[comboCharts[idx].Type](comboCharts[idx])
+		catAx, valAx := plotArea.CatAx, plotArea.ValAx
+		addChart(plotArea, combo)
+		// Keep the primary axes of the first chart and append the secondary
+		// axes of the combo chart, so that each axis is scaled independently
+		if len(catAx) > 0 && len(valAx) > 0 {
+			plotArea.CatAx, plotArea.ValAx = catAx, valAx
+			if comboCharts[idx].YAxis.Secondary && len(valAx) == 1 && len(combo.CatAx) > 1 && len(combo.ValAx) > 1 {
+				plotArea.CatAx = append(plotArea.CatAx, combo.CatAx[1:]...)
+				plotArea.ValAx = append(plotArea.ValAx, combo.ValAx[1:]...)
+			}
+		}
 		order += len(comboCharts[idx].Series)
 	}
+	if color := opts.PlotArea.Fill.Color; len(color) == 1 {
+		xlsxChartSpace.Chart.PlotArea.SpPr = &cSpPr{SolidFill: f.drawChartSolidFill(color[0])}
+	}
 	chart, _ := xml.Marshal(xlsxChartSpace)
 	media := "xl/charts/chart" + strconv.Itoa(count+1) + ".xml"
 	f.saveFileList(media, chart)
 }
 
+// addChartEx provides a function to create chart as xl/charts/chartEx%d.xml
+// by given worksheet name, cell reference and format sets. The chartex part is
+// used for the chart types introduced in Excel 2016, such as the funnel chart.
+func (f *File) addChartEx(ws *xlsxWorksheet, sheet, cell string, opts *Chart) error {
+	drawingID := f.countDrawings() + 1
+	chartID := f.countPartIndex("xl/charts/chartEx") + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
+	drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
+	drawingRID := f.addRels(drawingRels, SourceRelationshipChartEx, "../charts/chartEx"+strconv.Itoa(chartID)+".xml", "")
+	if err := f.addDrawingChartEx(sheet, drawingXML, cell, drawingRID, opts); err != nil {
+		return err
+	}
+	var title string
+	for _, run := range opts.Title {
+		title += run.Text
+	}
+	series := opts.Series[0]
+	chartSpace := xlsxChartExSpace{
+		XMLNSa: NameSpaceDrawingML.Value,
+		XMLNSr: SourceRelationship.Value,
+		ChartData: cxChartData{Data: []cxData{{
+			NumDim: &cxDim{Type: "val", F: series.Values},
+		}}},
+		Chart: cxChart{
+			PlotArea: cxPlotArea{
+				PlotAreaRegion: cxPlotAreaRegion{Series: []cxSeries{{
+					LayoutID: "funnel",
+					DataLabels: &cxDataLabels{
+						Pos: "inEnd",
+						Visibility: cxVisibility{
+							SeriesName:   opts.PlotArea.ShowSerName,
+							CategoryName: opts.PlotArea.ShowCatName,
+							Value:        true,
+						},
+					},
+					DataID: attrValInt{Val: intPtr(0)},
+				}}},
+				Axis: []cxAxis{{
+					ID:         0,
+					CatScaling: &cxCatScaling{GapWidth: 0.06},
+					TickLabels: stringPtr(""),
+				}},
+			},
+		},
+	}
+	if series.Categories != "" {
+		chartSpace.ChartData.Data[0].StrDim = &cxDim{Type: "cat", F: series.Categories}
+	}
+	if series.Name != "" {
+		chartSpace.Chart.PlotArea.PlotAreaRegion.Series[0].Tx = &cxTx{TxData: cxTxData{F: series.Name}}
+	}
+	if title != "" {
+		chartSpace.Chart.Title = &cxTitle{Pos: "t", Align: "ctr", Tx: cxTx{TxData: cxTxData{V: title}}}
+	}
+	chart, _ := xml.Marshal(chartSpace)
+	f.saveFileList("xl/charts/chartEx"+strconv.Itoa(chartID)+".xml", chart)
+	if err := f.addContentTypePart(chartID, "chartEx"); err != nil {
+		return err
+	}
+	_ = f.addContentTypePart(drawingID, "drawings")
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	return nil
+}
+
+// addDrawingChartEx provides a function to add the graphic frame of chartex
+// part in the alternate content of the drawing part by given worksheet name,
+// drawingXML, cell reference, relationship index and format sets, the
+// fallback shape will be displayed in the Excel which doesn't support the
+// chartex part.
+func (f *File) addDrawingChartEx(sheet, drawingXML, cell string, rID int, opts *Chart) error {
+	content, twoCellAnchor, cNvPrID, err := f.twoCellAnchorShape(sheet, drawingXML, cell, opts.Dimension.Width, opts.Dimension.Height, opts.Format)
+	if err != nil {
+		return err
+	}
+	graphicFrame := xlsxGraphicFrame{
+		NvGraphicFramePr: xlsxNvGraphicFramePr{
+			CNvPr: &xlsxCNvPr{
+				ID:   cNvPrID,
+				Name: "Chart " + strconv.Itoa(cNvPrID),
+			},
+		},
+		Xfrm: xlsxXfrm{Off: xlsxOff{}, Ext: aExt{}},
+		Graphic: &xlsxGraphic{
+			GraphicData: &xlsxGraphicData{
+				URI: NameSpaceDrawingMLChartEx.Value,
+				ChartEx: &xlsxChartEx{
+					Cx:  NameSpaceDrawingMLChartEx.Value,
+					R:   SourceRelationship.Value,
+					RID: "rId" + strconv.Itoa(rID),
+				},
+			},
+		},
+	}
+	graphic, _ := xml.Marshal(graphicFrame)
+	sp := xdrSp{
+		NvSpPr: &xdrNvSpPr{
+			CNvPr: &xlsxCNvPr{
+				ID: cNvPrID,
+			},
+			CNvSpPr: &xdrCNvSpPr{
+				TxBox: true,
+			},
+		},
+		SpPr: &xlsxSpPr{
+			Xfrm:      xlsxXfrm{Off: xlsxOff{}, Ext: aExt{Cx: int(opts.Dimension.Width) * EMU, Cy: int(opts.Dimension.Height) * EMU}},
+			SolidFill: &xlsxInnerXML{Content: "<a:prstClr val=\"white\"/>"},
+			PrstGeom: xlsxPrstGeom{
+				Prst: "rect",
+			},
+			Ln: xlsxLineProperties{W: 1, SolidFill: &xlsxInnerXML{Content: "<a:prstClr val=\"green\"/>"}},
+		},
+		TxBody: &xdrTxBody{
+			BodyPr: &aBodyPr{VertOverflow: "clip", HorzOverflow: "clip"},
+			P: []*aP{
+				{R: []*aR{{T: "This chart isn't available in your version of Excel."}}},
+				{R: []*aR{{T: "Editing this shape or saving this workbook into a different file format will permanently break the chart."}}},
+			},
+		},
+	}
+	shape, _ := xml.Marshal(sp)
+	twoCellAnchor.ClientData = &xdrClientData{
+		FLocksWithSheet:  *opts.Format.Locked,
+		FPrintsWithSheet: *opts.Format.PrintObject,
+	}
+	choice := xlsxChoice{
+		XMLNSCx2: NameSpaceDrawingMLChartEx201510.Value,
+		Requires: NameSpaceDrawingMLChartEx201510.Name.Local,
+		Content:  string(graphic),
+	}
+	choiceBytes, _ := xml.Marshal(choice)
+	shapeBytes, _ := xml.Marshal(xlsxFallback{Content: string(shape)})
+	twoCellAnchor.AlternateContent = append(twoCellAnchor.AlternateContent, &xlsxAlternateContent{
+		XMLNSMC: SourceRelationshipCompatibility.Value,
+		Content: string(choiceBytes) + string(shapeBytes),
+	})
+	content.TwoCellAnchor = append(content.TwoCellAnchor, twoCellAnchor)
+	f.Drawings.Store(drawingXML, content)
+	return err
+}
+
 // drawBaseChart provides a function to draw the c:plotArea element for bar,
 // and column series charts by given format sets.
 func (f *File) drawBaseChart(opts *Chart) *cPlotArea {
@@ -678,6 +835,52 @@ func (f *File) drawBubbleChart(opts *Chart) *cPlotArea {
 	return plotArea
 }
 
+// drawStockChart provides a function to draw the c:stockChart element by
+// given format sets.
+func (f *File) drawStockChart(opts *Chart) *cPlotArea {
+	plotArea := &cPlotArea{
+		StockChart: &cCharts{
+			Ser:   f.drawChartSeries(opts),
+			DLbls: f.drawChartDLbls(opts),
+			HiLowLines: &cChartLines{
+				SpPr: &cSpPr{
+					Ln: &aLn{
+						W: 9525,
+						SolidFill: &aSolidFill{
+							SchemeClr: &aSchemeClr{
+								Val:    "tx1",
+								LumMod: &attrValInt{Val: intPtr(75000)},
+								LumOff: &attrValInt{Val: intPtr(25000)},
+							},
+						},
+					},
+				},
+			},
+			AxID: f.genAxID(opts),
+		},
+		CatAx: f.drawPlotAreaCatAx(opts),
+		ValAx: f.drawPlotAreaValAx(opts),
+	}
+	if opts.Type == StockOpenHighLowClose {
+		plotArea.StockChart.UpDownBars = &cUpDownBars{
+			GapWidth: &attrValInt{Val: intPtr(150)},
+			UpBars: &cChartLines{
+				SpPr: &cSpPr{
+					SolidFill: &aSolidFill{SchemeClr: &aSchemeClr{Val: "lt1"}},
+					Ln:        &aLn{W: 9525, SolidFill: &aSolidFill{SchemeClr: &aSchemeClr{Val: "tx1"}}},
+				},
+			},
+			DownBars: &cChartLines{
+				SpPr: &cSpPr{
+					SolidFill: &aSolidFill{SchemeClr: &aSchemeClr{Val: "dk1"}},
+					Ln:        &aLn{W: 9525, SolidFill: &aSolidFill{SchemeClr: &aSchemeClr{Val: "tx1"}}},
+				},
+			},
+		}
+	}
+	return plotArea
+}
+
 // drawChartShape provides a function to draw the c:shape element by given
 // format sets.
 func (f *File) drawChartShape(opts *Chart) *attrValString {
@@ -726,8 +929,10 @@ func (f *File) drawChartSeries(opts *Chart) *[]cSer {
 			SpPr:             f.drawChartSeriesSpPr(k, opts),
 			Marker:           f.drawChartSeriesMarker(k, opts),
 			DPt:              f.drawChartSeriesDPt(k, opts),
-			DLbls:            f.drawChartSeriesDLbls(opts),
+			DLbls:            f.drawChartSeriesDLbls(k, opts),
 			InvertIfNegative: &attrValBool{Val: boolPtr(false)},
+			Trendline:        f.drawChartSeriesTrendline(k, opts),
+			ErrBars:          f.drawChartSeriesErrBars(k, opts),
 			Cat:              f.drawChartSeriesCat(opts.Series[k], opts),
 			Smooth:           &attrValBool{Val: boolPtr(opts.Series[k].Line.Smooth)},
 			Val:              f.drawChartSeriesVal(opts.Series[k], opts),
@@ -743,41 +948,61 @@ func (f *File) drawChartSeries(opts *Chart) *[]cSer {
 // drawChartSeriesSpPr provides a function to draw the c:spPr element by given
 // format sets.
 func (f *File) drawChartSeriesSpPr(i int, opts *Chart) *cSpPr {
-	var srgbClr *attrValString
-	var schemeClr *aSchemeClr
-
+	var fill, lineFill *aSolidFill
 	if color := opts.Series[i].Fill.Color; len(color) == 1 {
-		srgbClr = &attrValString{Val: stringPtr(strings.TrimPrefix(color[0], "#"))}
-	} else {
-		schemeClr = &aSchemeClr{Val: "accent" + strconv.Itoa((opts.order+i)%6+1)}
+		fill = f.drawChartSolidFill(color[0])
+	}
+	lineFill = fill
+	if fill == nil {
+		lineFill = &aSolidFill{SchemeClr: &aSchemeClr{Val: "accent" + strconv.Itoa((opts.order+i)%6+1)}}
+	}
+	if color := opts.Series[i].Line.Color; color != "" {
+		lineFill = f.drawChartSolidFill(color)
 	}
-
-	spPr := &cSpPr{SolidFill: &aSolidFill{SchemeClr: schemeClr, SrgbClr: srgbClr}}
 	spPrScatter := &cSpPr{
 		Ln: &aLn{
 			W:      25400,
 			NoFill: " ",
 		},
 	}
+	if opts.Series[i].Line.Color != "" {
+		spPrScatter.Ln = &aLn{W: f.ptToEMUs(opts.Series[i].Line.Width), Cap: "rnd", SolidFill: lineFill}
+	}
 	spPrLine := &cSpPr{
 		Ln: &aLn{
-			W:   f.ptToEMUs(opts.Series[i].Line.Width),
-			Cap: "rnd", // rnd, sq, flat
-			SolidFill: &aSolidFill{
-				SchemeClr: schemeClr,
-				SrgbClr:   srgbClr,
-			},
+			W:         f.ptToEMUs(opts.Series[i].Line.Width),
+			Cap:       "rnd", // rnd, sq, flat
+			SolidFill: lineFill,
 		},
 	}
 	if chartSeriesSpPr, ok := map[ChartType]*cSpPr{
-		Line: spPrLine, Scatter: spPrScatter,
+		Line: spPrLine, Scatter: spPrScatter, StockHighLowClose: spPrScatter, StockOpenHighLowClose: spPrScatter,
 	}[opts.Type]; ok {
 		return chartSeriesSpPr
 	}
-	if srgbClr != nil {
-		return spPr
+	var spPr *cSpPr
+	if fill != nil {
+		spPr = &cSpPr{SolidFill: fill}
 	}
-	return nil
+	if opts.Series[i].Line.Color != "" {
+		if spPr == nil {
+			spPr = &cSpPr{}
+		}
+		spPr.Ln = &aLn{SolidFill: lineFill}
+		if opts.Series[i].Line.Width != 0 {
+			spPr.Ln.W = f.ptToEMUs(opts.Series[i].Line.Width)
+		}
+	}
+	return spPr
+}
+
+// drawChartSolidFill provides a function to draw the a:solidFill element by
+// given RGB color or theme color name, such as "accent1" or "tx1".
+func (f *File) drawChartSolidFill(color string) *aSolidFill {
+	if inStrSlice(supportedChartThemeColors, color, true) != -1 {
+		return &aSolidFill{SchemeClr: &aSchemeClr{Val: color}}
+	}
+	return &aSolidFill{SrgbClr: &attrValString{Val: stringPtr(strings.TrimPrefix(color, "#"))}}
 }
 
 // drawChartSeriesDPt provides a function to draw the c:dPt element by given
@@ -842,18 +1067,28 @@ func (f *File) drawChartSeriesVal(v ChartSeries, opts *Chart) *cVal {
 // drawChartSeriesMarker provides a function to draw the c:marker element by
 // given data index and format sets.
 func (f *File) drawChartSeriesMarker(i int, opts *Chart) *cMarker {
-	defaultSymbol := map[ChartType]*attrValString{Scatter: {Val: stringPtr("circle")}}
+	defaultSymbol := map[ChartType]*attrValString{
+		Scatter:               {Val: stringPtr("circle")},
+		StockHighLowClose:     {Val: stringPtr("none")},
+		StockOpenHighLowClose: {Val: stringPtr("none")},
+	}
 	marker := &cMarker{
 		Symbol: defaultSymbol[opts.Type],
 		Size:   &attrValInt{Val: intPtr(5)},
 	}
+	if opts.Type == StockHighLowClose && i == 2 { // close price
+		marker.Symbol = &attrValString{Val: stringPtr("dot")}
+	}
 	if symbol := stringPtr(opts.Series[i].Marker.Symbol); *symbol != "" {
 		marker.Symbol = &attrValString{Val: symbol}
 	}
 	if size := intPtr(opts.Series[i].Marker.Size); *size != 0 {
 		marker.Size = &attrValInt{Val: size}
 	}
-	if i < 6 {
+	if color := opts.Series[i].Marker.Fill.Color; len(color) == 1 {
+		fill := f.drawChartSolidFill(color[0])
+		marker.SpPr = &cSpPr{SolidFill: fill, Ln: &aLn{W: 9252, SolidFill: fill}}
+	} else if i < 6 {
 		marker.SpPr = &cSpPr{
 			SolidFill: &aSolidFill{
 				SchemeClr: &aSchemeClr{
@@ -870,7 +1105,7 @@ func (f *File) drawChartSeriesMarker(i int, opts *Chart) *cMarker {
 			},
 		}
 	}
-	chartSeriesMarker := map[ChartType]*cMarker{Scatter: marker, Line: marker}
+	chartSeriesMarker := map[ChartType]*cMarker{Scatter: marker, Line: marker, StockHighLowClose: marker, StockOpenHighLowClose: marker}
 	return chartSeriesMarker[opts.Type]
 }
 
@@ -949,16 +1184,86 @@ func (f *File) drawChartDLbls(opts *Chart) *cDLbls {
 }
 
 // drawChartSeriesDLbls provides a function to draw the c:dLbls element by
-// given format sets.
-func (f *File) drawChartSeriesDLbls(opts *Chart) *cDLbls {
-	dLbls := f.drawChartDLbls(opts)
+// given series index and format sets.
+func (f *File) drawChartSeriesDLbls(i int, opts *Chart) *cDLbls {
 	chartSeriesDLbls := map[ChartType]*cDLbls{
 		Scatter: nil, Surface3D: nil, WireframeSurface3D: nil, Contour: nil, WireframeContour: nil, Bubble: nil, Bubble3D: nil,
 	}
+	if label := opts.Series[i].DataLabel; label != (ChartDataLabel{}) {
+		if _, ok := chartSeriesDLbls[opts.Type]; ok && opts.Type != Scatter && opts.Type != Bubble && opts.Type != Bubble3D {
+			return nil
+		}
+		dLbls := &cDLbls{
+			NumFmt:          f.drawChartNumFmt(label.NumFmt),
+			ShowLegendKey:   &attrValBool{Val: boolPtr(opts.Legend.ShowLegendKey)},
+			ShowVal:         &attrValBool{Val: boolPtr(label.ShowVal)},
+			ShowCatName:     &attrValBool{Val: boolPtr(label.ShowCatName)},
+			ShowSerName:     &attrValBool{Val: boolPtr(label.ShowSerName)},
+			ShowBubbleSize:  &attrValBool{Val: boolPtr(opts.PlotArea.ShowBubbleSize)},
+			ShowPercent:     &attrValBool{Val: boolPtr(label.ShowPercent)},
+			ShowLeaderLines: &attrValBool{Val: boolPtr(opts.PlotArea.ShowLeaderLines)},
+		}
+		if label.Position != "" {
+			dLbls.DLblPos = &attrValString{Val: stringPtr(chartDataLabelPosition[label.Position])}
+		}
+		return dLbls
+	}
 	if _, ok := chartSeriesDLbls[opts.Type]; ok {
 		return nil
 	}
-	return dLbls
+	return f.drawChartDLbls(opts)
+}
+
+// drawChartSeriesTrendline provides a function to draw the c:trendline
+// element by given series index and format sets.
+func (f *File) drawChartSeriesTrendline(i int, opts *Chart) *cTrendline {
+	trendline := opts.Series[i].Trendline
+	chartSeriesTrendline := map[ChartType]bool{Area: true, Bar: true, Bubble: true, Col: true, Line: true, Scatter: true}
+	if trendline.Type == "" || !chartSeriesTrendline[opts.Type] {
+		return nil
+	}
+	c := &cTrendline{
+		TrendlineType: &attrValString{Val: stringPtr(chartTrendlineType[trendline.Type])},
+		DispRSqr:      &attrValBool{Val: boolPtr(trendline.DisplayRSquared)},
+		DispEq:        &attrValBool{Val: boolPtr(trendline.DisplayEquation)},
+	}
+	switch trendline.Type {
+	case "polynomial":
+		c.Order = &attrValInt{Val: intPtr(trendline.Order)}
+	case "moving_average":
+		c.Period = &attrValInt{Val: intPtr(trendline.Period)}
+		c.DispRSqr, c.DispEq = nil, nil
+		return c
+	}
+	if trendline.DisplayEquation || trendline.DisplayRSquared {
+		c.TrendlineLbl = &cTrendlineLbl{NumFmt: &cNumFmt{FormatCode: "General"}}
+	}
+	return c
+}
+
+// drawChartSeriesErrBars provides a function to draw the c:errBars element by
+// given series index and format sets.
+func (f *File) drawChartSeriesErrBars(i int, opts *Chart) *cErrBars {
+	errorBars := opts.Series[i].ErrorBars
+	chartSeriesErrBars := map[ChartType]bool{
+		Area: true, AreaStacked: true, AreaPercentStacked: true, Bar: true, BarStacked: true, BarPercentStacked: true,
+		Bubble: true, Col: true, ColStacked: true, ColPercentStacked: true, Line: true, Scatter: true,
+	}
+	if errorBars.Type == "" || !chartSeriesErrBars[opts.Type] {
+		return nil
+	}
+	c := &cErrBars{
+		ErrBarType: &attrValString{Val: stringPtr(errorBars.Direction)},
+		ErrValType: &attrValString{Val: stringPtr(chartErrorBarsType[errorBars.Type])},
+		NoEndCap:   &attrValBool{Val: boolPtr(errorBars.NoEndCap)},
+	}
+	if errorBars.Type != "standard_error" {
+		c.Val = &attrValFloat{Val: float64Ptr(errorBars.Value)}
+	}
+	if opts.Type == Scatter || opts.Type == Bubble {
+		c.ErrDir = &attrValString{Val: stringPtr("y")}
+	}
+	return c
 }
 
 // drawPlotAreaCatAx provides a function to draw the c:catAx element.
@@ -984,7 +1289,7 @@ func (f *File) drawPlotAreaCatAx(opts *Chart) []*cAxs {
 			NumFmt:        &cNumFmt{FormatCode: "General"},
 			MajorTickMark: &attrValString{Val: stringPtr("none")},
 			MinorTickMark: &attrValString{Val: stringPtr("none")},
-			Title:         f.drawPlotAreaTitles(opts.XAxis.Title, ""),
+			Title:         f.drawPlotAreaAxisTitle(&opts.XAxis, ""),
 			TickLblPos:    &attrValString{Val: stringPtr("nextTo")},
 			SpPr:          f.drawPlotAreaSpPr(),
 			TxPr:          f.drawPlotAreaTxPr(&opts.YAxis),
@@ -1058,7 +1363,7 @@ func (f *File) drawPlotAreaValAx(opts *Chart) []*cAxs {
 			},
 			Delete: &attrValBool{Val: boolPtr(opts.YAxis.None)},
 			AxPos:  &attrValString{Val: stringPtr(valAxPos[opts.YAxis.ReverseOrder])},
-			Title:  f.drawPlotAreaTitles(opts.YAxis.Title, "horz"),
+			Title:  f.drawPlotAreaAxisTitle(&opts.YAxis, "horz"),
 			NumFmt: &cNumFmt{
 				FormatCode: chartValAxNumFmtFormatCode[opts.Type],
 			},
@@ -1087,16 +1392,22 @@ func (f *File) drawPlotAreaValAx(opts *Chart) []*cAxs {
 	if opts.YAxis.MajorUnit != 0 {
 		axs[0].MajorUnit = &attrValFloat{Val: float64Ptr(opts.YAxis.MajorUnit)}
 	}
+	if opts.YAxis.MinorUnit != 0 {
+		axs[0].MinorUnit = &attrValFloat{Val: float64Ptr(opts.YAxis.MinorUnit)}
+	}
 	if opts.order > 0 && opts.YAxis.Secondary {
 		axs = append(axs, &cAxs{
 			AxID: &attrValInt{Val: intPtr(opts.YAxis.axID)},
 			Scaling: &cScaling{
+				LogBase:     logBase,
 				Orientation: &attrValString{Val: stringPtr(orientation[opts.YAxis.ReverseOrder])},
 				Max:         max,
 				Min:         min,
 			},
-			Delete:        &attrValBool{Val: boolPtr(false)},
+			Delete:        &attrValBool{Val: boolPtr(opts.YAxis.None)},
 			AxPos:         &attrValString{Val: stringPtr("r")},
+			Title:         f.drawPlotAreaAxisTitle(&opts.YAxis, "horz"),
+			NumFmt:        axs[0].NumFmt,
 			MajorTickMark: &attrValString{Val: stringPtr("none")},
 			MinorTickMark: &attrValString{Val: stringPtr("none")},
 			TickLblPos:    &attrValString{Val: stringPtr("nextTo")},
@@ -1105,6 +1416,8 @@ func (f *File) drawPlotAreaValAx(opts *Chart) []*cAxs {
 			CrossAx:       &attrValInt{Val: intPtr(opts.XAxis.axID)},
 			Crosses:       &attrValString{Val: stringPtr("max")},
 			CrossBetween:  &attrValString{Val: stringPtr(chartValAxCrossBetween[opts.Type])},
+			MajorUnit:     axs[0].MajorUnit,
+			MinorUnit:     axs[0].MinorUnit,
 		})
 	}
 	return axs
@@ -1157,7 +1470,7 @@ func (f *File) drawPlotAreaTitles(runs []RichTextRun, vert string) *cTitle {
 		}
 		title.Tx.Rich.P = append(title.Tx.Rich.P, aP{
 			PPr:        &aPPr{DefRPr: aRPr{}},
-			R:          r,
+			R:          []*aR{r},
 			EndParaRPr: &aEndParaRPr{Lang: "en-US", AltLang: "en-US"},
 		})
 	}
@@ -1167,6 +1480,20 @@ func (f *File) drawPlotAreaTitles(runs []RichTextRun, vert string) *cTitle {
 	return title
 }
 
+// drawPlotAreaAxisTitle provides a function to draw the c:title element of
+// the axis, the axis title will be linked to the cell if the title reference
+// of the axis has been specified.
+func (f *File) drawPlotAreaAxisTitle(opts *ChartAxis, vert string) *cTitle {
+	if opts.TitleRef == "" {
+		return f.drawPlotAreaTitles(opts.Title, vert)
+	}
+	title := &cTitle{Tx: cTx{StrRef: &cStrRef{F: opts.TitleRef}}, Overlay: &attrValBool{Val: boolPtr(false)}}
+	if vert == "horz" {
+		title.TxPr.BodyPr = aBodyPr{Rot: -5400000, Vert: vert}
+	}
+	return title
+}
+
 // drawPlotAreaSpPr provides a function to draw the c:spPr element.
 func (f *File) drawPlotAreaSpPr() *cSpPr {
 	return &cSpPr{
@@ -1237,16 +1564,29 @@ func (f *File) drawPlotAreaTxPr(opts *ChartAxis) *cTxPr {
 	return cTxPr
 }
 
-// drawingParser provides a function to parse drawingXML. In order to solve
-// the problem that the label structure is changed after serialization and
-// deserialization, two different structures: decodeWsDr and encodeWsDr are
-// defined.
+// drawingParser provides a function to parse drawingXML for writing. In order
+// to solve the problem that the label structure is changed after
+// serialization and deserialization, two different structures: decodeWsDr and
+// encodeWsDr are defined.
 func (f *File) drawingParser(path string) (*xlsxWsDr, int, error) {
+	return f.getDrawing(path, false)
+}
+
+// drawingViewer provides a function to parse drawingXML for read only, the
+// drawing part will be kept as is on saving if it hasn't been modified.
+func (f *File) drawingViewer(path string) (*xlsxWsDr, int, error) {
+	return f.getDrawing(path, true)
+}
+
+// getDrawing provides a function to get the pointer to the structure after
+// deserialization of drawingXML for writing or read only.
+func (f *File) getDrawing(path string, readOnly bool) (*xlsxWsDr, int, error) {
 	var (
 		err error
 		ok  bool
 	)
 	_, ok = f.Drawings.Load(path)
+	defer f.trackPart(path, !ok, readOnly)
 	if !ok {
 		content := xlsxWsDr{
 			NS:  NameSpaceDrawingMLSpreadSheet.Value,
@@ -1266,6 +1606,12 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int, error) {
 					XMLNSMC: SourceRelationshipCompatibility.Value,
 				})
 			}
+			for _, v := range decodeWsDr.AbsoluteAnchor {
+				content.AbsoluteAnchor = append(content.AbsoluteAnchor, &xdrCellAnchor{
+					EditAs:       v.EditAs,
+					GraphicFrame: v.Content,
+				})
+			}
 			for _, v := range decodeWsDr.OneCellAnchor {
 				content.OneCellAnchor = append(content.OneCellAnchor, &xdrCellAnchor{
 					EditAs:       v.EditAs,
@@ -1287,7 +1633,7 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int, error) {
 	}
 	wsDr.mu.Lock()
 	defer wsDr.mu.Unlock()
-	return wsDr, len(wsDr.OneCellAnchor) + len(wsDr.TwoCellAnchor) + 2, nil
+	return wsDr, len(wsDr.AbsoluteAnchor) + len(wsDr.OneCellAnchor) + len(wsDr.TwoCellAnchor) + 2, nil
 }
 
 // addDrawingChart provides a function to add chart graphic frame by given
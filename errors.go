@@ -14,6 +14,7 @@ package excelize
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
@@ -28,12 +29,37 @@ var (
 	ErrCellCharsLength = fmt.Errorf("cell value must be 0-%d characters", TotalCellChars)
 	// ErrCellStyles defined the error message on cell styles exceeds the limit.
 	ErrCellStyles = fmt.Errorf("the cell styles exceeds the %d limit", MaxCellStyles)
+	// ErrChartDataLabelPosition defined the error message on receiving the
+	// unsupported chart series data label position.
+	ErrChartDataLabelPosition = errors.New("unsupported chart data label position")
+	// ErrChartErrorBars defined the error message on receiving the unsupported
+	// chart series error bars type or direction.
+	ErrChartErrorBars = errors.New("unsupported chart error bars type or direction")
+	// ErrChartLegendPosition defined the error message on receiving the
+	// unsupported chart legend position.
+	ErrChartLegendPosition = errors.New("unsupported chart legend position")
+	// ErrChartMarker defined the error message on receiving the unsupported
+	// chart marker symbol or size.
+	ErrChartMarker = errors.New("unsupported chart marker symbol or size, the size must be between 2 and 72")
+	// ErrChartSeries defined the error message on receiving the chart without
+	// series, or a series without the values.
+	ErrChartSeries = errors.New("the chart must contain at least one series with values")
+	// ErrChartStockSeries defined the error message on receiving the stock chart
+	// with invalid number of series.
+	ErrChartStockSeries = errors.New("the high-low-close stock chart must contain 3 series, and the open-high-low-close stock chart must contain 4 series")
+	// ErrChartTrendline defined the error message on receiving the unsupported
+	// chart series trendline type, or the invalid polynomial order or moving
+	// average period of the trendline.
+	ErrChartTrendline = errors.New("unsupported chart trendline type, order or period")
 	// ErrColumnNumber defined the error message on receive an invalid column
 	// number.
 	ErrColumnNumber = fmt.Errorf("the column number must be greater than or equal to %d and less than or equal to %d", MinColumns, MaxColumns)
 	// ErrColumnWidth defined the error message on receive an invalid column
 	// width.
 	ErrColumnWidth = fmt.Errorf("the width of the column must be less than or equal to %d characters", MaxColumnWidth)
+	// ErrCompressionLevel defined the error message on receive the unsupported
+	// compression level.
+	ErrCompressionLevel = errors.New("unsupported compression level")
 	// ErrCoordinates defined the error message on invalid coordinates tuples
 	// length.
 	ErrCoordinates = errors.New("coordinates length must be 4")
@@ -71,6 +97,9 @@ var (
 	// ErrInvalidFormula defined the error message on receive an invalid
 	// formula.
 	ErrInvalidFormula = errors.New("formula not valid")
+	// ErrMacroWorkbookFileFormat defined the error message on saving the
+	// workbook which contains VBA project with a macro-free file format.
+	ErrMacroWorkbookFileFormat = errors.New("the workbook contains VBA project, the file extension should be XLAM, XLSM or XLTM")
 	// ErrMaxFilePathLength defined the error message on receive the file path
 	// length overflow.
 	ErrMaxFilePathLength = fmt.Errorf("file path length exceeds maximum limit %d characters", MaxFilePathLength)
@@ -79,6 +108,9 @@ var (
 	ErrMaxRowHeight = fmt.Errorf("the height of the row must be less than or equal to %d points", MaxRowHeight)
 	// ErrMaxRows defined the error message on receive a row number exceeds maximum limit.
 	ErrMaxRows = errors.New("row number exceeds maximum limit")
+	// ErrMoveMergedCells defined the error message on moving the row or column
+	// which is a part of the merged cells.
+	ErrMoveMergedCells = errors.New("cannot move a part of the merged cells")
 	// ErrNameLength defined the error message on receiving the defined name or
 	// table name length exceeds the limit.
 	ErrNameLength = fmt.Errorf("the name length exceeds the %d characters limit", MaxFieldLength)
@@ -97,6 +129,9 @@ var (
 	// ErrPasswordLengthInvalid defined the error message on invalid password
 	// length.
 	ErrPasswordLengthInvalid = errors.New("password length invalid")
+	// ErrPhoneticCellValue defined the error message on set phonetic text for
+	// the cell which not contains a string value.
+	ErrPhoneticCellValue = errors.New("phonetic text can only be set on the cell with a string value")
 	// ErrSave defined the error message for saving file.
 	ErrSave = errors.New("no path defined for file, consider File.WriteTo or File.Write")
 	// ErrSheetIdx defined the error message on receive the invalid worksheet
@@ -246,6 +281,12 @@ func newInvalidNameError(name string) error {
 	return fmt.Errorf("invalid name %q, the name should be starts with a letter or underscore, can not include a space or character, and can not conflict with an existing name in the workbook", name)
 }
 
+// newInvalidOptionalValue defined the error message on receiving the invalid
+// optional value.
+func newInvalidOptionalValue(name, value string, values []string) error {
+	return fmt.Errorf("invalid %s value %q, acceptable value should be one of %s", name, value, strings.Join(values, ", "))
+}
+
 // newInvalidRowNumberError defined the error message on receiving the invalid
 // row number.
 func newInvalidRowNumberError(row int) error {
@@ -264,18 +305,48 @@ func newInvalidStyleID(styleID int) error {
 	return fmt.Errorf("invalid style ID %d", styleID)
 }
 
+// newInvalidTimelineNameError defined the error message on receiving the
+// invalid timeline name.
+func newInvalidTimelineNameError(name string) error {
+	return fmt.Errorf("invalid timeline name %q", name)
+}
+
+// newNoExistCommentError defined the error message on receiving the non
+// existing comment cell reference.
+func newNoExistCommentError(cell string) error {
+	return fmt.Errorf("comment in cell %s does not exist", cell)
+}
+
 // newNoExistTableError defined the error message on receiving the non existing
 // table name.
 func newNoExistTableError(name string) error {
 	return fmt.Errorf("table %s does not exist", name)
 }
 
+// newNoExistVBAModuleError defined the error message on receiving the non
+// existing VBA module name.
+func newNoExistVBAModuleError(name string) error {
+	return fmt.Errorf("VBA module %s does not exist", name)
+}
+
 // newNotWorksheetError defined the error message on receiving a sheet which
 // not a worksheet.
 func newNotWorksheetError(name string) error {
 	return fmt.Errorf("sheet %s is not a worksheet", name)
 }
 
+// newPictureDownloadError defined the error message on receiving an
+// unexpected HTTP response status on download the picture.
+func newPictureDownloadError(url, status string) error {
+	return fmt.Errorf("failed to download picture from %s: %s", url, status)
+}
+
+// newPictureSizeLimitError defined the error message on the picture size
+// exceeds the limit.
+func newPictureSizeLimitError(sizeLimit int64) error {
+	return fmt.Errorf("picture size exceeds the %d bytes limit", sizeLimit)
+}
+
 // newPivotTableDataRangeError defined the error message on receiving the
 // invalid pivot table data range.
 func newPivotTableDataRangeError(msg string) error {
@@ -19,25 +19,42 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/richardlehane/mscfb"
 	"golang.org/x/net/html/charset"
 )
 
-// File define a populated spreadsheet file struct.
+// File define a populated spreadsheet file struct. Each worksheet has its own
+// read-write lock, so the functions documented as concurrency safe can be
+// called on different worksheets from multiple goroutines in parallel, and
+// the getters documented as concurrency safe share the lock for reading the
+// same worksheet. The shared strings table and styles part are guarded by
+// their own locks. Functions that add, delete, rename, copy or move
+// worksheets, and functions that save or close the workbook, are not safe to
+// call concurrently with any other function on the same File.
 type File struct {
 	mu               sync.Mutex
 	options          *Options
+	calcCache        sync.Map
+	calcFuncs        sync.Map
+	calcExternals    sync.Map
+	calcLinks        map[string]*calcExternalLink
+	calcLinksMu      sync.Mutex
 	xmlAttr          sync.Map
 	checked          sync.Map
 	sheetMap         map[string]string
 	streams          map[string]*StreamWriter
 	tempFiles        sync.Map
+	lazyParts        sync.Map
+	unmodified       sync.Map
 	sharedStringsMap map[string]int
 	sharedStringItem [][]uint
 	sharedStringTemp *os.File
+	sharedStringLRU  *lruCache
 	CalcChain        *xlsxCalcChain
 	Comments         map[string]*xlsxComments
 	ContentTypes     *xlsxTypes
@@ -62,14 +79,47 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 
 // Options define the options for opening and reading the spreadsheet.
 //
+// CompressionLevel specifies the zip compression level for saving the
+// spreadsheet, the default value is CompressionDefault. Use the
+// CompressionBestSpeed for faster saving or the CompressionBestSize for
+// smaller file size.
+//
+// Deterministic specifies if save the spreadsheet in deterministic mode. The
+// parts of the spreadsheet will be written with fixed modification time in
+// stable order, so the same workbook content generate byte-identical files.
+//
+// FullPrecision specifies if keep all the digits of the numeric cell values
+// when reading the cells without number format applied. By default, the
+// numeric cell values will be rounded to 15 significant digits as the
+// spreadsheet applications do, this option keeps the value of the big numbers
+// and the high-precision decimals which were written as the full-precision
+// strings.
+//
 // MaxCalcIterations specifies the maximum iterations for iterative
 // calculation, the default value is 0.
 //
-// Password specifies the password of the spreadsheet in plain text.
+// LazyLoad specifies if load the worksheet parts on demand when opening the
+// spreadsheet. The worksheets will be kept compressed in the memory until they
+// were referenced, this speeds up opening a workbook with a large number of
+// worksheets when only a few of them will be used, the default value is
+// false.
+//
+// Password specifies the password of the spreadsheet in plain text. The
+// spreadsheet encrypted by ECMA-376 agile encryption or standard encryption
+// can be opened with the password, and the spreadsheet will be saved with
+// agile encryption when the password was specified.
 //
 // RawCellValue specifies if apply the number format for the cell value or get
 // the raw value.
 //
+// KeepTrailingEmpty specifies if keep the trailing empty cells and rows on
+// reading the rows by the GetRows function and the rows iterator, the
+// default value is false, which means the continually blank cells in the tail
+// of each row and the blank rows in the tail of the worksheet will be
+// trimmed. When this option is enabled, the cells and rows which exist in the
+// worksheet will be preserved even if they are empty, and each row returned
+// by the GetRows function will be padded to the same length.
+//
 // UnzipSizeLimit specifies to unzip size limit in bytes on open the
 // spreadsheet, this value should be greater than or equal to
 // UnzipXMLSizeLimit, the default size limit is 16GB.
@@ -80,6 +130,11 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // should be less than or equal to UnzipSizeLimit, the default value is
 // 16MB.
 //
+// SheetsToLoad specifies the names of worksheets to be loaded on open the
+// spreadsheet. The data of other worksheets will not be decoded, and their
+// original XML will be preserved on save the spreadsheet. All worksheets will
+// be loaded if this option is empty.
+//
 // ShortDatePattern specifies the short date number format code. In the
 // spreadsheet applications, date formats display date and time serial numbers
 // as date values. Date formats that begin with an asterisk (*) respond to
@@ -93,13 +148,24 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // LongTimePattern specifies the long time number format code.
 //
 // CultureInfo specifies the country code for applying built-in language number
-// format code these effect by the system's local language settings.
+// format code these effect by the system's local language settings. The
+// formulas set by the SetCellFormula function will be parsed with the
+// argument separator and decimal separator of the culture, for example, with
+// the CultureNameDeDE culture, the formula =SUM(1,5;2) will be stored as
+// =SUM(1.5,2), so that the formula can be calculated by the CalcCellValue
+// function and the spreadsheet applications in any language.
 type Options struct {
+	CompressionLevel  CompressionLevel
+	Deterministic     bool
+	FullPrecision     bool
 	MaxCalcIterations uint
+	LazyLoad          bool
 	Password          string
 	RawCellValue      bool
+	KeepTrailingEmpty bool
 	UnzipSizeLimit    int64
 	UnzipXMLSizeLimit int64
+	SheetsToLoad      []string
 	ShortDatePattern  string
 	LongDatePattern   string
 	LongTimePattern   string
@@ -201,19 +267,38 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	for k, v := range file {
 		f.Pkg.Store(k, v)
 	}
-	if f.CalcChain, err = f.calcChainReader(); err != nil {
+	if f.CalcChain, err = f.calcChainViewer(); err != nil {
 		return f, err
 	}
 	if f.sheetMap, err = f.getSheetMap(); err != nil {
 		return f, err
 	}
-	if f.Styles, err = f.stylesReader(); err != nil {
+	if err = f.loadSheets(f.options.SheetsToLoad); err != nil {
+		return f, err
+	}
+	if f.Styles, err = f.stylesViewer(); err != nil {
 		return f, err
 	}
 	f.Theme, err = f.themeReader()
+	f.trackPart(defaultXMLPathTheme, f.Theme != nil, true)
 	return f, err
 }
 
+// loadSheets provides a function to extract the worksheets by given
+// worksheets name on open the spreadsheet.
+func (f *File) loadSheets(sheets []string) error {
+	for _, sheet := range sheets {
+		name, ok := f.getSheetXMLPath(sheet)
+		if !ok {
+			return ErrSheetNotExist{sheet}
+		}
+		if err := f.loadLazyPart(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // getOptions provides a function to parse the optional settings for open
 // and reading spreadsheet.
 func getOptions(opts ...Options) *Options {
@@ -251,8 +336,26 @@ func (f *File) setDefaultTimeStyle(sheet, cell string, format int) error {
 }
 
 // workSheetReader provides a function to get the pointer to the structure
-// after deserialization by given worksheet name.
+// after deserialization by given worksheet name. The worksheet will be
+// serialized on save.
 func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
+	return f.getWorkSheet(sheet, false)
+}
+
+// workSheetViewer provides a function to get the pointer to the structure
+// after deserialization by given worksheet name for read only. If the
+// worksheet was loaded by this function and never be got by the
+// workSheetReader, the original worksheet part will be copied verbatim on
+// save instead of serialize the structure.
+func (f *File) workSheetViewer(sheet string) (*xlsxWorksheet, error) {
+	return f.getWorkSheet(sheet, true)
+}
+
+// getWorkSheet provides a function to get the pointer to the structure after
+// deserialization by given worksheet name, and track if the worksheet was
+// modified. The calculated cell values cache will be cleared when getting the
+// worksheet for writing.
+func (f *File) getWorkSheet(sheet string, readOnly bool) (ws *xlsxWorksheet, err error) {
 	var (
 		name string
 		ok   bool
@@ -264,8 +367,13 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 		err = ErrSheetNotExist{sheet}
 		return
 	}
+	if !readOnly {
+		f.clearCalcCache()
+	}
 	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil {
-		ws = worksheet.(*xlsxWorksheet)
+		if ws = worksheet.(*xlsxWorksheet); !readOnly {
+			f.unmodified.Delete(name)
+		}
 		return
 	}
 	for _, sheetType := range []string{"xl/chartsheets", "xl/dialogsheet", "xl/macrosheet"} {
@@ -289,77 +397,123 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 	}
 	err = nil
 	if _, ok = f.checked.Load(name); !ok {
-		ws.checkSheet()
-		if err = ws.checkRow(); err != nil {
-			return
+		if !ws.isSparseSheetData() {
+			ws.checkSheet()
+			if err = ws.checkRow(); err != nil {
+				return
+			}
 		}
 		f.checked.Store(name, true)
 	}
+	if readOnly {
+		f.unmodified.Store(name, true)
+	}
 	f.Sheet.Store(name, ws)
 	return
 }
 
-// checkSheet provides a function to fill each row element and make that is
-// continuous in a worksheet of XML.
-func (ws *xlsxWorksheet) checkSheet() {
+// trackPart provides a function to track if the package part was modified by
+// given part path, if the part was just loaded and if the part was got for
+// read only. The part just loaded for read only will be marked as unmodified,
+// and the part got for writing will be marked as modified.
+func (f *File) trackPart(path string, loaded, readOnly bool) {
+	if !readOnly {
+		f.unmodified.Delete(path)
+		return
+	}
+	if loaded {
+		f.unmodified.Store(path, true)
+	}
+}
+
+// isSparseSheetData provides a function to check if the worksheet data is
+// already in the sparse form, which the row number of each row element should
+// be in ascending order, and each cell should have the cell reference in the
+// row in ascending column order. This check will not allocate any rows or
+// cells, so the worksheet with data in the far rows or columns can be loaded
+// without checking the whole worksheet.
+func (ws *xlsxWorksheet) isSparseSheetData() bool {
 	var row int
-	var r0 xlsxRow
+	for _, r := range ws.SheetData.Row {
+		if r.R <= row {
+			return false
+		}
+		row = r.R
+		var col int
+		for _, c := range r.C {
+			cellCol, cellRow, err := CellNameToCoordinates(c.R)
+			if err != nil || cellRow != row || cellCol <= col {
+				return false
+			}
+			col = cellCol
+		}
+	}
+	return true
+}
+
+// checkSheet provides a function to give the row number for each row element
+// without r attribute, merge the row elements with the same row number and
+// sort the row elements by row number in a worksheet of XML. The missing rows
+// will not be filled, so the worksheet keeps sparse.
+func (ws *xlsxWorksheet) checkSheet() {
+	var (
+		row       int
+		r0        xlsxRow
+		sheetData xlsxSheetData
+		rows      = make(map[int]int, len(ws.SheetData.Row))
+	)
 	for i, r := range ws.SheetData.Row {
 		if i == 0 && r.R == 0 {
 			r0 = r
-			ws.SheetData.Row = ws.SheetData.Row[1:]
 			continue
 		}
-		if r.R != 0 && r.R > row {
-			row = r.R
+		if r.R == row && row > 0 {
+			idx := rows[row]
+			sheetData.Row[idx].C = append(sheetData.Row[idx].C, r.C...)
 			continue
 		}
-		if r.R != row {
+		if r.R == 0 {
 			row++
+			r.R = row
 		}
-	}
-	sheetData := xlsxSheetData{Row: make([]xlsxRow, row)}
-	row = 0
-	for _, r := range ws.SheetData.Row {
-		if r.R == row && row > 0 {
-			sheetData.Row[r.R-1].C = append(sheetData.Row[r.R-1].C, r.C...)
+		row = r.R
+		if idx, ok := rows[row]; ok {
+			sheetData.Row[idx] = r
 			continue
 		}
-		if r.R != 0 {
-			sheetData.Row[r.R-1] = r
-			row = r.R
-			continue
-		}
-		row++
-		r.R = row
-		sheetData.Row[row-1] = r
+		rows[row] = len(sheetData.Row)
+		sheetData.Row = append(sheetData.Row, r)
 	}
-	for i := 1; i <= row; i++ {
-		sheetData.Row[i-1].R = i
-	}
-	ws.checkSheetR0(&sheetData, &r0)
+	sort.SliceStable(sheetData.Row, func(i, j int) bool {
+		return sheetData.Row[i].R < sheetData.Row[j].R
+	})
+	ws.SheetData = sheetData
+	ws.checkSheetR0(&r0)
 }
 
 // checkSheetR0 handle the row element with r="0" attribute, cells in this row
 // could be disorderly, the cell in this row can be used as the value of
 // which cell is empty in the normal rows.
-func (ws *xlsxWorksheet) checkSheetR0(sheetData *xlsxSheetData, r0 *xlsxRow) {
+func (ws *xlsxWorksheet) checkSheetR0(r0 *xlsxRow) {
 	for _, cell := range r0.C {
-		if col, row, err := CellNameToCoordinates(cell.R); err == nil {
-			rows, rowIdx := len(sheetData.Row), row-1
-			for r := rows; r < row; r++ {
-				sheetData.Row = append(sheetData.Row, xlsxRow{R: r + 1})
+		if _, row, err := CellNameToCoordinates(cell.R); err == nil {
+			rowIdx, ok := ws.rowIndex(row)
+			if !ok {
+				ws.insertRowData(rowIdx, xlsxRow{R: row})
 			}
-			columns, colIdx := len(sheetData.Row[rowIdx].C), col-1
-			for c := columns; c < col; c++ {
-				sheetData.Row[rowIdx].C = append(sheetData.Row[rowIdx].C, xlsxC{})
+			rowData, colIdx := &ws.SheetData.Row[rowIdx], 0
+			for colIdx < len(rowData.C) && rowData.C[colIdx].R != cell.R {
+				colIdx++
 			}
-			if !sheetData.Row[rowIdx].C[colIdx].hasValue() {
-				sheetData.Row[rowIdx].C[colIdx] = cell
+			if colIdx == len(rowData.C) {
+				rowData.C = append(rowData.C, cell)
+				continue
+			}
+			if !rowData.C[colIdx].hasValue() {
+				rowData.C[colIdx] = cell
 			}
 		}
 	}
-	ws.SheetData = *sheetData
 }
 
 // setRels provides a function to set relationships by given relationship ID,
@@ -429,7 +583,9 @@ func (f *File) addRels(relPath, relType, target, targetMode string) int {
 // https://social.technet.microsoft.com/Forums/office/en-US/e16bae1f-6a2c-4325-8013-e989a3479066/excel-2010-linked-cells-not-updating
 //
 // Notice: after opening generated workbook, Excel will update the linked value
-// and generate a new value and will prompt to save the file or not.
+// and generate a new value and will prompt to save the file or not. Use the
+// SetCalcProps function with the "FullCalcOnLoad" option to ask Excel to
+// recalculate all formulas on open without removing the cached values.
 //
 // For example:
 //
@@ -475,7 +631,10 @@ func (f *File) UpdateLinkedValue() error {
 }
 
 // AddVBAProject provides the method to add vbaProject.bin file which contains
-// functions and/or macros. The file extension should be XLSM or XLTM. For
+// functions and/or macros. The file extension should be XLSM or XLTM, saving
+// the workbook which contains a VBA project with a macro-free file extension
+// will return ErrMacroWorkbookFileFormat, and the main content type will be
+// set as macro-enabled when writing the workbook without a file path. For
 // example:
 //
 //	codeName := "Sheet1"
@@ -537,8 +696,9 @@ func (f *File) AddVBAProject(file []byte) error {
 // setContentTypePartProjectExtensions provides a function to set the content
 // type for relationship parts and the main document part.
 func (f *File) setContentTypePartProjectExtensions(contentType string) error {
-	var ok bool
-	content, err := f.contentTypesReader()
+	var ok, override bool
+	vbaProjectPath := "/" + f.getVBAProjectPath()
+	content, err := f.contentTypesViewer()
 	if err != nil {
 		return err
 	}
@@ -546,13 +706,31 @@ func (f *File) setContentTypePartProjectExtensions(contentType string) error {
 	defer content.mu.Unlock()
 	for _, v := range content.Defaults {
 		if v.Extension == "bin" {
-			ok = true
+			ok, override = true, v.ContentType != ContentTypeVBA
 		}
 	}
 	for idx, o := range content.Overrides {
-		if o.PartName == "/xl/workbook.xml" {
+		if o.PartName == "/xl/workbook.xml" && o.ContentType != contentType {
+			f.trackPart(defaultXMLPathContentTypes, false, false)
 			content.Overrides[idx].ContentType = contentType
 		}
+		if o.PartName == vbaProjectPath {
+			override = false
+		}
+	}
+	if vbaProjectPath == "/" {
+		return err
+	}
+	if override || !ok {
+		f.trackPart(defaultXMLPathContentTypes, false, false)
+	}
+	// The default content type of the binary parts was used by other parts,
+	// such as the binary parts of the ActiveX controls
+	if override {
+		content.Overrides = append(content.Overrides, xlsxOverride{
+			PartName:    vbaProjectPath,
+			ContentType: ContentTypeVBA,
+		})
 	}
 	if !ok {
 		content.Defaults = append(content.Defaults, xlsxDefault{
@@ -562,3 +740,149 @@ func (f *File) setContentTypePartProjectExtensions(contentType string) error {
 	}
 	return err
 }
+
+// getVBAProjectPath provides a function to get the path of the VBA project
+// part in the spreadsheet, it will return empty if the workbook doesn't
+// contain a VBA project.
+func (f *File) getVBAProjectPath() string {
+	rels, _ := f.relsViewer(f.getWorkbookRelsPath())
+	if rels == nil {
+		return ""
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipVBAProject {
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/")
+			}
+			return "xl/" + rel.Target
+		}
+	}
+	return ""
+}
+
+// GetVBAProjectModules provides the method to get the names of the modules
+// in the VBA project of the workbook, including the document modules of the
+// workbook and worksheets, the standard modules, the class modules and the
+// user forms. It will return an empty list if the workbook doesn't contain a
+// VBA project. For example, get the module names from an opened macro-enabled
+// workbook:
+//
+//	f, err := excelize.OpenFile("macros.xlsm")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	modules, err := f.GetVBAProjectModules()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(modules)
+func (f *File) GetVBAProjectModules() ([]string, error) {
+	var modules []string
+	vbaProjectPath := f.getVBAProjectPath()
+	if vbaProjectPath == "" {
+		return modules, nil
+	}
+	doc, err := mscfb.New(bytes.NewReader(f.readBytes(vbaProjectPath)))
+	if err != nil {
+		return modules, ErrAddVBAProject
+	}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		if entry.Name != "PROJECT" || len(entry.Path) != 0 {
+			continue
+		}
+		project, err := io.ReadAll(entry)
+		if err != nil {
+			return modules, err
+		}
+		// The properties of the project stream are stored as lines of
+		// "key=value" before the first section.
+		for _, line := range strings.Split(string(project), "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, "[") {
+				break
+			}
+			idx := strings.Index(line, "=")
+			if idx == -1 {
+				continue
+			}
+			switch key, val := line[:idx], line[idx+1:]; key {
+			case "Document":
+				if idx := strings.Index(val, "/"); idx != -1 {
+					val = val[:idx]
+				}
+				modules = append(modules, val)
+			case "Module", "Class", "BaseClass":
+				modules = append(modules, val)
+			}
+		}
+	}
+	return modules, nil
+}
+
+// checkVBAMacro provides a function to check if the module of the given macro
+// name exists in the VBA project of the workbook. The macro name without a
+// module qualifier, the macro of other workbooks, and the macro in a workbook
+// which doesn't contain a VBA project will not be checked.
+func (f *File) checkVBAMacro(macro string) error {
+	if macro == "" || strings.Contains(macro, "!") || f.getVBAProjectPath() == "" {
+		return nil
+	}
+	idx := strings.Index(macro, ".")
+	if idx == -1 {
+		return nil
+	}
+	modules, err := f.GetVBAProjectModules()
+	if err != nil {
+		return err
+	}
+	if inStrSlice(modules, macro[:idx], false) == -1 {
+		return newNoExistVBAModuleError(macro[:idx])
+	}
+	return nil
+}
+
+// checkWorkbookFileFormat provides a function to get the main content type of
+// the workbook by given file path, and check if the file format supports the
+// VBA project of the workbook.
+func (f *File) checkWorkbookFileFormat(path string) (string, error) {
+	contentType, ok := supportedContentTypes[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return contentType, ErrWorkbookFileFormat
+	}
+	if !strings.Contains(contentType, "macroEnabled") && f.getVBAProjectPath() != "" {
+		return contentType, ErrMacroWorkbookFileFormat
+	}
+	return contentType, nil
+}
+
+// setContentTypePartMacroExtensions provides a function to set the main
+// content type of the macro-enabled workbook when writing the workbook which
+// contains a VBA project without a file path.
+func (f *File) setContentTypePartMacroExtensions() error {
+	if f.getVBAProjectPath() == "" {
+		return nil
+	}
+	content, err := f.contentTypesViewer()
+	if err != nil {
+		return err
+	}
+	contentType := ContentTypeMacro
+	content.mu.Lock()
+	for _, o := range content.Overrides {
+		if o.PartName == "/xl/workbook.xml" {
+			switch o.ContentType {
+			case ContentTypeAddinMacro, ContentTypeMacro, ContentTypeTemplateMacro:
+				content.mu.Unlock()
+				return nil
+			case ContentTypeTemplate:
+				contentType = ContentTypeTemplateMacro
+			}
+		}
+	}
+	content.mu.Unlock()
+	return f.setContentTypePartProjectExtensions(contentType)
+}
@@ -223,6 +223,191 @@ func TestCharsetTranscoder(t *testing.T) {
 	f.CharsetTranscoder(*new(charsetTranscoderFn))
 }
 
+func TestOpenFileLazyLoad(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{LazyLoad: true})
+	assert.NoError(t, err)
+	for _, sheetXMLPath := range []string{"xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		_, ok := f.lazyParts.Load(sheetXMLPath)
+		assert.True(t, ok)
+		_, ok = f.Pkg.Load(sheetXMLPath)
+		assert.False(t, ok)
+	}
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, f.GetSheetList())
+	// Test load the worksheet part on demand
+	val, err := f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	_, ok := f.lazyParts.Load("xl/worksheets/sheet2.xml")
+	assert.False(t, ok)
+	_, ok = f.lazyParts.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	// Test save the spreadsheet with not loaded worksheet parts
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	expected, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	saved, err := OpenReader(buf)
+	assert.NoError(t, err)
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		expectedRows, err := expected.GetRows(sheet)
+		assert.NoError(t, err)
+		rows, err := saved.GetRows(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedRows, rows)
+	}
+	rows, err := saved.GetRows("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, val, rows[0][0])
+	assert.NoError(t, expected.Close())
+	assert.NoError(t, saved.Close())
+	// Test delete not loaded worksheet
+	assert.NoError(t, f.DeleteSheet("Sheet1"))
+	_, ok = f.lazyParts.Load("xl/worksheets/sheet1.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f.Close())
+}
+
+func TestOpenFileSheetsToLoad(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{SheetsToLoad: []string{"sheet2"}})
+	assert.NoError(t, err)
+	_, ok := f.Pkg.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	_, ok = f.lazyParts.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	_, ok = f.Pkg.Load("xl/worksheets/sheet1.xml")
+	assert.False(t, ok)
+	// Test preserve the original XML of not loaded worksheets on save
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	expected, err := zip.OpenReader(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	for _, file := range expected.File {
+		if file.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		expectedContent, err := readFile(file)
+		assert.NoError(t, err)
+		for _, savedFile := range zr.File {
+			if savedFile.Name == file.Name {
+				content, err := readFile(savedFile)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedContent, content)
+			}
+		}
+	}
+	assert.NoError(t, expected.Close())
+	assert.NoError(t, f.Close())
+
+	// Test load the worksheet which exceeds the memory limit
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{SheetsToLoad: []string{"Sheet1"}, UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	_, ok = f.tempFiles.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	val, err := f.GetCellValue("Sheet1", "A19")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total:", val)
+	assert.NoError(t, f.Close())
+
+	// Test open the spreadsheet with not exists worksheet
+	_, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{SheetsToLoad: []string{"SheetN"}})
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
+func TestSaveUnmodifiedWorksheets(t *testing.T) {
+	readPart := func(b []byte, name string) []byte { return readZipPart(t, b, name) }
+	original, err := os.ReadFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	// Test read worksheets without modification
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		_, err = f.GetCellValue(sheet, "A1")
+		assert.NoError(t, err)
+		_, err = f.GetColWidth(sheet, "A")
+		assert.NoError(t, err)
+	}
+	_, ok := f.unmodified.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	// Test modify the worksheet after read
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "modified"))
+	_, ok = f.unmodified.Load("xl/worksheets/sheet2.xml")
+	assert.False(t, ok)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Equal(t, readPart(original, "xl/worksheets/sheet1.xml"), readPart(buf.Bytes(), "xl/worksheets/sheet1.xml"))
+	assert.NotEqual(t, readPart(original, "xl/worksheets/sheet2.xml"), readPart(buf.Bytes(), "xl/worksheets/sheet2.xml"))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	val, err := f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "modified", val)
+	val, err = f.GetCellValue("Sheet1", "A19")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total:", val)
+	assert.NoError(t, f.Close())
+}
+
+func TestSaveUnmodifiedParts(t *testing.T) {
+	parts := []string{
+		"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels",
+		"xl/workbook.xml", "xl/sharedStrings.xml", "xl/styles.xml", "xl/theme/theme1.xml",
+	}
+	original, err := os.ReadFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	// Test read the workbook without modification
+	_, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	_, err = f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	_, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	_, err = f.GetPictures("Sheet2", "I1")
+	assert.NoError(t, err)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	for _, part := range parts {
+		assert.Equal(t, readZipPart(t, original, part), readZipPart(t, buf.Bytes(), part), part)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test modify the shared strings, styles and workbook after read
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	_, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStr("Sheet1", "A1", "modified"))
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$1"}))
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	for _, part := range []string{"xl/sharedStrings.xml", "xl/styles.xml", "xl/workbook.xml"} {
+		assert.NotEqual(t, readZipPart(t, original, part), readZipPart(t, buf.Bytes(), part), part)
+	}
+	assert.NoError(t, f.Close())
+}
+
+// readZipPart provides a function to read the content of the package part by
+// given ZIP archive content and part name.
+func readZipPart(t *testing.T, b []byte, name string) []byte {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	assert.NoError(t, err)
+	for _, file := range zr.File {
+		if file.Name == name {
+			content, err := readFile(file)
+			assert.NoError(t, err)
+			return content
+		}
+	}
+	return nil
+}
+
 func TestOpenReader(t *testing.T) {
 	_, err := OpenReader(strings.NewReader(""))
 	assert.EqualError(t, err, zip.ErrFormat.Error())
@@ -483,6 +668,79 @@ func TestGetCellHyperLink(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestGetSheetHyperlinks(t *testing.T) {
+	f := NewFile()
+	links, err := f.GetSheetHyperlinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+	display, tooltip := "Display value", "Hover text"
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External", HyperlinkOpts{
+		Display: &display,
+		Tooltip: &tooltip,
+	}))
+	// Test set hyperlink across the range
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C5:B3", "Sheet1!D8", "Location"))
+	links, err = f.GetSheetHyperlinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Hyperlink{
+		{Cell: "A1", Link: "https://github.com/xuri/excelize", LinkType: "External", Display: display, Tooltip: tooltip},
+		{Cell: "B3:C5", Link: "Sheet1!D8", LinkType: "Location"},
+	}, links)
+	link, target, err := f.GetCellHyperLink("Sheet1", "C4")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "Sheet1!D8", target)
+	// Test set hyperlink with invalid range reference
+	assert.EqualError(t, f.SetCellHyperLink("Sheet1", "A1:B", "Sheet1!D8", "Location"), newCellNameToCoordinatesError("B", newInvalidCellNameError("B")).Error())
+	// Test get sheet hyperlinks with not exist worksheet
+	_, err = f.GetSheetHyperlinks("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get sheet hyperlinks with invalid sheet name
+	_, err = f.GetSheetHyperlinks("Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+}
+
+func TestDeleteCellHyperLink(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "Sheet1!D8", "Location"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B3:C5", "Sheet1!D9", "Location"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "D3:E5", "Sheet1!D10", "Location"))
+	// Test delete the external hyperlink and its relationship
+	assert.NoError(t, f.DeleteCellHyperLink("Sheet1", "A1"))
+	link, _, err := f.GetCellHyperLink("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.False(t, link)
+	rels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	assert.Empty(t, rels.Relationships)
+	// Test delete the hyperlink which covers the cell
+	assert.NoError(t, f.DeleteCellHyperLink("Sheet1", "C4"))
+	// Test delete the hyperlink by range reference
+	assert.NoError(t, f.DeleteCellHyperLink("Sheet1", "D4:E5"))
+	assert.NoError(t, f.DeleteCellHyperLink("Sheet1", "E5:D3"))
+	links, err := f.GetSheetHyperlinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Hyperlink{{Cell: "A2", Link: "Sheet1!D8", LinkType: "Location"}}, links)
+	// Test delete the last hyperlink in the worksheet
+	assert.NoError(t, f.DeleteCellHyperLink("Sheet1", "A2"))
+	assert.NoError(t, f.DeleteCellHyperLink("Sheet1", "A2"))
+	links, err = f.GetSheetHyperlinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+	// Test delete cell hyperlink with invalid cell reference
+	assert.EqualError(t, f.DeleteCellHyperLink("Sheet1", "A"), newInvalidCellNameError("A").Error())
+	// Test delete cell hyperlink with not exist worksheet
+	assert.EqualError(t, f.DeleteCellHyperLink("SheetN", "A1"), "sheet SheetN does not exist")
+	// Test delete cell hyperlink with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.DeleteCellHyperLink("Sheet:1", "A1"))
+	// Test delete cell hyperlink with invalid merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:A"}}}
+	assert.EqualError(t, f.DeleteCellHyperLink("Sheet1", "A1"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+}
+
 func TestSetSheetBackground(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -1013,6 +1271,137 @@ func TestCopySheetError(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopySheetError.xlsx")))
 }
 
+func TestCopySheetFrom(t *testing.T) {
+	src := NewFile()
+	assert.NoError(t, src.SetSheetName("Sheet1", "Data Sheet"))
+	style, err := src.NewStyle(&Style{Font: &Font{Bold: true, Color: "FF0000"}})
+	assert.NoError(t, err)
+	for _, cell := range []string{"A1", "A2", "A3"} {
+		assert.NoError(t, src.SetCellStr("Data Sheet", cell, cell))
+		assert.NoError(t, src.SetCellStyle("Data Sheet", cell, cell, style))
+	}
+	assert.NoError(t, src.SetCellRichText("Data Sheet", "B1", []RichTextRun{{Text: "rich", Font: &Font{Italic: true}}}))
+	for idx, val := range []int{1, 2, 3} {
+		cell, err := CoordinatesToCellName(3, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, src.SetCellValue("Data Sheet", cell, val))
+	}
+	assert.NoError(t, src.SetCellFormula("Data Sheet", "D1", "SUM('Data Sheet'!C1:C3)"))
+	assert.NoError(t, src.MergeCell("Data Sheet", "E1", "F2"))
+	assert.NoError(t, src.SetCellHyperLink("Data Sheet", "A1", "https://github.com/xuri/excelize", "External"))
+	format, err := src.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, src.SetConditionalFormat("Data Sheet", "C1:C3", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: format, Value: "1"},
+	}))
+	assert.NoError(t, src.AddPicture("Data Sheet", "H1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, src.AddChart("Data Sheet", "H10", &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{Name: "'Data Sheet'!$A$1", Values: "'Data Sheet'!$C$1:$C$3"},
+		},
+	}))
+	assert.NoError(t, src.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "'Data Sheet'!$C$1:$C$3"}))
+	assert.NoError(t, src.SetDefinedName(&DefinedName{Name: "Local", RefersTo: "'Data Sheet'!$A$1", Scope: "Data Sheet"}))
+
+	f := NewFile()
+	style, err = f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A1", "Sheet1"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$1"}))
+	assert.NoError(t, f.AddPicture("Sheet1", "B1", filepath.Join("test", "images", "excel.jpg"), nil))
+	assert.NoError(t, f.CopySheetFrom(src, "Data Sheet", "Sheet2"))
+	// Test copy worksheet with the existing worksheet name
+	assert.Equal(t, ErrExistsSheet, f.CopySheetFrom(src, "Data Sheet", "Sheet2"))
+
+	rows, err := f.GetRows("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A1", "rich", "1", ""}, {"A2", "", "2"}, {"A3", "", "3"}}, rows)
+	runs, err := f.GetCellRichText("Sheet2", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "rich", runs[0].Text)
+	assert.True(t, runs[0].Font.Italic)
+	formula, err := f.GetCellFormula("Sheet2", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(Sheet2!C1:C3)", formula)
+	styleID, err := f.GetCellStyle("Sheet2", "A2")
+	assert.NoError(t, err)
+	s, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.True(t, s.Font.Bold)
+	assert.Equal(t, "FF0000", s.Font.Color)
+	opts, err := f.GetConditionalFormats("Sheet2")
+	assert.NoError(t, err)
+	s, err = f.GetConditionalStyle(opts["C1:C3"][0].Format)
+	assert.NoError(t, err)
+	assert.Equal(t, "9A0511", s.Font.Color)
+	mergeCells, err := f.GetMergeCells("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "E1", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "F2", mergeCells[0].GetEndAxis())
+	link, target, err := f.GetCellHyperLink("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	pics, err := f.GetPictures("Sheet2", "H1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, ".png", pics[0].Extension)
+	pics, err = f.GetPictures("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, ".jpeg", pics[0].Extension)
+	chart, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(chart.([]byte)), "<f>Sheet2!$C$1:$C$3</f>")
+	assert.Equal(t, []DefinedName{
+		{Name: "Amount", RefersTo: "Sheet1!$A$1", Scope: "Workbook"},
+		{Name: "Amount", RefersTo: "Sheet2!$C$1:$C$3", Scope: "Sheet2"},
+		{Name: "Local", RefersTo: "Sheet2!$A$1", Scope: "Sheet2"},
+	}, f.GetDefinedName())
+	// Test the source workbook was not changed
+	formula, err = src.GetCellFormula("Data Sheet", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM('Data Sheet'!C1:C3)", formula)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopySheetFrom.xlsx")))
+
+	// Test copy worksheet with invalid worksheet name
+	f = NewFile()
+	assert.EqualError(t, f.CopySheetFrom(src, "Data Sheet", "Sheet:1"), ErrSheetNameInvalid.Error())
+	// Test copy worksheet from not exists worksheet
+	assert.EqualError(t, f.CopySheetFrom(src, "SheetN", "Sheet2"), "sheet SheetN does not exist")
+	// Test copy worksheet with unsupported charset shared strings table
+	src.SharedStrings = nil
+	src.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopySheetFrom(src, "Data Sheet", "Sheet2"), "XML syntax error on line 1: invalid UTF-8")
+	// Test copy worksheet with unsupported charset content types
+	src = NewFile()
+	assert.NoError(t, src.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	src.ContentTypes = nil
+	src.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopySheetFrom(src, "Sheet1", "Sheet2"), "XML syntax error on line 1: invalid UTF-8")
+	// Test copy worksheet with unsupported charset workbook
+	src = NewFile()
+	assert.NoError(t, src.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$1"}))
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopySheetFrom(src, "Sheet1", "Sheet2"), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestRenameFormulaSheet(t *testing.T) {
+	for _, c := range []struct{ formula, target, expected string }{
+		{"Sheet1!A1+'Sheet1'!B1", "Sheet 2", "'Sheet 2'!A1+'Sheet 2'!B1"},
+		{"SUM(Sheet1!A1:A2,Sheet3!A1)", "Sheet2", "SUM(Sheet2!A1:A2,Sheet3!A1)"},
+		{"Sheet1!A1", "2020", "'2020'!A1"},
+		{"Sheet1!A1", "It's", "'It''s'!A1"},
+	} {
+		assert.Equal(t, c.expected, renameFormulaSheet(c.formula, "Sheet1", c.target))
+	}
+}
+
 func TestGetSheetComments(t *testing.T) {
 	f := NewFile()
 	assert.Equal(t, "", f.getSheetComments("sheet0"))
@@ -1272,6 +1661,11 @@ func TestSetSheetCol(t *testing.T) {
 	assert.EqualError(t, f.SetSheetCol("Sheet:1", "A1", &[]interface{}{nil}), ErrSheetNameInvalid.Error())
 	assert.EqualError(t, f.SetSheetCol("Sheet1", "B27", []interface{}{}), ErrParameterInvalid.Error())
 	assert.EqualError(t, f.SetSheetCol("Sheet1", "B27", &f), ErrParameterInvalid.Error())
+	// Test set worksheet column values exceeds the maximum rows limit
+	assert.Equal(t, ErrMaxRows, f.SetSheetCol("Sheet1", fmt.Sprintf("A%d", TotalRows), &[]interface{}{1, 2}))
+	value, err := f.GetCellValue("Sheet1", fmt.Sprintf("A%d", TotalRows))
+	assert.NoError(t, err)
+	assert.Empty(t, value)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetCol.xlsx")))
 	assert.NoError(t, f.Close())
 }
@@ -1288,10 +1682,127 @@ func TestSetSheetRow(t *testing.T) {
 	assert.EqualError(t, f.SetSheetRow("Sheet:1", "A1", &[]interface{}{1}), ErrSheetNameInvalid.Error())
 	assert.EqualError(t, f.SetSheetRow("Sheet1", "B27", []interface{}{}), ErrParameterInvalid.Error())
 	assert.EqualError(t, f.SetSheetRow("Sheet1", "B27", &f), ErrParameterInvalid.Error())
+	// Test set worksheet row values exceeds the maximum columns limit
+	assert.Equal(t, ErrColumnNumber, f.SetSheetRow("Sheet1", "XFD1", &[]interface{}{1, 2}))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetRow.xlsx")))
 	assert.NoError(t, f.Close())
 }
 
+func TestSetRows(t *testing.T) {
+	date := time.Date(2023, 6, 1, 12, 30, 0, 0, time.UTC)
+	values := [][]interface{}{
+		{"Name", "Score", []byte("Date"), "Name"},
+		{int8(1), uint(2), float32(3.5), -4.25, true, nil, struct{}{}},
+		{},
+		{date, time.Hour, "Bob", " space "},
+	}
+	expected := NewFile()
+	for r := range values {
+		cell, err := CoordinatesToCellName(2, r+6)
+		assert.NoError(t, err)
+		assert.NoError(t, expected.SetSheetRow("Sheet1", cell, &values[r]))
+	}
+	f := NewFile()
+	assert.NoError(t, f.SetRows("Sheet1", "B6", values))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	expectedRows, err := expected.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRows, rows)
+	for _, cell := range []string{"B9", "C9"} {
+		style, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		expectedStyle, err := expected.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.NotZero(t, style)
+		assert.Equal(t, expectedStyle, style)
+	}
+	assert.Len(t, f.SharedStrings.SI, 6)
+	// Test set rows into merged cells
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "B2"))
+	assert.NoError(t, f.SetRows("Sheet1", "A1", [][]interface{}{{"a", "b", "c"}, {"d"}}))
+	cells, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"d", "", "c"}, cells[0][:3])
+	// Test set rows with empty values
+	assert.NoError(t, f.SetRows("Sheet1", "A1", nil))
+	assert.NoError(t, f.SetRows("Sheet1", "A1", [][]interface{}{{}}))
+	// Test set rows with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetRows("Sheet1", "A", values))
+	// Test set rows exceeds maximum limit of columns and rows
+	assert.Equal(t, ErrColumnNumber, f.SetRows("Sheet1", "XFD1", values))
+	assert.Equal(t, ErrMaxRows, f.SetRows("Sheet1", fmt.Sprintf("A%d", TotalRows), values))
+	// Test set rows with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.SetRows("Sheet:1", "A1", values))
+	// Test set rows with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetRows("Sheet1", "A1", values), "XML syntax error on line 1: invalid UTF-8")
+	// Test set rows with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetRows("Sheet1", "A1", [][]interface{}{{date}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, expected.Close())
+	assert.NoError(t, f.Close())
+}
+
+func TestSetSheetData(t *testing.T) {
+	type order struct {
+		ID     int       `xlsx:"Order ID"`
+		Amount float64   `xlsx:"Amount,#,##0.00"`
+		Date   time.Time `xlsx:",yyyy-mm-dd"`
+		Note   string    `xlsx:"-"`
+		Paid   bool
+		secret string
+	}
+	date := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFile()
+	assert.NoError(t, f.SetSheetData("Sheet1", "B2", &[]*order{
+		{ID: 1, Amount: 1200.5, Date: date, Note: "note", Paid: true, secret: "secret"},
+		nil,
+		{ID: 3, Amount: 86.25, Date: date.AddDate(0, 0, 1)},
+	}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		nil,
+		{"", "Order ID", "Amount", "Date", "Paid"},
+		{"", "1", "1,200.50", "2023-06-01", "TRUE"},
+		nil,
+		{"", "3", "86.25", "2023-06-02", "FALSE"},
+	}, rows)
+	// Test set sheet data with nil struct pointer clears the existing row
+	assert.NoError(t, f.SetSheetData("Sheet1", "B2", []*order{
+		{ID: 4, Amount: 10, Date: date, Paid: true},
+		{ID: 5, Amount: 20, Date: date},
+		nil,
+	}))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		nil,
+		{"", "Order ID", "Amount", "Date", "Paid"},
+		{"", "4", "10.00", "2023-06-01", "TRUE"},
+		{"", "5", "20.00", "2023-06-01", "FALSE"},
+	}, rows)
+	// Test set sheet data with a slice of structs
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetData("Sheet2", "A1", []order{{ID: 1}}))
+	// Test set sheet data with empty slice
+	assert.NoError(t, f.SetSheetData("Sheet1", "H1", []order{}))
+	header, err := f.GetCellValue("Sheet1", "H1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Order ID", header)
+	// Test set sheet data with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.SetSheetData("Sheet1", "A1", order{}))
+	assert.Equal(t, ErrParameterInvalid, f.SetSheetData("Sheet1", "A1", []int{1}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetSheetData("Sheet1", "A", []order{}))
+	// Test set sheet data with not exist worksheet
+	assert.EqualError(t, f.SetSheetData("SheetN", "A1", []order{}), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestHSL(t *testing.T) {
 	var hsl HSL
 	r, g, b, a := hsl.RGBA()
@@ -1340,13 +1851,28 @@ func TestProtectSheet(t *testing.T) {
 	f := NewFile()
 	sheetName := f.GetSheetName(0)
 	assert.EqualError(t, f.ProtectSheet(sheetName, nil), ErrParameterInvalid.Error())
-	// Test protect worksheet with XOR hash algorithm
+	// Test protect worksheet with SHA-512 hash algorithm by default
 	assert.NoError(t, f.ProtectSheet(sheetName, &SheetProtectionOptions{
 		Password:      "password",
 		EditScenarios: false,
 	}))
 	ws, err := f.workSheetReader(sheetName)
 	assert.NoError(t, err)
+	assert.Empty(t, ws.SheetProtection.Password)
+	assert.Equal(t, "SHA-512", ws.SheetProtection.AlgorithmName)
+	assert.Len(t, ws.SheetProtection.SaltValue, 24)
+	assert.Len(t, ws.SheetProtection.HashValue, 88)
+	assert.Equal(t, int(sheetProtectionSpinCount), ws.SheetProtection.SpinCount)
+	saltValue := ws.SheetProtection.SaltValue
+	assert.NoError(t, f.ProtectSheet(sheetName, &SheetProtectionOptions{Password: "password"}))
+	assert.NotEqual(t, saltValue, ws.SheetProtection.SaltValue)
+	assert.EqualError(t, f.UnprotectSheet(sheetName, "wrongPassword"), ErrUnprotectSheetPassword.Error())
+	assert.NoError(t, f.UnprotectSheet(sheetName, "password"))
+	// Test protect worksheet with XOR hash algorithm
+	assert.NoError(t, f.ProtectSheet(sheetName, &SheetProtectionOptions{
+		AlgorithmName: "XOR",
+		Password:      "password",
+	}))
 	assert.Equal(t, "83AF", ws.SheetProtection.Password)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestProtectSheet.xlsx")))
 	// Test protect worksheet with SHA-512 hash algorithm
@@ -1459,12 +1985,24 @@ func TestUnprotectWorkbook(t *testing.T) {
 	assert.EqualError(t, f.UnprotectWorkbook("wrongPassword"), ErrUnprotectWorkbookPassword.Error())
 	// Test remove workbook protection with password verification
 	assert.NoError(t, f.UnprotectWorkbook("password"))
+	// Test remove workbook protection with XOR hash algorithm
+	assert.NoError(t, f.ProtectWorkbook(&WorkbookProtectionOptions{
+		AlgorithmName: "XOR",
+		Password:      "password",
+		LockWindows:   true,
+	}))
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Equal(t, "83AF", wb.WorkbookProtection.WorkbookPassword)
+	assert.Empty(t, wb.WorkbookProtection.WorkbookHashValue)
+	assert.EqualError(t, f.UnprotectWorkbook("wrongPassword"), ErrUnprotectWorkbookPassword.Error())
+	assert.NoError(t, f.UnprotectWorkbook("password"))
 	// Test with invalid salt value
 	assert.NoError(t, f.ProtectWorkbook(&WorkbookProtectionOptions{
 		AlgorithmName: "SHA-512",
 		Password:      "password",
 	}))
-	wb, err := f.workbookReader()
+	wb, err = f.workbookReader()
 	assert.NoError(t, err)
 	wb.WorkbookProtection.WorkbookSaltValue = "YWJjZA====="
 	assert.EqualError(t, f.UnprotectWorkbook("wrongPassword"), "illegal base64 data at input byte 8")
@@ -1495,12 +2033,84 @@ func TestAddVBAProject(t *testing.T) {
 	// Test add VBA project twice
 	assert.NoError(t, f.AddVBAProject(file))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddVBAProject.xlsm")))
+	// Test save the workbook which contains VBA project with macro-free file extension
+	assert.Equal(t, ErrMacroWorkbookFileFormat, f.SaveAs(filepath.Join("test", "TestAddVBAProject.xlsx")))
+	_, err = os.Stat(filepath.Join("test", "TestAddVBAProject.xlsx"))
+	assert.True(t, os.IsNotExist(err))
+	f.Path = filepath.Join("test", "TestAddVBAProject.xltx")
+	_, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Equal(t, ErrMacroWorkbookFileFormat, f.Write(io.Discard))
+	// Test write the workbook which contains VBA project without file path
+	for contentType, expected := range map[string]string{
+		ContentTypeSheetML:       ContentTypeMacro,
+		ContentTypeTemplate:      ContentTypeTemplateMacro,
+		ContentTypeAddinMacro:    ContentTypeAddinMacro,
+		ContentTypeTemplateMacro: ContentTypeTemplateMacro,
+	} {
+		f.Path = ""
+		assert.NoError(t, f.setContentTypePartProjectExtensions(contentType))
+		assert.NoError(t, f.Write(io.Discard))
+		content, err := f.contentTypesReader()
+		assert.NoError(t, err)
+		for _, o := range content.Overrides {
+			if o.PartName == "/xl/workbook.xml" {
+				assert.Equal(t, expected, o.ContentType)
+			}
+		}
+	}
+	// Test write the workbook which contains VBA project without file path with unsupported charset content types
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.Write(io.Discard), "XML syntax error on line 1: invalid UTF-8")
 	// Test add VBA with unsupported charset workbook relationships
 	f.Relationships.Delete(defaultXMLPathWorkbookRels)
 	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.AddVBAProject(file), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetVBAProjectModules(t *testing.T) {
+	f := NewFile()
+	// Test get VBA project modules from the workbook without VBA project
+	modules, err := f.GetVBAProjectModules()
+	assert.NoError(t, err)
+	assert.Empty(t, modules)
+	assert.NoError(t, f.checkVBAMacro("Module1.Button1_Click"))
+	file, err := os.ReadFile(filepath.Join("test", "vbaProject.bin"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddVBAProject(file))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetVBAProjectModules.xlsm")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestGetVBAProjectModules.xlsm"))
+	assert.NoError(t, err)
+	modules, err = f.GetVBAProjectModules()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ThisWorkbook", "Sheet1", "ThisWorkbook1", "Module1"}, modules)
+	// Test add form control and shape with macro
+	for _, macro := range []string{"Button1_Click", "Module1.Button1_Click", "module1.Button1_Click", "'Book1.xlsm'!Module2.Button1_Click"} {
+		assert.NoError(t, f.AddFormControl("Sheet1", FormControl{Cell: "A1", Type: FormControlButton, Macro: macro}))
+	}
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "C1", Type: "rect", Macro: "Sheet1.Shape1_Click"}))
+	// Test add form control and shape with not exist VBA module
+	assert.Equal(t, newNoExistVBAModuleError("Module2"), f.AddFormControl("Sheet1", FormControl{Cell: "A1", Type: FormControlButton, Macro: "Module2.Button1_Click"}))
+	assert.Equal(t, newNoExistVBAModuleError("Module2"), f.AddShape("Sheet1", &Shape{Cell: "C1", Type: "rect", Macro: "Module2.Shape1_Click"}))
+	assert.NoError(t, f.Close())
+
+	// Test get VBA project modules with invalid VBA project
+	f = NewFile()
+	assert.NoError(t, f.AddVBAProject(oleIdentifier))
+	modules, err = f.GetVBAProjectModules()
+	assert.Equal(t, ErrAddVBAProject, err)
+	assert.Empty(t, modules)
+	assert.Equal(t, ErrAddVBAProject, f.checkVBAMacro("Module1.Button1_Click"))
+	// Test get VBA project path with unsupported charset workbook relationships
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.Empty(t, f.getVBAProjectPath())
+	assert.NoError(t, f.Close())
+}
+
 func TestContentTypesReader(t *testing.T) {
 	// Test unsupported charset
 	f := NewFile()
@@ -14,14 +14,42 @@ package excelize
 import (
 	"archive/zip"
 	"bytes"
+	"compress/flate"
 	"encoding/xml"
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
+	"sort"
 	"sync"
+	"time"
 )
 
+// CompressionLevel is the type of the zip compression level for saving the
+// spreadsheet.
+type CompressionLevel byte
+
+// This section defines the currently supported zip compression level types
+// enumeration for saving the spreadsheet.
+const (
+	CompressionDefault CompressionLevel = iota
+	CompressionNone
+	CompressionBestSpeed
+	CompressionBestSize
+)
+
+// compressionLevels maps the compression level types to the deflate
+// compression levels.
+var compressionLevels = map[CompressionLevel]int{
+	CompressionDefault:   flate.DefaultCompression,
+	CompressionNone:      flate.NoCompression,
+	CompressionBestSpeed: flate.BestSpeed,
+	CompressionBestSize:  flate.BestCompression,
+}
+
+// deterministicModified defined the fixed modification time of the zip
+// entries for saving the spreadsheet in deterministic mode.
+var deterministicModified = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
 // NewFile provides a function to create new file by default template.
 // For example:
 //
@@ -71,8 +99,8 @@ func (f *File) SaveAs(name string, opts ...Options) error {
 		return ErrMaxFilePathLength
 	}
 	f.Path = name
-	if _, ok := supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]; !ok {
-		return ErrWorkbookFileFormat
+	if _, err := f.checkWorkbookFileFormat(f.Path); err != nil {
+		return err
 	}
 	file, err := os.OpenFile(filepath.Clean(name), os.O_WRONLY|os.O_TRUNC|os.O_CREATE, os.ModePerm)
 	if err != nil {
@@ -114,13 +142,15 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 		f.options = &opts[i]
 	}
 	if len(f.Path) != 0 {
-		contentType, ok := supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]
-		if !ok {
-			return 0, ErrWorkbookFileFormat
+		contentType, err := f.checkWorkbookFileFormat(f.Path)
+		if err != nil {
+			return 0, err
 		}
 		if err := f.setContentTypePartProjectExtensions(contentType); err != nil {
 			return 0, err
 		}
+	} else if err := f.setContentTypePartMacroExtensions(); err != nil {
+		return 0, err
 	}
 	if f.options != nil && f.options.Password != "" {
 		buf, err := f.WriteToBuffer()
@@ -139,7 +169,10 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 // and it allocates space in memory. Be careful when the file size is large.
 func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 	buf := new(bytes.Buffer)
-	zw := zip.NewWriter(buf)
+	zw, err := f.newZipWriter(buf)
+	if err != nil {
+		return buf, err
+	}
 
 	if err := f.writeToZip(zw); err != nil {
 		return buf, zw.Close()
@@ -162,7 +195,10 @@ func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 
 // writeDirectToWriter provides a function to write to io.Writer.
 func (f *File) writeDirectToWriter(w io.Writer) error {
-	zw := zip.NewWriter(w)
+	zw, err := f.newZipWriter(w)
+	if err != nil {
+		return err
+	}
 	if err := f.writeToZip(zw); err != nil {
 		_ = zw.Close()
 		return err
@@ -170,6 +206,56 @@ func (f *File) writeDirectToWriter(w io.Writer) error {
 	return zw.Close()
 }
 
+// newZipWriter provides a function to create zip writer by given writer with
+// the compression level in the options.
+func (f *File) newZipWriter(w io.Writer) (*zip.Writer, error) {
+	zw := zip.NewWriter(w)
+	if f.options == nil || f.options.CompressionLevel == CompressionDefault {
+		return zw, nil
+	}
+	level, ok := compressionLevels[f.options.CompressionLevel]
+	if !ok {
+		return zw, ErrCompressionLevel
+	}
+	zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(out, level)
+	})
+	return zw, nil
+}
+
+// createZipEntry provides a function to add a file to the zip writer by given
+// path. The modification time of the file will be fixed in deterministic
+// mode.
+func (f *File) createZipEntry(zw *zip.Writer, path string) (io.Writer, error) {
+	if f.options != nil && f.options.Deterministic {
+		return zw.CreateHeader(&zip.FileHeader{Name: path, Method: zip.Deflate, Modified: deterministicModified})
+	}
+	return zw.Create(path)
+}
+
+// rangeZipEntries provides a function to call given function for each entry
+// of the given map with the path of the part as the key. The entries will be
+// ranged in the order of the path in deterministic mode.
+func (f *File) rangeZipEntries(m *sync.Map, fn func(path string, value interface{}) bool) {
+	if f.options == nil || !f.options.Deterministic {
+		m.Range(func(k, v interface{}) bool {
+			return fn(k.(string), v)
+		})
+		return
+	}
+	var paths []string
+	m.Range(func(k, v interface{}) bool {
+		paths = append(paths, k.(string))
+		return true
+	})
+	sort.Strings(paths)
+	for _, path := range paths {
+		if v, ok := m.Load(path); ok && !fn(path, v) {
+			return
+		}
+	}
+}
+
 // writeToZip provides a function to write to zip.Writer
 func (f *File) writeToZip(zw *zip.Writer) error {
 	f.calcChainWriter()
@@ -178,15 +264,24 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 	f.drawingsWriter()
 	f.vmlDrawingWriter()
 	f.workBookWriter()
-	f.workSheetWriter()
+	written, err := f.workSheetWriter(zw)
+	if err != nil {
+		return err
+	}
 	f.relsWriter()
 	_ = f.sharedStringsLoader()
 	f.sharedStringsWriter()
 	f.styleSheetWriter()
 	f.themeWriter()
 
-	for path, stream := range f.streams {
-		fi, err := zw.Create(path)
+	streams := make([]string, 0, len(f.streams))
+	for path := range f.streams {
+		streams = append(streams, path)
+	}
+	sort.Strings(streams)
+	for _, path := range streams {
+		stream := f.streams[path]
+		fi, err := f.createZipEntry(zw, path)
 		if err != nil {
 			return err
 		}
@@ -198,32 +293,61 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 		if _, err = io.Copy(fi, from); err != nil {
 			return err
 		}
+		written[path] = struct{}{}
 	}
-	var err error
-	f.Pkg.Range(func(path, content interface{}) bool {
-		if err != nil {
-			return false
-		}
-		if _, ok := f.streams[path.(string)]; ok {
-			return true
+	f.rangeZipEntries(&f.Pkg, func(path string, content interface{}) bool {
+		if _, ok := written[path]; ok || err != nil {
+			return err == nil
 		}
 		var fi io.Writer
-		if fi, err = zw.Create(path.(string)); err != nil {
+		if fi, err = f.createZipEntry(zw, path); err != nil {
 			return false
 		}
 		_, err = fi.Write(content.([]byte))
+		written[path] = struct{}{}
 		return true
 	})
-	f.tempFiles.Range(func(path, content interface{}) bool {
-		if _, ok := f.Pkg.Load(path); ok {
-			return true
+	f.rangeZipEntries(&f.lazyParts, func(path string, zipFile interface{}) bool {
+		if _, ok := written[path]; ok || err != nil {
+			return err == nil
 		}
-		var fi io.Writer
-		if fi, err = zw.Create(path.(string)); err != nil {
-			return false
+		err = f.copyToZip(zw, path, func() (io.ReadCloser, error) {
+			return zipFile.(*zip.File).Open()
+		})
+		written[path] = struct{}{}
+		return err == nil
+	})
+	f.rangeZipEntries(&f.tempFiles, func(path string, content interface{}) bool {
+		if _, ok := written[path]; ok || err != nil {
+			return err == nil
 		}
-		_, err = fi.Write(f.readBytes(path.(string)))
-		return true
+		err = f.copyToZip(zw, path, func() (io.ReadCloser, error) {
+			file, err := os.Open(content.(string))
+			if err != nil {
+				return io.NopCloser(bytes.NewReader(nil)), nil
+			}
+			return file, nil
+		})
+		return err == nil
 	})
 	return err
 }
+
+// copyToZip provides a function to copy the content from the given opener
+// into the zip entry by given path, without reading the whole content into
+// memory.
+func (f *File) copyToZip(zw *zip.Writer, path string, open func() (io.ReadCloser, error)) error {
+	fi, err := f.createZipEntry(zw, path)
+	if err != nil {
+		return err
+	}
+	rc, err := open()
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(fi, rc); err != nil {
+		_ = rc.Close()
+		return err
+	}
+	return rc.Close()
+}
@@ -1,8 +1,10 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bufio"
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -90,6 +92,58 @@ func TestWriteTo(t *testing.T) {
 	}
 }
 
+func TestWriteToOptions(t *testing.T) {
+	newWorkbook := func() *File {
+		f := NewFile()
+		for _, sheet := range []string{"Sheet2", "Sheet3"} {
+			_, err := f.NewSheet(sheet)
+			assert.NoError(t, err)
+		}
+		for row := 1; row <= 100; row++ {
+			assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{"This is test data", row}))
+		}
+		return f
+	}
+	// Test save the spreadsheet in deterministic mode
+	var results [][]byte
+	for i := 0; i < 3; i++ {
+		f := newWorkbook()
+		buf := new(bytes.Buffer)
+		assert.NoError(t, f.Write(buf, Options{Deterministic: true}))
+		results = append(results, buf.Bytes())
+		assert.NoError(t, f.Close())
+	}
+	assert.Equal(t, results[0], results[1])
+	assert.Equal(t, results[0], results[2])
+	zr, err := zip.NewReader(bytes.NewReader(results[0]), int64(len(results[0])))
+	assert.NoError(t, err)
+	for _, file := range zr.File {
+		assert.True(t, file.Modified.Equal(deterministicModified))
+	}
+	// Test save the spreadsheet with compression level
+	sizes := map[CompressionLevel]int{}
+	for _, level := range []CompressionLevel{CompressionNone, CompressionBestSpeed, CompressionBestSize} {
+		f := newWorkbook()
+		buf := new(bytes.Buffer)
+		assert.NoError(t, f.Write(buf, Options{CompressionLevel: level}))
+		sizes[level] = buf.Len()
+		f, err = OpenReader(buf)
+		assert.NoError(t, err)
+		val, err := f.GetCellValue("Sheet1", "B100")
+		assert.NoError(t, err)
+		assert.Equal(t, "100", val)
+		assert.NoError(t, f.Close())
+	}
+	assert.Greater(t, sizes[CompressionNone], sizes[CompressionBestSpeed])
+	assert.GreaterOrEqual(t, sizes[CompressionBestSpeed], sizes[CompressionBestSize])
+	// Test save the spreadsheet with unsupported compression level
+	f := newWorkbook()
+	assert.Equal(t, ErrCompressionLevel, f.Write(new(bytes.Buffer), Options{CompressionLevel: 10}))
+	_, err = f.WriteToBuffer()
+	assert.Equal(t, ErrCompressionLevel, err)
+	assert.NoError(t, f.Close())
+}
+
 func TestClose(t *testing.T) {
 	f := NewFile()
 	f.tempFiles.Store("/d/", "/d/")
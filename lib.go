@@ -15,6 +15,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"container/list"
+	"crypto/rand"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -24,6 +25,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // ReadZipReader extract spreadsheet with given options.
@@ -59,6 +61,10 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 		}
 		if strings.HasPrefix(strings.ToLower(fileName), "xl/worksheets/sheet") {
 			worksheets++
+			if (f.options.LazyLoad || len(f.options.SheetsToLoad) > 0) && !v.FileInfo().IsDir() {
+				f.lazyParts.Store(fileName, v)
+				continue
+			}
 			if fileSize > f.options.UnzipXMLSizeLimit && !v.FileInfo().IsDir() {
 				tempFile, err := f.unzipToTemp(v)
 				if tempFile != "" {
@@ -104,9 +110,41 @@ func (f *File) readXML(name string) []byte {
 	if content, ok := f.streams[name]; ok {
 		return content.rawData.buf.Bytes()
 	}
+	if _, ok := f.lazyParts.Load(name); ok && f.loadLazyPart(name) == nil {
+		if content, _ := f.Pkg.Load(name); content != nil {
+			return content.([]byte)
+		}
+	}
 	return []byte{}
 }
 
+// loadLazyPart provides a function to extract the lazy loading part by given
+// path in the zip. The part will be extracted to the system temporary
+// directory when its size is over the UnzipXMLSizeLimit.
+func (f *File) loadLazyPart(name string) error {
+	zipFile, ok := f.lazyParts.Load(name)
+	if !ok {
+		return nil
+	}
+	if zf := zipFile.(*zip.File); zf.FileInfo().Size() > f.options.UnzipXMLSizeLimit {
+		tempFile, err := f.unzipToTemp(zf)
+		if tempFile != "" {
+			f.tempFiles.Store(name, tempFile)
+		}
+		if err != nil {
+			return err
+		}
+	} else {
+		content, err := readFile(zf)
+		if err != nil {
+			return err
+		}
+		f.Pkg.Store(name, content)
+	}
+	f.lazyParts.Delete(name)
+	return nil
+}
+
 // readBytes read file as bytes by given path.
 func (f *File) readBytes(name string) []byte {
 	content := f.readXML(name)
@@ -437,6 +475,15 @@ func float64Ptr(f float64) *float64 { return &f }
 // stringPtr returns a pointer to a string with the given value.
 func stringPtr(s string) *string { return &s }
 
+// newGUID provides a function to generate a random (version 4) GUID in the
+// registry format, such as {5F5A5D0B-0E2F-4C4B-9A1E-3D6B2C1F0A9E}.
+func newGUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6], b[8] = b[6]&0x0F|0x40, b[8]&0x3F|0x80
+	return fmt.Sprintf("{%X-%X-%X-%X-%X}", b[:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
 // Value extracts string data type text from a attribute value.
 func (avb *attrValString) Value() string {
 	if avb != nil && avb.Val != nil {
@@ -681,11 +728,17 @@ func getXMLNamespace(space string, attr []xml.Attr) string {
 // attribute by the given component part path and XML content.
 func (f *File) replaceNameSpaceBytes(path string, contentMarshal []byte) []byte {
 	sourceXmlns := []byte(`xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	return bytesReplace(contentMarshal, sourceXmlns, f.getNameSpaceBytes(path), -1)
+}
+
+// getNameSpaceBytes provides a function to get the XML root element attribute
+// by the given component part path.
+func (f *File) getNameSpaceBytes(path string) []byte {
 	targetXmlns := []byte(templateNamespaceIDMap)
 	if attrs, ok := f.xmlAttr.Load(path); ok {
 		targetXmlns = []byte(genXMLNamespace(attrs.([]xml.Attr)))
 	}
-	return bytesReplace(contentMarshal, sourceXmlns, bytes.ReplaceAll(targetXmlns, []byte(" mc:Ignorable=\"r\""), []byte{}), -1)
+	return bytes.ReplaceAll(targetXmlns, []byte(" mc:Ignorable=\"r\""), []byte{})
 }
 
 // addNameSpaces provides a function to add an XML attribute by the given
@@ -859,6 +912,105 @@ func continuedFraction(n float64, i int64, limit int64, prec float64) *big.Rat {
 	return res
 }
 
+// lruCache defined a fixed capacity least recently used cache for the values
+// indexed by integer keys, such as shared string items which read from the
+// system temporary file.
+type lruCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[int]*list.Element
+	order    *list.List
+}
+
+// lruCacheEntry defined an entry of the least recently used cache.
+type lruCacheEntry struct {
+	key   int
+	value string
+}
+
+// newLRUCache create a new least recently used cache by given capacity.
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{capacity: capacity, items: make(map[int]*list.Element), order: list.New()}
+}
+
+// get provides a function to get the cached value by given key, and mark the
+// entry as the most recently used.
+func (c *lruCache) get(key int) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*lruCacheEntry).value, true
+	}
+	return "", false
+}
+
+// set provides a function to store the value by given key, and evict the
+// least recently used entry if the cache is full.
+func (c *lruCache) set(key int, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		e.Value.(*lruCacheEntry).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+	c.items[key] = c.order.PushFront(&lruCacheEntry{key: key, value: value})
+	if c.order.Len() > c.capacity {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.items, e.Value.(*lruCacheEntry).key)
+	}
+}
+
+// replaceWriter defined a writer that replaces the source bytes with the
+// target bytes in the data written to the underlying writer, the matched
+// source bytes may span across multiple writes.
+type replaceWriter struct {
+	w              io.Writer
+	source, target []byte
+	buf            []byte
+}
+
+// newReplaceWriter create a new replace writer by given underlying writer,
+// source bytes and target bytes.
+func newReplaceWriter(w io.Writer, source, target []byte) *replaceWriter {
+	return &replaceWriter{w: w, source: source, target: target}
+}
+
+// Write implements io.Writer, it replaces the source bytes and keeps the tail
+// of the data which may be the prefix of the source bytes in buffer.
+func (rw *replaceWriter) Write(p []byte) (int, error) {
+	rw.buf = append(rw.buf, p...)
+	for {
+		idx := bytes.Index(rw.buf, rw.source)
+		if idx < 0 {
+			break
+		}
+		if _, err := rw.w.Write(rw.buf[:idx]); err != nil {
+			return 0, err
+		}
+		if _, err := rw.w.Write(rw.target); err != nil {
+			return 0, err
+		}
+		rw.buf = rw.buf[idx+len(rw.source):]
+	}
+	if keep := len(rw.source) - 1; len(rw.buf) > keep {
+		if _, err := rw.w.Write(rw.buf[:len(rw.buf)-keep]); err != nil {
+			return 0, err
+		}
+		rw.buf = append(rw.buf[:0], rw.buf[len(rw.buf)-keep:]...)
+	}
+	return len(p), nil
+}
+
+// Flush writes the buffered data to the underlying writer.
+func (rw *replaceWriter) Flush() error {
+	_, err := rw.w.Write(rw.buf)
+	rw.buf = rw.buf[:0]
+	return err
+}
+
 // Stack defined an abstract data type that serves as a collection of elements.
 type Stack struct {
 	list *list.List
@@ -4,6 +4,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -307,6 +308,63 @@ func TestStack(t *testing.T) {
 	assert.Equal(t, s.Pop(), nil)
 }
 
+func TestLRUCache(t *testing.T) {
+	c := newLRUCache(2)
+	c.set(1, "a")
+	c.set(2, "b")
+	val, ok := c.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "a", val)
+	// Test evict the least recently used entry
+	c.set(3, "c")
+	_, ok = c.get(2)
+	assert.False(t, ok)
+	// Test update the value of the exists entry
+	c.set(1, "d")
+	val, ok = c.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "d", val)
+	assert.Equal(t, 2, c.order.Len())
+}
+
+// errorWriter defined a writer that returns an error after given number of
+// successful writes.
+type errorWriter struct {
+	n int
+}
+
+var errWrite = errors.New("write error")
+
+func (w *errorWriter) Write(p []byte) (int, error) {
+	if w.n <= 0 {
+		return 0, errWrite
+	}
+	w.n--
+	return len(p), nil
+}
+
+func TestReplaceWriter(t *testing.T) {
+	var buf bytes.Buffer
+	rw := newReplaceWriter(&buf, []byte("abc"), []byte("x"))
+	for _, p := range []string{"1a", "bc2ab", "c3a", "b"} {
+		n, err := rw.Write([]byte(p))
+		assert.NoError(t, err)
+		assert.Equal(t, len(p), n)
+	}
+	assert.NoError(t, rw.Flush())
+	assert.Equal(t, "1x2x3ab", buf.String())
+	// Test replace writer with failed underlying writer
+	for i := 0; i < 3; i++ {
+		rw = newReplaceWriter(&errorWriter{n: i}, []byte("abc"), []byte("x"))
+		_, err := rw.Write([]byte("1abc2345"))
+		assert.Equal(t, errWrite, err)
+	}
+	rw = newReplaceWriter(&errorWriter{}, []byte("abc"), []byte("x"))
+	_, err := rw.Write([]byte("a"))
+	assert.NoError(t, err)
+	assert.Equal(t, errWrite, rw.Flush())
+}
+
 func TestGenXMLNamespace(t *testing.T) {
 	assert.Equal(t, genXMLNamespace([]xml.Attr{
 		{Name: xml.Name{Space: NameSpaceXML, Local: "space"}, Value: "preserve"},
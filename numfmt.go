@@ -30,6 +30,13 @@ type languageInfo struct {
 	localMonth                           func(t time.Time, abbr int) string
 }
 
+// cultureInfo defined the default language ID, decimal and thousands
+// separators and the localized built-in number format code of the culture.
+type cultureInfo struct {
+	localCode, decimalSep, thousandsSep string
+	numFmt                              map[int]string
+}
+
 // numberFormat directly maps the number format parser runtime required
 // fields.
 type numberFormat struct {
@@ -57,6 +64,8 @@ const (
 	CultureNameUnknown CultureName = iota
 	CultureNameEnUS
 	CultureNameZhCN
+	CultureNameDeDE
+	CultureNameFrFR
 )
 
 var (
@@ -718,6 +727,34 @@ var (
 		nfp.TokenTypeDateTimes,
 		nfp.TokenTypeElapsedDateTimes,
 	}
+	// euroNumFmt defined the built-in currency and accounting number format
+	// code of the culture which use the euro currency.
+	euroNumFmt = map[int]string{
+		5:  "#,##0 \"€\";-#,##0 \"€\"",
+		6:  "#,##0 \"€\";[Red]-#,##0 \"€\"",
+		7:  "#,##0.00 \"€\";-#,##0.00 \"€\"",
+		8:  "#,##0.00 \"€\";[Red]-#,##0.00 \"€\"",
+		42: "_-* #,##0 \"€\"_-;-* #,##0 \"€\"_-;_-* \"-\" \"€\"_-;_-@_-",
+		44: "_-* #,##0.00 \"€\"_-;-* #,##0.00 \"€\"_-;_-* \"-\"?? \"€\"_-;_-@_-",
+	}
+	// supportedCultureInfo directly maps the supported culture names and the
+	// locale settings for apply number format.
+	supportedCultureInfo = map[CultureName]cultureInfo{
+		CultureNameEnUS: {decimalSep: ".", thousandsSep: ",", numFmt: map[int]string{
+			5: "\"$\"#,##0_);\\(\"$\"#,##0\\)",
+			6: "\"$\"#,##0_);[Red]\\(\"$\"#,##0\\)",
+			7: "\"$\"#,##0.00_);\\(\"$\"#,##0.00\\)",
+			8: "\"$\"#,##0.00_);[Red]\\(\"$\"#,##0.00\\)",
+		}},
+		CultureNameZhCN: {localCode: "804", decimalSep: ".", thousandsSep: ",", numFmt: map[int]string{
+			5: "\"¥\"#,##0;\"¥\"\\-#,##0",
+			6: "\"¥\"#,##0;[Red]\"¥\"\\-#,##0",
+			7: "\"¥\"#,##0.00;\"¥\"\\-#,##0.00",
+			8: "\"¥\"#,##0.00;[Red]\"¥\"\\-#,##0.00",
+		}},
+		CultureNameDeDE: {localCode: "407", decimalSep: ",", thousandsSep: ".", numFmt: euroNumFmt},
+		CultureNameFrFR: {localCode: "40C", decimalSep: ",", thousandsSep: "\u00a0", numFmt: euroNumFmt},
+	}
 	// supportedLanguageInfo directly maps the supported language ID and tags.
 	supportedLanguageInfo = map[string]languageInfo{
 		"36":   {tags: []string{"af"}, localMonth: localMonthsNameAfrikaans, apFmt: apFmtAfrikaans, weekdayNames: weekdayNamesAfrikaans, weekdayNamesAbbr: weekdayNamesAfrikaansAbbr},
@@ -4695,6 +4732,9 @@ func (f *File) langNumFmtFuncZhCN(numFmtID int) string {
 // getBuiltInNumFmtCode convert number format index to number format code with
 // specified locale and language.
 func (f *File) getBuiltInNumFmtCode(numFmtID int) (string, bool) {
+	if fmtCode, ok := supportedCultureInfo[f.options.CultureInfo].numFmt[numFmtID]; ok {
+		return fmtCode, true
+	}
 	if fmtCode, ok := builtInNumFmt[numFmtID]; ok {
 		return fmtCode, true
 	}
@@ -4726,6 +4766,9 @@ func (nf *numberFormat) prepareNumberic(value string) {
 func format(value, numFmt string, date1904 bool, cellType CellType, opts *Options) string {
 	p := nfp.NumberFormatParser()
 	nf := numberFormat{opts: opts, section: p.Parse(numFmt), value: value, date1904: date1904, cellType: cellType}
+	if opts != nil {
+		nf.localCode = supportedCultureInfo[opts.CultureInfo].localCode
+	}
 	nf.number, nf.valueSectionType = nf.getValueSectionType(value)
 	nf.prepareNumberic(value)
 	for i, section := range nf.section {
@@ -4748,6 +4791,20 @@ func format(value, numFmt string, date1904 bool, cellType CellType, opts *Option
 	return value
 }
 
+// isDateTimeNumFmt provides a function to check if the given number format
+// code contains date and time tokens.
+func isDateTimeNumFmt(numFmt string) bool {
+	p := nfp.NumberFormatParser()
+	for _, section := range p.Parse(numFmt) {
+		for _, token := range section.Items {
+			if token.TType == nfp.TokenTypeDateTimes || token.TType == nfp.TokenTypeElapsedDateTimes {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // getNumberPartLen returns the length of integer and fraction parts for the
 // numeric.
 func getNumberPartLen(n float64) (int, int) {
@@ -4954,7 +5011,7 @@ func (nf *numberFormat) numberHandler() string {
 	}
 	if isNum, precision, decimal := isNumeric(nf.value); isNum {
 		if precision > 15 && intLen+fracLen > 15 {
-			return nf.printNumberLiteral(nf.printBigNumber(decimal, fracLen))
+			return nf.printNumberLiteral(nf.localizeNumber(nf.printBigNumber(decimal, fracLen)))
 		}
 	}
 	paddingLen := intLen + fracLen
@@ -4978,7 +5035,20 @@ func (nf *numberFormat) numberHandler() string {
 	if result = fmt.Sprintf(fmtCode, math.Abs(num)); nf.useCommaSep {
 		result = printCommaSep(result)
 	}
-	return nf.printNumberLiteral(result)
+	return nf.printNumberLiteral(nf.localizeNumber(result))
+}
+
+// localizeNumber provides a function to replace the decimal and thousands
+// separators of the formatted number with the separators of the culture.
+func (nf *numberFormat) localizeNumber(text string) string {
+	if nf.opts == nil {
+		return text
+	}
+	info, ok := supportedCultureInfo[nf.opts.CultureInfo]
+	if !ok {
+		return text
+	}
+	return strings.NewReplacer(".", info.decimalSep, ",", info.thousandsSep).Replace(text)
 }
 
 // dateTimeHandler handling data and time number format expression for a
@@ -5006,7 +5076,7 @@ func (nf *numberFormat) dateTimeHandler() string {
 			continue
 		}
 		if token.TType == nfp.TokenTypeDecimalPoint {
-			nf.result += "."
+			nf.result += nf.localizeNumber(".")
 		}
 		if token.TType == nfp.TokenTypeSwitchArgument {
 			nf.switchArgument = token.TValue
@@ -5081,10 +5151,13 @@ func (nf *numberFormat) currencyLanguageHandler(token nfp.Token) (bool, error) {
 				}
 				part.Token.TValue = "409"
 			}
-			if _, ok := supportedLanguageInfo[strings.ToUpper(part.Token.TValue)]; !ok {
-				return false, ErrUnsupportedNumberFormat
+			localCode, ok := strings.ToUpper(part.Token.TValue), false
+			if _, ok = supportedLanguageInfo[localCode]; !ok {
+				if localCode, ok = getLanguageIDByTag(part.Token.TValue); !ok {
+					return false, ErrUnsupportedNumberFormat
+				}
 			}
-			nf.localCode = strings.ToUpper(part.Token.TValue)
+			nf.localCode = localCode
 		}
 		if part.Token.TType == nfp.TokenSubTypeCurrencyString {
 			nf.currencyString = part.Token.TValue
@@ -5093,6 +5166,19 @@ func (nf *numberFormat) currencyLanguageHandler(token nfp.Token) (bool, error) {
 	return false, nil
 }
 
+// getLanguageIDByTag returns the supported language ID by given language tag,
+// such as "de-DE".
+func getLanguageIDByTag(tag string) (string, bool) {
+	for localCode, info := range supportedLanguageInfo {
+		for _, t := range info.tags {
+			if strings.EqualFold(t, tag) {
+				return localCode, true
+			}
+		}
+	}
+	return "", false
+}
+
 // localAmPm return AM/PM name by supported language ID.
 func (nf *numberFormat) localAmPm(ap string) string {
 	if languageInfo, ok := supportedLanguageInfo[nf.localCode]; ok {
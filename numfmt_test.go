@@ -3587,6 +3587,48 @@ func TestNumFmt(t *testing.T) {
 		})
 		assert.Equal(t, item[2], result, item)
 	}
+	// Test format number with the separators and language of the culture
+	for culture, items := range map[CultureName][][]string{
+		CultureNameDeDE: {
+			{"1234.5", "#,##0.00", "1.234,50"},
+			{"1234.5", "0.00E+00", "1,23E+03"},
+			{"44562.5", "dddd, d mmmm yyyy", "Samstag, 1 Januar 2022"},
+			{"44562.5", "ss.000", "00,000"},
+			{"44562.5", "[$-409]mmmm", "January"},
+		},
+		CultureNameFrFR: {
+			{"1234.5", "#,##0.00", "1\u00a0234,50"},
+			{"44562.5", "mmmm", "janvier"},
+		},
+		CultureNameEnUS: {
+			{"1234.5", "#,##0.00", "1,234.50"},
+			{"44562.5", "[$-de-DE]mmmm", "Januar"},
+		},
+	} {
+		for _, item := range items {
+			result := format(item[0], item[1], false, CellTypeNumber, &Options{CultureInfo: culture})
+			assert.Equal(t, item[2], result, item)
+		}
+	}
+	// Test format number with the built-in currency number format of the culture
+	for culture, expected := range map[CultureName][]string{
+		CultureNameUnknown: {"-1234.5", "-1234.5", "$(1,234.50)"},
+		CultureNameEnUS:    {"($1,234)", "($1,234.50)", "$(1,234.50)"},
+		CultureNameZhCN:    {"¥-1,234", "¥-1,234.50", "$(1,234.50)"},
+		CultureNameDeDE:    {"-1.234 €", "-1.234,50 €", "1.234,50 €"},
+	} {
+		f := NewFile(Options{CultureInfo: culture})
+		styleSheet, err := f.stylesReader()
+		assert.NoError(t, err)
+		for i, numFmtID := range []int{5, 7, 44} {
+			styleSheet.CellXfs.Xf = append(styleSheet.CellXfs.Xf, xlsxXf{NumFmtID: intPtr(numFmtID)})
+			assert.NoError(t, f.SetCellValue("Sheet1", "A1", -1234.5))
+			assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", len(styleSheet.CellXfs.Xf)-1))
+			result, err := f.GetCellValue("Sheet1", "A1")
+			assert.NoError(t, err)
+			assert.Equal(t, expected[i], result, culture, numFmtID)
+		}
+	}
 	// Test format number with string data type cell value
 	for _, cellType := range []CellType{CellTypeSharedString, CellTypeInlineString} {
 		for _, item := range [][]string{
@@ -0,0 +1,329 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// oleObjectPackageCLSID defined the class identifier of the OLE package
+// object {0003000C-0000-0000-C000-000000000046}.
+var oleObjectPackageCLSID = []byte{0x0C, 0x00, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}
+
+// AddOLEObject provides the method to embed a file as an OLE (Object Linking
+// and Embedding) package object in a worksheet by given worksheet name and
+// OLE object settings. Any kind of file such as PDF, DOCX or another XLSX
+// could be embedded, and the embedded file will be opened by the associated
+// application when double-clicking on the object in the spreadsheet
+// application. For example, embed a PDF file at cell B2 of Sheet1:
+//
+//	file, err := os.ReadFile("report.pdf")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddOLEObject("Sheet1", excelize.OLEObject{
+//	    Cell:     "B2",
+//	    FileName: "report.pdf",
+//	    File:     file,
+//	    Caption:  "Annual Report",
+//	})
+//
+// The "FileName" and "File" parameters are required, which specifies the file
+// name and the content of the embedded file.
+//
+// The optional parameter "Caption" specifies the caption of the object which
+// will be displayed under the icon, the default value of that is the file
+// name.
+//
+// The optional parameter "Icon" specifies the picture to be displayed as the
+// icon of the object, supported image types: BMP, EMF, EMZ, GIF, JPEG, JPG,
+// PNG, SVG, TIF, TIFF, WMF, and WMZ. A default document icon with caption
+// will be generated if the icon was not specified.
+//
+// The optional parameters "Width" and "Height" specifies the size of the
+// object in pixels, the default size is the size of the icon.
+//
+// The optional parameter "Format" specifies the format of the object, the
+// "OffsetX", "OffsetY", "PrintObject" and "Positioning" settings are
+// supported.
+func (f *File) AddOLEObject(sheet string, opts OLEObject) error {
+	if opts.FileName == "" || len(opts.File) == 0 {
+		return ErrParameterInvalid
+	}
+	col, row, err := CellNameToCoordinates(opts.Cell)
+	if err != nil {
+		return err
+	}
+	if opts.Format.Positioning != "" && inStrSlice(supportedPositioning, opts.Format.Positioning, true) == -1 {
+		return ErrParameterInvalid
+	}
+	if opts.Caption == "" {
+		opts.Caption = opts.FileName
+	}
+	icon, iconWidth, iconHeight := newOLEObjectIcon(opts.Caption)
+	iconExt := ".emf"
+	if opts.Icon != nil {
+		var ok bool
+		if iconExt, ok = supportedImageTypes[strings.ToLower(opts.Icon.Extension)]; !ok || len(opts.Icon.File) == 0 {
+			return ErrImgExt
+		}
+		icon = opts.Icon.File
+		if img, _, err := image.DecodeConfig(bytes.NewReader(icon)); err == nil {
+			iconWidth, iconHeight = img.Width, img.Height
+		}
+	}
+	if opts.Width == 0 {
+		opts.Width = uint(iconWidth)
+	}
+	if opts.Height == 0 {
+		opts.Height = uint(iconHeight)
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	// Add the embedded OLE package xl/embeddings/oleObject%d.bin
+	oleID := f.countOLEObjects() + 1
+	f.Pkg.Store("xl/embeddings/oleObject"+strconv.Itoa(oleID)+".bin", newOLEObjectPackage(opts.FileName, opts.File))
+	rID := f.addRels(sheetRels, SourceRelationshipOLEObject, "../embeddings/oleObject"+strconv.Itoa(oleID)+".bin", "")
+	// Add the icon of the object in the legacy VML drawing
+	vmlID := f.countVMLDrawing() + 1
+	drawingVML := "xl/drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
+	sheetRelationshipsDrawingVML := "../drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
+	if ws.LegacyDrawing != nil {
+		// The worksheet already has a VML relationships, use the relationships drawing ../drawings/vmlDrawing%d.vml.
+		sheetRelationshipsDrawingVML = f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
+		vmlID, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
+		drawingVML = strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
+	} else {
+		// Add first VML drawing for given sheet.
+		f.addSheetLegacyDrawing(sheet, f.addRels(sheetRels, SourceRelationshipDrawingVML, sheetRelationshipsDrawingVML, ""))
+	}
+	vml, err := f.pictureVMLReader(vmlID, drawingVML)
+	if err != nil {
+		return err
+	}
+	spID := nextVMLShapeID(vml, vmlID)
+	drawingVMLRels := "xl/drawings/_rels/vmlDrawing" + strconv.Itoa(vmlID) + ".vml.rels"
+	mediaStr := ".." + strings.TrimPrefix(f.addMedia(icon, iconExt), "xl")
+	imageID := f.addRels(drawingVMLRels, SourceRelationshipImage, mediaStr, "")
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row, opts.Format.OffsetX, opts.Format.OffsetY, int(opts.Width), int(opts.Height))
+	sp := encodeShape{
+		ImageData: &vImageData{RelID: "rId" + strconv.Itoa(imageID)},
+		ClientData: &xClientData{
+			ObjectType:    "Pict",
+			SizeWithCells: stringPtr(""),
+			Anchor:        fmt.Sprintf("%d, %d, %d, %d, %d, %d, %d, %d", colStart, opts.Format.OffsetX, rowStart, opts.Format.OffsetY, colEnd, x2, rowEnd, y2),
+			CF:            "Pict",
+			AutoPict:      stringPtr(""),
+		},
+	}
+	if opts.Format.PrintObject != nil && !*opts.Format.PrintObject {
+		sp.ClientData.PrintObject = "False"
+	}
+	if opts.Format.Positioning != "" {
+		idx := inStrSlice(supportedPositioning, opts.Format.Positioning, true)
+		sp.ClientData.MoveWithCells = []*string{stringPtr(""), nil, nil}[idx]
+		sp.ClientData.SizeWithCells = []*string{stringPtr(""), stringPtr(""), nil}[idx]
+	}
+	s, _ := xml.Marshal(sp)
+	vml.Shape = append(vml.Shape, xlsxShape{
+		ID:          "_x0000_s" + strconv.Itoa(spID),
+		Type:        "#_x0000_t75",
+		Style:       fmt.Sprintf("position:absolute;margin-left:0;margin-top:0;width:%gpt;height:%gpt;z-index:%d", float64(opts.Width)*0.75, float64(opts.Height)*0.75, len(vml.Shape)+1),
+		Filled:      "t",
+		FillColor:   "window [65]",
+		Stroked:     "t",
+		StrokeColor: "windowText [64]",
+		Val:         string(s[13 : len(s)-14]),
+	})
+	f.VMLDrawing[drawingVML] = vml
+	if ws.OleObjects == nil {
+		ws.OleObjects = &xlsxOleObjects{}
+	}
+	ws.OleObjects.OleObject = append(ws.OleObjects.OleObject, xlsxOleObject{
+		ProgID:   "Package",
+		DvAspect: "DVASPECT_ICON",
+		ShapeID:  spID,
+		RID:      "rId" + strconv.Itoa(rID),
+	})
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	if err = f.setContentTypePartImageExtensions(); err != nil {
+		return err
+	}
+	if err = f.setContentTypePartVMLExtensions(); err != nil {
+		return err
+	}
+	return f.addContentTypePart(oleID, "oleObject")
+}
+
+// countOLEObjects provides a function to get embedded OLE objects count
+// storage in the folder xl/embeddings.
+func (f *File) countOLEObjects() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/embeddings/oleObject") {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// newOLEObjectPackage provides a function to create the compound file of the
+// OLE package object by given file name and file content. The compound file
+// contains the \x01CompObj stream which specifies the class of the object,
+// and the \x01Ole10Native stream which stores the embedded file.
+func newOLEObjectPackage(fileName string, file []byte) []byte {
+	var native, data bytes.Buffer
+	write := func(buf *bytes.Buffer, values ...interface{}) {
+		for _, value := range values {
+			_ = binary.Write(buf, binary.LittleEndian, value)
+		}
+	}
+	// Type, label, source path, reserved, temporary path and native data
+	write(&data, uint16(2), []byte(fileName), uint8(0), []byte(fileName), uint8(0), uint32(0x00030000))
+	write(&data, uint32(len(fileName)+1), []byte(fileName), uint8(0))
+	write(&data, uint32(len(file)), file)
+	write(&native, uint32(data.Len()), data.Bytes())
+	compoundFile := &cfb{
+		paths:   []string{"Root Entry/"},
+		sectors: []sector{{name: "Root Entry", typeID: 5, clsID: oleObjectPackageCLSID}},
+	}
+	compoundFile.put("\x01CompObj", newCompObjStream(oleObjectPackageCLSID, "OLE Package", "Package"))
+	compoundFile.put("\x01Ole10Native", native.Bytes())
+	return compoundFile.write()
+}
+
+// newCompObjStream provides a function to create the \x01CompObj stream of
+// the compound file by given class identifier, user type and program ID of
+// the object.
+func newCompObjStream(clsID []byte, userType, progID string) []byte {
+	var compObj bytes.Buffer
+	write := func(values ...interface{}) {
+		for _, value := range values {
+			_ = binary.Write(&compObj, binary.LittleEndian, value)
+		}
+	}
+	// CompObjHeader, AnsiUserType, AnsiClipboardFormat, Reserved1 (ProgID),
+	// UnicodeMarker, UnicodeUserType, UnicodeClipboardFormat and Reserved2
+	write(uint32(0xFFFE0001), uint32(0x00000A03), uint32(0xFFFFFFFF), clsID)
+	write(uint32(len(userType)+1), []byte(userType), uint8(0), uint32(0))
+	write(uint32(len(progID)+1), []byte(progID), uint8(0))
+	write(uint32(0x71B239F4), uint32(0), uint32(0), uint32(0))
+	return compObj.Bytes()
+}
+
+// emfWriter is used to write the records of the image in the EMF (Enhanced
+// Metafile Format).
+type emfWriter struct {
+	records bytes.Buffer
+	count   uint32
+}
+
+// record provides a function to write an EMF record by given record type
+// and values.
+func (w *emfWriter) record(typeID uint32, values ...interface{}) {
+	var buf bytes.Buffer
+	for _, value := range values {
+		_ = binary.Write(&buf, binary.LittleEndian, value)
+	}
+	_ = binary.Write(&w.records, binary.LittleEndian, []uint32{typeID, uint32(buf.Len() + 8)})
+	w.records.Write(buf.Bytes())
+	w.count++
+}
+
+// poly provides a function to write an EMR_POLYGON16 or EMR_POLYLINE16
+// record by given record type and points.
+func (w *emfWriter) poly(typeID uint32, points ...int16) {
+	w.record(typeID, []int32{0, 0, -1, -1}, uint32(len(points)/2), points)
+}
+
+// font provides a function to write the EMR_EXTCREATEFONTINDIRECTW and
+// EMR_SELECTOBJECT records by given object handle, which creates and selects
+// the 12 pixels height Arial font.
+func (w *emfWriter) font(handle uint32) {
+	faceName := make([]uint16, 32)
+	copy(faceName, utf16.Encode([]rune("Arial")))
+	w.record(82, handle, []int32{-12, 0, 0, 0, 400}, []uint8{0, 0, 0, 1, 0, 0, 0, 0}, faceName)
+	w.record(37, handle)
+}
+
+// text provides a function to write the EMR_EXTTEXTOUTW record by given
+// text, reference point and the width of each character in pixels.
+func (w *emfWriter) text(text []uint16, x, y int32, charWidth uint32) {
+	str := make([]uint16, (len(text)+1)/2*2)
+	copy(str, text)
+	dx := make([]uint32, len(text))
+	for i := range dx {
+		dx[i] = charWidth
+	}
+	w.record(84, []int32{0, 0, -1, -1}, uint32(1), []float32{0, 0}, []int32{x, y},
+		uint32(len(text)), uint32(76), uint32(0), []int32{0, 0, -1, -1}, uint32(76+len(str)*2), str, dx)
+}
+
+// bytes provides a function to write the EMR_EOF record and returns the EMF
+// image data with header by given width and height in pixels, and the number
+// of the object handles.
+func (w *emfWriter) bytes(width, height int, handles uint16) []byte {
+	w.record(14, uint32(0), uint32(16), uint32(20))
+	var header bytes.Buffer
+	_ = binary.Write(&header, binary.LittleEndian, []uint32{1, 88})
+	_ = binary.Write(&header, binary.LittleEndian, []int32{0, 0, int32(width - 1), int32(height - 1), 0, 0, int32(width * 2540 / 96), int32(height * 2540 / 96)})
+	_ = binary.Write(&header, binary.LittleEndian, []uint32{0x464D4520, 0x00010000, uint32(88 + w.records.Len()), w.count + 1})
+	_ = binary.Write(&header, binary.LittleEndian, []uint16{handles, 0})
+	_ = binary.Write(&header, binary.LittleEndian, []uint32{0, 0, 0, 1920, 1080, 508, 286})
+	return append(header.Bytes(), w.records.Bytes()...)
+}
+
+// newOLEObjectIcon provides a function to create the default icon of the OLE
+// object in the EMF (Enhanced Metafile Format) by given caption, which draws
+// a document shape and the caption under the document. This function returns
+// the icon data and the width and height of the icon in pixels.
+func newOLEObjectIcon(caption string) ([]byte, int, int) {
+	text := utf16.Encode([]rune(caption))
+	width, height := 7*len(text)+16, 64
+	if width < 80 {
+		width = 80
+	}
+	var w emfWriter
+	x0, y0 := int16(width/2-16), int16(4)
+	x1, y1 := x0+32, y0+40
+	// EMR_SETBKMODE, EMR_CREATEBRUSHINDIRECT, EMR_SELECTOBJECT and draw the
+	// document with EMR_POLYGON16 and EMR_POLYLINE16
+	w.record(18, uint32(1))
+	w.record(39, uint32(1), uint32(0), uint32(0x00FFFFFF), uint32(0))
+	w.record(37, uint32(1))
+	w.record(37, uint32(0x80000007))
+	w.poly(86, x0, y0, x1-10, y0, x1, y0+10, x1, y1, x0, y1)
+	w.poly(87, x1-10, y0, x1-10, y0+10, x1, y0+10)
+	for y := y0 + 16; y < y1-4; y += 6 {
+		w.poly(87, x0+6, y, x1-6, y)
+	}
+	// Create and select the font, EMR_SETTEXTCOLOR, EMR_SETTEXTALIGN and draw
+	// the caption
+	w.font(2)
+	w.record(24, uint32(0))
+	w.record(22, uint32(6))
+	w.text(text, int32(width/2), int32(y1)+4, 7)
+	return w.bytes(width, height, 3), width, height
+}
@@ -0,0 +1,116 @@
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richardlehane/mscfb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddOLEObject(t *testing.T) {
+	f := NewFile()
+	pdf := bytes.Repeat([]byte("%PDF-1.4"), 1024)
+	icon, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	assert.NoError(t, f.AddOLEObject("Sheet1", OLEObject{Cell: "B2", FileName: "report.pdf", File: pdf, Caption: "Annual Report"}))
+	assert.NoError(t, f.AddOLEObject("Sheet1", OLEObject{
+		Cell: "E2", FileName: "book.xlsx", File: []byte("xlsx"), Icon: &Picture{Extension: ".png", File: icon},
+		Format: GraphicOptions{OffsetX: 10, OffsetY: 5, PrintObject: boolPtr(false), Positioning: "absolute"},
+	}))
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddOLEObject("Sheet2", OLEObject{Cell: "A1", FileName: "notes.txt", File: []byte("notes"), Width: 100, Height: 50}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.OleObjects.OleObject, 2)
+	assert.Equal(t, []int{1026, 1027}, []int{ws.OleObjects.OleObject[0].ShapeID, ws.OleObjects.OleObject[1].ShapeID})
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Len(t, vml.Shape, 3)
+	assert.Equal(t, "_x0000_s1027", vml.Shape[2].ID)
+	assert.Contains(t, vml.Shape[2].Val, "<x:PrintObject>False</x:PrintObject>")
+	assert.Contains(t, vml.Shape[2].Val, "<x:Anchor>4, 10, 1, 5, 7, 18, 8, 7</x:Anchor>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddOLEObject.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test the embedded OLE package object
+	f, err = OpenFile(filepath.Join("test", "TestAddOLEObject.xlsx"))
+	assert.NoError(t, err)
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Package", ws.OleObjects.OleObject[0].ProgID)
+	assert.Equal(t, "DVASPECT_ICON", ws.OleObjects.OleObject[0].DvAspect)
+	target := f.getSheetRelationshipsTargetByID("Sheet1", ws.OleObjects.OleObject[0].RID)
+	assert.Equal(t, "../embeddings/oleObject1.bin", target)
+	data, ok := f.Pkg.Load("xl/embeddings/oleObject1.bin")
+	assert.True(t, ok)
+	doc, err := mscfb.New(bytes.NewReader(data.([]byte)))
+	assert.NoError(t, err)
+	streams := map[string][]byte{}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		buf, _ := io.ReadAll(entry)
+		streams[entry.Name] = buf
+	}
+	assert.True(t, bytes.Contains(streams["CompObj"], []byte("Package\x00")))
+	native := streams["Ole10Native"]
+	assert.Equal(t, len(native)-4, int(binary.LittleEndian.Uint32(native)))
+	assert.True(t, bytes.HasPrefix(native[4:], []byte("\x02\x00report.pdf\x00report.pdf\x00")))
+	assert.True(t, bytes.HasSuffix(native, pdf))
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	var partNames []string
+	for _, override := range content.Overrides {
+		if override.ContentType == ContentTypeOLEObject {
+			partNames = append(partNames, override.PartName)
+		}
+	}
+	assert.Equal(t, []string{"/xl/embeddings/oleObject1.bin", "/xl/embeddings/oleObject2.bin", "/xl/embeddings/oleObject3.bin"}, partNames)
+	// Test add OLE object on the worksheet which already has OLE objects
+	assert.NoError(t, f.AddOLEObject("Sheet1", OLEObject{Cell: "H2", FileName: "notes.txt", File: []byte("notes")}))
+	assert.Len(t, ws.OleObjects.OleObject, 3)
+	assert.Equal(t, 1028, ws.OleObjects.OleObject[2].ShapeID)
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add OLE object with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.AddOLEObject("Sheet1", OLEObject{Cell: "A1", File: []byte("notes")}))
+	assert.Equal(t, ErrParameterInvalid, f.AddOLEObject("Sheet1", OLEObject{Cell: "A1", FileName: "notes.txt"}))
+	assert.Equal(t, ErrParameterInvalid, f.AddOLEObject("Sheet1", OLEObject{Cell: "A1", FileName: "notes.txt", File: []byte("notes"), Format: GraphicOptions{Positioning: "x"}}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddOLEObject("Sheet1", OLEObject{Cell: "A", FileName: "notes.txt", File: []byte("notes")}))
+	// Test add OLE object with unsupported icon image type
+	assert.Equal(t, ErrImgExt, f.AddOLEObject("Sheet1", OLEObject{Cell: "A1", FileName: "notes.txt", File: []byte("notes"), Icon: &Picture{Extension: ".txt", File: icon}}))
+	// Test add OLE object on not exists worksheet
+	assert.EqualError(t, f.AddOLEObject("SheetN", OLEObject{Cell: "A1", FileName: "notes.txt", File: []byte("notes")}), "sheet SheetN does not exist")
+	// Test add OLE object with unsupported charset VML drawing
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/vmlDrawing" Target="../drawings/vmlDrawing1.vml"/></Relationships>`))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.LegacyDrawing = &xlsxLegacyDrawing{RID: "rId1"}
+	assert.EqualError(t, f.AddOLEObject("Sheet1", OLEObject{Cell: "A1", FileName: "notes.txt", File: []byte("notes")}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestNewOLEObjectIcon(t *testing.T) {
+	for _, caption := range []string{"a", "Annual Report", strings.Repeat("报告", 10)} {
+		icon, width, height := newOLEObjectIcon(caption)
+		assert.Equal(t, uint32(1), binary.LittleEndian.Uint32(icon))
+		assert.Equal(t, uint32(0x464D4520), binary.LittleEndian.Uint32(icon[40:]))
+		assert.Equal(t, len(icon), int(binary.LittleEndian.Uint32(icon[48:])))
+		assert.Equal(t, uint32(14), binary.LittleEndian.Uint32(icon[len(icon)-20:]))
+		assert.GreaterOrEqual(t, width, 80)
+		assert.Equal(t, 64, height)
+		// Each record size should be a multiple of 4
+		for offset := 0; offset < len(icon); {
+			size := int(binary.LittleEndian.Uint32(icon[offset+4:]))
+			assert.Zero(t, size%4)
+			offset += size
+		}
+	}
+}
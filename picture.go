@@ -13,12 +13,16 @@ package excelize
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/xml"
 	"image"
 	"io"
+	"math"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
 )
@@ -113,6 +117,9 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // The optional parameter "AltText" is used to add alternative text to a graph
 // object.
 //
+// The optional parameter "Name" specifies the name of the graph object, the
+// default name of the picture is "Picture" followed by the object ID.
+//
 // The optional parameter "PrintObject" indicates whether the graph object is
 // printed when the worksheet is printed, the default value of that is 'true'.
 //
@@ -137,6 +144,33 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // The optional parameter "ScaleY" specifies the vertical scale of graph object,
 // the default value of that is 1.0 which presents 100%.
 //
+// The optional parameter "Width" specifies the width of graph object in the
+// unit of measurement by the "Unit" parameter. If the "Height" was not
+// specified, the height will be calculated by the aspect ratio of the image.
+// The "ScaleX" and "ScaleY" will be ignored when the width or height was set.
+//
+// The optional parameter "Height" specifies the height of graph object in the
+// unit of measurement by the "Unit" parameter. If the "Width" was not
+// specified, the width will be calculated by the aspect ratio of the image.
+//
+// The optional parameter "Unit" specifies the unit of measurement for the
+// "OffsetX", "OffsetY", "Width" and "Height" parameters, the default unit is
+// "px". Supported units: "cm", "emu", "in", "mm", "pt", and "px". Use the
+// "emu" (English Metric Unit) for the exact placement of graph object. For
+// example, insert a picture 50 millimeters wide with 2 millimeters offset:
+//
+//	err := f.AddPicture("Sheet1", "A2", "image.png", &excelize.GraphicOptions{
+//	    OffsetX: 2,
+//	    OffsetY: 2,
+//	    Width:   50,
+//	    Unit:    "mm",
+//	})
+//
+// The optional parameter "UseImageDPI" specifies if scale the graph object by
+// the resolution metadata of BMP, JPEG and PNG images, so that the picture
+// keep its physical size when printed, the default value of that is 'false'.
+// The resolution will be regarded as 96 DPI if it's not available.
+//
 // The optional parameter "Hyperlink" specifies the hyperlink of the graph
 // object.
 //
@@ -204,6 +238,19 @@ func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error
 //	        fmt.Println(err)
 //	    }
 //	}
+//
+// Set the 'InsertType' of the picture as PictureInsertTypePlaceInCell to
+// place the picture in the cell instead of over cells, the picture will be
+// stored as the value of the cell, and the 'AltText' of the format settings
+// will be used as the alternative text of the picture, other format settings
+// will be ignored. For example, place a picture in cell A2 of Sheet1:
+//
+//	err := f.AddPictureFromBytes("Sheet1", "A2", &excelize.Picture{
+//	    Extension:  ".jpg",
+//	    File:       file,
+//	    Format:     &excelize.GraphicOptions{AltText: "Excel Logo"},
+//	    InsertType: excelize.PictureInsertTypePlaceInCell,
+//	})
 func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 	var drawingHyperlinkRID int
 	var hyperlinkType string
@@ -216,6 +263,19 @@ func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 	if err != nil {
 		return err
 	}
+	if options.UseImageDPI {
+		dpiOptions := *options
+		dpiX, dpiY := getImageDPI(pic.File)
+		dpiOptions.ScaleX *= defaultImageDPI / dpiX
+		dpiOptions.ScaleY *= defaultImageDPI / dpiY
+		options = &dpiOptions
+	}
+	if pic.InsertType != PictureInsertTypePlaceOverCells {
+		if pic.InsertType != PictureInsertTypePlaceInCell {
+			return ErrParameterInvalid
+		}
+		return f.addPictureInCell(sheet, cell, ext, pic)
+	}
 	// Read sheet data
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
@@ -262,6 +322,78 @@ func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 	return err
 }
 
+// AddPictureFromReader provides the method to add picture in a sheet by given
+// worksheet name, cell reference, the reader of the picture and format set.
+// The image type of the picture will be detected by the registered image
+// formats, please import the corresponding image decoder packages, and
+// supported image types: BMP, EMF, EMZ, GIF, JPEG, JPG, PNG, SVG, TIF, TIFF,
+// WMF, and WMZ. For example:
+//
+//	file, err := os.Open("image.png")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	if err := f.AddPictureFromReader("Sheet1", "A2", file, nil); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) AddPictureFromReader(sheet, cell string, r io.Reader, opts *GraphicOptions) error {
+	return f.addPictureFromReader(sheet, cell, "", r, PictureSizeLimit, opts)
+}
+
+// AddPictureFromURL provides the method to add picture in a sheet by given
+// worksheet name, cell reference, URL of the picture, size limit in bytes and
+// format set. The picture will be downloaded by HTTP GET request, and the
+// size of the picture should be less than or equal to the size limit, the
+// default size limit is 32MB if the given size limit is less than or equal to
+// 0. The image type of the picture will be detected by the extension name in
+// the URL path, or the registered image formats. For example:
+//
+//	err := f.AddPictureFromURL("Sheet1", "A2", "https://example.com/image.png", 0, nil)
+func (f *File) AddPictureFromURL(sheet, cell, url string, sizeLimit int64, opts *GraphicOptions) error {
+	if sizeLimit <= 0 {
+		sizeLimit = PictureSizeLimit
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return newPictureDownloadError(url, resp.Status)
+	}
+	if resp.ContentLength > sizeLimit {
+		return newPictureSizeLimitError(sizeLimit)
+	}
+	return f.addPictureFromReader(sheet, cell, path.Ext(resp.Request.URL.Path), resp.Body, sizeLimit, opts)
+}
+
+// addPictureFromReader provides a function to add picture in a sheet by given
+// worksheet name, cell reference, extension name, the reader of the picture,
+// size limit in bytes and format set. The image type of the picture will be
+// detected by the registered image formats if the extension name is not
+// supported.
+func (f *File) addPictureFromReader(sheet, cell, ext string, r io.Reader, sizeLimit int64, opts *GraphicOptions) error {
+	file, err := io.ReadAll(io.LimitReader(r, sizeLimit+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(file)) > sizeLimit {
+		return newPictureSizeLimitError(sizeLimit)
+	}
+	if _, ok := supportedImageTypes[strings.ToLower(ext)]; !ok {
+		_, format, err := image.DecodeConfig(bytes.NewReader(file))
+		if err != nil {
+			return err
+		}
+		if ext, ok = supportedImageTypes["."+format]; !ok {
+			return ErrImgExt
+		}
+	}
+	return f.AddPictureFromBytes(sheet, cell, &Picture{Extension: ext, File: file, Format: opts})
+}
+
 // addSheetLegacyDrawing provides a function to add legacy drawing element to
 // xl/worksheets/sheet%d.xml by given worksheet name and relationship index.
 func (f *File) addSheetLegacyDrawing(sheet string, rID int) {
@@ -271,6 +403,16 @@ func (f *File) addSheetLegacyDrawing(sheet string, rID int) {
 	}
 }
 
+// addSheetLegacyDrawingHF provides a function to add legacy drawing header
+// and footer element to xl/worksheets/sheet%d.xml by given worksheet name and
+// relationship index.
+func (f *File) addSheetLegacyDrawingHF(sheet string, rID int) {
+	ws, _ := f.workSheetReader(sheet)
+	ws.LegacyDrawingHF = &xlsxLegacyDrawingHF{
+		RID: "rId" + strconv.Itoa(rID),
+	}
+}
+
 // addSheetDrawing provides a function to add drawing element to
 // xl/worksheets/sheet%d.xml by given worksheet name and relationship index.
 func (f *File) addSheetDrawing(sheet string, rID int) {
@@ -323,16 +465,35 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 	if opts.Positioning != "" && inStrSlice(supportedPositioning, opts.Positioning, true) == -1 {
 		return ErrParameterInvalid
 	}
-	width, height := img.Width, img.Height
+	unit := float64(EMU)
+	if opts.Unit != "" {
+		var ok bool
+		if unit, ok = supportedDrawingUnits[strings.ToLower(opts.Unit)]; !ok {
+			return ErrParameterInvalid
+		}
+	}
+	x1, y1 := int(math.Round(float64(opts.OffsetX)*unit)), int(math.Round(float64(opts.OffsetY)*unit))
+	width, height, scaleX, scaleY := float64(img.Width*EMU), float64(img.Height*EMU), opts.ScaleX, opts.ScaleY
+	if opts.Width > 0 || opts.Height > 0 {
+		width, height, scaleX, scaleY = opts.Width*unit, opts.Height*unit, defaultDrawingScale, defaultDrawingScale
+		if opts.Height <= 0 && img.Width > 0 {
+			height = width * float64(img.Height) / float64(img.Width)
+		}
+		if opts.Width <= 0 && img.Height > 0 {
+			width = height * float64(img.Width) / float64(img.Height)
+		}
+	}
+	cx, cy := int(math.Round(width*scaleX)), int(math.Round(height*scaleY))
 	if opts.AutoFit {
-		if width, height, col, row, err = f.drawingResize(sheet, cell, float64(width), float64(height), opts); err != nil {
+		resizeOpts := *opts
+		resizeOpts.OffsetX, resizeOpts.OffsetY = x1/EMU, y1/EMU
+		resizeOpts.ScaleX, resizeOpts.ScaleY = scaleX, scaleY
+		if cx, cy, col, row, err = f.drawingResize(sheet, cell, width/float64(EMU), height/float64(EMU), &resizeOpts); err != nil {
 			return err
 		}
-	} else {
-		width = int(float64(width) * opts.ScaleX)
-		height = int(float64(height) * opts.ScaleY)
+		cx, cy = cx*EMU, cy*EMU
 	}
-	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row, opts.OffsetX, opts.OffsetY, width, height)
+	colStart, rowStart, colEnd, rowEnd, x1, y1, x2, y2 := f.positionObjectEMUs(sheet, col, row, x1, y1, cx, cy)
 	content, cNvPrID, err := f.drawingParser(drawingXML)
 	if err != nil {
 		return err
@@ -341,14 +502,14 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 	twoCellAnchor.EditAs = opts.Positioning
 	from := xlsxFrom{}
 	from.Col = colStart
-	from.ColOff = opts.OffsetX * EMU
+	from.ColOff = x1
 	from.Row = rowStart
-	from.RowOff = opts.OffsetY * EMU
+	from.RowOff = y1
 	to := xlsxTo{}
 	to.Col = colEnd
-	to.ColOff = x2 * EMU
+	to.ColOff = x2
 	to.Row = rowEnd
-	to.RowOff = y2 * EMU
+	to.RowOff = y2
 	twoCellAnchor.From = &from
 	twoCellAnchor.To = &to
 	pic := xlsxPic{}
@@ -356,6 +517,9 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 	pic.NvPicPr.CNvPr.ID = cNvPrID
 	pic.NvPicPr.CNvPr.Descr = opts.AltText
 	pic.NvPicPr.CNvPr.Name = "Picture " + strconv.Itoa(cNvPrID)
+	if opts.Name != "" {
+		pic.NvPicPr.CNvPr.Name = opts.Name
+	}
 	if hyperlinkRID != 0 {
 		pic.NvPicPr.CNvPr.HlinkClick = &xlsxHlinkClick{
 			R:   SourceRelationship.Value,
@@ -391,6 +555,63 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 	return err
 }
 
+// getImageDPI provides a function to get the horizontal and vertical
+// resolution in dots per inch by given image data. The resolution metadata of
+// BMP, JPEG and PNG images are supported, and the default 96 DPI will be
+// returned if the resolution of the image is not available.
+func getImageDPI(file []byte) (float64, float64) {
+	switch {
+	case bytes.HasPrefix(file, []byte("\x89PNG\r\n\x1a\n")):
+		// The pHYs chunk specifies the pixels per unit, and must precede the
+		// first IDAT chunk.
+		for i := 8; i+8 <= len(file); {
+			length, chunkType := int(binary.BigEndian.Uint32(file[i:])), string(file[i+4:i+8])
+			if chunkType == "IDAT" {
+				break
+			}
+			if chunkType == "pHYs" && length == 9 && i+17 <= len(file) && file[i+16] == 1 {
+				return ppmToDPI(binary.BigEndian.Uint32(file[i+8:]), binary.BigEndian.Uint32(file[i+12:]))
+			}
+			i += length + 12
+		}
+	case bytes.HasPrefix(file, []byte{0xFF, 0xD8}):
+		// The JFIF APP0 segment specifies the density unit and pixel density.
+		for i := 2; i+4 <= len(file) && file[i] == 0xFF; {
+			marker, length := file[i+1], int(binary.BigEndian.Uint16(file[i+2:]))
+			if marker == 0xDA {
+				break
+			}
+			if marker == 0xE0 && length >= 14 && i+16 <= len(file) && string(file[i+4:i+9]) == "JFIF\x00" {
+				x, y := float64(binary.BigEndian.Uint16(file[i+12:])), float64(binary.BigEndian.Uint16(file[i+14:]))
+				if x > 0 && y > 0 {
+					switch file[i+11] {
+					case 1:
+						return x, y
+					case 2:
+						return math.Round(x * 2.54), math.Round(y * 2.54)
+					}
+				}
+				break
+			}
+			i += length + 2
+		}
+	case bytes.HasPrefix(file, []byte("BM")) && len(file) >= 46:
+		// The BITMAPINFOHEADER specifies the pixels per meter.
+		return ppmToDPI(binary.LittleEndian.Uint32(file[38:]), binary.LittleEndian.Uint32(file[42:]))
+	}
+	return defaultImageDPI, defaultImageDPI
+}
+
+// ppmToDPI provides a function to convert the horizontal and vertical
+// resolution from pixels per meter to dots per inch.
+func ppmToDPI(x, y uint32) (float64, float64) {
+	dpiX, dpiY := math.Round(float64(int32(x))*0.0254), math.Round(float64(int32(y))*0.0254)
+	if dpiX < 1 || dpiY < 1 {
+		return defaultImageDPI, defaultImageDPI
+	}
+	return dpiX, dpiY
+}
+
 // countMedia provides a function to get media files count storage in the
 // folder xl/media/image.
 func (f *File) countMedia() int {
@@ -461,42 +682,118 @@ func (f *File) GetPictures(sheet, cell string) ([]Picture, error) {
 	col--
 	row--
 	f.mu.Lock()
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		f.mu.Unlock()
 		return nil, err
 	}
 	f.mu.Unlock()
-	if ws.Drawing == nil {
-		return nil, err
+	pics, err := f.getCellPicture(ws, col, row)
+	if err != nil || ws.Drawing == nil {
+		return pics, err
 	}
 	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
 	drawingXML := strings.ReplaceAll(target, "..", "xl")
 	drawingRelationships := strings.ReplaceAll(
 		strings.ReplaceAll(target, "../drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	drawingPics, err := f.getPicture(row, col, drawingXML, drawingRelationships)
+	return append(pics, drawingPics...), err
+}
 
-	return f.getPicture(row, col, drawingXML, drawingRelationships)
+// getCellPicture provides a function to get the picture which was placed in
+// the cell by given worksheet and 0-based column and row number.
+func (f *File) getCellPicture(ws *xlsxWorksheet, col, row int) ([]Picture, error) {
+	var vm *uint
+	ws.mu.Lock()
+	for _, r := range ws.SheetData.Row {
+		if r.R != row+1 {
+			continue
+		}
+		for _, c := range r.C {
+			if cCol, _, _ := CellNameToCoordinates(c.R); cCol == col+1 {
+				vm = c.Vm
+				break
+			}
+		}
+	}
+	ws.mu.Unlock()
+	if vm == nil {
+		return nil, nil
+	}
+	pics, err := f.getPicturesInCell()
+	if pic, ok := pics[*vm]; ok {
+		return []Picture{pic}, err
+	}
+	return nil, err
 }
 
 // GetPictureCells returns all picture cell references in a worksheet by a
-// specific worksheet name.
+// specific worksheet name, including the cells anchoring the pictures over
+// cells and the cells with the picture placed in the cell. Use GetPictures
+// with the returned cell references to get the pictures and their names. For
+// example, get all pictures and their names in Sheet1:
+//
+//	cells, err := f.GetPictureCells("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, cell := range cells {
+//	    pics, err := f.GetPictures("Sheet1", cell)
+//	    if err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	    for _, pic := range pics {
+//	        fmt.Println(cell, pic.Format.Name, pic.Format.AltText)
+//	    }
+//	}
 func (f *File) GetPictureCells(sheet string) ([]string, error) {
 	f.mu.Lock()
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		f.mu.Unlock()
 		return nil, err
 	}
 	f.mu.Unlock()
-	if ws.Drawing == nil {
-		return nil, err
+	cells, err := f.getPictureInCellCells(ws)
+	if err != nil || ws.Drawing == nil {
+		return cells, err
 	}
 	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
 	drawingXML := strings.ReplaceAll(target, "..", "xl")
 	drawingRelationships := strings.ReplaceAll(
 		strings.ReplaceAll(target, "../drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	drawingCells, err := f.getPictureCells(drawingXML, drawingRelationships)
+	for _, cell := range drawingCells {
+		if inStrSlice(cells, cell, true) == -1 {
+			cells = append(cells, cell)
+		}
+	}
+	return cells, err
+}
 
-	return f.getPictureCells(drawingXML, drawingRelationships)
+// getPictureInCellCells provides a function to get all cell references which
+// have the picture placed in the cell by given worksheet.
+func (f *File) getPictureInCellCells(ws *xlsxWorksheet) ([]string, error) {
+	var cells []string
+	pics, err := f.getPicturesInCell()
+	if err != nil || len(pics) == 0 {
+		return cells, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.Vm == nil {
+				continue
+			}
+			if _, ok := pics[*c.Vm]; ok {
+				cells = append(cells, c.R)
+			}
+		}
+	}
+	return cells, err
 }
 
 // DeletePicture provides a function to delete all pictures in a cell by given
@@ -557,7 +854,7 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 		deWsDr = new(decodeWsDr)
 		wsDr   *xlsxWsDr
 	)
-	if wsDr, _, err = f.drawingParser(drawingXML); err != nil {
+	if wsDr, _, err = f.drawingViewer(drawingXML); err != nil {
 		return
 	}
 	anchorCond := func(a *xdrCellAnchor) bool { return a.From.Col == col && a.From.Row == row }
@@ -566,6 +863,7 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 		if buffer, _ := f.Pkg.Load(strings.ReplaceAll(r.Target, "..", "xl")); buffer != nil {
 			pic.File = buffer.([]byte)
 			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
+			pic.Format.Name = a.Pic.NvPicPr.CNvPr.Name
 			pics = append(pics, pic)
 		}
 	}
@@ -581,6 +879,7 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 		if buffer, _ := f.Pkg.Load(strings.ReplaceAll(r.Target, "..", "xl")); buffer != nil {
 			pic.File = buffer.([]byte)
 			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
+			pic.Format.Name = a.Pic.NvPicPr.CNvPr.Name
 			pics = append(pics, pic)
 		}
 	}
@@ -635,7 +934,9 @@ func (f *File) extractDecodeCellAnchor(anchor *decodeCellAnchor, drawingRelation
 	}
 	if deCellAnchor.From != nil && deCellAnchor.Pic != nil {
 		if cond(deCellAnchor) {
-			drawRel = f.getDrawingRelationships(drawingRelationships, deCellAnchor.Pic.BlipFill.Blip.Embed)
+			if drawRel = f.getDrawingRelationships(drawingRelationships, deCellAnchor.Pic.BlipFill.Blip.Embed); drawRel == nil {
+				return
+			}
 			if _, ok := supportedImageTypes[strings.ToLower(filepath.Ext(drawRel.Target))]; ok {
 				cb(deCellAnchor, drawRel)
 			}
@@ -647,7 +948,7 @@ func (f *File) extractDecodeCellAnchor(anchor *decodeCellAnchor, drawingRelation
 // from xl/drawings/_rels/drawing%s.xml.rels by given file name and
 // relationship ID.
 func (f *File) getDrawingRelationships(rels, rID string) *xlsxRelationship {
-	if drawingRels, _ := f.relsReader(rels); drawingRels != nil {
+	if drawingRels, _ := f.relsViewer(rels); drawingRels != nil {
 		drawingRels.mu.Lock()
 		defer drawingRels.mu.Unlock()
 		for _, v := range drawingRels.Relationships {
@@ -663,6 +964,9 @@ func (f *File) getDrawingRelationships(rels, rID string) *xlsxRelationship {
 // serialize structure.
 func (f *File) drawingsWriter() {
 	f.Drawings.Range(func(path, d interface{}) bool {
+		if _, ok := f.unmodified.Load(path); ok {
+			return true
+		}
 		if d != nil {
 			v, _ := xml.Marshal(d.(*xlsxWsDr))
 			f.saveFileList(path.(string), v)
@@ -725,7 +1029,7 @@ func (f *File) getPictureCells(drawingXML, drawingRelationships string) ([]strin
 		deWsDr *decodeWsDr
 		wsDr   *xlsxWsDr
 	)
-	if wsDr, _, err = f.drawingParser(drawingXML); err != nil {
+	if wsDr, _, err = f.drawingViewer(drawingXML); err != nil {
 		return cells, err
 	}
 	anchorCond := func(a *xdrCellAnchor) bool { return true }
@@ -759,3 +1063,361 @@ func (f *File) getPictureCells(drawingXML, drawingRelationships string) ([]strin
 	}
 	return cells, err
 }
+
+// getWorkbookPartPath provides a function to get the path of the workbook
+// part by given relationship type, the empty string will be returned if the
+// workbook does not have the part with the relationship type.
+func (f *File) getWorkbookPartPath(relType string) (string, error) {
+	rels, err := f.relsViewer(f.getWorkbookRelsPath())
+	if err != nil || rels == nil {
+		return "", err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, rel := range rels.Relationships {
+		if rel.Type == relType {
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/"), err
+			}
+			return path.Join(path.Dir(f.getWorkbookPath()), rel.Target), err
+		}
+	}
+	return "", err
+}
+
+// richDataPartReader provides a function to get the path and decode the rich
+// data part of the workbook by given relationship type, the empty string will
+// be returned if the workbook does not have the part.
+func (f *File) richDataPartReader(relType string, v interface{}) (string, error) {
+	partPath, err := f.getWorkbookPartPath(relType)
+	if err != nil || partPath == "" {
+		return partPath, err
+	}
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(partPath)))).
+		Decode(v); err != nil && err != io.EOF {
+		return partPath, err
+	}
+	return partPath, nil
+}
+
+// addRichDataPart provides a function to add the relationship and content
+// type of the rich data part for the workbook by given relationship type and
+// content type, and returns the path of the part. The path of the existing
+// part will be returned if the workbook already has the part.
+func (f *File) addRichDataPart(relType, contentType string) (string, error) {
+	partPath, err := f.getWorkbookPartPath(relType)
+	if err != nil || partPath != "" {
+		return partPath, err
+	}
+	partNames := map[string]string{
+		"metadata":             "metadata.xml",
+		"rdRichValue":          "richData/rdrichvalue.xml",
+		"rdRichValueStructure": "richData/rdrichvaluestructure.xml",
+		"rdRichValueTypes":     "richData/rdRichValueTypes.xml",
+		"richValueRel":         "richData/richValueRel.xml",
+	}
+	f.addRels(f.getWorkbookRelsPath(), relType, partNames[contentType], "")
+	return "xl/" + partNames[contentType], f.addContentTypePart(0, contentType)
+}
+
+// addPictureInCell provides a function to place the picture in the cell by
+// given worksheet name, cell reference, extension name of the picture and the
+// picture. The picture will be stored as a local image rich value, and the
+// cell value will reference the rich value through the value metadata.
+func (f *File) addPictureInCell(sheet, cell, ext string, pic *Picture) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	c, _, _, err := ws.prepareCell(cell)
+	if err != nil {
+		return err
+	}
+	relIdx, err := f.addRichValueRel(pic.File, ext)
+	if err != nil {
+		return err
+	}
+	rvIdx, err := f.addRichValue(relIdx, parseGraphicOptions(pic.Format).AltText)
+	if err != nil {
+		return err
+	}
+	vm, err := f.addRichValueMetadata(rvIdx)
+	if err != nil {
+		return err
+	}
+	typesPath, err := f.addRichDataPart(SourceRelationshipRDRichValueTypes, "rdRichValueTypes")
+	if err != nil {
+		return err
+	}
+	if _, ok := f.Pkg.Load(typesPath); !ok {
+		f.Pkg.Store(typesPath, []byte(xml.Header+templateRichValueTypes))
+	}
+	if err = f.removeFormula(c, ws, sheet); err != nil {
+		return err
+	}
+	c.T, c.V, c.IS, c.Vm = "e", formulaErrorVALUE, nil, uintPtr(uint(vm))
+	return f.setContentTypePartImageExtensions()
+}
+
+// addRichValueRel provides a function to add the picture into the rich value
+// relationships part by given picture file and extension name, and returns
+// the index of the relationship in the part.
+func (f *File) addRichValueRel(file []byte, ext string) (int, error) {
+	rvRels := xlsxRichValueRels{}
+	partPath, err := f.richDataPartReader(SourceRelationshipRichValueRel, &rvRels)
+	if err != nil {
+		return -1, err
+	}
+	if partPath == "" {
+		if partPath, err = f.addRichDataPart(SourceRelationshipRichValueRel, "richValueRel"); err != nil {
+			return -1, err
+		}
+	}
+	relsPath := path.Join(path.Dir(partPath), "_rels", path.Base(partPath)+".rels")
+	mediaStr := ".." + strings.TrimPrefix(f.addMedia(file, ext), "xl")
+	var rID string
+	if rels, _ := f.relsReader(relsPath); rels != nil {
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipImage && rel.Target == mediaStr {
+				rID = rel.ID
+				break
+			}
+		}
+	}
+	if rID == "" {
+		rID = "rId" + strconv.Itoa(f.addRels(relsPath, SourceRelationshipImage, mediaStr, ""))
+	}
+	relIdx := -1
+	for idx, rel := range rvRels.Rels {
+		if rel.ID == rID {
+			relIdx = idx
+			break
+		}
+	}
+	if relIdx == -1 {
+		rvRels.Rels = append(rvRels.Rels, xlsxRichValueRel{ID: rID})
+		relIdx = len(rvRels.Rels) - 1
+	}
+	rvRels.XMLNSR = SourceRelationship.Value
+	output, err := xml.Marshal(rvRels)
+	f.saveFileList(partPath, replaceRelationshipsBytes(output))
+	return relIdx, err
+}
+
+// addRichValue provides a function to add the local image rich value by given
+// index of the rich value relationship and alternative text of the picture,
+// and returns the index of the rich value.
+func (f *File) addRichValue(relIdx int, altText string) (int, error) {
+	var (
+		rvStructures xlsxRichValueStructures
+		rvData       xlsxRichValueData
+		structIdx    = -1
+		keys         = []xlsxRichValueKey{
+			{N: "_rvRel:LocalImageIdentifier", T: "i"}, {N: "CalcOrigin", T: "i"},
+		}
+		values = []xlsxRichValueValue{{Val: strconv.Itoa(relIdx)}, {Val: "5"}}
+	)
+	if altText != "" {
+		keys = append(keys, xlsxRichValueKey{N: "Text", T: "s"})
+		values = append(values, xlsxRichValueValue{Val: altText})
+	}
+	structPath, err := f.richDataPartReader(SourceRelationshipRDRichValueStructure, &rvStructures)
+	if err != nil {
+		return -1, err
+	}
+	if structPath == "" {
+		if structPath, err = f.addRichDataPart(SourceRelationshipRDRichValueStructure, "rdRichValueStructure"); err != nil {
+			return -1, err
+		}
+	}
+	for idx, s := range rvStructures.S {
+		if s.T == "_localImage" && reflect.DeepEqual(s.K, keys) {
+			structIdx = idx
+			break
+		}
+	}
+	if structIdx == -1 {
+		rvStructures.S = append(rvStructures.S, xlsxRichValueStructure{T: "_localImage", K: keys})
+		structIdx = len(rvStructures.S) - 1
+	}
+	rvStructures.Count = len(rvStructures.S)
+	output, _ := xml.Marshal(rvStructures)
+	f.saveFileList(structPath, output)
+	dataPath, err := f.richDataPartReader(SourceRelationshipRDRichValue, &rvData)
+	if err != nil {
+		return -1, err
+	}
+	if dataPath == "" {
+		if dataPath, err = f.addRichDataPart(SourceRelationshipRDRichValue, "rdRichValue"); err != nil {
+			return -1, err
+		}
+	}
+	rvData.Rv = append(rvData.Rv, xlsxRichValue{S: structIdx, V: values})
+	rvData.Count = len(rvData.Rv)
+	output, err = xml.Marshal(rvData)
+	f.saveFileList(dataPath, output)
+	return rvData.Count - 1, err
+}
+
+// addRichValueMetadata provides a function to add the value metadata of the
+// rich value by given index of the rich value, and returns the 1-based index
+// of the value metadata block which was referenced by the cell.
+func (f *File) addRichValueMetadata(rvIdx int) (int, error) {
+	var (
+		metadata  xlsxMetadata
+		typeIdx   = -1
+		futureIdx = -1
+	)
+	partPath, err := f.richDataPartReader(SourceRelationshipSheetMetadata, &metadata)
+	if err != nil {
+		return -1, err
+	}
+	if partPath == "" {
+		if partPath, err = f.addRichDataPart(SourceRelationshipSheetMetadata, "metadata"); err != nil {
+			return -1, err
+		}
+	}
+	if metadata.MetadataTypes == nil {
+		metadata.MetadataTypes = &xlsxMetadataTypes{}
+	}
+	for idx, metadataType := range metadata.MetadataTypes.MetadataType {
+		if metadataType.Name == "XLRICHVALUE" {
+			typeIdx = idx
+			break
+		}
+	}
+	if typeIdx == -1 {
+		metadata.MetadataTypes.MetadataType = append(metadata.MetadataTypes.MetadataType, xlsxMetadataType{
+			Name: "XLRICHVALUE", MinSupportedVersion: 120000, Copy: true, PasteAll: true,
+			PasteValues: true, Merge: true, SplitFirst: true, RowColShift: true,
+			ClearFormats: true, ClearComments: true, Assign: true, Coerce: true,
+		})
+		typeIdx = len(metadata.MetadataTypes.MetadataType) - 1
+	}
+	metadata.MetadataTypes.Count = len(metadata.MetadataTypes.MetadataType)
+	for idx, futureMetadata := range metadata.FutureMetadata {
+		if futureMetadata.Name == "XLRICHVALUE" {
+			futureIdx = idx
+			break
+		}
+	}
+	if futureIdx == -1 {
+		metadata.FutureMetadata = append(metadata.FutureMetadata, xlsxFutureMetadata{Name: "XLRICHVALUE"})
+		futureIdx = len(metadata.FutureMetadata) - 1
+	}
+	futureMetadata := &metadata.FutureMetadata[futureIdx]
+	futureMetadata.Bk = append(futureMetadata.Bk, xlsxFutureMetadataBlock{
+		ExtLst: &xlsxFutureMetadataExtLst{
+			Ext: []xlsxFutureMetadataExt{{URI: ExtURIRichValueBlock, Rvb: &xlsxRichValueBlock{I: rvIdx}}},
+		},
+	})
+	futureMetadata.Count = len(futureMetadata.Bk)
+	if metadata.ValueMetadata == nil {
+		metadata.ValueMetadata = &xlsxMetadataBlocks{}
+	}
+	metadata.ValueMetadata.Bk = append(metadata.ValueMetadata.Bk, xlsxMetadataBlock{
+		Rc: []xlsxMetadataRecord{{T: typeIdx + 1, V: futureMetadata.Count - 1}},
+	})
+	metadata.ValueMetadata.Count = len(metadata.ValueMetadata.Bk)
+	output, err := xml.Marshal(metadata)
+	f.saveFileList(partPath, output)
+	return metadata.ValueMetadata.Count, err
+}
+
+// getPicturesInCell provides a function to get all pictures which were placed
+// in cells of the workbook, the key of the returned map is the 1-based index
+// of the value metadata block which was referenced by the cell.
+func (f *File) getPicturesInCell() (map[uint]Picture, error) {
+	var (
+		metadata     xlsxMetadata
+		rvData       xlsxRichValueData
+		rvStructures xlsxRichValueStructures
+		rvRels       xlsxRichValueRels
+		pics         = map[uint]Picture{}
+	)
+	partPath, err := f.richDataPartReader(SourceRelationshipSheetMetadata, &metadata)
+	if err != nil || partPath == "" || metadata.ValueMetadata == nil || metadata.MetadataTypes == nil {
+		return pics, err
+	}
+	if _, err = f.richDataPartReader(SourceRelationshipRDRichValue, &rvData); err != nil {
+		return pics, err
+	}
+	if _, err = f.richDataPartReader(SourceRelationshipRDRichValueStructure, &rvStructures); err != nil {
+		return pics, err
+	}
+	relPath, err := f.richDataPartReader(SourceRelationshipRichValueRel, &rvRels)
+	if err != nil || relPath == "" {
+		return pics, err
+	}
+	relsPath := path.Join(path.Dir(relPath), "_rels", path.Base(relPath)+".rels")
+	for idx, bk := range metadata.ValueMetadata.Bk {
+		for _, rc := range bk.Rc {
+			rvIdx := f.getRichValueIndex(&metadata, rc)
+			if rvIdx < 0 || rvIdx >= len(rvData.Rv) {
+				continue
+			}
+			if pic, ok := f.getRichValuePicture(&rvData.Rv[rvIdx], &rvStructures, &rvRels, relsPath); ok {
+				pics[uint(idx+1)] = pic
+			}
+		}
+	}
+	return pics, err
+}
+
+// getRichValueIndex provides a function to get the index of the rich value by
+// given metadata and the metadata record, -1 will be returned if the metadata
+// record does not reference a rich value.
+func (f *File) getRichValueIndex(metadata *xlsxMetadata, rc xlsxMetadataRecord) int {
+	if rc.T < 1 || rc.T > len(metadata.MetadataTypes.MetadataType) {
+		return -1
+	}
+	name := metadata.MetadataTypes.MetadataType[rc.T-1].Name
+	for _, futureMetadata := range metadata.FutureMetadata {
+		if futureMetadata.Name != name || rc.V < 0 || rc.V >= len(futureMetadata.Bk) {
+			continue
+		}
+		if extLst := futureMetadata.Bk[rc.V].ExtLst; extLst != nil {
+			for _, ext := range extLst.Ext {
+				if ext.Rvb != nil {
+					return ext.Rvb.I
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// getRichValuePicture provides a function to get the local image of the rich
+// value by given rich value, rich value structures, rich value relationships
+// and the path of the relationships part.
+func (f *File) getRichValuePicture(rv *xlsxRichValue, rvStructures *xlsxRichValueStructures, rvRels *xlsxRichValueRels, relsPath string) (Picture, bool) {
+	pic := Picture{Format: &GraphicOptions{}, InsertType: PictureInsertTypePlaceInCell}
+	if rv.S < 0 || rv.S >= len(rvStructures.S) || rvStructures.S[rv.S].T != "_localImage" {
+		return pic, false
+	}
+	relIdx := -1
+	for idx, key := range rvStructures.S[rv.S].K {
+		if idx >= len(rv.V) {
+			break
+		}
+		switch key.N {
+		case "_rvRel:LocalImageIdentifier":
+			relIdx, _ = strconv.Atoi(rv.V[idx].Val)
+		case "Text":
+			pic.Format.AltText = rv.V[idx].Val
+		}
+	}
+	if relIdx < 0 || relIdx >= len(rvRels.Rels) {
+		return pic, false
+	}
+	rels := f.getDrawingRelationships(relsPath, rvRels.Rels[relIdx].ID)
+	if rels == nil {
+		return pic, false
+	}
+	buffer, _ := f.Pkg.Load(path.Join(path.Dir(path.Dir(relsPath)), rels.Target))
+	if buffer == nil {
+		return pic, false
+	}
+	pic.Extension, pic.File = path.Ext(rels.Target), buffer.([]byte)
+	return pic, true
+}
@@ -1,16 +1,22 @@
 package excelize
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
 	"image"
 	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
 	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	_ "golang.org/x/image/bmp"
 	_ "golang.org/x/image/tiff"
@@ -115,6 +121,70 @@ func TestAddPicture(t *testing.T) {
 	assert.EqualError(t, f.AddPicture("Sheet:1", "A1", filepath.Join("test", "images", "excel.jpg"), nil), ErrSheetNameInvalid.Error())
 }
 
+func TestAddPictureFromReader(t *testing.T) {
+	f := NewFile()
+	file, err := os.Open(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPictureFromReader("Sheet1", "A1", file, &GraphicOptions{AltText: "Excel Logo"}))
+	assert.NoError(t, file.Close())
+	pics, err := f.GetPictures("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, ".png", pics[0].Extension)
+	assert.Len(t, pics[0].File, 13233)
+	assert.Equal(t, "Excel Logo", pics[0].Format.AltText)
+	// Test add picture from reader with read error
+	assert.EqualError(t, f.AddPictureFromReader("Sheet1", "A1", iotest.ErrReader(io.ErrUnexpectedEOF), nil), io.ErrUnexpectedEOF.Error())
+	// Test add picture from reader with invalid image data
+	assert.EqualError(t, f.AddPictureFromReader("Sheet1", "A1", strings.NewReader("image"), nil), image.ErrFormat.Error())
+	// Test add picture from reader with unsupported image type
+	decode := func(r io.Reader) (image.Image, error) { return nil, nil }
+	decodeConfig := func(r io.Reader) (image.Config, error) { return image.Config{Height: 16, Width: 16}, nil }
+	image.RegisterFormat("ico", "\x00\x00\x01\x00", decode, decodeConfig)
+	assert.Equal(t, ErrImgExt, f.AddPictureFromReader("Sheet1", "A1", strings.NewReader("\x00\x00\x01\x00"), nil))
+	// Test add picture from reader which exceeds the size limit
+	assert.EqualError(t, f.addPictureFromReader("Sheet1", "A1", ".png", strings.NewReader("image"), 4, nil),
+		newPictureSizeLimitError(4).Error())
+	assert.NoError(t, f.Close())
+}
+
+func TestAddPictureFromURL(t *testing.T) {
+	file, err := os.ReadFile(filepath.Join("test", "images", "excel.jpg"))
+	assert.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/excel.jpg", "/image":
+			_, _ = w.Write(file)
+		case "/large.png":
+			w.Header().Set("Content-Length", "1024")
+			_, _ = w.Write(make([]byte, 1024))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	f := NewFile()
+	assert.NoError(t, f.AddPictureFromURL("Sheet1", "A1", server.URL+"/excel.jpg", 0, nil))
+	assert.NoError(t, f.AddPictureFromURL("Sheet1", "F1", server.URL+"/image", 0, &GraphicOptions{ScaleX: 0.5}))
+	for _, cell := range []string{"A1", "F1"} {
+		pics, err := f.GetPictures("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		assert.Equal(t, ".jpeg", pics[0].Extension)
+		assert.Equal(t, file, pics[0].File)
+	}
+	// Test add picture from URL with not found response status
+	assert.EqualError(t, f.AddPictureFromURL("Sheet1", "A1", server.URL+"/missing.png", 0, nil),
+		newPictureDownloadError(server.URL+"/missing.png", "404 Not Found").Error())
+	// Test add picture from URL which exceeds the size limit
+	assert.EqualError(t, f.AddPictureFromURL("Sheet1", "A1", server.URL+"/large.png", 512, nil),
+		newPictureSizeLimitError(512).Error())
+	// Test add picture from URL with invalid URL
+	assert.Error(t, f.AddPictureFromURL("Sheet1", "A1", "\x00", 0, nil))
+	assert.NoError(t, f.Close())
+}
+
 func TestAddPictureErrors(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -247,6 +317,81 @@ func TestAddDrawingPicture(t *testing.T) {
 	assert.EqualError(t, f.addDrawingPicture("sheet1", path, "A1", "", 0, 0, image.Config{}, opts), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestAddPictureWithPhysicalSize(t *testing.T) {
+	f := NewFile()
+	file, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	img, _, err := image.DecodeConfig(bytes.NewReader(file))
+	assert.NoError(t, err)
+	getAnchor := func(idx int) *xdrCellAnchor {
+		drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+		assert.True(t, ok)
+		return drawing.(*xlsxWsDr).TwoCellAnchor[idx]
+	}
+	// Test add picture with physical size and keep the aspect ratio
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "A1", &Picture{Extension: ".png", File: file, Format: &GraphicOptions{Width: 50, Unit: "mm"}}))
+	anchor := getAnchor(0)
+	assert.Equal(t, []int{0, 0, 2, 580800}, []int{anchor.From.Col, anchor.From.ColOff, anchor.To.Col, anchor.To.ColOff})
+	height := int(math.Round(1800000 * float64(img.Height) / float64(img.Width)))
+	assert.Equal(t, height, anchor.To.Row*f.getRowHeight("Sheet1", 1)*EMU+anchor.To.RowOff)
+	// Test add picture with exact EMU offsets, the offset which greater than
+	// the cell width should be moved to the next cell
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "A10", &Picture{Extension: ".png", File: file, Format: &GraphicOptions{
+		OffsetX: 609601, OffsetY: 1, Width: 914400, Height: 457200, Unit: "EMU",
+	}}))
+	anchor = getAnchor(1)
+	assert.Equal(t, []int{1, 1, 9, 1}, []int{anchor.From.Col, anchor.From.ColOff, anchor.From.Row, anchor.From.RowOff})
+	assert.Equal(t, []int{2, 304801, 11, 114301}, []int{anchor.To.Col, anchor.To.ColOff, anchor.To.Row, anchor.To.RowOff})
+	// Test add picture with the image resolution
+	file, err = os.ReadFile(filepath.Join("test", "images", "excel.jpg"))
+	assert.NoError(t, err)
+	img, _, err = image.DecodeConfig(bytes.NewReader(file))
+	assert.NoError(t, err)
+	dpiX, dpiY := getImageDPI(file)
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "A20", &Picture{Extension: ".jpg", File: file, Format: &GraphicOptions{UseImageDPI: true, AutoFit: true}}))
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "A40", &Picture{Extension: ".jpg", File: file, Format: &GraphicOptions{UseImageDPI: true}}))
+	anchor = getAnchor(3)
+	assert.Equal(t, int(math.Round(float64(img.Width*EMU)*defaultImageDPI/dpiX)), anchor.To.Col*64*EMU+anchor.To.ColOff)
+	assert.Equal(t, int(math.Round(float64(img.Height*EMU)*defaultImageDPI/dpiY)), (anchor.To.Row-39)*f.getRowHeight("Sheet1", 1)*EMU+anchor.To.RowOff)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureWithPhysicalSize.xlsx")))
+	// Test add picture with unsupported unit
+	assert.Equal(t, ErrParameterInvalid, f.AddPictureFromBytes("Sheet1", "A1", &Picture{Extension: ".jpg", File: file, Format: &GraphicOptions{Unit: "x"}}))
+	assert.NoError(t, f.Close())
+}
+
+func TestGetImageDPI(t *testing.T) {
+	pngChunk := func(chunkType string, data []byte) []byte {
+		chunk := make([]byte, 8, 12+len(data))
+		binary.BigEndian.PutUint32(chunk, uint32(len(data)))
+		copy(chunk[4:], chunkType)
+		chunk = append(chunk, data...)
+		return append(chunk, 0, 0, 0, 0)
+	}
+	pHYs := make([]byte, 9)
+	binary.BigEndian.PutUint32(pHYs, 11811)
+	binary.BigEndian.PutUint32(pHYs[4:], 5906)
+	pHYs[8] = 1
+	png := append([]byte("\x89PNG\r\n\x1a\n"), pngChunk("IHDR", make([]byte, 13))...)
+	for _, c := range []struct {
+		file       []byte
+		dpiX, dpiY float64
+	}{
+		{file: append(png, pngChunk("pHYs", pHYs)...), dpiX: 300, dpiY: 150},
+		{file: append(append(png, pngChunk("IDAT", nil)...), pngChunk("pHYs", pHYs)...), dpiX: 96, dpiY: 96},
+		{file: append(png, pngChunk("pHYs", make([]byte, 9))...), dpiX: 96, dpiY: 96},
+		{file: []byte{0xFF, 0xD8, 0xFF, 0xE0, 0, 16, 'J', 'F', 'I', 'F', 0, 1, 1, 1, 0, 72, 0, 144, 0, 0}, dpiX: 72, dpiY: 144},
+		{file: []byte{0xFF, 0xD8, 0xFF, 0xE0, 0, 16, 'J', 'F', 'I', 'F', 0, 1, 1, 2, 0, 118, 0, 118, 0, 0}, dpiX: 300, dpiY: 300},
+		{file: []byte{0xFF, 0xD8, 0xFF, 0xE0, 0, 16, 'J', 'F', 'I', 'F', 0, 1, 1, 0, 0, 1, 0, 1, 0, 0}, dpiX: 96, dpiY: 96},
+		{file: []byte{0xFF, 0xD8, 0xFF, 0xDA, 0, 2}, dpiX: 96, dpiY: 96},
+		{file: append(append([]byte("BM"), make([]byte, 36)...), 0x13, 0x0B, 0, 0, 0x13, 0x0B, 0, 0), dpiX: 72, dpiY: 72},
+		{file: []byte("GIF89a"), dpiX: 96, dpiY: 96},
+	} {
+		dpiX, dpiY := getImageDPI(c.file)
+		assert.Equal(t, c.dpiX, dpiX)
+		assert.Equal(t, c.dpiY, dpiY)
+	}
+}
+
 func TestAddPictureFromBytes(t *testing.T) {
 	f := NewFile()
 	imgFile, err := os.ReadFile("logo.png")
@@ -384,6 +529,30 @@ func TestGetPictureCells(t *testing.T) {
 	// Test get picture cells on not exists worksheet
 	_, err = f.GetPictureCells("SheetN")
 	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get picture cells and names of the pictures
+	png, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), &GraphicOptions{Name: "Logo"}))
+	assert.NoError(t, f.AddPicture("Sheet1", "C3", filepath.Join("test", "images", "excel.jpg"), &GraphicOptions{Positioning: "oneCell"}))
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "E5", &Picture{Extension: ".png", File: png, InsertType: PictureInsertTypePlaceInCell}))
+	check := func(f *File) {
+		cells, err := f.GetPictureCells("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"E5", "A1", "C3"}, cells)
+		for cell, name := range map[string]string{"A1": "Logo", "C3": "Picture 3", "E5": ""} {
+			pics, err := f.GetPictures("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Len(t, pics, 1)
+			assert.Equal(t, name, pics[0].Format.Name)
+		}
+	}
+	check(f)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetPictureCells.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestGetPictureCells.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	assert.NoError(t, f.Close())
 }
 
 func TestExtractDecodeCellAnchor(t *testing.T) {
@@ -391,4 +560,125 @@ func TestExtractDecodeCellAnchor(t *testing.T) {
 	cond := func(a *decodeCellAnchor) bool { return true }
 	cb := func(a *decodeCellAnchor, r *xlsxRelationship) {}
 	f.extractDecodeCellAnchor(&decodeCellAnchor{Content: string(MacintoshCyrillicCharset)}, "", cond, cb)
+	// Test extract decode cell anchor without the picture relationships
+	called := false
+	f.extractDecodeCellAnchor(&decodeCellAnchor{Content: `<from><col>0</col><row>0</row></from><pic><blipFill><blip r:embed="rId1"/></blipFill></pic>`}, "",
+		cond, func(a *decodeCellAnchor, r *xlsxRelationship) { called = true })
+	assert.False(t, called)
+}
+
+func TestAddPictureInCell(t *testing.T) {
+	f := NewFile()
+	png, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	jpg, err := os.ReadFile(filepath.Join("test", "images", "excel.jpg"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "value"))
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "A1", &Picture{
+		Extension: ".png", File: png, Format: &GraphicOptions{AltText: "Excel Logo"},
+		InsertType: PictureInsertTypePlaceInCell,
+	}))
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "B2", &Picture{
+		Extension: ".jpg", File: jpg, InsertType: PictureInsertTypePlaceInCell,
+	}))
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "C3", &Picture{
+		Extension: ".png", File: png, InsertType: PictureInsertTypePlaceInCell,
+	}))
+	assert.NoError(t, f.AddPicture("Sheet1", "D4", filepath.Join("test", "images", "excel.png"), nil))
+
+	check := func(f *File) {
+		pics, err := f.GetPictures("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		assert.Equal(t, PictureInsertTypePlaceInCell, pics[0].InsertType)
+		assert.Equal(t, ".png", pics[0].Extension)
+		assert.Equal(t, png, pics[0].File)
+		assert.Equal(t, "Excel Logo", pics[0].Format.AltText)
+		pics, err = f.GetPictures("Sheet1", "B2")
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		assert.Equal(t, ".jpeg", pics[0].Extension)
+		assert.Equal(t, jpg, pics[0].File)
+		assert.Empty(t, pics[0].Format.AltText)
+		pics, err = f.GetPictures("Sheet1", "D4")
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		assert.Equal(t, PictureInsertTypePlaceOverCells, pics[0].InsertType)
+		pics, err = f.GetPictures("Sheet1", "E5")
+		assert.NoError(t, err)
+		assert.Empty(t, pics)
+		cells, err := f.GetPictureCells("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"A1", "B2", "C3", "D4"}, cells)
+		val, err := f.GetCellValue("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Empty(t, val)
+		rows, err := f.GetRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Empty(t, rows)
+	}
+	check(f)
+	// Test the media and relationships of the same picture are shared
+	rels, err := f.relsReader("xl/richData/_rels/richValueRel.xml.rels")
+	assert.NoError(t, err)
+	assert.Len(t, rels.Relationships, 2)
+	rvRels := xlsxRichValueRels{}
+	_, err = f.richDataPartReader(SourceRelationshipRichValueRel, &rvRels)
+	assert.NoError(t, err)
+	assert.Len(t, rvRels.Rels, 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureInCell.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestAddPictureInCell.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	// Test add picture in cell for the workbook which has rich data parts
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "E5", &Picture{
+		Extension: ".jpg", File: jpg, Format: &GraphicOptions{AltText: "Excel Logo"},
+		InsertType: PictureInsertTypePlaceInCell,
+	}))
+	pics, err := f.GetPictures("Sheet1", "E5")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, jpg, pics[0].File)
+	var rvStructures xlsxRichValueStructures
+	_, err = f.richDataPartReader(SourceRelationshipRDRichValueStructure, &rvStructures)
+	assert.NoError(t, err)
+	assert.Len(t, rvStructures.S, 2)
+	assert.NoError(t, f.Close())
+
+	// Test add picture with unsupported insert type
+	f = NewFile()
+	assert.Equal(t, ErrParameterInvalid, f.AddPictureFromBytes("Sheet1", "A1", &Picture{Extension: ".png", File: png, InsertType: 0xFF}))
+	// Test add picture in cell with invalid sheet name and cell reference
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet:1", "A1", &Picture{Extension: ".png", File: png, InsertType: PictureInsertTypePlaceInCell}), ErrSheetNameInvalid.Error())
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A", &Picture{Extension: ".png", File: png, InsertType: PictureInsertTypePlaceInCell}),
+		newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.NoError(t, f.Close())
+
+	// Test add and get picture in cell with unsupported charset rich data parts
+	for _, part := range []string{
+		"xl/richData/richValueRel.xml", "xl/richData/rdrichvaluestructure.xml",
+		"xl/richData/rdrichvalue.xml", "xl/metadata.xml",
+	} {
+		f, err = OpenFile(filepath.Join("test", "TestAddPictureInCell.xlsx"))
+		assert.NoError(t, err)
+		f.Pkg.Store(part, MacintoshCyrillicCharset)
+		_, err = f.GetPictures("Sheet1", "A1")
+		assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+		_, err = f.GetPictureCells("Sheet1")
+		assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+		_, err = f.GetCellValue("Sheet1", "A1")
+		assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+		assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "E5", &Picture{Extension: ".png", File: png, InsertType: PictureInsertTypePlaceInCell}),
+			"XML syntax error on line 1: invalid UTF-8")
+		assert.NoError(t, f.Close())
+	}
+	// Test add picture in cell with unsupported charset workbook relationships
+	f = NewFile()
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddPictureFromBytes("Sheet1", "A1", &Picture{Extension: ".png", File: png, InsertType: PictureInsertTypePlaceInCell}),
+		"XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
 }
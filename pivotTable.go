@@ -32,6 +32,13 @@ import (
 //	PivotStyleLight1 - PivotStyleLight28
 //	PivotStyleMedium1 - PivotStyleMedium28
 //	PivotStyleDark1 - PivotStyleDark28
+//
+// ClassicLayout specifies whether to display the pivot table in the classic
+// layout, which enables dragging of fields in the grid, and shows the fields
+// in the tabular form.
+//
+// CalculatedFields specifies the calculated fields of the pivot table, which
+// can be used in the Data fields by the name of the calculated field.
 type PivotTableOptions struct {
 	pivotTableXML       string
 	pivotCacheXML       string
@@ -45,6 +52,7 @@ type PivotTableOptions struct {
 	Columns             []PivotTableField
 	Data                []PivotTableField
 	Filter              []PivotTableField
+	CalculatedFields    []PivotTableCalculatedField
 	RowGrandTotals      bool
 	ColGrandTotals      bool
 	ShowDrill           bool
@@ -53,6 +61,7 @@ type PivotTableOptions struct {
 	MergeItem           bool
 	CompactData         bool
 	ShowError           bool
+	ClassicLayout       bool
 	ShowRowHeaders      bool
 	ShowColHeaders      bool
 	ShowRowStripes      bool
@@ -80,6 +89,14 @@ type PivotTableOptions struct {
 //
 // Name specifies the name of the data field. Maximum 255 characters
 // are allowed in data field name, excess characters will be truncated.
+//
+// ShowAll specifies whether to show all items for the row, column and filter
+// field, even if they have no data. InsertBlankRow specifies whether to insert
+// a blank row after each item of the row field.
+//
+// NumFmt specifies the built-in number format index for the data field, and
+// CustomNumFmt specifies the custom number format code for the data field,
+// the CustomNumFmt takes precedence over NumFmt.
 type PivotTableField struct {
 	Compact         bool
 	Data            string
@@ -87,6 +104,19 @@ type PivotTableField struct {
 	Outline         bool
 	Subtotal        string
 	DefaultSubtotal bool
+	ShowAll         bool
+	InsertBlankRow  bool
+	NumFmt          int
+	CustomNumFmt    *string
+}
+
+// PivotTableCalculatedField directly maps the calculated field settings of
+// the pivot table. Name specifies the name of the calculated field, and
+// Formula specifies the formula of the calculated field which references the
+// other fields by field name, for example: Sales*0.1
+type PivotTableCalculatedField struct {
+	Name    string
+	Formula string
 }
 
 // AddPivotTable provides the method to add pivot table by given pivot table
@@ -198,6 +228,9 @@ func (f *File) parseFormatPivotTableSet(opts *PivotTableOptions) (*xlsxWorksheet
 	if err = f.getPivotTableDataRange(opts); err != nil {
 		return nil, "", err
 	}
+	if err = f.checkPivotTableCalculatedFields(opts); err != nil {
+		return nil, "", err
+	}
 	dataSheetName, _, err := f.adjustRange(opts.pivotDataRange)
 	if err != nil {
 		return nil, "", newPivotTableDataRangeError(err.Error())
@@ -243,6 +276,45 @@ func (f *File) adjustRange(rangeStr string) (string, []int, error) {
 	return rng[0], []int{x1, y1, x2, y2}, nil
 }
 
+// checkPivotTableCalculatedFields provides a function to validate the
+// calculated fields of the pivot table, the name of the calculated field
+// should be unique and can not be used in the row, column and filter fields.
+func (f *File) checkPivotTableCalculatedFields(opts *PivotTableOptions) error {
+	if len(opts.CalculatedFields) == 0 {
+		return nil
+	}
+	order, err := f.getTableFieldsOrder(opts)
+	if err != nil {
+		return err
+	}
+	for _, field := range opts.CalculatedFields {
+		if field.Name == "" || strings.TrimPrefix(field.Formula, "=") == "" ||
+			inStrSlice(order, field.Name, false) != -1 {
+			return ErrParameterInvalid
+		}
+		order = append(order, field.Name)
+		for _, fields := range [][]PivotTableField{opts.Rows, opts.Columns, opts.Filter} {
+			if inPivotTableField(fields, field.Name) != -1 {
+				return ErrParameterInvalid
+			}
+		}
+	}
+	return err
+}
+
+// getPivotFieldsOrder provides a function to get order list of pivot table
+// fields, includes the fields in the data range and calculated fields.
+func (f *File) getPivotFieldsOrder(opts *PivotTableOptions) ([]string, error) {
+	order, err := f.getTableFieldsOrder(opts)
+	if err != nil {
+		return order, err
+	}
+	for _, field := range opts.CalculatedFields {
+		order = append(order, field.Name)
+	}
+	return order, err
+}
+
 // getTableFieldsOrder provides a function to get order list of pivot table
 // fields.
 func (f *File) getTableFieldsOrder(opts *PivotTableOptions) ([]string, error) {
@@ -300,6 +372,13 @@ func (f *File) addPivotCache(opts *PivotTableOptions) error {
 			SharedItems: &xlsxSharedItems{ContainsBlank: true, M: []xlsxMissing{{}}},
 		})
 	}
+	for _, field := range opts.CalculatedFields {
+		pc.CacheFields.CacheField = append(pc.CacheFields.CacheField, &xlsxCacheField{
+			Name:          field.Name,
+			Formula:       strings.TrimPrefix(field.Formula, "="),
+			DatabaseField: boolPtr(false),
+		})
+	}
 	pc.CacheFields.Count = len(pc.CacheFields.CacheField)
 	pivotCache, err := xml.Marshal(pc)
 	f.saveFileList(opts.pivotCacheXML, pivotCache)
@@ -370,6 +449,10 @@ func (f *File) addPivotTable(cacheID, pivotTableID int, opts *PivotTableOptions)
 	if pt.Name == "" {
 		pt.Name = fmt.Sprintf("PivotTable%d", pivotTableID)
 	}
+	if opts.ClassicLayout {
+		pt.Compact, pt.CompactData, pt.Outline = boolPtr(false), boolPtr(false), boolPtr(false)
+		pt.GridDropZones = true
+	}
 	// pivot fields
 	_ = f.addPivotFields(&pt, opts)
 
@@ -380,7 +463,9 @@ func (f *File) addPivotTable(cacheID, pivotTableID int, opts *PivotTableOptions)
 	_ = f.addPivotRowFields(&pt, opts)
 	_ = f.addPivotColFields(&pt, opts)
 	_ = f.addPivotPageFields(&pt, opts)
-	_ = f.addPivotDataFields(&pt, opts)
+	if err = f.addPivotDataFields(&pt, opts); err != nil {
+		return err
+	}
 
 	pivotTable, err := xml.Marshal(pt)
 	f.saveFileList(opts.pivotTableXML, pivotTable)
@@ -447,6 +532,10 @@ func (f *File) addPivotDataFields(pt *xlsxPivotTableDefinition, opts *PivotTable
 	}
 	dataFieldsSubtotals := f.getPivotTableFieldsSubtotal(opts.Data)
 	dataFieldsName := f.getPivotTableFieldsName(opts.Data)
+	dataFieldsNumFmtID, err := f.getPivotTableFieldsNumFmtID(opts.Data)
+	if err != nil {
+		return err
+	}
 	for idx, dataField := range dataFieldsIndex {
 		if pt.DataFields == nil {
 			pt.DataFields = &xlsxDataFields{}
@@ -455,6 +544,7 @@ func (f *File) addPivotDataFields(pt *xlsxPivotTableDefinition, opts *PivotTable
 			Name:     dataFieldsName[idx],
 			Fld:      dataField,
 			Subtotal: dataFieldsSubtotals[idx],
+			NumFmtID: dataFieldsNumFmtID[idx],
 		})
 	}
 
@@ -521,7 +611,7 @@ func (f *File) addPivotColFields(pt *xlsxPivotTableDefinition, opts *PivotTableO
 // addPivotFields create pivot fields based on the column order of the first
 // row in the data region by given pivot table definition and option.
 func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOptions) error {
-	order, err := f.getTableFieldsOrder(opts)
+	order, err := f.getPivotFieldsOrder(opts)
 	if err != nil {
 		return err
 	}
@@ -542,6 +632,8 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 				DataField:       inPivotTableField(opts.Data, name) != -1,
 				Compact:         &rowOptions.Compact,
 				Outline:         &rowOptions.Outline,
+				ShowAll:         rowOptions.ShowAll,
+				InsertBlankRow:  rowOptions.InsertBlankRow,
 				DefaultSubtotal: &rowOptions.DefaultSubtotal,
 				Items: &xlsxItems{
 					Count: len(items),
@@ -551,10 +643,12 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 			continue
 		}
 		if inPivotTableField(opts.Filter, name) != -1 {
+			filterOptions, _ := f.getPivotTableFieldOptions(name, opts.Filter)
 			pt.PivotFields.PivotField = append(pt.PivotFields.PivotField, &xlsxPivotField{
 				Axis:      "axisPage",
 				DataField: inPivotTableField(opts.Data, name) != -1,
-				Name:      f.getPivotTableFieldName(name, opts.Columns),
+				Name:      f.getPivotTableFieldName(name, opts.Filter),
+				ShowAll:   filterOptions.ShowAll,
 				Items: &xlsxItems{
 					Count: 1,
 					Item: []*xlsxItem{
@@ -578,6 +672,7 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 				DataField:       inPivotTableField(opts.Data, name) != -1,
 				Compact:         &columnOptions.Compact,
 				Outline:         &columnOptions.Outline,
+				ShowAll:         columnOptions.ShowAll,
 				DefaultSubtotal: &columnOptions.DefaultSubtotal,
 				Items: &xlsxItems{
 					Count: len(items),
@@ -597,26 +692,28 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 	return err
 }
 
-// countPivotTables provides a function to get pivot table files count storage
-// in the folder xl/pivotTables.
+// countPivotTables provides a function to get the maximum index of the pivot
+// table files storage in the folder xl/pivotTables.
 func (f *File) countPivotTables() int {
-	count := 0
-	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/pivotTables/pivotTable") {
-			count++
-		}
-		return true
-	})
-	return count
+	return f.countPartIndex("xl/pivotTables/pivotTable")
 }
 
-// countPivotCache provides a function to get pivot table cache definition files
-// count storage in the folder xl/pivotCache.
+// countPivotCache provides a function to get the maximum index of the pivot
+// table cache definition files storage in the folder xl/pivotCache.
 func (f *File) countPivotCache() int {
+	return f.countPartIndex("xl/pivotCache/pivotCacheDefinition")
+}
+
+// countPartIndex provides a function to get the maximum index of the parts by
+// given part name prefix, such as the index 2 of xl/pivotTables/pivotTable2.xml.
+func (f *File) countPartIndex(prefix string) int {
 	count := 0
 	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/pivotCache/pivotCacheDefinition") {
-			count++
+		if strings.Contains(k.(string), prefix) {
+			name := k.(string)[strings.Index(k.(string), prefix)+len(prefix):]
+			if idx, err := strconv.Atoi(strings.TrimSuffix(name, ".xml")); err == nil && idx > count {
+				count = idx
+			}
 		}
 		return true
 	})
@@ -627,7 +724,7 @@ func (f *File) countPivotCache() int {
 // to a sequential index by given fields and pivot option.
 func (f *File) getPivotFieldsIndex(fields []PivotTableField, opts *PivotTableOptions) ([]int, error) {
 	var pivotFieldsIndex []int
-	orders, err := f.getTableFieldsOrder(opts)
+	orders, err := f.getPivotFieldsOrder(opts)
 	if err != nil {
 		return pivotFieldsIndex, err
 	}
@@ -657,6 +754,34 @@ func (f *File) getPivotTableFieldsSubtotal(fields []PivotTableField) []string {
 	return field
 }
 
+// getPivotTableFieldsNumFmtID prepare fields number format ID list by given
+// pivot table fields, the custom number format code will be added to the
+// styles part if it does not exist.
+func (f *File) getPivotTableFieldsNumFmtID(fields []PivotTableField) ([]string, error) {
+	field := make([]string, len(fields))
+	for idx, fld := range fields {
+		if fld.CustomNumFmt != nil && *fld.CustomNumFmt != "" {
+			s, err := f.stylesReader()
+			if err != nil {
+				return field, err
+			}
+			s.mu.Lock()
+			style := &Style{CustomNumFmt: fld.CustomNumFmt}
+			numFmtID := getCustomNumFmtID(s, style)
+			if numFmtID == -1 {
+				numFmtID = setCustomNumFmt(s, style)
+			}
+			s.mu.Unlock()
+			field[idx] = strconv.Itoa(numFmtID)
+			continue
+		}
+		if _, ok := builtInNumFmt[fld.NumFmt]; ok && fld.NumFmt > 0 {
+			field[idx] = strconv.Itoa(fld.NumFmt)
+		}
+	}
+	return field, nil
+}
+
 // getPivotTableFieldsName prepare fields name list by given pivot table
 // fields.
 func (f *File) getPivotTableFieldsName(fields []PivotTableField) []string {
@@ -722,7 +847,7 @@ func (f *File) GetPivotTables(sheet string) ([]PivotTableOptions, error) {
 		return pivotTables, ErrSheetNotExist{sheet}
 	}
 	rels := "xl/worksheets/_rels/" + strings.TrimPrefix(name, "xl/worksheets/") + ".rels"
-	sheetRels, err := f.relsReader(rels)
+	sheetRels, err := f.relsViewer(rels)
 	if err != nil {
 		return pivotTables, err
 	}
@@ -783,7 +908,7 @@ func (f *File) getPivotTableDataRange(opts *PivotTableOptions) error {
 // worksheet name, pivot table XML path and pivot cache relationship XML path.
 func (f *File) getPivotTable(sheet, pivotTableXML, pivotCacheRels string) (PivotTableOptions, error) {
 	var opts PivotTableOptions
-	rels, err := f.relsReader(pivotCacheRels)
+	rels, err := f.relsViewer(pivotCacheRels)
 	if err != nil {
 		return opts, err
 	}
@@ -802,11 +927,15 @@ func (f *File) getPivotTable(sheet, pivotTableXML, pivotCacheRels string) (Pivot
 	if err != nil {
 		return opts, err
 	}
+	dataSheet := sheet
+	if pc.CacheSource.WorksheetSource.Sheet != "" {
+		dataSheet = pc.CacheSource.WorksheetSource.Sheet
+	}
 	opts = PivotTableOptions{
 		pivotTableXML:   pivotTableXML,
 		pivotCacheXML:   pivotCacheXML,
 		pivotSheetName:  sheet,
-		DataRange:       fmt.Sprintf("%s!%s", sheet, pc.CacheSource.WorksheetSource.Ref),
+		DataRange:       fmt.Sprintf("%s!%s", dataSheet, pc.CacheSource.WorksheetSource.Ref),
 		PivotTableRange: fmt.Sprintf("%s!%s", sheet, pt.Location.Ref),
 		Name:            pt.Name,
 	}
@@ -830,11 +959,23 @@ func (f *File) getPivotTable(sheet, pivotTableXML, pivotCacheRels string) (Pivot
 		opts.ShowLastColumn = si.ShowLastColumn
 		opts.PivotTableStyleName = si.Name
 	}
+	opts.ClassicLayout = pt.GridDropZones
 	order, err := f.getTableFieldsOrder(&opts)
 	if err != nil {
 		return opts, err
 	}
-	f.extractPivotTableFields(order, pt, &opts)
+	if pc.CacheFields != nil {
+		for i := len(order); i < len(pc.CacheFields.CacheField); i++ {
+			cacheField := pc.CacheFields.CacheField[i]
+			order = append(order, cacheField.Name)
+			if cacheField.Formula != "" {
+				opts.CalculatedFields = append(opts.CalculatedFields, PivotTableCalculatedField{
+					Name: cacheField.Name, Formula: cacheField.Formula,
+				})
+			}
+		}
+	}
+	err = f.extractPivotTableFields(order, pt, &opts)
 	return opts, err
 }
 
@@ -868,7 +1009,7 @@ func (f *File) pivotCacheReader(path string) (*xlsxPivotCacheDefinition, error)
 
 // extractPivotTableFields provides a function to extract all pivot table fields
 // settings by given pivot table fields.
-func (f *File) extractPivotTableFields(order []string, pt *xlsxPivotTableDefinition, opts *PivotTableOptions) {
+func (f *File) extractPivotTableFields(order []string, pt *xlsxPivotTableDefinition, opts *PivotTableOptions) error {
 	for fieldIdx, field := range pt.PivotFields.PivotField {
 		if field.Axis == "axisRow" {
 			opts.Rows = append(opts.Rows, extractPivotTableField(order[fieldIdx], field))
@@ -882,13 +1023,46 @@ func (f *File) extractPivotTableFields(order []string, pt *xlsxPivotTableDefinit
 	}
 	if pt.DataFields != nil {
 		for _, field := range pt.DataFields.DataField {
-			opts.Data = append(opts.Data, PivotTableField{
+			dataField := PivotTableField{
 				Data:     order[field.Fld],
 				Name:     field.Name,
 				Subtotal: cases.Title(language.English).String(field.Subtotal),
-			})
+			}
+			if err := f.extractPivotTableFieldNumFmt(field.NumFmtID, &dataField); err != nil {
+				return err
+			}
+			opts.Data = append(opts.Data, dataField)
 		}
 	}
+	return nil
+}
+
+// extractPivotTableFieldNumFmt provides a function to extract the number
+// format settings of the pivot table data field by given number format ID.
+func (f *File) extractPivotTableFieldNumFmt(numFmtID string, fld *PivotTableField) error {
+	if numFmtID == "" {
+		return nil
+	}
+	ID, err := strconv.Atoi(numFmtID)
+	if err != nil {
+		return nil
+	}
+	if _, ok := builtInNumFmt[ID]; ok {
+		fld.NumFmt = ID
+		return nil
+	}
+	s, err := f.stylesViewer()
+	if err != nil {
+		return err
+	}
+	if s.NumFmts != nil {
+		for _, numFmt := range s.NumFmts.NumFmt {
+			if numFmt.NumFmtID == ID {
+				fld.CustomNumFmt = stringPtr(numFmt.FormatCode)
+			}
+		}
+	}
+	return nil
 }
 
 // extractPivotTableField provides a function to extract pivot table field
@@ -897,13 +1071,16 @@ func extractPivotTableField(data string, fld *xlsxPivotField) PivotTableField {
 	pivotTableField := PivotTableField{
 		Data: data,
 	}
-	fields := []string{"Compact", "Name", "Outline", "Subtotal", "DefaultSubtotal"}
+	fields := []string{"Compact", "Name", "Outline", "Subtotal", "DefaultSubtotal", "ShowAll", "InsertBlankRow"}
 	immutable, mutable := reflect.ValueOf(*fld), reflect.ValueOf(&pivotTableField).Elem()
 	for _, field := range fields {
 		immutableField := immutable.FieldByName(field)
 		if immutableField.Kind() == reflect.String {
 			mutable.FieldByName(field).SetString(immutableField.String())
 		}
+		if immutableField.Kind() == reflect.Bool {
+			mutable.FieldByName(field).SetBool(immutableField.Bool())
+		}
 		if immutableField.Kind() == reflect.Ptr && !immutableField.IsNil() && immutableField.Elem().Kind() == reflect.Bool {
 			mutable.FieldByName(field).SetBool(immutableField.Elem().Bool())
 		}
@@ -941,13 +1118,47 @@ func (f *File) genPivotCacheDefinitionID() int {
 	return ID + 1
 }
 
-// deleteWorkbookPivotCache remove workbook pivot cache and pivot cache
-// relationships.
+// deletePivotParts provides a function to remove the part, relationships
+// part and content type of the part by given part path and content type, the
+// parts of the pivot cache records referenced by the part will be removed too.
+func (f *File) deletePivotParts(partPath, contentType string) error {
+	partPath = strings.TrimPrefix(partPath, "/")
+	rels := filepath.ToSlash(filepath.Join(filepath.Dir(partPath), "_rels", filepath.Base(partPath)+".rels"))
+	partRels, err := f.relsReader(rels)
+	if err != nil {
+		return err
+	}
+	if partRels != nil {
+		for _, v := range partRels.Relationships {
+			if v.Type != SourceRelationshipPivotCacheRecords {
+				continue
+			}
+			target := strings.TrimPrefix(v.Target, "/")
+			if !strings.HasPrefix(v.Target, "/") {
+				target = filepath.ToSlash(filepath.Clean(filepath.Join(filepath.Dir(partPath), v.Target)))
+			}
+			f.Pkg.Delete(target)
+			if err = f.removeContentTypesPart(ContentTypeSpreadSheetMLPivotCacheRecords, "/"+target); err != nil {
+				return err
+			}
+		}
+	}
+	f.Pkg.Delete(partPath)
+	f.Pkg.Delete(rels)
+	f.Relationships.Delete(rels)
+	return f.removeContentTypesPart(contentType, "/"+partPath)
+}
+
+// deleteWorkbookPivotCache remove workbook pivot cache, pivot cache
+// relationships and the parts of the pivot cache.
 func (f *File) deleteWorkbookPivotCache(opt PivotTableOptions) error {
 	rID, err := f.deleteWorkbookRels(SourceRelationshipPivotCache, strings.TrimPrefix(strings.TrimPrefix(opt.pivotCacheXML, "/"), "xl/"))
 	if err != nil {
 		return err
 	}
+	if err = f.deletePivotParts(opt.pivotCacheXML, ContentTypeSpreadSheetMLPivotCacheDefinition); err != nil {
+		return err
+	}
 	wb, err := f.workbookReader()
 	if err != nil {
 		return err
@@ -966,8 +1177,10 @@ func (f *File) deleteWorkbookPivotCache(opt PivotTableOptions) error {
 }
 
 // DeletePivotTable delete a pivot table by giving the worksheet name and pivot
-// table name. Note that this function does not clean cell values in the pivot
-// table range.
+// table name. The pivot table part, relationships and content type will be
+// removed, and the pivot cache will be removed too if there is no other pivot
+// table use it. Note that this function does not clean cell values in the
+// pivot table range.
 func (f *File) DeletePivotTable(sheet, name string) error {
 	sheetXML, ok := f.getSheetXMLPath(sheet)
 	if !ok {
@@ -998,10 +1211,12 @@ func (f *File) DeletePivotTable(sheet, name string) error {
 				pivotTableXML := strings.ReplaceAll(v.Target, "..", "xl")
 				if opt.Name == name && opt.pivotTableXML == pivotTableXML {
 					if pivotTableCaches[opt.pivotCacheXML] == 1 {
-						err = f.deleteWorkbookPivotCache(opt)
+						if err = f.deleteWorkbookPivotCache(opt); err != nil {
+							return err
+						}
 					}
 					f.deleteSheetRelationships(sheet, v.ID)
-					return err
+					return f.deletePivotParts(pivotTableXML, ContentTypeSpreadSheetMLPivotTable)
 				}
 			}
 		}
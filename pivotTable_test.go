@@ -338,6 +338,177 @@ func TestPivotTable(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestPivotTableLayout(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Year", "Type", "Sales", "Region"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Jan", 2017, "Meat", 100, "East"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Feb", 2018, "Dairy", 200, "West"}))
+	expected := &PivotTableOptions{
+		pivotTableXML:       "xl/pivotTables/pivotTable1.xml",
+		pivotCacheXML:       "xl/pivotCache/pivotCacheDefinition1.xml",
+		DataRange:           "Sheet1!A1:E3",
+		PivotTableRange:     "Sheet1!G2:M34",
+		Name:                "PivotTable1",
+		Rows:                []PivotTableField{{Data: "Month", Name: "Month", DefaultSubtotal: true, ShowAll: true, InsertBlankRow: true}, {Data: "Year"}},
+		Filter:              []PivotTableField{{Data: "Region", Name: "Region", ShowAll: true}},
+		Columns:             []PivotTableField{{Data: "Type", DefaultSubtotal: true, ShowAll: true}},
+		Data:                []PivotTableField{{Data: "Sales", Subtotal: "Sum", Name: "Sum of Sales"}},
+		RowGrandTotals:      true,
+		ColGrandTotals:      true,
+		ClassicLayout:       true,
+		PivotTableStyleName: "PivotStyleLight16",
+	}
+	assert.NoError(t, f.AddPivotTable(expected))
+	pivotTables, err := f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 1)
+	assert.Equal(t, *expected, pivotTables[0])
+	pt, err := f.pivotTableReader("xl/pivotTables/pivotTable1.xml")
+	assert.NoError(t, err)
+	assert.True(t, pt.GridDropZones)
+	assert.False(t, *pt.Compact)
+	assert.False(t, *pt.CompactData)
+	assert.False(t, *pt.Outline)
+	assert.NoError(t, f.Close())
+}
+
+func TestPivotTableCalculatedFields(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Year", "Type", "Sales", "Region"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Jan", 2017, "Meat", 100, "East"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Feb", 2018, "Dairy", 200, "West"}))
+	expected := &PivotTableOptions{
+		pivotTableXML:    "xl/pivotTables/pivotTable1.xml",
+		pivotCacheXML:    "xl/pivotCache/pivotCacheDefinition1.xml",
+		DataRange:        "Sheet1!A1:E3",
+		PivotTableRange:  "Sheet1!G2:M34",
+		Name:             "PivotTable1",
+		Rows:             []PivotTableField{{Data: "Month"}},
+		CalculatedFields: []PivotTableCalculatedField{{Name: "Bonus", Formula: "=Sales*0.1"}},
+		Data: []PivotTableField{
+			{Data: "Sales", Subtotal: "Average", Name: "Average of Sales", NumFmt: 4},
+			{Data: "Sales", Subtotal: "Max", Name: "Max of Sales", CustomNumFmt: stringPtr("#,##0.000")},
+			{Data: "Bonus", Subtotal: "Sum", Name: "Sum of Bonus", CustomNumFmt: stringPtr("#,##0.000")},
+		},
+		PivotTableStyleName: "PivotStyleLight16",
+	}
+	assert.NoError(t, f.AddPivotTable(expected))
+	pivotTables, err := f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 1)
+	expected.CalculatedFields[0].Formula = "Sales*0.1"
+	assert.Equal(t, *expected, pivotTables[0])
+	pc, err := f.pivotCacheReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	assert.Len(t, pc.CacheFields.CacheField, 6)
+	assert.False(t, *pc.CacheFields.CacheField[5].DatabaseField)
+	// Test the same custom number format code only be added once
+	styleSheet, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Len(t, styleSheet.NumFmts.NumFmt, 1)
+	// Test add pivot table with invalid calculated fields
+	for _, calculatedFields := range [][]PivotTableCalculatedField{
+		{{Formula: "Sales*0.1"}},
+		{{Name: "Bonus", Formula: "="}},
+		{{Name: "Sales", Formula: "Sales*0.1"}},
+		{{Name: "Bonus", Formula: "Sales*0.1"}, {Name: "Bonus", Formula: "Sales*0.2"}},
+		{{Name: "Month", Formula: "Sales*0.1"}},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AddPivotTable(&PivotTableOptions{
+			DataRange:        "Sheet1!A1:E3",
+			PivotTableRange:  "Sheet1!O2:U34",
+			Rows:             []PivotTableField{{Data: "Month"}},
+			CalculatedFields: calculatedFields,
+			Data:             []PivotTableField{{Data: "Sales"}},
+		}))
+	}
+	assert.Equal(t, ErrParameterInvalid, f.AddPivotTable(&PivotTableOptions{
+		DataRange:        "Sheet1!A1:E3",
+		PivotTableRange:  "Sheet1!O2:U34",
+		Rows:             []PivotTableField{{Data: "Bonus"}},
+		CalculatedFields: []PivotTableCalculatedField{{Name: "Bonus", Formula: "Sales*0.1"}},
+	}))
+	// Test add pivot table with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:E3",
+		PivotTableRange: "Sheet1!O2:U34",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Data:            []PivotTableField{{Data: "Sales", CustomNumFmt: stringPtr("0.0")}},
+	}), "XML syntax error on line 1: invalid UTF-8")
+	// Test get pivot tables with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetPivotTables("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestDeletePivotTable(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Year", "Type", "Sales", "Region"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Jan", 2017, "Meat", 100, "East"}))
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for _, pivotTableRange := range []string{"Sheet2!A1:E10", "Sheet2!G1:K10"} {
+		assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+			DataRange:       "Sheet1!A1:E2",
+			PivotTableRange: pivotTableRange,
+			Rows:            []PivotTableField{{Data: "Month"}},
+			Data:            []PivotTableField{{Data: "Sales"}},
+		}))
+	}
+	// Test get pivot tables with the data range on the other worksheet
+	pivotTables, err := f.GetPivotTables("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 2)
+	assert.Equal(t, "Sheet1!A1:E2", pivotTables[0].DataRange)
+	// Test delete pivot table with the pivot cache records
+	f.Pkg.Store("xl/pivotCache/pivotCacheRecords1.xml", []byte(`<pivotCacheRecords count="0"/>`))
+	f.Pkg.Store("xl/pivotCache/_rels/pivotCacheDefinition1.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="`+SourceRelationshipPivotCacheRecords+`" Target="pivotCacheRecords1.xml"/></Relationships>`))
+	assert.NoError(t, f.DeletePivotTable("Sheet2", "PivotTable1"))
+	for _, part := range []string{
+		"xl/pivotTables/pivotTable1.xml",
+		"xl/pivotTables/_rels/pivotTable1.xml.rels",
+		"xl/pivotCache/pivotCacheDefinition1.xml",
+		"xl/pivotCache/_rels/pivotCacheDefinition1.xml.rels",
+		"xl/pivotCache/pivotCacheRecords1.xml",
+	} {
+		_, ok := f.Pkg.Load(part)
+		assert.False(t, ok, part)
+	}
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, override := range content.Overrides {
+		assert.NotEqual(t, "/xl/pivotTables/pivotTable1.xml", override.PartName)
+		assert.NotEqual(t, "/xl/pivotCache/pivotCacheDefinition1.xml", override.PartName)
+	}
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Len(t, wb.PivotCaches.PivotCache, 1)
+	// Test add pivot table after deleted pivot table
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:E2",
+		PivotTableRange: "Sheet2!A20:E30",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+	}))
+	pivotTables, err = f.GetPivotTables("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 2)
+	assert.Equal(t, "xl/pivotTables/pivotTable3.xml", pivotTables[1].pivotTableXML)
+	assert.Equal(t, "xl/pivotCache/pivotCacheDefinition3.xml", pivotTables[1].pivotCacheXML)
+	// Test delete pivot table with unsupported charset pivot cache relationships
+	f.Pkg.Store("xl/pivotCache/_rels/pivotCacheDefinition2.xml.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeletePivotTable("Sheet2", "PivotTable2"), "XML syntax error on line 1: invalid UTF-8")
+	// Test delete pivot table with unsupported charset content types
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeletePivotTable("Sheet2", "PivotTable3"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestPivotTableDataRange(t *testing.T) {
 	f := NewFile()
 	// Create table in a worksheet
@@ -17,7 +17,9 @@ import (
 	"io"
 	"math"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/mohae/deepcopy"
 )
@@ -28,7 +30,8 @@ import (
 // the applied value will be used, otherwise the original value will be used.
 // GetRows fetched the rows with value or formula cells, the continually blank
 // cells in the tail of each row will be skipped, so the length of each row
-// may be inconsistent.
+// may be inconsistent. Use the KeepTrailingEmpty option to keep the trailing
+// blank cells and rows, and get the rows with the same length.
 //
 // For example, get and traverse the value of all cells by rows on a worksheet
 // named 'Sheet1':
@@ -44,12 +47,21 @@ import (
 //	    }
 //	    fmt.Println()
 //	}
+//
+// For example, get the raw value of all cells by rows, and keep the trailing
+// blank cells and rows on a worksheet named 'Sheet1':
+//
+//	rows, err := f.GetRows("Sheet1", excelize.Options{
+//	    RawCellValue:      true,
+//	    KeepTrailingEmpty: true,
+//	})
 func (f *File) GetRows(sheet string, opts ...Options) ([][]string, error) {
 	rows, err := f.Rows(sheet)
 	if err != nil {
 		return nil, err
 	}
-	results, cur, max := make([][]string, 0, 64), 0, 0
+	options := getOptions(opts...)
+	results, cur, max, maxCols := make([][]string, 0, 64), 0, 0, 0
 	for rows.Next() {
 		cur++
 		row, err := rows.Columns(opts...)
@@ -57,18 +69,58 @@ func (f *File) GetRows(sheet string, opts ...Options) ([][]string, error) {
 			break
 		}
 		results = append(results, row)
-		if len(row) > 0 {
+		if len(row) > 0 || options.KeepTrailingEmpty {
 			max = cur
 		}
+		if len(row) > maxCols {
+			maxCols = len(row)
+		}
+	}
+	if options.KeepTrailingEmpty {
+		for i := range results {
+			results[i] = append(results[i], make([]string, maxCols-len(results[i]))...)
+		}
 	}
 	return results[:max], rows.Close()
 }
 
+// WalkRows traverse the rows in a sheet by given worksheet name, and invoke
+// the callback function with the row number and the value of cells in the
+// row during streaming reading, without building the whole two-dimensional
+// array of the worksheet. The rows without value or formula cells will be
+// skipped, and the continually blank cells in the tail of each row will be
+// skipped too. Stop the traversal by returning false in the callback
+// function. For example, print the value of cells by rows on a worksheet
+// named 'Sheet1':
+//
+//	err := f.WalkRows("Sheet1", func(rowNum int, cells []string) bool {
+//	    fmt.Println(rowNum, cells)
+//	    return true
+//	})
+func (f *File) WalkRows(sheet string, fn func(rowNum int, cells []string) bool, opts ...Options) error {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		row, err := rows.Columns(opts...)
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+		if len(row) > 0 && !fn(rows.seekRow, row) {
+			break
+		}
+	}
+	return rows.Close()
+}
+
 // Rows defines an iterator to a sheet.
 type Rows struct {
 	err                     error
 	curRow, seekRow         int
-	needClose, rawCellValue bool
+	needClose               bool
+	options                 *Options
 	sheet                   string
 	f                       *File
 	tempFile                *os.File
@@ -80,6 +132,9 @@ type Rows struct {
 
 // Next will return true if it finds the next row element.
 func (rows *Rows) Next() bool {
+	if rows.decoder == nil {
+		return false
+	}
 	rows.seekRow++
 	if rows.curRow >= rows.seekRow {
 		rows.curRowOpts = rows.seekRowOpts
@@ -119,27 +174,84 @@ func (rows *Rows) Error() error {
 	return rows.err
 }
 
-// Close closes the open worksheet XML file in the system temporary
-// directory.
+// Close closes the open worksheet XML file in the system temporary directory
+// and releases the decoder and shared strings table held by the iterator.
+// The iterator can't be used after it was closed, and calling this function
+// more than once is safe.
 func (rows *Rows) Close() error {
+	rows.decoder, rows.token, rows.sst = nil, nil, nil
 	if rows.tempFile != nil {
-		return rows.tempFile.Close()
+		tempFile := rows.tempFile
+		rows.tempFile = nil
+		return tempFile.Close()
 	}
 	return nil
 }
 
 // Columns return the current row's column values. This fetches the worksheet
 // data as a stream, returns each cell in a row as is, and will not skip empty
-// rows in the tail of the worksheet.
+// rows in the tail of the worksheet. The continually blank cells in the tail
+// of the row will be skipped unless the KeepTrailingEmpty option is enabled.
 func (rows *Rows) Columns(opts ...Options) ([]string, error) {
-	if rows.curRow > rows.seekRow {
-		return nil, nil
-	}
 	var rowIterator rowXMLIterator
+	err := rows.readRow(&rowIterator, opts...)
+	return rowIterator.cells, err
+}
+
+// RowCell directly maps the metadata of a cell which read by the rows
+// iterator. The Value is the formatted cell value, or the raw cell value if
+// the RawCellValue option was specified. The RawValue is the cell value
+// without number format applied. The Formula only contains the formula text
+// of the cell, and the dependent cells of a shared formula will get an empty
+// formula.
+type RowCell struct {
+	Cell     string
+	Type     CellType
+	StyleID  int
+	Formula  string
+	RawValue string
+	Value    string
+}
+
+// Cells return the current row's cells with the metadata, including cell
+// reference, data type, style index, formula and value of each cell. This
+// fetches the worksheet data as a stream, and only returns the cells which
+// exists in the worksheet. For example, get style index and formula of each
+// cell on the worksheet named 'Sheet1':
+//
+//	rows, err := f.Rows("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for rows.Next() {
+//	    cells, err := rows.Cells()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    for _, cell := range cells {
+//	        fmt.Println(cell.Cell, cell.StyleID, cell.Formula, cell.Value)
+//	    }
+//	}
+//	if err = rows.Close(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (rows *Rows) Cells(opts ...Options) ([]RowCell, error) {
+	rowIterator := rowXMLIterator{withMetadata: true}
+	err := rows.readRow(&rowIterator, opts...)
+	return rowIterator.rowCells, err
+}
+
+// readRow parse the current row's cells of the worksheet by given row
+// iterator.
+func (rows *Rows) readRow(rowIterator *rowXMLIterator, opts ...Options) error {
+	if rows.decoder == nil || rows.curRow > rows.seekRow {
+		return nil
+	}
 	var token xml.Token
-	rows.rawCellValue = getOptions(opts...).RawCellValue
-	if rows.sst, rowIterator.err = rows.f.sharedStringsReader(); rowIterator.err != nil {
-		return rowIterator.cells, rowIterator.err
+	rows.options = getOptions(opts...)
+	if rows.sst, rowIterator.err = rows.f.sharedStringsViewer(); rowIterator.err != nil {
+		return rowIterator.err
 	}
 	for {
 		if rows.token != nil {
@@ -161,21 +273,22 @@ func (rows *Rows) Columns(opts ...Options) ([]string, error) {
 				rows.seekRowOpts = extractRowOpts(xmlElement.Attr)
 				if rows.curRow > rows.seekRow {
 					rows.token = nil
-					return rowIterator.cells, rowIterator.err
+					return rowIterator.err
 				}
+				rowIterator.cellRow = rows.curRow
 			}
-			if rows.rowXMLHandler(&rowIterator, &xmlElement, rows.rawCellValue); rowIterator.err != nil {
+			if rows.rowXMLHandler(rowIterator, &xmlElement, rows.options); rowIterator.err != nil {
 				rows.token = nil
-				return rowIterator.cells, rowIterator.err
+				return rowIterator.err
 			}
 			rows.token = nil
 		case xml.EndElement:
 			if xmlElement.Name.Local == "sheetData" {
-				return rowIterator.cells, rowIterator.err
+				return rowIterator.err
 			}
 		}
 	}
-	return rowIterator.cells, rowIterator.err
+	return rowIterator.err
 }
 
 // extractRowOpts extract row element attributes.
@@ -207,10 +320,12 @@ type rowXMLIterator struct {
 	inElement        string
 	cellCol, cellRow int
 	cells            []string
+	withMetadata     bool
+	rowCells         []RowCell
 }
 
 // rowXMLHandler parse the row XML element of the worksheet.
-func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.StartElement, raw bool) {
+func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.StartElement, opts *Options) {
 	if rowIterator.inElement == "c" {
 		rowIterator.cellCol++
 		colCell := xlsxC{}
@@ -220,13 +335,34 @@ func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 				return
 			}
 		}
+		if rowIterator.withMetadata {
+			rowIterator.rowCells = append(rowIterator.rowCells, rows.rowCell(rowIterator, &colCell, opts))
+			return
+		}
 		blank := rowIterator.cellCol - len(rowIterator.cells)
-		if val, _ := colCell.getValueFrom(rows.f, rows.sst, raw); val != "" || colCell.F != nil {
+		if val, _ := colCell.getValueFrom(rows.f, rows.sst, opts); val != "" || colCell.F != nil || opts.KeepTrailingEmpty {
 			rowIterator.cells = append(appendSpace(blank, rowIterator.cells), val)
 		}
 	}
 }
 
+// rowCell returns the metadata of the cell by given row iterator and decoded
+// cell element.
+func (rows *Rows) rowCell(rowIterator *rowXMLIterator, c *xlsxC, opts *Options) RowCell {
+	cell := RowCell{Cell: c.R, Type: cellTypes[c.T], StyleID: c.S}
+	if cell.Cell == "" {
+		cell.Cell, _ = CoordinatesToCellName(rowIterator.cellCol, rowIterator.cellRow)
+	}
+	if c.F != nil {
+		cell.Formula = c.F.Content
+	}
+	cell.RawValue, _ = c.getValueFrom(rows.f, rows.sst, &Options{RawCellValue: true})
+	if cell.Value = cell.RawValue; !opts.RawCellValue {
+		cell.Value, _ = c.getValueFrom(rows.f, rows.sst, opts)
+	}
+	return cell
+}
+
 // Rows returns a rows iterator, used for streaming reading data for a
 // worksheet with a large data. This function is concurrency safe. For
 // example:
@@ -272,17 +408,24 @@ func (f *File) Rows(sheet string) (*Rows, error) {
 }
 
 // getFromStringItem build shared string item offset list from system temporary
-// file at one time, and return value by given to string index.
+// file at one time, and return value by given to string index. The recently
+// used string items will be kept in a fixed capacity cache, to avoid reading
+// the system temporary file frequently without loading the whole shared
+// strings table into memory.
 func (f *File) getFromStringItem(index int) string {
 	if f.sharedStringTemp != nil {
 		if len(f.sharedStringItem) <= index {
 			return strconv.Itoa(index)
 		}
+		if val, ok := f.sharedStringLRU.get(index); ok {
+			return val
+		}
 		offsetRange := f.sharedStringItem[index]
 		buf := make([]byte, offsetRange[1]-offsetRange[0])
 		if _, err := f.sharedStringTemp.ReadAt(buf, int64(offsetRange[0])); err != nil {
 			return strconv.Itoa(index)
 		}
+		f.sharedStringLRU.set(index, string(buf))
 		return string(buf)
 	}
 	needClose, decoder, tempFile, err := f.xmlDecoder(defaultXMLPathSharedStrings)
@@ -291,7 +434,7 @@ func (f *File) getFromStringItem(index int) string {
 			err = tempFile.Close()
 		}()
 	}
-	f.sharedStringItem = [][]uint{}
+	f.sharedStringItem, f.sharedStringLRU = [][]uint{}, newLRUCache(defaultSharedStringsCache)
 	f.sharedStringTemp, _ = os.CreateTemp(os.TempDir(), "excelize-")
 	f.tempFiles.Store(defaultTempFileSST, f.sharedStringTemp.Name())
 	var (
@@ -352,9 +495,7 @@ func (f *File) SetRowHeight(sheet string, row int, height float64) error {
 		return err
 	}
 
-	ws.prepareSheetXML(0, row)
-
-	rowIdx := row - 1
+	rowIdx, _ := ws.prepareSheetXML(0, row)
 	ws.SheetData.Row[rowIdx].Ht = float64Ptr(height)
 	ws.SheetData.Row[rowIdx].CustomHeight = true
 	return nil
@@ -363,7 +504,7 @@ func (f *File) SetRowHeight(sheet string, row int, height float64) error {
 // getRowHeight provides a function to get row height in pixels by given sheet
 // name and row number.
 func (f *File) getRowHeight(sheet string, row int) int {
-	ws, _ := f.workSheetReader(sheet)
+	ws, _ := f.workSheetViewer(sheet)
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 	for i := range ws.SheetData.Row {
@@ -388,28 +529,43 @@ func (f *File) GetRowHeight(sheet string, row int) (float64, error) {
 		return defaultRowHeight, newInvalidRowNumberError(row)
 	}
 	ht := defaultRowHeight
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		return ht, err
 	}
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
 	if ws.SheetFormatPr != nil && ws.SheetFormatPr.CustomHeight {
 		ht = ws.SheetFormatPr.DefaultRowHeight
 	}
-	if row > len(ws.SheetData.Row) {
-		return ht, nil // it will be better to use 0, but we take care with BC
-	}
-	for _, v := range ws.SheetData.Row {
-		if v.R == row && v.Ht != nil {
-			return *v.Ht, nil
-		}
+	if rowIdx := ws.searchRow(row); rowIdx != -1 && ws.SheetData.Row[rowIdx].Ht != nil {
+		return *ws.SheetData.Row[rowIdx].Ht, nil
 	}
 	// Optimization for when the row heights haven't changed.
 	return ht, nil
 }
 
 // sharedStringsReader provides a function to get the pointer to the structure
-// after deserialization of xl/sharedStrings.xml.
+// after deserialization of xl/sharedStrings.xml, and track the shared string
+// table was modified.
 func (f *File) sharedStringsReader() (*xlsxSST, error) {
+	return f.getSharedStrings(false)
+}
+
+// sharedStringsViewer provides a function to get the pointer to the structure
+// after deserialization of xl/sharedStrings.xml for read only. If the shared
+// string table was loaded by this function and never be got by the
+// sharedStringsReader, the original part will be copied verbatim on save
+// instead of serialize the structure.
+func (f *File) sharedStringsViewer() (*xlsxSST, error) {
+	return f.getSharedStrings(true)
+}
+
+// getSharedStrings provides a function to get the pointer to the structure
+// after deserialization of xl/sharedStrings.xml, and track if the shared
+// string table was modified. The content type and the workbook relationship
+// of the shared string table will be added when getting it for writing.
+func (f *File) getSharedStrings(readOnly bool) (*xlsxSST, error) {
 	var err error
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -433,22 +589,28 @@ func (f *File) sharedStringsReader() (*xlsxSST, error) {
 				f.sharedStringsMap[sharedStrings.SI[i].T.Val] = i
 			}
 		}
-		if err = f.addContentTypePart(0, "sharedStrings"); err != nil {
-			return f.SharedStrings, err
-		}
-		rels, err := f.relsReader(relPath)
-		if err != nil {
-			return f.SharedStrings, err
+		if readOnly {
+			f.unmodified.Store(defaultXMLPathSharedStrings, true)
+			return f.SharedStrings, nil
 		}
-		for _, rel := range rels.Relationships {
-			if rel.Target == "/xl/sharedStrings.xml" {
-				return f.SharedStrings, nil
-			}
+	} else if _, ok := f.unmodified.Load(defaultXMLPathSharedStrings); !ok || readOnly {
+		return f.SharedStrings, nil
+	}
+	f.unmodified.Delete(defaultXMLPathSharedStrings)
+	if err = f.addContentTypePart(0, "sharedStrings"); err != nil {
+		return f.SharedStrings, err
+	}
+	rels, err := f.relsReader(relPath)
+	if err != nil {
+		return f.SharedStrings, err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.Target == "/xl/sharedStrings.xml" {
+			return f.SharedStrings, nil
 		}
-		// Update workbook.xml.rels
-		f.addRels(relPath, SourceRelationshipSharedStrings, "/xl/sharedStrings.xml", "")
 	}
-
+	// Update workbook.xml.rels
+	f.addRels(relPath, SourceRelationshipSharedStrings, "/xl/sharedStrings.xml", "")
 	return f.SharedStrings, nil
 }
 
@@ -465,8 +627,8 @@ func (f *File) SetRowVisible(sheet string, row int, visible bool) error {
 	if err != nil {
 		return err
 	}
-	ws.prepareSheetXML(0, row)
-	ws.SheetData.Row[row-1].Hidden = !visible
+	rowIdx, _ := ws.prepareSheetXML(0, row)
+	ws.SheetData.Row[rowIdx].Hidden = !visible
 	return nil
 }
 
@@ -479,15 +641,18 @@ func (f *File) GetRowVisible(sheet string, row int) (bool, error) {
 	if row < 1 {
 		return false, newInvalidRowNumberError(row)
 	}
-
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		return false, err
 	}
-	if row > len(ws.SheetData.Row) {
-		return false, nil
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+	rowIdx, ok := ws.rowIndex(row)
+	if !ok {
+		// the row after the last row is invisible for backward compatibility
+		return rowIdx < len(ws.SheetData.Row), nil
 	}
-	return !ws.SheetData.Row[row-1].Hidden, nil
+	return !ws.SheetData.Row[rowIdx].Hidden, nil
 }
 
 // SetRowOutlineLevel provides a function to set outline level number of a
@@ -506,8 +671,8 @@ func (f *File) SetRowOutlineLevel(sheet string, row int, level uint8) error {
 	if err != nil {
 		return err
 	}
-	ws.prepareSheetXML(0, row)
-	ws.SheetData.Row[row-1].OutlineLevel = level
+	rowIdx, _ := ws.prepareSheetXML(0, row)
+	ws.SheetData.Row[rowIdx].OutlineLevel = level
 	return nil
 }
 
@@ -520,14 +685,118 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 	if row < 1 {
 		return 0, newInvalidRowNumberError(row)
 	}
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		return 0, err
 	}
-	if row > len(ws.SheetData.Row) {
+	rowIdx := ws.searchRow(row)
+	if rowIdx == -1 {
 		return 0, nil
 	}
-	return ws.SheetData.Row[row-1].OutlineLevel, nil
+	return ws.SheetData.Row[rowIdx].OutlineLevel, nil
+}
+
+// GroupRows provides a function to group the rows by given worksheet name and
+// rows range, which will increase the outline level of the rows by 1. The
+// maximum outline level is 7. For example, group the rows 2 to 5 in Sheet1:
+//
+//	err := f.GroupRows("Sheet1", 2, 5)
+func (f *File) GroupRows(sheet string, start, end int) error {
+	return f.adjustRowsOutlineLevel(sheet, start, end, 1)
+}
+
+// UngroupRows provides a function to ungroup the rows by given worksheet name
+// and rows range, which will decrease the outline level of the rows by 1. For
+// example, ungroup the rows 2 to 5 in Sheet1:
+//
+//	err := f.UngroupRows("Sheet1", 2, 5)
+func (f *File) UngroupRows(sheet string, start, end int) error {
+	return f.adjustRowsOutlineLevel(sheet, start, end, -1)
+}
+
+// adjustRowsOutlineLevel provides a function to adjust the outline level of
+// the rows by given worksheet name, rows range and offset.
+func (f *File) adjustRowsOutlineLevel(sheet string, start, end, offset int) error {
+	if end < start {
+		start, end = end, start
+	}
+	if start < 1 {
+		return newInvalidRowNumberError(start)
+	}
+	if end > TotalRows {
+		return ErrMaxRows
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	for row := start; row <= end; row++ {
+		if rowIdx := ws.searchRow(row); rowIdx != -1 && int(ws.SheetData.Row[rowIdx].OutlineLevel)+offset > 7 {
+			return ErrOutlineLevel
+		}
+	}
+	for row := start; row <= end; row++ {
+		rowIdx := ws.searchRow(row)
+		if rowIdx == -1 {
+			if offset < 0 {
+				continue
+			}
+			rowIdx, _ = ws.prepareSheetXML(0, row)
+		}
+		if level := int(ws.SheetData.Row[rowIdx].OutlineLevel) + offset; level >= 0 {
+			ws.SheetData.Row[rowIdx].OutlineLevel = uint8(level)
+		}
+	}
+	var maxLevel uint8
+	for _, row := range ws.SheetData.Row {
+		if row.OutlineLevel > maxLevel {
+			maxLevel = row.OutlineLevel
+		}
+	}
+	if ws.SheetFormatPr == nil {
+		ws.SheetFormatPr = &xlsxSheetFormatPr{DefaultRowHeight: defaultRowHeight}
+	}
+	ws.SheetFormatPr.OutlineLevelRow = maxLevel
+	return err
+}
+
+// SetRowsCollapsed provides a function to collapse or expand the grouped rows
+// by given worksheet name, rows range and collapsed state. The rows in the
+// range will be hidden when collapsed, and the collapsed state will be set on
+// the summary row, which is the row below the range by default, or the row
+// above the range if the OutlineSummaryBelow of the worksheet properties was
+// set to false. For example, collapse the rows 2 to 5 in Sheet1:
+//
+//	err := f.SetRowsCollapsed("Sheet1", 2, 5, true)
+func (f *File) SetRowsCollapsed(sheet string, start, end int, collapsed bool) error {
+	if end < start {
+		start, end = end, start
+	}
+	if start < 1 {
+		return newInvalidRowNumberError(start)
+	}
+	if end > TotalRows {
+		return ErrMaxRows
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	summaryRow := end + 1
+	if ws.SheetPr != nil && ws.SheetPr.OutlinePr != nil &&
+		ws.SheetPr.OutlinePr.SummaryBelow != nil && !*ws.SheetPr.OutlinePr.SummaryBelow {
+		summaryRow = start - 1
+	}
+	for row := start; row <= end; row++ {
+		rowIdx, _ := ws.prepareSheetXML(0, row)
+		ws.SheetData.Row[rowIdx].Hidden = collapsed
+	}
+	if summaryRow < 1 || summaryRow > TotalRows {
+		return err
+	}
+	rowIdx, _ := ws.prepareSheetXML(0, summaryRow)
+	ws.SheetData.Row[rowIdx].Collapsed = collapsed
+	return err
 }
 
 // RemoveRow provides a function to remove single row by given worksheet name
@@ -540,27 +809,64 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
 func (f *File) RemoveRow(sheet string, row int) error {
+	return f.RemoveRows(sheet, row, 1)
+}
+
+// RemoveRows provides a function to remove rows by given worksheet name, the
+// first Excel row number to be removed and number of rows, the references
+// will be adjusted only once. For example, remove rows 3 to 7 in Sheet1:
+//
+//	err := f.RemoveRows("Sheet1", 3, 5)
+//
+// Use this method with caution, which will affect changes in references such
+// as formulas, charts, and so on. If there is any referenced value of the
+// worksheet, it will cause a file error when you open it. The excelize only
+// partially updates these references currently.
+func (f *File) RemoveRows(sheet string, row, n int) error {
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
-
+	if n < 1 {
+		return ErrParameterInvalid
+	}
+	if n > TotalRows {
+		return ErrMaxRows
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
-	if row > len(ws.SheetData.Row) {
-		return f.adjustHelper(sheet, rows, row, -1)
-	}
 	keep := 0
 	for rowIdx := 0; rowIdx < len(ws.SheetData.Row); rowIdx++ {
 		v := &ws.SheetData.Row[rowIdx]
-		if v.R != row {
+		if v.R < row || v.R >= row+n {
 			ws.SheetData.Row[keep] = *v
 			keep++
 		}
 	}
 	ws.SheetData.Row = ws.SheetData.Row[:keep]
-	return f.adjustHelper(sheet, rows, row, -1)
+	return f.adjustHelper(sheet, rows, row, -n)
+}
+
+// MoveRow provides a function to move a row to the destination position by
+// given worksheet name, source and destination Excel row number. The rows
+// between the source and destination will be shifted by one, and the cell
+// references, merged cells, hyperlinks, formulas and defined names which refer
+// to the worksheet will be updated. For example, move row 3 to row 7 in
+// Sheet1, and the original rows 4 to 7 will be shifted up as rows 3 to 6:
+//
+//	err := f.MoveRow("Sheet1", 3, 7)
+func (f *File) MoveRow(sheet string, row, dest int) error {
+	if row < 1 {
+		return newInvalidRowNumberError(row)
+	}
+	if dest < 1 {
+		return newInvalidRowNumberError(dest)
+	}
+	if row > TotalRows || dest > TotalRows {
+		return ErrMaxRows
+	}
+	return f.moveHelper(sheet, rows, row, dest)
 }
 
 // InsertRows provides a function to insert new rows after the given Excel row
@@ -599,7 +905,9 @@ func (f *File) DuplicateRow(sheet string, row int) error {
 }
 
 // DuplicateRowTo inserts a copy of specified row by it Excel number
-// to specified row position moving down exists rows after target position
+// to specified row position moving down exists rows after target position.
+// The height, outline level, single row merged cells, comments, hyperlinks,
+// data validations and conditional formats of the row will be copied too.
 //
 //	err := f.DuplicateRowTo("Sheet1", 2, 7)
 //
@@ -631,33 +939,29 @@ func (f *File) DuplicateRowTo(sheet string, row, row2 int) error {
 			break
 		}
 	}
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return err
+	}
 
 	if err := f.adjustHelper(sheet, rows, row2, 1); err != nil {
 		return err
 	}
-
-	if !ok {
-		return nil
+	if err := f.duplicateRowObjects(sheet, ws, comments, row, row2); err != nil {
+		return err
 	}
 
-	idx2 := -1
-	for i, r := range ws.SheetData.Row {
-		if r.R == row2 {
-			idx2 = i
-			break
-		}
-	}
-	if idx2 == -1 && len(ws.SheetData.Row) >= row2 {
+	if !ok {
 		return nil
 	}
 
 	rowCopy.C = append(make([]xlsxC, 0, len(rowCopy.C)), rowCopy.C...)
 	f.adjustSingleRowDimensions(&rowCopy, row2, row2-row, true)
 
-	if idx2 != -1 {
+	if idx2, ok := ws.rowIndex(row2); ok {
 		ws.SheetData.Row[idx2] = rowCopy
 	} else {
-		ws.SheetData.Row = append(ws.SheetData.Row, rowCopy)
+		ws.insertRowData(idx2, rowCopy)
 	}
 	return f.duplicateMergeCells(sheet, ws, row, row2)
 }
@@ -695,40 +999,207 @@ func (f *File) duplicateMergeCells(sheet string, ws *xlsxWorksheet, row, row2 in
 	return nil
 }
 
-// checkRow provides a function to check and fill each column element for all
-// rows and make that is continuous in a worksheet of XML. For example:
+// duplicateRowObjects copies the comments, hyperlinks, data validations and
+// conditional formats in the source row to the destination row, the comments
+// should be got before inserting the destination row.
+func (f *File) duplicateRowObjects(sheet string, ws *xlsxWorksheet, comments []Comment, row, row2 int) error {
+	if err := f.duplicateComments(sheet, comments, row, row2); err != nil {
+		return err
+	}
+	if row > row2 {
+		row++
+	}
+	if err := f.duplicateHyperlinks(sheet, ws, row, row2); err != nil {
+		return err
+	}
+	if err := f.duplicateDataValidations(ws, row, row2); err != nil {
+		return err
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		sqref, err := duplicateSqref(cf.SQRef, row, row2)
+		if err != nil {
+			return err
+		}
+		cf.SQRef = sqref
+	}
+	return nil
+}
+
+// duplicateComments add the comments in the source row to the destination row
+// if there is no comment in the destination cell. The threaded comments will
+// not be copied.
+func (f *File) duplicateComments(sheet string, comments []Comment, row, row2 int) error {
+	exists := make(map[string]bool, len(comments))
+	for _, comment := range comments {
+		exists[comment.Cell] = true
+	}
+	for _, comment := range comments {
+		col, r, err := CellNameToCoordinates(comment.Cell)
+		if err != nil {
+			return err
+		}
+		if r != row || strings.HasPrefix(comment.Author, "tc=") {
+			continue
+		}
+		if comment.Cell, err = CoordinatesToCellName(col, row2); err != nil {
+			return err
+		}
+		if exists[comment.Cell] {
+			continue
+		}
+		if err = f.AddComment(sheet, comment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// duplicateHyperlinks add the hyperlinks in the source row to the destination
+// row if there is no hyperlink in the destination cell. The external link will
+// be copied with a new relationship of the worksheet.
+func (f *File) duplicateHyperlinks(sheet string, ws *xlsxWorksheet, row, row2 int) error {
+	if ws.Hyperlinks == nil {
+		return nil
+	}
+	exists := make(map[string]bool, len(ws.Hyperlinks.Hyperlink))
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		exists[link.Ref] = true
+	}
+	for _, link := range ws.Hyperlinks.Hyperlink {
+		col, r, err := CellNameToCoordinates(link.Ref)
+		if err != nil || r != row {
+			continue
+		}
+		if link.Ref, err = CoordinatesToCellName(col, row2); err != nil {
+			return err
+		}
+		if exists[link.Ref] {
+			continue
+		}
+		if len(ws.Hyperlinks.Hyperlink) >= TotalSheetHyperlinks {
+			return ErrTotalSheetHyperlinks
+		}
+		if link.RID != "" {
+			sheetPath, _ := f.getSheetXMLPath(sheet)
+			sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
+			target := f.getSheetRelationshipsTargetByID(sheet, link.RID)
+			link.RID = "rId" + strconv.Itoa(f.addRels(sheetRels, SourceRelationshipHyperLink, target, "External"))
+		}
+		ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink, link)
+	}
+	return nil
+}
+
+// duplicateDataValidations apply the data validations in the source row to the
+// destination row, include the data validations in the worksheet extension
+// list.
+func (f *File) duplicateDataValidations(ws *xlsxWorksheet, row, row2 int) error {
+	extDataValidations, err := f.getExtDataValidations(ws)
+	if err != nil {
+		return err
+	}
+	for _, dv := range extDataValidations {
+		if dv.Sqref, err = duplicateSqref(dv.Sqref, row, row2); err != nil {
+			return err
+		}
+	}
+	if len(extDataValidations) > 0 {
+		if err = f.setExtDataValidations(ws, extDataValidations); err != nil {
+			return err
+		}
+	}
+	if ws.DataValidations == nil {
+		return err
+	}
+	for _, dv := range ws.DataValidations.DataValidation {
+		if dv.Sqref, err = duplicateSqref(dv.Sqref, row, row2); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// duplicateSqref returns the space-separated list of references after append
+// the cells in the source row to the destination row, the cells which already
+// referenced in the destination row will not be appended.
+func duplicateSqref(sqref string, row, row2 int) (string, error) {
+	refs := strings.Fields(sqref)
+	rects := make([][]int, 0, len(refs))
+	for _, ref := range refs {
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		coordinates, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return sqref, err
+		}
+		_ = sortCoordinates(coordinates)
+		rects = append(rects, coordinates)
+	}
+	inRects := func(col, row int) bool {
+		for _, rect := range rects {
+			if rect[0] <= col && col <= rect[2] && rect[1] <= row && row <= rect[3] {
+				return true
+			}
+		}
+		return false
+	}
+	for _, rect := range rects {
+		if row < rect[1] || rect[3] < row {
+			continue
+		}
+		for col := rect[0]; col <= rect[2]; col++ {
+			if inRects(col, row2) {
+				continue
+			}
+			end := col
+			for end < rect[2] && !inRects(end+1, row2) {
+				end++
+			}
+			ref, _ := CoordinatesToCellName(col, row2)
+			if end > col {
+				endRef, _ := CoordinatesToCellName(end, row2)
+				ref += ":" + endRef
+			}
+			refs = append(refs, ref)
+			rects = append(rects, []int{col, row2, end, row2})
+			col = end
+		}
+	}
+	return strings.Join(refs, " "), nil
+}
+
+// checkRow provides a function to check each column element for all rows
+// and make that is in ascending order in a worksheet of XML. The cell without
+// r attribute will be given the cell reference after the previous cell, the
+// cells will be sorted by the column number, and the latter one will be kept
+// if there are cells with the same column number. For example:
 //
 //	<row r="15">
-//	    <c r="A15" s="2" />
-//	    <c r="B15" s="2" />
 //	    <c r="F15" s="1" />
-//	    <c r="G15" s="1" />
+//	    <c s="1" />
+//	    <c r="A15" s="2" />
 //	</row>
 //
 // in this case, we should to change it to
 //
 //	<row r="15">
 //	    <c r="A15" s="2" />
-//	    <c r="B15" s="2" />
-//	    <c r="C15" s="2" />
-//	    <c r="D15" s="2" />
-//	    <c r="E15" s="2" />
 //	    <c r="F15" s="1" />
 //	    <c r="G15" s="1" />
 //	</row>
 //
-// Notice: this method could be very slow for large spreadsheets (more than
-// 3000 rows one sheet).
+// The missing cells will not be filled, so the row with data in the far
+// columns will not allocate the cells before it.
 func (ws *xlsxWorksheet) checkRow() error {
 	for rowIdx := range ws.SheetData.Row {
 		rowData := &ws.SheetData.Row[rowIdx]
-
-		colCount := len(rowData.C)
-		if colCount == 0 {
+		if len(rowData.C) == 0 {
 			continue
 		}
 		// check and fill the cell without r attribute in a row element
-		rCount := 0
+		rCount, sorted := 0, true
+		cols := make([]int, len(rowData.C))
 		for idx, cell := range rowData.C {
 			rCount++
 			if cell.R != "" {
@@ -739,44 +1210,90 @@ func (ws *xlsxWorksheet) checkRow() error {
 				if lastR > rCount {
 					rCount = lastR
 				}
-				continue
+				cols[idx] = lastR
+			} else {
+				rowData.C[idx].R, _ = CoordinatesToCellName(rCount, rowData.R)
+				cols[idx] = rCount
+			}
+			if idx > 0 && cols[idx] <= cols[idx-1] {
+				sorted = false
 			}
-			rowData.C[idx].R, _ = CoordinatesToCellName(rCount, rowIdx+1)
 		}
-		lastCol, _, err := CellNameToCoordinates(rowData.C[colCount-1].R)
-		if err != nil {
-			return err
+		if sorted {
+			continue
 		}
-
-		if colCount < lastCol {
-			sourceList := rowData.C
-			targetList := make([]xlsxC, 0, lastCol)
-
-			rowData.C = ws.SheetData.Row[rowIdx].C[:0]
-
-			for colIdx := 0; colIdx < lastCol; colIdx++ {
-				cellName, err := CoordinatesToCellName(colIdx+1, rowIdx+1)
-				if err != nil {
-					return err
-				}
-				targetList = append(targetList, xlsxC{R: cellName})
-			}
-
-			rowData.C = targetList
-
-			for colIdx := range sourceList {
-				colData := &sourceList[colIdx]
-				colNum, _, err := CellNameToCoordinates(colData.R)
-				if err != nil {
-					return err
-				}
-				ws.SheetData.Row[rowIdx].C[colNum-1] = *colData
+		cells := make(map[int]xlsxC, len(rowData.C))
+		for idx, cell := range rowData.C {
+			cells[cols[idx]] = cell
+		}
+		sort.Ints(cols)
+		rowData.C = rowData.C[:0]
+		for idx, col := range cols {
+			if idx > 0 && col == cols[idx-1] {
+				continue
 			}
+			rowData.C = append(rowData.C, cells[col])
 		}
 	}
 	return nil
 }
 
+// searchRow provides a function to get the index of the row element by given
+// row number in the worksheet data, and returns -1 if the row doesn't exist.
+// The row elements should be sorted by the row number in ascending order.
+func (ws *xlsxWorksheet) searchRow(row int) int {
+	if idx, ok := ws.rowIndex(row); ok {
+		return idx
+	}
+	return -1
+}
+
+// rowIndex provides a function to get the index of the row element by given
+// row number in the worksheet data with binary search, and reports whether
+// the row exists. If the row doesn't exist, the returned index is the position
+// where the row element should be inserted to keep the ascending order.
+func (ws *xlsxWorksheet) rowIndex(row int) (int, bool) {
+	rows := ws.SheetData.Row
+	if idx := row - 1; idx >= 0 && idx < len(rows) && rows[idx].R == row {
+		return idx, true
+	}
+	if l := len(rows); l == 0 || rows[l-1].R < row {
+		return l, false
+	}
+	idx := sort.Search(len(rows), func(i int) bool { return rows[i].R >= row })
+	return idx, idx < len(rows) && rows[idx].R == row
+}
+
+// searchCell provides a function to get the index of the cell element by
+// given column number in the row, and returns -1 if the cell doesn't exist.
+// The cell elements should be sorted by the column number in ascending order.
+func (r *xlsxRow) searchCell(col int) int {
+	if idx, ok := r.cellIndex(col); ok {
+		return idx
+	}
+	return -1
+}
+
+// cellIndex provides a function to get the index of the cell element by given
+// column number in the row with binary search, and reports whether the cell
+// exists. If the cell doesn't exist, the returned index is the position where
+// the cell element should be inserted to keep the ascending order.
+func (r *xlsxRow) cellIndex(col int) (int, bool) {
+	cells := r.C
+	colNum := func(i int) int {
+		c, _, _ := CellNameToCoordinates(cells[i].R)
+		return c
+	}
+	if idx := col - 1; idx >= 0 && idx < len(cells) && colNum(idx) == col {
+		return idx, true
+	}
+	if l := len(cells); l == 0 || colNum(l-1) < col {
+		return l, false
+	}
+	idx := sort.Search(len(cells), func(i int) bool { return colNum(i) >= col })
+	return idx, idx < len(cells) && colNum(idx) == col
+}
+
 // hasAttr determine if row non-default attributes.
 func (r *xlsxRow) hasAttr() bool {
 	return r.Spans != "" || r.S != 0 || r.CustomFormat || r.Ht != nil ||
@@ -805,7 +1322,7 @@ func (f *File) SetRowStyle(sheet string, start, end, styleID int) error {
 	if end > TotalRows {
 		return ErrMaxRows
 	}
-	s, err := f.stylesReader()
+	s, err := f.stylesViewer()
 	if err != nil {
 		return err
 	}
@@ -818,19 +1335,42 @@ func (f *File) SetRowStyle(sheet string, start, end, styleID int) error {
 	if err != nil {
 		return err
 	}
-	ws.prepareSheetXML(0, end)
-	for row := start - 1; row < end; row++ {
-		ws.SheetData.Row[row].S = styleID
-		ws.SheetData.Row[row].CustomFormat = true
-		for i := range ws.SheetData.Row[row].C {
-			if _, rowNum, err := CellNameToCoordinates(ws.SheetData.Row[row].C[i].R); err == nil && rowNum-1 == row {
-				ws.SheetData.Row[row].C[i].S = styleID
+	for row := start; row <= end; row++ {
+		rowIdx, _ := ws.prepareSheetXML(0, row)
+		rowData := &ws.SheetData.Row[rowIdx]
+		rowData.S = styleID
+		rowData.CustomFormat = true
+		for i := range rowData.C {
+			if _, rowNum, err := CellNameToCoordinates(rowData.C[i].R); err == nil && rowNum == row {
+				rowData.C[i].S = styleID
 			}
 		}
 	}
 	return nil
 }
 
+// GetRowStyle provides a function to get the style ID of the row by given
+// worksheet name and row number. The style ID of the row only takes effect on
+// the cells without explicit style in the row. For example, get the style ID of
+// the row 1 on Sheet1:
+//
+//	styleID, err := f.GetRowStyle("Sheet1", 1)
+func (f *File) GetRowStyle(sheet string, row int) (int, error) {
+	if row < 1 {
+		return 0, newInvalidRowNumberError(row)
+	}
+	ws, err := f.workSheetViewer(sheet)
+	if err != nil {
+		return 0, err
+	}
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+	if rowIdx := ws.searchRow(row); rowIdx != -1 && ws.SheetData.Row[rowIdx].CustomFormat {
+		return ws.SheetData.Row[rowIdx].S, err
+	}
+	return 0, err
+}
+
 // convertRowHeightToPixels provides a function to convert the height of a
 // cell from user's units to pixels. If the height hasn't been set by the user
 // we use the default value. If the row is hidden it has a value of zero.
@@ -14,13 +14,73 @@ import (
 func TestGetRows(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C2", 0.5))
+	style, err := f.NewStyle(&Style{NumFmt: 9})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "C2", style))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A4", "D4", style))
+	for _, c := range []struct {
+		opts     []Options
+		expected [][]string
+	}{
+		{nil, [][]string{{"A1"}, {"", "", "50%"}}},
+		{[]Options{{RawCellValue: true}}, [][]string{{"A1"}, {"", "", "0.5"}}},
+		{[]Options{{KeepTrailingEmpty: true}}, [][]string{{"A1", "", "", ""}, {"", "", "50%", ""}, {"", "", "", ""}, {"", "", "", ""}}},
+		{[]Options{{RawCellValue: true, KeepTrailingEmpty: true}}, [][]string{{"A1", "", "", ""}, {"", "", "0.5", ""}, {"", "", "", ""}, {"", "", "", ""}}},
+	} {
+		rows, err := f.GetRows("Sheet1", c.opts...)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, rows)
+	}
+	// Test get the trailing empty cells of the row by rows iterator
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	for rows.Next() {
+		if rows.seekRow == 4 {
+			cells, err := rows.Columns(Options{KeepTrailingEmpty: true})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"", "", "", ""}, cells)
+		}
+	}
+	assert.NoError(t, rows.Close())
 	// Test get rows with unsupported charset shared strings table
 	f.SharedStrings = nil
 	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
-	_, err := f.GetRows("Sheet1")
+	_, err = f.GetRows("Sheet1")
 	assert.NoError(t, err)
 }
 
+func TestWalkRows(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{"A1": "a", "B1": 1, "B3": "b", "C5": 2} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	var rowNums []int
+	var results [][]string
+	assert.NoError(t, f.WalkRows("Sheet1", func(rowNum int, cells []string) bool {
+		rowNums, results = append(rowNums, rowNum), append(results, cells)
+		return true
+	}))
+	assert.Equal(t, []int{1, 3, 5}, rowNums)
+	assert.Equal(t, [][]string{{"a", "1"}, {"", "b"}, {"", "", "2"}}, results)
+	// Test stop walking rows in the callback function
+	rowNums = nil
+	assert.NoError(t, f.WalkRows("Sheet1", func(rowNum int, cells []string) bool {
+		rowNums = append(rowNums, rowNum)
+		return rowNum < 3
+	}))
+	assert.Equal(t, []int{1, 3}, rowNums)
+	// Test walk rows with invalid sheet name
+	assert.EqualError(t, f.WalkRows("Sheet:1", nil), ErrSheetNameInvalid.Error())
+	// Test walk rows on not exists worksheet
+	assert.EqualError(t, f.WalkRows("SheetN", nil), "sheet SheetN does not exist")
+	// Test walk rows with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.WalkRows("Sheet1", nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestRows(t *testing.T) {
 	const sheet2 = "Sheet2"
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
@@ -73,6 +133,8 @@ func TestRows(t *testing.T) {
 	assert.NoError(t, f.Close())
 
 	// Test rows iterator with unsupported charset shared strings table
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
 	f.SharedStrings = nil
 	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
 	rows, err = f.Rows(sheet2)
@@ -112,6 +174,193 @@ func TestRowsIterator(t *testing.T) {
 	assert.Equal(t, expectedNumRow, rowCount)
 }
 
+func TestRowsClose(t *testing.T) {
+	// Test close rows iterator with the worksheet spilled to the system
+	// temporary directory
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	require.NoError(t, err)
+	rows, err := f.Rows("Sheet2")
+	require.NoError(t, err)
+	assert.NotNil(t, rows.tempFile)
+	assert.True(t, rows.Next())
+	row, err := rows.Columns()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, row)
+	assert.NoError(t, rows.Close())
+	assert.Nil(t, rows.tempFile)
+	assert.Nil(t, rows.decoder)
+	// Test close rows iterator twice
+	assert.NoError(t, rows.Close())
+	// Test use rows iterator after closed
+	assert.False(t, rows.Next())
+	row, err = rows.Columns()
+	assert.NoError(t, err)
+	assert.Nil(t, row)
+	assert.NoError(t, f.Close())
+}
+
+func TestRowsCells(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "text"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", 1.5))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C1", "C1", style))
+	assert.NoError(t, f.SetCellBool("Sheet1", "A3", true))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B3", "SUM(C1,1)"))
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	var results [][]RowCell
+	for rows.Next() {
+		cells, err := rows.Cells()
+		assert.NoError(t, err)
+		results = append(results, cells)
+	}
+	assert.NoError(t, rows.Close())
+	assert.Equal(t, [][]RowCell{
+		{
+			{Cell: "A1", Type: CellTypeSharedString, RawValue: "text", Value: "text"},
+			{Cell: "C1", StyleID: style, RawValue: "1.5", Value: "1.50"},
+		},
+		nil,
+		{
+			{Cell: "A3", Type: CellTypeBool, RawValue: "1", Value: "TRUE"},
+			{Cell: "B3", Type: CellTypeFormula, Formula: "SUM(C1,1)"},
+		},
+	}, results)
+
+	// Test get cells with raw cell value
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	cells, err := rows.Cells(Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", cells[1].Value)
+	assert.NoError(t, rows.Close())
+
+	// Test get cells without cell reference
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row><c t="inlineStr"><is><t>A</t></is></c><c s="1"><v>2</v></c></row></sheetData></worksheet>`))
+	f.checked.Delete("xl/worksheets/sheet1.xml")
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	cells, err = rows.Cells()
+	assert.NoError(t, err)
+	assert.Equal(t, []RowCell{
+		{Cell: "A1", Type: CellTypeInlineString, RawValue: "A", Value: "A"},
+		{Cell: "B1", StyleID: 1, RawValue: "2", Value: "2.00"},
+	}, cells)
+	assert.NoError(t, rows.Close())
+
+	// Test get cells with invalid cell reference
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="1"><c r="A"><v>1</v></c></row></sheetData></worksheet>`))
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	_, err = rows.Cells()
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.NoError(t, rows.Close())
+	assert.NoError(t, f.Close())
+}
+
+func TestSparseSheetData(t *testing.T) {
+	f := NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>A</t></is></c></row><row r="1000000" ht="30" hidden="1" customHeight="1"><c r="XFD1000000"><v>1</v></c></row></sheetData></worksheet>`))
+	f.checked.Delete("xl/worksheets/sheet1.xml")
+	// Test read the sparse worksheet without filling missing rows and cells
+	val, err := f.GetCellValue("Sheet1", "XFD1000000")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", val)
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A", val)
+	val, err = f.GetCellValue("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	ht, err := f.GetRowHeight("Sheet1", 1000000)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, ht)
+	ht, err = f.GetRowHeight("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultRowHeight, ht)
+	visible, err := f.GetRowVisible("Sheet1", 1000000)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	visible, err = f.GetRowVisible("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 2)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row[1].C, 1)
+
+	// Test write the sparse worksheet without filling missing rows and cells
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A1"><v>1</v></c></row><row r="100"><c r="C100"><v>2</v></c></row></sheetData></worksheet>`))
+	val, err = f.GetCellValue("Sheet1", "C100")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", val)
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", 3))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A50", 4))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 4)
+	assert.Equal(t, []int{1, 2, 50, 100}, []int{
+		ws.(*xlsxWorksheet).SheetData.Row[0].R, ws.(*xlsxWorksheet).SheetData.Row[1].R,
+		ws.(*xlsxWorksheet).SheetData.Row[2].R, ws.(*xlsxWorksheet).SheetData.Row[3].R,
+	})
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row[1].C, 1)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 100)
+	assert.Equal(t, []string{"", "3"}, rows[1])
+	assert.Equal(t, []string{"4"}, rows[49])
+	assert.Equal(t, []string{"", "", "2"}, rows[99])
+	assert.NoError(t, f.Close())
+
+	// Test write the far cell without filling missing rows and cells
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "XFD1000000", 1))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1000000", 2))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1000000", "A1000000", 0))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 999999, 30))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 2)
+	assert.Equal(t, 999999, ws.(*xlsxWorksheet).SheetData.Row[0].R)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row[1].C, 3)
+	for idx, cell := range []string{"A1000000", "C1000000", "XFD1000000"} {
+		assert.Equal(t, cell, ws.(*xlsxWorksheet).SheetData.Row[1].C[idx].R)
+	}
+	val, err = f.GetCellValue("Sheet1", "XFD1000000")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", val)
+	assert.NoError(t, f.Close())
+
+	// Test read the irregular worksheet without filling missing rows and cells
+	f = NewFile()
+	for _, sheetData := range []string{
+		`<row r="1000000"><c r="A1000000"><v>1</v></c></row><row r="1"><c r="A1"><v>2</v></c></row>`,
+		`<row r="1"><c><v>2</v></c><c><v>1</v></c></row><row r="1000000"><c r="B1000000"><v>1</v></c></row>`,
+		`<row r="1"><c r="B1"><v>1</v></c><c r="A1"><v>2</v></c></row><row r="1000000"><c r="B1000000"><v>1</v></c></row>`,
+	} {
+		f.Sheet.Delete("xl/worksheets/sheet1.xml")
+		f.checked.Delete("xl/worksheets/sheet1.xml")
+		f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`+sheetData+`</sheetData></worksheet>`))
+		val, err = f.GetCellValue("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, "2", val)
+		ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+		assert.True(t, ok)
+		assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 2)
+		assert.Equal(t, "A1", ws.(*xlsxWorksheet).SheetData.Row[0].C[0].R)
+		assert.Equal(t, 1000000, ws.(*xlsxWorksheet).SheetData.Row[1].R)
+	}
+	assert.NoError(t, f.Close())
+}
+
 func TestRowsGetRowOpts(t *testing.T) {
 	sheetName := "Sheet2"
 	expectedRowStyleID1 := RowOpts{Height: 17.0, Hidden: false, StyleID: 1}
@@ -359,6 +608,119 @@ func TestRemoveRow(t *testing.T) {
 	assert.EqualError(t, f.RemoveRow("Sheet:1", 1), ErrSheetNameInvalid.Error())
 }
 
+func TestRemoveRows(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, fillCells(f, "Sheet1", 10, 10))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A4", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A8", "https://github.com/xuri/excelize", "External"))
+	for _, rng := range [][]string{{"B2", "B7"}, {"C3", "C5"}, {"D4", "D8"}} {
+		assert.NoError(t, f.MergeCell("Sheet1", rng[0], rng[1]))
+	}
+	dv := NewDataValidation(true)
+	dv.Sqref = "E3:E5 F2:F9"
+	assert.NoError(t, dv.SetRange(10, 20, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$4:$A$9"}))
+	assert.NoError(t, f.RemoveRows("Sheet1", 3, 3))
+
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.SheetData.Row, 7)
+	for cell, expected := range map[string]string{"A2": "A2", "A3": "A6", "J7": "J10", "A8": ""} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	var refs []string
+	for _, mergeCell := range mergeCells {
+		refs = append(refs, mergeCell.GetStartAxis()+":"+mergeCell.GetEndAxis())
+	}
+	assert.Equal(t, []string{"B2:B4", "D3:D5"}, refs)
+	assert.Len(t, ws.Hyperlinks.Hyperlink, 1)
+	assert.Equal(t, "A5", ws.Hyperlinks.Hyperlink[0].Ref)
+	assert.Equal(t, "F2:F6", ws.DataValidations.DataValidation[0].Sqref)
+	definedNames := f.GetDefinedName()
+	assert.Equal(t, "Sheet1!$A$3:$A$6", definedNames[0].RefersTo)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveRows.xlsx")))
+
+	// Test remove rows with invalid row number and number of rows
+	assert.EqualError(t, f.RemoveRows("Sheet1", 0, 1), newInvalidRowNumberError(0).Error())
+	assert.Equal(t, ErrParameterInvalid, f.RemoveRows("Sheet1", 1, 0))
+	assert.Equal(t, ErrMaxRows, f.RemoveRows("Sheet1", 1, TotalRows+1))
+	// Test remove rows on not exists worksheet
+	assert.EqualError(t, f.RemoveRows("SheetN", 1, 2), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestMoveRow(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, fillCells(f, "Sheet1", 3, 8))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D3", "SUM(A3:C3)+A$8"))
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "Sheet1!B3+'Sheet1'!A2:A5+LOG10(A3)"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A4", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.MergeCell("Sheet1", "E6", "F6"))
+	assert.NoError(t, f.MergeCell("Sheet1", "E7", "E8"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$B$3"}))
+	assert.NoError(t, f.MoveRow("Sheet1", 3, 6))
+
+	for cell, expected := range map[string]string{"A3": "A4", "A5": "A6", "A6": "A3", "C6": "C3", "A7": "A7"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	for cell, expected := range map[string][]string{
+		"Sheet1!D6": {"Sheet1", "D6", "SUM(A6:C6)+A$8"},
+		"Sheet2!A1": {"Sheet2", "A1", "Sheet1!B6+'Sheet1'!A2:A4+LOG10(A3)"},
+	} {
+		formula, err := f.GetCellFormula(expected[0], expected[1])
+		assert.NoError(t, err)
+		assert.Equal(t, expected[2], formula, cell)
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "E5", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "F5", mergeCells[0].GetEndAxis())
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A3", ws.Hyperlinks.Hyperlink[0].Ref)
+	assert.Equal(t, "Sheet1!$B$6", f.GetDefinedName()[0].RefersTo)
+	// Test move row down to the end of the worksheet
+	assert.NoError(t, f.MoveRow("Sheet1", 1, 10))
+	value, err := f.GetCellValue("Sheet1", "B10")
+	assert.NoError(t, err)
+	assert.Equal(t, "B1", value)
+	assert.Equal(t, 10, ws.SheetData.Row[len(ws.SheetData.Row)-1].R)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMoveRow.xlsx")))
+	// Test move row which is a part of the merged cells
+	assert.Equal(t, ErrMoveMergedCells, f.MoveRow("Sheet1", 6, 1))
+	// Test move row to the same position
+	assert.NoError(t, f.MoveRow("Sheet1", 2, 2))
+	// Test move row with invalid row number
+	assert.EqualError(t, f.MoveRow("Sheet1", 0, 1), newInvalidRowNumberError(0).Error())
+	assert.EqualError(t, f.MoveRow("Sheet1", 1, 0), newInvalidRowNumberError(0).Error())
+	assert.Equal(t, ErrMaxRows, f.MoveRow("Sheet1", 1, TotalRows+1))
+	// Test move row on not exists worksheet
+	assert.EqualError(t, f.MoveRow("SheetN", 1, 2), "sheet SheetN does not exist")
+	// Test move row with invalid merged cell reference
+	ws.MergeCells.Cells[0].Ref = "A"
+	assert.Equal(t, ErrParameterInvalid, f.MoveRow("Sheet1", 1, 2))
+	ws.MergeCells = nil
+	// Test move row with invalid cell reference
+	ws.SheetData.Row[0].C[0].R = "A"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.MoveRow("Sheet1", 1, 2))
+	assert.NoError(t, f.Close())
+
+	// Test move row with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.MoveRow("Sheet1", 1, 2), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestInsertRows(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
@@ -373,17 +735,17 @@ func TestInsertRows(t *testing.T) {
 	assert.NoError(t, f.SetCellHyperLink(sheet1, "A5", "https://github.com/xuri/excelize", "External"))
 
 	assert.NoError(t, f.InsertRows(sheet1, 1, 1))
-	if !assert.Len(t, r.SheetData.Row, rowCount+1) {
+	if !assert.Len(t, r.SheetData.Row, rowCount) || !assert.Equal(t, rowCount+1, r.SheetData.Row[rowCount-1].R) {
 		t.FailNow()
 	}
 
 	assert.NoError(t, f.InsertRows(sheet1, 4, 1))
-	if !assert.Len(t, r.SheetData.Row, rowCount+2) {
+	if !assert.Len(t, r.SheetData.Row, rowCount) || !assert.Equal(t, rowCount+2, r.SheetData.Row[rowCount-1].R) {
 		t.FailNow()
 	}
 
 	assert.NoError(t, f.InsertRows(sheet1, 4, 2))
-	if !assert.Len(t, r.SheetData.Row, rowCount+4) {
+	if !assert.Len(t, r.SheetData.Row, rowCount) || !assert.Equal(t, rowCount+4, r.SheetData.Row[rowCount-1].R) {
 		t.FailNow()
 	}
 	// Test insert rows with invalid sheet name
@@ -901,11 +1263,113 @@ func TestDuplicateMergeCells(t *testing.T) {
 	assert.EqualError(t, f.duplicateMergeCells("SheetN", ws, 1, 2), "sheet SheetN does not exist")
 }
 
+func TestDuplicateRowObjects(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "A2"))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 2, 1))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B2", Author: "Excelize", Text: "Comment"}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B2", "Sheet2!A1", "Location"))
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:C2 E2"
+	assert.NoError(t, dv.SetDropList([]string{"1", "2"}))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	dv = NewDataValidation(true)
+	dv.Sqref = "B2"
+	dv.SetSqrefDropList("Sheet2!$A$1:$A$3")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:D2", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: format, Value: "6"},
+	}))
+	assert.NoError(t, f.DuplicateRowTo("Sheet1", 2, 5))
+
+	height, err := f.GetRowHeight("Sheet1", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	level, err := f.GetRowOutlineLevel("Sheet1", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(1), level)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "B5", comments[1].Cell)
+	assert.Equal(t, "Comment", comments[1].Text)
+	for cell, expected := range map[string]string{
+		"A2": "https://github.com/xuri/excelize", "A5": "https://github.com/xuri/excelize", "B5": "Sheet2!A1",
+	} {
+		ok, link, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, expected, link, cell)
+	}
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1:C2 E2 A5:C5 E5", "B2 B5"}, []string{dvs[0].Sqref, dvs[1].Sqref})
+	formats, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Contains(t, formats, "B1:D2 B5:D5")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDuplicateRowObjects.xlsx")))
+
+	// Test duplicate row to the row which partially referenced
+	assert.NoError(t, f.DuplicateRowTo("Sheet1", 1, 3))
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:C2 E2 A6:C6 E6 A3:C3", dvs[0].Sqref)
+	formats, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Contains(t, formats, "B1:D2 B6:D6 B3:D3")
+	// Test duplicate row before the source row
+	assert.NoError(t, f.DuplicateRowTo("Sheet1", 6, 1))
+	ok, link, err := f.GetCellHyperLink("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "Sheet2!A1", link)
+	assert.NoError(t, f.Close())
+
+	// Test duplicate row with invalid references
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ConditionalFormatting = []*xlsxConditionalFormatting{{SQRef: "A1:A"}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.DuplicateRowTo("Sheet1", 1, 2))
+	ws.ConditionalFormatting = nil
+	ws.DataValidations = &xlsxDataValidations{DataValidation: []*DataValidation{{Sqref: "A1:A"}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.DuplicateRowTo("Sheet1", 1, 2))
+	ws.DataValidations = nil
+	ws.ExtLst = &xlsxExtLst{Ext: "<ext><x14:dataValidations></ext>"}
+	assert.Error(t, f.DuplicateRowTo("Sheet1", 1, 2))
+	ws.ExtLst = nil
+	// Test duplicate row with the maximum hyperlinks limit
+	ws.Hyperlinks = &xlsxHyperlinks{Hyperlink: make([]xlsxHyperlink, TotalSheetHyperlinks)}
+	ws.Hyperlinks.Hyperlink[0].Ref = "A1"
+	assert.Equal(t, ErrTotalSheetHyperlinks, f.DuplicateRowTo("Sheet1", 1, 2))
+	ws.Hyperlinks = nil
+	// Test duplicate row with invalid comment reference
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	comments[0].Cell = "A"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.duplicateComments("Sheet1", comments, 1, 2))
+	comments[0].Cell = "A1"
+	assert.Equal(t, newCoordinatesToCellNameError(1, 0), f.duplicateComments("Sheet1", comments, 1, 0))
+	// Test duplicate row with unsupported charset comments
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DuplicateRowTo("Sheet1", 1, 2), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestGetValueFromInlineStr(t *testing.T) {
 	c := &xlsxC{T: "inlineStr"}
 	f := NewFile()
 	d := &xlsxSST{}
-	val, err := c.getValueFrom(f, d, false)
+	val, err := c.getValueFrom(f, d, &Options{})
 	assert.NoError(t, err)
 	assert.Equal(t, "", val)
 }
@@ -925,7 +1389,7 @@ func TestGetValueFromNumber(t *testing.T) {
 		"2.220000ddsf0000000002-r": "2.220000ddsf0000000002-r",
 	} {
 		c.V = input
-		val, err := c.getValueFrom(f, d, false)
+		val, err := c.getValueFrom(f, d, &Options{})
 		assert.NoError(t, err)
 		assert.Equal(t, expected, val)
 	}
@@ -948,6 +1412,63 @@ func TestCheckRow(t *testing.T) {
 	assert.EqualError(t, f.SetCellValue("Sheet1", "A1", false), newCellNameToCoordinatesError("-", newInvalidCellNameError("-")).Error())
 }
 
+func TestGroupRows(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.GroupRows("Sheet1", 2, 5))
+	assert.NoError(t, f.GroupRows("Sheet1", 4, 3))
+	for row, expected := range map[int]uint8{1: 0, 2: 1, 3: 2, 4: 2, 5: 1, 6: 0} {
+		level, err := f.GetRowOutlineLevel("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, level, row)
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, uint8(2), ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelRow)
+	// Test collapse and expand the grouped rows
+	assert.NoError(t, f.SetRowsCollapsed("Sheet1", 2, 5, true))
+	for row, expected := range map[int]bool{1: true, 2: false, 5: false, 6: true} {
+		visible, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, visible, row)
+	}
+	assert.True(t, ws.(*xlsxWorksheet).SheetData.Row[ws.(*xlsxWorksheet).searchRow(6)].Collapsed)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGroupRows.xlsx")))
+	assert.NoError(t, f.SetRowsCollapsed("Sheet1", 5, 2, false))
+	visible, err := f.GetRowVisible("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	assert.False(t, ws.(*xlsxWorksheet).SheetData.Row[ws.(*xlsxWorksheet).searchRow(6)].Collapsed)
+	// Test collapse the grouped rows with summary rows above
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{OutlineSummaryBelow: boolPtr(false)}))
+	assert.NoError(t, f.SetRowsCollapsed("Sheet1", 3, 4, true))
+	assert.True(t, ws.(*xlsxWorksheet).SheetData.Row[ws.(*xlsxWorksheet).searchRow(2)].Collapsed)
+	assert.NoError(t, f.SetRowsCollapsed("Sheet1", 1, 2, true))
+	// Test ungroup the rows
+	assert.NoError(t, f.UngroupRows("Sheet1", 1, 5))
+	assert.NoError(t, f.UngroupRows("Sheet1", 1, 5))
+	for row := 1; row <= 5; row++ {
+		level, err := f.GetRowOutlineLevel("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Zero(t, level, row)
+	}
+	assert.Zero(t, ws.(*xlsxWorksheet).SheetFormatPr.OutlineLevelRow)
+	// Test group rows exceeds the maximum outline level
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 3, 7))
+	assert.Equal(t, ErrOutlineLevel, f.GroupRows("Sheet1", 1, 3))
+	level, err := f.GetRowOutlineLevel("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Zero(t, level)
+	// Test group and collapse rows with invalid row number
+	assert.EqualError(t, f.GroupRows("Sheet1", 0, 1), newInvalidRowNumberError(0).Error())
+	assert.Equal(t, ErrMaxRows, f.GroupRows("Sheet1", 1, TotalRows+1))
+	assert.EqualError(t, f.SetRowsCollapsed("Sheet1", 0, 1, true), newInvalidRowNumberError(0).Error())
+	assert.Equal(t, ErrMaxRows, f.SetRowsCollapsed("Sheet1", 1, TotalRows+1, true))
+	// Test group and collapse rows on not exists worksheet
+	assert.EqualError(t, f.GroupRows("SheetN", 1, 2), "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetRowsCollapsed("SheetN", 1, 2, true), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestSetRowStyle(t *testing.T) {
 	f := NewFile()
 	style1, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"63BE7B"}, Pattern: 1}})
@@ -980,6 +1501,35 @@ func TestSetRowStyle(t *testing.T) {
 	assert.EqualError(t, f.SetRowStyle("Sheet1", 1, 1, cellStyleID), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetRowStyle(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{NumFmt: 9})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetRowStyle("Sheet1", 2, 3, styleID))
+	for row, expected := range map[int]int{1: 0, 2: styleID, 3: styleID, 4: 0} {
+		result, err := f.GetRowStyle("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result, row)
+	}
+	// Test get row style without custom format
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[ws.(*xlsxWorksheet).searchRow(2)].CustomFormat = false
+	result, err := f.GetRowStyle("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Zero(t, result)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 0.5))
+	cellValue, err := f.GetCellValue("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.5", cellValue)
+	// Test get row style with invalid row number
+	_, err = f.GetRowStyle("Sheet1", 0)
+	assert.EqualError(t, err, newInvalidRowNumberError(0).Error())
+	// Test get row style on not exists worksheet
+	_, err = f.GetRowStyle("SheetN", 1)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
 func TestNumberFormats(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -25,6 +25,11 @@ func parseShapeOptions(opts *Shape) (*Shape, error) {
 	if opts.Type == "" {
 		return nil, ErrParameterInvalid
 	}
+	if opts.EndCell != "" {
+		if _, _, err := CellNameToCoordinates(opts.EndCell); err != nil {
+			return nil, err
+		}
+	}
 	if opts.Width == 0 {
 		opts.Width = defaultShapeSize
 	}
@@ -78,6 +83,34 @@ func parseShapeOptions(opts *Shape) (*Shape, error) {
 //	    },
 //	)
 //
+// The rotation angle of the shape in degrees can be set by the 'Rotation'
+// field. Each rich text run in the 'Paragraph' will be added in the same
+// paragraph, and the line break in the text of the run starts a new
+// paragraph.
+//
+// Add a connector with an arrow at the end between the top-left corner of the
+// cell B2 and the top-left corner of the cell E8 in Sheet1:
+//
+//	err := f.AddShape("Sheet1",
+//	    &excelize.Shape{
+//	        Cell:    "B2",
+//	        EndCell: "E8",
+//	        Type:    "straightConnector1",
+//	        Line:    excelize.ShapeLine{Color: "4286F4", TailEnd: "triangle"},
+//	    },
+//	)
+//
+// When the 'EndCell' was specified, the 'Width', 'Height' and offset of the
+// shape will be ignored. The following shows the type of line end supported
+// by excelize in 'HeadEnd' and 'TailEnd' of the shape line:
+//
+//	arrow
+//	diamond
+//	none
+//	oval
+//	stealth
+//	triangle
+//
 // The following shows the type of shape supported by excelize:
 //
 //	accentBorderCallout1 (Callout 1 with Border and Accent Shape)
@@ -293,6 +326,9 @@ func (f *File) AddShape(sheet string, opts *Shape) error {
 	if err != nil {
 		return err
 	}
+	if err = f.checkVBAMacro(options.Macro); err != nil {
+		return err
+	}
 	// Read sheet data
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -322,6 +358,135 @@ func (f *File) AddShape(sheet string, opts *Shape) error {
 	return f.addContentTypePart(drawingID, "drawings")
 }
 
+// parseTextBoxOptions provides a function to parse the format settings of
+// the text box with default value.
+func parseTextBoxOptions(opts *TextBox) (*TextBox, error) {
+	if opts == nil {
+		return nil, ErrParameterInvalid
+	}
+	if opts.Anchor == "" {
+		opts.Anchor = "twoCell"
+	}
+	if inStrSlice(supportedPositioning, opts.Anchor, true) == -1 {
+		return nil, ErrParameterInvalid
+	}
+	opts.Link = strings.TrimPrefix(opts.Link, "=")
+	shape, err := parseShapeOptions(&Shape{
+		Cell: opts.Cell, Type: "rect", Width: opts.Width, Height: opts.Height,
+		Format: opts.Format, Line: opts.Line,
+	})
+	if err != nil {
+		return nil, err
+	}
+	opts.Width, opts.Height, opts.Format, opts.Line = shape.Width, shape.Height, shape.Format, shape.Line
+	return opts, err
+}
+
+// AddTextBox provides the method to add text box in a sheet by given
+// worksheet name and text box format set (such as anchor type, offset, scale
+// and print settings). The 'Anchor' specifies how the text box will be
+// anchored to the worksheet, the optional values are "absolute", "oneCell"
+// and "twoCell", default is "twoCell". The 'Link' specifies the cell
+// reference of the text box linked to, the text box will mirror the value of
+// the cell. For example, add a text box linked to the cell A1 in Sheet1 which
+// will be moved but not sized with the cells:
+//
+//	err := f.AddTextBox("Sheet1",
+//	    &excelize.TextBox{
+//	        Cell:   "C2",
+//	        Anchor: "oneCell",
+//	        Link:   "Sheet1!$A$1",
+//	        Line:   excelize.ShapeLine{Color: "4286F4"},
+//	        Fill:   excelize.Fill{Color: []string{"8EB9FF"}, Pattern: 1},
+//	        Width:  180,
+//	        Height: 40,
+//	    },
+//	)
+//
+// If the 'Paragraph' was not specified for the text box with link, the value
+// of the linked cell will be set as the text of the text box.
+func (f *File) AddTextBox(sheet string, opts *TextBox) error {
+	options, err := parseTextBoxOptions(opts)
+	if err != nil {
+		return err
+	}
+	if options.Link != "" && len(options.Paragraph) == 0 {
+		linkSheet, cell := sheet, options.Link
+		if i := strings.LastIndex(cell, "!"); i != -1 {
+			linkSheet, cell = strings.Trim(cell[:i], "'"), cell[i+1:]
+		}
+		val, err := f.GetCellValue(linkSheet, strings.ReplaceAll(cell, "$", ""))
+		if err != nil {
+			return err
+		}
+		options.Paragraph = []RichTextRun{{Text: val}}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	drawingID := f.countDrawings() + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	if err = f.addDrawingTextBox(sheet, drawingXML, options); err != nil {
+		return err
+	}
+	return f.addContentTypePart(drawingID, "drawings")
+}
+
+// addDrawingTextBox provides a function to add text box by given sheet,
+// drawingXML and format sets.
+func (f *File) addDrawingTextBox(sheet, drawingXML string, opts *TextBox) error {
+	content, anchor, cNvPrID, err := f.twoCellAnchorShape(
+		sheet, drawingXML, opts.Cell, opts.Width, opts.Height, opts.Format)
+	if err != nil {
+		return err
+	}
+	shape := &Shape{
+		Type: "rect", Macro: opts.Macro, Fill: opts.Fill,
+		Line: opts.Line, Paragraph: opts.Paragraph,
+	}
+	spPr := &xlsxSpPr{PrstGeom: xlsxPrstGeom{Prst: shape.Type}}
+	if *opts.Line.Width != 1 {
+		spPr.Ln.W = f.ptToEMUs(*opts.Line.Width)
+	}
+	if anchor.Sp, err = f.drawShape(cNvPrID, spPr, shape); err != nil {
+		return err
+	}
+	anchor.Sp.Textlink = opts.Link
+	anchor.Sp.NvSpPr.CNvPr.Name = "TextBox " + strconv.Itoa(cNvPrID)
+	anchor.Sp.TxBody.BodyPr.Wrap = "square"
+	anchor.ClientData = &xdrClientData{
+		FLocksWithSheet:  *opts.Format.Locked,
+		FPrintsWithSheet: *opts.Format.PrintObject,
+	}
+	col, row, _ := CellNameToCoordinates(opts.Cell)
+	w, h := int(float64(opts.Width)*opts.Format.ScaleX), int(float64(opts.Height)*opts.Format.ScaleY)
+	switch opts.Anchor {
+	case "absolute":
+		x, y := opts.Format.OffsetX, opts.Format.OffsetY
+		for c := 1; c < col; c++ {
+			x += f.getColWidth(sheet, c)
+		}
+		for r := 1; r < row; r++ {
+			y += f.getRowHeight(sheet, r)
+		}
+		anchor.EditAs, anchor.From, anchor.To = "", nil, nil
+		anchor.Pos = &xlsxPoint2D{X: x * EMU, Y: y * EMU}
+		anchor.Ext = &aExt{Cx: w * EMU, Cy: h * EMU}
+		content.AbsoluteAnchor = append(content.AbsoluteAnchor, anchor)
+	case "oneCell":
+		anchor.EditAs, anchor.To = "", nil
+		anchor.Ext = &aExt{Cx: w * EMU, Cy: h * EMU}
+		content.OneCellAnchor = append(content.OneCellAnchor, anchor)
+	default:
+		content.TwoCellAnchor = append(content.TwoCellAnchor, anchor)
+	}
+	f.Drawings.Store(drawingXML, content)
+	return err
+}
+
 // twoCellAnchorShape create a two cell anchor shape size placeholder for a
 // group, a shape, or a drawing element.
 func (f *File) twoCellAnchorShape(sheet, drawingXML, cell string, width, height uint, format GraphicOptions) (*xlsxWsDr, *xdrCellAnchor, int, error) {
@@ -361,6 +526,56 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 	if err != nil {
 		return err
 	}
+	spPr := &xlsxSpPr{
+		Xfrm: xlsxXfrm{
+			Rot: opts.Rotation * 60000,
+		},
+		PrstGeom: xlsxPrstGeom{
+			Prst: opts.Type,
+		},
+	}
+	if *opts.Line.Width != 1 {
+		spPr.Ln.W = f.ptToEMUs(*opts.Line.Width)
+	}
+	if inStrSlice(supportedDrawingLineEndTypes, opts.Line.HeadEnd, true) != -1 {
+		spPr.Ln.HeadEnd = &xlsxLineEnd{Type: opts.Line.HeadEnd}
+	}
+	if inStrSlice(supportedDrawingLineEndTypes, opts.Line.TailEnd, true) != -1 {
+		spPr.Ln.TailEnd = &xlsxLineEnd{Type: opts.Line.TailEnd}
+	}
+	if opts.EndCell != "" {
+		// The shape will be drawn from the top-left corner of the cell to the
+		// top-left corner of the end cell, and flipped if the end cell is on
+		// the left or above the cell
+		fromCol, fromRow, _ := CellNameToCoordinates(cell)
+		toCol, toRow, _ := CellNameToCoordinates(opts.EndCell)
+		spPr.Xfrm.FlipH, spPr.Xfrm.FlipV = toCol < fromCol, toRow < fromRow
+		if spPr.Xfrm.FlipH {
+			fromCol, toCol = toCol, fromCol
+		}
+		if spPr.Xfrm.FlipV {
+			fromRow, toRow = toRow, fromRow
+		}
+		twoCellAnchor.From = &xlsxFrom{Col: fromCol - 1, Row: fromRow - 1}
+		twoCellAnchor.To = &xlsxTo{Col: toCol - 1, Row: toRow - 1}
+	}
+	if inStrSlice(supportedConnectorShapeTypes, opts.Type, true) != -1 {
+		twoCellAnchor.CxnSp = f.drawConnector(cNvPrID, spPr, opts)
+	} else if twoCellAnchor.Sp, err = f.drawShape(cNvPrID, spPr, opts); err != nil {
+		return err
+	}
+	twoCellAnchor.ClientData = &xdrClientData{
+		FLocksWithSheet:  *opts.Format.Locked,
+		FPrintsWithSheet: *opts.Format.PrintObject,
+	}
+	content.TwoCellAnchor = append(content.TwoCellAnchor, twoCellAnchor)
+	f.Drawings.Store(drawingXML, content)
+	return err
+}
+
+// drawShape provides a function to draw the xdr:sp element by given shape ID,
+// shape properties and format sets.
+func (f *File) drawShape(cNvPrID int, spPr *xlsxSpPr, opts *Shape) (*xdrSp, error) {
 	var solidColor string
 	if len(opts.Fill.Color) == 1 {
 		solidColor = opts.Fill.Color[0]
@@ -376,11 +591,7 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 				TxBox: true,
 			},
 		},
-		SpPr: &xlsxSpPr{
-			PrstGeom: xlsxPrstGeom{
-				Prst: opts.Type,
-			},
-		},
+		SpPr: spPr,
 		Style: &xdrStyle{
 			LnRef:     setShapeRef(opts.Line.Color, 2),
 			FillRef:   setShapeRef(solidColor, 1),
@@ -402,14 +613,9 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 			},
 		},
 	}
-	if *opts.Line.Width != 1 {
-		shape.SpPr.Ln = xlsxLineProperties{
-			W: f.ptToEMUs(*opts.Line.Width),
-		}
-	}
 	defaultFont, err := f.GetDefaultFont()
 	if err != nil {
-		return err
+		return &shape, err
 	}
 	if len(opts.Paragraph) < 1 {
 		opts.Paragraph = []RichTextRun{
@@ -426,6 +632,7 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 			},
 		}
 	}
+	paragraph := &aP{EndParaRPr: &aEndParaRPr{Lang: "en-US"}}
 	for _, p := range opts.Paragraph {
 		u := "none"
 		font := &Font{}
@@ -439,41 +646,66 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 		if text == "" {
 			text = " "
 		}
-		paragraph := &aP{
-			R: &aR{
-				RPr: aRPr{
-					I:       font.Italic,
-					B:       font.Bold,
-					Lang:    "en-US",
-					AltLang: "en-US",
-					U:       u,
-					Sz:      font.Size * 100,
-					Latin:   &xlsxCTTextFont{Typeface: font.Family},
-				},
-				T: text,
-			},
-			EndParaRPr: &aEndParaRPr{
-				Lang: "en-US",
-			},
+		rPr := aRPr{
+			I:       font.Italic,
+			B:       font.Bold,
+			Lang:    "en-US",
+			AltLang: "en-US",
+			U:       u,
+			Sz:      font.Size * 100,
+			Latin:   &xlsxCTTextFont{Typeface: font.Family},
 		}
 		srgbClr := strings.ReplaceAll(strings.ToUpper(font.Color), "#", "")
 		if len(srgbClr) == 6 {
-			paragraph.R.RPr.SolidFill = &aSolidFill{
+			rPr.SolidFill = &aSolidFill{
 				SrgbClr: &attrValString{
 					Val: stringPtr(srgbClr),
 				},
 			}
 		}
-		shape.TxBody.P = append(shape.TxBody.P, paragraph)
+		// The line break in the text of the run starts a new paragraph
+		for i, line := range strings.Split(text, "\n") {
+			if i > 0 {
+				shape.TxBody.P = append(shape.TxBody.P, paragraph)
+				paragraph = &aP{EndParaRPr: &aEndParaRPr{Lang: "en-US"}}
+			}
+			if line != "" {
+				paragraph.R = append(paragraph.R, &aR{RPr: rPr, T: line})
+			}
+		}
 	}
-	twoCellAnchor.Sp = &shape
-	twoCellAnchor.ClientData = &xdrClientData{
-		FLocksWithSheet:  *opts.Format.Locked,
-		FPrintsWithSheet: *opts.Format.PrintObject,
+	shape.TxBody.P = append(shape.TxBody.P, paragraph)
+	return &shape, err
+}
+
+// drawConnector provides a function to draw the xdr:cxnSp element by given
+// shape ID, shape properties and format sets.
+func (f *File) drawConnector(cNvPrID int, spPr *xlsxSpPr, opts *Shape) *xdrCxnSp {
+	lnRef := setShapeRef(opts.Line.Color, 1)
+	if opts.Line.Color == "" {
+		lnRef = &aRef{Idx: 1, SchemeClr: &attrValString{Val: stringPtr("accent1")}}
+	}
+	return &xdrCxnSp{
+		Macro: opts.Macro,
+		NvCxnSpPr: &xdrNvCxnSpPr{
+			CNvPr: &xlsxCNvPr{
+				ID:   cNvPrID,
+				Name: "Connector " + strconv.Itoa(cNvPrID),
+			},
+		},
+		SpPr: spPr,
+		Style: &xdrStyle{
+			LnRef:     lnRef,
+			FillRef:   setShapeRef("", 0),
+			EffectRef: setShapeRef("", 0),
+			FontRef: &aFontRef{
+				Idx: "minor",
+				SchemeClr: &attrValString{
+					Val: stringPtr("tx1"),
+				},
+			},
+		},
 	}
-	content.TwoCellAnchor = append(content.TwoCellAnchor, twoCellAnchor)
-	f.Drawings.Store(drawingXML, content)
-	return err
 }
 
 // setShapeRef provides a function to set color with hex model by given actual
@@ -112,4 +112,153 @@ func TestAddDrawingShape(t *testing.T) {
 			},
 		},
 	), "XML syntax error on line 1: invalid UTF-8")
+	// Test add text box with unsupported charset drawing
+	assert.EqualError(t, f.addDrawingTextBox("Sheet1", path, &TextBox{Cell: "A1", Width: defaultShapeSize, Height: defaultShapeSize}),
+		"XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestAddShapeConnectorAndRichText(t *testing.T) {
+	f := NewFile()
+	// Test add shape with rotation and multiple runs in paragraphs
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell:     "A1",
+		Type:     "flowChartProcess",
+		Rotation: 45,
+		Paragraph: []RichTextRun{
+			{Text: "Flowchart ", Font: &Font{Color: "CD5C5C"}},
+			{Text: "Process\nStep 1", Font: &Font{Bold: true, Color: "#2980B9"}},
+		},
+	}))
+	// Test add connector with end cell and line ends
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell:    "F10",
+		EndCell: "C4",
+		Type:    "straightConnector1",
+		Line:    ShapeLine{Color: "4286F4", HeadEnd: "oval", TailEnd: "triangle"},
+	}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell:    "H2",
+		EndCell: "K8",
+		Type:    "bentConnector3",
+		Line:    ShapeLine{HeadEnd: "unknown", TailEnd: "arrow"},
+	}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	wsDr := drawing.(*xlsxWsDr)
+	assert.Len(t, wsDr.TwoCellAnchor, 3)
+	sp := wsDr.TwoCellAnchor[0].Sp
+	assert.Equal(t, 2700000, sp.SpPr.Xfrm.Rot)
+	assert.Len(t, sp.TxBody.P, 2)
+	assert.Len(t, sp.TxBody.P[0].R, 2)
+	assert.Equal(t, "Flowchart ", sp.TxBody.P[0].R[0].T)
+	assert.Equal(t, "Process", sp.TxBody.P[0].R[1].T)
+	assert.Equal(t, "Step 1", sp.TxBody.P[1].R[0].T)
+	assert.Equal(t, "2980B9", *sp.TxBody.P[1].R[0].RPr.SolidFill.SrgbClr.Val)
+
+	anchor := wsDr.TwoCellAnchor[1]
+	assert.Nil(t, anchor.Sp)
+	assert.Equal(t, "Connector 3", anchor.CxnSp.NvCxnSpPr.CNvPr.Name)
+	assert.Equal(t, &xlsxFrom{Col: 2, Row: 3}, anchor.From)
+	assert.Equal(t, &xlsxTo{Col: 5, Row: 9}, anchor.To)
+	assert.True(t, anchor.CxnSp.SpPr.Xfrm.FlipH)
+	assert.True(t, anchor.CxnSp.SpPr.Xfrm.FlipV)
+	assert.Equal(t, &xlsxLineEnd{Type: "oval"}, anchor.CxnSp.SpPr.Ln.HeadEnd)
+	assert.Equal(t, &xlsxLineEnd{Type: "triangle"}, anchor.CxnSp.SpPr.Ln.TailEnd)
+	assert.Equal(t, "4286F4", *anchor.CxnSp.Style.LnRef.SrgbClr.Val)
+
+	anchor = wsDr.TwoCellAnchor[2]
+	assert.Equal(t, &xlsxFrom{Col: 7, Row: 1}, anchor.From)
+	assert.Equal(t, &xlsxTo{Col: 10, Row: 7}, anchor.To)
+	assert.False(t, anchor.CxnSp.SpPr.Xfrm.FlipH)
+	assert.False(t, anchor.CxnSp.SpPr.Xfrm.FlipV)
+	assert.Nil(t, anchor.CxnSp.SpPr.Ln.HeadEnd)
+	assert.Equal(t, &xlsxLineEnd{Type: "arrow"}, anchor.CxnSp.SpPr.Ln.TailEnd)
+	assert.Equal(t, "accent1", *anchor.CxnSp.Style.LnRef.SchemeClr.Val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapeConnector.xlsx")))
+	// Test add shape with invalid end cell reference
+	assert.EqualError(t, f.AddShape("Sheet1", &Shape{Cell: "A1", EndCell: "B", Type: "straightConnector1"}),
+		newCellNameToCoordinatesError("B", newInvalidCellNameError("B")).Error())
+}
+
+func TestAddTextBox(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Linked"))
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet 2", "B2", 100))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 20))
+	// Test add text box with different anchor types
+	assert.NoError(t, f.AddTextBox("Sheet1", &TextBox{
+		Cell: "C2",
+		Link: "=$A$1",
+		Line: ShapeLine{Color: "4286F4"},
+		Fill: Fill{Color: []string{"8EB9FF"}, Pattern: 1},
+	}))
+	assert.NoError(t, f.AddTextBox("Sheet1", &TextBox{
+		Cell:   "C10",
+		Anchor: "oneCell",
+		Link:   "'Sheet 2'!$B$2",
+		Width:  180,
+		Height: 40,
+	}))
+	assert.NoError(t, f.AddTextBox("Sheet1", &TextBox{
+		Cell:   "B3",
+		Anchor: "absolute",
+		Format: GraphicOptions{OffsetX: 10, OffsetY: 5},
+		Paragraph: []RichTextRun{
+			{Text: "Text ", Font: &Font{Color: "CD5C5C"}},
+			{Text: "Box", Font: &Font{Bold: true}},
+		},
+	}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	wsDr := drawing.(*xlsxWsDr)
+	assert.Len(t, wsDr.TwoCellAnchor, 1)
+	assert.Len(t, wsDr.OneCellAnchor, 1)
+	assert.Len(t, wsDr.AbsoluteAnchor, 1)
+	sp := wsDr.TwoCellAnchor[0].Sp
+	assert.Equal(t, "$A$1", sp.Textlink)
+	assert.Equal(t, "TextBox 2", sp.NvSpPr.CNvPr.Name)
+	assert.Equal(t, "Linked", sp.TxBody.P[0].R[0].T)
+	anchor := wsDr.OneCellAnchor[0]
+	assert.Equal(t, "'Sheet 2'!$B$2", anchor.Sp.Textlink)
+	assert.Equal(t, "100", anchor.Sp.TxBody.P[0].R[0].T)
+	assert.Nil(t, anchor.To)
+	assert.Equal(t, &aExt{Cx: 180 * EMU, Cy: 40 * EMU}, anchor.Ext)
+	anchor = wsDr.AbsoluteAnchor[0]
+	assert.Equal(t, &xlsxPoint2D{X: (f.getColWidth("Sheet1", 1) + 10) * EMU, Y: (f.getRowHeight("Sheet1", 1) + f.getRowHeight("Sheet1", 2) + 5) * EMU}, anchor.Pos)
+	assert.Equal(t, &aExt{Cx: defaultShapeSize * EMU, Cy: defaultShapeSize * EMU}, anchor.Ext)
+	assert.Nil(t, anchor.From)
+	assert.Len(t, anchor.Sp.TxBody.P[0].R, 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTextBox.xlsx")))
+
+	// Test add text box in the workbook with existing absolute anchor
+	f, err = OpenFile(filepath.Join("test", "TestAddTextBox.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddTextBox("Sheet1", &TextBox{Cell: "F2"}))
+	drawing, ok = f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	wsDr = drawing.(*xlsxWsDr)
+	assert.Len(t, wsDr.AbsoluteAnchor, 1)
+	assert.Equal(t, "TextBox 5", wsDr.TwoCellAnchor[1].Sp.NvSpPr.CNvPr.Name)
+	assert.NoError(t, f.Close())
+
+	// Test add text box with invalid options
+	f = NewFile()
+	assert.Equal(t, ErrParameterInvalid, f.AddTextBox("Sheet1", nil))
+	assert.Equal(t, ErrParameterInvalid, f.AddTextBox("Sheet1", &TextBox{Cell: "A1", Anchor: "unknown"}))
+	assert.EqualError(t, f.AddTextBox("SheetN", &TextBox{Cell: "A1"}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.AddTextBox("Sheet1", &TextBox{Cell: "A"}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test add text box with invalid link
+	assert.EqualError(t, f.AddTextBox("Sheet1", &TextBox{Cell: "A1", Link: "SheetN!A1"}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.AddTextBox("Sheet1", &TextBox{Cell: "A1", Link: "A"}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test add text box with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddTextBox("Sheet1", &TextBox{Cell: "A1"}), "XML syntax error on line 1: invalid UTF-8")
+	// Test add text box with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddTextBox("Sheet1", &TextBox{Cell: "A1"}), "XML syntax error on line 1: invalid UTF-8")
 }
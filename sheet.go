@@ -12,6 +12,7 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/xml"
 	"fmt"
@@ -24,6 +25,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 	"unicode/utf16"
 	"unicode/utf8"
 
@@ -45,6 +47,7 @@ func (f *File) NewSheet(sheet string) (int, error) {
 		return index, err
 	}
 	_ = f.DeleteSheet(sheet)
+	f.clearCalcCache()
 	f.SheetCount++
 	wb, _ := f.workbookReader()
 	sheetID := 0
@@ -66,8 +69,26 @@ func (f *File) NewSheet(sheet string) (int, error) {
 }
 
 // contentTypesReader provides a function to get the pointer to the
-// [Content_Types].xml structure after deserialization.
+// [Content_Types].xml structure after deserialization, and track the content
+// types part was modified.
 func (f *File) contentTypesReader() (*xlsxTypes, error) {
+	return f.getContentTypes(false)
+}
+
+// contentTypesViewer provides a function to get the pointer to the
+// [Content_Types].xml structure after deserialization for read only. If the
+// content types part was loaded by this function and never be got by the
+// contentTypesReader, the original part will be copied verbatim on save
+// instead of serialize the structure.
+func (f *File) contentTypesViewer() (*xlsxTypes, error) {
+	return f.getContentTypes(true)
+}
+
+// getContentTypes provides a function to get the pointer to the
+// [Content_Types].xml structure after deserialization, and track if the
+// content types part was modified.
+func (f *File) getContentTypes(readOnly bool) (*xlsxTypes, error) {
+	defer f.trackPart(defaultXMLPathContentTypes, f.ContentTypes == nil, readOnly)
 	if f.ContentTypes == nil {
 		f.ContentTypes = new(xlsxTypes)
 		f.ContentTypes.mu.Lock()
@@ -83,6 +104,9 @@ func (f *File) contentTypesReader() (*xlsxTypes, error) {
 // contentTypesWriter provides a function to save [Content_Types].xml after
 // serialize structure.
 func (f *File) contentTypesWriter() {
+	if _, ok := f.unmodified.Load(defaultXMLPathContentTypes); ok {
+		return
+	}
 	if f.ContentTypes != nil {
 		output, _ := xml.Marshal(f.ContentTypes)
 		f.saveFileList(defaultXMLPathContentTypes, output)
@@ -134,14 +158,20 @@ func (f *File) mergeExpandedCols(ws *xlsxWorksheet) {
 }
 
 // workSheetWriter provides a function to save xl/worksheets/sheet%d.xml after
-// serialize structure.
-func (f *File) workSheetWriter() {
+// serialize structure. The worksheets will be encoded into the zip writer
+// directly without buffering the whole worksheet XML in memory, and returns
+// the path of the written worksheets.
+func (f *File) workSheetWriter(zw *zip.Writer) (map[string]struct{}, error) {
 	var (
-		arr     []byte
-		buffer  = bytes.NewBuffer(arr)
-		encoder = xml.NewEncoder(buffer)
+		err     error
+		written = map[string]struct{}{}
 	)
-	f.Sheet.Range(func(p, ws interface{}) bool {
+	f.rangeZipEntries(&f.Sheet, func(p string, ws interface{}) bool {
+		if _, ok := f.unmodified.Load(p); ok {
+			if _, ok = f.Pkg.Load(p); ok {
+				return true
+			}
+		}
 		if ws != nil {
 			sheet := ws.(*xlsxWorksheet)
 			if sheet.MergeCells != nil && len(sheet.MergeCells.Cells) > 0 {
@@ -152,27 +182,46 @@ func (f *File) workSheetWriter() {
 			}
 			sheet.SheetData.Row = trimRow(&sheet.SheetData)
 			if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
-				f.addNameSpaces(p.(string), SourceRelationship)
+				f.addNameSpaces(p, SourceRelationship)
 			}
-			if sheet.DecodeAlternateContent != nil {
-				sheet.AlternateContent = &xlsxAlternateContent{
-					Content: sheet.DecodeAlternateContent.Content,
+			for _, content := range sheet.DecodeAlternateContent {
+				sheet.AlternateContent = append(sheet.AlternateContent, &xlsxAlternateContent{
+					Content: content.Content,
 					XMLNSMC: SourceRelationshipCompatibility.Value,
-				}
+				})
 			}
 			sheet.DecodeAlternateContent = nil
-			// reusing buffer
-			_ = encoder.Encode(sheet)
-			f.saveFileList(p.(string), replaceRelationshipsBytes(f.replaceNameSpaceBytes(p.(string), buffer.Bytes())))
-			_, ok := f.checked.Load(p.(string))
-			if ok {
-				f.Sheet.Delete(p.(string))
-				f.checked.Store(p.(string), false)
+			var fi io.Writer
+			if fi, err = f.createZipEntry(zw, p); err != nil {
+				return false
+			}
+			if err = f.encodeWorksheet(fi, p, sheet); err != nil {
+				return false
 			}
-			buffer.Reset()
+			written[p] = struct{}{}
+			f.Pkg.Delete(p)
 		}
 		return true
 	})
+	return written, err
+}
+
+// encodeWorksheet provides a function to encode the worksheet into given
+// writer, the XML root element attribute and the relationships namespace will
+// be replaced during the encoding.
+func (f *File) encodeWorksheet(w io.Writer, path string, ws *xlsxWorksheet) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	rw := newReplaceWriter(w, []byte(`xmlns:relationships="http://schemas.openxmlformats.org/officeDocument/2006/relationships" relationships`), []byte("r"))
+	nw := newReplaceWriter(rw, []byte(`xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`), f.getNameSpaceBytes(path))
+	if err := xml.NewEncoder(nw).Encode(ws); err != nil {
+		return err
+	}
+	if err := nw.Flush(); err != nil {
+		return err
+	}
+	return rw.Flush()
 }
 
 // trimRow provides a function to trim empty rows.
@@ -190,7 +239,7 @@ func trimRow(sheetData *xlsxSheetData) []xlsxRow {
 	return rows
 }
 
-// trimCell provides a function to trim blank cells which created by fillColumns.
+// trimCell provides a function to trim blank cells which created by prepareSheetXML.
 func trimCell(column []xlsxC) []xlsxC {
 	rowFull := true
 	for i := range column {
@@ -244,6 +293,9 @@ func (f *File) setSheet(index int, name string) {
 // serialize structure.
 func (f *File) relsWriter() {
 	f.Relationships.Range(func(path, rel interface{}) bool {
+		if _, ok := f.unmodified.Load(path); ok {
+			return true
+		}
 		if rel != nil {
 			output, _ := xml.Marshal(rel.(*xlsxRelationships))
 			if strings.HasPrefix(path.(string), "xl/worksheets/sheet/rels/sheet") {
@@ -317,7 +369,7 @@ func (f *File) SetActiveSheet(index int) {
 // spreadsheet. If not found the active sheet will be return integer 0.
 func (f *File) GetActiveSheetIndex() (index int) {
 	sheetID := f.getActiveSheetID()
-	wb, _ := f.workbookReader()
+	wb, _ := f.workbookViewer()
 	if wb != nil {
 		for idx, sheet := range wb.Sheets.Sheet {
 			if sheet.SheetID == sheetID {
@@ -332,7 +384,7 @@ func (f *File) GetActiveSheetIndex() (index int) {
 // getActiveSheetID provides a function to get active sheet ID of the
 // spreadsheet. If not found the active sheet will be return integer 0.
 func (f *File) getActiveSheetID() int {
-	wb, _ := f.workbookReader()
+	wb, _ := f.workbookViewer()
 	if wb != nil {
 		if wb.BookViews != nil && len(wb.BookViews.WorkBookView) > 0 {
 			activeTab := wb.BookViews.WorkBookView[0].ActiveTab
@@ -353,6 +405,7 @@ func (f *File) getActiveSheetID() int {
 // sheet name in the formula or reference associated with the cell. So there
 // may be problem formula error or reference missing.
 func (f *File) SetSheetName(source, target string) error {
+	f.clearCalcCache()
 	var err error
 	if err = checkSheetName(source); err != nil {
 		return err
@@ -430,7 +483,7 @@ func (f *File) GetSheetIndex(sheet string) (int, error) {
 //	    fmt.Println(index, name)
 //	}
 func (f *File) GetSheetMap() map[int]string {
-	wb, _ := f.workbookReader()
+	wb, _ := f.workbookViewer()
 	sheetMap := map[int]string{}
 	if wb != nil {
 		for _, sheet := range wb.Sheets.Sheet {
@@ -443,7 +496,7 @@ func (f *File) GetSheetMap() map[int]string {
 // GetSheetList provides a function to get worksheets, chart sheets, and
 // dialog sheets name list of the workbook.
 func (f *File) GetSheetList() (list []string) {
-	wb, _ := f.workbookReader()
+	wb, _ := f.workbookViewer()
 	if wb != nil {
 		for _, sheet := range wb.Sheets.Sheet {
 			list = append(list, sheet.Name)
@@ -456,11 +509,11 @@ func (f *File) GetSheetList() (list []string) {
 // of the spreadsheet.
 func (f *File) getSheetMap() (map[string]string, error) {
 	maps := map[string]string{}
-	wb, err := f.workbookReader()
+	wb, err := f.workbookViewer()
 	if err != nil {
 		return nil, err
 	}
-	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	rels, err := f.relsViewer(f.getWorkbookRelsPath())
 	if err != nil {
 		return nil, err
 	}
@@ -477,6 +530,9 @@ func (f *File) getSheetMap() (map[string]string, error) {
 				if _, ok := f.tempFiles.Load(sheetXMLPath); ok {
 					maps[v.Name] = sheetXMLPath
 				}
+				if _, ok := f.lazyParts.Load(sheetXMLPath); ok {
+					maps[v.Name] = sheetXMLPath
+				}
 			}
 		}
 	}
@@ -546,6 +602,7 @@ func (f *File) setSheetBackground(sheet, extension string, file []byte) error {
 // value of the deleted worksheet, it will cause a file error when you open
 // it. This function will be invalid when only one worksheet is left.
 func (f *File) DeleteSheet(sheet string) error {
+	f.clearCalcCache()
 	if err := checkSheetName(sheet); err != nil {
 		return err
 	}
@@ -580,6 +637,8 @@ func (f *File) DeleteSheet(sheet string) error {
 		_ = f.deleteCalcChain(f.getSheetID(sheet), "")
 		delete(f.sheetMap, v.Name)
 		f.Pkg.Delete(sheetXML)
+		f.lazyParts.Delete(sheetXML)
+		f.unmodified.Delete(sheetXML)
 		f.Pkg.Delete(rels)
 		f.Relationships.Delete(rels)
 		f.Sheet.Delete(sheetXML)
@@ -658,7 +717,7 @@ func (f *File) getSheetRelationshipsTargetByID(sheet, rID string) string {
 		name = strings.ToLower(sheet) + ".xml"
 	}
 	rels := "xl/worksheets/_rels/" + strings.TrimPrefix(name, "xl/worksheets/") + ".rels"
-	sheetRels, _ := f.relsReader(rels)
+	sheetRels, _ := f.relsViewer(rels)
 	if sheetRels == nil {
 		sheetRels = &xlsxRelationships{}
 	}
@@ -684,6 +743,7 @@ func (f *File) getSheetRelationshipsTargetByID(sheet, rID string) string {
 //	}
 //	err := f.CopySheet(1, index)
 func (f *File) CopySheet(from, to int) error {
+	f.clearCalcCache()
 	if from < 0 || to < 0 || from == to || f.GetSheetName(from) == "" || f.GetSheetName(to) == "" {
 		return ErrSheetIdx
 	}
@@ -719,6 +779,543 @@ func (f *File) copySheet(from, to int) error {
 	return err
 }
 
+// CopySheetFrom provides a function to copy a worksheet from another workbook
+// by given source workbook, source worksheet name and destination worksheet
+// name, the destination worksheet will be created in the workbook. The cell
+// styles, conditional formats, shared strings, merged cells, hyperlinks,
+// pictures, charts and defined names of the source worksheet will be
+// translated into the workbook, and the references to the source worksheet in
+// the formulas, charts and defined names will be replaced with the
+// destination worksheet name. Note that the comments, tables, form controls,
+// slicers and background picture of the worksheet will not be copied. For
+// example, copy the worksheet named Template in the template workbook as
+// Sheet2:
+//
+//	tpl, err := excelize.OpenFile("Template.xlsx")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.CopySheetFrom(tpl, "Template", "Sheet2")
+func (f *File) CopySheetFrom(src *File, srcSheet, dstSheet string) error {
+	if err := checkSheetName(dstSheet); err != nil {
+		return err
+	}
+	if _, err := f.workbookReader(); err != nil {
+		return err
+	}
+	if index, _ := f.GetSheetIndex(dstSheet); index != -1 {
+		return ErrExistsSheet
+	}
+	srcWs, err := src.workSheetReader(srcSheet)
+	if err != nil {
+		return err
+	}
+	if err = src.sharedStringsLoader(); err != nil {
+		return err
+	}
+	sst, err := src.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	f.clearCalcCache()
+	ws := deepcopy.Copy(srcWs).(*xlsxWorksheet)
+	if err = f.copySheetCells(src, sst, ws, srcSheet, dstSheet); err != nil {
+		return err
+	}
+	if len(ws.SheetViews.SheetView) > 0 {
+		ws.SheetViews.SheetView[0].TabSelected = false
+	}
+	if ws.PageSetUp != nil {
+		ws.PageSetUp.RID = ""
+	}
+	ws.Drawing, ws.LegacyDrawing, ws.LegacyDrawingHF, ws.DrawingHF = nil, nil, nil, nil
+	ws.Picture, ws.OleObjects, ws.Controls, ws.TableParts = nil, nil, nil, nil
+	ws.AlternateContent, ws.DecodeAlternateContent = nil, nil
+	if err = f.removeSheetExt(ws, ExtURISlicerListX14, ExtURISlicerListX15, ExtURITimelineRefs); err != nil {
+		return err
+	}
+	if _, err = f.NewSheet(dstSheet); err != nil {
+		return err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(dstSheet)
+	srcSheetXMLPath, _ := src.getSheetXMLPath(srcSheet)
+	if attrs, ok := src.xmlAttr.Load(srcSheetXMLPath); ok {
+		f.xmlAttr.Store(sheetXMLPath, attrs)
+	}
+	f.Sheet.Store(sheetXMLPath, ws)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	if ws.Hyperlinks != nil {
+		for i := range ws.Hyperlinks.Hyperlink {
+			link := &ws.Hyperlinks.Hyperlink[i]
+			if link.RID == "" {
+				continue
+			}
+			target := src.getSheetRelationshipsTargetByID(srcSheet, link.RID)
+			link.RID = "rId" + strconv.Itoa(f.addRels(sheetRels, SourceRelationshipHyperLink, target, "External"))
+			f.addSheetNameSpace(dstSheet, SourceRelationship)
+		}
+	}
+	if srcWs.Drawing != nil {
+		if err = f.copySheetDrawing(src, srcSheet, dstSheet, srcWs.Drawing.RID); err != nil {
+			return err
+		}
+	}
+	return f.copySheetDefinedNames(src, srcSheet, dstSheet)
+}
+
+// copySheetCells translate the styles, shared strings and formulas of the
+// cells, the styles of rows and columns and the formats of conditional
+// formats in the worksheet copied from the source workbook.
+func (f *File) copySheetCells(src *File, sst *xlsxSST, ws *xlsxWorksheet, srcSheet, dstSheet string) error {
+	styles, dxfs := map[int]int{0: 0}, map[int]int{}
+	copyStyle := func(styleID int) (int, error) {
+		if ID, ok := styles[styleID]; ok {
+			return ID, nil
+		}
+		style, err := src.GetStyle(styleID)
+		if err != nil {
+			return styleID, err
+		}
+		styles[styleID], err = f.NewStyle(style)
+		return styles[styleID], err
+	}
+	var err error
+	for i := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[i]
+		if row.S, err = copyStyle(row.S); err != nil {
+			return err
+		}
+		for j := range row.C {
+			c := &row.C[j]
+			if c.S, err = copyStyle(c.S); err != nil {
+				return err
+			}
+			c.Cm, c.Vm = nil, nil
+			if c.F != nil {
+				c.F.Content = renameFormulaSheet(c.F.Content, srcSheet, dstSheet)
+			}
+			if idx, err := strconv.Atoi(c.V); c.T == "s" && err == nil && idx >= 0 && idx < len(sst.SI) {
+				if idx, err = f.copySharedString(sst.SI[idx]); err != nil {
+					return err
+				}
+				c.V = strconv.Itoa(idx)
+			}
+		}
+	}
+	if ws.Cols != nil {
+		for i := range ws.Cols.Col {
+			if ws.Cols.Col[i].Style, err = copyStyle(ws.Cols.Col[i].Style); err != nil {
+				return err
+			}
+		}
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			if rule.DxfID == nil {
+				continue
+			}
+			if ID, ok := dxfs[*rule.DxfID]; ok {
+				rule.DxfID = intPtr(ID)
+				continue
+			}
+			style, err := src.GetConditionalStyle(*rule.DxfID)
+			if err != nil {
+				return err
+			}
+			ID, err := f.NewConditionalStyle(style)
+			if err != nil {
+				return err
+			}
+			dxfs[*rule.DxfID], rule.DxfID = ID, intPtr(ID)
+		}
+	}
+	return err
+}
+
+// copySharedString provides a function to add the string item copied from the
+// source workbook to the shared string table, and returns the index of the
+// string item in the table.
+func (f *File) copySharedString(si xlsxSI) (int, error) {
+	if si.T != nil && si.R == nil && si.RPh == nil && si.PhoneticPr == nil {
+		return f.setSharedString(si.T.Val)
+	}
+	if err := f.sharedStringsLoader(); err != nil {
+		return 0, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return 0, err
+	}
+	sst.mu.Lock()
+	defer sst.mu.Unlock()
+	sst.Count++
+	sst.UniqueCount++
+	sst.SI = append(sst.SI, deepcopy.Copy(si).(xlsxSI))
+	return sst.UniqueCount - 1, nil
+}
+
+// removeSheetExt provides a function to remove the worksheet extensions by
+// given URIs.
+func (f *File) removeSheetExt(ws *xlsxWorksheet, URIs ...string) error {
+	if ws.ExtLst == nil {
+		return nil
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	var exts []*xlsxExt
+	for _, ext := range decodeExtLst.Ext {
+		if inStrSlice(URIs, ext.URI, true) == -1 {
+			exts = append(exts, ext)
+		}
+	}
+	if len(exts) == len(decodeExtLst.Ext) {
+		return nil
+	}
+	if len(exts) == 0 {
+		ws.ExtLst = nil
+		return nil
+	}
+	decodeExtLst.Ext = exts
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
+}
+
+// copySheetDrawing provides a function to copy the drawing part of the
+// worksheet and the pictures and charts in the drawing from the source
+// workbook by given relationship ID of the drawing in the source worksheet.
+func (f *File) copySheetDrawing(src *File, srcSheet, dstSheet, rID string) error {
+	srcDrawingXML := strings.TrimPrefix(strings.ReplaceAll(src.getSheetRelationshipsTargetByID(srcSheet, rID), "..", "xl"), "/")
+	wsDr, _, err := src.drawingParser(srcDrawingXML)
+	if err != nil {
+		return err
+	}
+	drawingID := f.countDrawings() + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	f.Drawings.Store(drawingXML, deepcopy.Copy(wsDr).(*xlsxWsDr))
+	if err = f.copyPartRels(src, srcSheet, dstSheet, srcDrawingXML, drawingXML); err != nil {
+		return err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(dstSheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	f.addSheetDrawing(dstSheet, f.addRels(sheetRels, SourceRelationshipDrawingML, "../drawings/drawing"+strconv.Itoa(drawingID)+".xml", ""))
+	f.addSheetNameSpace(dstSheet, SourceRelationship)
+	return f.addContentTypePart(drawingID, "drawings")
+}
+
+// copyPartRels provides a function to copy the relationships of the part and
+// the related parts from the source workbook by given source and destination
+// part path. The relationship IDs will be kept, so the content of the part
+// doesn't need to be changed.
+func (f *File) copyPartRels(src *File, srcSheet, dstSheet, srcPath, dstPath string) error {
+	srcRels, err := src.relsReader(path.Join(path.Dir(srcPath), "_rels", path.Base(srcPath)+".rels"))
+	if err != nil || srcRels == nil {
+		return err
+	}
+	rels := &xlsxRelationships{}
+	for _, rel := range srcRels.Relationships {
+		if rel.TargetMode != "External" {
+			target := path.Join(path.Dir(srcPath), rel.Target)
+			if strings.HasPrefix(rel.Target, "/") {
+				target = strings.TrimPrefix(rel.Target, "/")
+			}
+			if target, err = f.copyPart(src, srcSheet, dstSheet, target); err != nil {
+				return err
+			}
+			rel.Target = "../" + strings.TrimPrefix(target, "xl/")
+		}
+		rels.Relationships = append(rels.Relationships, rel)
+	}
+	f.Relationships.Store(path.Join(path.Dir(dstPath), "_rels", path.Base(dstPath)+".rels"), rels)
+	return err
+}
+
+// copyPart provides a function to copy the part with the content type and
+// relationships from the source workbook by given part path, and returns the
+// new part path in the workbook. The references to the source worksheet in
+// the charts will be replaced with the destination worksheet name.
+func (f *File) copyPart(src *File, srcSheet, dstSheet, partPath string) (string, error) {
+	content, ok := src.Pkg.Load(partPath)
+	if !ok {
+		return partPath, nil
+	}
+	dstPath := partPath
+	if strings.HasPrefix(partPath, "xl/media/image") {
+		dstPath = f.addMedia(content.([]byte), path.Ext(partPath))
+	} else {
+		ext := path.Ext(partPath)
+		prefix := strings.TrimRightFunc(strings.TrimSuffix(partPath, ext), func(r rune) bool {
+			return '0' <= r && r <= '9'
+		})
+		for i := 1; ; i++ {
+			if dstPath = prefix + strconv.Itoa(i) + ext; !f.partExists(dstPath) {
+				break
+			}
+		}
+		data := content.([]byte)
+		if strings.HasPrefix(partPath, "xl/charts/chart") {
+			data = renameChartSheet(data, srcSheet, dstSheet)
+		}
+		f.Pkg.Store(dstPath, data)
+	}
+	if err := f.copyContentType(src, partPath, dstPath); err != nil {
+		return dstPath, err
+	}
+	return dstPath, f.copyPartRels(src, srcSheet, dstSheet, partPath, dstPath)
+}
+
+// partExists provides a function to check if the part exists in the workbook
+// by given part path.
+func (f *File) partExists(partPath string) bool {
+	if _, ok := f.Pkg.Load(partPath); ok {
+		return true
+	}
+	_, ok := f.Drawings.Load(partPath)
+	return ok
+}
+
+// copyContentType provides a function to set the content type of the part
+// copied from the source workbook by given source and destination part path.
+func (f *File) copyContentType(src *File, srcPath, dstPath string) error {
+	srcContent, err := src.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	for _, override := range srcContent.Overrides {
+		if override.PartName == "/"+srcPath {
+			for _, v := range content.Overrides {
+				if v.PartName == "/"+dstPath {
+					return err
+				}
+			}
+			content.Overrides = append(content.Overrides, xlsxOverride{PartName: "/" + dstPath, ContentType: override.ContentType})
+			return err
+		}
+	}
+	ext := strings.TrimPrefix(path.Ext(dstPath), ".")
+	for _, v := range content.Defaults {
+		if strings.EqualFold(v.Extension, ext) {
+			return err
+		}
+	}
+	for _, v := range srcContent.Defaults {
+		if strings.EqualFold(v.Extension, ext) {
+			content.Defaults = append(content.Defaults, v)
+		}
+	}
+	return err
+}
+
+// copySheetDefinedNames provides a function to copy the defined names which
+// scope is the source worksheet, or the workbook scope defined names which
+// refer to the source worksheet, the workbook scope defined name will be
+// copied as the worksheet scope if the same name already exists.
+func (f *File) copySheetDefinedNames(src *File, srcSheet, dstSheet string) error {
+	srcWb, err := src.workbookReader()
+	if err != nil || srcWb.DefinedNames == nil {
+		return err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	srcIndex, _ := src.GetSheetIndex(srcSheet)
+	index, _ := f.GetSheetIndex(dstSheet)
+	for _, dn := range srcWb.DefinedNames.DefinedName {
+		data := renameFormulaSheet(dn.Data, srcSheet, dstSheet)
+		if (dn.LocalSheetID == nil && data == dn.Data) || (dn.LocalSheetID != nil && *dn.LocalSheetID != srcIndex) {
+			continue
+		}
+		definedName := dn
+		definedName.Data, definedName.LocalSheetID = data, nil
+		if wb.DefinedNames == nil {
+			wb.DefinedNames = &xlsxDefinedNames{}
+		}
+		for _, v := range wb.DefinedNames.DefinedName {
+			if v.LocalSheetID == nil && strings.EqualFold(v.Name, dn.Name) {
+				definedName.LocalSheetID = intPtr(index)
+			}
+		}
+		if dn.LocalSheetID != nil {
+			definedName.LocalSheetID = intPtr(index)
+		}
+		wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, definedName)
+	}
+	return err
+}
+
+// renameFormulaSheet provides a function to replace the worksheet name of the
+// references in the formula, such as Sheet1!$A$1:$B$2 or 'Sheet 1'!A1.
+func renameFormulaSheet(formula, source, target string) string {
+	return adjustFormulaRefRegexp.ReplaceAllStringFunc(formula, func(match string) string {
+		idx := strings.LastIndex(match, "!")
+		name := match[:idx]
+		if strings.HasPrefix(name, "'") {
+			name = strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(name, "'"), "'"), "''", "'")
+		}
+		if !strings.EqualFold(name, source) {
+			return match
+		}
+		return quoteSheetName(target) + match[idx:]
+	})
+}
+
+// quoteSheetName provides a function to quote the worksheet name by single
+// quotes for using in the references, if the name contains any characters
+// except letters, digits, underscores and periods, or starts with a digit.
+func quoteSheetName(name string) string {
+	if name != "" && strings.IndexFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.'
+	}) == -1 && !unicode.IsDigit([]rune(name)[0]) {
+		return name
+	}
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
+
+// renameChartSheet provides a function to replace the worksheet name of the
+// references in the formulas of the chart part.
+func renameChartSheet(content []byte, source, target string) []byte {
+	unescape := strings.NewReplacer("&apos;", "'", "&#39;", "'", "&quot;", "\"", "&#34;", "\"", "&lt;", "<", "&gt;", ">", "&amp;", "&")
+	return adjustChartFormulaRegexp.ReplaceAllFunc(content, func(match []byte) []byte {
+		matches := adjustChartFormulaRegexp.FindSubmatch(match)
+		formula := unescape.Replace(string(matches[2]))
+		ref := renameFormulaSheet(formula, source, target)
+		if ref == formula {
+			return match
+		}
+		var buf bytes.Buffer
+		buf.Write(matches[1])
+		_ = xml.EscapeText(&buf, []byte(ref))
+		buf.Write(matches[3])
+		return buf.Bytes()
+	})
+}
+
+// SheetVisibility is the type of the worksheet visibility.
+type SheetVisibility byte
+
+// This section defines the supported worksheet visibility types enumeration.
+const (
+	SheetVisible SheetVisibility = iota
+	SheetHidden
+	SheetVeryHidden
+)
+
+// SetSheetVisibility provides a function to set worksheet visibility by given
+// worksheet name and visibility type. The very hidden worksheet can't be
+// unhidden in the spreadsheet application user interface. A workbook must
+// contain at least one visible worksheet, and the activated worksheet can't
+// be hidden. For example, make Sheet2 very hidden:
+//
+//	err := f.SetSheetVisibility("Sheet2", excelize.SheetVeryHidden)
+func (f *File) SetSheetVisibility(sheet string, visibility SheetVisibility) error {
+	switch visibility {
+	case SheetVisible:
+		return f.SetSheetVisible(sheet, true)
+	case SheetHidden:
+		return f.SetSheetVisible(sheet, false)
+	case SheetVeryHidden:
+		return f.SetSheetVisible(sheet, false, true)
+	}
+	return ErrParameterInvalid
+}
+
+// GetSheetVisibility provides a function to get worksheet visibility by given
+// worksheet name. For example, get visibility of Sheet2:
+//
+//	visibility, err := f.GetSheetVisibility("Sheet2")
+func (f *File) GetSheetVisibility(sheet string) (SheetVisibility, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return SheetVisible, err
+	}
+	wb, err := f.workbookViewer()
+	if err != nil {
+		return SheetVisible, err
+	}
+	for _, v := range wb.Sheets.Sheet {
+		if strings.EqualFold(v.Name, sheet) {
+			switch v.State {
+			case "hidden":
+				return SheetHidden, err
+			case "veryHidden":
+				return SheetVeryHidden, err
+			}
+			return SheetVisible, err
+		}
+	}
+	return SheetVisible, ErrSheetNotExist{sheet}
+}
+
+// MoveSheet provides a function to move the worksheet to the given position
+// in the sheet tabs by given worksheet name and zero-based target index. The
+// scope of the worksheet level defined names and the active sheet will be
+// kept with the sheets. For example, move Sheet3 to be the first worksheet:
+//
+//	err := f.MoveSheet("Sheet3", 0)
+func (f *File) MoveSheet(sheet string, index int) error {
+	if err := checkSheetName(sheet); err != nil {
+		return err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	from := -1
+	for idx, v := range wb.Sheets.Sheet {
+		if strings.EqualFold(v.Name, sheet) {
+			from = idx
+		}
+	}
+	if from == -1 {
+		return ErrSheetNotExist{sheet}
+	}
+	if index < 0 || index >= len(wb.Sheets.Sheet) {
+		return ErrSheetIdx
+	}
+	if from == index {
+		return err
+	}
+	f.clearCalcCache()
+	moveIndex := func(idx int) int {
+		switch {
+		case idx == from:
+			return index
+		case from < index && idx > from && idx <= index:
+			return idx - 1
+		case index < from && idx >= index && idx < from:
+			return idx + 1
+		}
+		return idx
+	}
+	target := wb.Sheets.Sheet[from]
+	wb.Sheets.Sheet = append(wb.Sheets.Sheet[:from], wb.Sheets.Sheet[from+1:]...)
+	wb.Sheets.Sheet = append(wb.Sheets.Sheet[:index], append([]xlsxSheet{target}, wb.Sheets.Sheet[index:]...)...)
+	if wb.DefinedNames != nil {
+		for idx, dn := range wb.DefinedNames.DefinedName {
+			if dn.LocalSheetID != nil {
+				wb.DefinedNames.DefinedName[idx].LocalSheetID = intPtr(moveIndex(*dn.LocalSheetID))
+			}
+		}
+	}
+	if wb.BookViews != nil {
+		for idx, view := range wb.BookViews.WorkBookView {
+			wb.BookViews.WorkBookView[idx].ActiveTab = moveIndex(view.ActiveTab)
+			wb.BookViews.WorkBookView[idx].FirstSheet = moveIndex(view.FirstSheet)
+		}
+	}
+	return err
+}
+
 // getSheetState returns sheet visible enumeration by given hidden status.
 func getSheetState(visible bool, veryHidden []bool) string {
 	state := "hidden"
@@ -776,9 +1373,25 @@ func (f *File) SetSheetVisible(sheet string, visible bool, veryHidden ...bool) e
 
 // setPanes set create freeze panes and split panes by given options.
 func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
-	if panes == nil {
+	if panes == nil || panes.XSplit < 0 || panes.YSplit < 0 {
+		return ErrParameterInvalid
+	}
+	panesTypes := []string{"bottomLeft", "bottomRight", "topLeft", "topRight"}
+	if panes.ActivePane != "" && inStrSlice(panesTypes, panes.ActivePane, true) == -1 {
 		return ErrParameterInvalid
 	}
+	for _, s := range panes.Selection {
+		if s.Pane != "" && inStrSlice(panesTypes, s.Pane, true) == -1 {
+			return ErrParameterInvalid
+		}
+	}
+	if panes.Freeze || panes.Split {
+		opts := *panes
+		if err := opts.prepareDefaults(); err != nil {
+			return err
+		}
+		panes = &opts
+	}
 	p := &xlsxPane{
 		ActivePane:  panes.ActivePane,
 		TopLeftCell: panes.TopLeftCell,
@@ -809,6 +1422,29 @@ func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 	return nil
 }
 
+// prepareDefaults fill the top left visible cell of the frozen panes and the
+// active pane by given split positions if they were omitted.
+func (panes *Panes) prepareDefaults() error {
+	if panes.ActivePane == "" {
+		panes.ActivePane = "topLeft"
+		if panes.XSplit > 0 {
+			panes.ActivePane = "topRight"
+		}
+		if panes.YSplit > 0 {
+			panes.ActivePane = "bottomLeft"
+		}
+		if panes.XSplit > 0 && panes.YSplit > 0 {
+			panes.ActivePane = "bottomRight"
+		}
+	}
+	if !panes.Freeze || panes.TopLeftCell != "" {
+		return nil
+	}
+	var err error
+	panes.TopLeftCell, err = CoordinatesToCellName(panes.XSplit+1, panes.YSplit+1)
+	return err
+}
+
 // SetPanes provides a function to create and remove freeze panes and split panes
 // by given worksheet name and panes options.
 //
@@ -870,7 +1506,11 @@ func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 // attribute are defined by the W3C XML Schema double datatype.
 //
 // TopLeftCell: Location of the top left visible cell in the bottom right pane
-// (when in Left-To-Right mode).
+// (when in Left-To-Right mode). If the panes are frozen and this value is
+// empty, the cell next to the frozen rows and columns will be used.
+//
+// If the ActivePane is empty, the bottom right, bottom left, top right or top
+// left pane will be used by given split positions of the panes.
 //
 // SQRef (Sequence of References): Range of the selection. Can be non-contiguous
 // set of ranges.
@@ -958,9 +1598,8 @@ func (ws *xlsxWorksheet) getPanes() Panes {
 		return panes
 	}
 	panes.ActivePane = sw.Pane.ActivePane
-	if sw.Pane.State == "frozen" {
-		panes.Freeze = true
-	}
+	panes.Freeze = sw.Pane.State == "frozen" || sw.Pane.State == "frozenSplit"
+	panes.Split = !panes.Freeze && (sw.Pane.XSplit > 0 || sw.Pane.YSplit > 0)
 	panes.TopLeftCell = sw.Pane.TopLeftCell
 	panes.XSplit = int(sw.Pane.XSplit)
 	panes.YSplit = int(sw.Pane.YSplit)
@@ -971,7 +1610,7 @@ func (ws *xlsxWorksheet) getPanes() Panes {
 // views by given worksheet name.
 func (f *File) GetPanes(sheet string) (Panes, error) {
 	var panes Panes
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		return panes, err
 	}
@@ -987,7 +1626,7 @@ func (f *File) GetSheetVisible(sheet string) (bool, error) {
 	if err := checkSheetName(sheet); err != nil {
 		return visible, err
 	}
-	wb, _ := f.workbookReader()
+	wb, _ := f.workbookViewer()
 	for k, v := range wb.Sheets.Sheet {
 		if strings.EqualFold(v.Name, sheet) {
 			if wb.Sheets.Sheet[k].State == "" || wb.Sheets.Sheet[k].State == "visible" {
@@ -999,10 +1638,13 @@ func (f *File) GetSheetVisible(sheet string) (bool, error) {
 }
 
 // SearchSheet provides a function to get cell reference by given worksheet name,
-// cell value, and regular expression. The function doesn't support searching
-// on the calculated result, formatted numbers and conditional lookup
-// currently. If it is a merged cell, it will return the cell reference of the
-// upper left cell of the merged range reference.
+// cell value, and search options. The function reads the worksheet by stream,
+// and doesn't support searching on the calculated result, formatted numbers
+// and conditional lookup currently. If it is a merged cell, it will return the
+// cell reference of the upper left cell of the merged range reference. If the
+// search options are omitted, search the cell which the entire content is
+// equal to the value case-sensitively, and return the cell references row by
+// row.
 //
 // An example of search the cell reference of the value of "100" on Sheet1:
 //
@@ -1011,17 +1653,25 @@ func (f *File) GetSheetVisible(sheet string) (bool, error) {
 // An example of search the cell reference where the numerical value in the range
 // of "0-9" of Sheet1 is described:
 //
-//	result, err := f.SearchSheet("Sheet1", "[0-9]", true)
-func (f *File) SearchSheet(sheet, value string, reg ...bool) ([]string, error) {
-	var (
-		regSearch bool
-		result    []string
-	)
+//	result, err := f.SearchSheet("Sheet1", "[0-9]", excelize.SearchOptions{
+//	    Regex: true, MatchCase: true, ByRows: true,
+//	})
+//
+// An example of search the cell reference where the value contains "total"
+// case-insensitively on Sheet1, and return the cell references column by
+// column:
+//
+//	result, err := f.SearchSheet("Sheet1", "total", excelize.SearchOptions{
+//	    Regex: true,
+//	})
+func (f *File) SearchSheet(sheet, value string, opts ...SearchOptions) ([]string, error) {
+	var result []string
 	if err := checkSheetName(sheet); err != nil {
 		return result, err
 	}
-	for _, r := range reg {
-		regSearch = r
+	options := SearchOptions{MatchCase: true, ByRows: true}
+	for _, opt := range opts {
+		options = opt
 	}
 	name, ok := f.getSheetXMLPath(sheet)
 	if !ok {
@@ -1032,22 +1682,30 @@ func (f *File) SearchSheet(sheet, value string, reg ...bool) ([]string, error) {
 		output, _ := xml.Marshal(ws.(*xlsxWorksheet))
 		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
 	}
-	return f.searchSheet(name, value, regSearch)
+	return f.searchSheet(name, value, &options)
 }
 
 // searchSheet provides a function to get cell reference by given worksheet
-// name, cell value, and regular expression.
-func (f *File) searchSheet(name, value string, regSearch bool) (result []string, err error) {
+// name, cell value, and search options.
+func (f *File) searchSheet(name, value string, opts *SearchOptions) (result []string, err error) {
 	var (
 		cellName, inElement string
 		cellCol, row        int
 		sst                 *xlsxSST
+		regex               *regexp.Regexp
+		cells               [][]int
 	)
-
-	if sst, err = f.sharedStringsReader(); err != nil {
+	if opts.Regex {
+		if !opts.MatchCase {
+			value = "(?i)" + value
+		}
+		if regex, err = regexp.Compile(value); err != nil {
+			return
+		}
+	}
+	if sst, err = f.sharedStringsViewer(); err != nil {
 		return
 	}
-	regex := regexp.MustCompile(value)
 	decoder := f.xmlNewDecoder(bytes.NewReader(f.readBytes(name)))
 	for {
 		var token xml.Token
@@ -1070,32 +1728,46 @@ func (f *File) searchSheet(name, value string, regSearch bool) (result []string,
 			if inElement == "c" {
 				colCell := xlsxC{}
 				_ = decoder.DecodeElement(&colCell, &xmlElement)
-				val, _ := colCell.getValueFrom(f, sst, false)
-				if regSearch {
-					if !regex.MatchString(val) {
-						continue
-					}
-				} else {
-					if val != value {
-						continue
-					}
+				val, _ := colCell.getValueFrom(f, sst, &Options{})
+				if !opts.matchValue(regex, val, value) {
+					continue
 				}
 				cellCol, _, err = CellNameToCoordinates(colCell.R)
 				if err != nil {
 					return result, err
 				}
-				cellName, err = CoordinatesToCellName(cellCol, row)
-				if err != nil {
+				if _, err = CoordinatesToCellName(cellCol, row); err != nil {
 					return result, err
 				}
-				result = append(result, cellName)
+				cells = append(cells, []int{cellCol, row})
 			}
 		default:
 		}
 	}
+	if !opts.ByRows {
+		sort.SliceStable(cells, func(i, j int) bool {
+			return cells[i][0] < cells[j][0]
+		})
+	}
+	for _, cell := range cells {
+		cellName, _ = CoordinatesToCellName(cell[0], cell[1])
+		result = append(result, cellName)
+	}
 	return
 }
 
+// matchValue provides a function to check if the cell value matches the
+// search value with the given regular expression and search options.
+func (opts *SearchOptions) matchValue(regex *regexp.Regexp, val, value string) bool {
+	if opts.Regex {
+		return regex.MatchString(val)
+	}
+	if opts.MatchCase {
+		return val == value
+	}
+	return strings.EqualFold(val, value)
+}
+
 // attrValToInt provides a function to convert the local names to an integer
 // by given XML attributes and specified names.
 func attrValToInt(name string, attrs []xml.Attr) (val int, err error) {
@@ -1182,7 +1854,7 @@ func attrValToBool(name string, attrs []xml.Attr) (val bool, err error) {
 //	                        |
 //	 &F                     | Current workbook's file name
 //	                        |
-//	 &G                     | Drawing object as background (Not support currently)
+//	 &G                     | Drawing object as background (Use AddHeaderFooterImage)
 //	                        |
 //	 &H                     | Shadow text format
 //	                        |
@@ -1266,7 +1938,7 @@ func (f *File) SetHeaderFooter(sheet string, opts *HeaderFooterOptions) error {
 	v := reflect.ValueOf(*opts)
 	// Check 6 string type fields: OddHeader, OddFooter, EvenHeader, EvenFooter,
 	// FirstFooter, FirstHeader
-	for i := 4; i < v.NumField()-1; i++ {
+	for i := 4; i < v.NumField(); i++ {
 		if len(utf16.Encode([]rune(v.Field(i).String()))) > MaxFieldLength {
 			return newFieldLengthError(v.Type().Field(i).Name)
 		}
@@ -1286,15 +1958,37 @@ func (f *File) SetHeaderFooter(sheet string, opts *HeaderFooterOptions) error {
 	return err
 }
 
+// GetHeaderFooter provides a function to get worksheet header and footer by
+// given worksheet name.
+func (f *File) GetHeaderFooter(sheet string) (HeaderFooterOptions, error) {
+	var opts HeaderFooterOptions
+	ws, err := f.workSheetViewer(sheet)
+	if err != nil || ws.HeaderFooter == nil {
+		return opts, err
+	}
+	opts = HeaderFooterOptions{
+		AlignWithMargins: ws.HeaderFooter.AlignWithMargins,
+		DifferentFirst:   ws.HeaderFooter.DifferentFirst,
+		DifferentOddEven: ws.HeaderFooter.DifferentOddEven,
+		ScaleWithDoc:     ws.HeaderFooter.ScaleWithDoc,
+		OddHeader:        ws.HeaderFooter.OddHeader,
+		OddFooter:        ws.HeaderFooter.OddFooter,
+		EvenHeader:       ws.HeaderFooter.EvenHeader,
+		EvenFooter:       ws.HeaderFooter.EvenFooter,
+		FirstHeader:      ws.HeaderFooter.FirstHeader,
+		FirstFooter:      ws.HeaderFooter.FirstFooter,
+	}
+	return opts, err
+}
+
 // ProtectSheet provides a function to prevent other users from accidentally or
 // deliberately changing, moving, or deleting data in a worksheet. The
 // optional field AlgorithmName specified hash algorithm, support XOR, MD4,
-// MD5, SHA-1, SHA2-56, SHA-384, and SHA-512 currently, if no hash algorithm
-// specified, will be using the XOR algorithm as default. For example, protect
-// Sheet1 with protection settings:
+// MD5, SHA-1, SHA-256, SHA-384, and SHA-512 currently, if no hash algorithm
+// specified, will be using the SHA-512 algorithm with a random salt as
+// default. For example, protect Sheet1 with protection settings:
 //
 //	err := f.ProtectSheet("Sheet1", &excelize.SheetProtectionOptions{
-//	    AlgorithmName:       "SHA-512",
 //	    Password:            "password",
 //	    SelectLockedCells:   true,
 //	    SelectUnlockedCells: true,
@@ -1327,16 +2021,20 @@ func (f *File) ProtectSheet(sheet string, opts *SheetProtectionOptions) error {
 		Sort:                !opts.Sort,
 	}
 	if opts.Password != "" {
-		if opts.AlgorithmName == "" {
+		if opts.AlgorithmName == "XOR" {
 			ws.SheetProtection.Password = genSheetPasswd(opts.Password)
 			return err
 		}
-		hashValue, saltValue, err := genISOPasswdHash(opts.Password, opts.AlgorithmName, "", int(sheetProtectionSpinCount))
+		algorithmName := opts.AlgorithmName
+		if algorithmName == "" {
+			algorithmName = "SHA-512"
+		}
+		hashValue, saltValue, err := genISOPasswdHash(opts.Password, algorithmName, "", int(sheetProtectionSpinCount))
 		if err != nil {
 			return err
 		}
 		ws.SheetProtection.Password = ""
-		ws.SheetProtection.AlgorithmName = opts.AlgorithmName
+		ws.SheetProtection.AlgorithmName = algorithmName
 		ws.SheetProtection.SaltValue = saltValue
 		ws.SheetProtection.HashValue = hashValue
 		ws.SheetProtection.SpinCount = int(sheetProtectionSpinCount)
@@ -1396,7 +2094,28 @@ func checkSheetName(name string) error {
 	return nil
 }
 
-// SetPageLayout provides a function to sets worksheet page layout.
+// SetPageLayout provides a function to sets worksheet page layout. The print
+// scaling can be specified by the "AdjustTo" option, or fit the printed
+// worksheet to the given number of pages wide and tall by the "FitToWidth" and
+// "FitToHeight" options, which will override the "AdjustTo" option. For
+// example, set the A4 paper in landscape orientation, fit all columns on one
+// page with 600 DPI print quality:
+//
+//	var (
+//	    size        = 9
+//	    orientation = "landscape"
+//	    fitToWidth  = 1
+//	    fitToHeight = 0
+//	    dpi         = uint(600)
+//	)
+//	err := f.SetPageLayout("Sheet1", &excelize.PageLayoutOptions{
+//	    Size:          &size,
+//	    Orientation:   &orientation,
+//	    FitToWidth:    &fitToWidth,
+//	    FitToHeight:   &fitToHeight,
+//	    HorizontalDPI: &dpi,
+//	    VerticalDPI:   &dpi,
+//	})
 //
 // The following shows the paper size sorted by excelize index number:
 //
@@ -1556,6 +2275,9 @@ func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) {
 	if opts.AdjustTo != nil && 10 <= *opts.AdjustTo && *opts.AdjustTo <= 400 {
 		ws.newPageSetUp()
 		ws.PageSetUp.Scale = int(*opts.AdjustTo)
+		if ws.SheetPr != nil && ws.SheetPr.PageSetUpPr != nil {
+			ws.SheetPr.PageSetUpPr.FitToPage = false
+		}
 	}
 	if opts.FitToHeight != nil {
 		ws.newPageSetUp()
@@ -1565,10 +2287,29 @@ func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) {
 		ws.newPageSetUp()
 		ws.PageSetUp.FitToWidth = opts.FitToWidth
 	}
+	if opts.FitToHeight != nil || opts.FitToWidth != nil {
+		ws.prepareSheetPr()
+		if ws.SheetPr.PageSetUpPr == nil {
+			ws.SheetPr.PageSetUpPr = new(xlsxPageSetUpPr)
+		}
+		ws.SheetPr.PageSetUpPr.FitToPage = true
+	}
 	if opts.BlackAndWhite != nil {
 		ws.newPageSetUp()
 		ws.PageSetUp.BlackAndWhite = *opts.BlackAndWhite
 	}
+	if opts.Draft != nil {
+		ws.newPageSetUp()
+		ws.PageSetUp.Draft = *opts.Draft
+	}
+	if opts.HorizontalDPI != nil && *opts.HorizontalDPI > 0 {
+		ws.newPageSetUp()
+		ws.PageSetUp.HorizontalDPI = strconv.Itoa(int(*opts.HorizontalDPI))
+	}
+	if opts.VerticalDPI != nil && *opts.VerticalDPI > 0 {
+		ws.newPageSetUp()
+		ws.PageSetUp.VerticalDPI = strconv.Itoa(int(*opts.VerticalDPI))
+	}
 }
 
 // GetPageLayout provides a function to gets worksheet page layout.
@@ -1579,7 +2320,7 @@ func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 		FirstPageNumber: uintPtr(1),
 		AdjustTo:        uintPtr(100),
 	}
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		return opts, err
 	}
@@ -1603,13 +2344,161 @@ func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 			opts.FitToWidth = ws.PageSetUp.FitToWidth
 		}
 		opts.BlackAndWhite = boolPtr(ws.PageSetUp.BlackAndWhite)
+		opts.Draft = boolPtr(ws.PageSetUp.Draft)
+		if dpi, _ := strconv.Atoi(ws.PageSetUp.HorizontalDPI); dpi > 0 {
+			opts.HorizontalDPI = uintPtr(uint(dpi))
+		}
+		if dpi, _ := strconv.Atoi(ws.PageSetUp.VerticalDPI); dpi > 0 {
+			opts.VerticalDPI = uintPtr(uint(dpi))
+		}
 	}
 	return opts, err
 }
 
+// SetPrintArea provides a function to set the print area of the worksheet by
+// given worksheet name and range reference. Multiple areas can be separated
+// by commas, and each area will be printed on a separate page. The print area
+// will be removed if the range reference is empty. For example, set the print
+// area of Sheet1 to A1:D20 and F1:H20:
+//
+//	err := f.SetPrintArea("Sheet1", "A1:D20,F1:H20")
+func (f *File) SetPrintArea(sheet, rangeRef string) error {
+	sheetID, err := f.getExistSheetIndex(sheet)
+	if err != nil {
+		return err
+	}
+	var areas []string
+	for _, ref := range strings.Split(rangeRef, ",") {
+		if ref = strings.TrimSpace(ref); ref == "" {
+			continue
+		}
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		coordinates, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+		if ref, err = f.coordinatesToRangeRef(coordinates, true); err != nil {
+			return err
+		}
+		areas = append(areas, quoteSheetName(sheet)+"!"+ref)
+	}
+	return f.setSheetDefinedName(sheetID, builtInDefinedNames[0], strings.Join(areas, ","), false)
+}
+
+// SetPrintTitles provides a function to set the rows and columns to repeat on
+// each printed page of the worksheet by given worksheet name, the rows range
+// such as "1:2", and the columns range such as "A:B". The single row or column
+// can be specified as "1" or "A". The print titles will be removed if both of
+// the rows and columns are empty. For example, repeat the first row and the
+// column A on each printed page of Sheet1:
+//
+//	err := f.SetPrintTitles("Sheet1", "1:1", "A:A")
+func (f *File) SetPrintTitles(sheet, repeatRows, repeatCols string) error {
+	sheetID, err := f.getExistSheetIndex(sheet)
+	if err != nil {
+		return err
+	}
+	var titles []string
+	if repeatCols != "" {
+		cols := strings.Split(strings.ReplaceAll(repeatCols, "$", ""), ":")
+		if len(cols) > 2 {
+			return ErrParameterInvalid
+		}
+		first, err := ColumnNameToNumber(cols[0])
+		if err != nil {
+			return err
+		}
+		last, err := ColumnNameToNumber(cols[len(cols)-1])
+		if err != nil {
+			return err
+		}
+		if first > last {
+			first, last = last, first
+		}
+		firstCol, _ := ColumnNumberToName(first)
+		lastCol, _ := ColumnNumberToName(last)
+		titles = append(titles, fmt.Sprintf("%s!$%s:$%s", quoteSheetName(sheet), firstCol, lastCol))
+	}
+	if repeatRows != "" {
+		rows := strings.Split(strings.ReplaceAll(repeatRows, "$", ""), ":")
+		if len(rows) > 2 {
+			return ErrParameterInvalid
+		}
+		first, err := strconv.Atoi(rows[0])
+		if err != nil || first < 1 || first > TotalRows {
+			return newInvalidRowNumberError(first)
+		}
+		last, err := strconv.Atoi(rows[len(rows)-1])
+		if err != nil || last < 1 || last > TotalRows {
+			return newInvalidRowNumberError(last)
+		}
+		if first > last {
+			first, last = last, first
+		}
+		titles = append(titles, fmt.Sprintf("%s!$%d:$%d", quoteSheetName(sheet), first, last))
+	}
+	return f.setSheetDefinedName(sheetID, builtInDefinedNames[1], strings.Join(titles, ","), false)
+}
+
+// getExistSheetIndex provides a function to get the index of the worksheet by
+// given worksheet name, and returns an error if the worksheet doesn't exist.
+func (f *File) getExistSheetIndex(sheet string) (int, error) {
+	if _, err := f.workbookViewer(); err != nil {
+		return -1, err
+	}
+	sheetID, err := f.GetSheetIndex(sheet)
+	if err == nil && sheetID == -1 {
+		err = ErrSheetNotExist{sheet}
+	}
+	return sheetID, err
+}
+
+// setSheetDefinedName provides a function to set the built-in defined name
+// which scoped on the worksheet by given worksheet index, defined name, the
+// reference and if the defined name is hidden. The defined name will be
+// deleted if the reference is empty.
+func (f *File) setSheetDefinedName(sheetID int, name, refersTo string, hidden bool) error {
+	f.clearCalcCache()
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.DefinedNames == nil {
+		wb.DefinedNames = &xlsxDefinedNames{}
+	}
+	for idx := 0; idx < len(wb.DefinedNames.DefinedName); idx++ {
+		definedName := wb.DefinedNames.DefinedName[idx]
+		if definedName.Name != name || definedName.LocalSheetID == nil || *definedName.LocalSheetID != sheetID {
+			continue
+		}
+		if refersTo == "" {
+			wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName[:idx], wb.DefinedNames.DefinedName[idx+1:]...)
+			idx--
+			continue
+		}
+		wb.DefinedNames.DefinedName[idx].Data = refersTo
+		return err
+	}
+	if refersTo != "" {
+		wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, xlsxDefinedName{
+			Name:         name,
+			Hidden:       hidden,
+			LocalSheetID: intPtr(sheetID),
+			Data:         refersTo,
+		})
+	}
+	if len(wb.DefinedNames.DefinedName) == 0 {
+		wb.DefinedNames = nil
+	}
+	return err
+}
+
 // SetDefinedName provides a function to set the defined names of the workbook
-// or worksheet. If not specified scope, the default scope is workbook.
-// For example:
+// or worksheet. If not specified scope or the scope is "Workbook", the default
+// scope is workbook. For example:
 //
 //	err := f.SetDefinedName(&excelize.DefinedName{
 //	    Name:     "Amount",
@@ -1618,6 +2507,7 @@ func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 //	    Scope:    "Sheet2",
 //	})
 func (f *File) SetDefinedName(definedName *DefinedName) error {
+	f.clearCalcCache()
 	if definedName.Name == "" || definedName.RefersTo == "" {
 		return ErrParameterInvalid
 	}
@@ -1633,18 +2523,24 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 		Comment: definedName.Comment,
 		Data:    definedName.RefersTo,
 	}
-	if definedName.Scope != "" {
-		if sheetIndex, _ := f.GetSheetIndex(definedName.Scope); sheetIndex >= 0 {
-			d.LocalSheetID = &sheetIndex
+	scope := definedName.Scope
+	if scope == "Workbook" {
+		scope = ""
+	}
+	if scope != "" {
+		sheetIndex, _ := f.GetSheetIndex(scope)
+		if sheetIndex < 0 {
+			return ErrSheetNotExist{scope}
 		}
+		d.LocalSheetID = &sheetIndex
 	}
 	if wb.DefinedNames != nil {
 		for _, dn := range wb.DefinedNames.DefinedName {
-			var scope string
+			var dnScope string
 			if dn.LocalSheetID != nil {
-				scope = f.GetSheetName(*dn.LocalSheetID)
+				dnScope = f.GetSheetName(*dn.LocalSheetID)
 			}
-			if scope == definedName.Scope && dn.Name == definedName.Name {
+			if dnScope == scope && dn.Name == definedName.Name {
 				return ErrDefinedNameDuplicate
 			}
 		}
@@ -1657,6 +2553,80 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 	return nil
 }
 
+// SetDynamicDefinedName provides a function to set the defined name which
+// refers to a dynamic range of the worksheet by given defined name and dynamic
+// range options. The dynamic range starts from the given cell and expands
+// down to cover as many rows as the number of non-empty cells in the column of
+// the start cell, so the range grows as the data is appended, and the defined
+// name can be used as the source of the chart series. The range can be built
+// by the "OFFSET" function or the non-volatile "INDEX" function, the default
+// function is "OFFSET", and the default number of columns is 1. Note that the
+// data in the column of the start cell should be contiguous without blank
+// cells. The "RefersTo" field of the defined name will be ignored. For
+// example, define a name "Sales" scoped on Sheet1 for the data in column B
+// starts at B2, and use it as the values of the chart series:
+//
+//	err := f.SetDynamicDefinedName(&excelize.DefinedName{
+//	    Name:  "Sales",
+//	    Scope: "Sheet1",
+//	}, &excelize.DynamicRangeOptions{
+//	    Sheet:    "Sheet1",
+//	    Cell:     "B2",
+//	    Function: "INDEX",
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddChart("Sheet1", "D2", &excelize.Chart{
+//	    Type:   excelize.Line,
+//	    Series: []excelize.ChartSeries{{Name: "Sheet1!$B$1", Values: "Sheet1!Sales"}},
+//	})
+func (f *File) SetDynamicDefinedName(definedName *DefinedName, opts *DynamicRangeOptions) error {
+	if definedName == nil || opts == nil {
+		return ErrParameterInvalid
+	}
+	if _, ok := f.getSheetXMLPath(opts.Sheet); !ok {
+		return ErrSheetNotExist{opts.Sheet}
+	}
+	col, row, err := CellNameToCoordinates(opts.Cell)
+	if err != nil {
+		return err
+	}
+	cols, function := opts.Cols, strings.ToUpper(opts.Function)
+	if cols < 1 {
+		cols = 1
+	}
+	if col+cols-1 > MaxColumns {
+		return ErrColumnNumber
+	}
+	if function == "" {
+		function = "OFFSET"
+	}
+	if inStrSlice(supportedDynamicRangeFunctions, function, true) == -1 {
+		return newInvalidOptionalValue("Function", opts.Function, supportedDynamicRangeFunctions)
+	}
+	sheet := quoteSheetName(opts.Sheet)
+	startCell, _ := CoordinatesToCellName(col, row, true)
+	lastCell, _ := CoordinatesToCellName(col, TotalRows, true)
+	counts := fmt.Sprintf("COUNTA(%s!%s:%s)", sheet, startCell, lastCell)
+	refersTo := fmt.Sprintf("OFFSET(%s!%s,0,0,%s,%d)", sheet, startCell, counts, cols)
+	if function == "INDEX" {
+		lastCell, _ = CoordinatesToCellName(col+cols-1, TotalRows, true)
+		refersTo = fmt.Sprintf("%s!%s:INDEX(%s!%s:%s,%s", sheet, startCell, sheet, startCell, lastCell, counts)
+		if cols > 1 {
+			refersTo += "," + strconv.Itoa(cols)
+		}
+		refersTo += ")"
+	}
+	return f.SetDefinedName(&DefinedName{
+		Name:     definedName.Name,
+		Comment:  definedName.Comment,
+		RefersTo: refersTo,
+		Scope:    definedName.Scope,
+	})
+}
+
 // DeleteDefinedName provides a function to delete the defined names of the
 // workbook or worksheet. If not specified scope, the default scope is
 // workbook. For example:
@@ -1666,6 +2636,7 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 //	    Scope:    "Sheet2",
 //	})
 func (f *File) DeleteDefinedName(definedName *DefinedName) error {
+	f.clearCalcCache()
 	wb, err := f.workbookReader()
 	if err != nil {
 		return err
@@ -1693,7 +2664,7 @@ func (f *File) DeleteDefinedName(definedName *DefinedName) error {
 // or worksheet.
 func (f *File) GetDefinedName() []DefinedName {
 	var definedNames []DefinedName
-	wb, _ := f.workbookReader()
+	wb, _ := f.workbookViewer()
 	if wb.DefinedNames != nil {
 		for _, dn := range wb.DefinedNames.DefinedName {
 			definedName := DefinedName{
@@ -1885,8 +2856,25 @@ func (f *File) RemovePageBreak(sheet, cell string) error {
 }
 
 // relsReader provides a function to get the pointer to the structure
-// after deserialization of relationships parts.
+// after deserialization of relationships parts, and track the relationships
+// part was modified.
 func (f *File) relsReader(path string) (*xlsxRelationships, error) {
+	return f.getRels(path, false)
+}
+
+// relsViewer provides a function to get the pointer to the structure after
+// deserialization of relationships parts for read only. If the relationships
+// part was loaded by this function and never be got by the relsReader, the
+// original part will be copied verbatim on save instead of serialize the
+// structure.
+func (f *File) relsViewer(path string) (*xlsxRelationships, error) {
+	return f.getRels(path, true)
+}
+
+// getRels provides a function to get the pointer to the structure after
+// deserialization of relationships parts, and track if the relationships
+// part was modified.
+func (f *File) getRels(path string, readOnly bool) (*xlsxRelationships, error) {
 	rels, _ := f.Relationships.Load(path)
 	if rels == nil {
 		if _, ok := f.Pkg.Load(path); ok {
@@ -1896,56 +2884,57 @@ func (f *File) relsReader(path string) (*xlsxRelationships, error) {
 				return nil, err
 			}
 			f.Relationships.Store(path, &c)
+			f.trackPart(path, true, readOnly)
 		}
 	}
 	if rels, _ = f.Relationships.Load(path); rels != nil {
+		f.trackPart(path, false, readOnly)
 		return rels.(*xlsxRelationships), nil
 	}
 	return nil, nil
 }
 
-// fillSheetData ensures there are enough rows, and columns in the chosen
-// row to accept data. Missing rows are backfilled and given their row number
-// Uses the last populated row as a hint for the size of the next row to add
-func (ws *xlsxWorksheet) prepareSheetXML(col int, row int) {
-	rowCount := len(ws.SheetData.Row)
-	sizeHint := 0
-	var ht *float64
-	var customHeight bool
-	if ws.SheetFormatPr != nil && ws.SheetFormatPr.CustomHeight {
-		ht = float64Ptr(ws.SheetFormatPr.DefaultRowHeight)
-		customHeight = true
-	}
-	if rowCount > 0 {
-		sizeHint = len(ws.SheetData.Row[rowCount-1].C)
-	}
-	if rowCount < row {
-		// append missing rows
-		for rowIdx := rowCount; rowIdx < row; rowIdx++ {
-			ws.SheetData.Row = append(ws.SheetData.Row, xlsxRow{R: rowIdx + 1, CustomHeight: customHeight, Ht: ht, C: make([]xlsxC, 0, sizeHint)})
+// prepareSheetXML ensures there is the row, and the cell in the column of the
+// chosen row to accept data, and returns the index of the row element and the
+// cell element in the worksheet data. The missing row and cell are inserted at
+// the position found by binary search, so the rows and cells before it will
+// not be filled. The index of the cell element will be -1 if the column number
+// is 0.
+func (ws *xlsxWorksheet) prepareSheetXML(col int, row int) (int, int) {
+	rowIdx, ok := ws.rowIndex(row)
+	if !ok {
+		rowData := xlsxRow{R: row}
+		if ws.SheetFormatPr != nil && ws.SheetFormatPr.CustomHeight {
+			rowData.Ht = float64Ptr(ws.SheetFormatPr.DefaultRowHeight)
+			rowData.CustomHeight = true
 		}
+		ws.insertRowData(rowIdx, rowData)
+	}
+	if col < 1 {
+		return rowIdx, -1
 	}
-	rowData := &ws.SheetData.Row[row-1]
-	fillColumns(rowData, col, row)
+	return rowIdx, ws.SheetData.Row[rowIdx].prepareCell(col)
 }
 
-// fillColumns fill cells in the column of the row as contiguous.
-func fillColumns(rowData *xlsxRow, col, row int) {
-	cellCount := len(rowData.C)
-	if cellCount < col {
-		for colIdx := cellCount; colIdx < col; colIdx++ {
-			cellName, _ := CoordinatesToCellName(colIdx+1, row)
-			rowData.C = append(rowData.C, xlsxC{R: cellName})
-		}
-	}
+// insertRowData provides a function to insert the row element at the given
+// index of the worksheet data.
+func (ws *xlsxWorksheet) insertRowData(idx int, rowData xlsxRow) {
+	ws.SheetData.Row = append(ws.SheetData.Row, xlsxRow{})
+	copy(ws.SheetData.Row[idx+1:], ws.SheetData.Row[idx:])
+	ws.SheetData.Row[idx] = rowData
 }
 
-// makeContiguousColumns make columns in specific rows as contiguous.
-func (ws *xlsxWorksheet) makeContiguousColumns(fromRow, toRow, colCount int) {
-	for ; fromRow < toRow; fromRow++ {
-		rowData := &ws.SheetData.Row[fromRow-1]
-		fillColumns(rowData, colCount, fromRow)
+// prepareCell ensures there is the cell in the column of the row, and returns
+// the index of the cell element in the row.
+func (r *xlsxRow) prepareCell(col int) int {
+	colIdx, ok := r.cellIndex(col)
+	if !ok {
+		cellName, _ := CoordinatesToCellName(col, r.R)
+		r.C = append(r.C, xlsxC{})
+		copy(r.C[colIdx+1:], r.C[colIdx:])
+		r.C[colIdx] = xlsxC{R: cellName}
 	}
+	return colIdx
 }
 
 // SetSheetDimension provides the method to set or remove the used range of the
@@ -1987,7 +2976,7 @@ func (f *File) SetSheetDimension(sheet string, rangeRef string) error {
 // GetSheetDimension provides the method to get the used range of the worksheet.
 func (f *File) GetSheetDimension(sheet string) (string, error) {
 	var ref string
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		return ref, err
 	}
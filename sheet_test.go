@@ -1,6 +1,8 @@
 package excelize
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -94,7 +96,49 @@ func TestPanes(t *testing.T) {
 			},
 		},
 	))
-	assert.EqualError(t, f.SetPanes("Panes 4", nil), ErrParameterInvalid.Error())
+	panes, err = f.GetPanes("Panes 3")
+	assert.NoError(t, err)
+	assert.True(t, panes.Split)
+	assert.False(t, panes.Freeze)
+	assert.Equal(t, 3270, panes.XSplit)
+	// Test set frozen panes without top left cell and active pane
+	for _, c := range []struct {
+		panes                   Panes
+		topLeftCell, activePane string
+	}{
+		{Panes{Freeze: true, XSplit: 2}, "C1", "topRight"},
+		{Panes{Freeze: true, YSplit: 3}, "A4", "bottomLeft"},
+		{Panes{Freeze: true, XSplit: 1, YSplit: 1}, "B2", "bottomRight"},
+		{Panes{Split: true, XSplit: 1200, YSplit: 600, TopLeftCell: "C5"}, "C5", "bottomRight"},
+	} {
+		assert.NoError(t, f.SetPanes("Panes 4", &c.panes))
+		assert.Empty(t, c.panes.ActivePane)
+		panes, err = f.GetPanes("Panes 4")
+		assert.NoError(t, err)
+		assert.Equal(t, c.topLeftCell, panes.TopLeftCell)
+		assert.Equal(t, c.activePane, panes.ActivePane)
+		assert.Equal(t, c.panes.Freeze, panes.Freeze)
+		assert.Equal(t, c.panes.Split, panes.Split)
+	}
+	// Test set panes with invalid options
+	for _, opts := range []*Panes{
+		nil,
+		{Freeze: true, XSplit: -1},
+		{Freeze: true, XSplit: 1, ActivePane: "top"},
+		{Freeze: true, XSplit: 1, Selection: []Selection{{SQRef: "A1", Pane: "top"}}},
+	} {
+		assert.EqualError(t, f.SetPanes("Panes 4", opts), ErrParameterInvalid.Error())
+	}
+	assert.EqualError(t, f.SetPanes("Panes 4", &Panes{Freeze: true, XSplit: MaxColumns}), ErrColumnNumber.Error())
+	// Test get panes with frozen split state
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet4.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetViews.SheetView[0].Pane.State = "frozenSplit"
+	panes, err = f.GetPanes("Panes 4")
+	assert.NoError(t, err)
+	assert.True(t, panes.Freeze)
+	assert.False(t, panes.Split)
+	assert.NoError(t, f.SetPanes("Panes 4", &Panes{Freeze: true, YSplit: 9, TopLeftCell: "A34", ActivePane: "bottomLeft"}))
 	assert.EqualError(t, f.SetPanes("SheetN", nil), "sheet SheetN does not exist")
 	// Test set panes with invalid sheet name
 	assert.EqualError(t, f.SetPanes("Sheet:1", &Panes{Freeze: false, Split: false}), ErrSheetNameInvalid.Error())
@@ -102,7 +146,7 @@ func TestPanes(t *testing.T) {
 
 	// Test get panes with empty sheet views
 	f = NewFile()
-	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
 	assert.True(t, ok)
 	ws.(*xlsxWorksheet).SheetViews = &xlsxSheetViews{}
 	_, err = f.GetPanes("Sheet1")
@@ -160,11 +204,43 @@ func TestSearchSheet(t *testing.T) {
 	assert.EqualValues(t, []string{"A1"}, result)
 	// Test search the coordinates where the numerical value in the range of
 	// "0-9" of Sheet1 is described by regular expression:
-	result, err = f.SearchSheet("Sheet1", "[0-9]", true)
+	result, err = f.SearchSheet("Sheet1", "[0-9]", SearchOptions{Regex: true, MatchCase: true, ByRows: true})
 	assert.NoError(t, err)
 	assert.EqualValues(t, expected, result)
 	assert.NoError(t, f.Close())
 
+	// Test search worksheet with search options
+	f = NewFile()
+	for cell, value := range map[string]interface{}{
+		"A1": "Total", "B1": "total", "C1": "Subtotal", "A2": "TOTAL", "B2": 100, "A3": "total",
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	for _, c := range []struct {
+		value    string
+		opts     []SearchOptions
+		expected []string
+	}{
+		{"total", nil, []string{"B1", "A3"}},
+		{"total", []SearchOptions{{ByRows: true}}, []string{"A1", "B1", "A2", "A3"}},
+		{"total", []SearchOptions{{}}, []string{"A1", "A2", "A3", "B1"}},
+		{"total", []SearchOptions{{Regex: true, MatchCase: true, ByRows: true}}, []string{"B1", "C1", "A3"}},
+		{"^total$", []SearchOptions{{Regex: true}}, []string{"A1", "A2", "A3", "B1"}},
+		{"1[0-9]+", []SearchOptions{{Regex: true, MatchCase: true}}, []string{"B2"}},
+	} {
+		result, err = f.SearchSheet("Sheet1", c.value, c.opts...)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result, c.value)
+	}
+	// Test search worksheet with invalid regular expression
+	_, err = f.SearchSheet("Sheet1", "[", SearchOptions{Regex: true})
+	assert.EqualError(t, err, "error parsing regexp: missing closing ]: `[`")
+	// Test search worksheet with regular expression meta characters in the value
+	result, err = f.SearchSheet("Sheet1", "[")
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.NoError(t, f.Close())
+
 	// Test search worksheet data after set cell value
 	f = NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "A1", true))
@@ -210,11 +286,25 @@ func TestSetPageLayout(t *testing.T) {
 		FitToHeight:     intPtr(2),
 		FitToWidth:      intPtr(2),
 		BlackAndWhite:   boolPtr(true),
+		Draft:           boolPtr(true),
+		HorizontalDPI:   uintPtr(600),
+		VerticalDPI:     uintPtr(300),
 	}
 	assert.NoError(t, f.SetPageLayout("Sheet1", &expected))
 	opts, err := f.GetPageLayout("Sheet1")
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts)
+	props, err := f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, *props.FitToPage)
+	// Test set page layout with the print scaling without fit to page
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{AdjustTo: uintPtr(80)}))
+	props, err = f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, *props.FitToPage)
+	opts, err = f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(80), *opts.AdjustTo)
 	// Test set page layout on not exists worksheet
 	assert.EqualError(t, f.SetPageLayout("SheetN", nil), "sheet SheetN does not exist")
 	// Test set page layout with invalid sheet name
@@ -231,6 +321,72 @@ func TestGetPageLayout(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestSetPrintArea(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetPrintArea("Sheet1", "A1:D20"))
+	assert.NoError(t, f.SetPrintArea("Sheet 2", "D20:A1, F1:H20,J5"))
+	assert.Equal(t, []DefinedName{
+		{Name: "_xlnm.Print_Area", RefersTo: "Sheet1!$A$1:$D$20", Scope: "Sheet1"},
+		{Name: "_xlnm.Print_Area", RefersTo: "'Sheet 2'!$A$1:$D$20,'Sheet 2'!$F$1:$H$20,'Sheet 2'!$J$5:$J$5", Scope: "Sheet 2"},
+	}, f.GetDefinedName())
+	// Test update the print area
+	assert.NoError(t, f.SetPrintArea("Sheet1", "$B$2:$C$3"))
+	assert.Equal(t, "Sheet1!$B$2:$C$3", f.GetDefinedName()[0].RefersTo)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetPrintArea.xlsx")))
+	// Test remove the print area
+	assert.NoError(t, f.SetPrintArea("Sheet1", ""))
+	assert.NoError(t, f.SetPrintArea("Sheet 2", ""))
+	assert.Empty(t, f.GetDefinedName())
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Nil(t, wb.DefinedNames)
+	// Test set print area with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetPrintArea("Sheet1", "A:B1"))
+	assert.Equal(t, newCellNameToCoordinatesError("", newInvalidCellNameError("")), f.SetPrintArea("Sheet1", "A1:"))
+	// Test set print area on not exists worksheet
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.SetPrintArea("SheetN", "A1:B2"))
+	// Test set print area with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.SetPrintArea("Sheet:1", "A1:B2"))
+	assert.NoError(t, f.Close())
+	// Test set print area with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetPrintArea("Sheet1", "A1:B2"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetPrintTitles(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetPrintTitles("Sheet1", "$2:$1", "B:A"))
+	assert.NoError(t, f.SetPrintArea("Sheet1", "A1:D20"))
+	assert.Equal(t, []DefinedName{
+		{Name: "_xlnm.Print_Titles", RefersTo: "Sheet1!$A:$B,Sheet1!$1:$2", Scope: "Sheet1"},
+		{Name: "_xlnm.Print_Area", RefersTo: "Sheet1!$A$1:$D$20", Scope: "Sheet1"},
+	}, f.GetDefinedName())
+	assert.NoError(t, f.SetPrintTitles("Sheet1", "3", ""))
+	assert.Equal(t, "Sheet1!$3:$3", f.GetDefinedName()[0].RefersTo)
+	assert.NoError(t, f.SetPrintTitles("Sheet1", "", "$C"))
+	assert.Equal(t, "Sheet1!$C:$C", f.GetDefinedName()[0].RefersTo)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetPrintTitles.xlsx")))
+	// Test remove the print titles
+	assert.NoError(t, f.SetPrintTitles("Sheet1", "", ""))
+	assert.Equal(t, []DefinedName{{Name: "_xlnm.Print_Area", RefersTo: "Sheet1!$A$1:$D$20", Scope: "Sheet1"}}, f.GetDefinedName())
+	// Test set print titles with invalid rows and columns
+	assert.Equal(t, ErrParameterInvalid, f.SetPrintTitles("Sheet1", "1:2:3", ""))
+	assert.Equal(t, ErrParameterInvalid, f.SetPrintTitles("Sheet1", "", "A:B:C"))
+	assert.Equal(t, newInvalidRowNumberError(0), f.SetPrintTitles("Sheet1", "A", ""))
+	assert.Equal(t, newInvalidRowNumberError(0), f.SetPrintTitles("Sheet1", "1:A", ""))
+	assert.Equal(t, newInvalidRowNumberError(TotalRows+1), f.SetPrintTitles("Sheet1", "1:1048577", ""))
+	assert.Equal(t, newInvalidColumnNameError("1"), f.SetPrintTitles("Sheet1", "", "1"))
+	assert.Equal(t, newInvalidColumnNameError("1"), f.SetPrintTitles("Sheet1", "", "A:1"))
+	// Test set print titles on not exists worksheet
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.SetPrintTitles("SheetN", "1", ""))
+	assert.NoError(t, f.Close())
+}
+
 func TestSetHeaderFooter(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellStr("Sheet1", "A1", "Test SetHeaderFooter"))
@@ -242,6 +398,9 @@ func TestSetHeaderFooter(t *testing.T) {
 	assert.EqualError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
 		OddHeader: strings.Repeat("c", MaxFieldLength+1),
 	}), newFieldLengthError("OddHeader").Error())
+	assert.EqualError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
+		FirstFooter: strings.Repeat("c", MaxFieldLength+1),
+	}), newFieldLengthError("FirstFooter").Error())
 
 	assert.NoError(t, f.SetHeaderFooter("Sheet1", nil))
 	text := strings.Repeat("一", MaxFieldLength)
@@ -264,6 +423,38 @@ func TestSetHeaderFooter(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetHeaderFooter.xlsx")))
 }
 
+func TestGetHeaderFooter(t *testing.T) {
+	f := NewFile()
+	opts, err := f.GetHeaderFooter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, HeaderFooterOptions{}, opts)
+	expected := HeaderFooterOptions{
+		AlignWithMargins: boolPtr(false),
+		DifferentFirst:   true,
+		DifferentOddEven: true,
+		ScaleWithDoc:     boolPtr(true),
+		OddHeader:        `&L&"Arial,Bold"&12&KFF0000&A&C&P of &N&R&D &T`,
+		OddFooter:        "&C&F",
+		EvenHeader:       "&L&P",
+		EvenFooter:       "&L&Z&F",
+		FirstHeader:      "&C&G",
+		FirstFooter:      "&R&P",
+	}
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &expected))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetHeaderFooter.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestGetHeaderFooter.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetHeaderFooter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	// Test get header and footer on not exists worksheet
+	_, err = f.GetHeaderFooter("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestDefinedName(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetDefinedName(&DefinedName{
@@ -303,6 +494,18 @@ func TestDefinedName(t *testing.T) {
 	assert.EqualError(t, f.SetDefinedName(&DefinedName{
 		Name: "Amount",
 	}), ErrParameterInvalid.Error())
+	// Test set defined name with the workbook scope
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{
+		Name:     "Amount",
+		RefersTo: "Sheet1!$A$2:$D$5",
+		Scope:    "Workbook",
+	}), ErrDefinedNameDuplicate.Error())
+	// Test set defined name with not exist worksheet scope
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{
+		Name:     "Amount",
+		RefersTo: "Sheet1!$A$2:$D$5",
+		Scope:    "SheetN",
+	}), "sheet SheetN does not exist")
 	assert.Exactly(t, "Sheet1!$A$2:$D$5", f.GetDefinedName()[1].RefersTo)
 	assert.NoError(t, f.DeleteDefinedName(&DefinedName{
 		Name: "Amount",
@@ -323,6 +526,55 @@ func TestDefinedName(t *testing.T) {
 		"XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestSetDynamicDefinedName(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sales Data")
+	assert.NoError(t, err)
+	for r, row := range [][]interface{}{{"Month", "Amount", "Cost"}, {"Jan", 10, 1}, {"Feb", 20, 2}, {"Mar", 30, 3}} {
+		cell, err := CoordinatesToCellName(1, r+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sales Data", cell, &row))
+	}
+	assert.NoError(t, f.SetDynamicDefinedName(&DefinedName{Name: "Amount", Comment: "Dynamic range"}, &DynamicRangeOptions{Sheet: "Sales Data", Cell: "B2"}))
+	assert.NoError(t, f.SetDynamicDefinedName(&DefinedName{Name: "Amount", Scope: "Sales Data"}, &DynamicRangeOptions{Sheet: "Sales Data", Cell: "B2", Function: "index"}))
+	assert.NoError(t, f.SetDynamicDefinedName(&DefinedName{Name: "Data"}, &DynamicRangeOptions{Sheet: "Sales Data", Cell: "B2", Cols: 2, Function: "INDEX"}))
+	assert.NoError(t, f.SetDynamicDefinedName(&DefinedName{Name: "Months", Scope: "Sheet1"}, &DynamicRangeOptions{Sheet: "Sheet1", Cell: "A2", Cols: 2}))
+	assert.Equal(t, []DefinedName{
+		{Name: "Amount", Comment: "Dynamic range", RefersTo: "OFFSET('Sales Data'!$B$2,0,0,COUNTA('Sales Data'!$B$2:$B$1048576),1)", Scope: "Workbook"},
+		{Name: "Amount", RefersTo: "'Sales Data'!$B$2:INDEX('Sales Data'!$B$2:$B$1048576,COUNTA('Sales Data'!$B$2:$B$1048576))", Scope: "Sales Data"},
+		{Name: "Data", RefersTo: "'Sales Data'!$B$2:INDEX('Sales Data'!$B$2:$C$1048576,COUNTA('Sales Data'!$B$2:$B$1048576),2)", Scope: "Workbook"},
+		{Name: "Months", RefersTo: "OFFSET(Sheet1!$A$2,0,0,COUNTA(Sheet1!$A$2:$A$1048576),2)", Scope: "Sheet1"},
+	}, f.GetDefinedName())
+	assert.NoError(t, f.AddChart("Sales Data", "E2", &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "'Sales Data'!$B$1", Categories: "'Sales Data'!$A$2:$A$4", Values: "'Sales Data'!Amount"}},
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetDynamicDefinedName.xlsx")))
+	// Test set dynamic defined name with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.SetDynamicDefinedName(nil, &DynamicRangeOptions{}))
+	assert.Equal(t, ErrParameterInvalid, f.SetDynamicDefinedName(&DefinedName{Name: "Name"}, nil))
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.SetDynamicDefinedName(&DefinedName{Name: "Name"}, &DynamicRangeOptions{Sheet: "SheetN", Cell: "A1"}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetDynamicDefinedName(&DefinedName{Name: "Name"}, &DynamicRangeOptions{Sheet: "Sheet1", Cell: "A"}))
+	assert.Equal(t, ErrColumnNumber, f.SetDynamicDefinedName(&DefinedName{Name: "Name"}, &DynamicRangeOptions{Sheet: "Sheet1", Cell: "XFD1", Cols: 2}))
+	assert.EqualError(t, f.SetDynamicDefinedName(&DefinedName{Name: "Name"}, &DynamicRangeOptions{Sheet: "Sheet1", Cell: "A1", Function: "INDIRECT"}), "invalid Function value \"INDIRECT\", acceptable value should be one of OFFSET, INDEX")
+	assert.Equal(t, ErrDefinedNameDuplicate, f.SetDynamicDefinedName(&DefinedName{Name: "Data", Scope: "Workbook"}, &DynamicRangeOptions{Sheet: "Sheet1", Cell: "A1"}))
+	assert.NoError(t, f.Close())
+}
+
+func TestQuoteSheetName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Sheet1":     "Sheet1",
+		"Sales_2023": "Sales_2023",
+		"Data.1":     "Data.1",
+		"Sales Data": "'Sales Data'",
+		"2023":       "'2023'",
+		"Bob's":      "'Bob''s'",
+		"":           "''",
+	} {
+		assert.Equal(t, expected, quoteSheetName(name), name)
+	}
+}
+
 func TestGroupSheets(t *testing.T) {
 	f := NewFile()
 	sheets := []string{"Sheet2", "Sheet3"}
@@ -465,10 +717,24 @@ func TestWorksheetWriter(t *testing.T) {
 	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(worksheet, 1)))
 	f.checked = sync.Map{}
 	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 2))
-	f.workSheetWriter()
-	value, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
-	assert.True(t, ok)
-	assert.Equal(t, fmt.Sprintf(worksheet, 2), string(value.([]byte)))
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	written, err := f.workSheetWriter(zw)
+	assert.NoError(t, err)
+	assert.Contains(t, written, "xl/worksheets/sheet1.xml")
+	assert.NoError(t, zw.Close())
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	assert.Len(t, zr.File, 1)
+	value, err := readFile(zr.File[0])
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(worksheet, 2), string(value))
+	// Test encode worksheet with failed writer
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, errWrite, f.encodeWorksheet(&errorWriter{n: i}, "xl/worksheets/sheet1.xml", ws))
+	}
 }
 
 func TestGetWorkbookPath(t *testing.T) {
@@ -546,6 +812,91 @@ func TestGetSheetVisible(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestSetSheetVisibility(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sheet2", "Sheet3"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetSheetVisibility("Sheet2", SheetHidden))
+	assert.NoError(t, f.SetSheetVisibility("Sheet3", SheetVeryHidden))
+	for sheet, expected := range map[string]SheetVisibility{
+		"Sheet1": SheetVisible, "Sheet2": SheetHidden, "Sheet3": SheetVeryHidden,
+	} {
+		visibility, err := f.GetSheetVisibility(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, visibility)
+	}
+	assert.NoError(t, f.SetSheetVisibility("Sheet3", SheetVisible))
+	visibility, err := f.GetSheetVisibility("Sheet3")
+	assert.NoError(t, err)
+	assert.Equal(t, SheetVisible, visibility)
+	// Test set worksheet visibility with invalid visibility type
+	assert.Equal(t, ErrParameterInvalid, f.SetSheetVisibility("Sheet3", 3))
+	// Test get worksheet visibility with invalid sheet name
+	_, err = f.GetSheetVisibility("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get worksheet visibility with not exists worksheet
+	_, err = f.GetSheetVisibility("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get worksheet visibility with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetSheetVisibility("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestMoveSheet(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sheet2", "Sheet3", "Sheet4"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Name1", RefersTo: "Sheet1!$A$1", Scope: "Sheet1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Name3", RefersTo: "Sheet3!$A$1", Scope: "Sheet3"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Name4", RefersTo: "Sheet4!$A$1", Scope: "Sheet4"}))
+	f.SetActiveSheet(1)
+	assert.NoError(t, f.MoveSheet("Sheet3", 0))
+	assert.Equal(t, []string{"Sheet3", "Sheet1", "Sheet2", "Sheet4"}, f.GetSheetList())
+	assert.Equal(t, 2, f.GetActiveSheetIndex())
+	for _, dn := range f.GetDefinedName() {
+		assert.Equal(t, "Sheet"+dn.Name[4:], dn.Scope)
+	}
+	assert.NoError(t, f.MoveSheet("Sheet3", 3))
+	assert.Equal(t, []string{"Sheet1", "Sheet2", "Sheet4", "Sheet3"}, f.GetSheetList())
+	assert.Equal(t, 1, f.GetActiveSheetIndex())
+	for _, dn := range f.GetDefinedName() {
+		assert.Equal(t, "Sheet"+dn.Name[4:], dn.Scope)
+	}
+	assert.NoError(t, f.MoveSheet("Sheet3", 3))
+	assert.Equal(t, []string{"Sheet1", "Sheet2", "Sheet4", "Sheet3"}, f.GetSheetList())
+	// Test recalculate 3D reference after moving worksheet
+	for idx, sheet := range f.GetSheetList() {
+		assert.NoError(t, f.SetCellValue(sheet, "A1", idx+1))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "SUM(Sheet1:Sheet4!A1)"))
+	result, err := f.CalcCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "6", result)
+	assert.NoError(t, f.MoveSheet("Sheet3", 1))
+	assert.Equal(t, []string{"Sheet1", "Sheet3", "Sheet2", "Sheet4"}, f.GetSheetList())
+	result, err = f.CalcCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "10", result)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMoveSheet.xlsx")))
+	// Test move worksheet with invalid sheet name
+	assert.EqualError(t, f.MoveSheet("Sheet:1", 0), ErrSheetNameInvalid.Error())
+	// Test move not exists worksheet
+	assert.EqualError(t, f.MoveSheet("SheetN", 0), "sheet SheetN does not exist")
+	// Test move worksheet with invalid index
+	assert.Equal(t, ErrSheetIdx, f.MoveSheet("Sheet1", -1))
+	assert.Equal(t, ErrSheetIdx, f.MoveSheet("Sheet1", 4))
+	// Test move worksheet with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.MoveSheet("Sheet1", 0), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetSheetIndex(t *testing.T) {
 	f := NewFile()
 	// Test get sheet index with invalid sheet name
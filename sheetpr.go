@@ -13,7 +13,31 @@ package excelize
 
 import "reflect"
 
-// SetPageMargins provides a function to set worksheet page margins.
+// SetPageMargins provides a function to set worksheet page margins. The
+// margins are measured in inches and must not be negative, and the default
+// margins will be used for the unspecified margins if the worksheet has no
+// page margins settings. The properties that can be set are:
+//
+//	 Property     | Description
+//	--------------+------------------------------------------------------------
+//	 Bottom       | Bottom page margin, the default value is 0.75
+//	 Footer       | Footer page margin, the default value is 0.3
+//	 Header       | Header page margin, the default value is 0.3
+//	 Left         | Left page margin, the default value is 0.7
+//	 Right        | Right page margin, the default value is 0.7
+//	 Top          | Top page margin, the default value is 0.75
+//	 Horizontally | Center the data on page horizontally when printed
+//	 Vertically   | Center the data on page vertically when printed
+//
+// For example, set the top and bottom margins of Sheet1 to 1 inch, and center
+// the data on the printed page horizontally:
+//
+//	margin, centered := 1.0, true
+//	err := f.SetPageMargins("Sheet1", &excelize.PageLayoutMarginsOptions{
+//	    Top:          &margin,
+//	    Bottom:       &margin,
+//	    Horizontally: &centered,
+//	})
 func (f *File) SetPageMargins(sheet string, opts *PageLayoutMarginsOptions) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -24,7 +48,9 @@ func (f *File) SetPageMargins(sheet string, opts *PageLayoutMarginsOptions) erro
 	}
 	preparePageMargins := func(ws *xlsxWorksheet) {
 		if ws.PageMargins == nil {
-			ws.PageMargins = new(xlsxPageMargins)
+			ws.PageMargins = &xlsxPageMargins{
+				Left: 0.7, Right: 0.7, Top: 0.75, Bottom: 0.75, Header: 0.3, Footer: 0.3,
+			}
 		}
 	}
 	preparePrintOptions := func(ws *xlsxWorksheet) {
@@ -33,6 +59,11 @@ func (f *File) SetPageMargins(sheet string, opts *PageLayoutMarginsOptions) erro
 		}
 	}
 	s := reflect.ValueOf(opts).Elem()
+	for i := 0; i < 6; i++ {
+		if !s.Field(i).IsNil() && s.Field(i).Elem().Float() < 0 {
+			return ErrParameterInvalid
+		}
+	}
 	for i := 0; i < 6; i++ {
 		if !s.Field(i).IsNil() {
 			preparePageMargins(ws)
@@ -51,17 +82,20 @@ func (f *File) SetPageMargins(sheet string, opts *PageLayoutMarginsOptions) erro
 	return err
 }
 
-// GetPageMargins provides a function to get worksheet page margins.
+// GetPageMargins provides a function to get worksheet page margins, the
+// default value will be returned if the property was not set.
 func (f *File) GetPageMargins(sheet string) (PageLayoutMarginsOptions, error) {
 	opts := PageLayoutMarginsOptions{
-		Bottom: float64Ptr(0.75),
-		Footer: float64Ptr(0.3),
-		Header: float64Ptr(0.3),
-		Left:   float64Ptr(0.7),
-		Right:  float64Ptr(0.7),
-		Top:    float64Ptr(0.75),
+		Bottom:       float64Ptr(0.75),
+		Footer:       float64Ptr(0.3),
+		Header:       float64Ptr(0.3),
+		Left:         float64Ptr(0.7),
+		Right:        float64Ptr(0.7),
+		Top:          float64Ptr(0.75),
+		Horizontally: boolPtr(false),
+		Vertically:   boolPtr(false),
 	}
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		return opts, err
 	}
@@ -188,7 +222,7 @@ func (f *File) GetSheetProps(sheet string) (SheetPropsOptions, error) {
 		OutlineSummaryBelow:               boolPtr(true),
 		BaseColWidth:                      &baseColWidth,
 	}
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		return opts, err
 	}
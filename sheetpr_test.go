@@ -27,6 +27,27 @@ func TestSetPageMargins(t *testing.T) {
 	opts, err := f.GetPageMargins("Sheet1")
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts)
+	// Test set page margins without page margins settings
+	ws.(*xlsxWorksheet).PageMargins = nil
+	ws.(*xlsxWorksheet).PrintOptions = nil
+	assert.NoError(t, f.SetPageMargins("Sheet1", &PageLayoutMarginsOptions{Left: float64Ptr(0.5)}))
+	opts, err = f.GetPageMargins("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, PageLayoutMarginsOptions{
+		Bottom:       float64Ptr(0.75),
+		Footer:       float64Ptr(0.3),
+		Header:       float64Ptr(0.3),
+		Left:         float64Ptr(0.5),
+		Right:        float64Ptr(0.7),
+		Top:          float64Ptr(0.75),
+		Horizontally: boolPtr(false),
+		Vertically:   boolPtr(false),
+	}, opts)
+	// Test set page margins with negative margin
+	assert.Equal(t, ErrParameterInvalid, f.SetPageMargins("Sheet1", &PageLayoutMarginsOptions{Top: float64Ptr(-1)}))
+	opts, err = f.GetPageMargins("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.75, *opts.Top)
 	// Test set page margins on not exists worksheet
 	assert.EqualError(t, f.SetPageMargins("SheetN", nil), "sheet SheetN does not exist")
 	// Test set page margins with invalid sheet name
@@ -50,7 +71,7 @@ func TestSetSheetProps(t *testing.T) {
 	assert.True(t, ok)
 	ws.(*xlsxWorksheet).SheetPr = nil
 	ws.(*xlsxWorksheet).SheetFormatPr = nil
-	baseColWidth, enable := uint8(8), boolPtr(true)
+	baseColWidth, enable := uint(8), boolPtr(true)
 	expected := SheetPropsOptions{
 		CodeName:                          stringPtr("code"),
 		EnableFormatConditionsCalculation: enable,
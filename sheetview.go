@@ -57,6 +57,9 @@ func (view *xlsxSheetView) setSheetView(opts *ViewOptions) {
 	if opts.ShowZeros != nil {
 		view.ShowZeros = opts.ShowZeros
 	}
+	if opts.TabSelected != nil {
+		view.TabSelected = *opts.TabSelected
+	}
 	if opts.TopLeftCell != nil {
 		view.TopLeftCell = *opts.TopLeftCell
 	}
@@ -89,7 +92,7 @@ func (f *File) SetSheetView(sheet string, viewIndex int, opts *ViewOptions) erro
 func (f *File) GetSheetView(sheet string, viewIndex int) (ViewOptions, error) {
 	opts := ViewOptions{
 		DefaultGridColor:  boolPtr(true),
-		ShowFormulas:      boolPtr(true),
+		ShowFormulas:      boolPtr(false),
 		ShowGridLines:     boolPtr(true),
 		ShowRowColHeaders: boolPtr(true),
 		ShowRuler:         boolPtr(true),
@@ -118,6 +121,7 @@ func (f *File) GetSheetView(sheet string, viewIndex int) (ViewOptions, error) {
 	if view.ShowZeros != nil {
 		opts.ShowZeros = view.ShowZeros
 	}
+	opts.TabSelected = boolPtr(view.TabSelected)
 	opts.TopLeftCell = stringPtr(view.TopLeftCell)
 	if view.View != "" {
 		opts.View = stringPtr(view.View)
@@ -20,6 +20,7 @@ func TestSetView(t *testing.T) {
 		ShowRowColHeaders: boolPtr(false),
 		ShowRuler:         boolPtr(false),
 		ShowZeros:         boolPtr(false),
+		TabSelected:       boolPtr(false),
 		TopLeftCell:       stringPtr("A1"),
 		View:              stringPtr("normal"),
 		ZoomScale:         float64Ptr(120),
@@ -28,6 +29,12 @@ func TestSetView(t *testing.T) {
 	opts, err := f.GetSheetView("Sheet1", 0)
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts)
+	// Test set sheet view options with selected tab
+	assert.NoError(t, f.SetSheetView("Sheet1", 0, &ViewOptions{TabSelected: boolPtr(true), ZoomScale: float64Ptr(500)}))
+	opts, err = f.GetSheetView("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.True(t, *opts.TabSelected)
+	assert.Equal(t, float64(120), *opts.ZoomScale)
 	// Test set sheet view options with invalid view index
 	assert.EqualError(t, f.SetSheetView("Sheet1", 1, nil), "view index 1 out of range")
 	assert.EqualError(t, f.SetSheetView("Sheet1", -2, nil), "view index -2 out of range")
@@ -349,8 +349,9 @@ func (f *File) genSlicerName(name string) string {
 	return slicerName
 }
 
-// genSlicerNames generates a unique slicer cache name by giving the slicer name.
-func (f *File) genSlicerCacheName(name string) string {
+// genCacheName generates a unique slicer or timeline cache name by giving the
+// cache name prefix and the slicer or timeline name.
+func (f *File) genCacheName(prefix, name string) string {
 	var (
 		cnt             int
 		definedNames    []string
@@ -372,7 +373,7 @@ func (f *File) genSlicerCacheName(name string) string {
 		}
 		slicerCacheName += "_"
 	}
-	slicerCacheName = fmt.Sprintf("Slicer_%s", slicerCacheName)
+	slicerCacheName = prefix + slicerCacheName
 	for {
 		tmp := slicerCacheName
 		if cnt > 0 {
@@ -427,7 +428,7 @@ func (f *File) setSlicerCache(sheet string, colIdx int, opts *SlicerOptions, tab
 	if ok {
 		return slicerCacheName, nil
 	}
-	slicerCacheName = f.genSlicerCacheName(opts.Name)
+	slicerCacheName = f.genCacheName("Slicer_", opts.Name)
 	return slicerCacheName, f.addSlicerCache(slicerCacheName, colIdx, opts, table, pivotTable)
 }
 
@@ -571,8 +572,8 @@ func (f *File) addPivotCacheSlicer(opts *PivotTableOptions) (int, error) {
 	return pivotCacheID, err
 }
 
-// addDrawingSlicer adds a slicer shape and fallback shape by giving the
-// worksheet name, slicer name, and slicer options.
+// addDrawingSlicer adds a slicer or timeline shape and fallback shape by giving
+// the worksheet name, slicer name, namespace, and slicer options.
 func (f *File) addDrawingSlicer(sheet, slicerName string, ns xml.Attr, opts *SlicerOptions) error {
 	drawingID := f.countDrawings() + 1
 	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
@@ -600,6 +601,17 @@ func (f *File) addDrawingSlicer(sheet, slicerName string, ns xml.Attr, opts *Sli
 			},
 		},
 	}
+	paragraphs := []*aP{
+		{R: []*aR{{T: "This shape represents a table slicer. Table slicers are not supported in this version of Excel."}}},
+		{R: []*aR{{T: "If the shape was modified in an earlier version of Excel, or if the workbook was saved in Excel 2007 or earlier, the slicer can't be used."}}},
+	}
+	if ns.Value == NameSpaceDrawingMLTimeslicer.Value { // timeline
+		graphicFrame.Graphic.GraphicData = &xlsxGraphicData{
+			URI:  ns.Value,
+			Tsle: &xlsxTsle{XMLNS: ns.Value, Name: slicerName},
+		}
+		paragraphs = []*aP{{R: []*aR{{T: "Timeline: Works in Excel 2013 or higher. Do not move or resize."}}}}
+	}
 	graphic, _ := xml.Marshal(graphicFrame)
 	sp := xdrSp{
 		Macro: opts.Macro,
@@ -621,10 +633,7 @@ func (f *File) addDrawingSlicer(sheet, slicerName string, ns xml.Attr, opts *Sli
 		},
 		TxBody: &xdrTxBody{
 			BodyPr: &aBodyPr{VertOverflow: "clip", HorzOverflow: "clip"},
-			P: []*aP{
-				{R: &aR{T: "This shape represents a table slicer. Table slicers are not supported in this version of Excel."}},
-				{R: &aR{T: "If the shape was modified in an earlier version of Excel, or if the workbook was saved in Excel 2007 or earlier, the slicer can't be used."}},
-			},
+			P:      paragraphs,
 		},
 	}
 	shape, _ := xml.Marshal(sp)
@@ -639,6 +648,9 @@ func (f *File) addDrawingSlicer(sheet, slicerName string, ns xml.Attr, opts *Sli
 	if ns.Value == NameSpaceDrawingMLSlicerX15.Value { // table slicer
 		choice.XMLNSSle15 = ns.Value
 	}
+	if ns.Value == NameSpaceDrawingMLTimeslicer.Value { // timeline
+		choice.XMLNSTsle = ns.Value
+	}
 	fallback := xlsxFallback{Content: string(shape)}
 	choiceBytes, _ := xml.Marshal(choice)
 	shapeBytes, _ := xml.Marshal(fallback)
@@ -725,3 +737,356 @@ func (f *File) addWorkbookSlicerCache(slicerCacheID int, URI string) error {
 	wb.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
 	return err
 }
+
+// TimelineOptions represents the settings of the timeline.
+//
+// Name specifies the timeline name, should be an existing date field name of
+// the given pivot table, this setting is required.
+//
+// Cell specifies the left top cell coordinates the position for inserting the
+// timeline, this setting is required.
+//
+// TableSheet specifies the worksheet name of the pivot table, this setting is
+// required.
+//
+// TableName specifies the name of the pivot table, this setting is required.
+//
+// Caption specifies the caption of the timeline, this setting is optional.
+//
+// Level specifies the time level of the timeline, this setting is optional,
+// the possible values are "years", "quarters", "months" and "days", and the
+// default setting is "months".
+//
+// Macro used for set macro for the timeline, the workbook extension should be
+// XLSM or XLTM.
+//
+// Width specifies the width of the timeline, this setting is optional.
+//
+// Height specifies the height of the timeline, this setting is optional.
+//
+// DisplayHeader specifies if display header of the timeline, this setting is
+// optional, the default setting is display.
+//
+// DisplaySelectionLabel specifies if display the selection label of the
+// timeline, this setting is optional, the default setting is display.
+//
+// DisplayTimeLevel specifies if display the time level of the timeline, this
+// setting is optional, the default setting is display.
+//
+// DisplayScrollbar specifies if display the horizontal scrollbar of the
+// timeline, this setting is optional, the default setting is display.
+//
+// Format specifies the format of the timeline, this setting is optional.
+type TimelineOptions struct {
+	Name                  string
+	Cell                  string
+	TableSheet            string
+	TableName             string
+	Caption               string
+	Level                 string
+	Macro                 string
+	Width                 uint
+	Height                uint
+	DisplayHeader         *bool
+	DisplaySelectionLabel *bool
+	DisplayTimeLevel      *bool
+	DisplayScrollbar      *bool
+	Format                GraphicOptions
+}
+
+// AddTimeline function inserts a timeline by giving the worksheet name and
+// timeline settings. The timeline filters the pivot table by a date field in
+// years, quarters, months or days time level.
+//
+// For example, insert a timeline on the Sheet1!G2 with date field Date for the
+// pivot table named PivotTable1 in months time level:
+//
+//	err := f.AddTimeline("Sheet1", &excelize.TimelineOptions{
+//	    Name:       "Date",
+//	    Cell:       "G2",
+//	    TableSheet: "Sheet1",
+//	    TableName:  "PivotTable1",
+//	    Caption:    "Date",
+//	    Level:      "months",
+//	})
+func (f *File) AddTimeline(sheet string, opts *TimelineOptions) error {
+	opts, err := parseTimelineOptions(opts)
+	if err != nil {
+		return err
+	}
+	pivotTable, err := f.getTimelineSource(opts)
+	if err != nil {
+		return err
+	}
+	timelineID, err := f.addSheetTimeline(sheet)
+	if err != nil {
+		return err
+	}
+	timelineCacheName, err := f.setTimelineCache(opts, pivotTable)
+	if err != nil {
+		return err
+	}
+	timelineName := f.genSlicerName(opts.Name)
+	if err := f.addDrawingSlicer(sheet, timelineName, NameSpaceDrawingMLTimeslicer, &SlicerOptions{
+		Cell:   opts.Cell,
+		Macro:  opts.Macro,
+		Width:  opts.Width,
+		Height: opts.Height,
+		Format: opts.Format,
+	}); err != nil {
+		return err
+	}
+	level := inStrSlice(supportedTimelineLevels, opts.Level, true)
+	return f.addTimeline(timelineID, xlsxTimeline{
+		Name:                    timelineName,
+		Cache:                   timelineCacheName,
+		Caption:                 opts.Caption,
+		ShowHeader:              opts.DisplayHeader,
+		ShowSelectionLabel:      opts.DisplaySelectionLabel,
+		ShowTimeLevel:           opts.DisplayTimeLevel,
+		ShowHorizontalScrollbar: opts.DisplayScrollbar,
+		Level:                   level,
+		SelectionLevel:          level,
+	})
+}
+
+// parseTimelineOptions provides a function to parse the format settings of the
+// timeline with default value.
+func parseTimelineOptions(opts *TimelineOptions) (*TimelineOptions, error) {
+	if opts == nil {
+		return nil, ErrParameterRequired
+	}
+	if opts.Name == "" || opts.Cell == "" || opts.TableSheet == "" || opts.TableName == "" {
+		return nil, ErrParameterInvalid
+	}
+	if opts.Level == "" {
+		opts.Level = "months"
+	}
+	if inStrSlice(supportedTimelineLevels, opts.Level, true) == -1 {
+		return nil, ErrParameterInvalid
+	}
+	if opts.Width == 0 {
+		opts.Width = defaultTimelineWidth
+	}
+	if opts.Height == 0 {
+		opts.Height = defaultTimelineHeight
+	}
+	if opts.Format.PrintObject == nil {
+		opts.Format.PrintObject = boolPtr(true)
+	}
+	if opts.Format.Locked == nil {
+		opts.Format.Locked = boolPtr(false)
+	}
+	if opts.Format.ScaleX == 0 {
+		opts.Format.ScaleX = defaultDrawingScale
+	}
+	if opts.Format.ScaleY == 0 {
+		opts.Format.ScaleY = defaultDrawingScale
+	}
+	return opts, nil
+}
+
+// getTimelineSource returns the timeline data source pivot table settings by
+// given timeline options.
+func (f *File) getTimelineSource(opts *TimelineOptions) (*PivotTableOptions, error) {
+	pivotTables, err := f.GetPivotTables(opts.TableSheet)
+	if err != nil {
+		return nil, err
+	}
+	for _, tbl := range pivotTables {
+		if tbl.Name == opts.TableName {
+			order, _ := f.getTableFieldsOrder(&PivotTableOptions{DataRange: tbl.DataRange})
+			if inStrSlice(order, opts.Name, true) == -1 {
+				return nil, newInvalidTimelineNameError(opts.Name)
+			}
+			return &tbl, err
+		}
+	}
+	return nil, newNoExistTableError(opts.TableName)
+}
+
+// addSheetTimeline adds a new timeline and updates the namespace and
+// relationships parts of the worksheet by giving the worksheet name, and
+// returns the timeline ID.
+func (f *File) addSheetTimeline(sheet string) (int, error) {
+	var (
+		timelineID                     = f.countPartIndex("xl/timelines/timeline") + 1
+		ws, err                        = f.workSheetReader(sheet)
+		decodeExtLst                   = new(decodeExtLst)
+		timelineRefs                   = new(decodeTimelineRefs)
+		timelineRefsBytes, extLstBytes []byte
+	)
+	if err != nil {
+		return timelineID, err
+	}
+	if ws.ExtLst != nil {
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return timelineID, err
+		}
+		for _, ext := range decodeExtLst.Ext {
+			if ext.URI == ExtURITimelineRefs {
+				_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(timelineRefs)
+				for _, timelineRef := range timelineRefs.TimelineRef {
+					if timelineRef.RID != "" {
+						sheetRelationshipsTimelineXML := f.getSheetRelationshipsTargetByID(sheet, timelineRef.RID)
+						timelineID, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsTimelineXML, "../timelines/timeline"), ".xml"))
+						return timelineID, err
+					}
+				}
+			}
+		}
+	}
+	sheetRelationshipsTimelineXML := "../timelines/timeline" + strconv.Itoa(timelineID) + ".xml"
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	rID := f.addRels(sheetRels, SourceRelationshipTimeline, sheetRelationshipsTimelineXML, "")
+	f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX15)
+	timelineRefsBytes, _ = xml.Marshal(&xlsxX15TimelineRefs{
+		TimelineRef: []*xlsxX15TimelineRef{{RID: "rId" + strconv.Itoa(rID)}},
+	})
+	decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxExt{
+		xmlns: []xml.Attr{{Name: xml.Name{Local: "xmlns:" + NameSpaceSpreadSheetX15.Name.Local}, Value: NameSpaceSpreadSheetX15.Value}},
+		URI:   ExtURITimelineRefs, Content: string(timelineRefsBytes),
+	})
+	sort.Slice(decodeExtLst.Ext, func(i, j int) bool {
+		return inStrSlice(worksheetExtURIPriority, decodeExtLst.Ext[i].URI, false) <
+			inStrSlice(worksheetExtURIPriority, decodeExtLst.Ext[j].URI, false)
+	})
+	extLstBytes, err = xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return timelineID, err
+}
+
+// addTimeline adds a new timeline to the workbook by giving the timeline ID
+// and settings.
+func (f *File) addTimeline(timelineID int, timeline xlsxTimeline) error {
+	timelineXML := "xl/timelines/timeline" + strconv.Itoa(timelineID) + ".xml"
+	timelines, err := f.timelineReader(timelineXML)
+	if err != nil {
+		return err
+	}
+	if err := f.addContentTypePart(timelineID, "timeline"); err != nil {
+		return err
+	}
+	timelines.Timeline = append(timelines.Timeline, timeline)
+	output, err := xml.Marshal(timelines)
+	f.saveFileList(timelineXML, output)
+	return err
+}
+
+// setTimelineCache check if a timeline cache already exists or add a new
+// timeline cache by giving the timeline and pivot table options, and returns
+// the timeline cache name.
+func (f *File) setTimelineCache(opts *TimelineOptions, pivotTable *PivotTableOptions) (string, error) {
+	var ok bool
+	var timelineCacheName string
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/timelineCaches/timelineCache") {
+			timelineCache := &xlsxTimelineCacheDefinition{}
+			if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(v.([]byte)))).
+				Decode(timelineCache); err != nil && err != io.EOF {
+				return true
+			}
+			if timelineCache.PivotTables == nil || timelineCache.SourceName != opts.Name {
+				return true
+			}
+			for _, tbl := range timelineCache.PivotTables.PivotTable {
+				if tbl.Name == pivotTable.Name {
+					ok, timelineCacheName = true, timelineCache.Name
+					return false
+				}
+			}
+		}
+		return true
+	})
+	if ok {
+		return timelineCacheName, nil
+	}
+	timelineCacheName = f.genCacheName("NativeTimeline_", opts.Name)
+	return timelineCacheName, f.addTimelineCache(timelineCacheName, opts, pivotTable)
+}
+
+// addTimelineCache adds a new timeline cache by giving the timeline cache
+// name, timeline, and pivot table options.
+func (f *File) addTimelineCache(timelineCacheName string, opts *TimelineOptions, pivotTable *PivotTableOptions) error {
+	pivotCacheID, err := f.addPivotCacheSlicer(pivotTable)
+	if err != nil {
+		return err
+	}
+	timelineCacheID := f.countPartIndex("xl/timelineCaches/timelineCache") + 1
+	timelineCacheBytes, _ := xml.Marshal(xlsxTimelineCacheDefinition{
+		XMLNSXMC:   SourceRelationshipCompatibility.Value,
+		XMLNSX:     NameSpaceSpreadSheet.Value,
+		XMLNSXR10:  NameSpaceSpreadSheetXR10.Value,
+		Name:       timelineCacheName,
+		SourceName: opts.Name,
+		PivotTables: &xlsxSlicerCachePivotTables{
+			PivotTable: []xlsxSlicerCachePivotTable{
+				{TabID: f.getSheetID(opts.TableSheet), Name: pivotTable.Name},
+			},
+		},
+		State: &xlsxTimelineState{
+			MinimalRefreshVersion: 6,
+			LastRefreshVersion:    6,
+			PivotCacheID:          pivotCacheID,
+			FilterType:            "unknown",
+		},
+	})
+	f.saveFileList("xl/timelineCaches/timelineCache"+strconv.Itoa(timelineCacheID)+".xml", timelineCacheBytes)
+	if err := f.addContentTypePart(timelineCacheID, "timelineCache"); err != nil {
+		return err
+	}
+	if err := f.addWorkbookTimelineCache(timelineCacheID); err != nil {
+		return err
+	}
+	return f.SetDefinedName(&DefinedName{Name: timelineCacheName, RefersTo: formulaErrorNA})
+}
+
+// addWorkbookTimelineCache add the association ID of the timeline cache in
+// workbook.xml.
+func (f *File) addWorkbookTimelineCache(timelineCacheID int) error {
+	var (
+		wb                                                    *xlsxWorkbook
+		err                                                   error
+		appendMode                                            bool
+		decodeExtLst                                          = new(decodeExtLst)
+		decodeTimelineCacheRefs                               = new(decodeTimelineCacheRefs)
+		timelineCacheRefBytes, timelineCacheRefsBytes, extLst []byte
+	)
+	if wb, err = f.workbookReader(); err != nil {
+		return err
+	}
+	rID := f.addRels(f.getWorkbookRelsPath(), SourceRelationshipTimelineCache, fmt.Sprintf("/xl/timelineCaches/timelineCache%d.xml", timelineCacheID), "")
+	timelineCacheRefBytes, _ = xml.Marshal(xlsxX15TimelineCacheRef{RID: fmt.Sprintf("rId%d", rID)})
+	if wb.ExtLst != nil { // append mode ext
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + wb.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+		for idx, ext := range decodeExtLst.Ext {
+			if ext.URI == ExtURITimelineCacheRefs {
+				_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(decodeTimelineCacheRefs)
+				timelineCacheRefsBytes, _ = xml.Marshal(xlsxX15TimelineCacheRefs{
+					Content: decodeTimelineCacheRefs.Content + string(timelineCacheRefBytes),
+				})
+				decodeExtLst.Ext[idx].Content = string(timelineCacheRefsBytes)
+				appendMode = true
+			}
+		}
+	}
+	if !appendMode {
+		timelineCacheRefsBytes, _ = xml.Marshal(xlsxX15TimelineCacheRefs{Content: string(timelineCacheRefBytes)})
+		decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxExt{
+			xmlns: []xml.Attr{{Name: xml.Name{Local: "xmlns:" + NameSpaceSpreadSheetX15.Name.Local}, Value: NameSpaceSpreadSheetX15.Value}},
+			URI:   ExtURITimelineCacheRefs, Content: string(timelineCacheRefsBytes),
+		})
+	}
+	sort.Slice(decodeExtLst.Ext, func(i, j int) bool {
+		return inStrSlice(workbookExtURIPriority, decodeExtLst.Ext[i].URI, false) <
+			inStrSlice(workbookExtURIPriority, decodeExtLst.Ext[j].URI, false)
+	})
+	extLst, err = xml.Marshal(decodeExtLst)
+	wb.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLst), "<extLst>"), "</extLst>")}
+	return err
+}
@@ -5,7 +5,9 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -370,7 +372,7 @@ func TestAddWorkbookSlicerCache(t *testing.T) {
 func TestGenSlicerCacheName(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Slicer_Column_1", RefersTo: formulaErrorNA}))
-	assert.Equal(t, "Slicer_Column_11", f.genSlicerCacheName("Column 1"))
+	assert.Equal(t, "Slicer_Column_11", f.genCacheName("Slicer_", "Column 1"))
 	assert.NoError(t, f.Close())
 }
 
@@ -384,3 +386,183 @@ func TestAddPivotCacheSlicer(t *testing.T) {
 	})
 	assert.NoError(t, err)
 }
+
+func TestAddTimeline(t *testing.T) {
+	f := NewFile()
+	region := []string{"East", "West", "North", "South"}
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Date", "Region", "Sales"}))
+	for row := 2; row < 32; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row), time.Date(2023, time.Month(row%12+1), row%28+1, 0, 0, 0, 0, time.UTC)))
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("B%d", row), region[rand.Intn(4)]))
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("C%d", row), rand.Intn(5000)))
+	}
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:C31",
+		PivotTableRange: "Sheet1!E2:G20",
+		Name:            "PivotTable1",
+		Rows:            []PivotTableField{{Data: "Region"}},
+		Data:            []PivotTableField{{Data: "Sales", Subtotal: "Sum"}},
+		RowGrandTotals:  true,
+		ColGrandTotals:  true,
+	}))
+	assert.NoError(t, f.AddTimeline("Sheet1", &TimelineOptions{
+		Name:       "Date",
+		Cell:       "I2",
+		TableSheet: "Sheet1",
+		TableName:  "PivotTable1",
+		Caption:    "Date",
+	}))
+	// Test add a timeline with the same date field in another time level
+	assert.NoError(t, f.AddTimeline("Sheet1", &TimelineOptions{
+		Name:             "Date",
+		Cell:             "I12",
+		TableSheet:       "Sheet1",
+		TableName:        "PivotTable1",
+		Level:            "years",
+		DisplayScrollbar: boolPtr(false),
+	}))
+	timelines, err := f.timelineReader("xl/timelines/timeline1.xml")
+	assert.NoError(t, err)
+	assert.Len(t, timelines.Timeline, 2)
+	assert.Equal(t, "Date", timelines.Timeline[0].Name)
+	assert.Equal(t, "Date 1", timelines.Timeline[1].Name)
+	assert.Equal(t, "NativeTimeline_Date", timelines.Timeline[0].Cache)
+	assert.Equal(t, "NativeTimeline_Date", timelines.Timeline[1].Cache)
+	assert.Equal(t, 2, timelines.Timeline[0].Level)
+	assert.Equal(t, 0, timelines.Timeline[1].Level)
+	_, ok := f.Pkg.Load("xl/timelineCaches/timelineCache1.xml")
+	assert.True(t, ok)
+	_, ok = f.Pkg.Load("xl/timelineCaches/timelineCache2.xml")
+	assert.False(t, ok)
+	// Test add a timeline for another pivot table in a new worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:C31",
+		PivotTableRange: "Sheet2!A1:C20",
+		Name:            "PivotTable2",
+		Rows:            []PivotTableField{{Data: "Region"}},
+		Data:            []PivotTableField{{Data: "Sales", Subtotal: "Sum"}},
+	}))
+	assert.NoError(t, f.AddTimeline("Sheet2", &TimelineOptions{
+		Name:       "Date",
+		Cell:       "E2",
+		TableSheet: "Sheet2",
+		TableName:  "PivotTable2",
+		Level:      "days",
+	}))
+	_, ok = f.Pkg.Load("xl/timelines/timeline2.xml")
+	assert.True(t, ok)
+	_, ok = f.Pkg.Load("xl/timelineCaches/timelineCache2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTimeline.xlsx")))
+	// Test add a timeline with empty timeline options
+	assert.Equal(t, ErrParameterRequired, f.AddTimeline("Sheet1", nil))
+	// Test add a timeline with invalid timeline options
+	for _, opts := range []*TimelineOptions{
+		{Cell: "I2", TableSheet: "Sheet1", TableName: "PivotTable1"},
+		{Name: "Date", Cell: "I2", TableSheet: "Sheet1"},
+		{Name: "Date", TableSheet: "Sheet1", TableName: "PivotTable1"},
+		{Name: "Date", Cell: "I2", TableSheet: "Sheet1", TableName: "PivotTable1", Level: "weeks"},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AddTimeline("Sheet1", opts))
+	}
+	// Test add a timeline with not exist worksheet
+	assert.EqualError(t, f.AddTimeline("SheetN", &TimelineOptions{
+		Name:       "Date",
+		Cell:       "I2",
+		TableSheet: "Sheet1",
+		TableName:  "PivotTable1",
+	}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.AddTimeline("Sheet1", &TimelineOptions{
+		Name:       "Date",
+		Cell:       "I2",
+		TableSheet: "SheetN",
+		TableName:  "PivotTable1",
+	}), "sheet SheetN does not exist")
+	// Test add a timeline with not exist pivot table name
+	assert.Equal(t, newNoExistTableError("PivotTable3"), f.AddTimeline("Sheet1", &TimelineOptions{
+		Name:       "Date",
+		Cell:       "I2",
+		TableSheet: "Sheet1",
+		TableName:  "PivotTable3",
+	}))
+	// Test add a timeline with invalid timeline name
+	assert.Equal(t, newInvalidTimelineNameError("Time"), f.AddTimeline("Sheet1", &TimelineOptions{
+		Name:       "Time",
+		Cell:       "I2",
+		TableSheet: "Sheet1",
+		TableName:  "PivotTable1",
+	}))
+	// Test add a timeline with invalid cell reference
+	assert.EqualError(t, f.AddTimeline("Sheet1", &TimelineOptions{
+		Name:       "Date",
+		Cell:       "I",
+		TableSheet: "Sheet1",
+		TableName:  "PivotTable1",
+	}), newCellNameToCoordinatesError("I", newInvalidCellNameError("I")).Error())
+	// Test add a timeline with invalid worksheet extension list
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: "<>"}
+	assert.Error(t, f.AddTimeline("Sheet1", &TimelineOptions{
+		Name:       "Date",
+		Cell:       "I2",
+		TableSheet: "Sheet1",
+		TableName:  "PivotTable1",
+	}))
+	assert.NoError(t, f.Close())
+
+	// Test add a timeline with unsupported charset timeline
+	f, err = OpenFile(filepath.Join("test", "TestAddTimeline.xlsx"))
+	assert.NoError(t, err)
+	f.Pkg.Store("xl/timelines/timeline1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddTimeline("Sheet1", &TimelineOptions{
+		Name:       "Date",
+		Cell:       "I22",
+		TableSheet: "Sheet1",
+		TableName:  "PivotTable1",
+	}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddTimelineCache(t *testing.T) {
+	f := NewFile()
+	// Test add a timeline cache with unsupported charset pivot cache
+	pivotCacheXML := "xl/pivotCache/pivotCacheDefinition1.xml"
+	f.Pkg.Store(pivotCacheXML, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.addTimelineCache("NativeTimeline_Date", &TimelineOptions{},
+		&PivotTableOptions{pivotCacheXML: pivotCacheXML}), "XML syntax error on line 1: invalid UTF-8")
+	// Test add a timeline cache with unsupported charset content types
+	f.Pkg.Delete(pivotCacheXML)
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.addTimelineCache("NativeTimeline_Date", &TimelineOptions{},
+		&PivotTableOptions{pivotCacheXML: pivotCacheXML}), "XML syntax error on line 1: invalid UTF-8")
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.addTimeline(1, xlsxTimeline{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test set timeline cache with unsupported charset timeline cache
+	f = NewFile()
+	f.Pkg.Store("xl/timelineCaches/timelineCache1.xml", MacintoshCyrillicCharset)
+	_, err := f.setTimelineCache(&TimelineOptions{Name: "Date", TableSheet: "Sheet1"}, &PivotTableOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+}
+
+func TestAddWorkbookTimelineCache(t *testing.T) {
+	f := NewFile()
+	// Test add a workbook timeline cache with invalid workbook extension list
+	f.WorkBook.ExtLst = &xlsxExtLst{Ext: "<>"}
+	assert.Error(t, f.addWorkbookTimelineCache(1))
+	// Test add a workbook timeline cache with existing timeline cache references
+	f.WorkBook.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s" xmlns:x15="%s"><x15:timelineCacheRefs><x15:timelineCacheRef r:id="rId1"/></x15:timelineCacheRefs></ext>`, ExtURITimelineCacheRefs, NameSpaceSpreadSheetX15.Value)}
+	assert.NoError(t, f.addWorkbookTimelineCache(2))
+	assert.Equal(t, 2, strings.Count(f.WorkBook.ExtLst.Ext, "x15:timelineCacheRef "))
+	// Test add a workbook timeline cache with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.addWorkbookTimelineCache(1), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
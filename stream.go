@@ -16,6 +16,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"math/big"
 	"os"
 	"reflect"
 	"strconv"
@@ -35,7 +36,7 @@ type StreamWriter struct {
 	rows            int
 	mergeCellsCount int
 	mergeCells      strings.Builder
-	tableParts      string
+	tableParts      []int
 }
 
 // NewStreamWriter returns stream writer struct by given worksheet name used for
@@ -163,8 +164,8 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 // Note that the table must be at least two lines including the header. The
 // header cells must contain strings and must be unique.
 //
-// Currently, only one table is allowed for a StreamWriter. AddTable must be
-// called after the rows are written but before Flush.
+// AddTable must be called after the rows are written but before Flush, and
+// the tables should not overlap.
 //
 // See File.AddTable for details on the table format.
 func (sw *StreamWriter) AddTable(table *Table) error {
@@ -239,7 +240,7 @@ func (sw *StreamWriter) AddTable(table *Table) error {
 	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
 	rID := sw.file.addRels(sheetRels, SourceRelationshipTable, sheetRelationshipsTableXML, "")
 
-	sw.tableParts = fmt.Sprintf(`<tableParts count="1"><tablePart r:id="rId%d"></tablePart></tableParts>`, rID)
+	sw.tableParts = append(sw.tableParts, rID)
 
 	if err = sw.file.addContentTypePart(tableID, "table"); err != nil {
 		return err
@@ -249,6 +250,21 @@ func (sw *StreamWriter) AddTable(table *Table) error {
 	return err
 }
 
+// AutoFilter provides the method to add auto filter in a worksheet by given
+// range reference and settings for the StreamWriter. For example, apply an
+// auto filter to a cell range A1:D4 with the filter criteria for the column
+// B:
+//
+//	err := sw.AutoFilter("A1:D4", []excelize.AutoFilterOptions{
+//	    {Column: "B", Expression: "x != blanks"},
+//	})
+//
+// AutoFilter must be called before Flush. See File.AutoFilter for details on
+// the filter settings.
+func (sw *StreamWriter) AutoFilter(rangeRef string, opts []AutoFilterOptions) error {
+	return sw.file.AutoFilter(sw.Sheet, rangeRef, opts)
+}
+
 // Extract values from a row in the StreamWriter.
 func (sw *StreamWriter) getRowValues(hRow, hCol, vCol int) (res []string, err error) {
 	res = make([]string, vCol-hCol+1)
@@ -284,7 +300,7 @@ func (sw *StreamWriter) getRowValues(hRow, hCol, vCol int) (res []string, err er
 			if col < hCol || col > vCol {
 				continue
 			}
-			res[col-hCol], _ = c.getValueFrom(sw.file, nil, false)
+			res[col-hCol], _ = c.getValueFrom(sw.file, nil, &Options{})
 		}
 		return res, nil
 	}
@@ -400,6 +416,9 @@ func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpt
 	if err != nil {
 		return err
 	}
+	if err = sw.checkStyleID(options.StyleID); err != nil {
+		return err
+	}
 	_, _ = sw.rawData.WriteString(`<row r="`)
 	_, _ = sw.rawData.WriteString(strconv.Itoa(row))
 	_, _ = sw.rawData.WriteString(`"`)
@@ -423,6 +442,10 @@ func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpt
 			val = v.Value
 			setCellFormula(&c, v.Formula)
 		}
+		if err = sw.checkStyleID(c.S); err != nil {
+			_, _ = sw.rawData.WriteString(`</row>`)
+			return err
+		}
 		if err = sw.setCellValFunc(&c, val); err != nil {
 			_, _ = sw.rawData.WriteString(`</row>`)
 			return err
@@ -433,6 +456,24 @@ func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpt
 	return sw.rawData.Sync()
 }
 
+// checkStyleID provides a function to check if the style index exists in the
+// cell formats of the workbook.
+func (sw *StreamWriter) checkStyleID(styleID int) error {
+	if styleID == 0 {
+		return nil
+	}
+	s, err := sw.file.stylesReader()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		return newInvalidStyleID(styleID)
+	}
+	return nil
+}
+
 // SetColWidth provides a function to set the width of a single column or
 // multiple columns for the StreamWriter. Note that you must call
 // the 'SetColWidth' function before the 'SetRow' function. For example set
@@ -483,17 +524,21 @@ func (sw *StreamWriter) SetPanes(panes *Panes) error {
 
 // MergeCell provides a function to merge cells by a given range reference for
 // the StreamWriter. Don't create a merged cell that overlaps with another
-// existing merged cell.
+// existing merged cell. The range reference will be normalized, such as
+// correct C1:B3 to B1:C3.
 func (sw *StreamWriter) MergeCell(hCell, vCell string) error {
-	_, err := cellRefsToCoordinates(hCell, vCell)
+	coordinates, err := cellRefsToCoordinates(hCell, vCell)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	ref, err := sw.file.coordinatesToRangeRef(coordinates)
 	if err != nil {
 		return err
 	}
 	sw.mergeCellsCount++
 	_, _ = sw.mergeCells.WriteString(`<mergeCell ref="`)
-	_, _ = sw.mergeCells.WriteString(hCell)
-	_, _ = sw.mergeCells.WriteString(`:`)
-	_, _ = sw.mergeCells.WriteString(vCell)
+	_, _ = sw.mergeCells.WriteString(ref)
 	_, _ = sw.mergeCells.WriteString(`"/>`)
 	return nil
 }
@@ -507,14 +552,11 @@ func setCellFormula(c *xlsxC, formula string) {
 
 // setCellTime provides a function to set number of a cell with a time.
 func (sw *StreamWriter) setCellTime(c *xlsxC, val time.Time) error {
-	var date1904, isNum bool
-	wb, err := sw.file.workbookReader()
+	var isNum bool
+	date1904, err := sw.file.getDate1904()
 	if err != nil {
 		return err
 	}
-	if wb != nil && wb.WorkbookPr != nil {
-		date1904 = wb.WorkbookPr.Date1904
-	}
 	if isNum, err = c.setCellTime(val, date1904); err == nil && isNum && c.S == 0 {
 		style, _ := sw.file.NewStyle(&Style{NumFmt: 22})
 		c.S = style
@@ -547,6 +589,8 @@ func (sw *StreamWriter) setCellValFunc(c *xlsxC, val interface{}) error {
 	case []RichTextRun:
 		c.T, c.IS = "inlineStr", &xlsxSI{}
 		c.IS.R, err = setRichText(val)
+	case *big.Int, *big.Float, Decimal:
+		c.setCellBigNumber(val)
 	default:
 		c.setCellValue(fmt.Sprint(val))
 	}
@@ -656,6 +700,7 @@ func (sw *StreamWriter) writeSheetData() {
 
 // Flush ending the streaming writing process.
 func (sw *StreamWriter) Flush() error {
+	sw.file.clearCalcCache()
 	sw.writeSheetData()
 	_, _ = sw.rawData.WriteString(`</sheetData>`)
 	bulkAppendFields(&sw.rawData, sw.worksheet, 8, 15)
@@ -669,7 +714,17 @@ func (sw *StreamWriter) Flush() error {
 	}
 	_, _ = sw.rawData.WriteString(mergeCells.String())
 	bulkAppendFields(&sw.rawData, sw.worksheet, 17, 38)
-	_, _ = sw.rawData.WriteString(sw.tableParts)
+	if len(sw.tableParts) > 0 {
+		_, _ = sw.rawData.WriteString(`<tableParts count="`)
+		_, _ = sw.rawData.WriteString(strconv.Itoa(len(sw.tableParts)))
+		_, _ = sw.rawData.WriteString(`">`)
+		for _, rID := range sw.tableParts {
+			_, _ = sw.rawData.WriteString(`<tablePart r:id="rId`)
+			_, _ = sw.rawData.WriteString(strconv.Itoa(rID))
+			_, _ = sw.rawData.WriteString(`"></tablePart>`)
+		}
+		_, _ = sw.rawData.WriteString(`</tableParts>`)
+	}
 	bulkAppendFields(&sw.rawData, sw.worksheet, 40, 40)
 	_, _ = sw.rawData.WriteString(`</worksheet>`)
 	if err := sw.rawData.Flush(); err != nil {
@@ -680,6 +735,8 @@ func (sw *StreamWriter) Flush() error {
 	sw.file.Sheet.Delete(sheetPath)
 	sw.file.checked.Delete(sheetPath)
 	sw.file.Pkg.Delete(sheetPath)
+	sw.file.lazyParts.Delete(sheetPath)
+	sw.file.unmodified.Delete(sheetPath)
 
 	return nil
 }
@@ -83,8 +83,14 @@ func TestStreamWriter(t *testing.T) {
 		cell, _ := CoordinatesToCellName(1, rowID)
 		assert.NoError(t, streamWriter.SetRow(cell, row))
 	}
+	// Test rows data over the in-memory chunks size has been written to disk
+	assert.NotNil(t, streamWriter.rawData.tmp)
+	assert.Less(t, streamWriter.rawData.buf.Len(), StreamChunkSize)
 
 	assert.NoError(t, streamWriter.Flush())
+	// Test the worksheet has not been built in memory
+	_, ok := file.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.False(t, ok)
 	// Save spreadsheet by the given path
 	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamWriter.xlsx")))
 
@@ -124,6 +130,8 @@ func TestStreamWriter(t *testing.T) {
 
 	// Test read cell
 	file = NewFile()
+	styleID, err = file.NewStyle(&Style{Font: &Font{Color: "777777"}})
+	assert.NoError(t, err)
 	streamWriter, err = file.NewStreamWriter("Sheet1")
 	assert.NoError(t, err)
 	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{Cell{StyleID: styleID, Value: "Data"}}))
@@ -228,6 +236,46 @@ func TestStreamTable(t *testing.T) {
 	file.ContentTypes = nil
 	file.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
 	assert.EqualError(t, streamWriter.AddTable(&Table{Range: "A1:C2"}), "XML syntax error on line 1: invalid UTF-8")
+
+	// Test add multiple tables
+	file = NewFile()
+	streamWriter, err = file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"A", "B", nil, "C", "D"}))
+	assert.NoError(t, streamWriter.SetRow("A2", []interface{}{1, 2, nil, 3, 4}))
+	assert.NoError(t, streamWriter.AddTable(&Table{Range: "A1:B2"}))
+	assert.NoError(t, streamWriter.AddTable(&Table{Range: "D1:E2"}))
+	assert.NoError(t, streamWriter.Flush())
+	tables, err := file.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 2)
+	assert.Equal(t, "A1:B2", tables[0].Range)
+	assert.Equal(t, "D1:E2", tables[1].Range)
+	assert.NoError(t, file.Close())
+}
+
+func TestStreamAutoFilter(t *testing.T) {
+	file := NewFile()
+	defer func() {
+		assert.NoError(t, file.Close())
+	}()
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"A", "B", "C"}))
+	for r := 2; r <= 10; r++ {
+		assert.NoError(t, streamWriter.SetRow(fmt.Sprintf("A%d", r), []interface{}{r, r * 2, r * 3}))
+	}
+	// Test add auto filter with invalid range reference and options
+	assert.EqualError(t, streamWriter.AutoFilter("A1:B", nil), newCellNameToCoordinatesError("B", newInvalidCellNameError("B")).Error())
+	assert.EqualError(t, streamWriter.AutoFilter("A1:C10", []AutoFilterOptions{{Column: "D", Expression: "x > 10"}}), newInvalidAutoFilterColumnError("D").Error())
+	assert.NoError(t, streamWriter.AutoFilter("C10:A1", []AutoFilterOptions{{Column: "B", Expression: "x > 10"}}))
+	assert.NoError(t, streamWriter.Flush())
+	ws, err := file.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "$A$1:$C$10", ws.AutoFilter.Ref)
+	assert.Len(t, ws.AutoFilter.FilterColumn, 1)
+	assert.Equal(t, 1, ws.AutoFilter.FilterColumn[0].ColID)
+	assert.Equal(t, "'Sheet1'!$A$1:$C$10", file.GetDefinedName()[0].RefersTo)
 }
 
 func TestStreamMergeCells(t *testing.T) {
@@ -238,9 +286,16 @@ func TestStreamMergeCells(t *testing.T) {
 	streamWriter, err := file.NewStreamWriter("Sheet1")
 	assert.NoError(t, err)
 	assert.NoError(t, streamWriter.MergeCell("A1", "D1"))
+	// Test merge cells with the reversed range reference
+	assert.NoError(t, streamWriter.MergeCell("C3", "B2"))
 	// Test merge cells with illegal cell reference
 	assert.EqualError(t, streamWriter.MergeCell("A", "D1"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 	assert.NoError(t, streamWriter.Flush())
+	mergeCells, err := file.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 2)
+	assert.Equal(t, "B2", mergeCells[1].GetStartAxis())
+	assert.Equal(t, "C3", mergeCells[1].GetEndAxis())
 	// Save spreadsheet by the given path
 	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamMergeCells.xlsx")))
 }
@@ -271,6 +326,16 @@ func TestNewStreamWriter(t *testing.T) {
 	// Test new stream write with invalid sheet name
 	_, err = file.NewStreamWriter("Sheet:1")
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test the worksheet elements after the sheet data are in order
+	assert.NoError(t, file.SetCellValue("Sheet1", "A1", 1))
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{2}))
+	assert.NoError(t, streamWriter.Flush())
+	sheetXML := string(file.readBytes("xl/worksheets/sheet1.xml"))
+	assert.Equal(t, 1, strings.Count(sheetXML, "<sheetData>"))
+	assert.Contains(t, sheetXML, `<dimension ref="A1"></dimension><sheetViews>`)
+	assert.True(t, strings.HasSuffix(sheetXML, `</sheetData></worksheet>`))
 }
 
 func TestStreamMarshalAttrs(t *testing.T) {
@@ -309,7 +374,8 @@ func TestStreamSetRowNilValues(t *testing.T) {
 	streamWriter.Flush()
 	ws, err := file.workSheetReader("Sheet1")
 	assert.NoError(t, err)
-	assert.NotEqual(t, ws.SheetData.Row[0].C[0].XMLName.Local, "c")
+	assert.Len(t, ws.SheetData.Row[0].C, 1)
+	assert.Equal(t, "C1", ws.SheetData.Row[0].C[0].R)
 }
 
 func TestStreamSetRowWithStyle(t *testing.T) {
@@ -340,6 +406,17 @@ func TestStreamSetRowWithStyle(t *testing.T) {
 	for colIdx, expected := range []int{grayStyleID, zeroStyleID, zeroStyleID, blueStyleID, blueStyleID} {
 		assert.Equal(t, expected, ws.SheetData.Row[0].C[colIdx].S)
 	}
+
+	// Test set row with invalid style ID
+	streamWriter, err = file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.EqualError(t, streamWriter.SetRow("A1", []interface{}{"value"}, RowOpts{StyleID: -1}), newInvalidStyleID(-1).Error())
+	assert.EqualError(t, streamWriter.SetRow("A2", []interface{}{Cell{StyleID: 10, Value: "value"}}), newInvalidStyleID(10).Error())
+	assert.EqualError(t, streamWriter.SetRow("A3", []interface{}{&Cell{StyleID: -1}}), newInvalidStyleID(-1).Error())
+	// Test set row with unsupported charset style sheet
+	file.Styles = nil
+	file.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, streamWriter.SetRow("A4", []interface{}{Cell{StyleID: blueStyleID}}), "XML syntax error on line 1: invalid UTF-8")
 }
 
 func TestStreamSetCellValFunc(t *testing.T) {
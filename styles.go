@@ -18,6 +18,7 @@ import (
 	"io"
 	"math"
 	"reflect"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -33,12 +34,12 @@ var validType = map[string]string{
 	"unique":        "uniqueValues",
 	"top":           "top10",
 	"bottom":        "top10",
-	"text":          "text",              // Doesn't support currently
-	"time_period":   "timePeriod",        // Doesn't support currently
-	"blanks":        "containsBlanks",    // Doesn't support currently
-	"no_blanks":     "notContainsBlanks", // Doesn't support currently
-	"errors":        "containsErrors",    // Doesn't support currently
-	"no_errors":     "notContainsErrors", // Doesn't support currently
+	"text":          "text",
+	"time_period":   "timePeriod",
+	"blanks":        "containsBlanks",
+	"no_blanks":     "notContainsBlanks",
+	"errors":        "containsErrors",
+	"no_errors":     "notContainsErrors",
 	"2_color_scale": "2_color_scale",
 	"3_color_scale": "3_color_scale",
 	"data_bar":      "dataBar",
@@ -70,12 +71,15 @@ var criteriaType = map[string]string{
 	"ends with":                "endsWith",
 	"yesterday":                "yesterday",
 	"today":                    "today",
+	"tomorrow":                 "tomorrow",
 	"last 7 days":              "last7Days",
 	"last week":                "lastWeek",
 	"this week":                "thisWeek",
+	"next week":                "nextWeek",
 	"continue week":            "continueWeek",
 	"last month":               "lastMonth",
 	"this month":               "thisMonth",
+	"next month":               "nextMonth",
 	"continue month":           "continueMonth",
 }
 
@@ -100,13 +104,32 @@ var operatorType = map[string]string{
 	"lastWeek":           "last week",
 	"continueWeek":       "continue week",
 	"continueMonth":      "continue month",
+	"tomorrow":           "tomorrow",
+	"nextWeek":           "next week",
+	"nextMonth":          "next month",
 	"notBetween":         "not between",
 	"greaterThanOrEqual": "greater than or equal to",
 }
 
 // stylesReader provides a function to get the pointer to the structure after
-// deserialization of xl/styles.xml.
+// deserialization of xl/styles.xml, and track the styles part was modified.
 func (f *File) stylesReader() (*xlsxStyleSheet, error) {
+	return f.getStyles(false)
+}
+
+// stylesViewer provides a function to get the pointer to the structure after
+// deserialization of xl/styles.xml for read only. If the styles part was
+// loaded by this function and never be got by the stylesReader, the original
+// part will be copied verbatim on save instead of serialize the structure.
+func (f *File) stylesViewer() (*xlsxStyleSheet, error) {
+	return f.getStyles(true)
+}
+
+// getStyles provides a function to get the pointer to the structure after
+// deserialization of xl/styles.xml, and track if the styles part was
+// modified.
+func (f *File) getStyles(readOnly bool) (*xlsxStyleSheet, error) {
+	defer f.trackPart(defaultXMLPathStyles, f.Styles == nil, readOnly)
 	if f.Styles == nil {
 		f.Styles = new(xlsxStyleSheet)
 		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathStyles)))).
@@ -120,6 +143,9 @@ func (f *File) stylesReader() (*xlsxStyleSheet, error) {
 // styleSheetWriter provides a function to save xl/styles.xml after serialize
 // structure.
 func (f *File) styleSheetWriter() {
+	if _, ok := f.unmodified.Load(defaultXMLPathStyles); ok {
+		return
+	}
 	if f.Styles != nil {
 		output, _ := xml.Marshal(f.Styles)
 		f.saveFileList(defaultXMLPathStyles, f.replaceNameSpaceBytes(defaultXMLPathStyles, output))
@@ -129,6 +155,9 @@ func (f *File) styleSheetWriter() {
 // themeWriter provides a function to save xl/theme/theme1.xml after serialize
 // structure.
 func (f *File) themeWriter() {
+	if _, ok := f.unmodified.Load(defaultXMLPathTheme); ok {
+		return
+	}
 	newColor := func(c *decodeCTColor) xlsxCTColor {
 		return xlsxCTColor{
 			ScrgbClr:  c.ScrgbClr,
@@ -197,6 +226,9 @@ func (f *File) themeWriter() {
 // sharedStringsWriter provides a function to save xl/sharedStrings.xml after
 // serialize structure.
 func (f *File) sharedStringsWriter() {
+	if _, ok := f.unmodified.Load(defaultXMLPathSharedStrings); ok {
+		return
+	}
 	if f.SharedStrings != nil {
 		output, _ := xml.Marshal(f.SharedStrings)
 		f.saveFileList(defaultXMLPathSharedStrings, f.replaceNameSpaceBytes(defaultXMLPathSharedStrings, output))
@@ -218,6 +250,16 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 	if style.CustomNumFmt != nil && len(*style.CustomNumFmt) == 0 {
 		err = ErrCustomNumFmt
 	}
+	if len(style.Fill.Stops) > 0 {
+		if len(style.Fill.Stops) < 2 || style.Fill.Angle < 0 || style.Fill.Angle >= 360 {
+			return style, ErrParameterInvalid
+		}
+		for _, stop := range style.Fill.Stops {
+			if stop.Position < 0 || stop.Position > 1 {
+				return style, ErrParameterInvalid
+			}
+		}
+	}
 	return style, err
 }
 
@@ -281,6 +323,13 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //	 3-5   | Vertical        | 12-15 | From corner
 //	 6-8   | Diagonal Up     | 16    | From center
 //
+// The 'Fill.Stops' specifies the color stops of a linear gradient fill, each
+// stop position should be between 0 and 1, at least two stops are required.
+// The 'Fill.Angle' specifies the rotation angle in degrees of the linear
+// gradient, which should be greater than or equal to 0 and less than 360. The
+// 'Fill.Color' and 'Fill.Shading' will be ignored when 'Fill.Stops' is
+// specified.
+//
 // The following table shows the pattern styles used in 'Fill.Pattern' supported
 // by excelize index number:
 //
@@ -297,6 +346,29 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //	 8     | darkUp          | 18    | gray0625
 //	 9     | darkGrid        |       |
 //
+// The 'NamedStyle' specifies the name of the named cell style which the style
+// based on, the formatting of the named cell style will be inherited if the
+// number format, font, fill or border not specified in the style. The named
+// cell styles in the workbook could be referenced, and the following built-in
+// named cell styles will be created if not exist:
+//
+//	Normal             | Heading 1      | Input       | Good
+//	Comma              | Heading 2      | Output      | Bad
+//	Comma [0]          | Heading 3      | Calculation | Neutral
+//	Currency           | Heading 4      | Check Cell  | Accent1-6
+//	Currency [0]       | Title          | Linked Cell | 20% - Accent1-6
+//	Percent            | Total          | Note        | 40% - Accent1-6
+//	Hyperlink          | Warning Text   |             | 60% - Accent1-6
+//	Followed Hyperlink | Explanatory Text
+//
+// For example, create a style based on the built-in "Good" cell style with
+// bold font:
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    NamedStyle: "Good",
+//	    Font:       &excelize.Font{Bold: true, Color: "006100"},
+//	})
+//
 // The 'Alignment.Indent' is an integer value, where an increment of 1
 // represents 3 spaces. Indicates the number of spaces (of the normal style
 // font) of indentation for text in a cell. The number of spaces to indent is
@@ -359,6 +431,12 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //	 single
 //	 double
 //
+// The 'Font.VertAlign' specifies the vertical alignment of the font, the
+// possible values are "baseline", "superscript" and "subscript". The
+// 'Font.Charset' specifies the character set of the font, such as 128 for the
+// Shift JIS. The 'Font.Condense' and 'Font.Extend' specify whether to condense
+// or extend the font to make the text fit.
+//
 // Excel's built-in all languages formats are shown in the following table:
 //
 //	 Index | Format String
@@ -1052,6 +1130,12 @@ func (f *File) NewStyle(style *Style) (int, error) {
 	if cellXfsID, err = f.getStyleID(s, fs); err != nil || cellXfsID != -1 {
 		return cellXfsID, err
 	}
+	namedXfID := -1
+	if fs.NamedStyle != "" {
+		if namedXfID, err = f.newNamedStyle(s, fs.NamedStyle); err != nil {
+			return cellXfsID, err
+		}
+	}
 
 	numFmtID := newNumFmt(s, fs)
 
@@ -1086,11 +1170,89 @@ func (f *File) NewStyle(style *Style) (int, error) {
 		}
 	}
 
+	if namedXfID != -1 {
+		numFmtID, fontID, fillID, borderID = inheritNamedStyle(s.CellStyleXfs.Xf[namedXfID], fs, numFmtID, fontID, fillID, borderID)
+	}
 	applyAlignment, alignment := fs.Alignment != nil, newAlignment(fs)
 	applyProtection, protection := fs.Protection != nil, newProtection(fs)
-	return setCellXfs(s, fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection)
+	if cellXfsID, err = setCellXfs(s, fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection); err == nil && namedXfID != -1 {
+		s.CellXfs.Xf[cellXfsID].XfID = intPtr(namedXfID)
+	}
+	return cellXfsID, err
 }
 
+// builtInCellStyle defined the formatting of the built-in named cell style.
+type builtInCellStyle struct {
+	ID        int
+	NumFmt    int
+	Font      *Font
+	MajorFont bool
+	Fill      *xlsxColor
+	Border    *xlsxBorder
+}
+
+// builtInCellStyles defined the built-in named cell styles by the name.
+var builtInCellStyles = func() map[string]builtInCellStyle {
+	border := func(style string, color *xlsxColor) *xlsxBorder {
+		line := xlsxLine{Style: style, Color: color}
+		return &xlsxBorder{Left: line, Right: line, Top: line, Bottom: line}
+	}
+	heading := func(size float64, style string, tint float64) *builtInCellStyle {
+		heading := &builtInCellStyle{Font: &Font{Bold: true, Size: size, ColorTheme: intPtr(3)}}
+		if style != "" {
+			heading.Border = &xlsxBorder{Bottom: xlsxLine{Style: style, Color: &xlsxColor{Theme: intPtr(4), Tint: tint}}}
+		}
+		return heading
+	}
+	styles := map[string]builtInCellStyle{
+		"Normal":             {ID: 0},
+		"Comma":              {ID: 3, NumFmt: 43},
+		"Currency":           {ID: 4, NumFmt: 44},
+		"Percent":            {ID: 5, NumFmt: 9},
+		"Comma [0]":          {ID: 6, NumFmt: 41},
+		"Currency [0]":       {ID: 7, NumFmt: 42},
+		"Hyperlink":          {ID: 8, Font: &Font{Underline: "single", ColorTheme: intPtr(10)}},
+		"Followed Hyperlink": {ID: 9, Font: &Font{Underline: "single", ColorTheme: intPtr(11)}},
+		"Note":               {ID: 10, Fill: &xlsxColor{RGB: "FFFFFFCC"}, Border: border("thin", &xlsxColor{RGB: "FFB2B2B2"})},
+		"Warning Text":       {ID: 11, Font: &Font{Color: "FF0000"}},
+		"Title":              {ID: 15, Font: &Font{Size: 18, ColorTheme: intPtr(3)}, MajorFont: true},
+		"Input":              {ID: 20, Font: &Font{Color: "3F3F76"}, Fill: &xlsxColor{RGB: "FFFFCC99"}, Border: border("thin", &xlsxColor{RGB: "FF7F7F7F"})},
+		"Output":             {ID: 21, Font: &Font{Bold: true, Color: "3F3F3F"}, Fill: &xlsxColor{RGB: "FFF2F2F2"}, Border: border("thin", &xlsxColor{RGB: "FF3F3F3F"})},
+		"Calculation":        {ID: 22, Font: &Font{Bold: true, Color: "FA7D00"}, Fill: &xlsxColor{RGB: "FFF2F2F2"}, Border: border("thin", &xlsxColor{RGB: "FF7F7F7F"})},
+		"Check Cell":         {ID: 23, Font: &Font{Bold: true, ColorTheme: intPtr(0)}, Fill: &xlsxColor{RGB: "FFA5A5A5"}, Border: border("double", &xlsxColor{RGB: "FF3F3F3F"})},
+		"Linked Cell":        {ID: 24, Font: &Font{Color: "FA7D00"}, Border: &xlsxBorder{Bottom: xlsxLine{Style: "double", Color: &xlsxColor{RGB: "FFFF8001"}}}},
+		"Total": {ID: 25, Font: &Font{Bold: true, ColorTheme: intPtr(1)}, Border: &xlsxBorder{
+			Top:    xlsxLine{Style: "thin", Color: &xlsxColor{Theme: intPtr(4)}},
+			Bottom: xlsxLine{Style: "double", Color: &xlsxColor{Theme: intPtr(4)}},
+		}},
+		"Good":             {ID: 26, Font: &Font{Color: "006100"}, Fill: &xlsxColor{RGB: "FFC6EFCE"}},
+		"Bad":              {ID: 27, Font: &Font{Color: "9C0006"}, Fill: &xlsxColor{RGB: "FFFFC7CE"}},
+		"Neutral":          {ID: 28, Font: &Font{Color: "9C5700"}, Fill: &xlsxColor{RGB: "FFFFEB9C"}},
+		"Explanatory Text": {ID: 53, Font: &Font{Italic: true, Color: "7F7F7F"}},
+	}
+	for i, h := range []*builtInCellStyle{
+		heading(15, "thick", 0), heading(13, "thick", 0.499984740745262),
+		heading(11, "medium", 0.399975585192419), heading(11, "", 0),
+	} {
+		h.ID = 16 + i
+		styles["Heading "+strconv.Itoa(i+1)] = *h
+	}
+	for i := 1; i <= 6; i++ {
+		accent := "Accent" + strconv.Itoa(i)
+		styles[accent] = builtInCellStyle{ID: 25 + 4*i, Font: &Font{ColorTheme: intPtr(0)}, Fill: &xlsxColor{Theme: intPtr(3 + i)}}
+		for j, tint := range []float64{0.799981688894314, 0.599993896298105, 0.399975585192419} {
+			fontTheme := 1
+			if j == 2 {
+				fontTheme = 0
+			}
+			styles[strconv.Itoa(20*(j+1))+"% - "+accent] = builtInCellStyle{
+				ID: 26 + 4*i + j, Font: &Font{ColorTheme: intPtr(fontTheme)}, Fill: &xlsxColor{Theme: intPtr(3 + i), Tint: tint},
+			}
+		}
+	}
+	return styles
+}()
+
 var (
 	// styleBorders list all types of the cell border style.
 	styleBorders = []string{
@@ -1223,29 +1385,44 @@ var (
 		},
 	}
 	// drawContFmtFunc defines functions to create conditional formats.
-	drawContFmtFunc = map[string]func(p int, ct, GUID string, fmtCond *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule){
-		"cellIs":          drawCondFmtCellIs,
-		"top10":           drawCondFmtTop10,
-		"aboveAverage":    drawCondFmtAboveAverage,
-		"duplicateValues": drawCondFmtDuplicateUniqueValues,
-		"uniqueValues":    drawCondFmtDuplicateUniqueValues,
-		"2_color_scale":   drawCondFmtColorScale,
-		"3_color_scale":   drawCondFmtColorScale,
-		"dataBar":         drawCondFmtDataBar,
-		"expression":      drawCondFmtExp,
-		"iconSet":         drawCondFmtIconSet,
+	drawContFmtFunc = map[string]func(p int, ct, ref, GUID string, fmtCond *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule){
+		"cellIs":            drawCondFmtCellIs,
+		"text":              drawCondFmtText,
+		"timePeriod":        drawCondFmtTimePeriod,
+		"top10":             drawCondFmtTop10,
+		"aboveAverage":      drawCondFmtAboveAverage,
+		"duplicateValues":   drawCondFmtDuplicateUniqueValues,
+		"uniqueValues":      drawCondFmtDuplicateUniqueValues,
+		"containsBlanks":    drawCondFmtBlanksErrors,
+		"notContainsBlanks": drawCondFmtBlanksErrors,
+		"containsErrors":    drawCondFmtBlanksErrors,
+		"notContainsErrors": drawCondFmtBlanksErrors,
+		"2_color_scale":     drawCondFmtColorScale,
+		"3_color_scale":     drawCondFmtColorScale,
+		"dataBar":           drawCondFmtDataBar,
+		"expression":        drawCondFmtExp,
+		"iconSet":           drawCondFmtIconSet,
 	}
 	// extractContFmtFunc defines functions to get conditional formats.
 	extractContFmtFunc = map[string]func(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions{
-		"cellIs":          extractCondFmtCellIs,
-		"top10":           extractCondFmtTop10,
-		"aboveAverage":    extractCondFmtAboveAverage,
-		"duplicateValues": extractCondFmtDuplicateUniqueValues,
-		"uniqueValues":    extractCondFmtDuplicateUniqueValues,
-		"colorScale":      extractCondFmtColorScale,
-		"dataBar":         extractCondFmtDataBar,
-		"expression":      extractCondFmtExp,
-		"iconSet":         extractCondFmtIconSet,
+		"cellIs":            extractCondFmtCellIs,
+		"containsText":      extractCondFmtText,
+		"notContainsText":   extractCondFmtText,
+		"beginsWith":        extractCondFmtText,
+		"endsWith":          extractCondFmtText,
+		"timePeriod":        extractCondFmtTimePeriod,
+		"top10":             extractCondFmtTop10,
+		"aboveAverage":      extractCondFmtAboveAverage,
+		"duplicateValues":   extractCondFmtDuplicateUniqueValues,
+		"uniqueValues":      extractCondFmtDuplicateUniqueValues,
+		"containsBlanks":    extractCondFmtBlanksErrors,
+		"notContainsBlanks": extractCondFmtBlanksErrors,
+		"containsErrors":    extractCondFmtBlanksErrors,
+		"notContainsErrors": extractCondFmtBlanksErrors,
+		"colorScale":        extractCondFmtColorScale,
+		"dataBar":           extractCondFmtDataBar,
+		"expression":        extractCondFmtExp,
+		"iconSet":           extractCondFmtIconSet,
 	}
 )
 
@@ -1256,20 +1433,9 @@ func (f *File) getThemeColor(clr *xlsxColor) string {
 	if clr == nil || f.Theme == nil {
 		return RGB
 	}
-	if clrScheme := f.Theme.ThemeElements.ClrScheme; clr.Theme != nil {
-		if val, ok := map[int]*string{
-			0: &clrScheme.Lt1.SysClr.LastClr,
-			1: &clrScheme.Dk1.SysClr.LastClr,
-			2: clrScheme.Lt2.SrgbClr.Val,
-			3: clrScheme.Dk2.SrgbClr.Val,
-			4: clrScheme.Accent1.SrgbClr.Val,
-			5: clrScheme.Accent2.SrgbClr.Val,
-			6: clrScheme.Accent3.SrgbClr.Val,
-			7: clrScheme.Accent4.SrgbClr.Val,
-			8: clrScheme.Accent5.SrgbClr.Val,
-			9: clrScheme.Accent6.SrgbClr.Val,
-		}[*clr.Theme]; ok && val != nil {
-			return strings.TrimPrefix(ThemeColor(*val, clr.Tint), "FF")
+	if clr.Theme != nil && *clr.Theme >= 0 && *clr.Theme < themeColorCount {
+		if val := f.Theme.themeColors()[*clr.Theme].getRGB(); val != "" {
+			return strings.TrimPrefix(ThemeColor(val, clr.Tint), "FF")
 		}
 	}
 	if len(clr.RGB) == 6 {
@@ -1325,20 +1491,29 @@ func (f *File) extractFills(fl *xlsxFill, s *xlsxStyleSheet, style *Style) {
 		var fill Fill
 		if fl.GradientFill != nil {
 			fill.Type = "gradient"
+			matched := false
 			for shading, variants := range styleFillVariants {
 				if fl.GradientFill.Bottom == variants.Bottom &&
 					fl.GradientFill.Degree == variants.Degree &&
 					fl.GradientFill.Left == variants.Left &&
 					fl.GradientFill.Right == variants.Right &&
 					fl.GradientFill.Top == variants.Top &&
-					fl.GradientFill.Type == variants.Type {
-					fill.Shading = shading
+					fl.GradientFill.Type == variants.Type &&
+					sameGradientStops(fl.GradientFill.Stop, variants.Stop) {
+					fill.Shading, matched = shading, true
 					break
 				}
 			}
 			for _, stop := range fl.GradientFill.Stop {
+				if !matched {
+					fill.Stops = append(fill.Stops, GradientStop{Position: stop.Position, Color: f.getThemeColor(&stop.Color)})
+					continue
+				}
 				fill.Color = append(fill.Color, f.getThemeColor(&stop.Color))
 			}
+			if !matched {
+				fill.Angle = fl.GradientFill.Degree
+			}
 		}
 		if fl.PatternFill != nil {
 			fill.Type = "pattern"
@@ -1354,6 +1529,20 @@ func (f *File) extractFills(fl *xlsxFill, s *xlsxStyleSheet, style *Style) {
 	}
 }
 
+// sameGradientStops provides a function to check if the positions of the
+// given gradient fill stops are the same.
+func sameGradientStops(a, b []*xlsxGradientFillStop) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Position != b[i].Position {
+			return false
+		}
+	}
+	return true
+}
+
 // extractFont provides a function to extract font styles settings by given
 // font styles definition.
 func (f *File) extractFont(fnt *xlsxFont, s *xlsxStyleSheet, style *Style) {
@@ -1377,6 +1566,18 @@ func (f *File) extractFont(fnt *xlsxFont, s *xlsxStyleSheet, style *Style) {
 		if fnt.Strike != nil {
 			font.Strike = fnt.Strike.Value()
 		}
+		if fnt.VertAlign != nil {
+			font.VertAlign = fnt.VertAlign.Value()
+		}
+		if fnt.Condense != nil {
+			font.Condense = fnt.Condense.Value()
+		}
+		if fnt.Extend != nil {
+			font.Extend = fnt.Extend.Value()
+		}
+		if fnt.Charset != nil && fnt.Charset.Val != nil {
+			font.Charset = intPtr(*fnt.Charset.Val)
+		}
 		if fnt.Color != nil {
 			font.Color = strings.TrimPrefix(fnt.Color.RGB, "FF")
 			font.ColorIndexed = fnt.Color.Indexed
@@ -1398,6 +1599,9 @@ func (f *File) extractNumFmt(n *int, s *xlsxStyleSheet, style *Style) {
 		}
 		if s.NumFmts != nil {
 			for _, numFmt := range s.NumFmts.NumFmt {
+				if numFmt.NumFmtID != numFmtID {
+					continue
+				}
 				style.CustomNumFmt = &numFmt.FormatCode
 				if strings.Contains(numFmt.FormatCode, ";[Red]") {
 					style.NegRed = true
@@ -1437,7 +1641,7 @@ func (f *File) extractAlignment(a *xlsxAlignment, s *xlsxStyleSheet, style *Styl
 // given format definition.
 func (f *File) extractProtection(p *xlsxProtection, s *xlsxStyleSheet, style *Style) {
 	if p != nil {
-		style.Protection = &Protection{}
+		style.Protection = &Protection{Locked: true}
 		if p.Hidden != nil {
 			style.Protection.Hidden = *p.Hidden
 		}
@@ -1451,8 +1655,9 @@ func (f *File) extractProtection(p *xlsxProtection, s *xlsxStyleSheet, style *St
 func (f *File) GetStyle(idx int) (*Style, error) {
 	var style *Style
 	f.mu.Lock()
-	s, err := f.stylesReader()
+	s, err := f.stylesViewer()
 	if err != nil {
+		f.mu.Unlock()
 		return style, err
 	}
 	f.mu.Unlock()
@@ -1477,6 +1682,9 @@ func (f *File) GetStyle(idx int) (*Style, error) {
 		f.extractProtection(xf.Protection, s, style)
 	}
 	f.extractNumFmt(xf.NumFmtID, s, style)
+	if xf.XfID != nil && *xf.XfID != 0 {
+		style.NamedStyle = getCellStyleName(s, *xf.XfID)
+	}
 	return style, nil
 }
 
@@ -1498,7 +1706,13 @@ func (f *File) getStyleID(ss *xlsxStyleSheet, style *Style) (int, error) {
 	if style.CustomNumFmt != nil {
 		numFmtID = getCustomNumFmtID(ss, style)
 	}
+	if style.NamedStyle != "" {
+		return getNamedStyleXfID(ss, style, numFmtID, fontID, fillID, borderID), err
+	}
 	for xfID, xf := range ss.CellXfs.Xf {
+		if xf.XfID != nil && *xf.XfID != 0 {
+			continue
+		}
 		if getXfIDFuncs["numFmt"](numFmtID, xf, style) &&
 			getXfIDFuncs["font"](fontID, xf, style) &&
 			getXfIDFuncs["fill"](fillID, xf, style) &&
@@ -1512,10 +1726,142 @@ func (f *File) getStyleID(ss *xlsxStyleSheet, style *Style) (int, error) {
 	return styleID, err
 }
 
+// getNamedStyleXfID provides a function to get the cell style index which
+// based on the named cell style by given style and the number format, font,
+// fill and border ID of the style. If given style does not exist, will
+// return -1.
+func getNamedStyleXfID(ss *xlsxStyleSheet, style *Style, numFmtID, fontID, fillID, borderID int) int {
+	namedXfID := getCellStyleXfID(ss, style.NamedStyle)
+	if namedXfID == -1 {
+		return namedXfID
+	}
+	numFmtID, fontID, fillID, borderID = inheritNamedStyle(ss.CellStyleXfs.Xf[namedXfID], style, numFmtID, fontID, fillID, borderID)
+	equal := func(ID *int, val int) bool {
+		return ID != nil && *ID == val
+	}
+	for xfID, xf := range ss.CellXfs.Xf {
+		if equal(xf.XfID, namedXfID) && equal(xf.NumFmtID, numFmtID) &&
+			equal(xf.FontID, fontID) && equal(xf.FillID, fillID) &&
+			equal(xf.BorderID, borderID) &&
+			getXfIDFuncs["alignment"](0, xf, style) &&
+			getXfIDFuncs["protection"](0, xf, style) {
+			return xfID
+		}
+	}
+	return -1
+}
+
+// inheritNamedStyle provides a function to get the number format, font, fill
+// and border ID of the cell style, which inherit the formatting from the
+// given master formatting record of the named cell style if not specified in
+// the style.
+func inheritNamedStyle(xf xlsxXf, style *Style, numFmtID, fontID, fillID, borderID int) (int, int, int, int) {
+	if style.NumFmt == 0 && style.CustomNumFmt == nil && xf.NumFmtID != nil {
+		numFmtID = *xf.NumFmtID
+	}
+	if style.Font == nil && xf.FontID != nil {
+		fontID = *xf.FontID
+	}
+	if style.Fill.Type == "" && xf.FillID != nil {
+		fillID = *xf.FillID
+	}
+	if len(style.Border) == 0 && xf.BorderID != nil {
+		borderID = *xf.BorderID
+	}
+	return numFmtID, fontID, fillID, borderID
+}
+
+// getCellStyleXfID provides a function to get the index of the master
+// formatting record of the named cell style by given cell style name. If
+// given named cell style does not exist, will return -1.
+func getCellStyleXfID(ss *xlsxStyleSheet, name string) int {
+	if ss.CellStyles == nil || ss.CellStyleXfs == nil {
+		return -1
+	}
+	for _, cellStyle := range ss.CellStyles.CellStyle {
+		if cellStyle.Name == name && cellStyle.XfID >= 0 && cellStyle.XfID < len(ss.CellStyleXfs.Xf) {
+			return cellStyle.XfID
+		}
+	}
+	return -1
+}
+
+// getCellStyleName provides a function to get the name of the named cell
+// style by given index of the master formatting record.
+func getCellStyleName(ss *xlsxStyleSheet, xfID int) string {
+	if ss.CellStyles != nil {
+		for _, cellStyle := range ss.CellStyles.CellStyle {
+			if cellStyle.XfID == xfID {
+				return cellStyle.Name
+			}
+		}
+	}
+	return ""
+}
+
+// newNamedStyle provides a function to get the index of the master formatting
+// record of the named cell style by given cell style name, the built-in cell
+// style will be created if not exist.
+func (f *File) newNamedStyle(s *xlsxStyleSheet, name string) (int, error) {
+	if xfID := getCellStyleXfID(s, name); xfID != -1 {
+		return xfID, nil
+	}
+	builtIn, ok := builtInCellStyles[name]
+	if !ok {
+		return -1, ErrParameterInvalid
+	}
+	xf := xlsxXf{
+		NumFmtID: intPtr(builtIn.NumFmt), FontID: intPtr(0), FillID: intPtr(0), BorderID: intPtr(0),
+		ApplyNumberFormat: boolPtr(builtIn.NumFmt != 0), ApplyFont: boolPtr(builtIn.Font != nil),
+		ApplyFill: boolPtr(builtIn.Fill != nil), ApplyBorder: boolPtr(builtIn.Border != nil),
+		ApplyAlignment: boolPtr(false), ApplyProtection: boolPtr(false),
+	}
+	if builtIn.Font != nil {
+		fnt := *builtIn.Font
+		font, err := f.newFont(&Style{Font: &fnt})
+		if err != nil {
+			return -1, err
+		}
+		if major := f.Theme; builtIn.MajorFont && major != nil && major.ThemeElements.FontScheme.MajorFont.Latin != nil {
+			font.Name = &attrValString{Val: stringPtr(major.ThemeElements.FontScheme.MajorFont.Latin.Typeface)}
+			font.Scheme = &attrValString{Val: stringPtr("major")}
+		}
+		s.Fonts.Font = append(s.Fonts.Font, font)
+		s.Fonts.Count = len(s.Fonts.Font)
+		xf.FontID = intPtr(s.Fonts.Count - 1)
+	}
+	if builtIn.Fill != nil {
+		s.Fills.Fill = append(s.Fills.Fill, &xlsxFill{PatternFill: &xlsxPatternFill{PatternType: "solid", FgColor: builtIn.Fill}})
+		s.Fills.Count = len(s.Fills.Fill)
+		xf.FillID = intPtr(s.Fills.Count - 1)
+	}
+	if builtIn.Border != nil {
+		s.Borders.Border = append(s.Borders.Border, builtIn.Border)
+		s.Borders.Count = len(s.Borders.Border)
+		xf.BorderID = intPtr(s.Borders.Count - 1)
+	}
+	if s.CellStyleXfs == nil {
+		s.CellStyleXfs = &xlsxCellStyleXfs{}
+	}
+	if s.CellStyles == nil {
+		s.CellStyles = &xlsxCellStyles{}
+	}
+	s.CellStyleXfs.Xf = append(s.CellStyleXfs.Xf, xf)
+	s.CellStyleXfs.Count = len(s.CellStyleXfs.Xf)
+	s.CellStyles.CellStyle = append(s.CellStyles.CellStyle, &xlsxCellStyle{
+		Name: name, XfID: s.CellStyleXfs.Count - 1, BuiltInID: intPtr(builtIn.ID),
+	})
+	s.CellStyles.Count = len(s.CellStyles.CellStyle)
+	return s.CellStyleXfs.Count - 1, nil
+}
+
 // NewConditionalStyle provides a function to create style for conditional
 // format by given style format. The parameters are the same with the NewStyle
 // function.
 func (f *File) NewConditionalStyle(style *Style) (int, error) {
+	if style == nil {
+		return 0, ErrParameterRequired
+	}
 	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
@@ -1559,8 +1905,9 @@ func (f *File) NewConditionalStyle(style *Style) (int, error) {
 func (f *File) GetConditionalStyle(idx int) (*Style, error) {
 	var style *Style
 	f.mu.Lock()
-	s, err := f.stylesReader()
+	s, err := f.stylesViewer()
 	if err != nil {
+		f.mu.Unlock()
 		return style, err
 	}
 	f.mu.Unlock()
@@ -1651,7 +1998,7 @@ func (f *File) SetDefaultFont(fontName string) error {
 func (f *File) readDefaultFont() (*xlsxFont, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	s, err := f.stylesReader()
+	s, err := f.stylesViewer()
 	if err != nil {
 		return nil, err
 	}
@@ -1737,6 +2084,18 @@ func (f *File) newFont(style *Style) (*xlsxFont, error) {
 	if idx := inStrSlice(supportedUnderlineTypes, style.Font.Underline, true); idx != -1 {
 		fnt.U = &attrValString{Val: stringPtr(supportedUnderlineTypes[idx])}
 	}
+	if idx := inStrSlice(supportedVertAlignTypes, style.Font.VertAlign, true); idx != -1 {
+		fnt.VertAlign = &attrValString{Val: stringPtr(supportedVertAlignTypes[idx])}
+	}
+	if style.Font.Condense {
+		fnt.Condense = &attrValBool{Val: &style.Font.Condense}
+	}
+	if style.Font.Extend {
+		fnt.Extend = &attrValBool{Val: &style.Font.Extend}
+	}
+	if style.Font.Charset != nil {
+		fnt.Charset = &attrValInt{Val: intPtr(*style.Font.Charset)}
+	}
 	return &fnt, err
 }
 
@@ -1879,6 +2238,17 @@ func newFills(style *Style, fg bool) *xlsxFill {
 	var fill xlsxFill
 	switch style.Fill.Type {
 	case "gradient":
+		if len(style.Fill.Stops) > 0 {
+			gradient := xlsxGradientFill{Degree: style.Fill.Angle}
+			for _, stop := range style.Fill.Stops {
+				gradient.Stop = append(gradient.Stop, &xlsxGradientFillStop{
+					Position: stop.Position,
+					Color:    xlsxColor{RGB: getPaletteColor(stop.Color)},
+				})
+			}
+			fill.GradientFill = &gradient
+			break
+		}
 		if len(style.Fill.Color) != 2 || style.Fill.Shading < 0 || style.Fill.Shading > 16 {
 			break
 		}
@@ -2039,7 +2409,7 @@ func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, a
 // GetCellStyle provides a function to get cell style index by given worksheet
 // name and cell reference.
 func (f *File) GetCellStyle(sheet, cell string) (int, error) {
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		return 0, err
 	}
@@ -2047,10 +2417,119 @@ func (f *File) GetCellStyle(sheet, cell string) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	ws.prepareSheetXML(col, row)
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
-	return ws.prepareCellStyle(col, row, ws.SheetData.Row[row-1].C[col-1].S), err
+	var style int
+	if rowIdx := ws.searchRow(row); rowIdx != -1 {
+		if colIdx := ws.SheetData.Row[rowIdx].searchCell(col); colIdx != -1 {
+			style = ws.SheetData.Row[rowIdx].C[colIdx].S
+		}
+	}
+	return ws.prepareCellStyle(col, row, style), err
+}
+
+// GetCellStyleDetails provides a function to get the style definition of the
+// cell by given worksheet name and cell reference. For example, get the style
+// definition of Sheet1!A1:
+//
+//	style, err := f.GetCellStyleDetails("Sheet1", "A1")
+func (f *File) GetCellStyleDetails(sheet, cell string) (*Style, error) {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	return f.GetStyle(styleID)
+}
+
+// CopyCellStyle provides a function to copy the styles of the cells in the
+// source range of the worksheet to the destination range of the worksheet in
+// the given workbook, which could be the same workbook or another one. The
+// styles of the source range will be repeated if the destination range is
+// larger than the source range. When copying across workbooks, the fonts,
+// fills, borders and number formats will be added into the style sheet of the
+// destination workbook, and the theme colors will be converted to RGB colors.
+// For example, copy the styles of Sheet1!A1:B2 in the workbook f to the range
+// Sheet1!C1:F4 in the workbook dst:
+//
+//	err := f.CopyCellStyle("Sheet1", "A1:B2", dst, "Sheet1", "C1:F4")
+func (f *File) CopyCellStyle(srcSheet, srcRange string, dst *File, dstSheet, dstRange string) error {
+	if dst == nil {
+		return ErrParameterRequired
+	}
+	srcCoordinates, err := cellRangeToCoordinates(srcRange)
+	if err != nil {
+		return err
+	}
+	dstCoordinates, err := cellRangeToCoordinates(dstRange)
+	if err != nil {
+		return err
+	}
+	srcCols, srcRows := srcCoordinates[2]-srcCoordinates[0]+1, srcCoordinates[3]-srcCoordinates[1]+1
+	srcStyleIDs := make([][]int, srcRows)
+	for row := range srcStyleIDs {
+		srcStyleIDs[row] = make([]int, srcCols)
+		for col := range srcStyleIDs[row] {
+			cell, _ := CoordinatesToCellName(srcCoordinates[0]+col, srcCoordinates[1]+row)
+			if srcStyleIDs[row][col], err = f.GetCellStyle(srcSheet, cell); err != nil {
+				return err
+			}
+		}
+	}
+	styleIDs := map[int]int{}
+	for row := dstCoordinates[1]; row <= dstCoordinates[3]; row++ {
+		for col := dstCoordinates[0]; col <= dstCoordinates[2]; col++ {
+			srcStyleID := srcStyleIDs[(row-dstCoordinates[1])%srcRows][(col-dstCoordinates[0])%srcCols]
+			styleID, ok := styleIDs[srcStyleID]
+			if !ok {
+				if styleID, err = f.copyStyle(dst, srcStyleID); err != nil {
+					return err
+				}
+				styleIDs[srcStyleID] = styleID
+			}
+			cell, _ := CoordinatesToCellName(col, row)
+			if err = dst.SetCellStyle(dstSheet, cell, cell, styleID); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// cellRangeToCoordinates provides a function to convert the cell reference or
+// range reference to the sorted coordinates.
+func cellRangeToCoordinates(ref string) ([]int, error) {
+	cells := strings.Split(strings.ReplaceAll(ref, "$", ""), ":")
+	if len(cells) > 2 {
+		return nil, ErrParameterInvalid
+	}
+	coordinates, err := cellRefsToCoordinates(cells[0], cells[len(cells)-1])
+	if err != nil {
+		return coordinates, err
+	}
+	_ = sortCoordinates(coordinates)
+	return coordinates, err
+}
+
+// copyStyle provides a function to get the style index in the destination
+// workbook by given style index in the workbook, the style definition will be
+// added into the style sheet of the destination workbook if the workbooks are
+// different.
+func (f *File) copyStyle(dst *File, styleID int) (int, error) {
+	if dst == f || styleID == 0 {
+		return styleID, nil
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		return styleID, err
+	}
+	if _, ok := builtInCellStyles[style.NamedStyle]; !ok {
+		style.NamedStyle = ""
+	}
+	if fnt := style.Font; fnt != nil && (fnt.ColorTheme != nil || (fnt.Color == "" && fnt.ColorIndexed > 0)) {
+		fnt.Color = f.getThemeColor(&xlsxColor{Indexed: fnt.ColorIndexed, Theme: fnt.ColorTheme, Tint: fnt.ColorTint})
+		fnt.ColorIndexed, fnt.ColorTheme, fnt.ColorTint = 0, nil, 0
+	}
+	return dst.NewStyle(style)
 }
 
 // SetCellStyle provides a function to add style attribute for cells by given
@@ -2087,6 +2566,25 @@ func (f *File) GetCellStyle(sheet, cell string) (int, error) {
 //	}
 //	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
 //
+// Set linear gradient fill with 45 degrees angle and three color stops for cell
+// H9 on Sheet1:
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Fill: excelize.Fill{
+//	        Type:  "gradient",
+//	        Angle: 45,
+//	        Stops: []excelize.GradientStop{
+//	            {Position: 0, Color: "FFFFFF"},
+//	            {Position: 0.5, Color: "5B9BD5"},
+//	            {Position: 1, Color: "E0EBF5"},
+//	        },
+//	    },
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
+//
 // Set solid style pattern fill for cell H9 on Sheet1:
 //
 //	style, err := f.NewStyle(&excelize.Style{
@@ -2156,6 +2654,23 @@ func (f *File) GetCellStyle(sheet, cell string) (int, error) {
 //	    fmt.Println(err)
 //	}
 //	err = f.SetCellStyle("Sheet1", "H9", "H9", style)
+//
+// Unlock the range A1:B5 on Sheet1, these cells will stay editable after the
+// worksheet was protected:
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Protection: &excelize.Protection{Locked: false},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	if err = f.SetCellStyle("Sheet1", "A1", "B5", style); err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.ProtectSheet("Sheet1", &excelize.SheetProtectionOptions{
+//	    SelectLockedCells:   true,
+//	    SelectUnlockedCells: true,
+//	})
 func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 	hCol, hRow, err := CellNameToCoordinates(hCell)
 	if err != nil {
@@ -2176,18 +2691,13 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 		vRow, hRow = hRow, vRow
 	}
 
-	hColIdx := hCol - 1
-	hRowIdx := hRow - 1
-
-	vColIdx := vCol - 1
-	vRowIdx := vRow - 1
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		f.mu.Unlock()
 		return err
 	}
-	s, err := f.stylesReader()
+	s, err := f.stylesViewer()
 	if err != nil {
 		f.mu.Unlock()
 		return err
@@ -2197,16 +2707,15 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 
-	ws.prepareSheetXML(vCol, vRow)
-	ws.makeContiguousColumns(hRow, vRow, vCol)
-
 	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
 		return newInvalidStyleID(styleID)
 	}
 
-	for r := hRowIdx; r <= vRowIdx; r++ {
-		for k := hColIdx; k <= vColIdx; k++ {
-			ws.SheetData.Row[r].C[k].S = styleID
+	for r := hRow; r <= vRow; r++ {
+		rowIdx, _ := ws.prepareSheetXML(0, r)
+		rowData := &ws.SheetData.Row[rowIdx]
+		for k := hCol; k <= vCol; k++ {
+			rowData.C[rowData.prepareCell(k)].S = styleID
 		}
 	}
 	return err
@@ -2266,6 +2775,10 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //	               | BarBorderColor
 //	               | BarColor
 //	               | BarDirection
+//	               | BarAxisColor
+//	               | BarAxisPosition
+//	               | BarNegativeColor
+//	               | BarNegativeBorderColor
 //	               | BarOnly
 //	               | BarSolid
 //	 icon_set      | IconStyle
@@ -2341,6 +2854,26 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //	    },
 //	)
 //
+// type: Style - The Style parameter is used to create the format internally
+// instead of using the NewConditionalStyle function, the Format parameter will
+// be ignored if this parameter is specified:
+//
+//	err := f.SetConditionalFormat("Sheet1", "D1:D10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:     "cell",
+//	            Criteria: ">",
+//	            Style: &excelize.Style{
+//	                Font: &excelize.Font{Color: "9A0511"},
+//	                Fill: excelize.Fill{
+//	                    Type: "pattern", Color: []string{"FEC7CE"}, Pattern: 1,
+//	                },
+//	            },
+//	            Value: "6",
+//	        },
+//	    },
+//	)
+//
 // Note: In Excel, a conditional format is superimposed over the existing cell
 // format and not all cell format properties can be modified. Properties that
 // cannot be modified in a conditional format are font name, font size,
@@ -2446,6 +2979,39 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //	    },
 //	)
 //
+// type: text - The text type is used to specify Excel's "Specific Text" style
+// conditional format. The available criteria are "containing", "not
+// containing", "begins with" and "ends with":
+//
+//	// Highlight cells rules: Text that Contains...
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {Type: "text", Criteria: "containing", Format: format, Value: "foo"},
+//	    },
+//	)
+//
+// type: time_period - The time_period type is used to specify Excel's "Dates
+// Occurring" style conditional format. The available criteria are
+// "yesterday", "today", "tomorrow", "last 7 days", "last week", "this week",
+// "next week", "last month", "this month" and "next month":
+//
+//	// Highlight cells rules: A Date Occurring...
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {Type: "time_period", Criteria: "last 7 days", Format: format},
+//	    },
+//	)
+//
+// type: blanks - The blanks type is used to highlight blank cells in a range,
+// and the no_blanks, errors and no_errors types are used in the same way to
+// highlight non-blank cells, cells with errors and cells without errors:
+//
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {Type: "blanks", Format: format},
+//	    },
+//	)
+//
 // type: top - The top type is used to specify the top n values by number or
 // percentage in a range:
 //
@@ -2600,6 +3166,42 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 // BarSolid - Used for turns on a solid (non-gradient) fill for data bars, this
 // is only visible in Excel 2010 and later.
 //
+// BarAxisColor - Used for sets the color for the axis line of a data bar, this
+// is only visible in Excel 2010 and later.
+//
+// BarAxisPosition - Used for sets the position of the axis for data bars with
+// negative values, this is only visible in Excel 2010 and later. The available
+// options are:
+//
+//	automatic - The axis position is set by spreadsheet application based on the negative values.
+//	middle - The axis is positioned at the midpoint of the cell.
+//	none - No axis is displayed, negative values will be displayed in the same direction as positive values.
+//
+// BarNegativeColor - Used for sets the fill color for the data bars of
+// negative values, this is only visible in Excel 2010 and later.
+//
+// BarNegativeBorderColor - Used for sets the border color for the data bars of
+// negative values, this is only visible in Excel 2010 and later.
+//
+// For example, create a solid fill data bar with a custom negative fill color
+// and the axis at the midpoint of the cells:
+//
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:             "data_bar",
+//	            Criteria:         "=",
+//	            MinType:          "min",
+//	            MaxType:          "max",
+//	            BarColor:         "#638EC6",
+//	            BarSolid:         true,
+//	            BarNegativeColor: "#FF5050",
+//	            BarAxisColor:     "#000000",
+//	            BarAxisPosition:  "middle",
+//	        },
+//	    },
+//	)
+//
 // IconStyle - The available options are:
 //
 //	3Arrows
@@ -2628,19 +3230,66 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 // formatting rule when more than one rule is applied to a cell or a range of
 // cells. When this parameter is set then subsequent rules are not evaluated
 // if the current rule is true.
+//
+// Priority - used to set the evaluation order of the conditional formatting
+// rule in the worksheet, the rule with the lowest value will be evaluated
+// first. The priority of the existing rules which is equal or greater than the
+// specified priority will be increased by one. If not specified, the rule will
+// be evaluated after all existing rules in the worksheet. For example, create
+// a rule on Sheet1!A1:A10 which is evaluated before all existing rules, and
+// stop evaluating other rules if the cell value is greater than 6:
+//
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:       "cell",
+//	            Criteria:   ">",
+//	            Format:     format,
+//	            Value:      "6",
+//	            StopIfTrue: true,
+//	            Priority:   1,
+//	        },
+//	    },
+//	)
 func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFormatOptions) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
 	// Create a pseudo GUID for each unique rule.
-	var rules int
+	var rules, maxPriority int
 	for _, cf := range ws.ConditionalFormatting {
 		rules += len(cf.CfRule)
+		for _, rule := range cf.CfRule {
+			if rule.Priority > maxPriority {
+				maxPriority = rule.Priority
+			}
+		}
+	}
+	// The formulas of some rules are relative to the top-left cell of the range.
+	var ref string
+	if refs := strings.Fields(rangeRef); len(refs) > 0 {
+		ref = strings.Split(refs[0], ":")[0]
 	}
-	GUID := fmt.Sprintf("{00000000-0000-0000-%04X-%012X}", f.getSheetID(sheet), rules)
 	var cfRule []*xlsxCfRule
+	// Lower the priority of the existing rules which have the same or lower
+	// priority than the specified priority.
+	insertPriority := func(priority int) {
+		for _, rules := range append([]*xlsxConditionalFormatting{{CfRule: cfRule}}, ws.ConditionalFormatting...) {
+			for _, rule := range rules.CfRule {
+				if rule.Priority >= priority {
+					rule.Priority++
+				}
+			}
+		}
+		if maxPriority++; priority > maxPriority {
+			maxPriority = priority
+		}
+	}
 	for p, v := range opts {
+		if v.Priority < 0 {
+			return ErrParameterInvalid
+		}
 		var vt, ct string
 		var ok bool
 		// "type" is a required parameter, check for valid validation types.
@@ -2648,13 +3297,23 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 		if ok {
 			// Check for valid criteria types.
 			ct, ok = criteriaType[v.Criteria]
-			if ok || vt == "expression" || vt == "iconSet" {
+			if ok || inStrSlice([]string{"expression", "iconSet", "containsBlanks", "notContainsBlanks", "containsErrors", "notContainsErrors"}, vt, true) != -1 {
 				drawFunc, ok := drawContFmtFunc[vt]
 				if ok {
-					rule, x14rule := drawFunc(p, ct, GUID, &v)
+					if v.Style != nil {
+						if v.Format, err = f.NewConditionalStyle(v.Style); err != nil {
+							return err
+						}
+					}
+					GUID := fmt.Sprintf("{00000000-0000-0000-%04X-%012X}", f.getSheetID(sheet), rules+p)
+					rule, x14rule := drawFunc(p, ct, ref, GUID, &v)
 					if rule == nil {
 						return ErrParameterInvalid
 					}
+					if rule.Priority = v.Priority; v.Priority == 0 {
+						rule.Priority = maxPriority + 1
+					}
+					insertPriority(rule.Priority)
 					if x14rule != nil {
 						if err = f.appendCfRule(ws, x14rule); err != nil {
 							return err
@@ -2736,6 +3395,28 @@ func extractCondFmtCellIs(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOp
 	return format
 }
 
+// extractCondFmtText provides a function to extract conditional format
+// settings for text (include containing, not containing, begins with and ends
+// with) by given conditional formatting rule.
+func extractCondFmtText(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "text", Criteria: operatorType[c.Operator], Value: c.Text}
+	if c.DxfID != nil {
+		format.Format = *c.DxfID
+	}
+	return format
+}
+
+// extractCondFmtTimePeriod provides a function to extract conditional format
+// settings for dates occurring in a time period by given conditional
+// formatting rule.
+func extractCondFmtTimePeriod(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "time_period", Criteria: operatorType[c.TimePeriod]}
+	if c.DxfID != nil {
+		format.Format = *c.DxfID
+	}
+	return format
+}
+
 // extractCondFmtTop10 provides a function to extract conditional format
 // settings for top N (default is top 10) by given conditional formatting
 // rule.
@@ -2782,6 +3463,25 @@ func extractCondFmtDuplicateUniqueValues(c *xlsxCfRule, extLst *xlsxExtLst) Cond
 	}
 }
 
+// extractCondFmtBlanksErrors provides a function to extract conditional
+// format settings for blanks, no blanks, errors and no errors cells by given
+// conditional formatting rule.
+func extractCondFmtBlanksErrors(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	format := ConditionalFormatOptions{
+		StopIfTrue: c.StopIfTrue,
+		Type: map[string]string{
+			"containsBlanks":    "blanks",
+			"notContainsBlanks": "no_blanks",
+			"containsErrors":    "errors",
+			"notContainsErrors": "no_errors",
+		}[c.Type],
+	}
+	if c.DxfID != nil {
+		format.Format = *c.DxfID
+	}
+	return format
+}
+
 // extractCondFmtColorScale provides a function to extract conditional format
 // settings for color scale (include 2 color scale and 3 color scale) by given
 // conditional formatting rule.
@@ -2833,27 +3533,43 @@ func extractCondFmtDataBar(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatO
 			format.BarOnly = !*c.DataBar.ShowValue
 		}
 	}
-	extractDataBarRule := func(condFmts []decodeX14ConditionalFormatting) {
+	getColor := func(clr *xlsxColor) string {
+		if clr == nil || clr.RGB == "" {
+			return ""
+		}
+		return "#" + strings.TrimPrefix(strings.ToUpper(clr.RGB), "FF")
+	}
+	extractDataBarRule := func(ID string, condFmts []decodeX14ConditionalFormatting) {
 		for _, condFmt := range condFmts {
 			for _, rule := range condFmt.CfRule {
-				if rule.DataBar != nil {
-					format.BarSolid = !rule.DataBar.Gradient
+				if rule.DataBar != nil && rule.ID == ID {
+					format.BarSolid = rule.DataBar.Gradient != nil && !*rule.DataBar.Gradient
 					format.BarDirection = rule.DataBar.Direction
-					if rule.DataBar.BorderColor != nil {
-						format.BarBorderColor = "#" + strings.TrimPrefix(strings.ToUpper(rule.DataBar.BorderColor.RGB), "FF")
+					format.BarAxisPosition = rule.DataBar.AxisPosition
+					format.BarBorderColor = getColor(rule.DataBar.BorderColor)
+					if rule.DataBar.NegativeFillColor != nil && rule.DataBar.NegativeFillColor.RGB != "FFFF0000" {
+						format.BarNegativeColor = getColor(rule.DataBar.NegativeFillColor)
+					}
+					if sameAsPositive := rule.DataBar.NegativeBarBorderColorSameAsPositive; sameAsPositive != nil && !*sameAsPositive {
+						format.BarNegativeBorderColor = getColor(rule.DataBar.NegativeBorderColor)
+					}
+					if rule.DataBar.AxisColor != nil && rule.DataBar.AxisColor.RGB != "FF000000" {
+						format.BarAxisColor = getColor(rule.DataBar.AxisColor)
 					}
 				}
 			}
 		}
 	}
-	extractExtLst := func(extLst *decodeExtLst) {
+	extractExtLst := func(ID string, extLst *decodeExtLst) {
 		for _, ext := range extLst.Ext {
 			if ext.URI == ExtURIConditionalFormattings {
 				decodeCondFmts := new(decodeX14ConditionalFormattings)
 				if err := xml.Unmarshal([]byte(ext.Content), &decodeCondFmts); err == nil {
-					var condFmts []decodeX14ConditionalFormatting
-					if err = xml.Unmarshal([]byte(decodeCondFmts.Content), &condFmts); err == nil {
-						extractDataBarRule(condFmts)
+					var condFmts struct {
+						ConditionalFormatting []decodeX14ConditionalFormatting `xml:"conditionalFormatting"`
+					}
+					if err = xml.Unmarshal([]byte("<conditionalFormattings>"+decodeCondFmts.Content+"</conditionalFormattings>"), &condFmts); err == nil {
+						extractDataBarRule(ID, condFmts.ConditionalFormatting)
 					}
 				}
 			}
@@ -2864,7 +3580,7 @@ func extractCondFmtDataBar(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatO
 		if err := xml.Unmarshal([]byte(c.ExtLst.Ext), &ext); err == nil && extLst != nil {
 			decodeExtLst := new(decodeExtLst)
 			if err = xml.Unmarshal([]byte("<extLst>"+extLst.Ext+"</extLst>"), decodeExtLst); err == nil {
-				extractExtLst(decodeExtLst)
+				extractExtLst(ext.ID, decodeExtLst)
 			}
 		}
 	}
@@ -2884,7 +3600,7 @@ func extractCondFmtExp(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptio
 // extractCondFmtIconSet provides a function to extract conditional format
 // settings for icon sets by given conditional formatting rule.
 func extractCondFmtIconSet(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
-	format := ConditionalFormatOptions{Type: "icon_set"}
+	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "icon_set"}
 	if c.IconSet != nil {
 		if c.IconSet.ShowValue != nil {
 			format.IconsOnly = !*c.IconSet.ShowValue
@@ -2896,10 +3612,17 @@ func extractCondFmtIconSet(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatO
 }
 
 // GetConditionalFormats returns conditional format settings by given worksheet
-// name.
+// name. The format of the rule will be resolved to the Style field if the rule
+// applies a differential format.
 func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalFormatOptions, error) {
 	conditionalFormats := make(map[string][]ConditionalFormatOptions)
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
+	if err != nil {
+		return conditionalFormats, err
+	}
+	f.mu.Lock()
+	_, err = f.stylesViewer()
+	f.mu.Unlock()
 	if err != nil {
 		return conditionalFormats, err
 	}
@@ -2907,7 +3630,12 @@ func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalForm
 		var opts []ConditionalFormatOptions
 		for _, cr := range cf.CfRule {
 			if extractFunc, ok := extractContFmtFunc[cr.Type]; ok {
-				opts = append(opts, extractFunc(cr, ws.ExtLst))
+				opt := extractFunc(cr, ws.ExtLst)
+				opt.Priority = cr.Priority
+				if cr.DxfID != nil {
+					opt.Style, _ = f.GetConditionalStyle(*cr.DxfID)
+				}
+				opts = append(opts, opt)
 			}
 		}
 		conditionalFormats[cf.SQRef] = opts
@@ -2915,26 +3643,211 @@ func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalForm
 	return conditionalFormats, err
 }
 
+// SetConditionalFormatPriority provides a function to reorder the conditional
+// formatting rules on the range by given worksheet name, range reference and
+// the priorities of the rules on the range in the new evaluation order. The
+// priorities of the rules on the range will be reassigned, and the priorities
+// of the rules on other ranges will not be changed. For example, the rules
+// with priorities 1, 2 and 3 on Sheet1!A1:A10, swap the evaluation order of
+// the first and last rules:
+//
+//	err := f.SetConditionalFormatPriority("Sheet1", "A1:A10", []int{3, 2, 1})
+func (f *File) SetConditionalFormatPriority(sheet, rangeRef string, priorities []int) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		if cf.SQRef != rangeRef {
+			continue
+		}
+		if len(priorities) != len(cf.CfRule) {
+			return ErrParameterInvalid
+		}
+		var (
+			sorted []int
+			rules  = map[int]*xlsxCfRule{}
+		)
+		for _, rule := range cf.CfRule {
+			sorted, rules[rule.Priority] = append(sorted, rule.Priority), rule
+		}
+		sort.Ints(sorted)
+		cfRule := make([]*xlsxCfRule, 0, len(cf.CfRule))
+		for _, priority := range priorities {
+			rule, ok := rules[priority]
+			if !ok {
+				return ErrParameterInvalid
+			}
+			delete(rules, priority)
+			cfRule = append(cfRule, rule)
+		}
+		for i, rule := range cfRule {
+			rule.Priority = sorted[i]
+		}
+		cf.CfRule = cfRule
+		return err
+	}
+	return ErrParameterInvalid
+}
+
 // UnsetConditionalFormat provides a function to unset the conditional format
-// by given worksheet name and range reference.
-func (f *File) UnsetConditionalFormat(sheet, rangeRef string) error {
+// by given worksheet name, range reference and optional rules priority. All
+// rules of the range will be deleted if not specify the priority, and the
+// rules with given priority in all ranges of the worksheet will be deleted if
+// the range reference is empty. The differential formats only used by the
+// deleted rules will be removed. For example, delete the conditional format
+// rule with priority 2 on Sheet1!A1:A10:
+//
+//	err := f.UnsetConditionalFormat("Sheet1", "A1:A10", 2)
+func (f *File) UnsetConditionalFormat(sheet, rangeRef string, priority ...int) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
-	for i, cf := range ws.ConditionalFormatting {
-		if cf.SQRef == rangeRef {
+	var (
+		dxfIDs     []int
+		priorities = map[int]bool{}
+	)
+	for _, p := range priority {
+		priorities[p] = true
+	}
+	for i := 0; i < len(ws.ConditionalFormatting); i++ {
+		cf := ws.ConditionalFormatting[i]
+		if rangeRef != "" && cf.SQRef != rangeRef || rangeRef == "" && len(priority) == 0 {
+			continue
+		}
+		for j := 0; j < len(cf.CfRule); j++ {
+			if rule := cf.CfRule[j]; len(priority) == 0 || priorities[rule.Priority] {
+				if rule.DxfID != nil {
+					dxfIDs = append(dxfIDs, *rule.DxfID)
+				}
+				cf.CfRule = append(cf.CfRule[:j], cf.CfRule[j+1:]...)
+				j--
+			}
+		}
+		if len(cf.CfRule) == 0 {
 			ws.ConditionalFormatting = append(ws.ConditionalFormatting[:i], ws.ConditionalFormatting[i+1:]...)
-			return nil
+			i--
 		}
 	}
-	return nil
+	return f.deleteOrphanedDxfs(dxfIDs)
+}
+
+// deleteOrphanedDxfs provides a function to remove the differential formats
+// by given format indexes if they are no longer used in the workbook, and
+// update the differential format indexes in the workbook.
+func (f *File) deleteOrphanedDxfs(dxfIDs []int) error {
+	if len(dxfIDs) == 0 {
+		return nil
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil || s.Dxfs == nil {
+		return err
+	}
+	var (
+		used   = map[int]bool{}
+		sheets []*xlsxWorksheet
+		parts  []string
+		dxfRef = regexp.MustCompile(`(?i)(dxfId=")(\d+)(")`)
+	)
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheet).Error() {
+				continue
+			}
+			return err
+		}
+		sheets = append(sheets, ws)
+		for _, cf := range ws.ConditionalFormatting {
+			for _, rule := range cf.CfRule {
+				if rule.DxfID != nil {
+					used[*rule.DxfID] = true
+				}
+			}
+		}
+	}
+	// Differential formats also be referenced by the tables, pivot tables and
+	// the table styles.
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/tables/") || strings.HasPrefix(k.(string), "xl/pivotTables/") {
+			parts = append(parts, k.(string))
+			for _, match := range dxfRef.FindAllStringSubmatch(string(v.([]byte)), -1) {
+				idx, _ := strconv.Atoi(match[2])
+				used[idx] = true
+			}
+		}
+		return true
+	})
+	var styleRefs []*string
+	if s.TableStyles != nil {
+		for _, tableStyle := range s.TableStyles.TableStyles {
+			styleRefs = append(styleRefs, &tableStyle.TableStyleElement)
+		}
+	}
+	if s.ExtLst != nil {
+		styleRefs = append(styleRefs, &s.ExtLst.Ext)
+	}
+	for _, ref := range styleRefs {
+		for _, match := range dxfRef.FindAllStringSubmatch(*ref, -1) {
+			idx, _ := strconv.Atoi(match[2])
+			used[idx] = true
+		}
+	}
+	var orphaned []int
+	for _, idx := range dxfIDs {
+		if idx >= 0 && idx < len(s.Dxfs.Dxfs) && !used[idx] {
+			orphaned, used[idx] = append(orphaned, idx), true
+		}
+	}
+	if len(orphaned) == 0 {
+		return err
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(orphaned)))
+	for _, idx := range orphaned {
+		s.Dxfs.Dxfs = append(s.Dxfs.Dxfs[:idx], s.Dxfs.Dxfs[idx+1:]...)
+	}
+	s.Dxfs.Count = len(s.Dxfs.Dxfs)
+	newIdx := func(idx int) int {
+		var offset int
+		for _, i := range orphaned {
+			if i < idx {
+				offset++
+			}
+		}
+		return idx - offset
+	}
+	replaceRef := func(content string) string {
+		return dxfRef.ReplaceAllStringFunc(content, func(match string) string {
+			sub := dxfRef.FindStringSubmatch(match)
+			idx, _ := strconv.Atoi(sub[2])
+			return sub[1] + strconv.Itoa(newIdx(idx)) + sub[3]
+		})
+	}
+	for _, ws := range sheets {
+		for _, cf := range ws.ConditionalFormatting {
+			for _, rule := range cf.CfRule {
+				if rule.DxfID != nil {
+					rule.DxfID = intPtr(newIdx(*rule.DxfID))
+				}
+			}
+		}
+	}
+	for _, part := range parts {
+		f.Pkg.Store(part, []byte(replaceRef(string(f.readXML(part)))))
+	}
+	for _, ref := range styleRefs {
+		*ref = replaceRef(*ref)
+	}
+	return err
 }
 
 // drawCondFmtCellIs provides a function to create conditional formatting rule
 // for cell value (include between, not between, equal, not equal, greater
 // than and less than) by given priority, criteria type and format settings.
-func drawCondFmtCellIs(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtCellIs(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	c := &xlsxCfRule{
 		Priority:   p + 1,
 		StopIfTrue: format.StopIfTrue,
@@ -2952,10 +3865,72 @@ func drawCondFmtCellIs(p int, ct, GUID string, format *ConditionalFormatOptions)
 	return c, nil
 }
 
+// drawCondFmtText provides a function to create conditional formatting rule
+// for text (include containing, not containing, begins with and ends with) by
+// given priority, criteria type and format settings.
+func drawCondFmtText(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+	text := strings.ReplaceAll(format.Value, "\"", "\"\"")
+	types := map[string][]string{
+		"containsText": {"containsText", fmt.Sprintf("NOT(ISERROR(SEARCH(\"%s\",%s)))", text, ref)},
+		"notContains":  {"notContainsText", fmt.Sprintf("ISERROR(SEARCH(\"%s\",%s))", text, ref)},
+		"beginsWith":   {"beginsWith", fmt.Sprintf("LEFT(%s,LEN(\"%s\"))=\"%s\"", ref, text, text)},
+		"endsWith":     {"endsWith", fmt.Sprintf("RIGHT(%s,LEN(\"%s\"))=\"%s\"", ref, text, text)},
+	}
+	rule, ok := types[ct]
+	if !ok {
+		return nil, nil
+	}
+	return &xlsxCfRule{
+		Priority:   p + 1,
+		StopIfTrue: format.StopIfTrue,
+		Type:       rule[0],
+		Operator:   ct,
+		Text:       format.Value,
+		Formula:    []string{rule[1]},
+		DxfID:      intPtr(format.Format),
+	}, nil
+}
+
+// drawCondFmtTimePeriod provides a function to create conditional formatting
+// rule for dates occurring in a time period by given priority, criteria type
+// and format settings.
+func drawCondFmtTimePeriod(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+	formulas := map[string]string{
+		"yesterday":     "FLOOR(%[1]s,1)=TODAY()-1",
+		"today":         "FLOOR(%[1]s,1)=TODAY()",
+		"tomorrow":      "FLOOR(%[1]s,1)=TODAY()+1",
+		"last7Days":     "AND(TODAY()-FLOOR(%[1]s,1)<=6,FLOOR(%[1]s,1)<=TODAY())",
+		"lastWeek":      "AND(TODAY()-ROUNDDOWN(%[1]s,0)>=(WEEKDAY(TODAY())),TODAY()-ROUNDDOWN(%[1]s,0)<(WEEKDAY(TODAY())+7))",
+		"thisWeek":      "AND(TODAY()-ROUNDDOWN(%[1]s,0)<=WEEKDAY(TODAY())-1,ROUNDDOWN(%[1]s,0)-TODAY()<=7-WEEKDAY(TODAY()))",
+		"nextWeek":      "AND(ROUNDDOWN(%[1]s,0)-TODAY()>(7-WEEKDAY(TODAY())),ROUNDDOWN(%[1]s,0)-TODAY()<(15-WEEKDAY(TODAY())))",
+		"lastMonth":     "AND(MONTH(%[1]s)=MONTH(EDATE(TODAY(),0-1)),YEAR(%[1]s)=YEAR(EDATE(TODAY(),0-1)))",
+		"thisMonth":     "AND(MONTH(%[1]s)=MONTH(TODAY()),YEAR(%[1]s)=YEAR(TODAY()))",
+		"nextMonth":     "AND(MONTH(%[1]s)=MONTH(EDATE(TODAY(),0+1)),YEAR(%[1]s)=YEAR(EDATE(TODAY(),0+1)))",
+		"continueWeek":  "AND(ROUNDDOWN(%[1]s,0)-TODAY()>(7-WEEKDAY(TODAY())),ROUNDDOWN(%[1]s,0)-TODAY()<(15-WEEKDAY(TODAY())))",
+		"continueMonth": "AND(MONTH(%[1]s)=MONTH(EDATE(TODAY(),0+1)),YEAR(%[1]s)=YEAR(EDATE(TODAY(),0+1)))",
+	}
+	formula, ok := formulas[ct]
+	if !ok {
+		return nil, nil
+	}
+	timePeriod := map[string]string{"continueWeek": "nextWeek", "continueMonth": "nextMonth"}[ct]
+	if timePeriod == "" {
+		timePeriod = ct
+	}
+	return &xlsxCfRule{
+		Priority:   p + 1,
+		StopIfTrue: format.StopIfTrue,
+		Type:       validType[format.Type],
+		TimePeriod: timePeriod,
+		Formula:    []string{fmt.Sprintf(formula, ref)},
+		DxfID:      intPtr(format.Format),
+	}, nil
+}
+
 // drawCondFmtTop10 provides a function to create conditional formatting rule
 // for top N (default is top 10) by given priority, criteria type and format
 // settings.
-func drawCondFmtTop10(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtTop10(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	c := &xlsxCfRule{
 		Priority:   p + 1,
 		StopIfTrue: format.StopIfTrue,
@@ -2974,7 +3949,7 @@ func drawCondFmtTop10(p int, ct, GUID string, format *ConditionalFormatOptions)
 // drawCondFmtAboveAverage provides a function to create conditional
 // formatting rule for above average and below average by given priority,
 // criteria type and format settings.
-func drawCondFmtAboveAverage(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtAboveAverage(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	return &xlsxCfRule{
 		Priority:     p + 1,
 		StopIfTrue:   format.StopIfTrue,
@@ -2987,11 +3962,30 @@ func drawCondFmtAboveAverage(p int, ct, GUID string, format *ConditionalFormatOp
 // drawCondFmtDuplicateUniqueValues provides a function to create conditional
 // formatting rule for duplicate and unique values by given priority, criteria
 // type and format settings.
-func drawCondFmtDuplicateUniqueValues(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtDuplicateUniqueValues(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+	return &xlsxCfRule{
+		Priority:   p + 1,
+		StopIfTrue: format.StopIfTrue,
+		Type:       validType[format.Type],
+		DxfID:      intPtr(format.Format),
+	}, nil
+}
+
+// drawCondFmtBlanksErrors provides a function to create conditional
+// formatting rule for blanks, no blanks, errors and no errors cells by given
+// priority, criteria type and format settings.
+func drawCondFmtBlanksErrors(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+	formula := map[string]string{
+		"containsBlanks":    "LEN(TRIM(%s))=0",
+		"notContainsBlanks": "LEN(TRIM(%s))>0",
+		"containsErrors":    "ISERROR(%s)",
+		"notContainsErrors": "NOT(ISERROR(%s))",
+	}[validType[format.Type]]
 	return &xlsxCfRule{
 		Priority:   p + 1,
 		StopIfTrue: format.StopIfTrue,
 		Type:       validType[format.Type],
+		Formula:    []string{fmt.Sprintf(formula, ref)},
 		DxfID:      intPtr(format.Format),
 	}, nil
 }
@@ -2999,7 +3993,7 @@ func drawCondFmtDuplicateUniqueValues(p int, ct, GUID string, format *Conditiona
 // drawCondFmtColorScale provides a function to create conditional formatting
 // rule for color scale (include 2 color scale and 3 color scale) by given
 // priority, criteria type and format settings.
-func drawCondFmtColorScale(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtColorScale(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	minValue := format.MinValue
 	if minValue == "" {
 		minValue = "0"
@@ -3037,10 +4031,14 @@ func drawCondFmtColorScale(p int, ct, GUID string, format *ConditionalFormatOpti
 
 // drawCondFmtDataBar provides a function to create conditional formatting
 // rule for data bar by given priority, criteria type and format settings.
-func drawCondFmtDataBar(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtDataBar(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	var x14CfRule *xlsxX14CfRule
 	var extLst *xlsxExtLst
-	if format.BarSolid || format.BarDirection == "leftToRight" || format.BarDirection == "rightToLeft" || format.BarBorderColor != "" {
+	if format.BarAxisPosition != "" && inStrSlice([]string{"automatic", "middle", "none"}, format.BarAxisPosition, true) == -1 {
+		return nil, nil
+	}
+	if format.BarSolid || format.BarDirection == "leftToRight" || format.BarDirection == "rightToLeft" || format.BarBorderColor != "" ||
+		format.BarAxisColor != "" || format.BarAxisPosition != "" || format.BarNegativeColor != "" || format.BarNegativeBorderColor != "" {
 		extLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s" xmlns:x14="%s"><x14:id>%s</x14:id></ext>`, ExtURIConditionalFormattingRuleID, NameSpaceSpreadSheetX14.Value, GUID)}
 		x14CfRule = &xlsxX14CfRule{
 			Type: validType[format.Type],
@@ -3052,12 +4050,25 @@ func drawCondFmtDataBar(p int, ct, GUID string, format *ConditionalFormatOptions
 				Direction:         format.BarDirection,
 				Cfvo:              []*xlsxCfvo{{Type: "autoMin"}, {Type: "autoMax"}},
 				NegativeFillColor: &xlsxColor{RGB: "FFFF0000"},
-				AxisColor:         &xlsxColor{RGB: "FFFF0000"},
+				AxisColor:         &xlsxColor{RGB: "FF000000"},
 			},
 		}
+		if format.BarAxisPosition != "automatic" {
+			x14CfRule.DataBar.AxisPosition = format.BarAxisPosition
+		}
 		if x14CfRule.DataBar.Border {
 			x14CfRule.DataBar.BorderColor = &xlsxColor{RGB: getPaletteColor(format.BarBorderColor)}
 		}
+		if format.BarNegativeColor != "" {
+			x14CfRule.DataBar.NegativeFillColor = &xlsxColor{RGB: getPaletteColor(format.BarNegativeColor)}
+		}
+		if format.BarNegativeBorderColor != "" {
+			x14CfRule.DataBar.NegativeBarBorderColorSameAsPositive = boolPtr(false)
+			x14CfRule.DataBar.NegativeBorderColor = &xlsxColor{RGB: getPaletteColor(format.BarNegativeBorderColor)}
+		}
+		if format.BarAxisColor != "" {
+			x14CfRule.DataBar.AxisColor = &xlsxColor{RGB: getPaletteColor(format.BarAxisColor)}
+		}
 	}
 	return &xlsxCfRule{
 		Priority:   p + 1,
@@ -3074,7 +4085,7 @@ func drawCondFmtDataBar(p int, ct, GUID string, format *ConditionalFormatOptions
 
 // drawCondFmtExp provides a function to create conditional formatting rule
 // for expression by given priority, criteria type and format settings.
-func drawCondFmtExp(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtExp(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	return &xlsxCfRule{
 		Priority:   p + 1,
 		StopIfTrue: format.StopIfTrue,
@@ -3086,7 +4097,7 @@ func drawCondFmtExp(p int, ct, GUID string, format *ConditionalFormatOptions) (*
 
 // drawCondFmtIconSet provides a function to create conditional formatting rule
 // for icon set by given priority, criteria type and format settings.
-func drawCondFmtIconSet(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+func drawCondFmtIconSet(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	cfvo3 := &xlsxCfRule{IconSet: &xlsxIconSet{Cfvo: []*xlsxCfvo{
 		{Type: "percent", Val: "0"},
 		{Type: "percent", Val: "33"},
@@ -3129,6 +4140,7 @@ func drawCondFmtIconSet(p int, ct, GUID string, format *ConditionalFormatOptions
 		return nil, nil
 	}
 	cfRule.Priority = p + 1
+	cfRule.StopIfTrue = format.StopIfTrue
 	cfRule.IconSet.IconSet = format.IconStyle
 	cfRule.IconSet.Reverse = format.ReverseIcons
 	cfRule.IconSet.ShowValue = boolPtr(!format.IconsOnly)
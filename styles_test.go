@@ -5,6 +5,7 @@ import (
 	"math"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -154,6 +155,36 @@ func TestSetConditionalFormat(t *testing.T) {
 				}},
 			},
 		}},
+	}, {
+		label:  "text begins with",
+		format: []ConditionalFormatOptions{{Type: "text", Criteria: "begins with", Format: 1, Value: `a"b`}},
+		rules: []*xlsxCfRule{{
+			Priority: 1,
+			Type:     "beginsWith",
+			Operator: "beginsWith",
+			Text:     `a"b`,
+			Formula:  []string{`LEFT(A1,LEN("a""b"))="a""b"`},
+			DxfID:    intPtr(1),
+		}},
+	}, {
+		label:  "time_period",
+		format: []ConditionalFormatOptions{{Type: "time_period", Criteria: "continue month", Format: 1}},
+		rules: []*xlsxCfRule{{
+			Priority:   1,
+			Type:       "timePeriod",
+			TimePeriod: "nextMonth",
+			Formula:    []string{"AND(MONTH(A1)=MONTH(EDATE(TODAY(),0+1)),YEAR(A1)=YEAR(EDATE(TODAY(),0+1)))"},
+			DxfID:      intPtr(1),
+		}},
+	}, {
+		label:  "no_errors",
+		format: []ConditionalFormatOptions{{Type: "no_errors", Format: 1}},
+		rules: []*xlsxCfRule{{
+			Priority: 1,
+			Type:     "notContainsErrors",
+			Formula:  []string{"NOT(ISERROR(A1))"},
+			DxfID:    intPtr(1),
+		}},
 	}}
 
 	for _, testCase := range cases {
@@ -191,6 +222,39 @@ func TestSetConditionalFormat(t *testing.T) {
 	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A2", condFmts), "XML syntax error on line 1: element <conditionalFormattings> closed by </conditionalFormatting>")
 	// Test creating a conditional format with invalid icon set style
 	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "unknown"}}), ErrParameterInvalid.Error())
+	// Test creating a conditional format with invalid data bar axis position
+	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarAxisPosition: "top"}}), ErrParameterInvalid.Error())
+	// Test creating a conditional format with data bar axis and negative value settings
+	f = NewFile()
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C1:C2", []ConditionalFormatOptions{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarAxisPosition: "automatic", BarNegativeBorderColor: "#C00000"}}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, ws.(*xlsxWorksheet).ExtLst.Ext, `<x14:dataBar maxLength="100" minLength="0" border="false" gradient="true" negativeBarBorderColorSameAsPositive="false"><x14:cfvo type="autoMin"></x14:cfvo><x14:cfvo type="autoMax"></x14:cfvo><x14:negativeFillColor rgb="FFFF0000"></x14:negativeFillColor><x14:negativeBorderColor rgb="FFC00000"></x14:negativeBorderColor><x14:axisColor rgb="FF000000"></x14:axisColor></x14:dataBar>`)
+	// Test creating a conditional format with invalid text and time period criteria
+	for _, format := range []ConditionalFormatOptions{
+		{Type: "text", Criteria: "between", Value: "foo"},
+		{Type: "time_period", Criteria: ">"},
+	} {
+		assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{format}), ErrParameterInvalid.Error())
+	}
+	// Test creating a conditional format with style
+	f = NewFile()
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B2:B10 D2", []ConditionalFormatOptions{
+		{Type: "text", Criteria: "containing", Value: "foo", Style: &Style{Font: &Font{Color: "9A0511"}}},
+		{Type: "blanks", Style: &Style{Fill: Fill{Type: "pattern", Color: []string{"FEC7CE"}, Pattern: 1}}},
+	}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	cfRule := ws.(*xlsxWorksheet).ConditionalFormatting[0].CfRule
+	assert.Equal(t, []string{"NOT(ISERROR(SEARCH(\"foo\",B2)))"}, cfRule[0].Formula)
+	assert.Equal(t, []string{"LEN(TRIM(B2))=0"}, cfRule[1].Formula)
+	assert.Equal(t, []int{0, 1}, []int{*cfRule[0].DxfID, *cfRule[1].DxfID})
+	assert.Len(t, f.Styles.Dxfs.Dxfs, 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetConditionalFormat.xlsx")))
+	// Test creating a conditional format with invalid style
+	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{
+		{Type: "blanks", Style: &Style{Font: &Font{Size: MaxFontSize + 1}}},
+	}), ErrFontSize.Error())
 }
 
 func TestGetConditionalFormats(t *testing.T) {
@@ -206,14 +270,30 @@ func TestGetConditionalFormats(t *testing.T) {
 		{{Type: "2_color_scale", Criteria: "=", MinType: "num", MaxType: "num", MinColor: "#FF0000", MaxColor: "#0000FF"}},
 		{{Type: "data_bar", Criteria: "=", MinType: "num", MaxType: "num", MinValue: "-10", MaxValue: "10", BarBorderColor: "#0000FF", BarColor: "#638EC6", BarOnly: true, BarSolid: true, StopIfTrue: true}},
 		{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarBorderColor: "#0000FF", BarColor: "#638EC6", BarDirection: "rightToLeft", BarOnly: true, BarSolid: true, StopIfTrue: true}},
+		{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarAxisColor: "#0000FF", BarAxisPosition: "middle", BarNegativeColor: "#FF5050", BarNegativeBorderColor: "#C00000"}},
+		{
+			{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarAxisPosition: "none"},
+			{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarSolid: true, BarBorderColor: "#0000FF"},
+		},
 		{{Type: "formula", Format: 1, Criteria: "="}},
-		{{Type: "icon_set", IconStyle: "3Arrows", ReverseIcons: true, IconsOnly: true}},
+		{{Type: "icon_set", IconStyle: "3Arrows", ReverseIcons: true, IconsOnly: true, StopIfTrue: true}},
+		{{Type: "text", Format: 1, Criteria: "containing", Value: "foo"}},
+		{{Type: "text", Format: 1, Criteria: "not containing", Value: "foo"}},
+		{{Type: "text", Format: 1, Criteria: "ends with", Value: "foo", StopIfTrue: true}},
+		{{Type: "time_period", Format: 1, Criteria: "tomorrow"}},
+		{{Type: "time_period", Format: 1, Criteria: "last 7 days"}},
+		{{Type: "blanks", Format: 1}},
+		{{Type: "no_blanks", Format: 1}},
+		{{Type: "errors", Format: 1}, {Type: "no_errors", Format: 1}},
 	} {
 		f := NewFile()
 		err := f.SetConditionalFormat("Sheet1", "A1:A2", format)
 		assert.NoError(t, err)
 		opts, err := f.GetConditionalFormats("Sheet1")
 		assert.NoError(t, err)
+		for i := range format {
+			format[i].Priority = i + 1
+		}
 		assert.Equal(t, format, opts["A1:A2"])
 	}
 	// Test get conditional formats on no exists worksheet
@@ -225,6 +305,63 @@ func TestGetConditionalFormats(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestConditionalFormatPriority(t *testing.T) {
+	f := NewFile()
+	getPriorities := func(rangeRef string) []int {
+		opts, err := f.GetConditionalFormats("Sheet1")
+		assert.NoError(t, err)
+		var priorities []int
+		for _, opt := range opts[rangeRef] {
+			priorities = append(priorities, opt.Priority)
+		}
+		return priorities
+	}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: 0, Value: "6", StopIfTrue: true},
+		{Type: "cell", Criteria: "<", Format: 0, Value: "2"},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", []ConditionalFormatOptions{
+		{Type: "duplicate", Criteria: "=", Format: 0},
+	}))
+	assert.Equal(t, []int{1, 2}, getPriorities("A1:A10"))
+	assert.Equal(t, []int{3}, getPriorities("B1:B10"))
+	// Test create conditional format with explicit priority
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C1:C10", []ConditionalFormatOptions{
+		{Type: "unique", Criteria: "=", Format: 0, Priority: 2},
+		{Type: "blanks", Format: 0},
+		{Type: "errors", Format: 0, Priority: 10},
+	}))
+	assert.Equal(t, []int{1, 3}, getPriorities("A1:A10"))
+	assert.Equal(t, []int{4}, getPriorities("B1:B10"))
+	assert.Equal(t, []int{2, 5, 10}, getPriorities("C1:C10"))
+	// Test reorder conditional format rules on the range
+	assert.NoError(t, f.SetConditionalFormatPriority("Sheet1", "C1:C10", []int{10, 2, 5}))
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"errors", "unique", "blanks"}, []string{opts["C1:C10"][0].Type, opts["C1:C10"][1].Type, opts["C1:C10"][2].Type})
+	assert.Equal(t, []int{2, 5, 10}, getPriorities("C1:C10"))
+	assert.NoError(t, f.SetConditionalFormatPriority("Sheet1", "A1:A10", []int{3, 1}))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, ConditionalFormatOptions{Type: "cell", Criteria: "less than", Format: 0, Value: "2", Priority: 1}, opts["A1:A10"][0])
+	assert.True(t, opts["A1:A10"][1].StopIfTrue)
+	assert.Equal(t, []int{1, 3}, getPriorities("A1:A10"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestConditionalFormatPriority.xlsx")))
+	// Test reorder conditional format rules with invalid priorities
+	for _, priorities := range [][]int{nil, {1}, {1, 2}, {3, 3}} {
+		assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormatPriority("Sheet1", "A1:A10", priorities))
+	}
+	// Test reorder conditional format rules on the range without rules
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormatPriority("Sheet1", "D1:D10", []int{1}))
+	// Test reorder conditional format rules on not exists worksheet
+	assert.EqualError(t, f.SetConditionalFormatPriority("SheetN", "A1:A10", []int{1}), "sheet SheetN does not exist")
+	// Test create conditional format with invalid priority
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "D1:D10", []ConditionalFormatOptions{
+		{Type: "unique", Criteria: "=", Format: 0, Priority: -1},
+	}))
+	assert.NoError(t, f.Close())
+}
+
 func TestUnsetConditionalFormat(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 7))
@@ -239,6 +376,97 @@ func TestUnsetConditionalFormat(t *testing.T) {
 	assert.EqualError(t, f.UnsetConditionalFormat("Sheet:1", "A1:A10"), ErrSheetNameInvalid.Error())
 	// Save spreadsheet by the given path
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUnsetConditionalFormat.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test unset conditional format by rule priority and delete orphaned
+	// differential formats
+	f = NewFile()
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	styles := []*Style{
+		{Font: &Font{Color: "9A0511"}},
+		{Font: &Font{Color: "9B5713"}},
+		{Font: &Font{Color: "09600B"}},
+		{Font: &Font{Color: "0000FF"}},
+	}
+	var formats []int
+	for _, style := range styles {
+		format, err := f.NewConditionalStyle(style)
+		assert.NoError(t, err)
+		formats = append(formats, format)
+	}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: formats[0], Value: "6"},
+		{Type: "cell", Criteria: "<", Format: formats[1], Value: "2"},
+		{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6"},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: formats[1], Value: "6"},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet2", "A1:A10", []ConditionalFormatOptions{
+		{Type: "duplicate", Criteria: "=", Format: formats[2]},
+		{Type: "unique", Criteria: "=", Format: formats[3]},
+	}))
+	// Test the differential format which referenced by table should be kept
+	f.Pkg.Store("xl/tables/table1.xml", []byte(`<table dataDxfId="3" headerRowDxfId="2"/>`))
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "A1:A10", 1, 3))
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts["A1:A10"], 1)
+	assert.Equal(t, "less than", opts["A1:A10"][0].Criteria)
+	assert.Equal(t, "9B5713", opts["A1:A10"][0].Style.Font.Color)
+	// Test unset conditional format by rule priority in all ranges
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet2", "", 1))
+	opts, err = f.GetConditionalFormats("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, opts["A1:A10"], 1)
+	assert.Equal(t, []interface{}{"unique", 2, "0000FF"}, []interface{}{opts["A1:A10"][0].Type, opts["A1:A10"][0].Format, opts["A1:A10"][0].Style.Font.Color})
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Len(t, s.Dxfs.Dxfs, 3)
+	assert.Equal(t, 3, s.Dxfs.Count)
+	table, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, `<table dataDxfId="2" headerRowDxfId="1"/>`, string(table.([]byte)))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "9B5713", opts["A1:A10"][0].Style.Font.Color)
+	assert.Equal(t, "9B5713", opts["B1:B10"][0].Style.Font.Color)
+	// Test unset conditional format without range reference and priority
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", ""))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts, 2)
+	// Test delete differential formats which referenced by table styles
+	s.TableStyles = &xlsxTableStyles{TableStyles: []*xlsxTableStyle{{TableStyleElement: `<tableStyleElement type="wholeTable" dxfId="2"/>`}}}
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "A1:A10"))
+	assert.Len(t, s.Dxfs.Dxfs, 3)
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "B1:B10"))
+	assert.Len(t, s.Dxfs.Dxfs, 2)
+	assert.Equal(t, `<tableStyleElement type="wholeTable" dxfId="1"/>`, s.TableStyles.TableStyles[0].TableStyleElement)
+	assert.NoError(t, f.Close())
+
+	// Test unset conditional format with unsupported charset style sheet
+	f = NewFile()
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{{Type: "cell", Criteria: ">", Format: 0, Value: "6"}}))
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetConditionalFormats("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	assert.EqualError(t, f.UnsetConditionalFormat("Sheet1", "A1:A10"), "XML syntax error on line 1: invalid UTF-8")
+	// Test unset conditional format with unsupported charset workbook
+	f = NewFile()
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{{Type: "cell", Criteria: ">", Format: 0, Value: "6"}}))
+	_, err = f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.UnsetConditionalFormat("Sheet1", "A1:A10"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
 }
 
 func TestNewStyle(t *testing.T) {
@@ -270,6 +498,15 @@ func TestNewStyle(t *testing.T) {
 	assert.Equal(t, ErrFontLength, err)
 	_, err = f.NewStyle(&Style{Font: &Font{Size: MaxFontSize + 1}})
 	assert.Equal(t, ErrFontSize, err)
+	// Test create style with invalid gradient fill stops or angle
+	for _, fill := range []Fill{
+		{Type: "gradient", Stops: []GradientStop{{Color: "FFFFFF"}}},
+		{Type: "gradient", Stops: []GradientStop{{Color: "FFFFFF"}, {Position: 1.5, Color: "000000"}}},
+		{Type: "gradient", Angle: 360, Stops: []GradientStop{{Color: "FFFFFF"}, {Position: 1, Color: "000000"}}},
+	} {
+		_, err = f.NewStyle(&Style{Fill: fill})
+		assert.Equal(t, ErrParameterInvalid, err)
+	}
 
 	// Test create numeric custom style
 	numFmt := "####;####"
@@ -378,6 +615,9 @@ func TestConditionalStyle(t *testing.T) {
 	numFmt1 := "0.00"
 	_, err = f.NewConditionalStyle(&Style{CustomNumFmt: &numFmt1})
 	assert.NoError(t, err)
+	// Test create conditional style without style options
+	_, err = f.NewConditionalStyle(nil)
+	assert.Equal(t, ErrParameterRequired, err)
 	// Test create conditional style with unsupported charset style sheet
 	f.Styles = nil
 	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
@@ -527,6 +767,35 @@ func TestGetThemeColor(t *testing.T) {
 	assert.Empty(t, f.getThemeColor(&xlsxColor{Indexed: len(IndexedColorMapping), Tint: 0.5}))
 }
 
+func TestCellProtection(t *testing.T) {
+	f := NewFile()
+	unlocked, err := f.NewStyle(&Style{Protection: &Protection{Locked: false}})
+	assert.NoError(t, err)
+	hidden, err := f.NewStyle(&Style{Protection: &Protection{Hidden: true, Locked: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "B5", unlocked))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C1", "C1", hidden))
+	assert.NoError(t, f.ProtectSheet("Sheet1", &SheetProtectionOptions{SelectLockedCells: true, SelectUnlockedCells: true}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCellProtection.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestCellProtection.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string]*Protection{
+		"A1": {Locked: false}, "B5": {Locked: false}, "C1": {Hidden: true, Locked: true}, "D1": nil,
+	} {
+		style, err := f.GetCellStyleDetails("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, style.Protection, cell)
+	}
+	// Test get style with the default locked protection setting
+	f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, xlsxXf{ApplyProtection: boolPtr(true), Protection: &xlsxProtection{Hidden: boolPtr(true)}})
+	style, err := f.GetStyle(len(f.Styles.CellXfs.Xf) - 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &Protection{Hidden: true, Locked: true}, style.Protection)
+	assert.NoError(t, f.Close())
+}
+
 func TestGetStyle(t *testing.T) {
 	f := NewFile()
 	expected := &Style{
@@ -568,6 +837,18 @@ func TestGetStyle(t *testing.T) {
 	assert.Equal(t, expected.Protection, style.Protection)
 	assert.Equal(t, expected.NumFmt, style.NumFmt)
 
+	expected = &Style{
+		Font: &Font{
+			Family: "MS Gothic", Size: 11, VertAlign: "superscript",
+			Charset: intPtr(128), Condense: true, Extend: true,
+		},
+	}
+	styleID, err = f.NewStyle(expected)
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Font, style.Font)
+
 	expected = &Style{
 		Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"0000FF"}},
 	}
@@ -577,6 +858,26 @@ func TestGetStyle(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected.Fill, style.Fill)
 
+	// Test get style with linear gradient fill stops and angle
+	expected = &Style{
+		Fill: Fill{Type: "gradient", Angle: 45, Stops: []GradientStop{
+			{Position: 0, Color: "FFFFFF"}, {Position: 0.3, Color: "5B9BD5"}, {Position: 1, Color: "E0EBF5"},
+		}},
+	}
+	styleID, err = f.NewStyle(expected)
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Fill, style.Fill)
+	assert.Equal(t, &xlsxGradientFill{Degree: 45, Stop: []*xlsxGradientFillStop{
+		{Color: xlsxColor{RGB: "FFFFFFFF"}}, {Position: 0.3, Color: xlsxColor{RGB: "FF5B9BD5"}}, {Position: 1, Color: xlsxColor{RGB: "FFE0EBF5"}},
+	}}, f.Styles.Fills.Fill[f.Styles.Fills.Count-1].GradientFill)
+	// Test reuse the existing gradient fill
+	fillCount := f.Styles.Fills.Count
+	_, err = f.NewStyle(&Style{Fill: expected.Fill, NumFmt: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, fillCount, f.Styles.Fills.Count)
+
 	expected = &Style{NumFmt: 27}
 	styleID, err = f.NewStyle(expected)
 	assert.NoError(t, err)
@@ -598,6 +899,21 @@ func TestGetStyle(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected.NumFmt, style.NumFmt)
 
+	// Test get style with multiple custom number formats
+	customNumFmts := []string{"0.00%;[Red]-0.00%", "yyyy-mm-dd"}
+	var styleIDs []int
+	for i := range customNumFmts {
+		styleID, err = f.NewStyle(&Style{CustomNumFmt: &customNumFmts[i]})
+		assert.NoError(t, err)
+		styleIDs = append(styleIDs, styleID)
+	}
+	for i, styleID := range styleIDs {
+		style, err = f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, customNumFmts[i], *style.CustomNumFmt)
+		assert.Equal(t, i == 0, style.NegRed)
+	}
+
 	// Test get style with custom color index
 	f.Styles.Colors = &xlsxStyleColors{
 		IndexedColors: &xlsxIndexedColors{
@@ -617,3 +933,165 @@ func TestGetStyle(t *testing.T) {
 	assert.Nil(t, style)
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestCopyCellStyle(t *testing.T) {
+	f := NewFile()
+	customNumFmt := "0.00%"
+	boldStyle, err := f.NewStyle(&Style{
+		Font:         &Font{Bold: true, Family: "Arial", Size: 12, ColorTheme: intPtr(4)},
+		Fill:         Fill{Type: "pattern", Pattern: 1, Color: []string{"E0EBF5"}},
+		Border:       []Border{{Type: "left", Color: "0000FF", Style: 1}},
+		CustomNumFmt: &customNumFmt,
+	})
+	assert.NoError(t, err)
+	italicStyle, err := f.NewStyle(&Style{Font: &Font{Italic: true}, NumFmt: 14})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", boldStyle))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", italicStyle))
+	style, err := f.GetCellStyleDetails("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, customNumFmt, *style.CustomNumFmt)
+
+	// Test copy cell style in the same workbook with repeated source styles
+	assert.NoError(t, f.CopyCellStyle("Sheet1", "A1:B1", f, "Sheet1", "$A$3:$D$4"))
+	for cell, expected := range map[string]int{"A3": boldStyle, "B3": italicStyle, "C3": boldStyle, "D4": italicStyle} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, styleID)
+	}
+	// Test copy cell style with overlapped source and destination range
+	assert.NoError(t, f.CopyCellStyle("Sheet1", "A1:B1", f, "Sheet1", "B1:C1"))
+	for cell, expected := range map[string]int{"B1": boldStyle, "C1": italicStyle} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, styleID)
+	}
+
+	// Test copy cell style across workbooks
+	dst := NewFile()
+	_, err = dst.NewStyle(&Style{CustomNumFmt: stringPtr("yyyy")})
+	assert.NoError(t, err)
+	assert.NoError(t, f.CopyCellStyle("Sheet1", "A3:B3", dst, "Sheet1", "C2:F2"))
+	style, err = dst.GetCellStyleDetails("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, &Font{Bold: true, Family: "Arial", Size: 12, Color: "5B9BD5"}, style.Font)
+	assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{"E0EBF5"}}, style.Fill)
+	assert.Equal(t, []Border{{Type: "left", Color: "0000FF", Style: 1}}, style.Border)
+	assert.Equal(t, customNumFmt, *style.CustomNumFmt)
+	style, err = dst.GetCellStyleDetails("Sheet1", "F2")
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Italic)
+	assert.Equal(t, 14, style.NumFmt)
+	styleIDs := map[int]bool{}
+	for _, cell := range []string{"C2", "D2", "E2", "F2", "A1"} {
+		styleID, err := dst.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		styleIDs[styleID] = true
+	}
+	assert.Len(t, styleIDs, 3)
+
+	// Test copy cell style with invalid arguments
+	assert.Equal(t, ErrParameterRequired, f.CopyCellStyle("Sheet1", "A1", nil, "Sheet1", "A1"))
+	assert.Equal(t, ErrParameterInvalid, f.CopyCellStyle("Sheet1", "A1:B1:C1", dst, "Sheet1", "A1"))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.CopyCellStyle("Sheet1", "A", dst, "Sheet1", "A1"))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.CopyCellStyle("Sheet1", "A1", dst, "Sheet1", "A1:A"))
+	assert.EqualError(t, f.CopyCellStyle("SheetN", "A1", dst, "Sheet1", "A1"), "sheet SheetN does not exist")
+	assert.EqualError(t, f.CopyCellStyle("Sheet1", "A1", dst, "SheetN", "A1"), "sheet SheetN does not exist")
+	_, err = f.GetCellStyleDetails("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test copy cell style with unsupported charset style sheet
+	dst.Styles = nil
+	dst.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopyCellStyle("Sheet1", "A1", dst, "Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopyCellStyle("Sheet1", "A1", dst, "Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestNamedStyle(t *testing.T) {
+	f := NewFile()
+	// Test create style based on the built-in named cell style
+	goodStyle, err := f.NewStyle(&Style{NamedStyle: "Good"})
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxCellStyle{Name: "Good", XfID: 1, BuiltInID: intPtr(26)}, f.Styles.CellStyles.CellStyle[1])
+	assert.Equal(t, 2, f.Styles.CellStyleXfs.Count)
+	xf := f.Styles.CellXfs.Xf[goodStyle]
+	assert.Equal(t, 1, *xf.XfID)
+	assert.Equal(t, *f.Styles.CellStyleXfs.Xf[1].FontID, *xf.FontID)
+	assert.Equal(t, &xlsxColor{RGB: "FFC6EFCE"}, f.Styles.Fills.Fill[*xf.FillID].PatternFill.FgColor)
+	style, err := f.GetStyle(goodStyle)
+	assert.NoError(t, err)
+	assert.Equal(t, "Good", style.NamedStyle)
+	assert.Equal(t, "006100", style.Font.Color)
+	// Test create the same style should reuse the existing style
+	styleID, err := f.NewStyle(&Style{NamedStyle: "Good"})
+	assert.NoError(t, err)
+	assert.Equal(t, goodStyle, styleID)
+	assert.Len(t, f.Styles.CellStyles.CellStyle, 2)
+	// Test create style based on the named cell style with formatting
+	styleID, err = f.NewStyle(&Style{NamedStyle: "Good", NumFmt: 2, Alignment: &Alignment{Horizontal: "center"}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, goodStyle, styleID)
+	assert.Equal(t, *f.Styles.CellXfs.Xf[goodStyle].FillID, *f.Styles.CellXfs.Xf[styleID].FillID)
+	assert.Equal(t, 2, *f.Styles.CellXfs.Xf[styleID].NumFmtID)
+	assert.Len(t, f.Styles.CellStyles.CellStyle, 2)
+	// Test the style without named cell style should not reuse the style based
+	// on the named cell style
+	commaStyle, err := f.NewStyle(&Style{NamedStyle: "Comma"})
+	assert.NoError(t, err)
+	assert.Equal(t, 43, *f.Styles.CellXfs.Xf[commaStyle].NumFmtID)
+	styleID, err = f.NewStyle(&Style{NumFmt: 43})
+	assert.NoError(t, err)
+	assert.NotEqual(t, commaStyle, styleID)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Empty(t, style.NamedStyle)
+	// Test create the heading, title and accent built-in named cell styles
+	for _, name := range []string{"Heading 2", "Title", "40% - Accent3"} {
+		styleID, err = f.NewStyle(&Style{NamedStyle: name})
+		assert.NoError(t, err)
+		style, err = f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, name, style.NamedStyle)
+	}
+	assert.Equal(t, &xlsxBorder{Bottom: xlsxLine{Style: "thick", Color: &xlsxColor{Theme: intPtr(4), Tint: 0.499984740745262}}}, f.Styles.Borders.Border[f.Styles.Borders.Count-1])
+	assert.Equal(t, "Calibri Light", *f.Styles.Fonts.Font[f.Styles.Fonts.Count-2].Name.Val)
+	assert.Equal(t, &xlsxColor{Theme: intPtr(6), Tint: 0.599993896298105}, f.Styles.Fills.Fill[f.Styles.Fills.Count-1].PatternFill.FgColor)
+	styleID, err = f.NewStyle(&Style{NamedStyle: "Normal"})
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", goodStyle))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestNamedStyle.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test reference the named cell style in the existing workbook
+	f, err = OpenFile(filepath.Join("test", "TestNamedStyle.xlsx"))
+	assert.NoError(t, err)
+	styleID, err = f.NewStyle(&Style{NamedStyle: "Good"})
+	assert.NoError(t, err)
+	assert.Equal(t, goodStyle, styleID)
+	// Test copy cell style based on the named cell style across workbooks
+	dst := NewFile()
+	assert.NoError(t, f.CopyCellStyle("Sheet1", "A1", dst, "Sheet1", "B2"))
+	style, err = dst.GetCellStyleDetails("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Good", style.NamedStyle)
+	f.Styles.CellStyles.CellStyle[1].Name = "Custom"
+	assert.NoError(t, f.CopyCellStyle("Sheet1", "A1", dst, "Sheet1", "B3"))
+	style, err = dst.GetCellStyleDetails("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Empty(t, style.NamedStyle)
+	assert.NoError(t, f.Close())
+
+	// Test create style based on not exists named cell style
+	f = NewFile()
+	_, err = f.NewStyle(&Style{NamedStyle: "Unknown"})
+	assert.Equal(t, ErrParameterInvalid, err)
+	// Test create named cell style in the workbook without cell styles
+	f.Styles.CellStyles, f.Styles.CellStyleXfs = nil, nil
+	styleID, err = f.NewStyle(&Style{NamedStyle: "Bad"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, *f.Styles.CellXfs.Xf[styleID].XfID)
+	assert.Equal(t, "Bad", getCellStyleName(f.Styles, 0))
+}
@@ -129,7 +129,7 @@ func (f *File) AddTable(sheet string, table *Table) error {
 // worksheet name.
 func (f *File) GetTables(sheet string) ([]Table, error) {
 	var tables []Table
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		return tables, err
 	}
@@ -440,8 +440,7 @@ func (f *File) AutoFilter(sheet, rangeRef string, opts []AutoFilterOptions) erro
 	_ = sortCoordinates(coordinates)
 	// Correct reference range, such correct C1:B3 to B1:C3.
 	ref, _ := f.coordinatesToRangeRef(coordinates, true)
-	wb, err := f.workbookReader()
-	if err != nil {
+	if _, err = f.workbookReader(); err != nil {
 		return err
 	}
 	sheetID, err := f.GetSheetIndex(sheet)
@@ -449,31 +448,8 @@ func (f *File) AutoFilter(sheet, rangeRef string, opts []AutoFilterOptions) erro
 		return err
 	}
 	filterRange := fmt.Sprintf("'%s'!%s", sheet, ref)
-	d := xlsxDefinedName{
-		Name:         builtInDefinedNames[2],
-		Hidden:       true,
-		LocalSheetID: intPtr(sheetID),
-		Data:         filterRange,
-	}
-	if wb.DefinedNames == nil {
-		wb.DefinedNames = &xlsxDefinedNames{
-			DefinedName: []xlsxDefinedName{d},
-		}
-	} else {
-		var definedNameExists bool
-		for idx := range wb.DefinedNames.DefinedName {
-			definedName, localSheetID := wb.DefinedNames.DefinedName[idx], 0
-			if definedName.LocalSheetID != nil {
-				localSheetID = *definedName.LocalSheetID
-			}
-			if definedName.Name == builtInDefinedNames[2] && localSheetID == sheetID && definedName.Hidden {
-				wb.DefinedNames.DefinedName[idx].Data = filterRange
-				definedNameExists = true
-			}
-		}
-		if !definedNameExists {
-			wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, d)
-		}
+	if err = f.setSheetDefinedName(sheetID, builtInDefinedNames[2], filterRange, true); err != nil {
+		return err
 	}
 	columns := coordinates[2] - coordinates[0]
 	return f.autoFilter(sheet, ref, columns, coordinates[0], opts)
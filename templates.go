@@ -24,9 +24,12 @@ var (
 	NameSpaceDrawingML                      = xml.Attr{Name: xml.Name{Local: "a", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/main"}
 	NameSpaceDrawingMLA14                   = xml.Attr{Name: xml.Name{Local: "a14", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2010/main"}
 	NameSpaceDrawingMLChart                 = xml.Attr{Name: xml.Name{Local: "c", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/chart"}
+	NameSpaceDrawingMLChartEx               = xml.Attr{Name: xml.Name{Local: "cx", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2014/chartex"}
+	NameSpaceDrawingMLChartEx201510         = xml.Attr{Name: xml.Name{Local: "cx2", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2015/10/21/chartex"}
 	NameSpaceDrawingMLSlicer                = xml.Attr{Name: xml.Name{Local: "sle", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2010/slicer"}
 	NameSpaceDrawingMLSlicerX15             = xml.Attr{Name: xml.Name{Local: "sle15", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2012/slicer"}
 	NameSpaceDrawingMLSpreadSheet           = xml.Attr{Name: xml.Name{Local: "xdr", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"}
+	NameSpaceDrawingMLTimeslicer            = xml.Attr{Name: xml.Name{Local: "tsle", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2012/timeslicer"}
 	NameSpaceMacExcel2008Main               = xml.Attr{Name: xml.Name{Local: "mx", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/mac/excel/2008/main"}
 	NameSpaceSpreadSheet                    = xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: "http://schemas.openxmlformats.org/spreadsheetml/2006/main"}
 	NameSpaceSpreadSheetExcel2006Main       = xml.Attr{Name: xml.Name{Local: "xne", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/excel/2006/main"}
@@ -42,48 +45,88 @@ var (
 
 // Source relationship and namespace.
 const (
+	ContentTypeActiveX                            = "application/vnd.ms-office.activeX+xml"
+	ContentTypeActiveXBinary                      = "application/vnd.ms-office.activeX"
 	ContentTypeAddinMacro                         = "application/vnd.ms-excel.addin.macroEnabled.main+xml"
+	ContentTypeChartColorStyle                    = "application/vnd.ms-office.chartcolorstyle+xml"
+	ContentTypeChartEx                            = "application/vnd.ms-office.chartex+xml"
+	ContentTypeChartStyle                         = "application/vnd.ms-office.chartstyle+xml"
+	ContentTypeCustomProperties                   = "application/vnd.openxmlformats-officedocument.custom-properties+xml"
 	ContentTypeDrawing                            = "application/vnd.openxmlformats-officedocument.drawing+xml"
 	ContentTypeDrawingML                          = "application/vnd.openxmlformats-officedocument.drawingml.chart+xml"
 	ContentTypeMacro                              = "application/vnd.ms-excel.sheet.macroEnabled.main+xml"
+	ContentTypeOLEObject                          = "application/vnd.openxmlformats-officedocument.oleObject"
+	ContentTypePerson                             = "application/vnd.ms-excel.person+xml"
+	ContentTypeRDRichValue                        = "application/vnd.ms-excel.rdrichvalue+xml"
+	ContentTypeRDRichValueStructure               = "application/vnd.ms-excel.rdrichvaluestructure+xml"
+	ContentTypeRDRichValueTypes                   = "application/vnd.ms-excel.rdrichvaluetypes+xml"
 	ContentTypeRelationships                      = "application/vnd.openxmlformats-package.relationships+xml"
+	ContentTypeRichValueRel                       = "application/vnd.ms-excel.richvaluerel+xml"
 	ContentTypeSheetML                            = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"
+	ContentTypeSheetMetadata                      = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheetMetadata+xml"
 	ContentTypeSlicer                             = "application/vnd.ms-excel.slicer+xml"
 	ContentTypeSlicerCache                        = "application/vnd.ms-excel.slicerCache+xml"
 	ContentTypeSpreadSheetMLChartsheet            = "application/vnd.openxmlformats-officedocument.spreadsheetml.chartsheet+xml"
 	ContentTypeSpreadSheetMLComments              = "application/vnd.openxmlformats-officedocument.spreadsheetml.comments+xml"
 	ContentTypeSpreadSheetMLPivotCacheDefinition  = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheDefinition+xml"
+	ContentTypeSpreadSheetMLPivotCacheRecords     = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheRecords+xml"
 	ContentTypeSpreadSheetMLPivotTable            = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotTable+xml"
 	ContentTypeSpreadSheetMLSharedStrings         = "application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"
 	ContentTypeSpreadSheetMLTable                 = "application/vnd.openxmlformats-officedocument.spreadsheetml.table+xml"
 	ContentTypeSpreadSheetMLWorksheet             = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
+	ContentTypeTheme                              = "application/vnd.openxmlformats-officedocument.theme+xml"
 	ContentTypeTemplate                           = "application/vnd.openxmlformats-officedocument.spreadsheetml.template.main+xml"
 	ContentTypeTemplateMacro                      = "application/vnd.ms-excel.template.macroEnabled.main+xml"
+	ContentTypeThreadedComments                   = "application/vnd.ms-excel.threadedcomments+xml"
+	ContentTypeTimeline                           = "application/vnd.ms-excel.timeline+xml"
+	ContentTypeTimelineCache                      = "application/vnd.ms-excel.timelineCache+xml"
 	ContentTypeVBA                                = "application/vnd.ms-office.vbaProject"
 	ContentTypeVML                                = "application/vnd.openxmlformats-officedocument.vmlDrawing"
+	NameSpaceActiveX                              = "http://schemas.microsoft.com/office/2006/activeX"
+	NameSpaceCustomProperties                     = "http://schemas.openxmlformats.org/officeDocument/2006/custom-properties"
 	NameSpaceDrawingMLMain                        = "http://schemas.openxmlformats.org/drawingml/2006/main"
 	NameSpaceDublinCore                           = "http://purl.org/dc/elements/1.1/"
 	NameSpaceDublinCoreMetadataInitiative         = "http://purl.org/dc/dcmitype/"
 	NameSpaceDublinCoreTerms                      = "http://purl.org/dc/terms/"
 	NameSpaceExtendedProperties                   = "http://schemas.openxmlformats.org/officeDocument/2006/extended-properties"
+	NameSpaceSpreadSheetRichData                  = "http://schemas.microsoft.com/office/spreadsheetml/2017/richdata"
+	NameSpaceSpreadSheetThreadedComments          = "http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"
 	NameSpaceXML                                  = "http://www.w3.org/XML/1998/namespace"
 	NameSpaceXMLSchemaInstance                    = "http://www.w3.org/2001/XMLSchema-instance"
+	SourceRelationshipActiveXControl              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/control"
+	SourceRelationshipActiveXControlBinary        = "http://schemas.microsoft.com/office/2006/relationships/activeXControlBinary"
 	SourceRelationshipChart                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
+	SourceRelationshipChartColorStyle             = "http://schemas.microsoft.com/office/2011/relationships/chartColorStyle"
+	SourceRelationshipChartEx                     = "http://schemas.microsoft.com/office/2014/relationships/chartEx"
+	SourceRelationshipChartStyle                  = "http://schemas.microsoft.com/office/2011/relationships/chartStyle"
 	SourceRelationshipChartsheet                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartsheet"
 	SourceRelationshipComments                    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments"
+	SourceRelationshipCustomProperties            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/custom-properties"
 	SourceRelationshipDialogsheet                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/dialogsheet"
 	SourceRelationshipDrawingML                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/drawing"
 	SourceRelationshipDrawingVML                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/vmlDrawing"
 	SourceRelationshipExtendProperties            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/extended-properties"
 	SourceRelationshipHyperLink                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
 	SourceRelationshipImage                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
+	SourceRelationshipOLEObject                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/oleObject"
 	SourceRelationshipOfficeDocument              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	SourceRelationshipPerson                      = "http://schemas.microsoft.com/office/2017/10/relationships/person"
 	SourceRelationshipPivotCache                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheDefinition"
+	SourceRelationshipPivotCacheRecords           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheRecords"
 	SourceRelationshipPivotTable                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotTable"
+	SourceRelationshipRDRichValue                 = "http://schemas.microsoft.com/office/2017/06/relationships/rdRichValue"
+	SourceRelationshipRDRichValueStructure        = "http://schemas.microsoft.com/office/2017/06/relationships/rdRichValueStructure"
+	SourceRelationshipRDRichValueTypes            = "http://schemas.microsoft.com/office/2017/06/relationships/rdRichValueTypes"
+	SourceRelationshipRichValueRel                = "http://schemas.microsoft.com/office/2022/10/relationships/richValueRel"
 	SourceRelationshipSharedStrings               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
+	SourceRelationshipSheetMetadata               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sheetMetadata"
 	SourceRelationshipSlicer                      = "http://schemas.microsoft.com/office/2007/relationships/slicer"
 	SourceRelationshipSlicerCache                 = "http://schemas.microsoft.com/office/2007/relationships/slicerCache"
 	SourceRelationshipTable                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/table"
+	SourceRelationshipTheme                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme"
+	SourceRelationshipThreadedComment             = "http://schemas.microsoft.com/office/2017/10/relationships/threadedComment"
+	SourceRelationshipTimeline                    = "http://schemas.microsoft.com/office/2011/relationships/timeline"
+	SourceRelationshipTimelineCache               = "http://schemas.microsoft.com/office/2011/relationships/timelineCache"
 	SourceRelationshipVBAProject                  = "http://schemas.microsoft.com/office/2006/relationships/vbaProject"
 	SourceRelationshipWorkSheet                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
 	StrictNameSpaceDocumentPropertiesVariantTypes = "http://purl.oclc.org/ooxml/officeDocument/docPropsVTypes"
@@ -114,6 +157,7 @@ const (
 	ExtURIPivotCachesX15                 = "{841E416B-1EF1-43b6-AB56-02D37102CBD5}"
 	ExtURIPivotTableReferences           = "{983426D0-5260-488c-9760-48F4B6AC55F4}"
 	ExtURIProtectedRanges                = "{FC87AEE6-9EDD-4A0A-B7FB-166176984837}"
+	ExtURIRichValueBlock                 = "{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"
 	ExtURISlicerCacheDefinition          = "{2F2917AC-EB37-4324-AD4E-5DD8C200BD13}"
 	ExtURISlicerCacheHideItemsWithNoData = "{470722E0-AACD-4C17-9CDC-17EF765DBC7E}"
 	ExtURISlicerCachesX14                = "{BBE1A952-AA13-448e-AADC-164F8A28A991}"
@@ -175,6 +219,7 @@ const (
 	MaxSheetNameLength   = 31
 	MinColumns           = 1
 	MinFontSize          = 1
+	PictureSizeLimit     = 1 << 25
 	StreamChunkSize      = 1 << 24
 	TotalCellChars       = 32767
 	TotalRows            = 1048576
@@ -187,10 +232,13 @@ const (
 	pivotTableVersion           = 3
 	pivotTableRefreshedVersion  = 8
 	defaultDrawingScale         = 1.0
+	defaultImageDPI             = 96.0
 	defaultChartDimensionWidth  = 480
 	defaultChartDimensionHeight = 260
 	defaultSlicerWidth          = 200
 	defaultSlicerHeight         = 200
+	defaultTimelineWidth        = 320
+	defaultTimelineHeight       = 140
 	defaultChartLegendPosition  = "bottom"
 	defaultChartShowBlanksAs    = "gap"
 	defaultShapeSize            = 160
@@ -218,16 +266,18 @@ const (
 )
 
 const (
-	defaultXMLPathContentTypes  = "[Content_Types].xml"
-	defaultXMLPathDocPropsApp   = "docProps/app.xml"
-	defaultXMLPathDocPropsCore  = "docProps/core.xml"
-	defaultXMLPathCalcChain     = "xl/calcChain.xml"
-	defaultXMLPathSharedStrings = "xl/sharedStrings.xml"
-	defaultXMLPathStyles        = "xl/styles.xml"
-	defaultXMLPathTheme         = "xl/theme/theme1.xml"
-	defaultXMLPathWorkbook      = "xl/workbook.xml"
-	defaultXMLPathWorkbookRels  = "xl/_rels/workbook.xml.rels"
-	defaultTempFileSST          = "sharedStrings"
+	defaultXMLPathContentTypes   = "[Content_Types].xml"
+	defaultXMLPathDocPropsApp    = "docProps/app.xml"
+	defaultXMLPathDocPropsCore   = "docProps/core.xml"
+	defaultXMLPathDocPropsCustom = "docProps/custom.xml"
+	defaultXMLPathCalcChain      = "xl/calcChain.xml"
+	defaultXMLPathSharedStrings  = "xl/sharedStrings.xml"
+	defaultXMLPathStyles         = "xl/styles.xml"
+	defaultXMLPathTheme          = "xl/theme/theme1.xml"
+	defaultXMLPathWorkbook       = "xl/workbook.xml"
+	defaultXMLPathWorkbookRels   = "xl/_rels/workbook.xml.rels"
+	defaultTempFileSST           = "sharedStrings"
+	defaultSharedStringsCache    = 1 << 14
 )
 
 // IndexedColorMapping is the table of default mappings from indexed color value
@@ -270,6 +320,37 @@ var supportedContentTypes = map[string]string{
 // supportedUnderlineTypes defined supported underline types.
 var supportedUnderlineTypes = []string{"none", "single", "double"}
 
+// supportedPhoneticTypes defined supported phonetic character types.
+var supportedPhoneticTypes = []string{"halfwidthKatakana", "fullwidthKatakana", "Hiragana", "noConversion"}
+
+// supportedPhoneticAlignments defined supported phonetic text alignments.
+var supportedPhoneticAlignments = []string{"noControl", "left", "center", "distributed"}
+
+// supportedCalcMode defined supported formula calculation modes.
+var supportedCalcMode = []string{"manual", "auto", "autoNoTable"}
+
+// supportedRefMode defined supported formula reference modes.
+var supportedRefMode = []string{"A1", "R1C1"}
+
+// supportedDynamicRangeFunctions defined supported functions for building the
+// dynamic range.
+var supportedDynamicRangeFunctions = []string{"OFFSET", "INDEX"}
+
+// supportedVertAlignTypes defined supported font vertical alignment types.
+var supportedVertAlignTypes = []string{"baseline", "superscript", "subscript"}
+
+// supportedConnectorShapeTypes defined supported connector shape types, which
+// will be added as the connection shape in the drawing.
+var supportedConnectorShapeTypes = []string{
+	"bentConnector2", "bentConnector3", "bentConnector4", "bentConnector5",
+	"curvedConnector2", "curvedConnector3", "curvedConnector4", "curvedConnector5",
+	"straightConnector1",
+}
+
+// supportedDrawingLineEndTypes defined supported line end types of the shape
+// in drawing.
+var supportedDrawingLineEndTypes = []string{"arrow", "diamond", "none", "oval", "stealth", "triangle"}
+
 // supportedDrawingUnderlineTypes defined supported underline types in drawing
 // markup language.
 var supportedDrawingUnderlineTypes = []string{
@@ -277,12 +358,43 @@ var supportedDrawingUnderlineTypes = []string{
 	"wavyDbl",
 }
 
+// supportedChartErrorBarsDirection defined supported error bars directions of
+// the chart series.
+var supportedChartErrorBarsDirection = []string{"both", "minus", "plus"}
+
+// supportedChartMarkerSymbol defined supported marker symbols of the chart
+// series.
+var supportedChartMarkerSymbol = []string{"auto", "circle", "dash", "diamond", "dot", "none", "picture", "plus", "square", "star", "triangle", "x"}
+
+// supportedChartThemeColors defined supported theme colors of the chart, which
+// could be used in the chart fill and line colors instead of the RGB color.
+var supportedChartThemeColors = []string{
+	"accent1", "accent2", "accent3", "accent4", "accent5", "accent6",
+	"bg1", "bg2", "dk1", "dk2", "folHlink", "hlink", "lt1", "lt2", "tx1", "tx2",
+}
+
 // supportedPositioning defined supported positioning types.
 var supportedPositioning = []string{"absolute", "oneCell", "twoCell"}
 
+// supportedListBoxSelectionTypes defined supported selection types of the list
+// box form control.
+var supportedListBoxSelectionTypes = []string{"Single", "Multi", "Extend"}
+
+// supportedDrawingUnits defined supported units of measurement for the size
+// and offset of the graph objects, and the number of EMUs of each unit.
+var supportedDrawingUnits = map[string]float64{
+	"cm": 360000, "emu": 1, "in": 914400, "mm": 36000, "pt": 12700, "px": 9525,
+}
+
+// supportedTimelineLevels defined supported timeline time levels, the index of
+// each level is the level value in the timeline.
+var supportedTimelineLevels = []string{"years", "quarters", "months", "days"}
+
 // builtInDefinedNames defined built-in defined names are built with a _xlnm prefix.
 var builtInDefinedNames = []string{"_xlnm.Print_Area", "_xlnm.Print_Titles", "_xlnm._FilterDatabase"}
 
+const templateRichValueTypes = `<rvTypesInfo xmlns="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata2" xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" mc:Ignorable="x" xmlns:x="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><global><keyFlags><key name="_Self"><flag name="ExcludeFromFile" value="1"/><flag name="ExcludeFromCalcComparison" value="1"/></key><key name="_DisplayString"><flag name="ExcludeFromCalcComparison" value="1"/></key><key name="_Flags"><flag name="ExcludeFromCalcComparison" value="1"/></key><key name="_Format"><flag name="ExcludeFromCalcComparison" value="1"/></key><key name="_SubLabel"><flag name="ExcludeFromCalcComparison" value="1"/></key><key name="_Attribution"><flag name="ExcludeFromCalcComparison" value="1"/></key><key name="_Icon"><flag name="ExcludeFromCalcComparison" value="1"/></key><key name="_Display"><flag name="ExcludeFromCalcComparison" value="1"/></key><key name="_CanonicalPropertyNames"><flag name="ExcludeFromCalcComparison" value="1"/></key><key name="_ClassificationId"><flag name="ExcludeFromCalcComparison" value="1"/></key></keyFlags></global></rvTypesInfo>`
+
 const templateDocpropsApp = `<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties" xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"><TotalTime>0</TotalTime><Application>Go Excelize</Application></Properties>`
 
 const templateContentTypes = `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Override PartName="/xl/theme/theme1.xml" ContentType="application/vnd.openxmlformats-officedocument.theme+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/docProps/app.xml" ContentType="application/vnd.openxmlformats-officedocument.extended-properties+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/></Types>`
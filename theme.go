@@ -0,0 +1,185 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"encoding/hex"
+	"encoding/xml"
+	"strings"
+)
+
+// themeColorCount defined the number of the colors in the color scheme of the
+// theme.
+const themeColorCount = 12
+
+// GetTheme provides a function to get the theme settings of the workbook,
+// include the theme name, colors of the color scheme and the Latin typeface
+// of the headings and body font.
+func (f *File) GetTheme() (ThemeOptions, error) {
+	var opts ThemeOptions
+	if f.Theme == nil {
+		return opts, nil
+	}
+	opts.Name = f.Theme.Name
+	for _, clr := range f.Theme.themeColors() {
+		opts.Colors = append(opts.Colors, clr.getRGB())
+	}
+	if font := f.Theme.ThemeElements.FontScheme.MajorFont.Latin; font != nil {
+		opts.MajorFont = font.Typeface
+	}
+	if font := f.Theme.ThemeElements.FontScheme.MinorFont.Latin; font != nil {
+		opts.MinorFont = font.Typeface
+	}
+	return opts, nil
+}
+
+// SetTheme provides a function to set the theme settings of the workbook,
+// which could be used to apply the corporate color palette and fonts. The
+// colors and fonts which not specified will be kept. Note that the theme
+// colors and fonts only take effect on the styles, charts and shapes which
+// reference the theme. For example, set the accent colors and the body font
+// of the workbook:
+//
+//	err := f.SetTheme(&excelize.ThemeOptions{
+//	    Name: "Corporate",
+//	    Colors: []string{
+//	        "", "", "", "",
+//	        "1F4E79", "C00000", "7F7F7F", "FFC000", "2E75B6", "548235",
+//	    },
+//	    MinorFont: "Arial",
+//	})
+func (f *File) SetTheme(opts *ThemeOptions) error {
+	if opts == nil {
+		return ErrParameterRequired
+	}
+	if len(opts.Colors) > themeColorCount {
+		return ErrParameterInvalid
+	}
+	for _, color := range opts.Colors {
+		if color != "" && !isRGBColor(color) {
+			return ErrParameterInvalid
+		}
+	}
+	if err := f.prepareTheme(); err != nil {
+		return err
+	}
+	if opts.Name != "" {
+		f.Theme.Name = opts.Name
+		f.Theme.ThemeElements.ClrScheme.Name = opts.Name
+		f.Theme.ThemeElements.FontScheme.Name = opts.Name
+	}
+	colors := f.Theme.themeColors()
+	for idx, color := range opts.Colors {
+		if color != "" {
+			*colors[idx] = newThemeColor(color)
+		}
+	}
+	for _, font := range []struct {
+		typeface   string
+		collection *decodeFontCollection
+	}{
+		{opts.MajorFont, &f.Theme.ThemeElements.FontScheme.MajorFont},
+		{opts.MinorFont, &f.Theme.ThemeElements.FontScheme.MinorFont},
+	} {
+		if font.typeface != "" {
+			font.collection.Latin = &xlsxCTTextFont{Typeface: font.typeface}
+		}
+	}
+	return nil
+}
+
+// GetThemeColor provides a function to get the RGB color of the theme color
+// by given theme color index. The theme color index is an integer between 0
+// and 11, the same as the index used in 'Font.ColorTheme'. For example, get
+// the color of accent 1:
+//
+//	color, err := f.GetThemeColor(4)
+func (f *File) GetThemeColor(index int) (string, error) {
+	if index < 0 || index >= themeColorCount {
+		return "", ErrParameterInvalid
+	}
+	if f.Theme == nil {
+		return "", nil
+	}
+	return f.Theme.themeColors()[index].getRGB(), nil
+}
+
+// SetThemeColor provides a function to set the RGB color of the theme color by
+// given theme color index and color in 'RRGGBB' hexadecimal notation. The
+// styles which reference the theme color will be changed accordingly. For
+// example, set the color of accent 1 to blue:
+//
+//	err := f.SetThemeColor(4, "0000FF")
+func (f *File) SetThemeColor(index int, color string) error {
+	if index < 0 || index >= themeColorCount || color == "" {
+		return ErrParameterInvalid
+	}
+	colors := make([]string, index+1)
+	colors[index] = color
+	return f.SetTheme(&ThemeOptions{Colors: colors})
+}
+
+// prepareTheme provides a function to create the default theme of the
+// workbook if not exist, and track the theme was modified.
+func (f *File) prepareTheme() error {
+	f.trackPart(defaultXMLPathTheme, false, false)
+	if f.Theme != nil {
+		return nil
+	}
+	f.Pkg.Store(defaultXMLPathTheme, []byte(xml.Header+templateTheme))
+	theme, err := f.themeReader()
+	if err != nil {
+		return err
+	}
+	f.Theme = theme
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipTheme, strings.TrimPrefix(defaultXMLPathTheme, "xl/"), "")
+	return f.addContentTypePart(0, "theme")
+}
+
+// themeColors provides a function to get the colors of the color scheme in
+// the theme color index order.
+func (theme *decodeTheme) themeColors() []*decodeCTColor {
+	clrScheme := &theme.ThemeElements.ClrScheme
+	return []*decodeCTColor{
+		&clrScheme.Lt1, &clrScheme.Dk1, &clrScheme.Lt2, &clrScheme.Dk2,
+		&clrScheme.Accent1, &clrScheme.Accent2, &clrScheme.Accent3,
+		&clrScheme.Accent4, &clrScheme.Accent5, &clrScheme.Accent6,
+		&clrScheme.Hlink, &clrScheme.FolHlink,
+	}
+}
+
+// getRGB provides a function to get the RGB color of the theme color, the
+// empty string will be returned if the color is not specified by RGB or
+// system color.
+func (c *decodeCTColor) getRGB() string {
+	if c.SrgbClr != nil && c.SrgbClr.Val != nil {
+		return strings.ToUpper(*c.SrgbClr.Val)
+	}
+	if c.SysClr != nil {
+		return strings.ToUpper(c.SysClr.LastClr)
+	}
+	return ""
+}
+
+// newThemeColor provides a function to create the theme color by given color
+// in 'RRGGBB' hexadecimal notation.
+func newThemeColor(color string) decodeCTColor {
+	return decodeCTColor{SrgbClr: &attrValString{Val: stringPtr(strings.TrimPrefix(strings.ToUpper(color), "#"))}}
+}
+
+// isRGBColor provides a function to check if the given string is a color in
+// 'RRGGBB' hexadecimal notation.
+func isRGBColor(color string) bool {
+	color = strings.TrimPrefix(color, "#")
+	_, err := hex.DecodeString(color)
+	return len(color) == 6 && err == nil
+}
@@ -0,0 +1,107 @@
+package excelize
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetTheme(t *testing.T) {
+	f := NewFile()
+	opts, err := f.GetTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, ThemeOptions{
+		Name: "Office Theme",
+		Colors: []string{
+			"FFFFFF", "000000", "E7E6E6", "44546A", "5B9BD5", "ED7D31",
+			"A5A5A5", "FFC000", "4472C4", "70AD47", "0563C1", "954F72",
+		},
+		MajorFont: "Calibri Light",
+		MinorFont: "Calibri",
+	}, opts)
+	assert.NoError(t, f.SetTheme(&ThemeOptions{
+		Name:      "Corporate",
+		Colors:    []string{"#f2f2f2", "", "", "", "1f4e79"},
+		MajorFont: "Arial Black",
+		MinorFont: "Arial",
+	}))
+	style, err := f.NewStyle(&Style{
+		Font: &Font{ColorTheme: intPtr(4)},
+		Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFFFF"}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	// Test the theme colors should be resolved by the modified theme
+	assert.Equal(t, "F2F2F2", f.getThemeColor(&xlsxColor{Theme: intPtr(0)}))
+	assert.Equal(t, "1F4E79", f.getThemeColor(&xlsxColor{Theme: intPtr(4)}))
+	assert.Equal(t, "954F72", f.getThemeColor(&xlsxColor{Theme: intPtr(11)}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetTheme.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestSetTheme.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "Corporate", opts.Name)
+	assert.Equal(t, []string{"F2F2F2", "000000", "E7E6E6", "44546A", "1F4E79"}, opts.Colors[:5])
+	assert.Equal(t, "Arial Black", opts.MajorFont)
+	assert.Equal(t, "Arial", opts.MinorFont)
+	assert.Equal(t, "Corporate", f.Theme.ThemeElements.FontScheme.Name)
+	assert.Len(t, f.Theme.ThemeElements.FontScheme.MinorFont.Font, 30)
+	// Test set theme with invalid options
+	assert.Equal(t, ErrParameterRequired, f.SetTheme(nil))
+	assert.Equal(t, ErrParameterInvalid, f.SetTheme(&ThemeOptions{Colors: make([]string, 13)}))
+	for _, color := range []string{"FFF", "GGGGGG", "#FF00000"} {
+		assert.Equal(t, ErrParameterInvalid, f.SetTheme(&ThemeOptions{Colors: []string{color}}))
+	}
+	assert.NoError(t, f.Close())
+
+	// Test set theme in the workbook without theme
+	f = NewFile()
+	f.Theme = nil
+	f.Pkg.Delete(defaultXMLPathTheme)
+	opts, err = f.GetTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, ThemeOptions{}, opts)
+	assert.NoError(t, f.SetTheme(&ThemeOptions{MinorFont: "Arial"}))
+	opts, err = f.GetTheme()
+	assert.NoError(t, err)
+	assert.Equal(t, "Arial", opts.MinorFont)
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, content.Overrides, xlsxOverride{PartName: "/xl/theme/theme1.xml", ContentType: ContentTypeTheme})
+	assert.NoError(t, f.Close())
+
+	// Test set theme in the workbook without theme with unsupported charset
+	// content types
+	f = NewFile()
+	f.Theme, f.ContentTypes = nil, nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetTheme(&ThemeOptions{MinorFont: "Arial"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetThemeColor(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetThemeColor(5, "c00000"))
+	color, err := f.GetThemeColor(5)
+	assert.NoError(t, err)
+	assert.Equal(t, "C00000", color)
+	color, err = f.GetThemeColor(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "FFFFFF", color)
+	// Test get and set theme color with invalid theme color index
+	for _, idx := range []int{-1, 12} {
+		_, err = f.GetThemeColor(idx)
+		assert.Equal(t, ErrParameterInvalid, err)
+		assert.Equal(t, ErrParameterInvalid, f.SetThemeColor(idx, "000000"))
+	}
+	assert.Equal(t, ErrParameterInvalid, f.SetThemeColor(0, ""))
+	// Test get theme color in the workbook without theme
+	f.Theme = nil
+	color, err = f.GetThemeColor(0)
+	assert.NoError(t, err)
+	assert.Empty(t, color)
+	assert.NoError(t, f.Close())
+}
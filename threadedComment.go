@@ -0,0 +1,379 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// threadedCommentTimeLayout defined the layout of the date time of the
+// threaded comments.
+const threadedCommentTimeLayout = "2006-01-02T15:04:05.00"
+
+// AddThreadedComment provides the method to add a threaded comment (modern
+// comment) with replies in a worksheet by given worksheet name and threaded
+// comment options. If the cell already has a threaded comment, the comment and
+// its replies will be added as the replies of the existing thread. A legacy
+// comment with the thread content will also be added for the spreadsheet
+// applications which do not support the threaded comments. For example, add a
+// resolved threaded comment with a reply in Sheet1!A1:
+//
+//	err := f.AddThreadedComment("Sheet1", excelize.ThreadedComment{
+//	    Cell:   "A1",
+//	    Author: "Excelize",
+//	    Text:   "Please check the total.",
+//	    Done:   true,
+//	    Replies: []excelize.ThreadedComment{
+//	        {Author: "Reviewer", Text: "Fixed."},
+//	    },
+//	})
+//
+// The optional parameters "UserID" and "ProviderID" specifies the identity of
+// the author, the author name will be used as the user ID, and "None" will be
+// used as the provider ID by default. The optional parameter "DateTime"
+// specifies the date time of the comment, the current time will be used by
+// default.
+func (f *File) AddThreadedComment(sheet string, opts ThreadedComment) error {
+	if _, _, err := CellNameToCoordinates(opts.Cell); err != nil {
+		return err
+	}
+	var thread []xlsxThreadedComment
+	if err := f.updateThreadedComments(sheet, func(tcs *xlsxThreadedComments, persons *xlsxPersonList) error {
+		var parentID string
+		for i, tc := range tcs.ThreadedComment {
+			if tc.Ref == opts.Cell && tc.ParentID == "" {
+				parentID = tc.ID
+				tcs.ThreadedComment[i].Done = opts.Done
+			}
+		}
+		for _, comment := range append([]ThreadedComment{opts}, opts.Replies...) {
+			tc := xlsxThreadedComment{
+				Ref:      opts.Cell,
+				DT:       comment.DateTime.Format(threadedCommentTimeLayout),
+				PersonID: persons.getPersonID(comment),
+				ID:       newGUID(),
+				ParentID: parentID,
+				Text:     comment.Text,
+			}
+			if comment.DateTime.IsZero() {
+				tc.DT = time.Now().UTC().Format(threadedCommentTimeLayout)
+			}
+			if parentID == "" {
+				parentID, tc.Done = tc.ID, opts.Done
+			}
+			tcs.ThreadedComment = append(tcs.ThreadedComment, tc)
+		}
+		for _, tc := range tcs.ThreadedComment {
+			if tc.ID == parentID || tc.ParentID == parentID {
+				thread = append(thread, tc)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return f.setThreadedCommentPlaceholder(sheet, thread)
+}
+
+// GetThreadedComments retrieves all threaded comments with replies in a
+// worksheet by given worksheet name.
+func (f *File) GetThreadedComments(sheet string) ([]ThreadedComment, error) {
+	var comments []ThreadedComment
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return comments, ErrSheetNotExist{sheet}
+	}
+	tcs, err := f.threadedCommentsReader(f.getSheetThreadedComments(sheetXMLPath))
+	if err != nil {
+		return comments, err
+	}
+	personsXML, err := f.getWorkbookPartPath(SourceRelationshipPerson)
+	if err != nil {
+		return comments, err
+	}
+	persons, err := f.personListReader(personsXML)
+	if err != nil {
+		return comments, err
+	}
+	threads := map[string]int{}
+	for _, tc := range tcs.ThreadedComment {
+		comment := ThreadedComment{ID: tc.ID, Cell: tc.Ref, Text: tc.Text, Done: tc.Done}
+		comment.DateTime, _ = time.Parse("2006-01-02T15:04:05", tc.DT)
+		for _, person := range persons.Person {
+			if person.ID == tc.PersonID {
+				comment.Author, comment.UserID, comment.ProviderID = person.DisplayName, person.UserID, person.ProviderID
+			}
+		}
+		if idx, ok := threads[tc.ParentID]; ok && tc.ParentID != "" {
+			comments[idx].Replies = append(comments[idx].Replies, comment)
+			continue
+		}
+		threads[tc.ID] = len(comments)
+		comments = append(comments, comment)
+	}
+	return comments, err
+}
+
+// DeleteThreadedComment provides the method to delete the threaded comment
+// with replies in a worksheet by given worksheet name and cell reference, the
+// legacy comment of the thread will also be deleted. For example, delete the
+// threaded comment in Sheet1!$A$1:
+//
+//	err := f.DeleteThreadedComment("Sheet1", "A1")
+func (f *File) DeleteThreadedComment(sheet, cell string) error {
+	var deleted bool
+	if err := f.updateThreadedComments(sheet, func(tcs *xlsxThreadedComments, persons *xlsxPersonList) error {
+		var threads []string
+		var comments []xlsxThreadedComment
+		for _, tc := range tcs.ThreadedComment {
+			if (tc.Ref == cell && tc.ParentID == "") || inStrSlice(threads, tc.ParentID, true) != -1 {
+				threads = append(threads, tc.ID)
+				continue
+			}
+			comments = append(comments, tc)
+		}
+		tcs.ThreadedComment, deleted = comments, len(threads) > 0
+		return nil
+	}); err != nil || !deleted {
+		return err
+	}
+	return f.DeleteComment(sheet, cell)
+}
+
+// ConvertCommentsToThreadedComments provides the method to convert all legacy
+// comments (notes) in a worksheet to the threaded comments by given worksheet
+// name. The author and text of the legacy comment will be used as the author
+// and text of the threaded comment. For example, convert the comments in
+// Sheet1:
+//
+//	err := f.ConvertCommentsToThreadedComments("Sheet1")
+func (f *File) ConvertCommentsToThreadedComments(sheet string) error {
+	return f.updateThreadedComments(sheet, func(tcs *xlsxThreadedComments, persons *xlsxPersonList) error {
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		commentsXML := f.getSheetComments(filepath.Base(sheetXMLPath))
+		if !strings.HasPrefix(commentsXML, "/") {
+			commentsXML = "xl" + strings.TrimPrefix(commentsXML, "..")
+		}
+		commentsXML = strings.TrimPrefix(commentsXML, "/")
+		cmts, err := f.commentsReader(commentsXML)
+		if err != nil || cmts == nil {
+			return err
+		}
+		for i, cmt := range cmts.CommentList.Comment {
+			var author, text string
+			if cmt.AuthorID < len(cmts.Authors.Author) {
+				author = cmts.Authors.Author[cmt.AuthorID]
+			}
+			if strings.HasPrefix(author, "tc=") {
+				continue
+			}
+			if cmt.Text.T != nil {
+				text += *cmt.Text.T
+			}
+			for _, run := range cmt.Text.R {
+				if run.T != nil {
+					text += run.T.Val
+				}
+			}
+			tc := xlsxThreadedComment{
+				Ref:      cmt.Ref,
+				DT:       time.Now().UTC().Format(threadedCommentTimeLayout),
+				PersonID: persons.getPersonID(ThreadedComment{Author: author}),
+				ID:       newGUID(),
+				Text:     text,
+			}
+			tcs.ThreadedComment = append(tcs.ThreadedComment, tc)
+			cmts.Authors.Author = append(cmts.Authors.Author, "tc="+tc.ID)
+			cmts.CommentList.Comment[i].AuthorID = len(cmts.Authors.Author) - 1
+			cmts.CommentList.Comment[i].Text = xlsxText{T: stringPtr(threadedCommentPlaceholder([]xlsxThreadedComment{tc}))}
+		}
+		f.Comments[commentsXML] = cmts
+		return err
+	})
+}
+
+// updateThreadedComments provides a function to update the threaded comments
+// of the worksheet and the person list of the workbook by given worksheet
+// name and update function, the parts will be created if not exist.
+func (f *File) updateThreadedComments(sheet string, fn func(tcs *xlsxThreadedComments, persons *xlsxPersonList) error) error {
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return ErrSheetNotExist{sheet}
+	}
+	threadedCommentsXML := f.getSheetThreadedComments(sheetXMLPath)
+	tcs, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return err
+	}
+	personsXML, err := f.getWorkbookPartPath(SourceRelationshipPerson)
+	if err != nil {
+		return err
+	}
+	persons, err := f.personListReader(personsXML)
+	if err != nil {
+		return err
+	}
+	if err = fn(tcs, persons); err != nil {
+		return err
+	}
+	if threadedCommentsXML == "" {
+		if len(tcs.ThreadedComment) == 0 {
+			return err
+		}
+		threadedCommentsID := f.countThreadedComments() + 1
+		threadedCommentsXML = "xl/threadedComments/threadedComment" + strconv.Itoa(threadedCommentsID) + ".xml"
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+		f.addRels(sheetRels, SourceRelationshipThreadedComment, "../threadedComments/threadedComment"+strconv.Itoa(threadedCommentsID)+".xml", "")
+		if err = f.addContentTypePart(threadedCommentsID, "threadedComment"); err != nil {
+			return err
+		}
+	}
+	if personsXML == "" {
+		personsXML = "xl/persons/person.xml"
+		f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPerson, "persons/person.xml", "")
+		if err = f.addContentTypePart(0, "person"); err != nil {
+			return err
+		}
+	}
+	output, _ := xml.Marshal(tcs)
+	f.saveFileList(threadedCommentsXML, output)
+	output, _ = xml.Marshal(persons)
+	f.saveFileList(personsXML, output)
+	return err
+}
+
+// setThreadedCommentPlaceholder provides a function to set the legacy comment
+// with the content of the given thread, which will be displayed in the
+// spreadsheet applications which do not support the threaded comments.
+func (f *File) setThreadedCommentPlaceholder(sheet string, thread []xlsxThreadedComment) error {
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	commentsXML := f.getSheetComments(filepath.Base(sheetXMLPath))
+	if !strings.HasPrefix(commentsXML, "/") {
+		commentsXML = "xl" + strings.TrimPrefix(commentsXML, "..")
+	}
+	commentsXML = strings.TrimPrefix(commentsXML, "/")
+	cmts, err := f.commentsReader(commentsXML)
+	if err != nil {
+		return err
+	}
+	author, text := "tc="+thread[0].ID, threadedCommentPlaceholder(thread)
+	if cmts != nil {
+		for i, cmt := range cmts.CommentList.Comment {
+			if cmt.AuthorID < len(cmts.Authors.Author) && cmts.Authors.Author[cmt.AuthorID] == author {
+				cmts.CommentList.Comment[i].Text = xlsxText{T: stringPtr(text)}
+				return err
+			}
+		}
+	}
+	return f.AddComment(sheet, Comment{Cell: thread[0].Ref, Author: author, Text: text})
+}
+
+// threadedCommentPlaceholder provides a function to get the text of the
+// legacy comment by given thread.
+func threadedCommentPlaceholder(thread []xlsxThreadedComment) string {
+	text := "[Threaded comment]\n\nYour version of Excel allows you to read this threaded comment; however, any edits to it will get removed if the file is opened in a newer version of Excel. Learn more: https://go.microsoft.com/fwlink/?linkid=870924\n\nComment:\n    " + thread[0].Text
+	for _, reply := range thread[1:] {
+		text += "\nReply:\n    " + reply.Text
+	}
+	return text
+}
+
+// getPersonID provides a function to get the ID of the person by given
+// threaded comment options, the person will be added to the person list if
+// not exist.
+func (persons *xlsxPersonList) getPersonID(opts ThreadedComment) string {
+	if opts.Author == "" {
+		opts.Author = "Author"
+	}
+	if len(opts.Author) > MaxFieldLength {
+		opts.Author = opts.Author[:MaxFieldLength]
+	}
+	if opts.UserID == "" && opts.ProviderID == "" {
+		opts.UserID, opts.ProviderID = opts.Author, "None"
+	}
+	for _, person := range persons.Person {
+		if person.DisplayName == opts.Author && person.UserID == opts.UserID && person.ProviderID == opts.ProviderID {
+			return person.ID
+		}
+	}
+	person := xlsxPerson{DisplayName: opts.Author, ID: newGUID(), UserID: opts.UserID, ProviderID: opts.ProviderID}
+	persons.Person = append(persons.Person, person)
+	return person.ID
+}
+
+// getSheetThreadedComments provides a function to get the path of the
+// threaded comments part by given worksheet XML path, the empty string will be
+// returned if the worksheet does not have threaded comments.
+func (f *File) getSheetThreadedComments(sheetXMLPath string) string {
+	rels, _ := f.relsViewer("xl/worksheets/_rels/" + filepath.Base(sheetXMLPath) + ".rels")
+	if rels == nil {
+		return ""
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, v := range rels.Relationships {
+		if v.Type == SourceRelationshipThreadedComment {
+			if strings.HasPrefix(v.Target, "/") {
+				return strings.TrimPrefix(v.Target, "/")
+			}
+			return "xl" + strings.TrimPrefix(v.Target, "..")
+		}
+	}
+	return ""
+}
+
+// countThreadedComments provides a function to get threaded comments files
+// count storage in the folder xl/threadedComments.
+func (f *File) countThreadedComments() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/threadedComments/threadedComment") {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// threadedCommentsReader provides a function to get the pointer to the
+// structure after deserialization of xl/threadedComments/threadedComment%d.xml.
+func (f *File) threadedCommentsReader(path string) (*xlsxThreadedComments, error) {
+	tcs := &xlsxThreadedComments{XMLNSX: NameSpaceSpreadSheet.Value}
+	if path == "" {
+		return tcs, nil
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(tcs); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return tcs, nil
+}
+
+// personListReader provides a function to get the pointer to the structure
+// after deserialization of xl/persons/person.xml.
+func (f *File) personListReader(path string) (*xlsxPersonList, error) {
+	persons := &xlsxPersonList{XMLNSX: NameSpaceSpreadSheet.Value}
+	if path == "" {
+		return persons, nil
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(persons); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return persons, nil
+}
@@ -0,0 +1,203 @@
+package excelize
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddThreadedComment(t *testing.T) {
+	f := NewFile()
+	dateTime := time.Date(2023, 6, 1, 8, 30, 0, 0, time.UTC)
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{
+		Cell: "A1", Author: "Excelize", Text: "Please check the total.", DateTime: dateTime,
+		Replies: []ThreadedComment{{Author: "Reviewer", UserID: "reviewer@example.com", ProviderID: "AD", Text: "Fixed."}},
+	}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "B2", Author: "Excelize", Text: "Second thread"}))
+	// Test add threaded comment on the cell which already has a thread
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Author: "Excelize", Text: "Thanks.", Done: true}))
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddThreadedComment("Sheet2", ThreadedComment{Cell: "C3", Text: "Default author"}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddThreadedComment.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestAddThreadedComment.xlsx"))
+	assert.NoError(t, err)
+	comments, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "A1", comments[0].Cell)
+	assert.Equal(t, "Excelize", comments[0].Author)
+	assert.Equal(t, "Excelize", comments[0].UserID)
+	assert.Equal(t, "None", comments[0].ProviderID)
+	assert.Equal(t, "Please check the total.", comments[0].Text)
+	assert.Equal(t, dateTime, comments[0].DateTime)
+	assert.True(t, comments[0].Done)
+	assert.Len(t, comments[0].Replies, 2)
+	assert.Equal(t, "Reviewer", comments[0].Replies[0].Author)
+	assert.Equal(t, "reviewer@example.com", comments[0].Replies[0].UserID)
+	assert.Equal(t, "AD", comments[0].Replies[0].ProviderID)
+	assert.Equal(t, "Fixed.", comments[0].Replies[0].Text)
+	assert.Equal(t, "Thanks.", comments[0].Replies[1].Text)
+	assert.False(t, comments[1].Done)
+	assert.Empty(t, comments[1].Replies)
+	threadID := comments[0].ID
+	comments, err = f.GetThreadedComments("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "Author", comments[0].Author)
+	// Test the person list and legacy comments of the threaded comments
+	persons, err := f.personListReader("xl/persons/person.xml")
+	assert.NoError(t, err)
+	assert.Len(t, persons.Person, 3)
+	legacyComments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, legacyComments, 2)
+	assert.Equal(t, "tc="+threadID, legacyComments[0].Author)
+	assert.True(t, strings.HasPrefix(legacyComments[0].Text, "[Threaded comment]"))
+	assert.True(t, strings.HasSuffix(legacyComments[0].Text, "Comment:\n    Please check the total.\nReply:\n    Fixed.\nReply:\n    Thanks."))
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add threaded comment with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A"}))
+	// Test add threaded comment on not exists worksheet
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.AddThreadedComment("SheetN", ThreadedComment{Cell: "A1"}))
+	// Test add threaded comment with unsupported charset threaded comments
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", MacintoshCyrillicCharset)
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="`+SourceRelationshipThreadedComment+`" Target="../threadedComments/threadedComment1.xml"/></Relationships>`))
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1"}), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetThreadedComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add threaded comment with unsupported charset person list
+	f.Pkg.Store("xl/persons/person.xml", MacintoshCyrillicCharset)
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPerson, "/xl/persons/person.xml", "")
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1"}), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetThreadedComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test add threaded comment with unsupported charset workbook relationships
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1"}), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetThreadedComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add threaded comment with unsupported charset content types
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add threaded comment with unsupported charset comments
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="`+SourceRelationshipComments+`" Target="/xl/comments1.xml"/></Relationships>`))
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetThreadedComments(t *testing.T) {
+	f := NewFile()
+	comments, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+	// Test get threaded comments on not exists worksheet
+	_, err = f.GetThreadedComments("SheetN")
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, err)
+	// Test get threaded comments with absolute path target
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", []byte(`<ThreadedComments xmlns="`+NameSpaceSpreadSheetThreadedComments+`"><threadedComment ref="A1" dT="2023-06-01T08:30:00.00" personId="{P}" id="{1}" done="1"><text>Text</text></threadedComment><threadedComment ref="A1" personId="{P}" id="{2}" parentId="{1}"><text>Reply</text></threadedComment></ThreadedComments>`))
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="`+SourceRelationshipThreadedComment+`" Target="/xl/threadedComments/threadedComment1.xml"/></Relationships>`))
+	comments, err = f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ThreadedComment{{
+		ID: "{1}", Cell: "A1", Text: "Text", Done: true,
+		DateTime: time.Date(2023, 6, 1, 8, 30, 0, 0, time.UTC),
+		Replies:  []ThreadedComment{{ID: "{2}", Cell: "A1", Text: "Reply"}},
+	}}, comments)
+	assert.NoError(t, f.Close())
+}
+
+func TestDeleteThreadedComment(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "C3", Author: "Excelize", Text: "Note"}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{
+		Cell: "A1", Text: "Thread 1", Replies: []ThreadedComment{{Text: "Reply 1"}},
+	}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "B2", Text: "Thread 2"}))
+	assert.NoError(t, f.DeleteThreadedComment("Sheet1", "A1"))
+	// Test delete threaded comment on the cell without thread
+	assert.NoError(t, f.DeleteThreadedComment("Sheet1", "C3"))
+	comments, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "B2", comments[0].Cell)
+	legacyComments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, legacyComments, 2)
+	assert.Equal(t, "C3", legacyComments[0].Cell)
+	assert.Equal(t, "B2", legacyComments[1].Cell)
+	// Test delete threaded comment on the worksheet without threaded comments
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.DeleteThreadedComment("Sheet2", "A1"))
+	_, ok := f.Pkg.Load("xl/threadedComments/threadedComment2.xml")
+	assert.False(t, ok)
+	// Test delete threaded comment on not exists worksheet
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.DeleteThreadedComment("SheetN", "A1"))
+	assert.NoError(t, f.Close())
+}
+
+func TestConvertCommentsToThreadedComments(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Note: ", Paragraph: []RichTextRun{{Text: "check"}}}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B2", Author: "Reviewer", Text: "Looks good"}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "C3", Author: "Excelize", Text: "Thread"}))
+	assert.NoError(t, f.ConvertCommentsToThreadedComments("Sheet1"))
+	comments, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, []string{"C3", "A1", "B2"}, []string{comments[0].Cell, comments[1].Cell, comments[2].Cell})
+	assert.Equal(t, []string{"Thread", "Note: check", "Looks good"}, []string{comments[0].Text, comments[1].Text, comments[2].Text})
+	assert.Equal(t, []string{"Excelize", "Excelize", "Reviewer"}, []string{comments[0].Author, comments[1].Author, comments[2].Author})
+	legacyComments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, legacyComments, 3)
+	for _, comment := range legacyComments {
+		assert.True(t, strings.HasPrefix(comment.Author, "tc={"))
+		assert.True(t, strings.HasPrefix(comment.Text, "[Threaded comment]"))
+	}
+	// Test convert comments on the worksheet without comments
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.ConvertCommentsToThreadedComments("Sheet2"))
+	comments, err = f.GetThreadedComments("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestConvertCommentsToThreadedComments.xlsx")))
+	// Test convert comments on not exists worksheet
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.ConvertCommentsToThreadedComments("SheetN"))
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test convert comments with unsupported charset comments
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="`+SourceRelationshipComments+`" Target="../comments1.xml"/></Relationships>`))
+	assert.EqualError(t, f.ConvertCommentsToThreadedComments("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestNewGUID(t *testing.T) {
+	GUID := newGUID()
+	assert.Len(t, GUID, 38)
+	assert.Regexp(t, `^\{[0-9A-F]{8}-[0-9A-F]{4}-4[0-9A-F]{3}-[89AB][0-9A-F]{3}-[0-9A-F]{12}\}$`, GUID)
+	assert.NotEqual(t, GUID, newGUID())
+}
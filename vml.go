@@ -15,10 +15,12 @@ import (
 	"bytes"
 	"encoding/xml"
 	"fmt"
+	"image"
 	"io"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // FormControlType is the type of supported form controls.
@@ -34,9 +36,26 @@ const (
 	FormControlGroupBox
 	FormControlLabel
 	FormControlScrollBar
+	FormControlComboBox
+	FormControlListBox
+)
+
+// HeaderFooterImagePositionType is the type of supported position of the
+// header and footer images.
+type HeaderFooterImagePositionType byte
+
+// This section defines the currently supported header and footer image
+// position types enumeration.
+const (
+	HeaderFooterImagePositionLeft HeaderFooterImagePositionType = iota
+	HeaderFooterImagePositionCenter
+	HeaderFooterImagePositionRight
 )
 
 // GetComments retrieves all comments in a worksheet by given worksheet name.
+// The visibility, size and offset of the comment box will also be returned,
+// and for the legacy comment of the threaded comment, the creation date time
+// of the thread will be returned.
 func (f *File) GetComments(sheet string) ([]Comment, error) {
 	var comments []Comment
 	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
@@ -48,7 +67,15 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 		commentsXML = "xl" + strings.TrimPrefix(commentsXML, "..")
 	}
 	commentsXML = strings.TrimPrefix(commentsXML, "/")
-	cmts, err := f.commentsReader(commentsXML)
+	cmts, err := f.commentsViewer(commentsXML)
+	if err != nil {
+		return comments, err
+	}
+	shapes, err := f.getCommentShapes(sheet)
+	if err != nil {
+		return comments, err
+	}
+	tcs, err := f.threadedCommentsReader(f.getSheetThreadedComments(sheetXMLPath))
 	if err != nil {
 		return comments, err
 	}
@@ -60,6 +87,19 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 			}
 			comment.Cell = cmt.Ref
 			comment.AuthorID = cmt.AuthorID
+			if len(shapes[cmt.Ref]) > 0 {
+				if err = f.extractCommentBox(sheet, shapes[cmt.Ref][0], &comment); err != nil {
+					return comments, err
+				}
+				shapes[cmt.Ref] = shapes[cmt.Ref][1:]
+			}
+			if strings.HasPrefix(comment.Author, "tc=") {
+				for _, tc := range tcs.ThreadedComment {
+					if "tc="+tc.ID == comment.Author {
+						comment.DateTime, _ = time.Parse("2006-01-02T15:04:05", tc.DT)
+					}
+				}
+			}
 			if cmt.Text.T != nil {
 				comment.Text += *cmt.Text.T
 			}
@@ -78,10 +118,96 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 	return comments, nil
 }
 
+// getCommentShapes provides a function to get the VML shapes of the comments
+// by given worksheet name, the key of the map is the cell reference of the
+// comment, and the shapes of the comments in the same cell are kept in order.
+func (f *File) getCommentShapes(sheet string) (map[string][]decodeShapeVal, error) {
+	shapes := map[string][]decodeShapeVal{}
+	ws, err := f.workSheetViewer(sheet)
+	if err != nil || ws.LegacyDrawing == nil {
+		return shapes, err
+	}
+	drawingVML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID), "..", "xl")
+	var values []string
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		for _, sp := range vml.Shape {
+			values = append(values, sp.Val)
+		}
+	} else {
+		d, err := f.decodeVMLDrawingReader(drawingVML)
+		if err != nil || d == nil {
+			return shapes, err
+		}
+		for _, sp := range d.Shape {
+			values = append(values, sp.Val)
+		}
+	}
+	for _, val := range values {
+		var shapeVal decodeShapeVal
+		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", val)), &shapeVal); err != nil {
+			return shapes, err
+		}
+		clientData := shapeVal.ClientData
+		if clientData.ObjectType != "Note" || clientData.Column == nil || clientData.Row == nil {
+			continue
+		}
+		cell, err := CoordinatesToCellName(*clientData.Column+1, *clientData.Row+1)
+		if err != nil {
+			return shapes, err
+		}
+		shapes[cell] = append(shapes[cell], shapeVal)
+	}
+	return shapes, err
+}
+
+// extractCommentBox provides a function to extract the visibility, size and
+// the offset from the default position of the comment box by given worksheet
+// name and VML shape of the comment.
+func (f *File) extractCommentBox(sheet string, shape decodeShapeVal, comment *Comment) error {
+	col, row, err := CellNameToCoordinates(comment.Cell)
+	if err != nil {
+		return err
+	}
+	comment.Visible = shape.ClientData.Visible != nil
+	pos := strings.Split(shape.ClientData.Anchor, ",")
+	if len(pos) != 8 {
+		return err
+	}
+	var anchor [8]int
+	for i := range pos {
+		if anchor[i], err = strconv.Atoi(strings.TrimSpace(pos[i])); err != nil {
+			return err
+		}
+	}
+	x, y := anchor[1]-anchor[5], anchor[3]-anchor[7]
+	for c := anchor[0] + 1; c <= anchor[4]; c++ {
+		x -= f.getColWidth(sheet, c)
+	}
+	for r := anchor[2] + 1; r <= anchor[6]; r++ {
+		y -= f.getRowHeight(sheet, r)
+	}
+	comment.Width, comment.Height = uint(-x), uint(-y)
+	x, y = anchor[1]-15, anchor[3]
+	for c := 1; c <= anchor[0]; c++ {
+		x += f.getColWidth(sheet, c)
+	}
+	for c := 1; c <= col; c++ {
+		x -= f.getColWidth(sheet, c)
+	}
+	for r := 1; r <= anchor[2]; r++ {
+		y += f.getRowHeight(sheet, r)
+	}
+	for r := 1; r < row; r++ {
+		y -= f.getRowHeight(sheet, r)
+	}
+	comment.OffsetX, comment.OffsetY = x, y
+	return err
+}
+
 // getSheetComments provides the method to get the target comment reference by
 // given worksheet file path.
 func (f *File) getSheetComments(sheetFile string) string {
-	rels, _ := f.relsReader("xl/worksheets/_rels/" + sheetFile + ".rels")
+	rels, _ := f.relsViewer("xl/worksheets/_rels/" + sheetFile + ".rels")
 	if sheetRels := rels; sheetRels != nil {
 		sheetRels.mu.Lock()
 		defer sheetRels.mu.Unlock()
@@ -97,8 +223,14 @@ func (f *File) getSheetComments(sheetFile string) string {
 // AddComment provides the method to add comments in a sheet by giving the
 // worksheet name, cell reference, and format set (such as author and text).
 // Note that the maximum author name length is 255 and the max text length is
-// 32512. For example, add a rich-text comment with a specified comments box
-// size in Sheet1!A5:
+// 32512. The comment box will be placed 15 pixels to the right of the top-right
+// corner of the commented cell by default, the optional parameters "OffsetX"
+// and "OffsetY" specifies the offset of the comment box in pixels from the
+// default position, and the negative offset value moves the comment box to the
+// left or up. The optional parameters "Width" and "Height" specifies the size
+// of the comment box in pixels, and set the "Visible" as true to always show
+// the comment box. For example, add a rich-text comment with a specified
+// comments box size in Sheet1!A5:
 //
 //	err := f.AddComment("Sheet1", excelize.Comment{
 //	    Cell:   "A5",
@@ -130,6 +262,13 @@ func (f *File) AddComment(sheet string, opts Comment) error {
 //
 //	err := f.DeleteComment("Sheet1", "A30")
 func (f *File) DeleteComment(sheet, cell string) error {
+	return f.deleteComments(sheet, func(ref string) bool { return ref == cell })
+}
+
+// deleteComments provides a function to delete the comments in a worksheet by
+// given worksheet name and the function to check if the comment with the cell
+// reference should be deleted.
+func (f *File) deleteComments(sheet string, fn func(ref string) bool) error {
 	if err := checkSheetName(sheet); err != nil {
 		return err
 	}
@@ -149,7 +288,7 @@ func (f *File) DeleteComment(sheet, cell string) error {
 	if cmts != nil {
 		for i := 0; i < len(cmts.CommentList.Comment); i++ {
 			cmt := cmts.CommentList.Comment[i]
-			if cmt.Ref != cell {
+			if !fn(cmt.Ref) {
 				continue
 			}
 			if len(cmts.CommentList.Comment) > 1 {
@@ -170,41 +309,111 @@ func (f *File) DeleteComment(sheet, cell string) error {
 // addComment provides a function to create chart as xl/comments%d.xml by
 // given cell and format sets.
 func (f *File) addComment(commentsXML string, opts vmlOptions) error {
-	if opts.Author == "" {
-		opts.Author = "Author"
-	}
-	if len(opts.Author) > MaxFieldLength {
-		opts.Author = opts.Author[:MaxFieldLength]
-	}
 	cmts, err := f.commentsReader(commentsXML)
 	if err != nil {
 		return err
 	}
-	var authorID int
 	if cmts == nil {
-		cmts = &xlsxComments{Authors: xlsxAuthor{Author: []string{opts.Author}}}
+		cmts = &xlsxComments{}
 	}
-	if inStrSlice(cmts.Authors.Author, opts.Author, true) == -1 {
-		cmts.Authors.Author = append(cmts.Authors.Author, opts.Author)
-		authorID = len(cmts.Authors.Author) - 1
-	}
-	defaultFont, err := f.GetDefaultFont()
+	text, err := f.newCommentText(opts.Comment)
 	if err != nil {
 		return err
 	}
-	chars, cmt := 0, xlsxComment{
+	cmts.CommentList.Comment = append(cmts.CommentList.Comment, xlsxComment{
 		Ref:      opts.Comment.Cell,
-		AuthorID: authorID,
-		Text:     xlsxText{R: []xlsxR{}},
+		AuthorID: cmts.getAuthorID(opts.Author),
+		Text:     text,
+	})
+	f.Comments[commentsXML] = cmts
+	return err
+}
+
+// EditComment provides the method to update the author and text of an
+// existing comment in place by given worksheet name and comment options, the
+// comment box of the comment will be kept. The author of the comment will not
+// be changed if the author is empty, and the text of the comment will be
+// replaced by the given text and rich-text runs. For example, update the
+// comment in Sheet1!A5:
+//
+//	err := f.EditComment("Sheet1", excelize.Comment{
+//	    Cell:   "A5",
+//	    Author: "Excelize",
+//	    Paragraph: []excelize.RichTextRun{
+//	        {Text: "Excelize: ", Font: &excelize.Font{Bold: true}},
+//	        {Text: "This is an updated comment."},
+//	    },
+//	})
+func (f *File) EditComment(sheet string, opts Comment) error {
+	if _, _, err := CellNameToCoordinates(opts.Cell); err != nil {
+		return err
+	}
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return ErrSheetNotExist{sheet}
+	}
+	commentsXML := f.getSheetComments(filepath.Base(sheetXMLPath))
+	if !strings.HasPrefix(commentsXML, "/") {
+		commentsXML = "xl" + strings.TrimPrefix(commentsXML, "..")
+	}
+	commentsXML = strings.TrimPrefix(commentsXML, "/")
+	cmts, err := f.commentsReader(commentsXML)
+	if err != nil {
+		return err
+	}
+	if cmts != nil {
+		for i, cmt := range cmts.CommentList.Comment {
+			if cmt.Ref != opts.Cell {
+				continue
+			}
+			text, err := f.newCommentText(opts)
+			if err != nil {
+				return err
+			}
+			if opts.Author != "" {
+				cmts.CommentList.Comment[i].AuthorID = cmts.getAuthorID(opts.Author)
+			}
+			cmts.CommentList.Comment[i].Text = text
+			f.Comments[commentsXML] = cmts
+			return err
+		}
+	}
+	return newNoExistCommentError(opts.Cell)
+}
+
+// getAuthorID provides a function to get the index of the comment author by
+// given author name, the author will be added to the authors list if not
+// exist.
+func (cmts *xlsxComments) getAuthorID(author string) int {
+	if author == "" {
+		author = "Author"
+	}
+	if len(author) > MaxFieldLength {
+		author = author[:MaxFieldLength]
+	}
+	if idx := inStrSlice(cmts.Authors.Author, author, true); idx != -1 {
+		return idx
+	}
+	cmts.Authors.Author = append(cmts.Authors.Author, author)
+	return len(cmts.Authors.Author) - 1
+}
+
+// newCommentText provides a function to create the rich text of the comment
+// by given comment options.
+func (f *File) newCommentText(opts Comment) (xlsxText, error) {
+	defaultFont, err := f.GetDefaultFont()
+	if err != nil {
+		return xlsxText{}, err
 	}
-	if opts.Comment.Text != "" {
-		if len(opts.Comment.Text) > TotalCellChars {
-			opts.Comment.Text = opts.Comment.Text[:TotalCellChars]
+	chars, text := 0, xlsxText{R: []xlsxR{}}
+	if opts.Text != "" {
+		if len(opts.Text) > TotalCellChars {
+			opts.Text = opts.Text[:TotalCellChars]
 		}
-		cmt.Text.T = stringPtr(opts.Comment.Text)
-		chars += len(opts.Comment.Text)
+		text.T = stringPtr(opts.Text)
+		chars += len(opts.Text)
 	}
-	for _, run := range opts.Comment.Paragraph {
+	for _, run := range opts.Paragraph {
 		if chars == TotalCellChars {
 			break
 		}
@@ -229,11 +438,9 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 		if run.Font != nil {
 			r.RPr = newRpr(run.Font)
 		}
-		cmt.Text.R = append(cmt.Text.R, r)
+		text.R = append(text.R, r)
 	}
-	cmts.CommentList.Comment = append(cmts.CommentList.Comment, cmt)
-	f.Comments[commentsXML] = cmts
-	return err
+	return text, err
 }
 
 // countComments provides a function to get comments files count storage in
@@ -255,8 +462,21 @@ func (f *File) countComments() int {
 }
 
 // commentsReader provides a function to get the pointer to the structure
-// after deserialization of xl/comments%d.xml.
+// after deserialization of xl/comments%d.xml for writing.
 func (f *File) commentsReader(path string) (*xlsxComments, error) {
+	return f.getComments(path, false)
+}
+
+// commentsViewer provides a function to get the pointer to the structure
+// after deserialization of xl/comments%d.xml for read only.
+func (f *File) commentsViewer(path string) (*xlsxComments, error) {
+	return f.getComments(path, true)
+}
+
+// getComments provides a function to get the pointer to the structure after
+// deserialization of xl/comments%d.xml for writing or read only.
+func (f *File) getComments(path string, readOnly bool) (*xlsxComments, error) {
+	defer f.trackPart(path, f.Comments[path] == nil, readOnly)
 	if f.Comments[path] == nil {
 		content, ok := f.Pkg.Load(path)
 		if ok && content != nil {
@@ -274,6 +494,9 @@ func (f *File) commentsReader(path string) (*xlsxComments, error) {
 // serialize structure.
 func (f *File) commentsWriter() {
 	for path, c := range f.Comments {
+		if _, ok := f.unmodified.Load(path); ok {
+			continue
+		}
 		if c != nil {
 			v, _ := xml.Marshal(c)
 			f.saveFileList(path, v)
@@ -283,9 +506,11 @@ func (f *File) commentsWriter() {
 
 // AddFormControl provides the method to add form control button in a worksheet
 // by given worksheet name and form control options. Supported form control
-// type: button, check box, group box, label, option button, scroll bar and
-// spinner. If set macro for the form control, the workbook extension should be
-// XLSM or XLTM. Scroll value must be between 0 and 30000.
+// type: button, check box, combo box, group box, label, list box, option
+// button, scroll bar and spinner. If set macro for the form control, the workbook extension should be
+// XLSM or XLTM. The module of the macro name qualified with a module name, such
+// as "Module1.Button1_Click", should exist in the VBA project if the workbook
+// already contains a VBA project. Scroll value must be between 0 and 30000.
 //
 // Example 1, add button form control with macro, rich-text, custom button size,
 // print property on Sheet1!A2, and let the button do not move or size with
@@ -359,6 +584,41 @@ func (f *File) commentsWriter() {
 //	    CellLink:     "A1",
 //	    Horizontally: true,
 //	})
+//
+// Example 5, add combo box form control on Sheet1!C1 with the items in
+// Sheet1!A1:A5 and 3-D shading, the index of the selected item will be set in
+// Sheet1!D1:
+//
+//	err := f.AddFormControl("Sheet1", excelize.FormControl{
+//	    Cell:          "C1",
+//	    Type:          excelize.FormControlComboBox,
+//	    Width:         100,
+//	    Height:        20,
+//	    InputRange:    "$A$1:$A$5",
+//	    CellLink:      "D1",
+//	    CurrentVal:    1,
+//	    DropLines:     5,
+//	    ThreeDShading: true,
+//	})
+//
+// Example 6, add list box form control on Sheet1!C3 with the items in
+// Sheet1!A1:A5, which allows select multiple items:
+//
+//	err := f.AddFormControl("Sheet1", excelize.FormControl{
+//	    Cell:          "C3",
+//	    Type:          excelize.FormControlListBox,
+//	    Width:         100,
+//	    Height:        80,
+//	    InputRange:    "Sheet1!$A$1:$A$5",
+//	    SelectionType: "Multi",
+//	})
+//
+// For the combo box and list box form controls, the "InputRange" specifies the
+// range of the items, the "CurrentVal" specifies the 1-based index of the
+// selected item, the "DropLines" specifies the number of the lines in the
+// drop-down list of the combo box, the default value is 8. The
+// "SelectionType" specifies the selection type of the list box, the possible
+// values are "Single", "Multi" and "Extend", the default value is "Single".
 func (f *File) AddFormControl(sheet string, opts FormControl) error {
 	return f.addVMLObject(vmlOptions{
 		formCtrl: true, sheet: sheet, FormControl: opts,
@@ -385,52 +645,17 @@ func (f *File) DeleteFormControl(sheet, cell string) error {
 	sheetRelationshipsDrawingVML := f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
 	vmlID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
 	drawingVML := strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
-	vml := f.VMLDrawing[drawingVML]
-	if vml == nil {
-		vml = &vmlDrawing{
-			XMLNSv:  "urn:schemas-microsoft-com:vml",
-			XMLNSo:  "urn:schemas-microsoft-com:office:office",
-			XMLNSx:  "urn:schemas-microsoft-com:office:excel",
-			XMLNSmv: "http://macVmlSchemaUri",
-			ShapeLayout: &xlsxShapeLayout{
-				Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: vmlID},
-			},
-			ShapeType: &xlsxShapeType{
-				Stroke: &xlsxStroke{JoinStyle: "miter"},
-				VPath:  &vPath{GradientShapeOK: "t", ConnectType: "rect"},
-			},
-		}
-		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
-		d, err := f.decodeVMLDrawingReader(drawingVML)
-		if err != nil {
-			return err
-		}
-		if d != nil {
-			vml.ShapeType.ID = d.ShapeType.ID
-			vml.ShapeType.CoordSize = d.ShapeType.CoordSize
-			vml.ShapeType.Spt = d.ShapeType.Spt
-			vml.ShapeType.Path = d.ShapeType.Path
-			for _, v := range d.Shape {
-				s := xlsxShape{
-					ID:          v.ID,
-					Type:        v.Type,
-					Style:       v.Style,
-					Button:      v.Button,
-					Filled:      v.Filled,
-					FillColor:   v.FillColor,
-					InsetMode:   v.InsetMode,
-					Stroked:     v.Stroked,
-					StrokeColor: v.StrokeColor,
-					Val:         v.Val,
-				}
-				vml.Shape = append(vml.Shape, s)
-			}
-		}
+	vml, err := f.vmlDrawingReader(vmlID, drawingVML, &xlsxShapeType{
+		Stroke: &xlsxStroke{JoinStyle: "miter"},
+		VPath:  &vPath{GradientShapeOK: "t", ConnectType: "rect"},
+	})
+	if err != nil {
+		return err
 	}
 	for i, sp := range vml.Shape {
 		var shapeVal decodeShapeVal
 		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err == nil &&
-			shapeVal.ClientData.ObjectType != "Note" && shapeVal.ClientData.Anchor != "" {
+			isFormCtrlObjectType(shapeVal.ClientData.ObjectType) && shapeVal.ClientData.Anchor != "" {
 			leftCol, topRow, err := extractAnchorCell(shapeVal.ClientData.Anchor)
 			if err != nil {
 				return err
@@ -445,6 +670,18 @@ func (f *File) DeleteFormControl(sheet, cell string) error {
 	return err
 }
 
+// isFormCtrlObjectType returns whether the given object type of the VML client
+// data is a form control, the comments (Note), pictures of the ActiveX
+// controls and OLE objects (Pict) are not form controls.
+func isFormCtrlObjectType(objectType string) bool {
+	for formCtrlType, preset := range formCtrlPresets {
+		if formCtrlType != FormControlNote && preset.objectType == objectType {
+			return true
+		}
+	}
+	return false
+}
+
 // countVMLDrawing provides a function to get VML drawing files count storage
 // in the folder xl/drawings.
 func (f *File) countVMLDrawing() int {
@@ -463,6 +700,53 @@ func (f *File) countVMLDrawing() int {
 	return len(drawings)
 }
 
+// vmlDrawingReader provides a function to get the pointer to the VML drawing
+// by given drawing data ID, XML path and the default shape type, the exist
+// shapes in the xl/drawings/vmlDrawing%d.vml will be loaded.
+func (f *File) vmlDrawingReader(dataID int, drawingVML string, shapeType *xlsxShapeType) (*vmlDrawing, error) {
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		return vml, nil
+	}
+	vml := &vmlDrawing{
+		XMLNSv:  "urn:schemas-microsoft-com:vml",
+		XMLNSo:  "urn:schemas-microsoft-com:office:office",
+		XMLNSx:  "urn:schemas-microsoft-com:office:excel",
+		XMLNSmv: "http://macVmlSchemaUri",
+		ShapeLayout: &xlsxShapeLayout{
+			Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: dataID},
+		},
+		ShapeType: shapeType,
+	}
+	// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
+	d, err := f.decodeVMLDrawingReader(drawingVML)
+	if err != nil {
+		return nil, err
+	}
+	if d != nil {
+		vml.ShapeType.ID = d.ShapeType.ID
+		vml.ShapeType.CoordSize = d.ShapeType.CoordSize
+		vml.ShapeType.Spt = d.ShapeType.Spt
+		vml.ShapeType.Path = d.ShapeType.Path
+		for _, v := range d.Shape {
+			s := xlsxShape{
+				ID:          v.ID,
+				SpID:        v.SpID,
+				Type:        v.Type,
+				Style:       v.Style,
+				Button:      v.Button,
+				Filled:      v.Filled,
+				FillColor:   v.FillColor,
+				InsetMode:   v.InsetMode,
+				Stroked:     v.Stroked,
+				StrokeColor: v.StrokeColor,
+				Val:         v.Val,
+			}
+			vml.Shape = append(vml.Shape, s)
+		}
+	}
+	return vml, nil
+}
+
 // decodeVMLDrawingReader provides a function to get the pointer to the
 // structure after deserialization of xl/drawings/vmlDrawing%d.xml.
 func (f *File) decodeVMLDrawingReader(path string) (*decodeVmlDrawing, error) {
@@ -500,9 +784,12 @@ func (f *File) addVMLObject(opts vmlOptions) error {
 	}
 	vmlID := f.countComments() + 1
 	if opts.formCtrl {
-		if opts.Type > FormControlScrollBar {
+		if opts.Type > FormControlListBox {
 			return ErrParameterInvalid
 		}
+		if err = f.checkVBAMacro(opts.Macro); err != nil {
+			return err
+		}
 		vmlID = f.countVMLDrawing() + 1
 	}
 	drawingVML := "xl/drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
@@ -707,6 +994,36 @@ var formCtrlPresets = map[FormControlType]formCtrlPreset{
 		firstButton:  nil,
 		shadow:       nil,
 	},
+	FormControlComboBox: {
+		objectType:   "Drop",
+		autoFill:     "",
+		filled:       "",
+		fillColor:    "",
+		stroked:      "f",
+		strokeColor:  "windowText [64]",
+		strokeButton: "",
+		fill:         nil,
+		textHAlign:   "",
+		textVAlign:   "",
+		noThreeD:     nil,
+		firstButton:  nil,
+		shadow:       nil,
+	},
+	FormControlListBox: {
+		objectType:   "List",
+		autoFill:     "",
+		filled:       "",
+		fillColor:    "",
+		stroked:      "f",
+		strokeColor:  "windowText [64]",
+		strokeButton: "",
+		fill:         nil,
+		textHAlign:   "",
+		textVAlign:   "",
+		noThreeD:     nil,
+		firstButton:  nil,
+		shadow:       nil,
+	},
 	FormControlSpinButton: {
 		objectType:   "Spin",
 		autoFill:     "False",
@@ -724,8 +1041,12 @@ var formCtrlPresets = map[FormControlType]formCtrlPreset{
 	},
 }
 
-// addFormCtrl check and add scroll bar or spinner form control by given options.
+// addFormCtrl check and add scroll bar, spinner, combo box or list box form
+// control by given options.
 func (sp *encodeShape) addFormCtrl(opts *vmlOptions) error {
+	if opts.Type == FormControlComboBox || opts.Type == FormControlListBox {
+		return sp.addListFormCtrl(opts)
+	}
 	if opts.Type != FormControlScrollBar && opts.Type != FormControlSpinButton {
 		return nil
 	}
@@ -756,6 +1077,46 @@ func (sp *encodeShape) addFormCtrl(opts *vmlOptions) error {
 	return nil
 }
 
+// addListFormCtrl check and add combo box or list box form control by given
+// options.
+func (sp *encodeShape) addListFormCtrl(opts *vmlOptions) error {
+	if opts.CurrentVal > MaxFormControlValue || opts.DropLines > MaxFormControlValue {
+		return ErrFormControlValue
+	}
+	if opts.CellLink != "" {
+		if _, _, err := CellNameToCoordinates(opts.CellLink); err != nil {
+			return err
+		}
+	}
+	if opts.InputRange != "" {
+		if _, err := rangeRefToCoordinates(opts.InputRange[strings.LastIndex(opts.InputRange, "!")+1:]); err != nil {
+			return err
+		}
+	}
+	sp.ClientData.FmlaLink = opts.CellLink
+	sp.ClientData.FmlaRange = opts.InputRange
+	sp.ClientData.Sel = opts.CurrentVal
+	if !opts.ThreeDShading {
+		sp.ClientData.NoThreeD2 = stringPtr("")
+	}
+	if opts.Type == FormControlComboBox {
+		sp.ClientData.DropLines = 8
+		if opts.DropLines != 0 {
+			sp.ClientData.DropLines = opts.DropLines
+		}
+		sp.ClientData.DropStyle = "Combo"
+		return nil
+	}
+	if opts.SelectionType != "" {
+		idx := inStrSlice(supportedListBoxSelectionTypes, opts.SelectionType, false)
+		if idx == -1 {
+			return ErrParameterInvalid
+		}
+		sp.ClientData.SelType = supportedListBoxSelectionTypes[idx]
+	}
+	return nil
+}
+
 // addFormCtrlShape returns a VML shape by given preset and options.
 func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor string, opts *vmlOptions) (*encodeShape, error) {
 	sp := encodeShape{
@@ -792,6 +1153,9 @@ func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor stri
 	if opts.FormControl.Type == FormControlNote {
 		sp.ClientData.MoveWithCells = stringPtr("")
 		sp.ClientData.SizeWithCells = stringPtr("")
+		if opts.Visible {
+			sp.ClientData.Visible = stringPtr("")
+		}
 	}
 	if !opts.formCtrl {
 		return &sp, nil
@@ -804,6 +1168,85 @@ func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor stri
 	return &sp, sp.addFormCtrl(opts)
 }
 
+// commentBoxAnchor provides a function to get the anchor and style of the
+// comment box by given cell coordinates and VML options. The comment box will
+// be placed 15 pixels to the right of the top-right corner of the commented
+// cell by default, and the offsets in the options specifies the offset from
+// the default position.
+func (f *File) commentBoxAnchor(col, row int, opts *vmlOptions) (string, string) {
+	x, y := 15+opts.Comment.OffsetX, opts.Comment.OffsetY
+	for c := 1; c <= col; c++ {
+		x += f.getColWidth(opts.sheet, c)
+	}
+	for r := 1; r < row; r++ {
+		y += f.getRowHeight(opts.sheet, r)
+	}
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+	width, height := int(opts.FormControl.Width), int(opts.FormControl.Height)
+	style := fmt.Sprintf("position:absolute;margin-left:%gpt;margin-top:%gpt;width:%gpt;height:%gpt;z-index:1;visibility:hidden",
+		float64(x)*0.75, float64(y)*0.75, float64(width)*0.75, float64(height)*0.75)
+	if opts.Visible {
+		style = strings.Replace(style, "visibility:hidden", "visibility:visible", 1)
+	}
+	colIdx, rowIdx := 0, 0
+	for x >= f.getColWidth(opts.sheet, colIdx+1) {
+		colIdx++
+		x -= f.getColWidth(opts.sheet, colIdx)
+	}
+	for y >= f.getRowHeight(opts.sheet, rowIdx+1) {
+		rowIdx++
+		y -= f.getRowHeight(opts.sheet, rowIdx)
+	}
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(opts.sheet, colIdx+1, rowIdx+1, x, y, width, height)
+	return fmt.Sprintf("%d, %d, %d, %d, %d, %d, %d, %d", colStart, x, rowStart, y, colEnd, x2, rowEnd, y2), style
+}
+
+// nextVMLShapeID provides a function to get the next unused shape ID in the
+// VML drawing by given drawing data ID. The shape ID is also used by the
+// ActiveX controls and OLE objects in the worksheet, so the shapes in one
+// worksheet should not have the same ID.
+func nextVMLShapeID(vml *vmlDrawing, dataID int) int {
+	spID := dataID * 1024
+	for _, shape := range vml.Shape {
+		for _, ID := range []string{shape.ID, shape.SpID} {
+			if shapeID, _ := strconv.Atoi(strings.TrimPrefix(ID, "_x0000_s")); shapeID > spID {
+				spID = shapeID
+			}
+		}
+	}
+	return spID + 1
+}
+
+// formCtrlAnchor provides a function to get the anchor and style of the form
+// control by given cell coordinates and VML options. The offsets in the
+// graphic options specifies the offset from the top-left corner of the cell.
+func (f *File) formCtrlAnchor(col, row int, opts *vmlOptions) (string, string) {
+	width, height := int(opts.FormControl.Width), int(opts.FormControl.Height)
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(opts.sheet, col, row, opts.Format.OffsetX, opts.Format.OffsetY, width, height)
+	x1, y1 := opts.Format.OffsetX, opts.Format.OffsetY
+	for c := col; c <= colStart; c++ {
+		x1 -= f.getColWidth(opts.sheet, c)
+	}
+	for r := row; r <= rowStart; r++ {
+		y1 -= f.getRowHeight(opts.sheet, r)
+	}
+	x, y := x1, y1
+	for c := 1; c <= colStart; c++ {
+		x += f.getColWidth(opts.sheet, c)
+	}
+	for r := 1; r <= rowStart; r++ {
+		y += f.getRowHeight(opts.sheet, r)
+	}
+	style := fmt.Sprintf("position:absolute;margin-left:%gpt;margin-top:%gpt;width:%gpt;height:%gpt;z-index:1;mso-wrap-style:tight",
+		float64(x)*0.75, float64(y)*0.75, float64(width)*0.75, float64(height)*0.75)
+	return fmt.Sprintf("%d, %d, %d, %d, %d, %d, %d, %d", colStart, x1, rowStart, y1, colEnd, x2, rowEnd, y2), style
+}
+
 // addDrawingVML provides a function to create VML drawing XML as
 // xl/drawings/vmlDrawing%d.vml by given data ID, XML path and VML options. The
 // anchor value is a comma-separated list of data written out as: LeftColumn,
@@ -814,58 +1257,24 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 	if err != nil {
 		return err
 	}
-	leftOffset, vmlID, vml, preset := 23, 202, f.VMLDrawing[drawingVML], formCtrlPresets[opts.Type]
-	style := "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden"
+	vmlID, preset := 202, formCtrlPresets[opts.Type]
+	var anchor, style string
 	if opts.formCtrl {
-		leftOffset, vmlID = 0, 201
-		style = "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;mso-wrap-style:tight"
-	}
-	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(opts.sheet, col, row, opts.Format.OffsetX, opts.Format.OffsetY, int(opts.FormControl.Width), int(opts.FormControl.Height))
-	anchor := fmt.Sprintf("%d, %d, %d, 0, %d, %d, %d, %d", colStart, leftOffset, rowStart, colEnd, x2, rowEnd, y2)
-	if vml == nil {
-		vml = &vmlDrawing{
-			XMLNSv:  "urn:schemas-microsoft-com:vml",
-			XMLNSo:  "urn:schemas-microsoft-com:office:office",
-			XMLNSx:  "urn:schemas-microsoft-com:office:excel",
-			XMLNSmv: "http://macVmlSchemaUri",
-			ShapeLayout: &xlsxShapeLayout{
-				Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: dataID},
-			},
-			ShapeType: &xlsxShapeType{
-				ID:        fmt.Sprintf("_x0000_t%d", vmlID),
-				CoordSize: "21600,21600",
-				Spt:       202,
-				Path:      "m0,0l0,21600,21600,21600,21600,0xe",
-				Stroke:    &xlsxStroke{JoinStyle: "miter"},
-				VPath:     &vPath{GradientShapeOK: "t", ConnectType: "rect"},
-			},
-		}
-		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
-		d, err := f.decodeVMLDrawingReader(drawingVML)
-		if err != nil {
-			return err
-		}
-		if d != nil {
-			vml.ShapeType.ID = d.ShapeType.ID
-			vml.ShapeType.CoordSize = d.ShapeType.CoordSize
-			vml.ShapeType.Spt = d.ShapeType.Spt
-			vml.ShapeType.Path = d.ShapeType.Path
-			for _, v := range d.Shape {
-				s := xlsxShape{
-					ID:          v.ID,
-					Type:        v.Type,
-					Style:       v.Style,
-					Button:      v.Button,
-					Filled:      v.Filled,
-					FillColor:   v.FillColor,
-					InsetMode:   v.InsetMode,
-					Stroked:     v.Stroked,
-					StrokeColor: v.StrokeColor,
-					Val:         v.Val,
-				}
-				vml.Shape = append(vml.Shape, s)
-			}
-		}
+		vmlID = 201
+		anchor, style = f.formCtrlAnchor(col, row, opts)
+	} else {
+		anchor, style = f.commentBoxAnchor(col, row, opts)
+	}
+	vml, err := f.vmlDrawingReader(dataID, drawingVML, &xlsxShapeType{
+		ID:        fmt.Sprintf("_x0000_t%d", vmlID),
+		CoordSize: "21600,21600",
+		Spt:       202,
+		Path:      "m0,0l0,21600,21600,21600,21600,0xe",
+		Stroke:    &xlsxStroke{JoinStyle: "miter"},
+		VPath:     &vPath{GradientShapeOK: "t", ConnectType: "rect"},
+	})
+	if err != nil {
+		return err
 	}
 	sp, err := f.addFormCtrlShape(preset, col, row, anchor, opts)
 	if err != nil {
@@ -873,7 +1282,7 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 	}
 	s, _ := xml.Marshal(sp)
 	shape := xlsxShape{
-		ID:          "_x0000_s1025",
+		ID:          "_x0000_s" + strconv.Itoa(nextVMLShapeID(vml, dataID)),
 		Type:        fmt.Sprintf("#_x0000_t%d", vmlID),
 		Style:       style,
 		Button:      preset.strokeButton,
@@ -889,12 +1298,13 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 }
 
 // GetFormControls retrieves all form controls in a worksheet by a given
-// worksheet name. Note that, this function does not support getting the width
-// and height of the form controls currently.
+// worksheet name. The width, height and the offsets from the top-left corner
+// of the cell of the form controls will be calculated from the anchor of the
+// form control shapes.
 func (f *File) GetFormControls(sheet string) ([]FormControl, error) {
 	var formControls []FormControl
 	// Read sheet data
-	ws, err := f.workSheetReader(sheet)
+	ws, err := f.workSheetViewer(sheet)
 	if err != nil {
 		return formControls, err
 	}
@@ -914,7 +1324,7 @@ func (f *File) GetFormControls(sheet string) ([]FormControl, error) {
 			if sp.Type != "#_x0000_t201" {
 				continue
 			}
-			formControl, err := extractFormControl(sp.Val)
+			formControl, err := f.extractFormControl(sheet, sp.Val)
 			if err != nil {
 				return formControls, err
 			}
@@ -929,7 +1339,7 @@ func (f *File) GetFormControls(sheet string) ([]FormControl, error) {
 		if sp.Type != "#_x0000_t201" {
 			continue
 		}
-		formControl, err := extractFormControl(sp.Val)
+		formControl, err := f.extractFormControl(sheet, sp.Val)
 		if err != nil {
 			return formControls, err
 		}
@@ -942,8 +1352,8 @@ func (f *File) GetFormControls(sheet string) ([]FormControl, error) {
 }
 
 // extractFormControl provides a function to extract form controls for a
-// worksheets by given client data.
-func extractFormControl(clientData string) (FormControl, error) {
+// worksheets by given worksheet name and client data.
+func (f *File) extractFormControl(sheet, clientData string) (FormControl, error) {
 	var (
 		err         error
 		formControl FormControl
@@ -967,6 +1377,9 @@ func extractFormControl(clientData string) (FormControl, error) {
 			if formControl.Cell, err = CoordinatesToCellName(col+1, row+1); err != nil {
 				return formControl, err
 			}
+			if err = f.extractFormControlBox(sheet, shapeVal.ClientData.Anchor, &formControl); err != nil {
+				return formControl, err
+			}
 			formControl.Macro = shapeVal.ClientData.FmlaMacro
 			formControl.Checked = shapeVal.ClientData.Checked != 0
 			formControl.CellLink = shapeVal.ClientData.FmlaLink
@@ -976,11 +1389,44 @@ func extractFormControl(clientData string) (FormControl, error) {
 			formControl.IncChange = shapeVal.ClientData.Inc
 			formControl.PageChange = shapeVal.ClientData.Page
 			formControl.Horizontally = shapeVal.ClientData.Horiz != nil
+			if formCtrlType == FormControlComboBox || formCtrlType == FormControlListBox {
+				formControl.InputRange = shapeVal.ClientData.FmlaRange
+				formControl.CurrentVal = shapeVal.ClientData.Sel
+				formControl.DropLines = shapeVal.ClientData.DropLines
+				formControl.SelectionType = shapeVal.ClientData.SelType
+				formControl.ThreeDShading = shapeVal.ClientData.NoThreeD2 == nil
+			}
 		}
 	}
 	return formControl, err
 }
 
+// extractFormControlBox provides a function to extract the size and the offset
+// from the top-left corner of the cell of the form control by given worksheet
+// name and VML anchor comma-separated list values.
+func (f *File) extractFormControlBox(sheet, anchor string, formControl *FormControl) error {
+	var (
+		pos    = strings.Split(anchor, ",")
+		values [8]int
+		err    error
+	)
+	for i := range pos {
+		if values[i], err = strconv.Atoi(strings.TrimSpace(pos[i])); err != nil {
+			return err
+		}
+	}
+	width, height := values[5]-values[1], values[7]-values[3]
+	for c := values[0] + 1; c <= values[4]; c++ {
+		width += f.getColWidth(sheet, c)
+	}
+	for r := values[2] + 1; r <= values[6]; r++ {
+		height += f.getRowHeight(sheet, r)
+	}
+	formControl.Width, formControl.Height = uint(width), uint(height)
+	formControl.Format.OffsetX, formControl.Format.OffsetY = values[1], values[3]
+	return err
+}
+
 // extractAnchorCell extract left-top cell coordinates from given VML anchor
 // comma-separated list values.
 func extractAnchorCell(anchor string) (int, int, error) {
@@ -1056,3 +1502,180 @@ func extractVMLFont(font []decodeVMLFont) []RichTextRun {
 	}
 	return runs
 }
+
+// AddHeaderFooterImage provides a mechanism to set the graphics that can be
+// referenced in the header and footer definitions via &G by given worksheet
+// name and image options. Supported image types: BMP, EMF, EMZ, GIF, JPEG,
+// JPG, PNG, SVG, TIF, TIFF, WMF, and WMZ. The extension should be provided
+// with a "." in front, e.g. ".png". The width and height are in pixels, and
+// will be detected from the image data if not specified. The "FirstPage" and
+// "EvenPage" options specifies the image for the first page or the even pages
+// header and footer, which can not be set at the same time, and will be
+// referenced by the "FirstHeader", "FirstFooter", "EvenHeader" and
+// "EvenFooter" settings of the header and footer. Each position of the header
+// or footer can only be set one image, the existing image in the same
+// position will be replaced. For example, add a centered header image as the
+// printed watermark of the worksheet "Sheet1":
+//
+//	file, err := os.ReadFile("image.png")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.AddHeaderFooterImage("Sheet1", &excelize.HeaderFooterImageOptions{
+//	    Position:  excelize.HeaderFooterImagePositionCenter,
+//	    File:      file,
+//	    Extension: ".png",
+//	}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetHeaderFooter("Sheet1", &excelize.HeaderFooterOptions{
+//	    OddHeader: "&C&G",
+//	})
+func (f *File) AddHeaderFooterImage(sheet string, opts *HeaderFooterImageOptions) error {
+	if opts == nil || opts.Position > HeaderFooterImagePositionRight || (opts.FirstPage && opts.EvenPage) {
+		return ErrParameterInvalid
+	}
+	ext, ok := supportedImageTypes[strings.ToLower(opts.Extension)]
+	if !ok {
+		return ErrImgExt
+	}
+	width, height := opts.Width, opts.Height
+	if width == 0 || height == 0 {
+		img, _, err := image.DecodeConfig(bytes.NewReader(opts.File))
+		if err != nil {
+			return err
+		}
+		width, height = uint(img.Width), uint(img.Height)
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	vmlID := f.countVMLDrawing() + 1
+	drawingVML := "xl/drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
+	sheetRelationshipsDrawingVML := "../drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
+	if ws.LegacyDrawingHF != nil {
+		// The worksheet already has a header and footer VML relationships, use
+		// the relationships drawing ../drawings/vmlDrawing%d.vml.
+		sheetRelationshipsDrawingVML = f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawingHF.RID)
+		vmlID, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
+		drawingVML = strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
+	} else {
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+		rID := f.addRels(sheetRels, SourceRelationshipDrawingVML, sheetRelationshipsDrawingVML, "")
+		f.addSheetNameSpace(sheet, SourceRelationship)
+		f.addSheetLegacyDrawingHF(sheet, rID)
+	}
+	vml, err := f.pictureVMLReader(vmlID, drawingVML)
+	if err != nil {
+		return err
+	}
+	shapeID := []string{"LH", "CH", "RH"}[opts.Position]
+	if opts.IsFooter {
+		shapeID = []string{"LF", "CF", "RF"}[opts.Position]
+	}
+	if opts.FirstPage {
+		shapeID += "FIRST"
+	}
+	if opts.EvenPage {
+		shapeID += "EVEN"
+	}
+	drawingVMLRels := "xl/drawings/_rels/vmlDrawing" + strconv.Itoa(vmlID) + ".vml.rels"
+	spID := vmlID * 1024
+	for i := 0; i < len(vml.Shape); i++ {
+		if shape := vml.Shape[i]; shape.ID == shapeID {
+			// Remove the existing image in the same position
+			if idx := strings.Index(shape.Val, `o:relid="`); idx != -1 {
+				relID := shape.Val[idx+len(`o:relid="`):]
+				f.deleteDrawingRels(drawingVMLRels, relID[:strings.Index(relID, `"`)])
+			}
+			vml.Shape = append(vml.Shape[:i], vml.Shape[i+1:]...)
+			i--
+			continue
+		}
+		if ID, _ := strconv.Atoi(strings.TrimPrefix(vml.Shape[i].SpID, "_x0000_s")); ID > spID {
+			spID = ID
+		}
+	}
+	mediaStr := ".." + strings.TrimPrefix(f.addMedia(opts.File, ext), "xl")
+	imageID := f.addRels(drawingVMLRels, SourceRelationshipImage, mediaStr, "")
+	vml.Shape = append(vml.Shape, xlsxShape{
+		ID:    shapeID,
+		SpID:  "_x0000_s" + strconv.Itoa(spID+1),
+		Type:  "#_x0000_t75",
+		Style: fmt.Sprintf("position:absolute;margin-left:0;margin-top:0;width:%gpt;height:%gpt;z-index:%d", float64(width)*0.75, float64(height)*0.75, len(vml.Shape)+1),
+		Val:   fmt.Sprintf(`<v:imagedata o:relid="rId%d" o:title="%s"/><o:lock v:ext="edit" rotation="t"/>`, imageID, shapeID),
+	})
+	f.VMLDrawing[drawingVML] = vml
+	if err = f.setContentTypePartImageExtensions(); err != nil {
+		return err
+	}
+	return f.setContentTypePartVMLExtensions()
+}
+
+// pictureVMLReader provides a function to get the VML drawing of the pictures,
+// such as the worksheet header and footer images and the icon of the OLE
+// objects by given data ID and XML path, the existing shapes will be loaded
+// from the xl/drawings/vmlDrawing%d.vml.
+func (f *File) pictureVMLReader(dataID int, drawingVML string) (*vmlDrawing, error) {
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		return vml, nil
+	}
+	formulas := &vFormulas{}
+	for _, eqn := range []string{
+		"if lineDrawn pixelLineWidth 0", "sum @0 1 0", "sum 0 0 @1",
+		"prod @2 1 2", "prod @3 21600 pixelWidth", "prod @3 21600 pixelHeight",
+		"sum @0 0 1", "prod @6 1 2", "prod @7 21600 pixelWidth",
+		"sum @8 21600 0", "prod @7 21600 pixelHeight", "sum @10 21600 0",
+	} {
+		formulas.Formulas = append(formulas.Formulas, vFormula{Equation: eqn})
+	}
+	vml := &vmlDrawing{
+		XMLNSv:  "urn:schemas-microsoft-com:vml",
+		XMLNSo:  "urn:schemas-microsoft-com:office:office",
+		XMLNSx:  "urn:schemas-microsoft-com:office:excel",
+		XMLNSmv: "http://macVmlSchemaUri",
+		ShapeLayout: &xlsxShapeLayout{
+			Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: dataID},
+		},
+		ShapeType: &xlsxShapeType{
+			ID:             "_x0000_t75",
+			CoordSize:      "21600,21600",
+			Spt:            75,
+			PreferRelative: "t",
+			Path:           "m@4@5l@4@11@9@11@9@5xe",
+			Filled:         "f",
+			Stroked:        "f",
+			Stroke:         &xlsxStroke{JoinStyle: "miter"},
+			Formulas:       formulas,
+			VPath:          &vPath{ExtrusionOK: "f", GradientShapeOK: "t", ConnectType: "rect"},
+			Lock:           &oLock{Ext: "edit", AspectRatio: "t"},
+		},
+	}
+	// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
+	d, err := f.decodeVMLDrawingReader(drawingVML)
+	if err != nil {
+		return nil, err
+	}
+	if d != nil {
+		for _, v := range d.Shape {
+			vml.Shape = append(vml.Shape, xlsxShape{
+				ID:          v.ID,
+				SpID:        v.SpID,
+				Type:        v.Type,
+				Style:       v.Style,
+				Button:      v.Button,
+				Filled:      v.Filled,
+				FillColor:   v.FillColor,
+				InsetMode:   v.InsetMode,
+				Stroked:     v.Stroked,
+				StrokeColor: v.StrokeColor,
+				Val:         v.Val,
+			})
+		}
+	}
+	return vml, nil
+}
@@ -44,12 +44,13 @@ type xlsxIDmap struct {
 type xlsxShape struct {
 	XMLName     xml.Name `xml:"v:shape"`
 	ID          string   `xml:"id,attr"`
+	SpID        string   `xml:"o:spid,attr,omitempty"`
 	Type        string   `xml:"type,attr"`
 	Style       string   `xml:"style,attr"`
 	Button      string   `xml:"o:button,attr,omitempty"`
 	Filled      string   `xml:"filled,attr,omitempty"`
 	FillColor   string   `xml:"fillcolor,attr,omitempty"`
-	InsetMode   string   `xml:"urn:schemas-microsoft-com:office:office insetmode,attr,omitempty"`
+	InsetMode   string   `xml:"o:insetmode,attr,omitempty"`
 	Stroked     string   `xml:"stroked,attr,omitempty"`
 	StrokeColor string   `xml:"strokecolor,attr,omitempty"`
 	Val         string   `xml:",innerxml"`
@@ -57,12 +58,17 @@ type xlsxShape struct {
 
 // xlsxShapeType directly maps the shapetype element.
 type xlsxShapeType struct {
-	ID        string      `xml:"id,attr"`
-	CoordSize string      `xml:"coordsize,attr"`
-	Spt       int         `xml:"o:spt,attr"`
-	Path      string      `xml:"path,attr"`
-	Stroke    *xlsxStroke `xml:"v:stroke"`
-	VPath     *vPath      `xml:"v:path"`
+	ID             string      `xml:"id,attr"`
+	CoordSize      string      `xml:"coordsize,attr"`
+	Spt            int         `xml:"o:spt,attr"`
+	PreferRelative string      `xml:"o:preferrelative,attr,omitempty"`
+	Path           string      `xml:"path,attr"`
+	Filled         string      `xml:"filled,attr,omitempty"`
+	Stroked        string      `xml:"stroked,attr,omitempty"`
+	Stroke         *xlsxStroke `xml:"v:stroke"`
+	Formulas       *vFormulas  `xml:"v:formulas"`
+	VPath          *vPath      `xml:"v:path"`
+	Lock           *oLock      `xml:"o:lock"`
 }
 
 // xlsxStroke directly maps the stroke element.
@@ -70,8 +76,26 @@ type xlsxStroke struct {
 	JoinStyle string `xml:"joinstyle,attr"`
 }
 
+// vFormulas directly maps the v:formulas element.
+type vFormulas struct {
+	Formulas []vFormula `xml:"v:f"`
+}
+
+// vFormula directly maps the v:f element.
+type vFormula struct {
+	Equation string `xml:"eqn,attr"`
+}
+
+// oLock directly maps the o:lock element.
+type oLock struct {
+	Ext         string `xml:"v:ext,attr"`
+	AspectRatio string `xml:"aspectratio,attr,omitempty"`
+	Rotation    string `xml:"rotation,attr,omitempty"`
+}
+
 // vPath directly maps the v:path element.
 type vPath struct {
+	ExtrusionOK     string `xml:"o:extrusionok,attr,omitempty"`
 	GradientShapeOK string `xml:"gradientshapeok,attr,omitempty"`
 	ConnectType     string `xml:"o:connecttype,attr"`
 }
@@ -91,6 +115,14 @@ type oFill struct {
 	Type string `xml:"type,attr,omitempty"`
 }
 
+// vImageData directly maps the v:imagedata element. This element specifies
+// the image to be rendered on top of the shape, the relid attribute specifies
+// the relationship ID of the image in the VML drawing relationships.
+type vImageData struct {
+	RelID string `xml:"o:relid,attr"`
+	Title string `xml:"o:title,attr"`
+}
+
 // vShadow directly maps the v:shadow element. This element must be defined
 // within a Shape element. In addition, the On attribute must be set to True.
 type vShadow struct {
@@ -139,6 +171,7 @@ type xClientData struct {
 	TextVAlign    string  `xml:"x:TextVAlign,omitempty"`
 	Row           *int    `xml:"x:Row"`
 	Column        *int    `xml:"x:Column"`
+	Visible       *string `xml:"x:Visible"`
 	Checked       int     `xml:"x:Checked,omitempty"`
 	FmlaLink      string  `xml:"x:FmlaLink,omitempty"`
 	NoThreeD      *string `xml:"x:NoThreeD"`
@@ -150,6 +183,14 @@ type xClientData struct {
 	Page          uint    `xml:"x:Page,omitempty"`
 	Horiz         *string `xml:"x:Horiz"`
 	Dx            uint    `xml:"x:Dx,omitempty"`
+	FmlaRange     string  `xml:"x:FmlaRange,omitempty"`
+	Sel           uint    `xml:"x:Sel,omitempty"`
+	SelType       string  `xml:"x:SelType,omitempty"`
+	NoThreeD2     *string `xml:"x:NoThreeD2"`
+	DropLines     uint    `xml:"x:DropLines,omitempty"`
+	DropStyle     string  `xml:"x:DropStyle,omitempty"`
+	CF            string  `xml:"x:CF,omitempty"`
+	AutoPict      *string `xml:"x:AutoPict"`
 }
 
 // decodeVmlDrawing defines the structure used to parse the file
@@ -171,6 +212,7 @@ type decodeShapeType struct {
 // decodeShape defines the structure used to parse the particular shape element.
 type decodeShape struct {
 	ID          string `xml:"id,attr"`
+	SpID        string `xml:"urn:schemas-microsoft-com:office:office spid,attr,omitempty"`
 	Type        string `xml:"type,attr"`
 	Style       string `xml:"style,attr"`
 	Button      string `xml:"button,attr,omitempty"`
@@ -238,6 +280,7 @@ type decodeVMLClientData struct {
 	FmlaMacro  string
 	Column     *int
 	Row        *int
+	Visible    *string
 	Checked    int
 	FmlaLink   string
 	Val        uint
@@ -246,6 +289,11 @@ type decodeVMLClientData struct {
 	Inc        uint
 	Page       uint
 	Horiz      *string
+	FmlaRange  string
+	Sel        uint
+	SelType    string
+	NoThreeD2  *string
+	DropLines  uint
 }
 
 // encodeShape defines the structure used to re-serialization shape element.
@@ -253,6 +301,7 @@ type encodeShape struct {
 	Fill       *vFill       `xml:"v:fill"`
 	Shadow     *vShadow     `xml:"v:shadow"`
 	Path       *vPath       `xml:"v:path"`
+	ImageData  *vImageData  `xml:"v:imagedata"`
 	TextBox    *vTextBox    `xml:"v:textbox"`
 	ClientData *xClientData `xml:"x:ClientData"`
 }
@@ -284,20 +333,73 @@ type vmlOptions struct {
 
 // FormControl directly maps the form controls information.
 type FormControl struct {
-	Cell         string
-	Macro        string
-	Width        uint
-	Height       uint
-	Checked      bool
-	CurrentVal   uint
-	MinVal       uint
-	MaxVal       uint
-	IncChange    uint
-	PageChange   uint
-	Horizontally bool
-	CellLink     string
-	Text         string
-	Paragraph    []RichTextRun
-	Type         FormControlType
-	Format       GraphicOptions
+	Cell          string
+	Macro         string
+	Width         uint
+	Height        uint
+	Checked       bool
+	CurrentVal    uint
+	MinVal        uint
+	MaxVal        uint
+	IncChange     uint
+	PageChange    uint
+	Horizontally  bool
+	CellLink      string
+	InputRange    string
+	DropLines     uint
+	SelectionType string
+	ThreeDShading bool
+	Text          string
+	Paragraph     []RichTextRun
+	Type          FormControlType
+	Format        GraphicOptions
+}
+
+// HeaderFooterImageOptions defines the settings for an image to be accessible
+// from the worksheet header and footer options.
+type HeaderFooterImageOptions struct {
+	Position  HeaderFooterImagePositionType
+	File      []byte
+	IsFooter  bool
+	FirstPage bool
+	EvenPage  bool
+	Extension string
+	Width     uint
+	Height    uint
+}
+
+// xlsxOcx directly maps the ocx element in the namespace
+// http://schemas.microsoft.com/office/2006/activeX - The root element of the
+// ActiveX control part, which specifies the class identifier of the ActiveX
+// control and the relationship to the binary part of the persisted data.
+type xlsxOcx struct {
+	XMLName     xml.Name `xml:"ax:ocx"`
+	XMLNSAx     string   `xml:"xmlns:ax,attr"`
+	XMLNSR      string   `xml:"xmlns:r,attr"`
+	ClassID     string   `xml:"ax:classid,attr"`
+	Persistence string   `xml:"ax:persistence,attr"`
+	RID         string   `xml:"r:id,attr"`
+}
+
+// ActiveXControl directly maps the settings of the ActiveX control.
+type ActiveXControl struct {
+	Cell    string
+	Name    string
+	Caption string
+	Width   uint
+	Height  uint
+	Format  GraphicOptions
+}
+
+// OLEObject directly maps the settings of the embedded OLE (Object Linking and
+// Embedding) object.
+type OLEObject struct {
+	Cell     string
+	FileName string
+	File     []byte
+	Caption  string
+	Icon     *Picture
+	Width    uint
+	Height   uint
+	Format   GraphicOptions
 }
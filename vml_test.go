@@ -18,6 +18,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -81,6 +82,124 @@ func TestAddComment(t *testing.T) {
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
 
+func TestAddCommentBox(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Default comment box"}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B3", Author: "Excelize", Text: "Visible comment box", Width: 100, Height: 40, OffsetX: -100, OffsetY: -10, Visible: true}))
+	// Test add comment with the offsets exceeds the top-left corner of the worksheet
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment", OffsetX: -200, OffsetY: -200}))
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Len(t, vml.Shape, 3)
+	assert.Equal(t, "position:absolute;margin-left:59.25pt;margin-top:0pt;width:105pt;height:45pt;z-index:1;visibility:hidden", vml.Shape[0].Style)
+	assert.Contains(t, vml.Shape[0].Val, "<x:Anchor>1, 15, 0, 0, 3, 27, 3, 6</x:Anchor>")
+	assert.NotContains(t, vml.Shape[0].Val, "<x:Visible></x:Visible>")
+	assert.Equal(t, "position:absolute;margin-left:32.25pt;margin-top:19.5pt;width:75pt;height:30pt;z-index:1;visibility:visible", vml.Shape[1].Style)
+	assert.Contains(t, vml.Shape[1].Val, "<x:Anchor>0, 43, 1, 8, 2, 15, 3, 12</x:Anchor>")
+	assert.Contains(t, vml.Shape[1].Val, "<x:Visible></x:Visible>")
+	assert.Contains(t, vml.Shape[2].Val, "<x:Anchor>0, 0, 0, 0, 2, 12, 3, 6</x:Anchor>")
+	expected := []Comment{
+		{Cell: "A1", Width: 140, Height: 60},
+		{Cell: "B3", Width: 100, Height: 40, OffsetX: -100, OffsetY: -10, Visible: true},
+		{Cell: "A1", Width: 140, Height: 60, OffsetX: -79},
+	}
+	checkCommentBox := func(comments []Comment) {
+		assert.Len(t, comments, len(expected))
+		for i, comment := range comments {
+			assert.Equal(t, expected[i].Cell, comment.Cell)
+			assert.Equal(t, expected[i].Width, comment.Width)
+			assert.Equal(t, expected[i].Height, comment.Height)
+			assert.Equal(t, expected[i].OffsetX, comment.OffsetX)
+			assert.Equal(t, expected[i].OffsetY, comment.OffsetY)
+			assert.Equal(t, expected[i].Visible, comment.Visible)
+		}
+	}
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	checkCommentBox(comments)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddCommentBox.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestAddCommentBox.xlsx"))
+	assert.NoError(t, err)
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	checkCommentBox(comments)
+	// Test get comments with invalid anchor of the comment box
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape[0].Val = "<x:ClientData ObjectType=\"Note\"><x:Anchor>1, A, 0, 0, 3, 27, 3, 6</x:Anchor><x:Row>0</x:Row><x:Column>0</x:Column></x:ClientData>"
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, `strconv.Atoi: parsing "A": invalid syntax`)
+	// Test get comments with invalid cell reference of the comment box
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape[0].Val = "<x:ClientData ObjectType=\"Note\"><x:Row>0</x:Row><x:Column>-1</x:Column></x:ClientData>"
+	_, err = f.GetComments("Sheet1")
+	assert.Equal(t, newCoordinatesToCellNameError(0, 1), err)
+	// Test get comments with invalid VML shape
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape[0].Val = "<x:ClientData>"
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: element <ClientData> closed by </shape>")
+	// Test get comments with unsupported charset VML drawing
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"] = nil
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test get comments with the creation date time of the threaded comments
+	f = NewFile()
+	dateTime := time.Date(2023, 6, 1, 8, 30, 0, 0, time.UTC)
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Text: "Thread", DateTime: dateTime}))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, dateTime, comments[0].DateTime)
+	// Test get comments with unsupported charset threaded comments
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestEditComment(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment 1"}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B2", Author: "Reviewer", Text: "Comment 2"}))
+	assert.NoError(t, f.EditComment("Sheet1", Comment{Cell: "A1", Paragraph: []RichTextRun{{Text: "Excelize: ", Font: &Font{Bold: true}}, {Text: "Updated"}}}))
+	assert.NoError(t, f.EditComment("Sheet1", Comment{Cell: "B2", Author: "Excelize", Text: "Updated comment 2"}))
+	assert.NoError(t, f.EditComment("Sheet1", Comment{Cell: "B2", Author: "Editor", Text: "Updated comment 2"}))
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "Excelize", comments[0].Author)
+	assert.Empty(t, comments[0].Text)
+	assert.Len(t, comments[0].Paragraph, 2)
+	assert.Equal(t, "Excelize: ", comments[0].Paragraph[0].Text)
+	assert.True(t, comments[0].Paragraph[0].Font.Bold)
+	assert.Equal(t, "Updated", comments[0].Paragraph[1].Text)
+	assert.Equal(t, "Editor", comments[1].Author)
+	assert.Equal(t, 2, comments[1].AuthorID)
+	assert.Equal(t, "Updated comment 2", comments[1].Text)
+	assert.Len(t, f.VMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape, 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestEditComment.xlsx")))
+	// Test edit comment on the cell without comment
+	assert.Equal(t, newNoExistCommentError("C3"), f.EditComment("Sheet1", Comment{Cell: "C3", Text: "Comment"}))
+	// Test edit comment with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.EditComment("Sheet1", Comment{Cell: "A", Text: "Comment"}))
+	// Test edit comment on not exists worksheet
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.EditComment("SheetN", Comment{Cell: "A1", Text: "Comment"}))
+	// Test edit comment with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.EditComment("Sheet1", Comment{Cell: "A1", Text: "Comment"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test edit comment with unsupported charset comments
+	f = NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment 1"}))
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.EditComment("Sheet1", Comment{Cell: "A1", Text: "Comment"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteComment(t *testing.T) {
 	f, err := prepareTestBook1()
 	if !assert.NoError(t, err) {
@@ -403,8 +522,198 @@ func TestFormControl(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestAddListFormControl(t *testing.T) {
+	f := NewFile()
+	for i, item := range []string{"Apple", "Banana", "Cherry"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", i+1), item))
+	}
+	formControls := []FormControl{
+		{
+			Cell: "C1", Type: FormControlComboBox, Width: 100, Height: 20,
+			InputRange: "$A$1:$A$3", CellLink: "E1", CurrentVal: 2, DropLines: 3, ThreeDShading: true,
+		},
+		{
+			Cell: "C3", Type: FormControlComboBox, Width: 100, Height: 20,
+			InputRange: "Sheet1!$A$1:$A$3",
+		},
+		{
+			Cell: "C5", Type: FormControlListBox, Width: 100, Height: 60,
+			InputRange: "$A$1:$A$3", CellLink: "E5", CurrentVal: 1, SelectionType: "Multi",
+		},
+	}
+	for _, formCtrl := range formControls {
+		assert.NoError(t, f.AddFormControl("Sheet1", formCtrl))
+	}
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Contains(t, vml.Shape[0].Val, "<x:DropStyle>Combo</x:DropStyle>")
+	assert.NotContains(t, vml.Shape[0].Val, "<x:NoThreeD2></x:NoThreeD2>")
+	assert.Contains(t, vml.Shape[1].Val, "<x:DropLines>8</x:DropLines>")
+	assert.Contains(t, vml.Shape[1].Val, "<x:NoThreeD2></x:NoThreeD2>")
+	assert.Contains(t, vml.Shape[2].Val, "<x:SelType>Multi</x:SelType>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddListFormControl.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestAddListFormControl.xlsx"))
+	assert.NoError(t, err)
+	// Test get combo box and list box form controls
+	result, err := f.GetFormControls("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+	expected := []FormControl{
+		{Cell: "C1", Type: FormControlComboBox, InputRange: "$A$1:$A$3", CellLink: "E1", CurrentVal: 2, DropLines: 3, ThreeDShading: true},
+		{Cell: "C3", Type: FormControlComboBox, InputRange: "Sheet1!$A$1:$A$3", DropLines: 8},
+		{Cell: "C5", Type: FormControlListBox, InputRange: "$A$1:$A$3", CellLink: "E5", CurrentVal: 1, SelectionType: "Multi"},
+	}
+	for i, formCtrl := range expected {
+		assert.Equal(t, formCtrl.Cell, result[i].Cell)
+		assert.Equal(t, formCtrl.Type, result[i].Type)
+		assert.Equal(t, formCtrl.InputRange, result[i].InputRange)
+		assert.Equal(t, formCtrl.CellLink, result[i].CellLink)
+		assert.Equal(t, formCtrl.CurrentVal, result[i].CurrentVal)
+		assert.Equal(t, formCtrl.DropLines, result[i].DropLines)
+		assert.Equal(t, formCtrl.SelectionType, result[i].SelectionType)
+		assert.Equal(t, formCtrl.ThreeDShading, result[i].ThreeDShading)
+	}
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add combo box and list box form controls with invalid options
+	assert.Equal(t, ErrFormControlValue, f.AddFormControl("Sheet1", FormControl{
+		Cell: "C1", Type: FormControlComboBox, CurrentVal: MaxFormControlValue + 1,
+	}))
+	assert.Equal(t, ErrFormControlValue, f.AddFormControl("Sheet1", FormControl{
+		Cell: "C1", Type: FormControlComboBox, DropLines: MaxFormControlValue + 1,
+	}))
+	assert.Equal(t, newCellNameToCoordinatesError("E", newInvalidCellNameError("E")), f.AddFormControl("Sheet1", FormControl{
+		Cell: "C1", Type: FormControlComboBox, CellLink: "E",
+	}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddFormControl("Sheet1", FormControl{
+		Cell: "C1", Type: FormControlListBox, InputRange: "Sheet1!A:A3",
+	}))
+	assert.Equal(t, ErrParameterInvalid, f.AddFormControl("Sheet1", FormControl{
+		Cell: "C1", Type: FormControlListBox, InputRange: "A1:A3", SelectionType: "Double",
+	}))
+	assert.NoError(t, f.Close())
+}
+
+func TestGetFormControlBox(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "C", 20))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 3, 30))
+	formControls := []FormControl{
+		{Cell: "A1", Type: FormControlButton, Text: "Button 1"},
+		{Cell: "B2", Type: FormControlCheckBox, Text: "Check Box 1", Width: 200, Height: 45, Format: GraphicOptions{OffsetX: 10, OffsetY: 5}},
+		{Cell: "D5", Type: FormControlSpinButton, Width: 20, Height: 40, Format: GraphicOptions{OffsetX: 3, OffsetY: 17}},
+	}
+	for _, formCtrl := range formControls {
+		assert.NoError(t, f.AddFormControl("Sheet1", formCtrl))
+	}
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Equal(t, "position:absolute;margin-left:55.5pt;margin-top:17.25pt;width:150pt;height:33.75pt;z-index:1;mso-wrap-style:tight", vml.Shape[1].Style)
+	result, err := f.GetFormControls("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+	expected := [][4]int{{140, 60, 0, 0}, {200, 45, 10, 5}, {20, 40, 3, 17}}
+	for i, formCtrl := range result {
+		assert.Equal(t, formControls[i].Cell, formCtrl.Cell)
+		assert.Equal(t, expected[i], [4]int{int(formCtrl.Width), int(formCtrl.Height), formCtrl.Format.OffsetX, formCtrl.Format.OffsetY})
+	}
+	// Test copy form controls to another worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for _, formCtrl := range result {
+		assert.NoError(t, f.AddFormControl("Sheet2", formCtrl))
+	}
+	copied, err := f.GetFormControls("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, copied, 3)
+	for i, formCtrl := range copied {
+		assert.Equal(t, result[i].Cell, formCtrl.Cell)
+		assert.Equal(t, result[i].Text, formCtrl.Text)
+		assert.Equal(t, expected[i], [4]int{int(formCtrl.Width), int(formCtrl.Height), formCtrl.Format.OffsetX, formCtrl.Format.OffsetY})
+	}
+	// Test get form controls with offset greater than the cell size
+	assert.NoError(t, f.AddFormControl("Sheet1", FormControl{Cell: "F1", Type: FormControlLabel, Text: "Label 1", Width: 50, Height: 20, Format: GraphicOptions{OffsetX: 70, OffsetY: 25}}))
+	result, err = f.GetFormControls("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "G2", result[3].Cell)
+	assert.Equal(t, [4]int{50, 20, 6, 7}, [4]int{int(result[3].Width), int(result[3].Height), result[3].Format.OffsetX, result[3].Format.OffsetY})
+	// Test get form controls with invalid shape anchor
+	f.VMLDrawing["xl/drawings/vmlDrawing1.vml"] = &vmlDrawing{
+		Shape: []xlsxShape{{Type: "#_x0000_t201", Val: "<x:ClientData ObjectType=\"Scroll\"><x:Anchor>0,0,0,0,0,0,0,x</x:Anchor></x:ClientData>"}},
+	}
+	_, err = f.GetFormControls("Sheet1")
+	assert.EqualError(t, err, "strconv.Atoi: parsing \"x\": invalid syntax")
+	assert.NoError(t, f.Close())
+}
+
 func TestExtractFormControl(t *testing.T) {
 	// Test extract form control with unsupported charset
-	_, err := extractFormControl(string(MacintoshCyrillicCharset))
+	_, err := NewFile().extractFormControl("Sheet1", string(MacintoshCyrillicCharset))
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestAddHeaderFooterImage(t *testing.T) {
+	f := NewFile()
+	file, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	for _, opts := range []*HeaderFooterImageOptions{
+		{Position: HeaderFooterImagePositionCenter, File: file, Extension: ".png"},
+		{Position: HeaderFooterImagePositionLeft, File: file, IsFooter: true, Extension: ".png", Width: 50, Height: 32},
+		{Position: HeaderFooterImagePositionRight, File: file, FirstPage: true, Extension: ".png"},
+		{Position: HeaderFooterImagePositionCenter, File: file, Extension: ".png", Width: 100, Height: 64},
+	} {
+		assert.NoError(t, f.AddHeaderFooterImage("Sheet1", opts))
+	}
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
+		DifferentFirst: true,
+		OddHeader:      "&C&G",
+		OddFooter:      "&L&G",
+		FirstHeader:    "&R&G",
+	}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.NotNil(t, ws.LegacyDrawingHF)
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Len(t, vml.Shape, 3)
+	assert.Equal(t, "CH", vml.Shape[2].ID)
+	assert.Contains(t, vml.Shape[2].Style, "width:75pt;height:48pt")
+	assert.Equal(t, []string{"_x0000_s1026", "_x0000_s1027", "_x0000_s1028"}, []string{vml.Shape[0].SpID, vml.Shape[1].SpID, vml.Shape[2].SpID})
+	rels, err := f.relsReader("xl/drawings/_rels/vmlDrawing1.vml.rels")
+	assert.NoError(t, err)
+	assert.Len(t, rels.Relationships, 3)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddHeaderFooterImage.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test add header and footer image on the existing VML drawing part
+	f, err = OpenFile(filepath.Join("test", "TestAddHeaderFooterImage.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{
+		Position: HeaderFooterImagePositionRight, File: file, IsFooter: true, Extension: ".png",
+	}))
+	vml = f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Len(t, vml.Shape, 4)
+	assert.Equal(t, []string{"LF", "RHFIRST", "CH", "RF"}, []string{vml.Shape[0].ID, vml.Shape[1].ID, vml.Shape[2].ID, vml.Shape[3].ID})
+	// Test add header and footer image for the even pages
+	assert.NoError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{
+		Position: HeaderFooterImagePositionLeft, File: file, EvenPage: true, Extension: ".png",
+	}))
+	assert.Len(t, vml.Shape, 5)
+	assert.Equal(t, "LHEVEN", vml.Shape[4].ID)
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add header and footer image with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.AddHeaderFooterImage("Sheet1", nil))
+	assert.Equal(t, ErrParameterInvalid, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{Position: 3}))
+	assert.Equal(t, ErrParameterInvalid, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{FirstPage: true, EvenPage: true}))
+	// Test add header and footer image with unsupported image type
+	assert.Equal(t, ErrImgExt, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{File: file, Extension: ".txt"}))
+	// Test add header and footer image with not exist worksheet
+	assert.EqualError(t, f.AddHeaderFooterImage("SheetN", &HeaderFooterImageOptions{File: file, Extension: ".png"}), "sheet SheetN does not exist")
+	// Test add header and footer image with unsupported charset VML drawing
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	_, err = f.pictureVMLReader(1, "xl/drawings/vmlDrawing1.vml")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
@@ -15,11 +15,18 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
 
+// externalSheetDataSetRegexp matches the element which stores the cached
+// values of the cells in the external workbook references part.
+var externalSheetDataSetRegexp = regexp.MustCompile(`(?s)<(?:[a-zA-Z0-9]+:)?sheetDataSet(?:\s[^>]*)?(?:/>|>.*?</(?:[a-zA-Z0-9]+:)?sheetDataSet>)`)
+
 // SetWorkbookProps provides a function to sets workbook properties.
 func (f *File) SetWorkbookProps(opts *WorkbookPropsOptions) error {
 	wb, err := f.workbookReader()
@@ -34,6 +41,7 @@ func (f *File) SetWorkbookProps(opts *WorkbookPropsOptions) error {
 	}
 	if opts.Date1904 != nil {
 		wb.WorkbookPr.Date1904 = *opts.Date1904
+		f.clearCalcCache()
 	}
 	if opts.FilterPrivacy != nil {
 		wb.WorkbookPr.FilterPrivacy = *opts.FilterPrivacy
@@ -47,7 +55,7 @@ func (f *File) SetWorkbookProps(opts *WorkbookPropsOptions) error {
 // GetWorkbookProps provides a function to gets workbook properties.
 func (f *File) GetWorkbookProps() (WorkbookPropsOptions, error) {
 	var opts WorkbookPropsOptions
-	wb, err := f.workbookReader()
+	wb, err := f.workbookViewer()
 	if err != nil {
 		return opts, err
 	}
@@ -59,13 +67,183 @@ func (f *File) GetWorkbookProps() (WorkbookPropsOptions, error) {
 	return opts, err
 }
 
+// SetCalcProps provides a function to sets calculation properties. Optional
+// value of "CalcMode" property is: "manual", "auto" or "autoNoTable". Optional
+// value of "RefMode" property is: "A1" or "R1C1". For example, ask the
+// spreadsheet application to perform a full calculation of all formulas when
+// the workbook is opened, instead of removing the cached values by the
+// UpdateLinkedValue function:
+//
+//	fullCalcOnLoad := true
+//	err := f.SetCalcProps(&excelize.CalcPropsOptions{
+//	    FullCalcOnLoad: &fullCalcOnLoad,
+//	})
+func (f *File) SetCalcProps(opts *CalcPropsOptions) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.CalcPr == nil {
+		wb.CalcPr = new(xlsxCalcPr)
+	}
+	if opts == nil {
+		return nil
+	}
+	if opts.CalcMode != nil {
+		if inStrSlice(supportedCalcMode, *opts.CalcMode, true) == -1 {
+			return newInvalidOptionalValue("CalcMode", *opts.CalcMode, supportedCalcMode)
+		}
+		wb.CalcPr.CalcMode = *opts.CalcMode
+	}
+	if opts.RefMode != nil {
+		if inStrSlice(supportedRefMode, *opts.RefMode, true) == -1 {
+			return newInvalidOptionalValue("RefMode", *opts.RefMode, supportedRefMode)
+		}
+		wb.CalcPr.RefMode = *opts.RefMode
+	}
+	if opts.CalcID != nil {
+		wb.CalcPr.CalcID = *opts.CalcID
+	}
+	if opts.FullCalcOnLoad != nil {
+		wb.CalcPr.FullCalcOnLoad = *opts.FullCalcOnLoad
+	}
+	if opts.Iterate != nil {
+		wb.CalcPr.Iterate = *opts.Iterate
+	}
+	if opts.IterateCount != nil {
+		wb.CalcPr.IterateCount = uintPtr(*opts.IterateCount)
+	}
+	if opts.IterateDelta != nil {
+		wb.CalcPr.IterateDelta = float64Ptr(*opts.IterateDelta)
+	}
+	if opts.FullPrecision != nil {
+		wb.CalcPr.FullPrecision = boolPtr(*opts.FullPrecision)
+	}
+	if opts.CalcCompleted != nil {
+		wb.CalcPr.CalcCompleted = boolPtr(*opts.CalcCompleted)
+	}
+	if opts.CalcOnSave != nil {
+		wb.CalcPr.CalcOnSave = boolPtr(*opts.CalcOnSave)
+	}
+	if opts.ConcurrentCalc != nil {
+		wb.CalcPr.ConcurrentCalc = boolPtr(*opts.ConcurrentCalc)
+	}
+	if opts.ConcurrentManualCount != nil {
+		wb.CalcPr.ConcurrentManualCount = *opts.ConcurrentManualCount
+	}
+	if opts.ForceFullCalc != nil {
+		wb.CalcPr.ForceFullCalc = *opts.ForceFullCalc
+	}
+	return err
+}
+
+// GetCalcProps provides a function to gets calculation properties, the
+// default value will be returned if the property was not set.
+func (f *File) GetCalcProps() (CalcPropsOptions, error) {
+	opts := CalcPropsOptions{
+		CalcMode:       stringPtr("auto"),
+		FullCalcOnLoad: boolPtr(false),
+		RefMode:        stringPtr("A1"),
+		Iterate:        boolPtr(false),
+		IterateCount:   uintPtr(100),
+		IterateDelta:   float64Ptr(0.001),
+		FullPrecision:  boolPtr(true),
+		CalcCompleted:  boolPtr(true),
+		CalcOnSave:     boolPtr(true),
+		ConcurrentCalc: boolPtr(true),
+		ForceFullCalc:  boolPtr(false),
+	}
+	wb, err := f.workbookViewer()
+	if err != nil || wb.CalcPr == nil {
+		return opts, err
+	}
+	opts.CalcID = uintPtr(wb.CalcPr.CalcID)
+	if wb.CalcPr.CalcMode != "" {
+		opts.CalcMode = stringPtr(wb.CalcPr.CalcMode)
+	}
+	if wb.CalcPr.RefMode != "" {
+		opts.RefMode = stringPtr(wb.CalcPr.RefMode)
+	}
+	opts.FullCalcOnLoad = boolPtr(wb.CalcPr.FullCalcOnLoad)
+	opts.Iterate = boolPtr(wb.CalcPr.Iterate)
+	if wb.CalcPr.IterateCount != nil {
+		opts.IterateCount = uintPtr(*wb.CalcPr.IterateCount)
+	}
+	if wb.CalcPr.IterateDelta != nil {
+		opts.IterateDelta = float64Ptr(*wb.CalcPr.IterateDelta)
+	}
+	if wb.CalcPr.FullPrecision != nil {
+		opts.FullPrecision = boolPtr(*wb.CalcPr.FullPrecision)
+	}
+	if wb.CalcPr.CalcCompleted != nil {
+		opts.CalcCompleted = boolPtr(*wb.CalcPr.CalcCompleted)
+	}
+	if wb.CalcPr.CalcOnSave != nil {
+		opts.CalcOnSave = boolPtr(*wb.CalcPr.CalcOnSave)
+	}
+	if wb.CalcPr.ConcurrentCalc != nil {
+		opts.ConcurrentCalc = boolPtr(*wb.CalcPr.ConcurrentCalc)
+	}
+	opts.ConcurrentManualCount = uintPtr(wb.CalcPr.ConcurrentManualCount)
+	opts.ForceFullCalc = boolPtr(wb.CalcPr.ForceFullCalc)
+	return opts, err
+}
+
+// DateSystem is the type of the date system used by the workbook.
+type DateSystem byte
+
+// This section defines the supported date systems.
+const (
+	DateSystem1900 DateSystem = iota
+	DateSystem1904
+)
+
+// SetWorkbookDateSystem provides a function to set the date system of the
+// workbook. The workbooks created by Excel for Mac before 2011 use the 1904
+// date system, which starts counting the serial date numbers from January 1,
+// 1904. The dates set by the SetCellValue, SetCellDate and the stream writer
+// will be converted to the serial date numbers in the date system of the
+// workbook, and the dates will be read in the same system. Note that the
+// serial date numbers already stored in the workbook will not be changed, so
+// the dates shift by 1462 days after changing the date system. For example,
+// use the 1904 date system:
+//
+//	err := f.SetWorkbookDateSystem(excelize.DateSystem1904)
+func (f *File) SetWorkbookDateSystem(dateSystem DateSystem) error {
+	if dateSystem != DateSystem1900 && dateSystem != DateSystem1904 {
+		return ErrParameterInvalid
+	}
+	return f.SetWorkbookProps(&WorkbookPropsOptions{Date1904: boolPtr(dateSystem == DateSystem1904)})
+}
+
+// GetWorkbookDateSystem provides a function to get the date system of the
+// workbook.
+func (f *File) GetWorkbookDateSystem() (DateSystem, error) {
+	date1904, err := f.getDate1904()
+	if date1904 {
+		return DateSystem1904, err
+	}
+	return DateSystem1900, err
+}
+
+// getDate1904 provides a function to check if the workbook uses the 1904 date
+// system.
+func (f *File) getDate1904() (bool, error) {
+	wb, err := f.workbookViewer()
+	if err != nil {
+		return false, err
+	}
+	return wb != nil && wb.WorkbookPr != nil && wb.WorkbookPr.Date1904, err
+}
+
 // ProtectWorkbook provides a function to prevent other users from viewing
 // hidden worksheets, adding, moving, deleting, or hiding worksheets, and
 // renaming worksheets in a workbook. The optional field AlgorithmName
-// specified hash algorithm, support XOR, MD4, MD5, SHA-1, SHA2-56, SHA-384,
-// and SHA-512 currently, if no hash algorithm specified, will be using the XOR
-// algorithm as default. The generated workbook only works on Microsoft Office
-// 2007 and later. For example, protect workbook with protection settings:
+// specified hash algorithm, support XOR, MD4, MD5, SHA-1, SHA-256, SHA-384,
+// and SHA-512 currently, if no hash algorithm specified, will be using the
+// SHA-512 algorithm as default. The generated workbook only works on Microsoft
+// Office 2007 and later. For example, protect workbook with protection
+// settings:
 //
 //	err := f.ProtectWorkbook(&excelize.WorkbookProtectionOptions{
 //	    Password:      "password",
@@ -87,6 +265,10 @@ func (f *File) ProtectWorkbook(opts *WorkbookProtectionOptions) error {
 		LockWindows:   opts.LockWindows,
 	}
 	if opts.Password != "" {
+		if opts.AlgorithmName == "XOR" {
+			wb.WorkbookProtection.WorkbookPassword = genSheetPasswd(opts.Password)
+			return nil
+		}
 		if opts.AlgorithmName == "" {
 			opts.AlgorithmName = "SHA-512"
 		}
@@ -115,6 +297,10 @@ func (f *File) UnprotectWorkbook(password ...string) error {
 		if wb.WorkbookProtection == nil {
 			return ErrUnprotectWorkbook
 		}
+		if wb.WorkbookProtection.WorkbookAlgorithmName == "" && wb.WorkbookProtection.WorkbookPassword != "" &&
+			wb.WorkbookProtection.WorkbookPassword != genSheetPasswd(password[0]) {
+			return ErrUnprotectWorkbookPassword
+		}
 		if wb.WorkbookProtection.WorkbookAlgorithmName != "" {
 			// check with given salt value
 			hashValue, _, err := genISOPasswdHash(password[0], wb.WorkbookProtection.WorkbookAlgorithmName, wb.WorkbookProtection.WorkbookSaltValue, wb.WorkbookProtection.WorkbookSpinCount)
@@ -130,6 +316,183 @@ func (f *File) UnprotectWorkbook(password ...string) error {
 	return err
 }
 
+// RemoveMetadata provides a function to remove the personal information and
+// the hidden data of the workbook before publishing it by given options. The
+// author and the organization in the document properties, the names of the
+// comment authors and the cached values of the external workbook references
+// will always be removed, the comments, hidden worksheets, hidden rows and
+// hidden columns will be deleted when the corresponding options were set.
+// Note that deleting worksheets, rows and columns will affect the references
+// in formulas and others as DeleteSheet, RemoveRow and RemoveCol do. For
+// example, remove all the personal information and hidden data:
+//
+//	err := f.RemoveMetadata(&excelize.RemoveMetadataOptions{
+//	    Comments:       true,
+//	    HiddenSheets:   true,
+//	    HiddenRowsCols: true,
+//	})
+func (f *File) RemoveMetadata(opts *RemoveMetadataOptions) error {
+	if opts == nil {
+		opts = &RemoveMetadataOptions{}
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if err = f.removeDocPropsPersonalInfo(); err != nil {
+		return err
+	}
+	f.clearCalcCache()
+	if opts.HiddenSheets {
+		var hiddenSheets []string
+		for _, sheet := range wb.Sheets.Sheet {
+			if sheet.State == "hidden" || sheet.State == "veryHidden" {
+				hiddenSheets = append(hiddenSheets, sheet.Name)
+			}
+		}
+		for _, sheet := range hiddenSheets {
+			if err = f.DeleteSheet(sheet); err != nil {
+				return err
+			}
+		}
+	}
+	for _, sheet := range f.GetSheetList() {
+		if sheetXMLPath, _ := f.getSheetXMLPath(sheet); !strings.HasPrefix(sheetXMLPath, "xl/worksheets/") {
+			continue
+		}
+		if err = f.removeCommentsPersonalInfo(sheet, opts.Comments); err != nil {
+			return err
+		}
+		if opts.HiddenRowsCols {
+			if err = f.removeHiddenRowsCols(sheet); err != nil {
+				return err
+			}
+		}
+	}
+	if err = f.removePersonsInfo(); err != nil {
+		return err
+	}
+	return f.removeExternalLinksCache()
+}
+
+// removeCommentsPersonalInfo provides a function to remove the names of the
+// comment authors by given worksheet name, or delete all comments and
+// threaded comments in the worksheet.
+func (f *File) removeCommentsPersonalInfo(sheet string, deleteComments bool) error {
+	if deleteComments {
+		if err := f.updateThreadedComments(sheet, func(tcs *xlsxThreadedComments, persons *xlsxPersonList) error {
+			tcs.ThreadedComment = nil
+			return nil
+		}); err != nil {
+			return err
+		}
+		return f.deleteComments(sheet, func(ref string) bool { return true })
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	commentsXML := f.getSheetComments(filepath.Base(sheetXMLPath))
+	if !strings.HasPrefix(commentsXML, "/") {
+		commentsXML = "xl" + strings.TrimPrefix(commentsXML, "..")
+	}
+	commentsXML = strings.TrimPrefix(commentsXML, "/")
+	cmts, err := f.commentsReader(commentsXML)
+	if err != nil || cmts == nil {
+		return err
+	}
+	for i, author := range cmts.Authors.Author {
+		if !strings.HasPrefix(author, "tc=") {
+			cmts.Authors.Author[i] = "Author"
+		}
+	}
+	return err
+}
+
+// removePersonsInfo provides a function to remove the names and the identity
+// of the threaded comments authors in the person list of the workbook.
+func (f *File) removePersonsInfo() error {
+	personsXML, err := f.getWorkbookPartPath(SourceRelationshipPerson)
+	if err != nil || personsXML == "" {
+		return err
+	}
+	persons, err := f.personListReader(personsXML)
+	if err != nil {
+		return err
+	}
+	for i := range persons.Person {
+		persons.Person[i].DisplayName = "Author"
+		persons.Person[i].UserID, persons.Person[i].ProviderID = "", ""
+	}
+	output, err := xml.Marshal(persons)
+	f.saveFileList(personsXML, output)
+	return err
+}
+
+// removeHiddenRowsCols provides a function to delete the hidden rows and
+// columns by given worksheet name.
+func (f *File) removeHiddenRowsCols(sheet string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var rows []int
+	for _, row := range ws.SheetData.Row {
+		if row.Hidden {
+			rows = append(rows, row.R)
+		}
+	}
+	var cols []xlsxCol
+	if ws.Cols != nil {
+		for _, col := range ws.Cols.Col {
+			if col.Hidden {
+				cols = append(cols, col)
+			}
+		}
+	}
+	for i := len(rows) - 1; i >= 0; i-- {
+		if err = f.RemoveRow(sheet, rows[i]); err != nil {
+			return err
+		}
+	}
+	sort.Slice(cols, func(i, j int) bool { return cols[i].Min > cols[j].Min })
+	for _, col := range cols {
+		colName, err := ColumnNumberToName(col.Min)
+		if err != nil {
+			return err
+		}
+		if err = f.RemoveCols(sheet, colName, col.Max-col.Min+1); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// removeExternalLinksCache provides a function to remove the cached values of
+// the cells in the external workbook references parts.
+func (f *File) removeExternalLinksCache() error {
+	wb, err := f.workbookReader()
+	if err != nil || wb.ExternalReferences == nil {
+		return err
+	}
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil || rels == nil {
+		return err
+	}
+	for _, ref := range wb.ExternalReferences.ExternalReference {
+		for _, rel := range rels.Relationships {
+			if rel.ID != ref.RID {
+				continue
+			}
+			linkPath := strings.TrimPrefix(rel.Target, "/")
+			if !strings.HasPrefix(rel.Target, "/") {
+				linkPath = path.Join(path.Dir(f.getWorkbookPath()), rel.Target)
+			}
+			if content := f.readXML(linkPath); len(content) > 0 {
+				f.Pkg.Store(linkPath, externalSheetDataSetRegexp.ReplaceAll(content, nil))
+			}
+		}
+	}
+	return err
+}
+
 // setWorkbook update workbook property of the spreadsheet. Maximum 31
 // characters are allowed in sheet title.
 func (f *File) setWorkbook(name string, sheetID, rid int) {
@@ -144,7 +507,7 @@ func (f *File) setWorkbook(name string, sheetID, rid int) {
 // getWorkbookPath provides a function to get the path of the workbook.xml in
 // the spreadsheet.
 func (f *File) getWorkbookPath() (path string) {
-	if rels, _ := f.relsReader("_rels/.rels"); rels != nil {
+	if rels, _ := f.relsViewer("_rels/.rels"); rels != nil {
 		rels.mu.Lock()
 		defer rels.mu.Unlock()
 		for _, rel := range rels.Relationships {
@@ -191,11 +554,26 @@ func (f *File) deleteWorkbookRels(relType, relTarget string) (string, error) {
 }
 
 // workbookReader provides a function to get the pointer to the workbook.xml
-// structure after deserialization.
+// structure after deserialization, and track the workbook was modified.
 func (f *File) workbookReader() (*xlsxWorkbook, error) {
+	return f.getWorkbook(false)
+}
+
+// workbookViewer provides a function to get the pointer to the workbook.xml
+// structure after deserialization for read only. If the workbook was loaded
+// by this function and never be got by the workbookReader, the original part
+// will be copied verbatim on save instead of serialize the structure.
+func (f *File) workbookViewer() (*xlsxWorkbook, error) {
+	return f.getWorkbook(true)
+}
+
+// getWorkbook provides a function to get the pointer to the workbook.xml
+// structure after deserialization, and track if the workbook was modified.
+func (f *File) getWorkbook(readOnly bool) (*xlsxWorkbook, error) {
 	var err error
+	wbPath := f.getWorkbookPath()
+	defer f.trackPart(wbPath, f.WorkBook == nil, readOnly)
 	if f.WorkBook == nil {
-		wbPath := f.getWorkbookPath()
 		f.WorkBook = new(xlsxWorkbook)
 		if attrs, ok := f.xmlAttr.Load(wbPath); !ok {
 			d := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(wbPath))))
@@ -217,6 +595,9 @@ func (f *File) workbookReader() (*xlsxWorkbook, error) {
 // workBookWriter provides a function to save workbook.xml after serialize
 // structure.
 func (f *File) workBookWriter() {
+	if _, ok := f.unmodified.Load(f.getWorkbookPath()); ok {
+		return
+	}
 	if f.WorkBook != nil {
 		if f.WorkBook.DecodeAlternateContent != nil {
 			f.WorkBook.AlternateContent = &xlsxAlternateContent{
@@ -310,28 +691,56 @@ func (f *File) addContentTypePart(index int, contentType string) error {
 		"drawings": f.setContentTypePartImageExtensions,
 	}
 	partNames := map[string]string{
-		"chart":         "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
-		"chartsheet":    "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
-		"comments":      "/xl/comments" + strconv.Itoa(index) + ".xml",
-		"drawings":      "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
-		"table":         "/xl/tables/table" + strconv.Itoa(index) + ".xml",
-		"pivotTable":    "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
-		"pivotCache":    "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
-		"sharedStrings": "/xl/sharedStrings.xml",
-		"slicer":        "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
-		"slicerCache":   "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
+		"activeX":              "/xl/activeX/activeX" + strconv.Itoa(index) + ".xml",
+		"activeXBinary":        "/xl/activeX/activeX" + strconv.Itoa(index) + ".bin",
+		"chart":                "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
+		"chartEx":              "/xl/charts/chartEx" + strconv.Itoa(index) + ".xml",
+		"chartsheet":           "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
+		"comments":             "/xl/comments" + strconv.Itoa(index) + ".xml",
+		"drawings":             "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
+		"table":                "/xl/tables/table" + strconv.Itoa(index) + ".xml",
+		"pivotTable":           "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
+		"pivotCache":           "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
+		"person":               "/xl/persons/person.xml",
+		"metadata":             "/xl/metadata.xml",
+		"oleObject":            "/xl/embeddings/oleObject" + strconv.Itoa(index) + ".bin",
+		"rdRichValue":          "/xl/richData/rdrichvalue.xml",
+		"rdRichValueStructure": "/xl/richData/rdrichvaluestructure.xml",
+		"rdRichValueTypes":     "/xl/richData/rdRichValueTypes.xml",
+		"richValueRel":         "/xl/richData/richValueRel.xml",
+		"sharedStrings":        "/xl/sharedStrings.xml",
+		"slicer":               "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
+		"slicerCache":          "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
+		"theme":                "/" + defaultXMLPathTheme,
+		"threadedComment":      "/xl/threadedComments/threadedComment" + strconv.Itoa(index) + ".xml",
+		"timeline":             "/xl/timelines/timeline" + strconv.Itoa(index) + ".xml",
+		"timelineCache":        "/xl/timelineCaches/timelineCache" + strconv.Itoa(index) + ".xml",
 	}
 	contentTypes := map[string]string{
-		"chart":         ContentTypeDrawingML,
-		"chartsheet":    ContentTypeSpreadSheetMLChartsheet,
-		"comments":      ContentTypeSpreadSheetMLComments,
-		"drawings":      ContentTypeDrawing,
-		"table":         ContentTypeSpreadSheetMLTable,
-		"pivotTable":    ContentTypeSpreadSheetMLPivotTable,
-		"pivotCache":    ContentTypeSpreadSheetMLPivotCacheDefinition,
-		"sharedStrings": ContentTypeSpreadSheetMLSharedStrings,
-		"slicer":        ContentTypeSlicer,
-		"slicerCache":   ContentTypeSlicerCache,
+		"activeX":              ContentTypeActiveX,
+		"activeXBinary":        ContentTypeActiveXBinary,
+		"chart":                ContentTypeDrawingML,
+		"chartEx":              ContentTypeChartEx,
+		"chartsheet":           ContentTypeSpreadSheetMLChartsheet,
+		"comments":             ContentTypeSpreadSheetMLComments,
+		"drawings":             ContentTypeDrawing,
+		"table":                ContentTypeSpreadSheetMLTable,
+		"pivotTable":           ContentTypeSpreadSheetMLPivotTable,
+		"pivotCache":           ContentTypeSpreadSheetMLPivotCacheDefinition,
+		"person":               ContentTypePerson,
+		"metadata":             ContentTypeSheetMetadata,
+		"oleObject":            ContentTypeOLEObject,
+		"rdRichValue":          ContentTypeRDRichValue,
+		"rdRichValueStructure": ContentTypeRDRichValueStructure,
+		"rdRichValueTypes":     ContentTypeRDRichValueTypes,
+		"richValueRel":         ContentTypeRichValueRel,
+		"sharedStrings":        ContentTypeSpreadSheetMLSharedStrings,
+		"slicer":               ContentTypeSlicer,
+		"slicerCache":          ContentTypeSlicerCache,
+		"theme":                ContentTypeTheme,
+		"threadedComment":      ContentTypeThreadedComments,
+		"timeline":             ContentTypeTimeline,
+		"timelineCache":        ContentTypeTimelineCache,
 	}
 	s, ok := setContentType[contentType]
 	if ok {
@@ -339,7 +748,7 @@ func (f *File) addContentTypePart(index int, contentType string) error {
 			return err
 		}
 	}
-	content, err := f.contentTypesReader()
+	content, err := f.contentTypesViewer()
 	if err != nil {
 		return err
 	}
@@ -350,6 +759,7 @@ func (f *File) addContentTypePart(index int, contentType string) error {
 			return err
 		}
 	}
+	f.trackPart(defaultXMLPathContentTypes, false, false)
 	content.Overrides = append(content.Overrides, xlsxOverride{
 		PartName:    partNames[contentType],
 		ContentType: contentTypes[contentType],
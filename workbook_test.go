@@ -1,7 +1,10 @@
 package excelize
 
 import (
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,6 +35,123 @@ func TestWorkbookProps(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestWorkbookDateSystem(t *testing.T) {
+	f := NewFile()
+	dateSystem, err := f.GetWorkbookDateSystem()
+	assert.NoError(t, err)
+	assert.Equal(t, DateSystem1900, dateSystem)
+	assert.NoError(t, f.SetWorkbookDateSystem(DateSystem1904))
+	dateSystem, err = f.GetWorkbookDateSystem()
+	assert.NoError(t, err)
+	assert.Equal(t, DateSystem1904, dateSystem)
+	// Test dates round trip in the 1904 date system
+	date := time.Date(2023, time.June, 1, 12, 30, 0, 0, time.UTC)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", date))
+	raw, err := f.GetCellValue("Sheet1", "A1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "43616.520833333336", raw)
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "6/1/23 12:30", val)
+	typed, _, err := f.GetCellValueTyped("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, date, typed)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	dateSystem, err = f.GetWorkbookDateSystem()
+	assert.NoError(t, err)
+	assert.Equal(t, DateSystem1904, dateSystem)
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "6/1/23 12:30", val)
+	assert.NoError(t, f.SetWorkbookDateSystem(DateSystem1900))
+	dateSystem, err = f.GetWorkbookDateSystem()
+	assert.NoError(t, err)
+	assert.Equal(t, DateSystem1900, dateSystem)
+	// Test set workbook date system with invalid date system
+	assert.Equal(t, ErrParameterInvalid, f.SetWorkbookDateSystem(DateSystem(2)))
+	// Test get workbook date system with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetWorkbookDateSystem()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestCalcProps(t *testing.T) {
+	f := NewFile()
+	opts, err := f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Equal(t, CalcPropsOptions{
+		CalcID:                uintPtr(122211),
+		CalcMode:              stringPtr("auto"),
+		FullCalcOnLoad:        boolPtr(false),
+		RefMode:               stringPtr("A1"),
+		Iterate:               boolPtr(false),
+		IterateCount:          uintPtr(100),
+		IterateDelta:          float64Ptr(0.001),
+		FullPrecision:         boolPtr(true),
+		CalcCompleted:         boolPtr(true),
+		CalcOnSave:            boolPtr(true),
+		ConcurrentCalc:        boolPtr(true),
+		ConcurrentManualCount: uintPtr(0),
+		ForceFullCalc:         boolPtr(false),
+	}, opts)
+	expected := CalcPropsOptions{
+		CalcID:                uintPtr(191029),
+		CalcMode:              stringPtr("manual"),
+		FullCalcOnLoad:        boolPtr(true),
+		RefMode:               stringPtr("R1C1"),
+		Iterate:               boolPtr(true),
+		IterateCount:          uintPtr(10),
+		IterateDelta:          float64Ptr(0.0001),
+		FullPrecision:         boolPtr(false),
+		CalcCompleted:         boolPtr(false),
+		CalcOnSave:            boolPtr(false),
+		ConcurrentCalc:        boolPtr(false),
+		ConcurrentManualCount: uintPtr(4),
+		ForceFullCalc:         boolPtr(true),
+	}
+	assert.NoError(t, f.SetCalcProps(&expected))
+	assert.NoError(t, f.SetCalcProps(nil))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCalcProps.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestCalcProps.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	// Test set calculation properties with invalid calculation mode and reference mode
+	assert.EqualError(t, f.SetCalcProps(&CalcPropsOptions{CalcMode: stringPtr("AUTO")}), "invalid CalcMode value \"AUTO\", acceptable value should be one of manual, auto, autoNoTable")
+	assert.EqualError(t, f.SetCalcProps(&CalcPropsOptions{RefMode: stringPtr("a1")}), "invalid RefMode value \"a1\", acceptable value should be one of A1, R1C1")
+	assert.NoError(t, f.Close())
+
+	// Test get calculation properties without calculation properties element
+	f = NewFile()
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.CalcPr = nil
+	opts, err = f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Nil(t, opts.CalcID)
+	assert.Equal(t, "auto", *opts.CalcMode)
+
+	// Test set and get calculation properties with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCalcProps(&CalcPropsOptions{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetCalcProps()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteWorkbookRels(t *testing.T) {
 	f := NewFile()
 	// Test delete pivot table without worksheet relationships
@@ -41,3 +161,97 @@ func TestDeleteWorkbookRels(t *testing.T) {
 	assert.Empty(t, rID)
 	assert.NoError(t, err)
 }
+
+func TestRemoveMetadata(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetDocProps(&DocProperties{Creator: "Excelize", LastModifiedBy: "Excelize", Title: "Title"}))
+	assert.NoError(t, f.SetAppProps(&AppProperties{Company: "Company"}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "B1", Author: "Excelize", UserID: "excelize@example.com", ProviderID: "AD", Text: "Thread"}))
+	for _, sheet := range []string{"Sheet2", "Sheet3"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetSheetVisibility("Sheet2", SheetHidden))
+	assert.NoError(t, f.SetSheetVisibility("Sheet3", SheetVeryHidden))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{1, 2, 3, 4}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{5, 6, 7, 8}))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 2, false))
+	assert.NoError(t, f.SetColVisible("Sheet1", "B:C", false))
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.ExternalReferences = &xlsxExternalReferences{ExternalReference: []xlsxExternalReference{{RID: "rId100"}}}
+	rels, err := f.relsReader(defaultXMLPathWorkbookRels)
+	assert.NoError(t, err)
+	rels.Relationships = append(rels.Relationships, xlsxRelationship{ID: "rId100", Target: "externalLinks/externalLink1.xml"})
+	f.Pkg.Store("xl/externalLinks/externalLink1.xml", []byte(`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><externalBook><sheetNames><sheetName val="Sheet1"/></sheetNames><sheetDataSet><sheetData sheetId="0"><row r="1"><cell r="A1"><v>1</v></cell></row></sheetData></sheetDataSet></externalBook></externalLink>`))
+
+	// Test remove metadata with default options
+	assert.NoError(t, f.RemoveMetadata(nil))
+	props, err := f.GetDocProps()
+	assert.NoError(t, err)
+	assert.Empty(t, props.Creator)
+	assert.Empty(t, props.LastModifiedBy)
+	assert.Equal(t, "Title", props.Title)
+	appProps, err := f.GetAppProps()
+	assert.NoError(t, err)
+	assert.Empty(t, appProps.Company)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	for _, comment := range comments {
+		if comment.Cell == "A1" {
+			assert.Equal(t, "Author", comment.Author)
+		}
+	}
+	threadedComments, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, threadedComments, 1)
+	assert.Equal(t, "Author", threadedComments[0].Author)
+	assert.Empty(t, threadedComments[0].UserID)
+	assert.Empty(t, threadedComments[0].ProviderID)
+	assert.NotContains(t, string(f.readXML("xl/externalLinks/externalLink1.xml")), "sheetDataSet")
+	assert.Equal(t, []string{"Sheet1", "Sheet2", "Sheet3"}, f.GetSheetList())
+
+	// Test remove metadata with all options
+	assert.NoError(t, f.RemoveMetadata(&RemoveMetadataOptions{Comments: true, HiddenSheets: true, HiddenRowsCols: true}))
+	assert.Equal(t, []string{"Sheet1"}, f.GetSheetList())
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+	threadedComments, err = f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, threadedComments)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, {"5", "8"}}, rows)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveMetadata.xlsx")))
+
+	// Test remove metadata with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemoveMetadata(nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test remove metadata with unsupported charset document properties
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPathDocPropsCore, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemoveMetadata(nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test remove metadata with unsupported charset comments
+	f = NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemoveMetadata(nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test remove metadata with unsupported charset worksheet
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.RemoveMetadata(&RemoveMetadataOptions{HiddenRowsCols: true}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
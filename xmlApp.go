@@ -11,7 +11,10 @@
 
 package excelize
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // AppProperties directly maps the document application properties.
 type AppProperties struct {
@@ -19,6 +22,7 @@ type AppProperties struct {
 	ScaleCrop         bool
 	DocSecurity       int
 	Company           string
+	Manager           string
 	LinksUpToDate     bool
 	HyperlinksChanged bool
 	AppVersion        string
@@ -73,3 +77,55 @@ type xlsxVectorLpstr struct {
 type xlsxDigSig struct {
 	Content string `xml:",innerxml"`
 }
+
+// CustomProperty directly maps the custom property of the workbook. The value
+// data type may be one of the following: int32, float64, string, bool,
+// time.Time, or nil.
+type CustomProperty struct {
+	Name  string
+	Value interface{}
+}
+
+// xlsxCustomProperties specifies to an OOXML document custom properties.
+type xlsxCustomProperties struct {
+	XMLName  xml.Name             `xml:"http://schemas.openxmlformats.org/officeDocument/2006/custom-properties Properties"`
+	Vt       string               `xml:"xmlns:vt,attr"`
+	Property []xlsxCustomProperty `xml:"property"`
+}
+
+// xlsxCustomProperty directly maps the property element of the custom
+// properties, the value of the property will be stored as a variant type
+// element.
+type xlsxCustomProperty struct {
+	FmtID      string `xml:"fmtid,attr"`
+	PID        int    `xml:"pid,attr"`
+	Name       string `xml:"name,attr,omitempty"`
+	LinkTarget string `xml:"linkTarget,attr,omitempty"`
+	Value      string `xml:",innerxml"`
+}
+
+// decodeCustomProperties directly maps the root element for a part of this
+// content type shall custom properties. This structure just for
+// deserialization.
+type decodeCustomProperties struct {
+	XMLName  xml.Name               `xml:"http://schemas.openxmlformats.org/officeDocument/2006/custom-properties Properties"`
+	Property []decodeCustomProperty `xml:"property"`
+}
+
+// decodeCustomProperty directly maps the property element of the custom
+// properties. The raw variant type element will be kept for preserving the
+// value of the unsupported data type.
+type decodeCustomProperty struct {
+	FmtID      string     `xml:"fmtid,attr"`
+	PID        int        `xml:"pid,attr"`
+	Name       string     `xml:"name,attr,omitempty"`
+	LinkTarget string     `xml:"linkTarget,attr,omitempty"`
+	I4         *int32     `xml:"i4"`
+	Int        *int32     `xml:"int"`
+	R8         *float64   `xml:"r8"`
+	Lpwstr     *string    `xml:"lpwstr"`
+	Lpstr      *string    `xml:"lpstr"`
+	Bool       *bool      `xml:"bool"`
+	FileTime   *time.Time `xml:"filetime"`
+	Value      string     `xml:",innerxml"`
+}
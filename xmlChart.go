@@ -105,7 +105,7 @@ type aBodyPr struct {
 // paragraph of content in the document.
 type aP struct {
 	PPr        *aPPr        `xml:"a:pPr"`
-	R          *aR          `xml:"a:r"`
+	R          []*aR        `xml:"a:r"`
 	EndParaRPr *aEndParaRPr `xml:"a:endParaRPr"`
 }
 
@@ -314,6 +314,7 @@ type cPlotArea struct {
 	OfPieChart     *cCharts `xml:"ofPieChart"`
 	RadarChart     *cCharts `xml:"radarChart"`
 	ScatterChart   *cCharts `xml:"scatterChart"`
+	StockChart     *cCharts `xml:"stockChart"`
 	Surface3DChart *cCharts `xml:"surface3DChart"`
 	SurfaceChart   *cCharts `xml:"surfaceChart"`
 	CatAx          []*cAxs  `xml:"catAx"`
@@ -340,9 +341,19 @@ type cCharts struct {
 	HoleSize     *attrValInt    `xml:"holeSize"`
 	Smooth       *attrValBool   `xml:"smooth"`
 	Overlap      *attrValInt    `xml:"overlap"`
+	HiLowLines   *cChartLines   `xml:"hiLowLines"`
+	UpDownBars   *cUpDownBars   `xml:"upDownBars"`
 	AxID         []*attrValInt  `xml:"axId"`
 }
 
+// cUpDownBars directly maps the upDownBars element. This element specifies
+// the up and down bars of the open-high-low-close stock chart.
+type cUpDownBars struct {
+	GapWidth *attrValInt  `xml:"gapWidth"`
+	UpBars   *cChartLines `xml:"upBars"`
+	DownBars *cChartLines `xml:"downBars"`
+}
+
 // cAxs directly maps the catAx and valAx element.
 type cAxs struct {
 	AxID           *attrValInt    `xml:"axId"`
@@ -403,6 +414,8 @@ type cSer struct {
 	DLbls            *cDLbls      `xml:"dLbls"`
 	Marker           *cMarker     `xml:"marker"`
 	InvertIfNegative *attrValBool `xml:"invertIfNegative"`
+	Trendline        *cTrendline  `xml:"trendline"`
+	ErrBars          *cErrBars    `xml:"errBars"`
 	Cat              *cCat        `xml:"cat"`
 	Val              *cVal        `xml:"val"`
 	XVal             *cCat        `xml:"xVal"`
@@ -420,6 +433,35 @@ type cMarker struct {
 	SpPr   *cSpPr         `xml:"spPr"`
 }
 
+// cTrendline (Trendline) directly maps the trendline element. This element
+// specifies a trendline.
+type cTrendline struct {
+	SpPr          *cSpPr         `xml:"spPr"`
+	TrendlineType *attrValString `xml:"trendlineType"`
+	Order         *attrValInt    `xml:"order"`
+	Period        *attrValInt    `xml:"period"`
+	DispRSqr      *attrValBool   `xml:"dispRSqr"`
+	DispEq        *attrValBool   `xml:"dispEq"`
+	TrendlineLbl  *cTrendlineLbl `xml:"trendlineLbl"`
+}
+
+// cTrendlineLbl (Trendline Label) directly maps the trendlineLbl element. This
+// element specifies a trendline label.
+type cTrendlineLbl struct {
+	NumFmt *cNumFmt `xml:"numFmt"`
+}
+
+// cErrBars (Error Bars) directly maps the errBars element. This element
+// specifies the error bars.
+type cErrBars struct {
+	ErrDir     *attrValString `xml:"errDir"`
+	ErrBarType *attrValString `xml:"errBarType"`
+	ErrValType *attrValString `xml:"errValType"`
+	NoEndCap   *attrValBool   `xml:"noEndCap"`
+	Val        *attrValFloat  `xml:"val"`
+	SpPr       *cSpPr         `xml:"spPr"`
+}
+
 // cDPt (Data Point) directly maps the dPt element. This element specifies a
 // single data point.
 type cDPt struct {
@@ -482,14 +524,15 @@ type cNumCache struct {
 // entire series or the entire chart. It contains child elements that specify
 // the specific formatting and positioning settings.
 type cDLbls struct {
-	NumFmt          *cNumFmt     `xml:"numFmt"`
-	ShowLegendKey   *attrValBool `xml:"showLegendKey"`
-	ShowVal         *attrValBool `xml:"showVal"`
-	ShowCatName     *attrValBool `xml:"showCatName"`
-	ShowSerName     *attrValBool `xml:"showSerName"`
-	ShowPercent     *attrValBool `xml:"showPercent"`
-	ShowBubbleSize  *attrValBool `xml:"showBubbleSize"`
-	ShowLeaderLines *attrValBool `xml:"showLeaderLines"`
+	NumFmt          *cNumFmt       `xml:"numFmt"`
+	DLblPos         *attrValString `xml:"dLblPos"`
+	ShowLegendKey   *attrValBool   `xml:"showLegendKey"`
+	ShowVal         *attrValBool   `xml:"showVal"`
+	ShowCatName     *attrValBool   `xml:"showCatName"`
+	ShowSerName     *attrValBool   `xml:"showSerName"`
+	ShowPercent     *attrValBool   `xml:"showPercent"`
+	ShowBubbleSize  *attrValBool   `xml:"showBubbleSize"`
+	ShowLeaderLines *attrValBool   `xml:"showLeaderLines"`
 }
 
 // cLegend (Legend) directly maps the legend element. This element specifies
@@ -533,6 +576,7 @@ type ChartAxis struct {
 	MajorGridLines bool
 	MinorGridLines bool
 	MajorUnit      float64
+	MinorUnit      float64
 	TickLabelSkip  int
 	ReverseOrder   bool
 	Secondary      bool
@@ -542,6 +586,7 @@ type ChartAxis struct {
 	LogBase        float64
 	NumFmt         ChartNumFmt
 	Title          []RichTextRun
+	TitleRef       string
 	axID           int
 }
 
@@ -561,6 +606,7 @@ type ChartPlotArea struct {
 	ShowSerName      bool
 	ShowVal          bool
 	NumFmt           ChartNumFmt
+	Fill             Fill
 }
 
 // Chart directly maps the format settings of the chart.
@@ -588,12 +634,14 @@ type ChartLegend struct {
 
 // ChartMarker directly maps the format settings of the chart marker.
 type ChartMarker struct {
+	Fill   Fill
 	Symbol string
 	Size   int
 }
 
 // ChartLine directly maps the format settings of the chart line.
 type ChartLine struct {
+	Color  string
 	Smooth bool
 	Width  float64
 }
@@ -607,4 +655,37 @@ type ChartSeries struct {
 	Fill       Fill
 	Line       ChartLine
 	Marker     ChartMarker
+	DataLabel  ChartDataLabel
+	Trendline  ChartTrendline
+	ErrorBars  ChartErrorBars
+}
+
+// ChartDataLabel directly maps the format settings of the chart series data
+// labels.
+type ChartDataLabel struct {
+	Position    string
+	ShowCatName bool
+	ShowPercent bool
+	ShowSerName bool
+	ShowVal     bool
+	NumFmt      ChartNumFmt
+}
+
+// ChartTrendline directly maps the format settings of the chart series
+// trendline.
+type ChartTrendline struct {
+	Type            string
+	Order           int
+	Period          int
+	DisplayEquation bool
+	DisplayRSquared bool
+}
+
+// ChartErrorBars directly maps the format settings of the chart series error
+// bars.
+type ChartErrorBars struct {
+	Type      string
+	Direction string
+	Value     float64
+	NoEndCap  bool
 }
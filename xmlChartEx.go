@@ -0,0 +1,119 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxChartExSpace directly maps the chartSpace element of the chartex part,
+// which is used by the chart types introduced in Excel 2016, such as the
+// funnel chart.
+type xlsxChartExSpace struct {
+	XMLName   xml.Name    `xml:"http://schemas.microsoft.com/office/drawing/2014/chartex chartSpace"`
+	XMLNSa    string      `xml:"xmlns:a,attr"`
+	XMLNSr    string      `xml:"xmlns:r,attr"`
+	ChartData cxChartData `xml:"chartData"`
+	Chart     cxChart     `xml:"chart"`
+}
+
+// cxChartData directly maps the chartData element. This element specifies the
+// data sources of the chart.
+type cxChartData struct {
+	Data []cxData `xml:"data"`
+}
+
+// cxData directly maps the data element. This element specifies the
+// dimensions of a data source which referenced by the series.
+type cxData struct {
+	ID     int    `xml:"id,attr"`
+	StrDim *cxDim `xml:"strDim"`
+	NumDim *cxDim `xml:"numDim"`
+}
+
+// cxDim directly maps the strDim and numDim element. This element specifies
+// the formula of a string or numeric data dimension.
+type cxDim struct {
+	Type string `xml:"type,attr"`
+	F    string `xml:"f"`
+}
+
+// cxChart directly maps the chart element of the chartex part.
+type cxChart struct {
+	Title    *cxTitle   `xml:"title"`
+	PlotArea cxPlotArea `xml:"plotArea"`
+}
+
+// cxTitle directly maps the title element of the chartex part.
+type cxTitle struct {
+	Pos     string `xml:"pos,attr"`
+	Align   string `xml:"align,attr"`
+	Overlay bool   `xml:"overlay,attr"`
+	Tx      cxTx   `xml:"tx"`
+}
+
+// cxTx directly maps the tx element of the chartex part. This element
+// specifies the text by formula or literal value.
+type cxTx struct {
+	TxData cxTxData `xml:"txData"`
+}
+
+// cxTxData directly maps the txData element.
+type cxTxData struct {
+	F string `xml:"f,omitempty"`
+	V string `xml:"v,omitempty"`
+}
+
+// cxPlotArea directly maps the plotArea element of the chartex part.
+type cxPlotArea struct {
+	PlotAreaRegion cxPlotAreaRegion `xml:"plotAreaRegion"`
+	Axis           []cxAxis         `xml:"axis"`
+}
+
+// cxPlotAreaRegion directly maps the plotAreaRegion element.
+type cxPlotAreaRegion struct {
+	Series []cxSeries `xml:"series"`
+}
+
+// cxSeries directly maps the series element of the chartex part.
+type cxSeries struct {
+	LayoutID   string        `xml:"layoutId,attr"`
+	Tx         *cxTx         `xml:"tx"`
+	DataLabels *cxDataLabels `xml:"dataLabels"`
+	DataID     attrValInt    `xml:"dataId"`
+}
+
+// cxDataLabels directly maps the dataLabels element of the chartex part.
+type cxDataLabels struct {
+	Pos        string       `xml:"pos,attr,omitempty"`
+	Visibility cxVisibility `xml:"visibility"`
+}
+
+// cxVisibility directly maps the visibility element. This element specifies
+// the visibility of the content in the data labels.
+type cxVisibility struct {
+	SeriesName   bool `xml:"seriesName,attr"`
+	CategoryName bool `xml:"categoryName,attr"`
+	Value        bool `xml:"value,attr"`
+}
+
+// cxAxis directly maps the axis element of the chartex part.
+type cxAxis struct {
+	ID         int           `xml:"id,attr"`
+	Hidden     bool          `xml:"hidden,attr,omitempty"`
+	CatScaling *cxCatScaling `xml:"catScaling"`
+	TickLabels *string       `xml:"tickLabels"`
+}
+
+// cxCatScaling directly maps the catScaling element. This element specifies
+// the scaling of the category axis.
+type cxCatScaling struct {
+	GapWidth float64 `xml:"gapWidth,attr"`
+}
@@ -11,7 +11,10 @@
 
 package excelize
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // xlsxComments directly maps the comments element from the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main. A comment is a
@@ -56,10 +59,10 @@ type xlsxComment struct {
 // spreadsheet application implementation detail. A recommended guideline is
 // 32767 chars.
 type xlsxText struct {
-	T          *string          `xml:"t"`
-	R          []xlsxR          `xml:"r"`
-	RPh        *xlsxPhoneticRun `xml:"rPh"`
-	PhoneticPr *xlsxPhoneticPr  `xml:"phoneticPr"`
+	T          *string            `xml:"t"`
+	R          []xlsxR            `xml:"r"`
+	RPh        []*xlsxPhoneticRun `xml:"rPh"`
+	PhoneticPr *xlsxPhoneticPr    `xml:"phoneticPr"`
 }
 
 // xlsxPhoneticRun element represents a run of text which displays a phonetic
@@ -72,6 +75,51 @@ type xlsxPhoneticRun struct {
 	T  string `xml:"t"`
 }
 
+// xlsxThreadedComments directly maps the ThreadedComments element. This
+// element represents the threaded comments (modern comments) of a
+// worksheet, each thread consists of a top-level comment and a list of
+// replies which reference the top-level comment by the parent ID.
+type xlsxThreadedComments struct {
+	XMLName         xml.Name              `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments ThreadedComments"`
+	XMLNSX          string                `xml:"xmlns:x,attr,omitempty"`
+	ThreadedComment []xlsxThreadedComment `xml:"threadedComment"`
+	ExtLst          *xlsxInnerXML         `xml:"extLst"`
+}
+
+// xlsxThreadedComment directly maps the threadedComment element. This element
+// represents a single threaded comment or a reply of the threaded comment.
+type xlsxThreadedComment struct {
+	Ref      string        `xml:"ref,attr,omitempty"`
+	DT       string        `xml:"dT,attr,omitempty"`
+	PersonID string        `xml:"personId,attr"`
+	ID       string        `xml:"id,attr"`
+	ParentID string        `xml:"parentId,attr,omitempty"`
+	Done     bool          `xml:"done,attr,omitempty"`
+	Text     string        `xml:"text"`
+	Mentions *xlsxInnerXML `xml:"mentions"`
+	ExtLst   *xlsxInnerXML `xml:"extLst"`
+}
+
+// xlsxPersonList directly maps the personList element. This element
+// represents the list of the persons who author the threaded comments in the
+// workbook.
+type xlsxPersonList struct {
+	XMLName xml.Name      `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments personList"`
+	XMLNSX  string        `xml:"xmlns:x,attr,omitempty"`
+	Person  []xlsxPerson  `xml:"person"`
+	ExtLst  *xlsxInnerXML `xml:"extLst"`
+}
+
+// xlsxPerson directly maps the person element. This element represents the
+// identity of an author of the threaded comments.
+type xlsxPerson struct {
+	DisplayName string        `xml:"displayName,attr"`
+	ID          string        `xml:"id,attr"`
+	UserID      string        `xml:"userId,attr,omitempty"`
+	ProviderID  string        `xml:"providerId,attr,omitempty"`
+	ExtLst      *xlsxInnerXML `xml:"extLst"`
+}
+
 // Comment directly maps the comment information.
 type Comment struct {
 	Author    string
@@ -80,5 +128,24 @@ type Comment struct {
 	Text      string
 	Width     uint
 	Height    uint
+	OffsetX   int
+	OffsetY   int
+	Visible   bool
+	DateTime  time.Time
 	Paragraph []RichTextRun
 }
+
+// ThreadedComment directly maps the threaded comment information. The
+// "UserID" and "ProviderID" specifies the identity of the author, and the
+// "Done" specifies if the thread has been resolved.
+type ThreadedComment struct {
+	ID         string
+	Cell       string
+	Author     string
+	UserID     string
+	ProviderID string
+	Text       string
+	DateTime   time.Time
+	Done       bool
+	Replies    []ThreadedComment
+}
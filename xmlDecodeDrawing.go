@@ -18,13 +18,14 @@ import "encoding/xml"
 // specifies a two cell anchor placeholder for a group, a shape, or a drawing
 // element. It moves with cells and its extents are in EMU units.
 type decodeCellAnchor struct {
-	EditAs     string            `xml:"editAs,attr,omitempty"`
-	From       *decodeFrom       `xml:"from"`
-	To         *decodeTo         `xml:"to"`
-	Sp         *decodeSp         `xml:"sp"`
-	Pic        *decodePic        `xml:"pic"`
-	ClientData *decodeClientData `xml:"clientData"`
-	Content    string            `xml:",innerxml"`
+	EditAs       string              `xml:"editAs,attr,omitempty"`
+	From         *decodeFrom         `xml:"from"`
+	To           *decodeTo           `xml:"to"`
+	Sp           *decodeSp           `xml:"sp"`
+	Pic          *decodePic          `xml:"pic"`
+	GraphicFrame *decodeGraphicFrame `xml:"graphicFrame"`
+	ClientData   *decodeClientData   `xml:"clientData"`
+	Content      string              `xml:",innerxml"`
 }
 
 // xdrSp (Shape) directly maps the sp element. This element specifies the
@@ -69,6 +70,7 @@ type decodeWsDr struct {
 	Xdr              string              `xml:"xmlns xdr,attr"`
 	R                string              `xml:"xmlns r,attr"`
 	AlternateContent []*xlsxInnerXML     `xml:"http://schemas.openxmlformats.org/markup-compatibility/2006 AlternateContent"`
+	AbsoluteAnchor   []*decodeCellAnchor `xml:"absoluteAnchor,omitempty"`
 	OneCellAnchor    []*decodeCellAnchor `xml:"oneCellAnchor,omitempty"`
 	TwoCellAnchor    []*decodeCellAnchor `xml:"twoCellAnchor,omitempty"`
 }
@@ -223,3 +225,110 @@ type decodeClientData struct {
 	FLocksWithSheet  bool `xml:"fLocksWithSheet,attr"`
 	FPrintsWithSheet bool `xml:"fPrintsWithSheet,attr"`
 }
+
+// decodeGraphicFrame directly maps the xdr:graphicFrame element. This element
+// describes a single graphical object frame for a spreadsheet which contains
+// a graphical object, such as a chart.
+type decodeGraphicFrame struct {
+	GraphicData decodeGraphicData `xml:"graphic>graphicData"`
+}
+
+// decodeGraphicData directly maps the a:graphicData element. This element
+// specifies the reference to a graphic object within the document.
+type decodeGraphicData struct {
+	URI   string       `xml:"uri,attr"`
+	Chart *decodeChart `xml:"chart"`
+}
+
+// decodeChart directly maps the c:chart element. This element specifies the
+// relationship ID of the chart part in the graphic frame.
+type decodeChart struct {
+	RID string `xml:"id,attr"`
+}
+
+// decodeChartSpace defines the structure used to parse the titles and shape
+// properties of the chart and axes in the chart part, which the DrawingML
+// namespace prefixed elements can't be parsed by the xlsxChartSpace.
+type decodeChartSpace struct {
+	Title    *decodeChartTitle   `xml:"chart>title"`
+	PlotArea decodeChartPlotArea `xml:"chart>plotArea"`
+}
+
+// decodeChartPlotArea defines the structure used to parse the axes, shape
+// properties and chart groups of the c:plotArea element.
+type decodeChartPlotArea struct {
+	CatAx  []decodeChartAxis  `xml:"catAx"`
+	ValAx  []decodeChartAxis  `xml:"valAx"`
+	SpPr   *decodeChartSpPr   `xml:"spPr"`
+	Charts []decodeChartGroup `xml:",any"`
+}
+
+// decodeChartGroup defines the structure used to parse the series of the chart
+// group element, such as c:barChart, c:lineChart and etc.
+type decodeChartGroup struct {
+	XMLName xml.Name
+	Ser     []decodeChartSer `xml:"ser"`
+}
+
+// decodeChartSer defines the structure used to parse the shape properties of
+// the series and markers in the c:ser element.
+type decodeChartSer struct {
+	SpPr   *decodeChartSpPr `xml:"spPr"`
+	Marker *decodeChartSpPr `xml:"marker>spPr"`
+}
+
+// decodeChartSpPr directly maps the c:spPr element. This element specifies
+// the fill and line color of the chart element.
+type decodeChartSpPr struct {
+	SolidFill *decodeChartSolidFill `xml:"solidFill"`
+	Ln        *decodeChartLn        `xml:"ln"`
+}
+
+// decodeChartLn directly maps the a:ln element in the shape properties.
+type decodeChartLn struct {
+	W         int                   `xml:"w,attr"`
+	SolidFill *decodeChartSolidFill `xml:"solidFill"`
+}
+
+// decodeChartSolidFill directly maps the a:solidFill element with the RGB or
+// theme color.
+type decodeChartSolidFill struct {
+	SchemeClr *attrValString `xml:"schemeClr"`
+	SrgbClr   *attrValString `xml:"srgbClr"`
+}
+
+// decodeChartAxis defines the structure used to parse the axis ID and title
+// of the catAx and valAx element.
+type decodeChartAxis struct {
+	AxID  attrValInt        `xml:"axId"`
+	Title *decodeChartTitle `xml:"title"`
+}
+
+// decodeChartTitle directly maps the c:title element. This element specifies
+// a title.
+type decodeChartTitle struct {
+	F string              `xml:"tx>strRef>f"`
+	P []decodeChartTitleP `xml:"tx>rich>p"`
+}
+
+// decodeChartTitleP directly maps the a:p element in the rich text of the
+// title.
+type decodeChartTitleP struct {
+	R []decodeChartTitleR `xml:"r"`
+}
+
+// decodeChartTitleR directly maps the a:r element in the rich text of the
+// title.
+type decodeChartTitleR struct {
+	RPr decodeChartTitleRPr `xml:"rPr"`
+	T   string              `xml:"t"`
+}
+
+// decodeChartTitleRPr directly maps the a:rPr element in the rich text of the
+// title.
+type decodeChartTitleRPr struct {
+	B       bool           `xml:"b,attr"`
+	I       bool           `xml:"i,attr"`
+	Sz      float64        `xml:"sz,attr"`
+	SrgbClr *attrValString `xml:"solidFill>srgbClr"`
+}
@@ -102,8 +102,11 @@ type xlsxPrstGeom struct {
 // frame. This transformation is applied to the graphic frame just as it would
 // be for a shape or group shape.
 type xlsxXfrm struct {
-	Off xlsxOff `xml:"a:off"`
-	Ext aExt    `xml:"a:ext"`
+	Rot   int     `xml:"rot,attr,omitempty"`
+	FlipH bool    `xml:"flipH,attr,omitempty"`
+	FlipV bool    `xml:"flipV,attr,omitempty"`
+	Off   xlsxOff `xml:"a:off"`
+	Ext   aExt    `xml:"a:ext"`
 }
 
 // xlsxCNvPicPr directly maps the cNvPicPr (Non-Visual Picture Drawing
@@ -163,6 +166,14 @@ type xlsxBlipFill struct {
 type xlsxLineProperties struct {
 	W         int           `xml:"w,attr,omitempty"`
 	SolidFill *xlsxInnerXML `xml:"a:solidFill"`
+	HeadEnd   *xlsxLineEnd  `xml:"a:headEnd"`
+	TailEnd   *xlsxLineEnd  `xml:"a:tailEnd"`
+}
+
+// xlsxLineEnd directly maps the a:headEnd and a:tailEnd element. This element
+// specifies decorations which can be added to the head or tail of a line.
+type xlsxLineEnd struct {
+	Type string `xml:"type,attr"`
 }
 
 // xlsxSpPr directly maps the spPr (Shape Properties). This element specifies
@@ -224,6 +235,7 @@ type xdrCellAnchor struct {
 	To               *xlsxTo                 `xml:"xdr:to"`
 	Ext              *aExt                   `xml:"xdr:ext"`
 	Sp               *xdrSp                  `xml:"xdr:sp"`
+	CxnSp            *xdrCxnSp               `xml:"xdr:cxnSp"`
 	Pic              *xlsxPic                `xml:"xdr:pic,omitempty"`
 	GraphicFrame     string                  `xml:",innerxml"`
 	AlternateContent []*xlsxAlternateContent `xml:"mc:AlternateContent"`
@@ -290,9 +302,11 @@ type xlsxGraphic struct {
 // document. This graphic object is provided entirely by the document authors
 // who choose to persist this data within the document.
 type xlsxGraphicData struct {
-	URI   string     `xml:"uri,attr"`
-	Chart *xlsxChart `xml:"c:chart,omitempty"`
-	Sle   *xlsxSle   `xml:"sle:slicer"`
+	URI     string       `xml:"uri,attr"`
+	Chart   *xlsxChart   `xml:"c:chart,omitempty"`
+	ChartEx *xlsxChartEx `xml:"cx:chart,omitempty"`
+	Sle     *xlsxSle     `xml:"sle:slicer"`
+	Tsle    *xlsxTsle    `xml:"tsle:timeslicer"`
 }
 
 type xlsxSle struct {
@@ -300,6 +314,13 @@ type xlsxSle struct {
 	Name  string `xml:"name,attr"`
 }
 
+// xlsxTsle directly maps the tsle:timeslicer element that specifies a
+// timeline in the drawing.
+type xlsxTsle struct {
+	XMLNS string `xml:"xmlns:tsle,attr"`
+	Name  string `xml:"name,attr"`
+}
+
 // xlsxChart (Chart) directly maps the c:chart element.
 type xlsxChart struct {
 	C   string `xml:"xmlns:c,attr"`
@@ -307,6 +328,14 @@ type xlsxChart struct {
 	R   string `xml:"xmlns:r,attr"`
 }
 
+// xlsxChartEx directly maps the cx:chart element. This element specifies the
+// relationship ID of the chartex part in the graphic frame.
+type xlsxChartEx struct {
+	Cx  string `xml:"xmlns:cx,attr"`
+	RID string `xml:"r:id,attr"`
+	R   string `xml:"xmlns:r,attr"`
+}
+
 // xdrSp (Shape) directly maps the xdr:sp element. This element specifies the
 // existence of a single shape. A shape can either be a preset or a custom
 // geometry, defined using the SpreadsheetDrawingML framework. In addition to a
@@ -324,6 +353,23 @@ type xdrSp struct {
 	TxBody   *xdrTxBody `xml:"xdr:txBody"`
 }
 
+// xdrCxnSp (Connection Shape) directly maps the xdr:cxnSp element. This
+// element specifies a connection shape that is used to connect two shapes or
+// draw a line between two points of the worksheet.
+type xdrCxnSp struct {
+	Macro     string        `xml:"macro,attr"`
+	NvCxnSpPr *xdrNvCxnSpPr `xml:"xdr:nvCxnSpPr"`
+	SpPr      *xlsxSpPr     `xml:"xdr:spPr"`
+	Style     *xdrStyle     `xml:"xdr:style"`
+}
+
+// xdrNvCxnSpPr (Non-Visual Properties for a Connection Shape) directly maps
+// the xdr:nvCxnSpPr element.
+type xdrNvCxnSpPr struct {
+	CNvPr      *xlsxCNvPr `xml:"xdr:cNvPr"`
+	CNvCxnSpPr string     `xml:"xdr:cNvCxnSpPr"`
+}
+
 // xdrNvSpPr (Non-Visual Properties for a Shape) directly maps the xdr:nvSpPr
 // element. This element specifies all non-visual properties for a shape. This
 // element is a container for the non-visual identification properties, shape
@@ -389,16 +435,28 @@ type xdrTxBody struct {
 	P      []*aP    `xml:"a:p"`
 }
 
+// PictureInsertType defines the type of the picture has been inserted into
+// the worksheet.
+type PictureInsertType byte
+
+// Insert picture types.
+const (
+	PictureInsertTypePlaceOverCells PictureInsertType = iota
+	PictureInsertTypePlaceInCell
+)
+
 // Picture maps the format settings of the picture.
 type Picture struct {
-	Extension string
-	File      []byte
-	Format    *GraphicOptions
+	Extension  string
+	File       []byte
+	Format     *GraphicOptions
+	InsertType PictureInsertType
 }
 
 // GraphicOptions directly maps the format settings of the picture.
 type GraphicOptions struct {
 	AltText         string
+	Name            string
 	PrintObject     *bool
 	Locked          *bool
 	LockAspectRatio bool
@@ -407,6 +465,10 @@ type GraphicOptions struct {
 	OffsetY         int
 	ScaleX          float64
 	ScaleY          float64
+	Width           float64
+	Height          float64
+	Unit            string
+	UseImageDPI     bool
 	Hyperlink       string
 	HyperlinkType   string
 	Positioning     string
@@ -415,10 +477,12 @@ type GraphicOptions struct {
 // Shape directly maps the format settings of the shape.
 type Shape struct {
 	Cell      string
+	EndCell   string
 	Type      string
 	Macro     string
 	Width     uint
 	Height    uint
+	Rotation  int
 	Format    GraphicOptions
 	Fill      Fill
 	Line      ShapeLine
@@ -432,8 +496,24 @@ type ShapeColor struct {
 	Effect string
 }
 
+// TextBox directly maps the format settings of the text box.
+type TextBox struct {
+	Cell      string
+	Anchor    string
+	Link      string
+	Macro     string
+	Width     uint
+	Height    uint
+	Format    GraphicOptions
+	Fill      Fill
+	Line      ShapeLine
+	Paragraph []RichTextRun
+}
+
 // ShapeLine directly maps the line settings of the shape.
 type ShapeLine struct {
-	Color string
-	Width *float64
+	Color   string
+	Width   *float64
+	HeadEnd string
+	TailEnd string
 }
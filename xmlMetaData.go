@@ -0,0 +1,204 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxMetadata directly maps the metadata element. A cell in a spreadsheet
+// application can have metadata associated with it. Metadata is just a set
+// of additional properties about the particular cell, and this metadata is
+// stored in the metadata xml part. There are two types of metadata: cell
+// metadata and value metadata. Cell metadata contains information about the
+// cell itself, and this metadata can be carried along with the cell as it
+// moves (insert, shift, copy/paste, merge, unmerge, etc). Value metadata is
+// information about the value of a particular cell. Value metadata properties
+// can be propagated along with the value as it is referenced in formulas.
+type xlsxMetadata struct {
+	XMLName         xml.Name             `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main metadata"`
+	MetadataTypes   *xlsxMetadataTypes   `xml:"metadataTypes"`
+	MetadataStrings *xlsxInnerXML        `xml:"metadataStrings"`
+	MdxMetadata     *xlsxInnerXML        `xml:"mdxMetadata"`
+	FutureMetadata  []xlsxFutureMetadata `xml:"futureMetadata"`
+	CellMetadata    *xlsxMetadataBlocks  `xml:"cellMetadata"`
+	ValueMetadata   *xlsxMetadataBlocks  `xml:"valueMetadata"`
+	ExtLst          *xlsxInnerXML        `xml:"extLst"`
+}
+
+// xlsxMetadataTypes directly maps the metadataTypes element. This element
+// represents the set of metadata types used in this workbook.
+type xlsxMetadataTypes struct {
+	Count        int                `xml:"count,attr,omitempty"`
+	MetadataType []xlsxMetadataType `xml:"metadataType"`
+}
+
+// xlsxMetadataType directly maps the metadataType element. This element
+// represents a single metadata type, and the flags specifies the behavior of
+// the metadata when the cell which has the metadata was been operated.
+type xlsxMetadataType struct {
+	Name                string `xml:"name,attr"`
+	MinSupportedVersion int    `xml:"minSupportedVersion,attr"`
+	GhostRow            bool   `xml:"ghostRow,attr,omitempty"`
+	GhostCol            bool   `xml:"ghostCol,attr,omitempty"`
+	Edit                bool   `xml:"edit,attr,omitempty"`
+	Delete              bool   `xml:"delete,attr,omitempty"`
+	Copy                bool   `xml:"copy,attr,omitempty"`
+	PasteAll            bool   `xml:"pasteAll,attr,omitempty"`
+	PasteFormulas       bool   `xml:"pasteFormulas,attr,omitempty"`
+	PasteValues         bool   `xml:"pasteValues,attr,omitempty"`
+	PasteFormats        bool   `xml:"pasteFormats,attr,omitempty"`
+	PasteComments       bool   `xml:"pasteComments,attr,omitempty"`
+	PasteDataValidation bool   `xml:"pasteDataValidation,attr,omitempty"`
+	PasteBorders        bool   `xml:"pasteBorders,attr,omitempty"`
+	PasteColWidths      bool   `xml:"pasteColWidths,attr,omitempty"`
+	PasteNumberFormats  bool   `xml:"pasteNumberFormats,attr,omitempty"`
+	Merge               bool   `xml:"merge,attr,omitempty"`
+	SplitFirst          bool   `xml:"splitFirst,attr,omitempty"`
+	SplitAll            bool   `xml:"splitAll,attr,omitempty"`
+	RowColShift         bool   `xml:"rowColShift,attr,omitempty"`
+	ClearAll            bool   `xml:"clearAll,attr,omitempty"`
+	ClearFormats        bool   `xml:"clearFormats,attr,omitempty"`
+	ClearContents       bool   `xml:"clearContents,attr,omitempty"`
+	ClearComments       bool   `xml:"clearComments,attr,omitempty"`
+	Assign              bool   `xml:"assign,attr,omitempty"`
+	Coerce              bool   `xml:"coerce,attr,omitempty"`
+	Adjust              bool   `xml:"adjust,attr,omitempty"`
+	CellMeta            bool   `xml:"cellMeta,attr,omitempty"`
+}
+
+// xlsxFutureMetadata directly maps the futureMetadata element. This element
+// represents future metadata information, the rich value block of the rich
+// data was stored in the extension list of each future metadata block.
+type xlsxFutureMetadata struct {
+	Name   string                    `xml:"name,attr"`
+	Count  int                       `xml:"count,attr,omitempty"`
+	Bk     []xlsxFutureMetadataBlock `xml:"bk"`
+	ExtLst *xlsxInnerXML             `xml:"extLst"`
+}
+
+// xlsxFutureMetadataBlock directly maps the bk element in the future
+// metadata. This element represents a block of future metadata information.
+type xlsxFutureMetadataBlock struct {
+	ExtLst *xlsxFutureMetadataExtLst `xml:"extLst"`
+}
+
+// xlsxFutureMetadataExtLst directly maps the extLst element in the future
+// metadata block.
+type xlsxFutureMetadataExtLst struct {
+	Ext []xlsxFutureMetadataExt `xml:"ext"`
+}
+
+// xlsxFutureMetadataExt directly maps the ext element in the future metadata
+// block.
+type xlsxFutureMetadataExt struct {
+	URI string                `xml:"uri,attr"`
+	Rvb *xlsxRichValueBlock   `xml:"http://schemas.microsoft.com/office/spreadsheetml/2017/richdata rvb"`
+	Any []xlsxFutureExtAnyXML `xml:",any"`
+}
+
+// xlsxFutureExtAnyXML preserves the unknown elements in the extension of the
+// future metadata block.
+type xlsxFutureExtAnyXML struct {
+	XMLName xml.Name
+	Content string `xml:",innerxml"`
+}
+
+// xlsxRichValueBlock directly maps the rvb element. This element specifies
+// the index of the rich value in the rich value data part.
+type xlsxRichValueBlock struct {
+	I int `xml:"i,attr"`
+}
+
+// xlsxMetadataBlocks directly maps the cellMetadata and valueMetadata
+// element. This element represents the metadata blocks of the cells or
+// values.
+type xlsxMetadataBlocks struct {
+	Count int                 `xml:"count,attr,omitempty"`
+	Bk    []xlsxMetadataBlock `xml:"bk"`
+}
+
+// xlsxMetadataBlock directly maps the bk element in the cell metadata and
+// value metadata. This element represents a block of metadata records.
+type xlsxMetadataBlock struct {
+	Rc []xlsxMetadataRecord `xml:"rc"`
+}
+
+// xlsxMetadataRecord directly maps the rc element. This element represents
+// the reference to the metadata record, the 't' attribute specifies the
+// 1-based index of the metadata type, and the 'v' attribute specifies the
+// 0-based index of the metadata record in the metadata of the given type.
+type xlsxMetadataRecord struct {
+	T int `xml:"t,attr"`
+	V int `xml:"v,attr"`
+}
+
+// xlsxRichValueData directly maps the rvData element that specifies rich
+// value data.
+type xlsxRichValueData struct {
+	XMLName xml.Name        `xml:"http://schemas.microsoft.com/office/spreadsheetml/2017/richdata rvData"`
+	Count   int             `xml:"count,attr,omitempty"`
+	Rv      []xlsxRichValue `xml:"rv"`
+	ExtLst  *xlsxInnerXML   `xml:"extLst"`
+}
+
+// xlsxRichValue directly maps the rv element that specifies rich value data
+// information for a single rich value, the 's' attribute specifies the index
+// of the rich value structure.
+type xlsxRichValue struct {
+	S  int                  `xml:"s,attr"`
+	Fb *xlsxInnerXML        `xml:"fb"`
+	V  []xlsxRichValueValue `xml:"v"`
+}
+
+// xlsxRichValueValue directly maps the v element in the rich value.
+type xlsxRichValueValue struct {
+	XMLSpace xml.Attr `xml:"space,attr,omitempty"`
+	Val      string   `xml:",chardata"`
+}
+
+// xlsxRichValueStructures directly maps the rvStructures element that
+// specifies rich value structures.
+type xlsxRichValueStructures struct {
+	XMLName xml.Name                 `xml:"http://schemas.microsoft.com/office/spreadsheetml/2017/richdata rvStructures"`
+	Count   int                      `xml:"count,attr,omitempty"`
+	S       []xlsxRichValueStructure `xml:"s"`
+	ExtLst  *xlsxInnerXML            `xml:"extLst"`
+}
+
+// xlsxRichValueStructure directly maps the s element that specifies a single
+// rich value structure.
+type xlsxRichValueStructure struct {
+	T string             `xml:"t,attr"`
+	K []xlsxRichValueKey `xml:"k"`
+}
+
+// xlsxRichValueKey directly maps the k element that specifies a key in the
+// rich value structure.
+type xlsxRichValueKey struct {
+	N string `xml:"n,attr"`
+	T string `xml:"t,attr,omitempty"`
+}
+
+// xlsxRichValueRels directly maps the richValueRels element. This element
+// specifies the relationships of the rich value, such as the local images of
+// the cells.
+type xlsxRichValueRels struct {
+	XMLName xml.Name           `xml:"http://schemas.microsoft.com/office/spreadsheetml/2022/richvaluerel richValueRels"`
+	XMLNSR  string             `xml:"xmlns:r,attr,omitempty"`
+	Rels    []xlsxRichValueRel `xml:"rel"`
+	ExtLst  *xlsxInnerXML      `xml:"extLst"`
+}
+
+// xlsxRichValueRel directly maps the rel element in the rich value
+// relationships.
+type xlsxRichValueRel struct {
+	ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+}
@@ -85,6 +85,23 @@ type xlsxRPr struct {
 	Scheme    *attrValString `xml:"scheme"`
 }
 
+// PhoneticRun directly maps the settings of the phonetic run. The Start and
+// End specify the zero-based characters offset of the base text which the
+// phonetic text is applied to, and the End is exclusive.
+type PhoneticRun struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// Phonetic directly maps the phonetic settings of the cell.
+type Phonetic struct {
+	Show      bool
+	Type      string
+	Alignment string
+	Runs      []PhoneticRun
+}
+
 // RichTextRun directly maps the settings of the rich text run.
 type RichTextRun struct {
 	Font *Font
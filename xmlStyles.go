@@ -87,20 +87,21 @@ type xlsxFonts struct {
 // xlsxFont directly maps the font element. This element defines the
 // properties for one of the fonts used in this workbook.
 type xlsxFont struct {
-	B        *attrValBool   `xml:"b"`
-	I        *attrValBool   `xml:"i"`
-	Strike   *attrValBool   `xml:"strike"`
-	Outline  *attrValBool   `xml:"outline"`
-	Shadow   *attrValBool   `xml:"shadow"`
-	Condense *attrValBool   `xml:"condense"`
-	Extend   *attrValBool   `xml:"extend"`
-	U        *attrValString `xml:"u"`
-	Sz       *attrValFloat  `xml:"sz"`
-	Color    *xlsxColor     `xml:"color"`
-	Name     *attrValString `xml:"name"`
-	Family   *attrValInt    `xml:"family"`
-	Charset  *attrValInt    `xml:"charset"`
-	Scheme   *attrValString `xml:"scheme"`
+	B         *attrValBool   `xml:"b"`
+	I         *attrValBool   `xml:"i"`
+	Strike    *attrValBool   `xml:"strike"`
+	Outline   *attrValBool   `xml:"outline"`
+	Shadow    *attrValBool   `xml:"shadow"`
+	Condense  *attrValBool   `xml:"condense"`
+	Extend    *attrValBool   `xml:"extend"`
+	U         *attrValString `xml:"u"`
+	VertAlign *attrValString `xml:"vertAlign"`
+	Sz        *attrValFloat  `xml:"sz"`
+	Color     *xlsxColor     `xml:"color"`
+	Name      *attrValString `xml:"name"`
+	Family    *attrValInt    `xml:"family"`
+	Charset   *attrValInt    `xml:"charset"`
+	Scheme    *attrValString `xml:"scheme"`
 }
 
 // xlsxFills directly maps the fills' element. This element defines the cell
@@ -348,6 +349,15 @@ type Font struct {
 	ColorTheme   *int
 	ColorTint    float64
 	VertAlign    string
+	Charset      *int
+	Condense     bool
+	Extend       bool
+}
+
+// GradientStop directly maps the stop settings of the gradient fill.
+type GradientStop struct {
+	Position float64
+	Color    string
 }
 
 // Fill directly maps the fill settings of the cells.
@@ -356,9 +366,13 @@ type Fill struct {
 	Pattern int
 	Color   []string
 	Shading int
+	Angle   float64
+	Stops   []GradientStop
 }
 
-// Protection directly maps the protection settings of the cells.
+// Protection directly maps the protection settings of the cells. The cells
+// are locked by default, and the protection settings do not take effect unless
+// the worksheet has been protected.
 type Protection struct {
 	Hidden bool
 	Locked bool
@@ -375,4 +389,5 @@ type Style struct {
 	DecimalPlaces *int
 	CustomNumFmt  *string
 	NegRed        bool
+	NamedStyle    string
 }
@@ -243,3 +243,15 @@ type decodeStyleMatrix struct {
 	EffectStyleLst xlsxEffectStyleLst `xml:"effectStyleLst"`
 	BgFillStyleLst xlsxBgFillStyleLst `xml:"bgFillStyleLst"`
 }
+
+// ThemeOptions directly maps the settings of the workbook theme. The 'Colors'
+// specifies the colors of the color scheme in theme color index order: light
+// 1, dark 1, light 2, dark 2, accent 1 to 6, hyperlink and followed
+// hyperlink. The 'MajorFont' and 'MinorFont' specifies the Latin typeface of
+// the headings and body font in the font scheme.
+type ThemeOptions struct {
+	Name      string
+	Colors    []string
+	MajorFont string
+	MinorFont string
+}
@@ -82,6 +82,8 @@ type xlsxFileRecoveryPr struct {
 // there is a leading BOM character (U+FEFF) in the encoded password it is
 // removed before hash calculation.
 type xlsxWorkbookProtection struct {
+	WorkbookPassword       string `xml:"workbookPassword,attr,omitempty"`
+	RevisionsPassword      string `xml:"revisionsPassword,attr,omitempty"`
 	LockRevision           bool   `xml:"lockRevision,attr,omitempty"`
 	LockStructure          bool   `xml:"lockStructure,attr,omitempty"`
 	LockWindows            bool   `xml:"lockWindows,attr,omitempty"`
@@ -195,6 +197,63 @@ type xlsxExternalReference struct {
 	RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr,omitempty"`
 }
 
+// xlsxExternalLink directly maps the externalLink element of the external
+// workbook references part. This element stores the names of the worksheets
+// and the cached values of the cells in the external workbook.
+type xlsxExternalLink struct {
+	ExternalBook *xlsxExternalBook `xml:"externalBook"`
+}
+
+// xlsxExternalBook directly maps the externalBook element. This element
+// specifies the reference to the external workbook and its cached data.
+type xlsxExternalBook struct {
+	RID          string                    `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr,omitempty"`
+	SheetNames   *xlsxExternalSheetNames   `xml:"sheetNames"`
+	SheetDataSet *xlsxExternalSheetDataSet `xml:"sheetDataSet"`
+}
+
+// xlsxExternalSheetNames directly maps the sheetNames element of the external
+// workbook references part.
+type xlsxExternalSheetNames struct {
+	SheetName []xlsxExternalSheetName `xml:"sheetName"`
+}
+
+// xlsxExternalSheetName directly maps the sheetName element of the external
+// workbook references part.
+type xlsxExternalSheetName struct {
+	Val string `xml:"val,attr"`
+}
+
+// xlsxExternalSheetDataSet directly maps the sheetDataSet element of the
+// external workbook references part.
+type xlsxExternalSheetDataSet struct {
+	SheetData []xlsxExternalSheetData `xml:"sheetData"`
+}
+
+// xlsxExternalSheetData directly maps the sheetData element of the external
+// workbook references part. This element specifies the cached values of the
+// cells in a worksheet of the external workbook.
+type xlsxExternalSheetData struct {
+	SheetID      int               `xml:"sheetId,attr"`
+	RefreshError bool              `xml:"refreshError,attr,omitempty"`
+	Row          []xlsxExternalRow `xml:"row"`
+}
+
+// xlsxExternalRow directly maps the row element of the external workbook
+// references part.
+type xlsxExternalRow struct {
+	R    int                `xml:"r,attr"`
+	Cell []xlsxExternalCell `xml:"cell"`
+}
+
+// xlsxExternalCell directly maps the cell element of the external workbook
+// references part.
+type xlsxExternalCell struct {
+	R string `xml:"r,attr"`
+	T string `xml:"t,attr,omitempty"`
+	V string `xml:"v,omitempty"`
+}
+
 // xlsxPivotCaches element enumerates pivot cache definition parts used by pivot
 // tables and formulas in this workbook.
 type xlsxPivotCaches struct {
@@ -246,7 +305,9 @@ type xlsxAlternateContent struct {
 type xlsxChoice struct {
 	XMLName    xml.Name `xml:"mc:Choice"`
 	XMLNSA14   string   `xml:"xmlns:a14,attr,omitempty"`
+	XMLNSCx2   string   `xml:"xmlns:cx2,attr,omitempty"`
 	XMLNSSle15 string   `xml:"xmlns:sle15,attr,omitempty"`
+	XMLNSTsle  string   `xml:"xmlns:tsle,attr,omitempty"`
 	Requires   string   `xml:"Requires,attr,omitempty"`
 	Content    string   `xml:",innerxml"`
 }
@@ -314,19 +375,19 @@ type xlsxDefinedName struct {
 // and details. Calculation is the process of computing formulas and then
 // displaying the results as values in the cells that contain the formulas.
 type xlsxCalcPr struct {
-	CalcCompleted         bool    `xml:"calcCompleted,attr,omitempty"`
-	CalcID                string  `xml:"calcId,attr,omitempty"`
-	CalcMode              string  `xml:"calcMode,attr,omitempty"`
-	CalcOnSave            bool    `xml:"calcOnSave,attr,omitempty"`
-	ConcurrentCalc        *bool   `xml:"concurrentCalc,attr"`
-	ConcurrentManualCount int     `xml:"concurrentManualCount,attr,omitempty"`
-	ForceFullCalc         bool    `xml:"forceFullCalc,attr,omitempty"`
-	FullCalcOnLoad        bool    `xml:"fullCalcOnLoad,attr,omitempty"`
-	FullPrecision         bool    `xml:"fullPrecision,attr,omitempty"`
-	Iterate               bool    `xml:"iterate,attr,omitempty"`
-	IterateCount          int     `xml:"iterateCount,attr,omitempty"`
-	IterateDelta          float64 `xml:"iterateDelta,attr,omitempty"`
-	RefMode               string  `xml:"refMode,attr,omitempty"`
+	CalcCompleted         *bool    `xml:"calcCompleted,attr"`
+	CalcID                uint     `xml:"calcId,attr,omitempty"`
+	CalcMode              string   `xml:"calcMode,attr,omitempty"`
+	CalcOnSave            *bool    `xml:"calcOnSave,attr"`
+	ConcurrentCalc        *bool    `xml:"concurrentCalc,attr"`
+	ConcurrentManualCount uint     `xml:"concurrentManualCount,attr,omitempty"`
+	ForceFullCalc         bool     `xml:"forceFullCalc,attr,omitempty"`
+	FullCalcOnLoad        bool     `xml:"fullCalcOnLoad,attr,omitempty"`
+	FullPrecision         *bool    `xml:"fullPrecision,attr"`
+	Iterate               bool     `xml:"iterate,attr,omitempty"`
+	IterateCount          *uint    `xml:"iterateCount,attr"`
+	IterateDelta          *float64 `xml:"iterateDelta,attr"`
+	RefMode               string   `xml:"refMode,attr,omitempty"`
 }
 
 // xlsxCustomWorkbookViews defines the collection of custom workbook views that
@@ -384,6 +445,17 @@ type DefinedName struct {
 	Scope    string
 }
 
+// DynamicRangeOptions directly maps the settings of the dynamic range. The
+// "Sheet" and "Cell" specifies the start cell of the range, the "Cols"
+// specifies the number of columns of the range, and the "Function" specifies
+// the function for building the range, "OFFSET" or "INDEX".
+type DynamicRangeOptions struct {
+	Sheet    string
+	Cell     string
+	Cols     int
+	Function string
+}
+
 // WorkbookPropsOptions directly maps the settings of workbook proprieties.
 type WorkbookPropsOptions struct {
 	Date1904      *bool
@@ -391,6 +463,35 @@ type WorkbookPropsOptions struct {
 	CodeName      *string
 }
 
+// CalcPropsOptions directly maps the settings of the workbook calculation
+// properties.
+type CalcPropsOptions struct {
+	CalcID                *uint
+	CalcMode              *string
+	FullCalcOnLoad        *bool
+	RefMode               *string
+	Iterate               *bool
+	IterateCount          *uint
+	IterateDelta          *float64
+	FullPrecision         *bool
+	CalcCompleted         *bool
+	CalcOnSave            *bool
+	ConcurrentCalc        *bool
+	ConcurrentManualCount *uint
+	ForceFullCalc         *bool
+}
+
+// RemoveMetadataOptions directly maps the settings of removing the metadata and
+// the hidden data of the workbook.
+type RemoveMetadataOptions struct {
+	// Comments specifies if remove all comments and threaded comments.
+	Comments bool
+	// HiddenSheets specifies if delete the hidden and very hidden worksheets.
+	HiddenSheets bool
+	// HiddenRowsCols specifies if delete the hidden rows and columns.
+	HiddenRowsCols bool
+}
+
 // WorkbookProtectionOptions directly maps the settings of workbook protection.
 type WorkbookProtectionOptions struct {
 	AlgorithmName string
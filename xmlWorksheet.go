@@ -19,7 +19,7 @@ import (
 // xlsxWorksheet directly maps the worksheet element in the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main.
 type xlsxWorksheet struct {
-	mu                     sync.Mutex
+	mu                     sync.RWMutex
 	XMLName                xml.Name                     `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main worksheet"`
 	SheetPr                *xlsxSheetPr                 `xml:"sheetPr"`
 	Dimension              *xlsxDimension               `xml:"dimension"`
@@ -55,13 +55,14 @@ type xlsxWorksheet struct {
 	LegacyDrawingHF        *xlsxLegacyDrawingHF         `xml:"legacyDrawingHF"`
 	DrawingHF              *xlsxDrawingHF               `xml:"drawingHF"`
 	Picture                *xlsxPicture                 `xml:"picture"`
-	OleObjects             *xlsxInnerXML                `xml:"oleObjects"`
+	OleObjects             *xlsxOleObjects              `xml:"oleObjects"`
 	Controls               *xlsxInnerXML                `xml:"controls"`
 	WebPublishItems        *xlsxInnerXML                `xml:"webPublishItems"`
-	AlternateContent       *xlsxAlternateContent        `xml:"mc:AlternateContent"`
+	AlternateContent       []*xlsxAlternateContent      `xml:"mc:AlternateContent"`
 	TableParts             *xlsxTableParts              `xml:"tableParts"`
 	ExtLst                 *xlsxExtLst                  `xml:"extLst"`
-	DecodeAlternateContent *xlsxInnerXML                `xml:"http://schemas.openxmlformats.org/markup-compatibility/2006 AlternateContent"`
+	DecodeAlternateContent []*xlsxInnerXML              `xml:"http://schemas.openxmlformats.org/markup-compatibility/2006 AlternateContent"`
+	mergeMu                sync.Mutex
 }
 
 // xlsxDrawing change r:id to rid in the namespace.
@@ -699,6 +700,26 @@ type xlsxLegacyDrawingHF struct {
 	RID     string   `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr,omitempty"`
 }
 
+// xlsxOleObjects directly maps the oleObjects element. This element specifies
+// the collection of embedded or linked OLE objects in the worksheet.
+type xlsxOleObjects struct {
+	OleObject []xlsxOleObject `xml:"oleObject"`
+}
+
+// xlsxOleObject directly maps the oleObject element. This element specifies
+// an embedded or linked OLE object, the shapeId attribute specifies the ID of
+// the VML shape which represents the object in the worksheet.
+type xlsxOleObject struct {
+	ProgID    string        `xml:"progId,attr,omitempty"`
+	DvAspect  string        `xml:"dvAspect,attr,omitempty"`
+	Link      string        `xml:"link,attr,omitempty"`
+	OleUpdate string        `xml:"oleUpdate,attr,omitempty"`
+	AutoLoad  bool          `xml:"autoLoad,attr,omitempty"`
+	ShapeID   int           `xml:"shapeId,attr"`
+	RID       string        `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr,omitempty"`
+	ObjectPr  *xlsxInnerXML `xml:"objectPr"`
+}
+
 // decodeX14SparklineGroups directly maps the sparklineGroups element.
 type decodeX14SparklineGroups struct {
 	XMLName xml.Name `xml:"sparklineGroups"`
@@ -735,19 +756,59 @@ type decodeX14CfRule struct {
 	DataBar *decodeX14DataBar `xml:"dataBar"`
 }
 
+// decodeX14DataValidations directly maps the dataValidations element in the
+// worksheet extension list.
+type decodeX14DataValidations struct {
+	XMLName        xml.Name                   `xml:"dataValidations"`
+	Count          int                        `xml:"count,attr,omitempty"`
+	DisablePrompts bool                       `xml:"disablePrompts,attr,omitempty"`
+	XWindow        int                        `xml:"xWindow,attr,omitempty"`
+	YWindow        int                        `xml:"yWindow,attr,omitempty"`
+	DataValidation []*decodeX14DataValidation `xml:"dataValidation"`
+}
+
+// decodeX14DataValidation directly maps the dataValidation element in the
+// worksheet extension list.
+type decodeX14DataValidation struct {
+	AllowBlank       bool              `xml:"allowBlank,attr"`
+	Error            *string           `xml:"error,attr"`
+	ErrorStyle       *string           `xml:"errorStyle,attr"`
+	ErrorTitle       *string           `xml:"errorTitle,attr"`
+	Operator         string            `xml:"operator,attr,omitempty"`
+	Prompt           *string           `xml:"prompt,attr"`
+	PromptTitle      *string           `xml:"promptTitle,attr"`
+	ShowDropDown     bool              `xml:"showDropDown,attr,omitempty"`
+	ShowErrorMessage bool              `xml:"showErrorMessage,attr,omitempty"`
+	ShowInputMessage bool              `xml:"showInputMessage,attr,omitempty"`
+	Type             string            `xml:"type,attr,omitempty"`
+	Formula1         *decodeX14Formula `xml:"formula1"`
+	Formula2         *decodeX14Formula `xml:"formula2"`
+	Sqref            string            `xml:"sqref"`
+}
+
+// decodeX14Formula directly maps the formula1 and formula2 element in the
+// data validation of the worksheet extension list.
+type decodeX14Formula struct {
+	F string `xml:"f"`
+}
+
 // decodeX14DataBar directly maps the dataBar element.
 type decodeX14DataBar struct {
-	XMLName           xml.Name    `xml:"dataBar"`
-	MaxLength         int         `xml:"maxLength,attr"`
-	MinLength         int         `xml:"minLength,attr"`
-	Border            bool        `xml:"border,attr,omitempty"`
-	Gradient          bool        `xml:"gradient,attr"`
-	ShowValue         bool        `xml:"showValue,attr,omitempty"`
-	Direction         string      `xml:"direction,attr,omitempty"`
-	Cfvo              []*xlsxCfvo `xml:"cfvo"`
-	BorderColor       *xlsxColor  `xml:"borderColor"`
-	NegativeFillColor *xlsxColor  `xml:"negativeFillColor"`
-	AxisColor         *xlsxColor  `xml:"axisColor"`
+	XMLName                              xml.Name    `xml:"dataBar"`
+	MaxLength                            int         `xml:"maxLength,attr"`
+	MinLength                            int         `xml:"minLength,attr"`
+	Border                               bool        `xml:"border,attr,omitempty"`
+	Gradient                             *bool       `xml:"gradient,attr"`
+	ShowValue                            bool        `xml:"showValue,attr,omitempty"`
+	Direction                            string      `xml:"direction,attr,omitempty"`
+	NegativeBarColorSameAsPositive       bool        `xml:"negativeBarColorSameAsPositive,attr,omitempty"`
+	NegativeBarBorderColorSameAsPositive *bool       `xml:"negativeBarBorderColorSameAsPositive,attr"`
+	AxisPosition                         string      `xml:"axisPosition,attr,omitempty"`
+	Cfvo                                 []*xlsxCfvo `xml:"cfvo"`
+	BorderColor                          *xlsxColor  `xml:"borderColor"`
+	NegativeFillColor                    *xlsxColor  `xml:"negativeFillColor"`
+	NegativeBorderColor                  *xlsxColor  `xml:"negativeBorderColor"`
+	AxisColor                            *xlsxColor  `xml:"axisColor"`
 }
 
 // xlsxX14ConditionalFormattings directly maps the conditionalFormattings
@@ -773,16 +834,57 @@ type xlsxX14CfRule struct {
 
 // xlsx14DataBar directly maps the dataBar element.
 type xlsx14DataBar struct {
-	MaxLength         int         `xml:"maxLength,attr"`
-	MinLength         int         `xml:"minLength,attr"`
-	Border            bool        `xml:"border,attr"`
-	Gradient          bool        `xml:"gradient,attr"`
-	ShowValue         bool        `xml:"showValue,attr,omitempty"`
-	Direction         string      `xml:"direction,attr,omitempty"`
-	Cfvo              []*xlsxCfvo `xml:"x14:cfvo"`
-	BorderColor       *xlsxColor  `xml:"x14:borderColor"`
-	NegativeFillColor *xlsxColor  `xml:"x14:negativeFillColor"`
-	AxisColor         *xlsxColor  `xml:"x14:axisColor"`
+	MaxLength                            int         `xml:"maxLength,attr"`
+	MinLength                            int         `xml:"minLength,attr"`
+	Border                               bool        `xml:"border,attr"`
+	Gradient                             bool        `xml:"gradient,attr"`
+	ShowValue                            bool        `xml:"showValue,attr,omitempty"`
+	Direction                            string      `xml:"direction,attr,omitempty"`
+	NegativeBarColorSameAsPositive       bool        `xml:"negativeBarColorSameAsPositive,attr,omitempty"`
+	NegativeBarBorderColorSameAsPositive *bool       `xml:"negativeBarBorderColorSameAsPositive,attr"`
+	AxisPosition                         string      `xml:"axisPosition,attr,omitempty"`
+	Cfvo                                 []*xlsxCfvo `xml:"x14:cfvo"`
+	BorderColor                          *xlsxColor  `xml:"x14:borderColor"`
+	NegativeFillColor                    *xlsxColor  `xml:"x14:negativeFillColor"`
+	NegativeBorderColor                  *xlsxColor  `xml:"x14:negativeBorderColor"`
+	AxisColor                            *xlsxColor  `xml:"x14:axisColor"`
+}
+
+// xlsxX14DataValidations directly maps the dataValidations element in the
+// worksheet extension list.
+type xlsxX14DataValidations struct {
+	XMLName        xml.Name                 `xml:"x14:dataValidations"`
+	XMLNSXM        string                   `xml:"xmlns:xm,attr"`
+	Count          int                      `xml:"count,attr"`
+	DisablePrompts bool                     `xml:"disablePrompts,attr,omitempty"`
+	XWindow        int                      `xml:"xWindow,attr,omitempty"`
+	YWindow        int                      `xml:"yWindow,attr,omitempty"`
+	DataValidation []*xlsxX14DataValidation `xml:"x14:dataValidation"`
+}
+
+// xlsxX14DataValidation directly maps the dataValidation element in the
+// worksheet extension list.
+type xlsxX14DataValidation struct {
+	AllowBlank       bool            `xml:"allowBlank,attr"`
+	Error            *string         `xml:"error,attr"`
+	ErrorStyle       *string         `xml:"errorStyle,attr"`
+	ErrorTitle       *string         `xml:"errorTitle,attr"`
+	Operator         string          `xml:"operator,attr,omitempty"`
+	Prompt           *string         `xml:"prompt,attr"`
+	PromptTitle      *string         `xml:"promptTitle,attr"`
+	ShowDropDown     bool            `xml:"showDropDown,attr,omitempty"`
+	ShowErrorMessage bool            `xml:"showErrorMessage,attr,omitempty"`
+	ShowInputMessage bool            `xml:"showInputMessage,attr,omitempty"`
+	Type             string          `xml:"type,attr,omitempty"`
+	Formula1         *xlsxX14Formula `xml:"x14:formula1"`
+	Formula2         *xlsxX14Formula `xml:"x14:formula2"`
+	Sqref            string          `xml:"xm:sqref"`
+}
+
+// xlsxX14Formula directly maps the formula1 and formula2 element in the data
+// validation of the worksheet extension list.
+type xlsxX14Formula struct {
+	F string `xml:"xm:f"`
 }
 
 // xlsxX14SparklineGroups directly maps the sparklineGroups element.
@@ -886,30 +988,61 @@ type Panes struct {
 
 // ConditionalFormatOptions directly maps the conditional format settings of the cells.
 type ConditionalFormatOptions struct {
-	Type           string
-	AboveAverage   bool
-	Percent        bool
-	Format         int
-	Criteria       string
-	Value          string
-	MinType        string
-	MidType        string
-	MaxType        string
-	MinValue       string
-	MidValue       string
-	MaxValue       string
-	MinColor       string
-	MidColor       string
-	MaxColor       string
-	BarColor       string
-	BarBorderColor string
-	BarDirection   string
-	BarOnly        bool
-	BarSolid       bool
-	IconStyle      string
-	ReverseIcons   bool
-	IconsOnly      bool
-	StopIfTrue     bool
+	Type                   string
+	AboveAverage           bool
+	Percent                bool
+	Format                 int
+	Style                  *Style
+	Criteria               string
+	Value                  string
+	MinType                string
+	MidType                string
+	MaxType                string
+	MinValue               string
+	MidValue               string
+	MaxValue               string
+	MinColor               string
+	MidColor               string
+	MaxColor               string
+	BarColor               string
+	BarBorderColor         string
+	BarDirection           string
+	BarOnly                bool
+	BarSolid               bool
+	BarAxisColor           string
+	BarAxisPosition        string
+	BarNegativeColor       string
+	BarNegativeBorderColor string
+	IconStyle              string
+	ReverseIcons           bool
+	IconsOnly              bool
+	StopIfTrue             bool
+	Priority               int
+}
+
+// AutoFitColWidthOptions directly maps the settings of auto fit column width.
+// The MinWidth and MaxWidth specifies the minimum and maximum width of the
+// columns in characters.
+type AutoFitColWidthOptions struct {
+	MinWidth float64
+	MaxWidth float64
+}
+
+// CopyOptions directly maps the settings of copying cell range. The Transpose
+// specifies if switch the rows and columns of the copied range.
+type CopyOptions struct {
+	Transpose bool
+}
+
+// SearchOptions directly maps the settings of searching worksheet. The Regex
+// specifies if search the cell value by regular expression, otherwise the
+// value must match the entire cell content. The MatchCase specifies if the
+// search is case-sensitive. The ByRows specifies if return the matched cell
+// references row by row, otherwise column by column.
+type SearchOptions struct {
+	Regex     bool
+	MatchCase bool
+	ByRows    bool
 }
 
 // SheetProtectionOptions directly maps the settings of worksheet protection.
@@ -972,12 +1105,20 @@ type PageLayoutOptions struct {
 	// value ranging from 10 (10%) to 400 (400%). This setting is overridden
 	// when fitToWidth and/or fitToHeight are in use.
 	AdjustTo *uint
-	// FitToHeight specified the number of vertical pages to fit on.
+	// FitToHeight specified the number of vertical pages to fit on, the Fit
+	// to Page print option will be enabled when it was set.
 	FitToHeight *int
-	// FitToWidth specified the number of horizontal pages to fit on.
+	// FitToWidth specified the number of horizontal pages to fit on, the Fit
+	// to Page print option will be enabled when it was set.
 	FitToWidth *int
 	// BlackAndWhite specified print black and white.
 	BlackAndWhite *bool
+	// Draft specified print without graphics.
+	Draft *bool
+	// HorizontalDPI specified the horizontal print resolution of the device.
+	HorizontalDPI *uint
+	// VerticalDPI specified the vertical print resolution of the device.
+	VerticalDPI *uint
 }
 
 // ViewOptions directly maps the settings of sheet view.
@@ -1005,6 +1146,10 @@ type ViewOptions struct {
 	// the referenced value becomes 0 when the flag is true. (Default setting
 	// is true.)
 	ShowZeros *bool
+	// TabSelected indicating whether this sheet tab is selected. When only one
+	// sheet is selected and active, this value should be in synch with the
+	// active tab of the workbook.
+	TabSelected *bool
 	// TopLeftCell specifies a location of the top left visible cell Location
 	// of the top left visible cell in the bottom right pane (when in
 	// Left-to-Right mode).